@@ -807,6 +807,8 @@ const defaultServerConfig: AdminConfig = {
         GlobalUserSyncBatchSize: 25,
         MaxPostsPerSync: 50,
         MemberSyncBatchSize: 20,
+        Transport: 'http',
+        SpoolDirectory: '',
     },
     AccessControlSettings: {
         EnableAttributeBasedAccessControl: false,
@@ -103,6 +103,8 @@ type Routes struct {
 
 	Recaps *mux.Router // 'api/v4/recaps'
 
+	MembershipRequests *mux.Router // 'api/v4/membership_requests'
+
 	Preferences *mux.Router // 'api/v4/users/{user_id:[A-Za-z0-9]+}/preferences'
 
 	License *mux.Router // 'api/v4/license'
@@ -167,6 +169,8 @@ type Routes struct {
 
 	Agents      *mux.Router // 'api/v4/agents'
 	LLMServices *mux.Router // 'api/v4/llmservices'
+
+	Scim *mux.Router // 'api/v4/scim/v2'
 }
 
 type API struct {
@@ -259,6 +263,7 @@ func Init(srv *app.Server) (*API, error) {
 	api.BaseRoutes.Reactions = api.BaseRoutes.APIRoot.PathPrefix("/reactions").Subrouter()
 	api.BaseRoutes.Jobs = api.BaseRoutes.APIRoot.PathPrefix("/jobs").Subrouter()
 	api.BaseRoutes.Recaps = api.BaseRoutes.APIRoot.PathPrefix("/recaps").Subrouter()
+	api.BaseRoutes.MembershipRequests = api.BaseRoutes.APIRoot.PathPrefix("/membership_requests").Subrouter()
 	api.BaseRoutes.Elasticsearch = api.BaseRoutes.APIRoot.PathPrefix("/elasticsearch").Subrouter()
 	api.BaseRoutes.DataRetention = api.BaseRoutes.APIRoot.PathPrefix("/data_retention").Subrouter()
 
@@ -320,6 +325,8 @@ func Init(srv *app.Server) (*API, error) {
 	api.BaseRoutes.Agents = api.BaseRoutes.APIRoot.PathPrefix("/agents").Subrouter()
 	api.BaseRoutes.LLMServices = api.BaseRoutes.APIRoot.PathPrefix("/llmservices").Subrouter()
 
+	api.BaseRoutes.Scim = api.BaseRoutes.APIRoot.PathPrefix(model.ScimURLPath).Subrouter()
+
 	api.InitUser()
 	api.InitBot()
 	api.InitTeam()
@@ -341,6 +348,7 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitBrand()
 	api.InitJob()
 	api.InitRecap()
+	api.InitMembershipRequest()
 	api.InitCommand()
 	api.InitStatus()
 	api.InitWebSocket()
@@ -375,6 +383,7 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitAccessControlPolicy()
 	api.InitContentFlagging()
 	api.InitAgents()
+	api.InitScim()
 
 	// If we allow testing then listen for manual testing URL hits
 	if *srv.Config().ServiceSettings.EnableTesting {
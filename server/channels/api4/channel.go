@@ -347,8 +347,9 @@ func patchChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	updatingProperties := patch.DisplayName != nil || patch.Name != nil || patch.Header != nil || patch.Purpose != nil || patch.GroupConstrained != nil
 	updatingAutoTranslation := patch.AutoTranslation != nil
+	updatingMembershipRequests := patch.AllowMembershipRequests != nil
 
-	if !updatingProperties && !updatingAutoTranslation && patch.BannerInfo == nil {
+	if !updatingProperties && !updatingAutoTranslation && !updatingMembershipRequests && patch.BannerInfo == nil {
 		c.Err = model.NewAppError("patchChannel", "api.channel.patch_update_channel.no_changes.app_error", nil, "", http.StatusBadRequest)
 		return
 	}
@@ -408,6 +409,18 @@ func patchChannel(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Only private channels can be asked to join, by those who can't see them.
+	if updatingMembershipRequests {
+		if oldChannel.Type != model.ChannelTypePrivate {
+			c.Err = model.NewAppError("patchChannel", "api.channel.patch_update_channel.membership_requests_private_only.app_error", nil, "", http.StatusBadRequest)
+			return
+		}
+		if ok, _ := c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionManageChannelRoles); !ok {
+			c.SetPermissionError(model.PermissionManageChannelRoles)
+			return
+		}
+	}
+
 	if oldChannel.Name == model.DefaultChannelName {
 		if patch.Name != nil && *patch.Name != oldChannel.Name {
 			c.Err = model.NewAppError("patchChannel", "api.channel.update_channel.tried.app_error", map[string]any{"Channel": model.DefaultChannelName}, "", http.StatusBadRequest)
@@ -1362,6 +1362,11 @@ func addGroupMembers(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if group.IsProvisioned() {
+		c.Err = model.NewAppError("Api4.addGroupMembers", "api.custom_groups.provisioned.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
 	appErr = licensedAndConfiguredForGroupBySource(c.App, model.GroupSourceCustom)
 	if appErr != nil {
 		appErr.Where = "Api4.addGroupMembers"
@@ -1430,6 +1435,11 @@ func deleteGroupMembers(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if group.IsProvisioned() {
+		c.Err = model.NewAppError("Api4.deleteGroupMembers", "api.custom_groups.provisioned.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
 	appErr = licensedAndConfiguredForGroupBySource(c.App, model.GroupSourceCustom)
 	if appErr != nil {
 		appErr.Where = "Api4.deleteGroupMembers"
@@ -1516,6 +1526,10 @@ func licensedAndConfiguredForGroupBySource(app *app.App, source model.GroupSourc
 		return model.NewAppError("", "api.ldap_groups.license_error", nil, "", http.StatusForbidden)
 	}
 
+	if strings.HasPrefix(string(source), string(model.GroupSourceOpenidPrefix)) && !*lic.Features.LDAPGroups {
+		return model.NewAppError("", "api.ldap_groups.license_error", nil, "", http.StatusForbidden)
+	}
+
 	if source == model.GroupSourceCustom && !model.MinimumProfessionalLicense(lic) {
 		return model.NewAppError("", "api.custom_groups.license_error", nil, "", http.StatusBadRequest)
 	}
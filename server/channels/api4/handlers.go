@@ -31,6 +31,7 @@ func (api *API) APIHandler(h handlerFunc, opts ...APIHandlerOption) http.Handler
 		Srv:            api.srv,
 		HandleFunc:     h,
 		HandlerName:    web.GetHandlerName(h),
+		OAuthScope:     handlerOAuthScopes[web.GetHandlerName(h)],
 		RequireSession: false,
 		TrustRequester: false,
 		RequireMfa:     false,
@@ -52,6 +53,7 @@ func (api *API) APISessionRequired(h handlerFunc, opts ...APIHandlerOption) http
 		Srv:            api.srv,
 		HandleFunc:     h,
 		HandlerName:    web.GetHandlerName(h),
+		OAuthScope:     handlerOAuthScopes[web.GetHandlerName(h)],
 		RequireSession: true,
 		TrustRequester: false,
 		RequireMfa:     true,
@@ -117,6 +119,7 @@ func (api *API) APISessionRequiredMfa(h handlerFunc, opts ...APIHandlerOption) h
 		Srv:            api.srv,
 		HandleFunc:     h,
 		HandlerName:    web.GetHandlerName(h),
+		OAuthScope:     handlerOAuthScopes[web.GetHandlerName(h)],
 		RequireSession: true,
 		TrustRequester: false,
 		RequireMfa:     false,
@@ -139,6 +142,7 @@ func (api *API) APIHandlerTrustRequester(h handlerFunc, opts ...APIHandlerOption
 		Srv:            api.srv,
 		HandleFunc:     h,
 		HandlerName:    web.GetHandlerName(h),
+		OAuthScope:     handlerOAuthScopes[web.GetHandlerName(h)],
 		RequireSession: false,
 		TrustRequester: true,
 		RequireMfa:     false,
@@ -160,6 +164,7 @@ func (api *API) APISessionRequiredTrustRequester(h handlerFunc, opts ...APIHandl
 		Srv:            api.srv,
 		HandleFunc:     h,
 		HandlerName:    web.GetHandlerName(h),
+		OAuthScope:     handlerOAuthScopes[web.GetHandlerName(h)],
 		RequireSession: true,
 		TrustRequester: true,
 		RequireMfa:     true,
@@ -181,6 +186,7 @@ func (api *API) APISessionRequiredDisableWhenBusy(h handlerFunc, opts ...APIHand
 		Srv:             api.srv,
 		HandleFunc:      h,
 		HandlerName:     web.GetHandlerName(h),
+		OAuthScope:      handlerOAuthScopes[web.GetHandlerName(h)],
 		RequireSession:  true,
 		TrustRequester:  false,
 		RequireMfa:      true,
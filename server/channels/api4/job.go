@@ -65,18 +65,23 @@ func downloadJob(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !*config.MessageExportSettings.DownloadExportResults {
-		c.Err = model.NewAppError("downloadExportResultsNotEnabled", "app.job.download_export_results_not_enabled", nil, "", http.StatusNotImplemented)
-		return
-	}
-
 	job, err := c.App.GetJob(c.AppContext, c.Params.JobId)
 	if err != nil {
 		c.Err = err
 		return
 	}
 
-	// Currently, this endpoint only supports downloading the compliance report.
+	if job.Type == model.JobTypeExportAccessReport || job.Type == model.JobTypeUserSync {
+		downloadReportJob(c, w, r, job)
+		return
+	}
+
+	if !*config.MessageExportSettings.DownloadExportResults {
+		c.Err = model.NewAppError("downloadExportResultsNotEnabled", "app.job.download_export_results_not_enabled", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	// Besides the reports, this endpoint only supports downloading the compliance report.
 	// If you need to download another job type, you will need to alter this section of the code to accommodate it.
 	if job.Type == model.JobTypeMessageExport && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionDownloadComplianceExportResult) {
 		c.SetPermissionError(model.PermissionDownloadComplianceExportResult)
@@ -284,6 +289,34 @@ func getJobsByType(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func downloadReportJob(c *Context, w http.ResponseWriter, r *http.Request, job *model.Job) {
+	hasPermission, permissionRequired := c.App.SessionHasPermissionToReadJob(*c.AppContext.Session(), job.Type)
+	if !hasPermission {
+		c.SetPermissionError(permissionRequired)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDownloadReportJob, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "job_id", job.Id)
+	model.AddEventParameterToAuditRec(auditRec, "job_type", job.Type)
+
+	fileReader, fileName, appErr := c.App.GetReportFileReader(job)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	defer fileReader.Close()
+
+	mimeType := "text/csv"
+	if job.Data["format"] == "json" {
+		mimeType = "application/json"
+	}
+
+	auditRec.Success()
+	web.WriteFileResponse(fileName, mimeType, 0, time.UnixMilli(job.LastActivityAt), *c.App.Config().ServiceSettings.WebserverMode, fileReader, true, w, r)
+}
+
 func cancelJob(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireJobId()
 	if c.Err != nil {
@@ -0,0 +1,359 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *API) InitMembershipRequest() {
+	api.BaseRoutes.MembershipRequests.Handle("", api.APISessionRequired(createMembershipRequest)).Methods(http.MethodPost)
+	api.BaseRoutes.MembershipRequests.Handle("/{membership_request_id:[A-Za-z0-9]+}", api.APISessionRequired(getMembershipRequest)).Methods(http.MethodGet)
+	api.BaseRoutes.MembershipRequests.Handle("/{membership_request_id:[A-Za-z0-9]+}/approve", api.APISessionRequired(approveMembershipRequest)).Methods(http.MethodPost)
+	api.BaseRoutes.MembershipRequests.Handle("/{membership_request_id:[A-Za-z0-9]+}/deny", api.APISessionRequired(denyMembershipRequest)).Methods(http.MethodPost)
+	api.BaseRoutes.MembershipRequests.Handle("/{membership_request_id:[A-Za-z0-9]+}/cancel", api.APISessionRequired(cancelMembershipRequest)).Methods(http.MethodPost)
+
+	api.BaseRoutes.User.Handle("/membership_requests", api.APISessionRequired(getMembershipRequestsForUser)).Methods(http.MethodGet)
+	api.BaseRoutes.Channel.Handle("/membership_requests", api.APISessionRequired(getMembershipRequestsForChannel)).Methods(http.MethodGet)
+	api.BaseRoutes.Team.Handle("/membership_requests", api.APISessionRequired(getMembershipRequestsForTeam)).Methods(http.MethodGet)
+
+	api.BaseRoutes.ChannelsForTeam.Handle("/requestable", api.APISessionRequired(getRequestableChannelsForTeam)).Methods(http.MethodGet)
+	api.BaseRoutes.TeamsForUser.Handle("/requestable", api.APISessionRequired(getRequestableTeamsForUser)).Methods(http.MethodGet)
+}
+
+func requireMembershipRequestsEnabled(c *Context) {
+	if !*c.App.Config().MembershipRequestSettings.Enable {
+		c.Err = model.NewAppError("requireMembershipRequestsEnabled", "app.membership_request.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+}
+
+// hasPermissionToReviewMembershipRequest returns whether the session can
+// approve or deny the request, which the admins of the channel or team can.
+func hasPermissionToReviewMembershipRequest(c *Context, membershipRequest *model.MembershipRequest) bool {
+	if membershipRequest.IsForChannel() {
+		hasPermission, _ := c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), membershipRequest.ChannelId, model.PermissionManageChannelRoles)
+		return hasPermission
+	}
+
+	return c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), membershipRequest.TeamId, model.PermissionManageTeam)
+}
+
+func createMembershipRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireMembershipRequestsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	var create model.CreateMembershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&create); err != nil {
+		c.SetInvalidParamWithErr("membership_request", err)
+		return
+	}
+
+	if !model.IsValidId(create.TeamId) {
+		c.SetInvalidParam("team_id")
+		return
+	}
+
+	if create.ChannelId != "" && !model.IsValidId(create.ChannelId) {
+		c.SetInvalidParam("channel_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCreateMembershipRequest, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddEventObjectType("membership_request")
+	model.AddEventParameterAuditableToAuditRec(auditRec, "membership_request", &create)
+
+	membershipRequest, appErr := c.App.CreateMembershipRequest(c.AppContext, c.AppContext.Session().UserId, &create)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddEventResultState(membershipRequest)
+	auditRec.Success()
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(membershipRequest); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getMembershipRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireMembershipRequestsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireMembershipRequestId()
+	if c.Err != nil {
+		return
+	}
+
+	membershipRequest, appErr := c.App.GetMembershipRequest(c.AppContext, c.Params.MembershipRequestId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if membershipRequest.UserId != c.AppContext.Session().UserId && !hasPermissionToReviewMembershipRequest(c, membershipRequest) {
+		c.Err = model.NewAppError("getMembershipRequest", "app.membership_request.get.not_found.app_error", nil, "", http.StatusNotFound)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(membershipRequest); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func approveMembershipRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	reviewMembershipRequest(c, w, model.MembershipRequestActionApprove)
+}
+
+func denyMembershipRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	reviewMembershipRequest(c, w, model.MembershipRequestActionDeny)
+}
+
+func reviewMembershipRequest(c *Context, w http.ResponseWriter, action string) {
+	requireMembershipRequestsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireMembershipRequestId()
+	if c.Err != nil {
+		return
+	}
+
+	event := model.AuditEventApproveMembershipRequest
+	if action == model.MembershipRequestActionDeny {
+		event = model.AuditEventDenyMembershipRequest
+	}
+	auditRec := c.MakeAuditRecord(event, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddEventObjectType("membership_request")
+	model.AddEventParameterToAuditRec(auditRec, "membership_request_id", c.Params.MembershipRequestId)
+
+	membershipRequest, appErr := c.App.GetMembershipRequest(c.AppContext, c.Params.MembershipRequestId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.AddEventPriorState(membershipRequest)
+
+	if !hasPermissionToReviewMembershipRequest(c, membershipRequest) {
+		if membershipRequest.IsForChannel() {
+			c.SetPermissionError(model.PermissionManageChannelRoles)
+		} else {
+			c.SetPermissionError(model.PermissionManageTeam)
+		}
+		return
+	}
+
+	if action == model.MembershipRequestActionApprove {
+		membershipRequest, appErr = c.App.ApproveMembershipRequest(c.AppContext, membershipRequest, c.AppContext.Session().UserId)
+	} else {
+		membershipRequest, appErr = c.App.DenyMembershipRequest(c.AppContext, membershipRequest, c.AppContext.Session().UserId)
+	}
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddEventResultState(membershipRequest)
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(membershipRequest); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func cancelMembershipRequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireMembershipRequestsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireMembershipRequestId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCancelMembershipRequest, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddEventObjectType("membership_request")
+	model.AddEventParameterToAuditRec(auditRec, "membership_request_id", c.Params.MembershipRequestId)
+
+	membershipRequest, appErr := c.App.GetMembershipRequest(c.AppContext, c.Params.MembershipRequestId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.AddEventPriorState(membershipRequest)
+
+	if membershipRequest.UserId != c.AppContext.Session().UserId {
+		c.Err = model.NewAppError("cancelMembershipRequest", "app.membership_request.cancel.permissions.app_error", nil, "", http.StatusForbidden)
+		return
+	}
+
+	membershipRequest, appErr = c.App.CancelMembershipRequest(c.AppContext, membershipRequest)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddEventResultState(membershipRequest)
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(membershipRequest); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getMembershipRequestsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireMembershipRequestsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	writeMembershipRequests(c, w, model.MembershipRequestGetOptions{
+		UserId:  c.Params.UserId,
+		Status:  r.URL.Query().Get("status"),
+		Page:    c.Params.Page,
+		PerPage: c.Params.PerPage,
+	})
+}
+
+func getMembershipRequestsForChannel(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireMembershipRequestsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if ok, _ := c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionManageChannelRoles); !ok {
+		c.SetPermissionError(model.PermissionManageChannelRoles)
+		return
+	}
+
+	writeMembershipRequests(c, w, model.MembershipRequestGetOptions{
+		ChannelId: c.Params.ChannelId,
+		Status:    r.URL.Query().Get("status"),
+		Page:      c.Params.Page,
+		PerPage:   c.Params.PerPage,
+	})
+}
+
+func getMembershipRequestsForTeam(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireMembershipRequestsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageTeam) {
+		c.SetPermissionError(model.PermissionManageTeam)
+		return
+	}
+
+	writeMembershipRequests(c, w, model.MembershipRequestGetOptions{
+		TeamId:   c.Params.TeamId,
+		TeamOnly: true,
+		Status:   r.URL.Query().Get("status"),
+		Page:     c.Params.Page,
+		PerPage:  c.Params.PerPage,
+	})
+}
+
+func writeMembershipRequests(c *Context, w http.ResponseWriter, opts model.MembershipRequestGetOptions) {
+	membershipRequests, appErr := c.App.GetMembershipRequests(c.AppContext, opts)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(membershipRequests); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getRequestableChannelsForTeam(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireMembershipRequestsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireTeamId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionViewTeam) {
+		c.SetPermissionError(model.PermissionViewTeam)
+		return
+	}
+
+	channels, appErr := c.App.GetRequestableChannels(c.AppContext, c.Params.TeamId, c.AppContext.Session().UserId, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(channels); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getRequestableTeamsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireMembershipRequestsEnabled(c)
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	teams, appErr := c.App.GetRequestableTeams(c.AppContext, c.Params.UserId, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	c.App.SanitizeTeams(*c.AppContext.Session(), teams)
+
+	if err := json.NewEncoder(w).Encode(teams); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestMembershipRequests(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	// BasicUser creates the channel, so is its admin.
+	channel := th.CreatePrivateChannel(t)
+	create := &model.CreateMembershipRequest{
+		TeamId:        th.BasicTeam.Id,
+		ChannelId:     channel.Id,
+		Justification: "I work on this project",
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		_, resp, err := th.Client.CreateMembershipRequest(context.Background(), create)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.MembershipRequestSettings.Enable = true
+	})
+
+	t.Run("only private channels allow requests", func(t *testing.T) {
+		_, resp, err := th.Client.PatchChannel(context.Background(), th.BasicChannel.Id, &model.ChannelPatch{AllowMembershipRequests: model.NewPointer(true)})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("channel not allowing requests", func(t *testing.T) {
+		th.LoginBasic2(t)
+		defer th.LoginBasic(t)
+
+		_, resp, err := th.Client.CreateMembershipRequest(context.Background(), create)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	patched, _, err := th.Client.PatchChannel(context.Background(), channel.Id, &model.ChannelPatch{AllowMembershipRequests: model.NewPointer(true)})
+	require.NoError(t, err)
+	require.True(t, patched.AllowMembershipRequests)
+
+	th.LoginBasic2(t)
+
+	channels, _, err := th.Client.GetRequestableChannelsForTeam(context.Background(), th.BasicTeam.Id, 0, 60)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	assert.Equal(t, channel.Id, channels[0].Id)
+
+	membershipRequest, resp, err := th.Client.CreateMembershipRequest(context.Background(), create)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, model.MembershipRequestStatusPending, membershipRequest.Status)
+
+	membershipRequests, _, err := th.Client.GetMembershipRequestsForUser(context.Background(), th.BasicUser2.Id, model.MembershipRequestStatusPending, 0, 60)
+	require.NoError(t, err)
+	require.Len(t, membershipRequests, 1)
+	assert.Equal(t, membershipRequest.Id, membershipRequests[0].Id)
+
+	t.Run("requester can't review", func(t *testing.T) {
+		_, resp, err := th.Client.ApproveMembershipRequest(context.Background(), membershipRequest.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetMembershipRequestsForChannel(context.Background(), channel.Id, "", 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.LoginBasic(t)
+
+	t.Run("only the requester can cancel", func(t *testing.T) {
+		_, resp, err := th.Client.CancelMembershipRequest(context.Background(), membershipRequest.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	membershipRequests, _, err = th.Client.GetMembershipRequestsForChannel(context.Background(), channel.Id, model.MembershipRequestStatusPending, 0, 60)
+	require.NoError(t, err)
+	require.Len(t, membershipRequests, 1)
+
+	approved, _, err := th.Client.ApproveMembershipRequest(context.Background(), membershipRequest.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipRequestStatusApproved, approved.Status)
+	assert.Equal(t, th.BasicUser.Id, approved.ReviewerId)
+
+	_, _, err = th.Client.GetChannelMember(context.Background(), channel.Id, th.BasicUser2.Id, "")
+	require.NoError(t, err)
+
+	_, resp, err = th.Client.DenyMembershipRequest(context.Background(), membershipRequest.Id)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	t.Run("plain channel member can't review", func(t *testing.T) {
+		requester := th.CreateUser(t)
+		th.LinkUserToTeam(t, requester, th.BasicTeam)
+		requesterClient := th.CreateClient()
+		_, _, err := requesterClient.Login(context.Background(), requester.Email, requester.Password)
+		require.NoError(t, err)
+		otherRequest, _, err := requesterClient.CreateMembershipRequest(context.Background(), create)
+		require.NoError(t, err)
+
+		// BasicUser2 joined the channel as a plain member when approved.
+		th.LoginBasic2(t)
+		defer th.LoginBasic(t)
+
+		_, resp, err := th.Client.ApproveMembershipRequest(context.Background(), otherRequest.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetMembershipRequestsForChannel(context.Background(), channel.Id, "", 0, 60)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.PatchChannel(context.Background(), channel.Id, &model.ChannelPatch{AllowMembershipRequests: model.NewPointer(false)})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestRequestableTeams(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.MembershipRequestSettings.Enable = true
+	})
+
+	team := th.CreateTeam(t)
+	_, _, err := th.Client.PatchTeam(context.Background(), team.Id, &model.TeamPatch{AllowMembershipRequests: model.NewPointer(true)})
+	require.NoError(t, err)
+
+	th.LoginBasic2(t)
+
+	teams, _, err := th.Client.GetRequestableTeamsForUser(context.Background(), th.BasicUser2.Id, 0, 60)
+	require.NoError(t, err)
+	var ids []string
+	for _, team := range teams {
+		ids = append(ids, team.Id)
+	}
+	assert.Contains(t, ids, team.Id)
+	assert.NotContains(t, ids, th.BasicTeam.Id)
+
+	_, resp, err := th.Client.GetRequestableTeamsForUser(context.Background(), th.BasicUser.Id, 0, 60)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	membershipRequest, _, err := th.Client.CreateMembershipRequest(context.Background(), &model.CreateMembershipRequest{TeamId: team.Id})
+	require.NoError(t, err)
+
+	cancelled, _, err := th.Client.CancelMembershipRequest(context.Background(), membershipRequest.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipRequestStatusCancelled, cancelled.Status)
+}
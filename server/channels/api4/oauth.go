@@ -41,6 +41,7 @@ func createOAuthApp(c *Context, w http.ResponseWriter, r *http.Request) {
 		CallbackUrls: appRequest.CallbackUrls,
 		Homepage:     appRequest.Homepage,
 		IsTrusted:    appRequest.IsTrusted,
+		Scopes:       appRequest.Scopes,
 	}
 
 	auditRec := c.MakeAuditRecord(model.AuditEventCreateOAuthApp, model.AuditStatusFail)
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// handlerOAuthScopes maps API handlers to the OAuth scope a session restricted to scopes, such as
+// one created from a scoped OAuth app or personal access token, needs to call them. Such sessions
+// can't call handlers missing from the map. The permissions of the user are checked as usual.
+var handlerOAuthScopes = map[string]string{
+	// Posts, reactions, threads and files
+	"getPost":                            model.OAuthScopePostsRead,
+	"getPostsByIds":                      model.OAuthScopePostsRead,
+	"getEditHistoryForPost":              model.OAuthScopePostsRead,
+	"getPostThread":                      model.OAuthScopePostsRead,
+	"getPostInfo":                        model.OAuthScopePostsRead,
+	"getFileInfosForPost":                model.OAuthScopePostsRead,
+	"getPostsForChannel":                 model.OAuthScopePostsRead,
+	"getPostsForChannelAroundLastUnread": model.OAuthScopePostsRead,
+	"getFlaggedPostsForUser":             model.OAuthScopePostsRead,
+	"getPinnedPosts":                     model.OAuthScopePostsRead,
+	"searchPostsInTeam":                  model.OAuthScopePostsRead,
+	"searchPostsInAllTeams":              model.OAuthScopePostsRead,
+	"getReactions":                       model.OAuthScopePostsRead,
+	"getBulkReactions":                   model.OAuthScopePostsRead,
+	"getThreadsForUser":                  model.OAuthScopePostsRead,
+	"getThreadForUser":                   model.OAuthScopePostsRead,
+	"getFile":                            model.OAuthScopePostsRead,
+	"getFileThumbnail":                   model.OAuthScopePostsRead,
+	"getFilePreview":                     model.OAuthScopePostsRead,
+	"getFileInfo":                        model.OAuthScopePostsRead,
+	"searchFilesInTeam":                  model.OAuthScopePostsRead,
+	"searchFilesInAllTeams":              model.OAuthScopePostsRead,
+	"createPost":                         model.OAuthScopePostsWrite,
+	"updatePost":                         model.OAuthScopePostsWrite,
+	"patchPost":                          model.OAuthScopePostsWrite,
+	"deletePost":                         model.OAuthScopePostsWrite,
+	"pinPost":                            model.OAuthScopePostsWrite,
+	"unpinPost":                          model.OAuthScopePostsWrite,
+	"acknowledgePost":                    model.OAuthScopePostsWrite,
+	"unacknowledgePost":                  model.OAuthScopePostsWrite,
+	"saveReaction":                       model.OAuthScopePostsWrite,
+	"deleteReaction":                     model.OAuthScopePostsWrite,
+	"uploadFileStream":                   model.OAuthScopePostsWrite,
+	"createUpload":                       model.OAuthScopePostsWrite,
+	"uploadData":                         model.OAuthScopePostsWrite,
+
+	// Channels
+	"getChannel":                      model.OAuthScopeChannelsRead,
+	"getChannelByName":                model.OAuthScopeChannelsRead,
+	"getChannelByNameForTeamName":     model.OAuthScopeChannelsRead,
+	"getChannelsForTeamForUser":       model.OAuthScopeChannelsRead,
+	"getChannelsForUser":              model.OAuthScopeChannelsRead,
+	"getPublicChannelsForTeam":        model.OAuthScopeChannelsRead,
+	"getPublicChannelsByIdsForTeam":   model.OAuthScopeChannelsRead,
+	"searchChannelsForTeam":           model.OAuthScopeChannelsRead,
+	"autocompleteChannelsForTeam":     model.OAuthScopeChannelsRead,
+	"getChannelStats":                 model.OAuthScopeChannelsRead,
+	"getChannelUnread":                model.OAuthScopeChannelsRead,
+	"getChannelMembers":               model.OAuthScopeChannelsRead,
+	"getChannelMembersByIds":          model.OAuthScopeChannelsRead,
+	"getChannelMember":                model.OAuthScopeChannelsRead,
+	"getChannelMembersForTeamForUser": model.OAuthScopeChannelsRead,
+	"createChannel":                   model.OAuthScopeChannelsManage,
+	"createDirectChannel":             model.OAuthScopeChannelsManage,
+	"createGroupChannel":              model.OAuthScopeChannelsManage,
+	"updateChannel":                   model.OAuthScopeChannelsManage,
+	"patchChannel":                    model.OAuthScopeChannelsManage,
+	"updateChannelPrivacy":            model.OAuthScopeChannelsManage,
+	"restoreChannel":                  model.OAuthScopeChannelsManage,
+	"deleteChannel":                   model.OAuthScopeChannelsManage,
+	"addChannelMember":                model.OAuthScopeChannelsManage,
+	"removeChannelMember":             model.OAuthScopeChannelsManage,
+
+	// Teams
+	"getTeam":               model.OAuthScopeTeamsRead,
+	"getTeamByName":         model.OAuthScopeTeamsRead,
+	"getTeamsForUser":       model.OAuthScopeTeamsRead,
+	"getTeamsUnreadForUser": model.OAuthScopeTeamsRead,
+	"getTeamUnread":         model.OAuthScopeTeamsRead,
+	"getTeamStats":          model.OAuthScopeTeamsRead,
+	"getTeamIcon":           model.OAuthScopeTeamsRead,
+	"getTeamMembers":        model.OAuthScopeTeamsRead,
+	"getTeamMembersByIds":   model.OAuthScopeTeamsRead,
+	"getTeamMember":         model.OAuthScopeTeamsRead,
+	"getTeamMembersForUser": model.OAuthScopeTeamsRead,
+
+	// Users
+	"getUser":                model.OAuthScopeUsersRead,
+	"getUsers":               model.OAuthScopeUsersRead,
+	"getUsersByIds":          model.OAuthScopeUsersRead,
+	"getUsersByNames":        model.OAuthScopeUsersRead,
+	"getUserByUsername":      model.OAuthScopeUsersRead,
+	"getUserByEmail":         model.OAuthScopeUsersRead,
+	"searchUsers":            model.OAuthScopeUsersRead,
+	"autocompleteUsers":      model.OAuthScopeUsersRead,
+	"getProfileImage":        model.OAuthScopeUsersRead,
+	"getDefaultProfileImage": model.OAuthScopeUsersRead,
+	"getUserStatus":          model.OAuthScopeUsersRead,
+	"getUserStatusesByIds":   model.OAuthScopeUsersRead,
+
+	// SCIM provisioning
+	"getScimServiceProviderConfig": model.OAuthScopeScim,
+	"getScimResourceTypes":         model.OAuthScopeScim,
+	"getScimUsers":                 model.OAuthScopeScim,
+	"getScimUser":                  model.OAuthScopeScim,
+	"createScimUser":               model.OAuthScopeScim,
+	"replaceScimUser":              model.OAuthScopeScim,
+	"patchScimUser":                model.OAuthScopeScim,
+	"deleteScimUser":               model.OAuthScopeScim,
+	"getScimGroups":                model.OAuthScopeScim,
+	"getScimGroup":                 model.OAuthScopeScim,
+	"createScimGroup":              model.OAuthScopeScim,
+	"replaceScimGroup":             model.OAuthScopeScim,
+	"patchScimGroup":               model.OAuthScopeScim,
+	"deleteScimGroup":              model.OAuthScopeScim,
+}
@@ -1000,3 +1000,55 @@ func TestRegisterOAuthClientAudit(t *testing.T) {
 		assert.Equal(t, "fail", entry.Status)
 	})
 }
+
+func TestAuthorizeOAuthAppScopes(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	client := th.Client
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oapp := &model.OAuthApp{
+		Name:         GenerateTestAppName(),
+		Homepage:     "https://nowhere.com",
+		Description:  "test",
+		CallbackUrls: []string{"https://nowhere.com"},
+		Scopes:       model.StringArray{model.OAuthScopePostsRead},
+	}
+	rapp, _, err := th.SystemAdminClient.CreateOAuthApp(context.Background(), oapp)
+	require.NoError(t, err)
+	require.Equal(t, oapp.Scopes, rapp.Scopes)
+
+	authRequest := &model.AuthorizeRequest{
+		ResponseType: model.AuthCodeResponseType,
+		ClientId:     rapp.Id,
+		RedirectURI:  rapp.CallbackUrls[0],
+		State:        "123",
+	}
+
+	t.Run("scope of the app", func(t *testing.T) {
+		authRequest.Scope = model.OAuthScopePostsRead
+		_, _, err := client.AuthorizeOAuthApp(context.Background(), authRequest)
+		require.NoError(t, err)
+	})
+
+	t.Run("scope not allowed for the app", func(t *testing.T) {
+		authRequest.Scope = model.OAuthScopePostsWrite
+		_, resp, err := client.AuthorizeOAuthApp(context.Background(), authRequest)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+		CheckErrorID(t, err, "model.oauth.grant_scopes.not_allowed.app_error")
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		oapp := &model.OAuthApp{
+			Name:         GenerateTestAppName(),
+			Homepage:     "https://nowhere.com",
+			CallbackUrls: []string{"https://nowhere.com"},
+			Scopes:       model.StringArray{"posts:admin"},
+		}
+		_, resp, err := th.SystemAdminClient.CreateOAuthApp(context.Background(), oapp)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
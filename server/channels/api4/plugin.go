@@ -29,6 +29,7 @@ func (api *API) InitPlugin() {
 	api.BaseRoutes.Plugin.Handle("", api.APISessionRequired(removePlugin)).Methods(http.MethodDelete)
 	api.BaseRoutes.Plugins.Handle("/install_from_url", api.APISessionRequired(installPluginFromURL)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugins.Handle("/marketplace", api.APISessionRequired(installMarketplacePlugin)).Methods(http.MethodPost)
+	api.BaseRoutes.Plugins.Handle("/verify", api.APISessionRequired(verifyPluginSignature, handlerParamFileAPI)).Methods(http.MethodPost)
 
 	api.BaseRoutes.Plugins.Handle("/statuses", api.APISessionRequired(getPluginStatuses)).Methods(http.MethodGet)
 	api.BaseRoutes.Plugin.Handle("/enable", api.APISessionRequired(enablePlugin)).Methods(http.MethodPost)
@@ -44,7 +45,7 @@ func (api *API) InitPlugin() {
 
 func uploadPlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 	config := c.App.Config()
-	if !*config.PluginSettings.Enable || !*config.PluginSettings.EnableUploads || *config.PluginSettings.RequirePluginSignature {
+	if !*config.PluginSettings.Enable || !*config.PluginSettings.EnableUploads {
 		c.Err = model.NewAppError("uploadPlugin", "app.plugin.upload_disabled.app_error", nil, "", http.StatusNotImplemented)
 		return
 	}
@@ -92,8 +93,90 @@ func uploadPlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 		force = true
 	}
 
-	installPlugin(c, w, file, force)
+	// Plugins uploaded with a signature are verified against the trust store, which is required
+	// to upload plugins when signatures are.
+	signatureArray := m.File["signature"]
+	if len(signatureArray) == 0 {
+		if *config.PluginSettings.RequirePluginSignature {
+			c.Err = model.NewAppError("uploadPlugin", "app.plugin.upload_disabled.app_error", nil, "", http.StatusNotImplemented)
+			return
+		}
+
+		installPlugin(c, w, file, force)
+		auditRec.Success()
+		return
+	}
+
+	signature, err := signatureArray[0].Open()
+	if err != nil {
+		c.Err = model.NewAppError("uploadPlugin", "api.plugin.upload.file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+	defer signature.Close()
+
+	manifest, signer, appErr := c.App.InstallPluginWithSignature(file, signature, force)
+	if signer != nil {
+		auditRec.AddMeta("signer", signer.Auditable())
+	}
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
 	auditRec.Success()
+	auditRec.AddMeta("plugin_id", manifest.Id)
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(manifest); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func verifyPluginSignature(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadPlugins) {
+		c.SetPermissionError(model.PermissionSysconsoleReadPlugins)
+		return
+	}
+
+	if err := r.ParseMultipartForm(MaxPluginMemory); err != nil {
+		if err.Error() == "http: request body too large" {
+			c.Err = model.NewAppError("verifyPluginSignature", "api.plugin.upload.file_too_large.app_error", nil, "", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m := r.MultipartForm
+	pluginArray, signatureArray := m.File["plugin"], m.File["signature"]
+	if len(pluginArray) == 0 || len(signatureArray) == 0 {
+		c.Err = model.NewAppError("verifyPluginSignature", "api.plugin.upload.no_file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	file, err := pluginArray[0].Open()
+	if err != nil {
+		c.Err = model.NewAppError("verifyPluginSignature", "api.plugin.upload.file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	signature, err := signatureArray[0].Open()
+	if err != nil {
+		c.Err = model.NewAppError("verifyPluginSignature", "api.plugin.upload.file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+	defer signature.Close()
+
+	verification, appErr := c.App.VerifyPluginSignature(c.AppContext, file, signature)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(verification); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
 }
 
 func installPluginFromURL(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -156,7 +239,10 @@ func installMarketplacePlugin(c *Context, w http.ResponseWriter, r *http.Request
 	// https://mattermost.atlassian.net/browse/MM-41981
 	pluginRequest.Version = ""
 
-	manifest, appErr := c.App.Channels().InstallMarketplacePlugin(pluginRequest)
+	manifest, signer, appErr := c.App.Channels().InstallMarketplacePlugin(pluginRequest)
+	if signer != nil {
+		auditRec.AddMeta("signer", signer.Auditable())
+	}
 	if appErr != nil {
 		c.Err = appErr
 		return
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
 func (api *API) InitPluginLocal() {
@@ -18,9 +19,12 @@ func (api *API) InitPluginLocal() {
 	api.BaseRoutes.Plugin.Handle("/enable", api.APILocal(enablePlugin)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugin.Handle("/disable", api.APILocal(disablePlugin)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugins.Handle("/marketplace", api.APILocal(installMarketplacePlugin)).Methods(http.MethodPost)
+	api.BaseRoutes.Plugins.Handle("/verify", api.APILocal(verifyPluginSignature, handlerParamFileAPI)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugins.Handle("/marketplace", api.APILocal(getMarketplacePlugins)).Methods(http.MethodGet)
 	api.BaseRoutes.Plugins.Handle("/reattach", api.APILocal(reattachPlugin)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugin.Handle("/detach", api.APILocal(detachPlugin)).Methods(http.MethodPost)
+	api.BaseRoutes.Plugins.Handle("/watch", api.APILocal(watchPlugin)).Methods(http.MethodPost)
+	api.BaseRoutes.Plugin.Handle("/unwatch", api.APILocal(unwatchPlugin)).Methods(http.MethodPost)
 }
 
 // reattachPlugin allows the server to bind to an existing plugin instance launched elsewhere.
@@ -60,3 +64,46 @@ func detachPlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// watchPlugin installs a plugin from a local source directory and reloads it whenever it changes.
+//
+// This API is only exposed over a local socket.
+func watchPlugin(c *Context, w http.ResponseWriter, r *http.Request) {
+	var pluginWatchRequest model.PluginWatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&pluginWatchRequest); err != nil {
+		c.Err = model.NewAppError("watchPlugin", "api4.plugin.watchPlugin.invalid_request", nil, "", http.StatusBadRequest).Wrap(err)
+		return
+	}
+
+	if err := pluginWatchRequest.IsValid(); err != nil {
+		c.Err = err
+		return
+	}
+
+	manifest, err := c.App.WatchPlugin(&pluginWatchRequest)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(manifest); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// unwatchPlugin stops watching the source directory of a plugin.
+//
+// This API is only exposed over a local socket.
+func unwatchPlugin(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePluginId()
+	if c.Err != nil {
+		return
+	}
+
+	if err := c.App.UnwatchPlugin(c.Params.PluginId); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
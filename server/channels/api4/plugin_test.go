@@ -2080,3 +2080,97 @@ func TestPluginWebSocketRemoteAddress(t *testing.T) {
 	_, remoteAddr := hooks.MessageWillBePosted(nil, nil)
 	require.NotEmpty(t, remoteAddr)
 }
+
+func TestVerifyPluginSignature(t *testing.T) {
+	mainHelper.Parallel(t)
+	path, _ := fileutils.FindDir("tests")
+	publicKeyFile := filepath.Join(path, "development-public-key.asc")
+
+	th := SetupConfig(t, func(cfg *model.Config) {
+		cfg.PluginSettings.SignaturePublicKeyFiles = []string{publicKeyFile}
+	}).InitBasic(t)
+
+	verify := func(t *testing.T, client *model.Client4) (*model.PluginSignatureVerification, *model.Response, error) {
+		t.Helper()
+
+		pluginReader, err := os.Open(filepath.Join(path, "testplugin.tar.gz"))
+		require.NoError(t, err)
+		defer pluginReader.Close()
+		signatureReader, err := os.Open(filepath.Join(path, "testplugin.tar.gz.sig"))
+		require.NoError(t, err)
+		defer signatureReader.Close()
+
+		return client.VerifyPlugin(context.Background(), pluginReader, signatureReader)
+	}
+
+	t.Run("requires permission", func(t *testing.T) {
+		_, resp, err := verify(t, th.Client)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.PluginSettings.SignatureKeyPolicies = nil
+		})
+
+		verification, _, err := verify(t, client)
+		require.NoError(t, err)
+		assert.Equal(t, "testplugin", verification.PluginId)
+		assert.True(t, verification.Trusted)
+		require.NotNil(t, verification.Signer)
+		assert.Equal(t, publicKeyFile, verification.Signer.PublicKeyFile)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.PluginSettings.SignatureKeyPolicies = []*model.PluginSignatureKeyPolicy{{
+				PublicKeyFile:    model.NewPointer(publicKeyFile),
+				AllowedPluginIds: []string{"com.example.internal"},
+				ExpiresAt:        model.NewPointer(int64(0)),
+			}}
+		})
+
+		verification, _, err = verify(t, client)
+		require.NoError(t, err)
+		assert.False(t, verification.Trusted)
+		assert.NotEmpty(t, verification.Reason)
+		require.NotNil(t, verification.Signer)
+	}, "trust policies")
+}
+
+func TestUploadSignedPlugin(t *testing.T) {
+	mainHelper.Parallel(t)
+	path, _ := fileutils.FindDir("tests")
+
+	th := SetupConfig(t, func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+		*cfg.PluginSettings.EnableUploads = true
+		*cfg.PluginSettings.RequirePluginSignature = true
+		cfg.PluginSettings.SignaturePublicKeyFiles = []string{filepath.Join(path, "development-public-key.asc")}
+	}).InitBasic(t)
+
+	upload := func(t *testing.T, client *model.Client4, signatureFile string) (*model.Manifest, *model.Response, error) {
+		t.Helper()
+
+		pluginReader, err := os.Open(filepath.Join(path, "testplugin.tar.gz"))
+		require.NoError(t, err)
+		defer pluginReader.Close()
+		signatureReader, err := os.Open(filepath.Join(path, signatureFile))
+		require.NoError(t, err)
+		defer signatureReader.Close()
+
+		return client.UploadSignedPlugin(context.Background(), pluginReader, signatureReader, true)
+	}
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		_, resp, err := upload(t, client, "testplugin2.tar.gz.sig")
+		require.Error(t, err)
+		CheckInternalErrorStatus(t, resp)
+
+		manifest, _, err := upload(t, client, "testplugin.tar.gz.sig")
+		require.NoError(t, err)
+		assert.Equal(t, "testplugin", manifest.Id)
+
+		_, err = client.RemovePlugin(context.Background(), manifest.Id)
+		require.NoError(t, err)
+	}, "signature required")
+}
@@ -34,7 +34,8 @@ func (api *API) InitRemoteCluster() {
 
 func remoteClusterPing(c *Context, w http.ResponseWriter, r *http.Request) {
 	// make sure remote cluster service is enabled.
-	if _, appErr := c.App.GetRemoteClusterService(); appErr != nil {
+	service, appErr := c.App.GetRemoteClusterService()
+	if appErr != nil {
 		c.Err = appErr
 		return
 	}
@@ -69,6 +70,10 @@ func remoteClusterPing(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	ping.RecvAt = model.GetMillis()
 
+	// remember what the sender supports and reply with our own features.
+	service.SetRemoteFeatures(rc.RemoteId, ping.Features)
+	ping.Features = model.RemoteClusterFeatures
+
 	if metrics := c.App.Metrics(); metrics != nil {
 		metrics.IncrementRemoteClusterMsgReceivedCounter(rc.RemoteId)
 	}
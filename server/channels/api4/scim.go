@@ -0,0 +1,591 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *API) InitScim() {
+	api.BaseRoutes.Scim.Handle("/ServiceProviderConfig", api.APISessionRequired(getScimServiceProviderConfig)).Methods(http.MethodGet)
+	api.BaseRoutes.Scim.Handle("/ResourceTypes", api.APISessionRequired(getScimResourceTypes)).Methods(http.MethodGet)
+
+	api.BaseRoutes.Scim.Handle("/Users", api.APISessionRequired(getScimUsers)).Methods(http.MethodGet)
+	api.BaseRoutes.Scim.Handle("/Users", api.APISessionRequired(createScimUser)).Methods(http.MethodPost)
+	api.BaseRoutes.Scim.Handle("/Users/{user_id:[A-Za-z0-9]+}", api.APISessionRequired(getScimUser)).Methods(http.MethodGet)
+	api.BaseRoutes.Scim.Handle("/Users/{user_id:[A-Za-z0-9]+}", api.APISessionRequired(replaceScimUser)).Methods(http.MethodPut)
+	api.BaseRoutes.Scim.Handle("/Users/{user_id:[A-Za-z0-9]+}", api.APISessionRequired(patchScimUser)).Methods(http.MethodPatch)
+	api.BaseRoutes.Scim.Handle("/Users/{user_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteScimUser)).Methods(http.MethodDelete)
+
+	api.BaseRoutes.Scim.Handle("/Groups", api.APISessionRequired(getScimGroups)).Methods(http.MethodGet)
+	api.BaseRoutes.Scim.Handle("/Groups", api.APISessionRequired(createScimGroup)).Methods(http.MethodPost)
+	api.BaseRoutes.Scim.Handle("/Groups/{group_id:[A-Za-z0-9]+}", api.APISessionRequired(getScimGroup)).Methods(http.MethodGet)
+	api.BaseRoutes.Scim.Handle("/Groups/{group_id:[A-Za-z0-9]+}", api.APISessionRequired(replaceScimGroup)).Methods(http.MethodPut)
+	api.BaseRoutes.Scim.Handle("/Groups/{group_id:[A-Za-z0-9]+}", api.APISessionRequired(patchScimGroup)).Methods(http.MethodPatch)
+	api.BaseRoutes.Scim.Handle("/Groups/{group_id:[A-Za-z0-9]+}", api.APISessionRequired(deleteScimGroup)).Methods(http.MethodDelete)
+}
+
+// scimErrorTypes maps the errors to the SCIM error types identity providers
+// act upon, such as uniqueness, on which they look the existing user up.
+var scimErrorTypes = map[string]string{
+	"model.scim.invalid_filter.app_error":      model.ScimErrorTypeInvalidFilter,
+	"app.scim.unsupported_filter.app_error":    model.ScimErrorTypeInvalidFilter,
+	"model.scim.invalid_path.app_error":        model.ScimErrorTypeInvalidPath,
+	"model.scim.invalid_value.app_error":       model.ScimErrorTypeInvalidValue,
+	"model.scim.invalid_operation.app_error":   model.ScimErrorTypeInvalidSyntax,
+	"model.scim.no_target.app_error":           model.ScimErrorTypeNoTarget,
+	"api.scim.invalid_patch.app_error":         model.ScimErrorTypeInvalidSyntax,
+	"api.context.invalid_body_param.app_error": model.ScimErrorTypeInvalidSyntax,
+	"app.scim.missing_attribute.app_error":     model.ScimErrorTypeInvalidValue,
+	"app.scim.invalid_member.app_error":        model.ScimErrorTypeInvalidValue,
+	"app.user.save.username_exists.app_error":  model.ScimErrorTypeUniqueness,
+	"app.user.save.email_exists.app_error":     model.ScimErrorTypeUniqueness,
+	"app.user.save.existing.app_error":         model.ScimErrorTypeUniqueness,
+	"app.scim.group_exists.app_error":          model.ScimErrorTypeUniqueness,
+	"app.custom_group.unique_name":             model.ScimErrorTypeUniqueness,
+}
+
+// writeScimError writes the error of the context, if any, in the format
+// defined by SCIM rather than as an AppError. Handlers defer it before
+// anything else, so that audit records still get the error.
+func writeScimError(c *Context, w http.ResponseWriter) {
+	if c.Err == nil {
+		return
+	}
+
+	c.Err.RequestId = c.AppContext.RequestId()
+	c.LogErrorByCode(c.Err)
+	c.Err.Translate(c.AppContext.T)
+
+	status := c.Err.StatusCode
+	scimType := scimErrorTypes[c.Err.Id]
+	if scimType == model.ScimErrorTypeUniqueness {
+		status = http.StatusConflict
+	}
+
+	scimErr := &model.ScimError{
+		Schemas:  []string{model.ScimSchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   c.Err.Message,
+	}
+	if *c.App.Config().ServiceSettings.ExperimentalEnableHardenedMode && status >= http.StatusInternalServerError {
+		scimErr.Detail = "Internal Server Error"
+	}
+
+	c.Err = nil
+	writeScimResponse(c, w, status, scimErr)
+}
+
+func writeScimResponse(c *Context, w http.ResponseWriter, status int, resource any) {
+	w.Header().Set("Content-Type", model.ScimContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resource); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func requireScimEnabled(c *Context) bool {
+	if !*c.App.Config().ScimSettings.Enable {
+		c.Err = model.NewAppError("Api4.requireScimEnabled", "api.scim.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return false
+	}
+	return true
+}
+
+func requireScimUsersPermission(c *Context, permission *model.Permission) bool {
+	if !requireScimEnabled(c) {
+		return false
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), permission) {
+		c.SetPermissionError(permission)
+		return false
+	}
+	return true
+}
+
+// requireScimUserPermission additionally checks that the session can manage
+// the given user, as only system admins can modify other system admins.
+func requireScimUserPermission(c *Context, userID string) bool {
+	if !requireScimUsersPermission(c, model.PermissionSysconsoleWriteUserManagementUsers) {
+		return false
+	}
+
+	user, appErr := c.App.GetUser(userID)
+	if appErr != nil {
+		c.Err = appErr
+		return false
+	}
+
+	if user.IsSystemAdmin() && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return false
+	}
+	return true
+}
+
+func requireScimGroupsPermission(c *Context, permission *model.Permission) bool {
+	if !requireScimEnabled(c) {
+		return false
+	}
+
+	if appErr := licensedAndConfiguredForGroupBySource(c.App, model.GroupSourceCustom); appErr != nil {
+		appErr.Where = "Api4.requireScimGroupsPermission"
+		c.Err = appErr
+		return false
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), permission) {
+		c.SetPermissionError(permission)
+		return false
+	}
+	return true
+}
+
+// scimListParams returns the filter and the pagination of a list request.
+func scimListParams(c *Context, r *http.Request) (*model.ScimFilter, int, int, bool) {
+	query := r.URL.Query()
+
+	var filter *model.ScimFilter
+	if f := query.Get("filter"); f != "" {
+		var appErr *model.AppError
+		filter, appErr = model.ParseScimFilter(f)
+		if appErr != nil {
+			c.Err = appErr
+			return nil, 0, 0, false
+		}
+	}
+
+	startIndex := 1
+	if s := query.Get("startIndex"); s != "" {
+		if i, err := strconv.Atoi(s); err == nil && i > 1 {
+			startIndex = i
+		}
+	}
+
+	count := model.ScimDefaultCount
+	if s := query.Get("count"); s != "" {
+		if i, err := strconv.Atoi(s); err == nil {
+			count = max(0, min(i, model.ScimMaxCount))
+		}
+	}
+
+	return filter, startIndex, count, true
+}
+
+func decodeScimPatchRequest(c *Context, r *http.Request) *model.ScimPatchRequest {
+	var patch *model.ScimPatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil || patch == nil {
+		c.SetInvalidParamWithErr("Operations", err)
+		return nil
+	}
+
+	if len(patch.Operations) == 0 {
+		c.Err = model.NewAppError("Api4.decodeScimPatchRequest", "api.scim.invalid_patch.app_error", nil, "", http.StatusBadRequest)
+		return nil
+	}
+	for _, op := range patch.Operations {
+		if op == nil {
+			c.Err = model.NewAppError("Api4.decodeScimPatchRequest", "api.scim.invalid_patch.app_error", nil, "", http.StatusBadRequest)
+			return nil
+		}
+	}
+
+	return patch
+}
+
+func getScimServiceProviderConfig(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	if !requireScimUsersPermission(c, model.PermissionSysconsoleReadUserManagementUsers) {
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, map[string]any{
+		"schemas":        []string{model.ScimSchemaServiceProviderConfig},
+		"patch":          map[string]any{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": model.ScimMaxCount},
+		"changePassword": map[string]any{"supported": true},
+		"sort":           map[string]any{"supported": false},
+		"etag":           map[string]any{"supported": false},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "OAuth Bearer Token",
+			"description": "Authentication with a personal access token or an OAuth access token with the scim scope.",
+			"primary":     true,
+		}},
+	})
+}
+
+func getScimResourceTypes(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	if !requireScimUsersPermission(c, model.PermissionSysconsoleReadUserManagementUsers) {
+		return
+	}
+
+	resources := []any{
+		map[string]any{
+			"schemas":  []string{model.ScimSchemaResourceType},
+			"id":       model.ScimResourceTypeUser,
+			"name":     model.ScimResourceTypeUser,
+			"endpoint": "/Users",
+			"schema":   model.ScimSchemaUser,
+		},
+		map[string]any{
+			"schemas":  []string{model.ScimSchemaResourceType},
+			"id":       model.ScimResourceTypeGroup,
+			"name":     model.ScimResourceTypeGroup,
+			"endpoint": "/Groups",
+			"schema":   model.ScimSchemaGroup,
+		},
+	}
+
+	writeScimResponse(c, w, http.StatusOK, model.NewScimListResponse(resources, len(resources), 1))
+}
+
+func getScimUsers(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	if !requireScimUsersPermission(c, model.PermissionSysconsoleReadUserManagementUsers) {
+		return
+	}
+
+	filter, startIndex, count, ok := scimListParams(c, r)
+	if !ok {
+		return
+	}
+
+	list, appErr := c.App.ListScimUsers(filter, startIndex, count)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, list)
+}
+
+func getScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !requireScimUsersPermission(c, model.PermissionSysconsoleReadUserManagementUsers) {
+		return
+	}
+
+	user, appErr := c.App.GetScimUser(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, user)
+}
+
+func createScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	if !requireScimUsersPermission(c, model.PermissionSysconsoleWriteUserManagementUsers) {
+		return
+	}
+
+	var scimUser *model.ScimUser
+	if err := json.NewDecoder(r.Body).Decode(&scimUser); err != nil || scimUser == nil {
+		c.SetInvalidParamWithErr("user", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCreateScimUser, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "user", scimUser)
+
+	created, appErr := c.App.CreateScimUser(c.AppContext, scimUser)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(created)
+	auditRec.AddEventObjectType("user")
+
+	w.Header().Set("Location", created.Meta.Location)
+	writeScimResponse(c, w, http.StatusCreated, created)
+}
+
+func replaceScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	var scimUser *model.ScimUser
+	if err := json.NewDecoder(r.Body).Decode(&scimUser); err != nil || scimUser == nil {
+		c.SetInvalidParamWithErr("user", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventReplaceScimUser, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "user", scimUser)
+
+	if !requireScimUserPermission(c, c.Params.UserId) {
+		return
+	}
+
+	updated, appErr := c.App.ReplaceScimUser(c.AppContext, c.Params.UserId, scimUser)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(updated)
+	auditRec.AddEventObjectType("user")
+
+	writeScimResponse(c, w, http.StatusOK, updated)
+}
+
+func patchScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	patch := decodeScimPatchRequest(c, r)
+	if patch == nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventPatchScimUser, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "patch", patch)
+
+	if !requireScimUserPermission(c, c.Params.UserId) {
+		return
+	}
+
+	updated, appErr := c.App.PatchScimUser(c.AppContext, c.Params.UserId, patch.Operations)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(updated)
+	auditRec.AddEventObjectType("user")
+
+	writeScimResponse(c, w, http.StatusOK, updated)
+}
+
+func deleteScimUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteScimUser, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+
+	if !requireScimUserPermission(c, c.Params.UserId) {
+		return
+	}
+
+	if appErr := c.App.DeleteScimUser(c.AppContext, c.Params.UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// scimExcludesMembers returns whether the request excludes the members of the
+// groups, which identity providers do as groups may have many members.
+func scimExcludesMembers(r *http.Request) bool {
+	for attr := range strings.SplitSeq(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return true
+		}
+	}
+	return false
+}
+
+func getScimGroups(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	if !requireScimGroupsPermission(c, model.PermissionSysconsoleReadUserManagementGroups) {
+		return
+	}
+
+	filter, startIndex, count, ok := scimListParams(c, r)
+	if !ok {
+		return
+	}
+
+	list, appErr := c.App.ListScimGroups(filter, startIndex, count, scimExcludesMembers(r))
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, list)
+}
+
+func getScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	if !requireScimGroupsPermission(c, model.PermissionSysconsoleReadUserManagementGroups) {
+		return
+	}
+
+	group, appErr := c.App.GetScimGroup(c.Params.GroupId, scimExcludesMembers(r))
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	writeScimResponse(c, w, http.StatusOK, group)
+}
+
+func createScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	if !requireScimGroupsPermission(c, model.PermissionSysconsoleWriteUserManagementGroups) {
+		return
+	}
+
+	var scimGroup *model.ScimGroup
+	if err := json.NewDecoder(r.Body).Decode(&scimGroup); err != nil || scimGroup == nil {
+		c.SetInvalidParamWithErr("group", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCreateScimGroup, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "group", scimGroup)
+
+	created, appErr := c.App.CreateScimGroup(c.AppContext, scimGroup)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(created)
+	auditRec.AddEventObjectType("group")
+
+	w.Header().Set("Location", created.Meta.Location)
+	writeScimResponse(c, w, http.StatusCreated, created)
+}
+
+func replaceScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	if !requireScimGroupsPermission(c, model.PermissionSysconsoleWriteUserManagementGroups) {
+		return
+	}
+
+	var scimGroup *model.ScimGroup
+	if err := json.NewDecoder(r.Body).Decode(&scimGroup); err != nil || scimGroup == nil {
+		c.SetInvalidParamWithErr("group", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventReplaceScimGroup, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "group_id", c.Params.GroupId)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "group", scimGroup)
+
+	updated, appErr := c.App.ReplaceScimGroup(c.AppContext, c.Params.GroupId, scimGroup)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(updated)
+	auditRec.AddEventObjectType("group")
+
+	writeScimResponse(c, w, http.StatusOK, updated)
+}
+
+func patchScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	if !requireScimGroupsPermission(c, model.PermissionSysconsoleWriteUserManagementGroups) {
+		return
+	}
+
+	patch := decodeScimPatchRequest(c, r)
+	if patch == nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventPatchScimGroup, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "group_id", c.Params.GroupId)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "patch", patch)
+
+	updated, appErr := c.App.PatchScimGroup(c.AppContext, c.Params.GroupId, patch.Operations)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(updated)
+	auditRec.AddEventObjectType("group")
+
+	writeScimResponse(c, w, http.StatusOK, updated)
+}
+
+func deleteScimGroup(c *Context, w http.ResponseWriter, r *http.Request) {
+	defer writeScimError(c, w)
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	if !requireScimGroupsPermission(c, model.PermissionSysconsoleWriteUserManagementGroups) {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteScimGroup, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "group_id", c.Params.GroupId)
+
+	if appErr := c.App.DeleteScimGroup(c.AppContext, c.Params.GroupId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func doScimRequest(t *testing.T, client *model.Client4, method, route string, body any, result any) int {
+	t.Helper()
+
+	var data string
+	if body != nil {
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+		data = string(b)
+	}
+
+	resp, _ := client.DoAPIRequestWithHeaders(context.Background(), method, model.ScimURLPath+route, data, map[string]string{"Content-Type": model.ScimContentType})
+	require.NotNil(t, resp)
+	defer closeBody(resp)
+
+	if result != nil && resp.StatusCode < http.StatusMultipleChoices {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+	}
+
+	return resp.StatusCode
+}
+
+func TestScimUsers(t *testing.T) {
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ScimSettings.Enable = true })
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ScimSettings.Enable = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ScimSettings.Enable = true })
+
+		status := doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users", nil, nil)
+		assert.Equal(t, http.StatusNotImplemented, status)
+	})
+
+	t.Run("requires permission", func(t *testing.T) {
+		status := doScimRequest(t, th.Client, http.MethodGet, "/Users", nil, nil)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	var created model.ScimUser
+	t.Run("create", func(t *testing.T) {
+		status := doScimRequest(t, th.SystemAdminClient, http.MethodPost, "/Users", &model.ScimUser{
+			Schemas:  []string{model.ScimSchemaUser},
+			UserName: "BJensen",
+			Name:     &model.ScimName{GivenName: "Barbara", FamilyName: "Jensen"},
+			Emails:   []model.ScimMultiValuedAttribute{{Value: "bjensen@example.com", Primary: true}},
+		}, &created)
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, "bjensen", created.UserName)
+		assert.Equal(t, "bjensen@example.com", created.PrimaryEmail())
+		require.NotNil(t, created.Active)
+		assert.True(t, *created.Active)
+
+		user, appErr := th.App.GetUser(created.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "Barbara", user.FirstName)
+		assert.True(t, user.EmailVerified)
+
+		status = doScimRequest(t, th.SystemAdminClient, http.MethodPost, "/Users", &model.ScimUser{
+			Schemas:  []string{model.ScimSchemaUser},
+			UserName: "bjensen",
+			Emails:   []model.ScimMultiValuedAttribute{{Value: "other@example.com"}},
+		}, nil)
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		var list model.ScimListResponse
+		status := doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName eq "bjensen"`), nil, &list)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 1, list.TotalResults)
+
+		status = doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`name.familyName sw "jen"`), nil, &list)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 1, list.TotalResults)
+
+		status = doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName eq "nobody"`), nil, &list)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 0, list.TotalResults)
+
+		status = doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`userName eq`), nil, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		status = doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?filter="+url.QueryEscape(`active eq true and (name.givenName co "arbar" or userName eq "nobody")`), nil, &list)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 1, list.TotalResults)
+
+		for _, filter := range []string{`not (userName eq "bjensen")`, `groups.value eq "id"`, `meta.lastModified gt "2026-01-01T00:00:00Z"`} {
+			status = doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users?filter="+url.QueryEscape(filter), nil, nil)
+			assert.Equal(t, http.StatusBadRequest, status, filter)
+		}
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		var patched model.ScimUser
+		status := doScimRequest(t, th.SystemAdminClient, http.MethodPatch, "/Users/"+created.Id, &model.ScimPatchRequest{
+			Schemas: []string{model.ScimSchemaPatchOp},
+			Operations: []*model.ScimPatchOperation{
+				{Op: "Replace", Path: "title", Value: "Tour Guide"},
+				{Op: "Replace", Path: `emails[primary eq true].value`, Value: "babs@example.com"},
+			},
+		}, &patched)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Tour Guide", patched.Title)
+		assert.Equal(t, "babs@example.com", patched.PrimaryEmail())
+	})
+
+	t.Run("cannot modify system admins without manage system", func(t *testing.T) {
+		_, appErr := th.App.UpdateUserRoles(th.Context, th.BasicUser.Id, model.SystemUserRoleId+" "+model.SystemUserManagerRoleId, false)
+		require.Nil(t, appErr)
+		defer func() {
+			_, appErr = th.App.UpdateUserRoles(th.Context, th.BasicUser.Id, model.SystemUserRoleId, false)
+			require.Nil(t, appErr)
+		}()
+
+		status := doScimRequest(t, th.Client, http.MethodDelete, "/Users/"+th.SystemAdminUser.Id, nil, nil)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("delete deactivates", func(t *testing.T) {
+		status := doScimRequest(t, th.SystemAdminClient, http.MethodDelete, "/Users/"+created.Id, nil, nil)
+		require.Equal(t, http.StatusNoContent, status)
+
+		var user model.ScimUser
+		status = doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users/"+created.Id, nil, &user)
+		require.Equal(t, http.StatusOK, status)
+		require.NotNil(t, user.Active)
+		assert.False(t, *user.Active)
+	})
+
+	t.Run("bots are not provisioned", func(t *testing.T) {
+		status := doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Users/"+th.CreateBotWithSystemAdminClient(t).UserId, nil, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+func TestScimGroups(t *testing.T) {
+	th := Setup(t).InitBasic(t)
+
+	th.App.Srv().SetLicense(model.NewTestLicenseSKU(model.LicenseShortSkuProfessional))
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ScimSettings.Enable = true
+		*cfg.ServiceSettings.EnableCustomGroups = true
+	})
+
+	var created model.ScimGroup
+	t.Run("create", func(t *testing.T) {
+		status := doScimRequest(t, th.SystemAdminClient, http.MethodPost, "/Groups", &model.ScimGroup{
+			Schemas:     []string{model.ScimSchemaGroup},
+			ExternalId:  "engineering",
+			DisplayName: "Engineering",
+			Members:     []model.ScimMultiValuedAttribute{{Value: th.BasicUser.Id}},
+		}, &created)
+		require.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, "engineering", created.ExternalId)
+		assert.Equal(t, []string{th.BasicUser.Id}, created.MemberIds())
+
+		group, appErr := th.App.GetGroup(created.Id, nil, nil)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.GroupSourceCustom, group.Source)
+		assert.True(t, group.IsProvisioned())
+
+		status = doScimRequest(t, th.SystemAdminClient, http.MethodPost, "/Groups", &model.ScimGroup{
+			Schemas:     []string{model.ScimSchemaGroup},
+			ExternalId:  "engineering",
+			DisplayName: "Engineering 2",
+		}, nil)
+		assert.Equal(t, http.StatusConflict, status)
+	})
+
+	t.Run("patch members", func(t *testing.T) {
+		var patched model.ScimGroup
+		status := doScimRequest(t, th.SystemAdminClient, http.MethodPatch, "/Groups/"+created.Id, &model.ScimPatchRequest{
+			Schemas: []string{model.ScimSchemaPatchOp},
+			Operations: []*model.ScimPatchOperation{
+				{Op: "add", Path: "members", Value: []any{map[string]any{"value": th.BasicUser2.Id}}},
+				{Op: "remove", Path: `members[value eq "` + th.BasicUser.Id + `"]`},
+			},
+		}, &patched)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []string{th.BasicUser2.Id}, patched.MemberIds())
+	})
+
+	t.Run("custom group member endpoints are refused", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.UpsertGroupMembers(context.Background(), created.Id, &model.GroupModifyMembers{UserIds: []string{th.BasicUser.Id}})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		var list model.ScimListResponse
+		status := doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Groups?excludedAttributes=members&filter="+url.QueryEscape(`displayName eq "engineering"`), nil, &list)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 1, list.TotalResults)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		status := doScimRequest(t, th.SystemAdminClient, http.MethodDelete, "/Groups/"+created.Id, nil, nil)
+		require.Equal(t, http.StatusNoContent, status)
+
+		status = doScimRequest(t, th.SystemAdminClient, http.MethodGet, "/Groups/"+created.Id, nil, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
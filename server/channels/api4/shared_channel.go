@@ -20,6 +20,8 @@ func (api *API) InitSharedChannels() {
 	api.BaseRoutes.SharedChannelRemotes.Handle("", api.APISessionRequired(getSharedChannelRemotesByRemoteCluster)).Methods(http.MethodGet)
 	api.BaseRoutes.ChannelForRemote.Handle("/invite", api.APISessionRequired(inviteRemoteClusterToChannel)).Methods(http.MethodPost)
 	api.BaseRoutes.ChannelForRemote.Handle("/uninvite", api.APISessionRequired(uninviteRemoteClusterToChannel)).Methods(http.MethodPost)
+	api.BaseRoutes.ChannelForRemote.Handle("/compare", api.APISessionRequired(compareSharedChannelWithRemote)).Methods(http.MethodPost)
+	api.BaseRoutes.ChannelForRemote.Handle("/resync", api.APISessionRequired(resyncSharedChannelRemote)).Methods(http.MethodPost)
 }
 
 func getSharedChannels(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -249,6 +251,107 @@ func uninviteRemoteClusterToChannel(c *Context, w http.ResponseWriter, r *http.R
 	ReturnStatusOK(w)
 }
 
+func compareSharedChannelWithRemote(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireRemoteId()
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSecureConnections) {
+		c.SetPermissionError(model.PermissionManageSecureConnections)
+		return
+	}
+
+	// make sure remote cluster service is enabled.
+	if _, appErr := c.App.GetRemoteClusterService(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	var req model.SharedChannelSyncCompareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.SetInvalidParamWithErr("sync_compare", err)
+		return
+	}
+	if req.Until == 0 {
+		req.Until = model.GetMillis()
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCompareSharedChannelWithRemote, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "remote_id", c.Params.RemoteId)
+	model.AddEventParameterToAuditRec(auditRec, "channel_id", c.Params.ChannelId)
+	model.AddEventParameterToAuditRec(auditRec, "since", req.Since)
+	model.AddEventParameterToAuditRec(auditRec, "until", req.Until)
+
+	comparison, err := c.App.CompareSharedChannelWithRemote(c.Params.ChannelId, c.Params.RemoteId, req.Since, req.Until)
+	if err != nil {
+		if appErr, ok := err.(*model.AppError); ok {
+			c.Err = appErr
+		} else {
+			c.Err = model.NewAppError("compareSharedChannelWithRemote", "api.shared_channel.compare_with_remote_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return
+	}
+
+	auditRec.Success()
+	if err := json.NewEncoder(w).Encode(comparison); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func resyncSharedChannelRemote(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireRemoteId()
+	if c.Err != nil {
+		return
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSecureConnections) {
+		c.SetPermissionError(model.PermissionManageSecureConnections)
+		return
+	}
+
+	// make sure remote cluster service is enabled.
+	if _, appErr := c.App.GetRemoteClusterService(); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	var req model.SharedChannelResyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.SetInvalidParamWithErr("resync", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventResyncSharedChannelRemote, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "remote_id", c.Params.RemoteId)
+	model.AddEventParameterToAuditRec(auditRec, "channel_id", c.Params.ChannelId)
+	model.AddEventParameterToAuditRec(auditRec, "since", req.Since)
+
+	if err := c.App.ResyncSharedChannelRemote(c.Params.ChannelId, c.Params.RemoteId, req.Since); err != nil {
+		if appErr, ok := err.(*model.AppError); ok {
+			c.Err = appErr
+		} else {
+			c.Err = model.NewAppError("resyncSharedChannelRemote", "api.shared_channel.resync_remote_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
 // getSharedChannelRemotes returns info about remote clusters for a shared channel
 func getSharedChannelRemotes(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
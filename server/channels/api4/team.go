@@ -550,7 +550,7 @@ func deleteTeam(c *Context, w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	} else {
-		err = c.App.SoftDeleteTeam(c.Params.TeamId)
+		err = c.App.SoftDeleteTeam(c.AppContext, c.Params.TeamId)
 	}
 
 	if err != nil {
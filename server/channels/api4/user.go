@@ -54,6 +54,7 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("/convert_to_bot", api.APISessionRequired(convertUserToBot)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/password/reset", api.APIHandler(resetPassword)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/password/reset/send", api.APIHandler(sendPasswordReset)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/password/hashers", api.APISessionRequired(getPasswordHasherUsage)).Methods(http.MethodGet)
 	api.BaseRoutes.Users.Handle("/email/verify", api.APIHandler(verifyUserEmail)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/email/verify/send", api.APIHandler(sendVerificationEmail)).Methods(http.MethodPost)
 	api.BaseRoutes.User.Handle("/email/verify/member", api.APISessionRequired(verifyUserEmailWithoutToken)).Methods(http.MethodPost)
@@ -65,10 +66,18 @@ func (api *API) InitUser() {
 
 	api.BaseRoutes.User.Handle("/mfa", api.APISessionRequiredMfa(updateUserMfa)).Methods(http.MethodPut)
 	api.BaseRoutes.User.Handle("/mfa/generate", api.APISessionRequiredMfa(generateMfaSecret)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/mfa/recovery_codes", api.APISessionRequiredMfa(generateMfaRecoveryCodes)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/mfa/trusted_devices", api.APISessionRequiredMfa(revokeMfaTrustedDevices)).Methods(http.MethodDelete)
+	api.BaseRoutes.User.Handle("/webauthn", api.APISessionRequiredMfa(getWebAuthnCredentials)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/webauthn/register/begin", api.APISessionRequiredMfa(beginWebAuthnRegistration)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/webauthn/register/finish", api.APISessionRequiredMfa(finishWebAuthnRegistration)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/webauthn/{credential_id:[A-Za-z0-9]+}", api.APISessionRequiredMfa(deleteWebAuthnCredential)).Methods(http.MethodDelete)
 
 	api.BaseRoutes.Users.Handle("/login", api.RateLimitedHandler(api.APIHandler(login), model.RateLimitSettings{PerSec: model.NewPointer(5), MaxBurst: model.NewPointer(10)})).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/sso/code-exchange", api.APIHandler(loginSSOCodeExchange)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/desktop_token", api.RateLimitedHandler(api.APIHandler(loginWithDesktopToken), model.RateLimitSettings{PerSec: model.NewPointer(2), MaxBurst: model.NewPointer(1)})).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/login/webauthn", api.RateLimitedHandler(api.APIHandler(loginWithWebAuthn), model.RateLimitSettings{PerSec: model.NewPointer(5), MaxBurst: model.NewPointer(10)})).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/login/webauthn/begin", api.RateLimitedHandler(api.APIHandler(beginWebAuthnLogin), model.RateLimitSettings{PerSec: model.NewPointer(5), MaxBurst: model.NewPointer(10)})).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/switch", api.APIHandler(switchAccountType)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/cws", api.APIHandlerTrustRequester(loginCWS)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/login/type", api.APIHandler(getLoginType)).Methods(http.MethodPost)
@@ -748,6 +757,23 @@ func getFilteredUsersStats(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getPasswordHasherUsage(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadAuthenticationPassword) {
+		c.SetPermissionError(model.PermissionSysconsoleReadAuthenticationPassword)
+		return
+	}
+
+	usage, err := c.App.GetPasswordHasherUsage()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(usage); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func getUsersByGroupChannelIds(c *Context, w http.ResponseWriter, r *http.Request) {
 	channelIds, err := model.SortedArrayFromJSON(r.Body)
 	if err != nil || len(channelIds) == 0 {
@@ -1803,7 +1829,8 @@ func updateUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	c.LogAudit("attempt")
 
-	if appErr := c.App.UpdateMfa(c.AppContext, activate, c.Params.UserId, code); appErr != nil {
+	recoveryCodes, appErr := c.App.UpdateMfa(c.AppContext, activate, c.Params.UserId, code)
+	if appErr != nil {
 		c.Err = appErr
 		return
 	}
@@ -1812,7 +1839,18 @@ func updateUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("activate", activate)
 	c.LogAudit("success - mfa updated")
 
-	ReturnStatusOK(w)
+	// Recovery codes are only ever shown to the users they belong to, who can generate new ones.
+	if len(recoveryCodes) == 0 || c.AppContext.Session().UserId != c.Params.UserId {
+		ReturnStatusOK(w)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	if err := json.NewEncoder(w).Encode(map[string]any{"status": "OK", "recovery_codes": recoveryCodes}); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
 }
 
 func generateMfaSecret(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -1846,6 +1884,77 @@ func generateMfaSecret(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func generateMfaRecoveryCodes(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.AppContext.Session().IsOAuth {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	// Recovery codes are only ever shown to the users they belong to.
+	if c.AppContext.Session().UserId != c.Params.UserId {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventGenerateMfaRecoveryCodes, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+
+	recoveryCodes, appErr := c.App.GenerateMfaRecoveryCodes(c.AppContext, c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("success - mfa recovery codes generated")
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Pragma", "no-cache")
+	w.Header().Set("Expires", "0")
+	if err := json.NewEncoder(w).Encode(&model.MfaRecoveryCodes{RecoveryCodes: recoveryCodes}); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func revokeMfaTrustedDevices(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.AppContext.Session().IsOAuth {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRevokeMfaTrustedDevices, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+
+	if appErr := c.App.RevokeMfaTrustedDevices(c.AppContext, c.Params.UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("success - mfa trusted devices revoked")
+
+	ReturnStatusOK(w)
+}
+
 func updatePassword(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -2010,8 +2119,9 @@ func login(c *Context, w http.ResponseWriter, r *http.Request) {
 		openidEnabled := *config.OpenIdSettings.Enable
 		googleEnabled := *config.GoogleSettings.Enable
 		office365Enabled := *config.Office365Settings.Enable
+		openidProvidersEnabled := len(config.OpenIdProviderSettings.EnabledProviders()) > 0
 
-		if samlEnabled || gitlabEnabled || googleEnabled || office365Enabled || openidEnabled {
+		if samlEnabled || gitlabEnabled || googleEnabled || office365Enabled || openidEnabled || openidProvidersEnabled {
 			c.Err = model.NewAppError("login", "api.user.login.invalid_credentials_sso", nil, "", http.StatusUnauthorized)
 			return
 		}
@@ -2036,6 +2146,7 @@ func login(c *Context, w http.ResponseWriter, r *http.Request) {
 	mfaToken := props["token"]
 	deviceId := props["device_id"]
 	ldapOnly := props["ldap_only"] == "true"
+	trustDevice := props["trust_device"] == "true"
 	magicLinkToken := props["magic_link_token"]
 
 	auditRec := c.MakeAuditRecord(model.AuditEventLogin, model.AuditStatusFail)
@@ -2064,6 +2175,11 @@ func login(c *Context, w http.ResponseWriter, r *http.Request) {
 		model.AddEventParameterToAuditRec(auditRec, "login_id", loginId)
 		c.LogAuditWithUserId(id, "attempt - login_id="+loginId)
 
+		// A device trusted on a previous login holds a token in place of an MFA code.
+		if cookie, cookieErr := r.Cookie(model.MfaTrustedDeviceCookie); mfaToken == "" && cookieErr == nil {
+			mfaToken = model.MfaTrustedDeviceMfaToken(cookie.Value, deviceId)
+		}
+
 		user, err = c.App.AuthenticateUserForLogin(c.AppContext, id, loginId, password, mfaToken, "", ldapOnly)
 		if err != nil {
 			c.LogAuditWithUserId(id, "failure - login_id="+loginId)
@@ -2106,6 +2222,28 @@ func login(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	c.AppContext = c.AppContext.WithSession(session)
 
+	if _, ok := model.WebAuthnAssertionFromMfaToken(mfaToken); ok && user.MfaActive {
+		auditRec.AddMeta("mfa_method", model.SessionPropMfaMethodWebAuthn)
+		c.App.MarkSessionMfaMethod(c.AppContext, session, model.SessionPropMfaMethodWebAuthn)
+	} else if _, ok := model.NormalizeMfaRecoveryCode(mfaToken); ok && user.MfaActive {
+		auditRec.AddMeta("mfa_method", model.SessionPropMfaMethodRecoveryCode)
+		c.App.MarkSessionMfaMethod(c.AppContext, session, model.SessionPropMfaMethodRecoveryCode)
+	}
+
+	if _, _, ok := model.MfaTrustedDeviceFromMfaToken(mfaToken); ok && user.MfaActive {
+		auditRec.AddMeta("mfa_method", model.SessionPropMfaMethodTrustedDevice)
+		c.App.MarkSessionMfaMethod(c.AppContext, session, model.SessionPropMfaMethodTrustedDevice)
+	} else if trustDevice && user.MfaActive && *c.App.Config().ServiceSettings.MfaTrustedDeviceDays > 0 &&
+		// A device trusted after entering an authenticator code must not stand in for a security key.
+		(!*c.App.Config().ServiceSettings.EnableWebAuthn || !*c.App.Config().ServiceSettings.EnforceWebAuthn || session.Props[model.SessionPropMfaMethod] != "") {
+		device, token, appErr := c.App.TrustMfaDevice(c.AppContext, user.Id, deviceId)
+		if appErr != nil {
+			c.Err = appErr
+			return
+		}
+		c.App.AttachMfaTrustedDeviceCookie(w, r, device, token)
+	}
+
 	c.LogAuditWithUserId(user.Id, "success")
 
 	if r.Header.Get(model.HeaderRequestedWith) == model.HeaderRequestedWithXML {
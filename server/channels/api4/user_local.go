@@ -22,6 +22,7 @@ func (api *API) InitUserLocal() {
 	api.BaseRoutes.Users.Handle("", api.APILocal(localPermanentDeleteAllUsers)).Methods(http.MethodDelete)
 	api.BaseRoutes.Users.Handle("", api.APILocal(createUser)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/password/reset/send", api.APILocal(sendPasswordReset)).Methods(http.MethodPost)
+	api.BaseRoutes.Users.Handle("/password/hashers", api.APILocal(getPasswordHasherUsage)).Methods(http.MethodGet)
 	api.BaseRoutes.Users.Handle("/ids", api.APILocal(localGetUsersByIds)).Methods(http.MethodPost)
 
 	api.BaseRoutes.User.Handle("", api.APILocal(localGetUser)).Methods(http.MethodGet)
@@ -0,0 +1,184 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"testing"
+	"time"
+
+	"github.com/dgryski/dgoogauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func setupMfaRecovery(t *testing.T) *TestHelper {
+	th := Setup(t).InitBasic(t)
+	th.App.Srv().SetLicense(model.NewTestLicense("mfa"))
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.EnableMfaRecoveryCodes = true
+		*cfg.ServiceSettings.MfaTrustedDeviceDays = 30
+	})
+	return th
+}
+
+// activateMfa logs in as a new user and activates MFA through the API.
+func activateMfa(t *testing.T, th *TestHelper) (*model.User, *model.Client4) {
+	t.Helper()
+
+	user := th.CreateUser(t)
+	client := th.CreateClient()
+	_, _, err := client.Login(context.Background(), user.Email, user.Password)
+	require.NoError(t, err)
+
+	secret, _, err := client.GenerateMfaSecret(context.Background(), user.Id)
+	require.NoError(t, err)
+
+	code := dgoogauth.ComputeCode(secret.Secret, time.Now().UTC().Unix()/30)
+	_, err = client.UpdateUserMfa(context.Background(), user.Id, fmt.Sprintf("%06d", code), true)
+	require.NoError(t, err)
+
+	return user, client
+}
+
+// enforceWebAuthn requires sessions to verify a security key or passkey for the rest of the test.
+func enforceWebAuthn(t *testing.T, th *TestHelper) {
+	t.Helper()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnforceMultifactorAuthentication = true
+		*cfg.ServiceSettings.EnableWebAuthn = true
+		*cfg.ServiceSettings.EnforceWebAuthn = true
+	})
+	t.Cleanup(func() {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnforceMultifactorAuthentication = false
+			*cfg.ServiceSettings.EnableWebAuthn = false
+			*cfg.ServiceSettings.EnforceWebAuthn = false
+		})
+	})
+}
+
+func TestMfaRecoveryCodes(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupMfaRecovery(t)
+
+	user, client := activateMfa(t, th)
+
+	recoveryCodes, _, err := client.GenerateMfaRecoveryCodes(context.Background(), user.Id)
+	require.NoError(t, err)
+	require.Len(t, recoveryCodes.RecoveryCodes, model.MfaRecoveryCodeCount)
+
+	t.Run("login", func(t *testing.T) {
+		ruser, _, err := th.CreateClient().LoginWithMFA(context.Background(), user.Email, user.Password, recoveryCodes.RecoveryCodes[0])
+		require.NoError(t, err)
+		assert.Equal(t, user.Id, ruser.Id)
+
+		_, _, err = th.CreateClient().LoginWithMFA(context.Background(), user.Email, user.Password, recoveryCodes.RecoveryCodes[0])
+		require.Error(t, err)
+		CheckErrorID(t, err, "api.user.check_user_mfa.bad_code.app_error")
+	})
+
+	t.Run("satisfies enforced webauthn", func(t *testing.T) {
+		enforceWebAuthn(t, th)
+
+		loginClient := th.CreateClient()
+		_, _, err := loginClient.LoginWithMFA(context.Background(), user.Email, user.Password, recoveryCodes.RecoveryCodes[1])
+		require.NoError(t, err)
+
+		_, _, err = loginClient.GetTeamsForUser(context.Background(), user.Id, "")
+		require.NoError(t, err)
+	})
+
+	t.Run("only for oneself", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GenerateMfaRecoveryCodes(context.Background(), user.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("requires active mfa", func(t *testing.T) {
+		_, resp, err := th.Client.GenerateMfaRecoveryCodes(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMfaRecoveryCodes = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMfaRecoveryCodes = true })
+
+		_, resp, err := client.GenerateMfaRecoveryCodes(context.Background(), user.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+}
+
+func TestMfaTrustedDevices(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupMfaRecovery(t)
+
+	user, client := activateMfa(t, th)
+	recoveryCodes, _, err := client.GenerateMfaRecoveryCodes(context.Background(), user.Id)
+	require.NoError(t, err)
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	deviceClient := th.CreateClient()
+	deviceClient.HTTPClient.Jar = jar
+
+	resp, err := deviceClient.DoAPIPostJSON(context.Background(), "/users/login", map[string]string{
+		"login_id":     user.Email,
+		"password":     user.Password,
+		"token":        recoveryCodes.RecoveryCodes[0],
+		"trust_device": "true",
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp.Body.Close()
+
+	t.Run("login without code", func(t *testing.T) {
+		ruser, _, err := deviceClient.Login(context.Background(), user.Email, user.Password)
+		require.NoError(t, err)
+		assert.Equal(t, user.Id, ruser.Id)
+
+		_, _, err = th.CreateClient().Login(context.Background(), user.Email, user.Password)
+		require.Error(t, err)
+		CheckErrorID(t, err, "mfa.validate_token.authenticate.app_error")
+	})
+
+	t.Run("satisfies enforced webauthn", func(t *testing.T) {
+		enforceWebAuthn(t, th)
+
+		_, _, err := deviceClient.Login(context.Background(), user.Email, user.Password)
+		require.NoError(t, err)
+
+		_, _, err = deviceClient.GetTeamsForUser(context.Background(), user.Id, "")
+		require.NoError(t, err)
+	})
+
+	t.Run("bound to the device", func(t *testing.T) {
+		_, _, err := deviceClient.LoginWithDevice(context.Background(), user.Email, user.Password, "android_rn-v2:"+model.NewId())
+		require.Error(t, err)
+		CheckErrorID(t, err, "mfa.validate_token.authenticate.app_error")
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		resp, err := th.Client.RevokeMfaTrustedDevices(context.Background(), user.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.SystemAdminClient.RevokeMfaTrustedDevices(context.Background(), user.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		_, _, err = deviceClient.Login(context.Background(), user.Email, user.Password)
+		require.Error(t, err)
+		CheckErrorID(t, err, "mfa.validate_token.authenticate.app_error")
+	})
+}
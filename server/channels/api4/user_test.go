@@ -2246,6 +2246,29 @@ func TestGetTotalUsersStat(t *testing.T) {
 	require.Equal(t, total, rstats.TotalUsersCount)
 }
 
+func TestGetPasswordHasherUsage(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	_, resp, err := th.Client.GetPasswordHasherUsage(context.Background())
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		usages, _, err := client.GetPasswordHasherUsage(context.Background())
+		require.NoError(t, err)
+
+		var users int64
+		for _, usage := range usages {
+			if usage.Hasher == "pbkdf2" && usage.Parameters == "f=SHA256,w=600000,l=32" {
+				assert.False(t, usage.Outdated)
+				users += usage.Users
+			}
+		}
+		assert.GreaterOrEqual(t, users, int64(3))
+	})
+}
+
 func TestUpdateUser(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t)
@@ -9570,3 +9593,68 @@ func TestSearchUsersWithMfaEnforced(t *testing.T) {
 		CheckForbiddenStatus(t, resp)
 	})
 }
+
+func TestScopedUserAccessToken(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	_, appErr := th.App.UpdateUserRoles(th.Context, th.BasicUser.Id, model.SystemUserRoleId+" "+model.SystemUserAccessTokenRoleId, false)
+	require.Nil(t, appErr)
+
+	t.Run("unknown scope", func(t *testing.T) {
+		_, resp, err := th.Client.CreateScopedUserAccessToken(context.Background(), th.BasicUser.Id, "test token", []string{"posts:admin"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	token, _, err := th.Client.CreateScopedUserAccessToken(context.Background(), th.BasicUser.Id, "read-only token", []string{model.OAuthScopePostsRead})
+	require.NoError(t, err)
+	require.Equal(t, model.StringArray{model.OAuthScopePostsRead}, token.Scopes)
+
+	client := th.CreateClient()
+	client.AuthToken = token.Token
+
+	t.Run("allowed by the scope", func(t *testing.T) {
+		_, _, err := client.GetPost(context.Background(), th.BasicPost.Id, "")
+		require.NoError(t, err)
+	})
+
+	t.Run("denied by the scope", func(t *testing.T) {
+		_, resp, err := client.CreatePost(context.Background(), &model.Post{ChannelId: th.BasicChannel.Id, Message: "hello"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "api.context.oauth_scope.app_error")
+
+		_, resp, err = client.GetMe(context.Background(), "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("endpoint without a scope", func(t *testing.T) {
+		_, resp, err := client.CreateUserAccessToken(context.Background(), th.BasicUser.Id, "another token")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("endpoint not requiring a session", func(t *testing.T) {
+		_, resp, err := client.PostLog(context.Background(), map[string]string{"level": "ERROR", "message": "test"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "api.context.oauth_scope.app_error")
+	})
+
+	t.Run("still subject to permissions", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel(t)
+		_, err := th.SystemAdminClient.RemoveUserFromChannel(context.Background(), privateChannel.Id, th.BasicUser.Id)
+		require.NoError(t, err)
+		post, _, err := th.SystemAdminClient.CreatePost(context.Background(), &model.Post{ChannelId: privateChannel.Id, Message: "private"})
+		require.NoError(t, err)
+
+		_, resp, err := client.GetPost(context.Background(), post.Id, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
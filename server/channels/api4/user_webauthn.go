@@ -0,0 +1,204 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+)
+
+// requireWebAuthnUserAccess checks that the session may manage the security keys and passkeys of
+// the user in the request.
+func requireWebAuthnUserAccess(c *Context) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if c.AppContext.Session().IsOAuth {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+}
+
+func getWebAuthnCredentials(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireWebAuthnUserAccess(c)
+	if c.Err != nil {
+		return
+	}
+
+	credentials, appErr := c.App.GetWebAuthnCredentials(c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(credentials); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func beginWebAuthnRegistration(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireWebAuthnUserAccess(c)
+	if c.Err != nil {
+		return
+	}
+
+	// Only users themselves hold their authenticators.
+	if c.AppContext.Session().UserId != c.Params.UserId {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	options, appErr := c.App.BeginWebAuthnRegistration(c.AppContext, c.Params.UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func finishWebAuthnRegistration(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireWebAuthnUserAccess(c)
+	if c.Err != nil {
+		return
+	}
+
+	if c.AppContext.Session().UserId != c.Params.UserId {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	var registration model.WebAuthnRegistrationRequest
+	if err := json.NewDecoder(r.Body).Decode(&registration); err != nil || registration.Credential == nil {
+		c.SetInvalidParamWithErr("credential", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRegisterWebAuthnCredential, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+
+	credential, appErr := c.App.FinishWebAuthnRegistration(c.AppContext, c.Params.UserId, &registration)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("credential_id", credential.Id)
+	c.LogAudit("success - webauthn credential registered")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(credential); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteWebAuthnCredential(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireWebAuthnUserAccess(c)
+	if c.Err != nil {
+		return
+	}
+
+	credentialID := mux.Vars(r)["credential_id"]
+	if !model.IsValidId(credentialID) {
+		c.SetInvalidURLParam("credential_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteWebAuthnCredential, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+	model.AddEventParameterToAuditRec(auditRec, "credential_id", credentialID)
+
+	if appErr := c.App.DeleteWebAuthnCredential(c.AppContext, c.Params.UserId, credentialID); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("success - webauthn credential deleted")
+
+	ReturnStatusOK(w)
+}
+
+func beginWebAuthnLogin(c *Context, w http.ResponseWriter, r *http.Request) {
+	props := model.MapFromJSON(r.Body)
+
+	options, appErr := c.App.BeginWebAuthnLogin(c.AppContext, props["login_id"])
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func loginWithWebAuthn(c *Context, w http.ResponseWriter, r *http.Request) {
+	var loginRequest model.WebAuthnLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&loginRequest); err != nil || loginRequest.Credential == nil {
+		c.SetInvalidParamWithErr("credential", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventLoginWithWebAuthn, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("login_method", "webauthn")
+	model.AddEventParameterToAuditRec(auditRec, "device_id", loginRequest.DeviceId)
+
+	user, appErr := c.App.AuthenticateUserForWebAuthn(c.AppContext, loginRequest.Credential)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.AddEventResultState(user)
+
+	if user.IsGuest() && (c.App.Channels().License() == nil || !*c.App.Config().GuestAccountsSettings.Enable) {
+		c.Err = model.NewAppError("loginWithWebAuthn", "api.user.login.guest_accounts.disabled.error", nil, "", http.StatusUnauthorized)
+		return
+	}
+
+	if user.IsRemote() {
+		c.Err = model.NewAppError("loginWithWebAuthn", "api.user.login.remote_users.login.error", nil, "", http.StatusUnauthorized)
+		return
+	}
+
+	session, appErr := c.App.DoLogin(c.AppContext, w, r, user, loginRequest.DeviceId, utils.IsMobileRequest(r), false, false)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	c.AppContext = c.AppContext.WithSession(session)
+	c.App.MarkSessionMfaMethod(c.AppContext, session, model.SessionPropMfaMethodWebAuthn)
+
+	c.App.AttachSessionCookies(c.AppContext, w, r)
+
+	auditRec.Success()
+	c.LogAuditWithUserId(user.Id, "success")
+
+	user.Sanitize(map[string]bool{})
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa/webauthntest"
+)
+
+const webAuthnTestSiteURL = "http://localhost:8065"
+
+func setupWebAuthn(t *testing.T) *TestHelper {
+	th := Setup(t).InitBasic(t)
+	th.App.Srv().SetLicense(model.NewTestLicense("mfa"))
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SiteURL = webAuthnTestSiteURL
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.EnableWebAuthn = true
+	})
+	return th
+}
+
+func registerWebAuthnCredential(t *testing.T, client *model.Client4, authenticator *webauthntest.Authenticator, userID string) *model.WebAuthnCredential {
+	t.Helper()
+
+	options, _, err := client.BeginWebAuthnRegistration(context.Background(), userID)
+	require.NoError(t, err)
+
+	attestation, err := authenticator.Create(options)
+	require.NoError(t, err)
+
+	credential, resp, err := client.FinishWebAuthnRegistration(context.Background(), userID, &model.WebAuthnRegistrationRequest{
+		Name:       "Security key",
+		Credential: attestation,
+	})
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+
+	return credential
+}
+
+func TestWebAuthnCredentials(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupWebAuthn(t)
+
+	authenticator := webauthntest.NewAuthenticator(webAuthnTestSiteURL)
+	credential := registerWebAuthnCredential(t, th.Client, authenticator, th.BasicUser.Id)
+
+	t.Run("list", func(t *testing.T) {
+		credentials, _, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+		assert.Equal(t, credential.Id, credentials[0].Id)
+
+		_, resp, err := th.Client.GetWebAuthnCredentials(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		credentials, _, err = th.SystemAdminClient.GetWebAuthnCredentials(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+	})
+
+	t.Run("cannot register for another user", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebAuthn = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebAuthn = true })
+
+		_, resp, err := th.Client.BeginWebAuthnRegistration(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		user := th.CreateUser(t)
+		client := th.CreateClient()
+		_, _, err := client.Login(context.Background(), user.Email, user.Password)
+		require.NoError(t, err)
+
+		other := registerWebAuthnCredential(t, client, webauthntest.NewAuthenticator(webAuthnTestSiteURL), user.Id)
+
+		resp, err := th.Client.DeleteWebAuthnCredential(context.Background(), user.Id, other.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.SystemAdminClient.DeleteWebAuthnCredential(context.Background(), user.Id, other.Id)
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+
+		ruser, _, err := th.SystemAdminClient.GetUser(context.Background(), user.Id, "")
+		require.NoError(t, err)
+		assert.False(t, ruser.MfaActive)
+	})
+}
+
+func TestLoginWithWebAuthn(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupWebAuthn(t)
+
+	user := th.CreateUser(t)
+	client := th.CreateClient()
+	_, _, err := client.Login(context.Background(), user.Email, user.Password)
+	require.NoError(t, err)
+
+	authenticator := webauthntest.NewAuthenticator(webAuthnTestSiteURL)
+	registerWebAuthnCredential(t, client, authenticator, user.Id)
+
+	t.Run("second factor", func(t *testing.T) {
+		_, _, err := th.CreateClient().Login(context.Background(), user.Email, user.Password)
+		require.Error(t, err)
+
+		options, _, err := th.Client.BeginWebAuthnLogin(context.Background(), user.Email)
+		require.NoError(t, err)
+		require.Len(t, options.AllowCredentials, 1)
+
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		loginClient := th.CreateClient()
+		ruser, _, err := loginClient.LoginWithMFA(context.Background(), user.Email, user.Password, assertion.ToMfaToken())
+		require.NoError(t, err)
+		assert.Equal(t, user.Id, ruser.Id)
+
+		session, appErr := th.App.GetSession(loginClient.AuthToken)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.SessionPropMfaMethodWebAuthn, session.Props[model.SessionPropMfaMethod])
+	})
+
+	t.Run("passwordless disabled", func(t *testing.T) {
+		options, _, err := th.Client.BeginWebAuthnLogin(context.Background(), "")
+		require.NoError(t, err)
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		_, resp, err := th.CreateClient().LoginWithWebAuthn(context.Background(), &model.WebAuthnLoginRequest{Credential: assertion})
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	t.Run("passwordless", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebAuthnPasswordless = true })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebAuthnPasswordless = false })
+
+		options, _, err := th.Client.BeginWebAuthnLogin(context.Background(), "")
+		require.NoError(t, err)
+		assert.Empty(t, options.AllowCredentials)
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		loginClient := th.CreateClient()
+		ruser, _, err := loginClient.LoginWithWebAuthn(context.Background(), &model.WebAuthnLoginRequest{Credential: assertion})
+		require.NoError(t, err)
+		assert.Equal(t, user.Id, ruser.Id)
+
+		_, _, err = loginClient.GetMe(context.Background(), "")
+		require.NoError(t, err)
+	})
+
+	t.Run("enforced", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnforceMultifactorAuthentication = true
+			*cfg.ServiceSettings.EnforceWebAuthn = true
+		})
+		defer th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnforceMultifactorAuthentication = false
+			*cfg.ServiceSettings.EnforceWebAuthn = false
+		})
+
+		// A session not verified with a security key or passkey.
+		session, appErr := th.App.CreateSession(th.Context, &model.Session{UserId: user.Id, Roles: user.GetRawRoles()})
+		require.Nil(t, appErr)
+		sessionClient := th.CreateClient()
+		sessionClient.SetToken(session.Token)
+
+		_, resp, err := sessionClient.GetTeamsForUser(context.Background(), user.Id, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+		CheckErrorID(t, err, "api.context.webauthn_required.app_error")
+
+		options, _, err := th.Client.BeginWebAuthnLogin(context.Background(), user.Email)
+		require.NoError(t, err)
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		loginClient := th.CreateClient()
+		_, _, err = loginClient.LoginWithMFA(context.Background(), user.Email, user.Password, assertion.ToMfaToken())
+		require.NoError(t, err)
+
+		_, _, err = loginClient.GetTeamsForUser(context.Background(), user.Id, "")
+		require.NoError(t, err)
+	})
+}
@@ -55,6 +55,12 @@ func (api *API) InitWebSocket() {
 }
 
 func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
+	// WebSocket events aren't filtered by OAuth scope, so scoped sessions can't subscribe to them.
+	if len(c.AppContext.Session().GetOAuthScopes()) > 0 {
+		c.Err = model.NewAppError("connectWebSocket", "api.web_socket.connect.oauth_scope.app_error", nil, "", http.StatusForbidden)
+		return
+	}
+
 	upgrader := websocket.Upgrader{
 		ReadBufferSize:  model.SocketMaxMessageSizeKb,
 		WriteBufferSize: model.SocketMaxMessageSizeKb,
@@ -0,0 +1,227 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const accessReportMaxOwnedObjects = 200
+
+// StartAccessReportExport creates a job generating the access review
+// report. The report is sent to the requesting user once finished and
+// can be downloaded through the job.
+func (a *App) StartAccessReportExport(rctx request.CTX, jobData map[string]string) (*model.Job, *model.AppError) {
+	format := jobData["format"]
+	if format == "" {
+		format = "csv"
+	}
+	if !model.IsValidAccessReportExportFormat(format) {
+		return nil, model.NewAppError("StartAccessReportExport", "app.report.start_access_report_export.invalid_format", nil, "format="+format, http.StatusBadRequest)
+	}
+
+	inactiveDays := model.AccessReportDefaultInactiveDays
+	if val := jobData["inactive_days"]; val != "" {
+		var err error
+		if inactiveDays, err = strconv.Atoi(val); err != nil || inactiveDays < 0 {
+			return nil, model.NewAppError("StartAccessReportExport", "app.report.start_access_report_export.invalid_inactive_days", nil, "inactive_days="+val, http.StatusBadRequest)
+		}
+	}
+
+	data := map[string]string{
+		"format":         format,
+		"inactive_days":  strconv.Itoa(inactiveDays),
+		"inactive_since": strconv.FormatInt(time.Now().AddDate(0, 0, -inactiveDays).UnixMilli(), 10),
+	}
+	if session := rctx.Session(); session != nil && session.UserId != "" {
+		data["requesting_user_id"] = session.UserId
+	}
+
+	return a.Srv().Jobs.CreateJob(rctx, model.JobTypeExportAccessReport, data)
+}
+
+// GetUsersForAccessReport returns a batch of the access review report,
+// ordered by user ID and starting after options.FromUserId.
+func (a *App) GetUsersForAccessReport(rctx request.CTX, options *model.AccessReportOptions) ([]*model.UserAccessReport, *model.AppError) {
+	if appErr := options.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	users, err := a.Srv().Store().User().GetAllAfter(options.PageSize, options.FromUserId)
+	if err != nil {
+		return nil, model.NewAppError("GetUsersForAccessReport", "app.report.get_access_report.store_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	reports := make([]*model.UserAccessReport, 0, len(users))
+	for _, user := range users {
+		report, err := a.getUserAccessReport(rctx, user, options.InactiveSince)
+		if err != nil {
+			return nil, model.NewAppError("GetUsersForAccessReport", "app.report.get_access_report.store_error", nil, "user_id="+user.Id, http.StatusInternalServerError).Wrap(err)
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func (a *App) getUserAccessReport(rctx request.CTX, user *model.User, inactiveSince int64) (*model.UserAccessReport, error) {
+	report := &model.UserAccessReport{
+		UserId:       user.Id,
+		Username:     user.Username,
+		Email:        user.Email,
+		AuthService:  user.AuthService,
+		SystemRoles:  user.Roles,
+		DeleteAt:     user.DeleteAt,
+		Teams:        []*model.AccessReportMembership{},
+		Channels:     []*model.AccessReportMembership{},
+		AccessTokens: []*model.AccessReportToken{},
+		Bots:         []string{},
+		OAuthApps:    []string{},
+	}
+	effectiveRoles := strings.Fields(user.Roles)
+
+	teamMembers, err := a.Srv().Store().Team().GetTeamsForUser(rctx, user.Id, "", false)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := a.Srv().Store().Team().GetTeamsByUserId(user.Id)
+	if err != nil {
+		return nil, err
+	}
+	teamNames := make(map[string]string, len(teams))
+	for _, team := range teams {
+		teamNames[team.Id] = team.Name
+	}
+	for _, member := range teamMembers {
+		if member.DeleteAt != 0 {
+			continue
+		}
+		report.Teams = append(report.Teams, &model.AccessReportMembership{
+			Id:    member.TeamId,
+			Name:  teamNames[member.TeamId],
+			Roles: member.Roles,
+		})
+		effectiveRoles = append(effectiveRoles, strings.Fields(member.Roles)...)
+	}
+
+	channelRoles, err := a.Srv().Store().Channel().GetAllChannelMembersForUser(rctx, user.Id, false, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(channelRoles) > 0 {
+		channelIDs := make([]string, 0, len(channelRoles))
+		for channelID := range channelRoles {
+			channelIDs = append(channelIDs, channelID)
+		}
+		channels, err := a.Srv().Store().Channel().GetChannelsByIds(channelIDs, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, channel := range channels {
+			// direct and group messages don't grant access to anything
+			// beyond the conversation itself, so they are not reviewed
+			if channel.TeamId == "" {
+				continue
+			}
+			report.Channels = append(report.Channels, &model.AccessReportMembership{
+				Id:    channel.Id,
+				Name:  teamNames[channel.TeamId] + ":" + channel.Name,
+				Type:  string(channel.Type),
+				Roles: channelRoles[channel.Id],
+			})
+			effectiveRoles = append(effectiveRoles, strings.Fields(channelRoles[channel.Id])...)
+		}
+		sort.Slice(report.Channels, func(i, j int) bool {
+			return report.Channels[i].Name < report.Channels[j].Name
+		})
+	}
+
+	sort.Strings(effectiveRoles)
+	report.EffectiveRoles = slices.Compact(effectiveRoles)
+
+	tokens, err := a.Srv().Store().UserAccessToken().GetByUser(user.Id, 0, accessReportMaxOwnedObjects)
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokens {
+		reportToken := &model.AccessReportToken{
+			Id:          token.Id,
+			Description: token.Description,
+			IsActive:    token.IsActive,
+		}
+		// the session of a token lives as long as the token is active, so
+		// its last activity is the last time the token was used
+		session, err := a.Srv().Store().Session().Get(rctx, token.Token)
+		var nfErr *store.ErrNotFound
+		if err != nil && !errors.As(err, &nfErr) {
+			return nil, err
+		} else if err == nil {
+			reportToken.LastUsedAt = session.LastActivityAt
+		}
+		report.AccessTokens = append(report.AccessTokens, reportToken)
+	}
+
+	bots, err := a.Srv().Store().Bot().GetAll(&model.BotGetOptions{
+		OwnerId:        user.Id,
+		IncludeDeleted: true,
+		PerPage:        accessReportMaxOwnedObjects,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, bot := range bots {
+		report.Bots = append(report.Bots, bot.Username)
+	}
+
+	apps, err := a.Srv().Store().OAuth().GetAppByUser(user.Id, 0, accessReportMaxOwnedObjects)
+	if err != nil {
+		return nil, err
+	}
+	for _, oauthApp := range apps {
+		report.OAuthApps = append(report.OAuthApps, oauthApp.Name)
+	}
+
+	report.LastActivityAt = user.LastLogin
+	status, err := a.Srv().Store().Status().Get(user.Id)
+	var nfErr *store.ErrNotFound
+	if err != nil && !errors.As(err, &nfErr) {
+		return nil, err
+	} else if err == nil && status.LastActivityAt > report.LastActivityAt {
+		report.LastActivityAt = status.LastActivityAt
+	}
+	report.Inactive = user.DeleteAt != 0 || report.LastActivityAt < inactiveSince
+
+	return report, nil
+}
+
+// GetReportFileReader returns a reader for the compiled file of a
+// finished report job, together with its file name.
+func (a *App) GetReportFileReader(job *model.Job) (filestore.ReadCloseSeeker, string, *model.AppError) {
+	if job.Status != model.JobStatusSuccess {
+		return nil, "", model.NewAppError("GetReportFileReader", "app.report.get_report_file.not_finished", nil, "", http.StatusBadRequest)
+	}
+
+	format := job.Data["format"]
+	if format == "" {
+		format = "csv"
+	}
+
+	reader, appErr := a.FileReader(makeCompiledFilePath(job.Id, format))
+	if appErr != nil {
+		return nil, "", appErr
+	}
+
+	return reader, makeCompiledFilename(job.Id, format), nil
+}
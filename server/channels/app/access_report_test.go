@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestGetUserAccessReport(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	privateChannel := th.CreatePrivateChannel(t, th.BasicTeam)
+	th.AddUserToChannel(t, th.BasicUser, privateChannel)
+	_, appErr := th.App.UpdateChannelMemberRoles(th.Context, privateChannel.Id, th.BasicUser.Id, model.ChannelUserRoleId+" "+model.ChannelAdminRoleId)
+	require.Nil(t, appErr)
+
+	token, appErr := th.App.CreateUserAccessToken(th.Context, &model.UserAccessToken{
+		UserId:      th.BasicUser.Id,
+		Description: "dashboard",
+	})
+	require.Nil(t, appErr)
+
+	bot := th.CreateBot(t)
+
+	t.Run("should report memberships, tokens and owned bots", func(t *testing.T) {
+		report, err := th.App.getUserAccessReport(th.Context, th.BasicUser, 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, th.BasicUser.Id, report.UserId)
+		assert.Equal(t, th.BasicUser.Roles, report.SystemRoles)
+		assert.False(t, report.Inactive)
+
+		require.Len(t, report.Teams, 1)
+		assert.Equal(t, th.BasicTeam.Name, report.Teams[0].Name)
+
+		var privateMembership *model.AccessReportMembership
+		for _, membership := range report.Channels {
+			if membership.Id == privateChannel.Id {
+				privateMembership = membership
+			}
+		}
+		require.NotNil(t, privateMembership)
+		assert.Equal(t, th.BasicTeam.Name+":"+privateChannel.Name, privateMembership.Name)
+		assert.Equal(t, string(model.ChannelTypePrivate), privateMembership.Type)
+		assert.Contains(t, report.EffectiveRoles, model.ChannelAdminRoleId)
+		assert.Contains(t, report.EffectiveRoles, model.TeamUserRoleId)
+		assert.Contains(t, report.EffectiveRoles, model.SystemUserRoleId)
+
+		require.Len(t, report.AccessTokens, 1)
+		assert.Equal(t, token.Id, report.AccessTokens[0].Id)
+		assert.True(t, report.AccessTokens[0].IsActive)
+		assert.Zero(t, report.AccessTokens[0].LastUsedAt)
+
+		assert.Equal(t, []string{bot.Username}, report.Bots)
+	})
+
+	t.Run("should report users without recent activity as inactive", func(t *testing.T) {
+		report, err := th.App.getUserAccessReport(th.Context, th.BasicUser2, model.GetMillis()+1000)
+		require.NoError(t, err)
+		assert.True(t, report.Inactive)
+	})
+}
+
+func TestStartAccessReportExport(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+
+	t.Run("should reject an unknown format", func(t *testing.T) {
+		_, appErr := th.App.StartAccessReportExport(th.Context, map[string]string{"format": "xml"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.report.start_access_report_export.invalid_format", appErr.Id)
+	})
+
+	t.Run("should reject an invalid number of inactive days", func(t *testing.T) {
+		_, appErr := th.App.StartAccessReportExport(th.Context, map[string]string{"inactive_days": "-1"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.report.start_access_report_export.invalid_inactive_days", appErr.Id)
+	})
+
+	t.Run("should create the job with the default options", func(t *testing.T) {
+		job, appErr := th.App.StartAccessReportExport(th.Context, map[string]string{})
+		require.Nil(t, appErr)
+		assert.Equal(t, model.JobTypeExportAccessReport, job.Type)
+		assert.Equal(t, "csv", job.Data["format"])
+		assert.Equal(t, "90", job.Data["inactive_days"])
+		assert.NotEmpty(t, job.Data["inactive_since"])
+	})
+}
@@ -92,7 +92,7 @@ func (a *App) checkUserPassword(user *model.User, password string, invalidateCac
 	}
 
 	// Migrate the password if needed
-	if !hashers.IsLatestHasher(hasher) {
+	if hashers.NeedsMigration(hasher) {
 		return a.migratePassword(user, password)
 	}
 
@@ -312,6 +312,35 @@ func (a *App) CheckUserMfa(rctx request.CTX, user *model.User, token string) *mo
 		return model.NewAppError("CheckUserMfa", "mfa.mfa_disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
+	// A security key or passkey assertion may be given in place of a code.
+	if assertion, ok := model.WebAuthnAssertionFromMfaToken(token); ok {
+		if _, appErr := a.verifyWebAuthnAssertion(user.Id, assertion, false); appErr != nil {
+			if appErr.StatusCode == http.StatusInternalServerError {
+				return appErr
+			}
+			return model.NewAppError("checkUserMfa", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized).Wrap(appErr)
+		}
+
+		return nil
+	}
+
+	// So may one of the user's recovery codes, or the token of a device the user trusted.
+	if code, ok := model.NormalizeMfaRecoveryCode(token); ok && *a.Config().ServiceSettings.EnableMfaRecoveryCodes {
+		return a.checkMfaRecoveryCode(rctx, user, code)
+	}
+
+	if deviceToken, deviceID, ok := model.MfaTrustedDeviceFromMfaToken(token); ok {
+		if appErr := a.checkMfaTrustedDevice(user, deviceToken, deviceID); appErr != nil {
+			if appErr.StatusCode == http.StatusInternalServerError {
+				return appErr
+			}
+			// Have the client ask for a code, as if none was given.
+			return model.NewAppError("CheckUserMfa", "mfa.validate_token.authenticate.app_error", nil, "", http.StatusBadRequest).Wrap(appErr)
+		}
+
+		return nil
+	}
+
 	ok, err := mfa.New(a.Srv().Store().User()).ValidateToken(user, token)
 	if err != nil {
 		return model.NewAppError("CheckUserMfa", "mfa.validate_token.authenticate.app_error", nil, "", http.StatusBadRequest).Wrap(err)
@@ -370,6 +399,16 @@ func (a *App) MFARequired(rctx request.CTX) *model.AppError {
 		return model.NewAppError("MfaRequired", "api.context.mfa_required.app_error", nil, "", http.StatusForbidden)
 	}
 
+	// Sessions of integrations can't be verified with a security key or passkey. Recovery codes
+	// and trusted devices stand in for one, so users locked out of their keys can sign in.
+	if *a.Config().ServiceSettings.EnableWebAuthn && *a.Config().ServiceSettings.EnforceWebAuthn && !session.IsUserAccessToken() {
+		switch session.Props[model.SessionPropMfaMethod] {
+		case model.SessionPropMfaMethodWebAuthn, model.SessionPropMfaMethodRecoveryCode, model.SessionPropMfaMethodTrustedDevice:
+		default:
+			return model.NewAppError("MfaRequired", "api.context.webauthn_required.app_error", nil, "", http.StatusForbidden)
+		}
+	}
+
 	return nil
 }
 
@@ -721,6 +721,22 @@ func (a *App) UpdateChannel(rctx request.CTX, channel *model.Channel) (*model.Ch
 		return nil, model.NewAppError("UpdateChannel", "api.channel.update_channel.not_allowed.app_error", nil, "", http.StatusForbidden)
 	}
 
+	// the cache holds copies, so this is the channel as it was before the caller modified it.
+	oldChannel, appErr := a.GetChannel(rctx, channel.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var rejectionReason string
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.ChannelWillBeUpdated(pluginContext, channel, oldChannel)
+		return rejectionReason == ""
+	}, plugin.ChannelWillBeUpdatedID)
+	if rejectionReason != "" {
+		return nil, model.NewAppError("UpdateChannel", "app.channel.update.rejected_by_plugin", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
 	_, err := a.Srv().Store().Channel().Update(rctx, channel)
 	if err != nil {
 		var appErr *model.AppError
@@ -748,6 +764,14 @@ func (a *App) UpdateChannel(rctx request.CTX, channel *model.Channel) (*model.Ch
 	messageWs.Add("channel", string(channelJSON))
 	a.Publish(messageWs)
 
+	updatedChannel := channel.DeepCopy()
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.ChannelHasBeenUpdated(pluginContext, updatedChannel, oldChannel)
+			return true
+		}, plugin.ChannelHasBeenUpdatedID)
+	})
+
 	return channel, nil
 }
 
@@ -863,12 +887,33 @@ func (a *App) RestoreChannel(rctx request.CTX, channel *model.Channel, userID st
 		return nil, model.NewAppError("restoreChannel", "api.channel.restore_channel.restored.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	oldChannel := channel.DeepCopy()
+	restoredChannel := channel.DeepCopy()
+	restoredChannel.DeleteAt = 0
+
+	var rejectionReason string
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.ChannelWillBeUpdated(pluginContext, restoredChannel, oldChannel)
+		return rejectionReason == ""
+	}, plugin.ChannelWillBeUpdatedID)
+	if rejectionReason != "" {
+		return nil, model.NewAppError("RestoreChannel", "app.channel.update.rejected_by_plugin", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
 	if err := a.Srv().Store().Channel().Restore(channel.Id, model.GetMillis()); err != nil {
 		return nil, model.NewAppError("RestoreChannel", "app.channel.restore.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 	channel.DeleteAt = 0
 	a.Srv().Platform().InvalidateCacheForChannel(channel)
 
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.ChannelHasBeenUpdated(pluginContext, restoredChannel, oldChannel)
+			return true
+		}, plugin.ChannelHasBeenUpdatedID)
+	})
+
 	var message *model.WebSocketEvent
 	if channel.Type == model.ChannelTypeOpen {
 		message = model.NewWebSocketEvent(model.WebsocketEventChannelRestored, channel.TeamId, "", "", nil, "")
@@ -1604,6 +1649,16 @@ func (a *App) DeleteChannel(rctx request.CTX, channel *model.Channel, userID str
 		return err
 	}
 
+	var rejectionReason string
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.ChannelWillBeArchived(pluginContext, channel, user)
+		return rejectionReason == ""
+	}, plugin.ChannelWillBeArchivedID)
+	if rejectionReason != "" {
+		return model.NewAppError("DeleteChannel", "app.channel.delete.rejected_by_plugin", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
 	if user != nil {
 		T := i18n.GetUserTranslations(user.Locale)
 
@@ -1677,6 +1732,15 @@ func (a *App) DeleteChannel(rctx request.CTX, channel *model.Channel, userID str
 	message.Add("delete_at", deleteAt)
 	a.Publish(message)
 
+	archivedChannel := channel.DeepCopy()
+	archivedChannel.DeleteAt = deleteAt
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.ChannelHasBeenArchived(pluginContext, archivedChannel, user)
+			return true
+		}, plugin.ChannelHasBeenArchivedID)
+	})
+
 	return nil
 }
 
@@ -0,0 +1,313 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"io"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const channelExportBatchSize = 1000
+
+// BulkExportChannel writes an archive with a single channel, its
+// members, its history and, optionally, the files attached to its
+// posts. The archive uses the bulk import format, so it can be
+// processed by any import, and it is meant to be imported into another
+// server with BulkImportChannel.
+func (a *App) BulkExportChannel(rctx request.CTX, writer io.Writer, job *model.Job, channelID string, opts model.BulkExportOpts) *model.AppError {
+	channel, appErr := a.GetChannel(rctx, channelID)
+	if appErr != nil {
+		return appErr
+	}
+	if channel.TeamId == "" {
+		return model.NewAppError("BulkExportChannel", "app.export.channel_export.direct_channel.app_error", nil, "channel_id="+channelID, http.StatusBadRequest)
+	}
+	team, appErr := a.GetTeam(channel.TeamId)
+	if appErr != nil {
+		return appErr
+	}
+
+	if job == nil {
+		job = &model.Job{
+			Data: make(model.StringMap),
+		}
+	} else if job.Data == nil {
+		job.Data = make(model.StringMap)
+	}
+
+	zipWr := zip.NewWriter(writer)
+	defer func() {
+		if err := zipWr.Close(); err != nil {
+			rctx.Logger().Error("Error closing zip writer", mlog.Err(err))
+		}
+	}()
+	writer, err := zipWr.Create("import.jsonl")
+	if err != nil {
+		return model.NewAppError("BulkExportChannel", "app.export.zip_create.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	rctx.Logger().Info("Channel export: exporting version")
+	if appErr = a.exportVersion(writer); appErr != nil {
+		return appErr
+	}
+
+	// Schemes are not part of the archive, so the team and the channel
+	// use the default scheme of the server they are imported into.
+	rctx.Logger().Info("Channel export: exporting team and channel")
+	if appErr = a.exportWriteLine(writer, importLineFromTeam(&model.TeamForExport{Team: *team})); appErr != nil {
+		return appErr
+	}
+	if appErr = a.exportWriteLine(writer, importLineFromChannel(&model.ChannelForExport{Channel: *channel, TeamName: team.Name})); appErr != nil {
+		return appErr
+	}
+
+	rctx.Logger().Info("Channel export: exporting users")
+	users, appErr := a.exportChannelUsers(rctx, job, writer, team, channel)
+	if appErr != nil {
+		return appErr
+	}
+
+	rctx.Logger().Info("Channel export: exporting posts")
+	attachments, appErr := a.exportChannelPosts(rctx, job, writer, team, channel, users, opts.IncludeAttachments)
+	if appErr != nil {
+		return appErr
+	}
+
+	if opts.IncludeAttachments {
+		rctx.Logger().Info("Channel export: exporting file attachments")
+		warnings, appErr := a.exportAttachments(rctx, attachments, "", zipWr)
+		if appErr != nil {
+			return appErr
+		}
+
+		if len(warnings) > 0 {
+			warningsFile, _ := zipWr.Create(warningsFilename)
+			for _, warning := range warnings {
+				if _, err := warningsFile.Write([]byte(warning + "\n")); err != nil {
+					return model.NewAppError("BulkExportChannel", "app.export.zip_create.error", nil, "", http.StatusInternalServerError).Wrap(err)
+				}
+			}
+			updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "num_warnings", len(warnings))
+		}
+
+		updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "attachments_exported", len(attachments))
+	}
+
+	return nil
+}
+
+// exportChannelUsers writes a user line for the members of the channel
+// and for everyone who has posted or reacted in it. Only the membership
+// of the exported team and channel is included. The users are returned
+// by ID.
+func (a *App) exportChannelUsers(rctx request.CTX, job *model.Job, writer io.Writer, team *model.Team, channel *model.Channel) (map[string]*model.User, *model.AppError) {
+	members := map[string]*model.ChannelMember{}
+	for offset := 0; ; offset += channelExportBatchSize {
+		page, err := a.Srv().Store().Channel().GetMembers(model.ChannelMembersGetOptions{
+			ChannelID: channel.Id,
+			Offset:    offset,
+			Limit:     channelExportBatchSize,
+		})
+		if err != nil {
+			return nil, model.NewAppError("exportChannelUsers", "app.channel.get_members.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for i := range page {
+			members[page[i].UserId] = &page[i]
+		}
+		if len(page) < channelExportBatchSize {
+			break
+		}
+	}
+
+	userIDs := map[string]bool{}
+	for userID := range members {
+		userIDs[userID] = true
+	}
+	err := a.forEachChannelPost(channel.Id, func(post *model.Post) *model.AppError {
+		userIDs[post.UserId] = true
+		if !post.HasReactions {
+			return nil
+		}
+		reactions, err := a.Srv().Store().Reaction().GetForPost(post.Id, false)
+		if err != nil {
+			return model.NewAppError("exportChannelUsers", "app.reaction.get_for_post.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, reaction := range reactions {
+			userIDs[reaction.UserId] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(userIDs))
+	for userID := range userIDs {
+		ids = append(ids, userID)
+	}
+
+	users := make(map[string]*model.User, len(ids))
+	cnt := 0
+	for start := 0; start < len(ids); start += channelExportBatchSize {
+		end := min(start+channelExportBatchSize, len(ids))
+		page, nErr := a.Srv().Store().User().GetProfileByIds(rctx, ids[start:end], &store.UserGetByIdsOpts{}, false)
+		if nErr != nil {
+			return nil, model.NewAppError("exportChannelUsers", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+		}
+
+		for _, user := range page {
+			users[user.Id] = user
+
+			// Bots are not exported, their content is imported only if a
+			// bot with the same username exists on the other server.
+			if user.IsBot {
+				continue
+			}
+
+			userLine := importLineFromUser(user, nil)
+			// System roles are not carried over, users created by the
+			// import must be granted them explicitly.
+			roles := model.SystemUserRoleId
+			if user.IsGuest() {
+				roles = model.SystemGuestRoleId
+			}
+			userLine.User.Roles = &roles
+
+			if member, ok := members[user.Id]; ok {
+				teamMember, nErr := a.Srv().Store().Team().GetMember(rctx, team.Id, user.Id)
+				if nErr != nil {
+					return nil, model.NewAppError("exportChannelUsers", "app.team.get_member.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+				}
+
+				teamData := importUserTeamDataFromTeamMember(&model.TeamMemberForExport{TeamMember: *teamMember, TeamName: team.Name})
+				channelData := importUserChannelDataFromChannelMemberAndPreferences(&model.ChannelMemberForExport{
+					ChannelMember: *member,
+					ChannelName:   channel.Name,
+					Username:      user.Username,
+				}, &model.Preferences{})
+				teamData.Channels = &[]imports.UserChannelImportData{*channelData}
+				userLine.User.Teams = &[]imports.UserTeamImportData{*teamData}
+			}
+
+			if appErr := a.exportWriteLine(writer, userLine); appErr != nil {
+				return nil, appErr
+			}
+			cnt++
+		}
+	}
+	updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "users_exported", cnt)
+
+	return users, nil
+}
+
+func (a *App) exportChannelPosts(rctx request.CTX, job *model.Job, writer io.Writer, team *model.Team, channel *model.Channel, users map[string]*model.User, withAttachments bool) ([]imports.AttachmentImportData, *model.AppError) {
+	var attachments []imports.AttachmentImportData
+	cnt := 0
+	err := a.forEachChannelPost(channel.Id, func(post *model.Post) *model.AppError {
+		// Replies are exported with their root post.
+		if post.RootId != "" {
+			return nil
+		}
+		author, ok := users[post.UserId]
+		if !ok {
+			rctx.Logger().Warn("Skipping post of a user that doesn't exist anymore", mlog.String("post_id", post.Id), mlog.String("user_id", post.UserId))
+			return nil
+		}
+
+		postForExport := &model.PostForExport{
+			TeamName:    team.Name,
+			ChannelName: channel.Name,
+			Username:    author.Username,
+		}
+		if err := post.ShallowCopy(&postForExport.Post); err != nil {
+			return model.NewAppError("exportChannelPosts", "app.export.marshal.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		postLine := importLineForPost(postForExport)
+		// Flags are personal, they are not carried over.
+		postLine.Post.FlaggedBy = nil
+
+		replies, replyAttachments, appErr := a.buildPostReplies(rctx, post.Id, withAttachments)
+		if appErr != nil {
+			return appErr
+		}
+		for i := range replies {
+			replies[i].FlaggedBy = nil
+		}
+		postLine.Post.Replies = &replies
+		attachments = append(attachments, replyAttachments...)
+
+		followers, appErr := a.buildThreadFollowers(rctx, post.Id)
+		if appErr != nil {
+			return appErr
+		}
+		if len(followers) > 0 {
+			postLine.Post.ThreadFollowers = &followers
+		}
+
+		postLine.Post.Reactions = &[]imports.ReactionImportData{}
+		if post.HasReactions {
+			if postLine.Post.Reactions, appErr = a.BuildPostReactions(rctx, post.Id); appErr != nil {
+				return appErr
+			}
+		}
+
+		if len(post.FileIds) > 0 {
+			postAttachments, appErr := a.buildPostAttachments(post.Id)
+			if appErr != nil {
+				return appErr
+			}
+			postLine.Post.Attachments = &postAttachments
+			if withAttachments {
+				attachments = append(attachments, postAttachments...)
+			}
+		}
+
+		if appErr := a.exportWriteLine(writer, postLine); appErr != nil {
+			return appErr
+		}
+
+		cnt++
+		if cnt%channelExportBatchSize == 0 {
+			updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "posts_exported", cnt)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "posts_exported", cnt)
+
+	return attachments, nil
+}
+
+// forEachChannelPost calls fn for every post of the channel that hasn't
+// been deleted, in the order they were last updated.
+func (a *App) forEachChannelPost(channelID string, fn func(post *model.Post) *model.AppError) *model.AppError {
+	options := model.GetPostsSinceForSyncOptions{ChannelId: channelID}
+	cursor := model.GetPostsSinceForSyncCursor{}
+	for {
+		posts, nextCursor, err := a.Srv().Store().Post().GetPostsSinceForSync(options, cursor, channelExportBatchSize)
+		if err != nil {
+			return model.NewAppError("forEachChannelPost", "app.post.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, post := range posts {
+			if appErr := fn(post); appErr != nil {
+				return appErr
+			}
+		}
+
+		if len(posts) < channelExportBatchSize {
+			return nil
+		}
+		cursor = nextCursor
+	}
+}
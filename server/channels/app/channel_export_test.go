@@ -0,0 +1,240 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func readChannelArchive(t *testing.T, b []byte) (*zip.Reader, []byte) {
+	t.Helper()
+
+	zipReader, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+
+	jsonFile, err := zipReader.Open("import.jsonl")
+	require.NoError(t, err)
+	defer jsonFile.Close()
+
+	jsonl, err := io.ReadAll(jsonFile)
+	require.NoError(t, err)
+
+	return zipReader, jsonl
+}
+
+func TestBulkExportChannel(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	channel := th.CreateChannel(t, th.BasicTeam)
+	th.AddUserToChannel(t, th.BasicUser2, channel)
+	root := th.CreatePost(t, channel)
+	_, _, appErr := th.App.CreatePost(th.Context, &model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: channel.Id,
+		RootId:    root.Id,
+		Message:   "reply",
+	}, channel, model.CreatePostFlags{})
+	require.Nil(t, appErr)
+	th.CreatePost(t, th.BasicChannel)
+
+	t.Run("should export the channel, its members and its posts", func(t *testing.T) {
+		var b bytes.Buffer
+		appErr := th.App.BulkExportChannel(th.Context, &b, nil, channel.Id, model.BulkExportOpts{})
+		require.Nil(t, appErr)
+
+		_, jsonl := readChannelArchive(t, b.Bytes())
+		lines := strings.Split(strings.TrimSpace(string(jsonl)), "\n")
+
+		assert.Contains(t, lines[0], `"type":"version"`)
+		assert.Contains(t, lines[1], `"type":"team"`)
+		assert.Contains(t, lines[2], `"type":"channel"`)
+		assert.Contains(t, string(jsonl), th.BasicUser.Email)
+		assert.Contains(t, string(jsonl), th.BasicUser2.Email)
+		assert.Contains(t, string(jsonl), root.Message)
+		assert.Contains(t, string(jsonl), `"message":"reply"`)
+		assert.NotContains(t, string(jsonl), th.BasicChannel.Name)
+		assert.NotContains(t, string(jsonl), model.SystemAdminRoleId)
+	})
+
+	t.Run("should not export direct channels", func(t *testing.T) {
+		dm := th.CreateDmChannel(t, th.BasicUser2)
+
+		var b bytes.Buffer
+		appErr := th.App.BulkExportChannel(th.Context, &b, nil, dm.Id, model.BulkExportOpts{})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.export.channel_export.direct_channel.app_error", appErr.Id)
+	})
+}
+
+func TestBulkImportChannel(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	channel := th.CreateChannel(t, th.BasicTeam)
+	th.AddUserToChannel(t, th.BasicUser2, channel)
+	post := th.CreatePost(t, channel)
+
+	var b bytes.Buffer
+	appErr := th.App.BulkExportChannel(th.Context, &b, nil, channel.Id, model.BulkExportOpts{})
+	require.Nil(t, appErr)
+	zipReader, jsonl := readChannelArchive(t, b.Bytes())
+
+	t.Run("should import the channel into another team and map the existing users", func(t *testing.T) {
+		team := th.CreateTeam(t)
+
+		line, appErr := th.App.BulkImportChannel(th.Context, bytes.NewReader(jsonl), zipReader, model.ChannelImportOpts{
+			Team:         team.Name,
+			UserMapping:  model.ChannelImportUserMappingEmail,
+			UnknownUsers: model.ChannelImportUnknownUsersCreate,
+		}, false, 2, model.ExportDataDir)
+		require.Nil(t, appErr)
+		require.Zero(t, line)
+
+		imported, appErr := th.App.GetChannelByName(th.Context, channel.Name, team.Id, false)
+		require.Nil(t, appErr)
+
+		posts, appErr := th.App.GetPostsPage(th.Context, model.GetPostsOptions{ChannelId: imported.Id, PerPage: 10})
+		require.Nil(t, appErr)
+		var messages []string
+		for _, p := range posts.Posts {
+			messages = append(messages, p.Message)
+		}
+		assert.Contains(t, messages, post.Message)
+
+		_, appErr = th.App.GetChannelMember(th.Context, imported.Id, th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		_, appErr = th.App.GetTeamMember(th.Context, team.Id, th.BasicUser2.Id)
+		require.Nil(t, appErr)
+	})
+
+	unknownUserArchive := func(username string) []byte {
+		return []byte(strings.Join([]string{
+			`{"type":"version","version":1}`,
+			`{"type":"channel","channel":{"team":"sourceteam","name":"unknown-users","display_name":"Unknown users","type":"O"}}`,
+			`{"type":"user","user":{"username":"` + username + `","email":"` + username + `@example.com","teams":[{"name":"sourceteam","channels":[{"name":"unknown-users"}]}]}}`,
+			`{"type":"post","post":{"team":"sourceteam","channel":"unknown-users","user":"` + username + `","message":"from an unknown user","create_at":1700000000000}}`,
+			`{"type":"post","post":{"team":"sourceteam","channel":"unknown-users","user":"` + th.BasicUser.Username + `","message":"from a known user","create_at":1700000000001,"reactions":[{"user":"` + username + `","emoji_name":"smile","create_at":1700000000002}]}}`,
+		}, "\n") + "\n")
+	}
+
+	importedMessages := func(t *testing.T, team *model.Team) map[string]string {
+		imported, appErr := th.App.GetChannelByName(th.Context, "unknown-users", team.Id, false)
+		require.Nil(t, appErr)
+		posts, appErr := th.App.GetPostsPage(th.Context, model.GetPostsOptions{ChannelId: imported.Id, PerPage: 10})
+		require.Nil(t, appErr)
+		messages := map[string]string{}
+		for _, p := range posts.Posts {
+			messages[p.Message] = p.UserId
+		}
+		return messages
+	}
+
+	t.Run("should skip the content of unknown users", func(t *testing.T) {
+		team := th.CreateTeam(t)
+		username := "unknown" + model.NewId()
+
+		_, appErr := th.App.BulkImportChannel(th.Context, bytes.NewReader(unknownUserArchive(username)), nil, model.ChannelImportOpts{
+			Team:         team.Name,
+			UserMapping:  model.ChannelImportUserMappingEmail,
+			UnknownUsers: model.ChannelImportUnknownUsersSkip,
+		}, false, 2, model.ExportDataDir)
+		require.Nil(t, appErr)
+
+		messages := importedMessages(t, team)
+		assert.NotContains(t, messages, "from an unknown user")
+		assert.Equal(t, th.BasicUser.Id, messages["from a known user"])
+
+		_, appErr = th.App.GetUserByUsername(username)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("should remap the content of unknown users", func(t *testing.T) {
+		team := th.CreateTeam(t)
+		username := "unknown" + model.NewId()
+
+		_, appErr := th.App.BulkImportChannel(th.Context, bytes.NewReader(unknownUserArchive(username)), nil, model.ChannelImportOpts{
+			Team:          team.Name,
+			UserMapping:   model.ChannelImportUserMappingEmail,
+			UnknownUsers:  model.ChannelImportUnknownUsersRemap,
+			RemapUsername: th.BasicUser2.Username,
+		}, false, 2, model.ExportDataDir)
+		require.Nil(t, appErr)
+
+		messages := importedMessages(t, team)
+		assert.Equal(t, th.BasicUser2.Id, messages["from an unknown user"])
+	})
+
+	t.Run("should create unknown users", func(t *testing.T) {
+		team := th.CreateTeam(t)
+		username := "unknown" + model.NewId()
+
+		_, appErr := th.App.BulkImportChannel(th.Context, bytes.NewReader(unknownUserArchive(username)), nil, model.ChannelImportOpts{
+			Team:         team.Name,
+			UserMapping:  model.ChannelImportUserMappingEmail,
+			UnknownUsers: model.ChannelImportUnknownUsersCreate,
+		}, false, 2, model.ExportDataDir)
+		require.Nil(t, appErr)
+
+		user, appErr := th.App.GetUserByUsername(username)
+		require.Nil(t, appErr)
+		messages := importedMessages(t, team)
+		assert.Equal(t, user.Id, messages["from an unknown user"])
+	})
+
+	t.Run("should not reuse the account of another user with the same username", func(t *testing.T) {
+		team := th.CreateTeam(t)
+
+		archive := strings.ReplaceAll(string(unknownUserArchive(th.BasicUser2.Username)), th.BasicUser2.Username+"@example.com", "someone-else@example.com")
+		_, appErr := th.App.BulkImportChannel(th.Context, strings.NewReader(archive), nil, model.ChannelImportOpts{
+			Team:         team.Name,
+			UserMapping:  model.ChannelImportUserMappingEmail,
+			UnknownUsers: model.ChannelImportUnknownUsersCreate,
+		}, false, 2, model.ExportDataDir)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.channel_import.username_conflict.app_error", appErr.Id)
+	})
+
+	t.Run("should not import into an existing channel with the same name", func(t *testing.T) {
+		team := th.CreateTeam(t)
+		existing := th.CreateChannel(t, team)
+		archive := strings.ReplaceAll(string(unknownUserArchive(th.BasicUser2.Username)), "unknown-users", existing.Name)
+
+		_, appErr := th.App.BulkImportChannel(th.Context, strings.NewReader(archive), nil, model.ChannelImportOpts{
+			Team:         team.Name,
+			UserMapping:  model.ChannelImportUserMappingEmail,
+			UnknownUsers: model.ChannelImportUnknownUsersCreate,
+		}, false, 2, model.ExportDataDir)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.channel_import.channel_exists.app_error", appErr.Id)
+
+		posts, appErr := th.App.GetPostsPage(th.Context, model.GetPostsOptions{ChannelId: existing.Id, PerPage: 10})
+		require.Nil(t, appErr)
+		for _, p := range posts.Posts {
+			assert.NotEqual(t, "from a known user", p.Message)
+		}
+	})
+
+	t.Run("should reject archives with other line types", func(t *testing.T) {
+		team := th.CreateTeam(t)
+
+		archive := `{"type":"version","version":1}` + "\n" + `{"type":"emoji","emoji":{"name":"emoji","image":"image.png"}}` + "\n"
+		_, appErr := th.App.BulkImportChannel(th.Context, strings.NewReader(archive), nil, model.ChannelImportOpts{
+			Team:         team.Name,
+			UserMapping:  model.ChannelImportUserMappingEmail,
+			UnknownUsers: model.ChannelImportUnknownUsersCreate,
+		}, false, 2, model.ExportDataDir)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.import.channel_import.unsupported_line_type.app_error", appErr.Id)
+	})
+}
@@ -0,0 +1,385 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/imports"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// BulkImportChannel imports the archive of a single channel, created by
+// BulkExportChannel on another server, into the team set in the
+// options. The users of the archive are matched with the users of the
+// server by email or username. Users that match keep their account and
+// are only added to the channel, the others are created, remapped to a
+// single user or skipped along with their content.
+func (a *App) BulkImportChannel(rctx request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts model.ChannelImportOpts, extractContent bool, workers int, importPath string) (int, *model.AppError) {
+	if appErr := opts.IsValid(); appErr != nil {
+		return 0, appErr
+	}
+
+	team, err := a.Srv().Store().Team().GetByName(opts.Team)
+	if err != nil {
+		return 0, model.NewAppError("BulkImportChannel", "app.import.import_channel.team_not_found.error", map[string]any{"TeamName": opts.Team}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	mapper := &channelImportMapper{
+		a:         a,
+		rctx:      rctx,
+		opts:      opts,
+		team:      team,
+		usernames: map[string]string{},
+	}
+	if opts.UnknownUsers == model.ChannelImportUnknownUsersRemap {
+		if mapper.remapUser, err = a.Srv().Store().User().GetByUsername(opts.RemapUsername); err != nil {
+			return 0, model.NewAppError("BulkImportChannel", "app.import.channel_import.remap_user_not_found.app_error", map[string]any{"Username": opts.RemapUsername}, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	rd, wr := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mapper.rewrite(jsonlReader, wr)
+		if mapper.err != nil {
+			wr.CloseWithError(mapper.err) // CloseWithError never returns an error
+			return
+		}
+		wr.Close() // Close never returns an error
+	}()
+
+	lineNumber, appErr := a.bulkImport(rctx, rd, attachmentsReader, false, extractContent, workers, importPath)
+	// unblock the mapper if the import stopped before reading everything
+	rd.CloseWithError(io.ErrClosedPipe) // CloseWithError never returns an error
+	<-done
+
+	if mapper.err != nil {
+		return mapper.lineNumber, mapper.err
+	}
+	if appErr != nil {
+		return lineNumber, appErr
+	}
+
+	for _, member := range mapper.members {
+		if appErr := a.importChannelMember(rctx, team, member.user, member.channels); appErr != nil {
+			return 0, appErr
+		}
+	}
+
+	return 0, nil
+}
+
+// importChannelMember adds an existing user to the imported channel,
+// and to its team if needed, without touching the rest of the account.
+func (a *App) importChannelMember(rctx request.CTX, team *model.Team, user *model.User, channels []imports.UserChannelImportData) *model.AppError {
+	_, err := a.Srv().Store().Team().GetMember(rctx, team.Id, user.Id)
+	var nfErr *store.ErrNotFound
+	switch {
+	case err == nil:
+		return a.importUserChannels(rctx, user, team, &channels)
+	case errors.As(err, &nfErr):
+		return a.importUserTeams(rctx, user, &[]imports.UserTeamImportData{{
+			Name:     &team.Name,
+			Channels: &channels,
+		}})
+	default:
+		return model.NewAppError("importChannelMember", "app.team.get_member.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+}
+
+type channelImportMember struct {
+	user     *model.User
+	channels []imports.UserChannelImportData
+}
+
+// channelImportMapper rewrites the lines of a channel archive so that
+// they reference the team and the users of the server.
+type channelImportMapper struct {
+	a         *App
+	rctx      request.CTX
+	opts      model.ChannelImportOpts
+	team      *model.Team
+	remapUser *model.User
+
+	channel string
+	// usernames maps the usernames of the archive to the usernames of
+	// the server. Skipped users are mapped to an empty string.
+	usernames map[string]string
+	// members are the existing users that have to be added to the
+	// channel once it has been imported.
+	members []channelImportMember
+
+	lineNumber int
+	err        *model.AppError
+}
+
+func (m *channelImportMapper) rewrite(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, maxScanTokenSize)
+
+	for scanner.Scan() {
+		m.lineNumber++
+
+		var line imports.LineImportData
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			m.err = model.NewAppError("BulkImportChannel", "app.import.bulk_import.json_decode.error", nil, "", http.StatusBadRequest).Wrap(err)
+			return
+		}
+
+		keep, appErr := m.mapLine(&line)
+		if appErr != nil {
+			m.err = appErr
+			return
+		}
+		if !keep {
+			continue
+		}
+
+		b, err := json.Marshal(&line)
+		if err != nil {
+			m.err = model.NewAppError("BulkImportChannel", "app.export.export_write_line.json_marshall.error", nil, "", http.StatusInternalServerError).Wrap(err)
+			return
+		}
+		if _, err := w.Write(append(b, '\n')); err != nil {
+			// the import has stopped, its error is the one to report
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		m.err = model.NewAppError("BulkImportChannel", "app.import.bulk_import.file_scan.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+}
+
+func (m *channelImportMapper) mapLine(line *imports.LineImportData) (bool, *model.AppError) {
+	switch line.Type {
+	case "version":
+		return true, nil
+	case "team":
+		// the channel is imported into an existing team
+		return false, nil
+	case "channel":
+		if line.Channel == nil {
+			return false, model.NewAppError("BulkImportChannel", "app.import.import_line.null_channel.error", nil, "", http.StatusBadRequest)
+		}
+		if line.Channel.Name == nil {
+			return false, model.NewAppError("BulkImportChannel", "app.import.validate_channel_import_data.name_missing.error", nil, "", http.StatusBadRequest)
+		}
+		if m.channel != "" {
+			return false, model.NewAppError("BulkImportChannel", "app.import.channel_import.multiple_channels.app_error", nil, "", http.StatusBadRequest)
+		}
+		// the import would merge the archive into an existing channel
+		// with the same name, including an archived one
+		_, err := m.a.Srv().Store().Channel().GetByNameIncludeDeleted(m.team.Id, *line.Channel.Name, false)
+		var nfErr *store.ErrNotFound
+		switch {
+		case err == nil:
+			return false, model.NewAppError("BulkImportChannel", "app.import.channel_import.channel_exists.app_error", map[string]any{"ChannelName": *line.Channel.Name, "TeamName": m.team.Name}, "", http.StatusBadRequest)
+		case !errors.As(err, &nfErr):
+			return false, model.NewAppError("BulkImportChannel", "app.channel.get_by_name.existing.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		m.channel = *line.Channel.Name
+		line.Channel.Team = &m.team.Name
+		return true, nil
+	case "user":
+		return m.mapUser(line.User)
+	case "post":
+		return m.mapPost(line.Post)
+	default:
+		return false, model.NewAppError("BulkImportChannel", "app.import.channel_import.unsupported_line_type.app_error", map[string]any{"Type": line.Type}, "", http.StatusBadRequest)
+	}
+}
+
+func (m *channelImportMapper) mapUser(data *imports.UserImportData) (bool, *model.AppError) {
+	if data == nil {
+		return false, model.NewAppError("BulkImportChannel", "app.import.import_line.null_user.error", nil, "", http.StatusBadRequest)
+	}
+	if data.Username == nil || data.Email == nil {
+		return false, imports.ValidateUserImportData(data)
+	}
+
+	var existing *model.User
+	var err error
+	if m.opts.UserMapping == model.ChannelImportUserMappingEmail {
+		existing, err = m.a.Srv().Store().User().GetByEmail(*data.Email)
+	} else {
+		existing, err = m.a.Srv().Store().User().GetByUsername(*data.Username)
+	}
+	var nfErr *store.ErrNotFound
+	if err != nil && !errors.As(err, &nfErr) {
+		return false, model.NewAppError("BulkImportChannel", "app.user.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	channels := m.channelMemberships(data.Teams)
+
+	if existing != nil {
+		m.usernames[*data.Username] = existing.Username
+		if len(channels) > 0 {
+			m.members = append(m.members, channelImportMember{user: existing, channels: channels})
+		}
+		return false, nil
+	}
+
+	switch m.opts.UnknownUsers {
+	case model.ChannelImportUnknownUsersRemap:
+		m.usernames[*data.Username] = m.remapUser.Username
+		return false, nil
+	case model.ChannelImportUnknownUsersSkip:
+		m.usernames[*data.Username] = ""
+		return false, nil
+	}
+
+	// The import updates the user that has the same username, so the
+	// account of someone else must not be reused when matching by email.
+	if _, err := m.a.Srv().Store().User().GetByUsername(*data.Username); err == nil {
+		return false, model.NewAppError("BulkImportChannel", "app.import.channel_import.username_conflict.app_error", map[string]any{"Username": *data.Username}, "", http.StatusBadRequest)
+	} else if !errors.As(err, &nfErr) {
+		return false, model.NewAppError("BulkImportChannel", "app.user.get_by_username.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	m.usernames[*data.Username] = *data.Username
+	if len(channels) > 0 {
+		data.Teams = &[]imports.UserTeamImportData{{
+			Name:     &m.team.Name,
+			Channels: &channels,
+		}}
+	} else {
+		data.Teams = nil
+	}
+	return true, nil
+}
+
+// channelMemberships returns the memberships of the imported channel
+// found in the team memberships of a user line.
+func (m *channelImportMapper) channelMemberships(teams *[]imports.UserTeamImportData) []imports.UserChannelImportData {
+	var channels []imports.UserChannelImportData
+	if teams == nil {
+		return channels
+	}
+	for _, team := range *teams {
+		if team.Channels == nil {
+			continue
+		}
+		for _, channel := range *team.Channels {
+			if channel.Name != nil && *channel.Name == m.channel {
+				channels = append(channels, channel)
+			}
+		}
+	}
+	return channels
+}
+
+// resolveUsername returns the username of the server that a username of
+// the archive maps to, or false if the content of the user is skipped.
+// Usernames that aren't declared in the archive, such as the ones of
+// bots, are looked up on the server.
+func (m *channelImportMapper) resolveUsername(username string) (string, bool) {
+	if mapped, ok := m.usernames[username]; ok {
+		return mapped, mapped != ""
+	}
+
+	mapped := ""
+	if _, err := m.a.Srv().Store().User().GetByUsername(username); err == nil {
+		mapped = username
+	} else if m.remapUser != nil {
+		mapped = m.remapUser.Username
+	} else {
+		m.rctx.Logger().Warn("Skipping the content of a user that is not part of the channel archive", mlog.String("username", username))
+	}
+	m.usernames[username] = mapped
+	return mapped, mapped != ""
+}
+
+func (m *channelImportMapper) mapPost(data *imports.PostImportData) (bool, *model.AppError) {
+	if data == nil {
+		return false, model.NewAppError("BulkImportChannel", "app.import.import_line.null_post.error", nil, "", http.StatusBadRequest)
+	}
+	if data.User == nil {
+		return false, imports.ValidatePostImportData(data, m.a.MaxPostSize())
+	}
+
+	username, ok := m.resolveUsername(*data.User)
+	if !ok {
+		return false, nil
+	}
+	data.User = &username
+	data.Team = &m.team.Name
+	data.FlaggedBy = m.mapUsernames(data.FlaggedBy)
+	data.Reactions = m.mapReactions(data.Reactions)
+
+	if data.ThreadFollowers != nil {
+		followers := make([]imports.ThreadFollowerImportData, 0, len(*data.ThreadFollowers))
+		for _, follower := range *data.ThreadFollowers {
+			if follower.User == nil {
+				continue
+			}
+			if username, ok := m.resolveUsername(*follower.User); ok {
+				follower.User = &username
+				followers = append(followers, follower)
+			}
+		}
+		data.ThreadFollowers = &followers
+	}
+
+	if data.Replies != nil {
+		replies := make([]imports.ReplyImportData, 0, len(*data.Replies))
+		for _, reply := range *data.Replies {
+			if reply.User == nil {
+				continue
+			}
+			username, ok := m.resolveUsername(*reply.User)
+			if !ok {
+				continue
+			}
+			reply.User = &username
+			reply.FlaggedBy = m.mapUsernames(reply.FlaggedBy)
+			reply.Reactions = m.mapReactions(reply.Reactions)
+			replies = append(replies, reply)
+		}
+		data.Replies = &replies
+	}
+
+	return true, nil
+}
+
+func (m *channelImportMapper) mapUsernames(usernames *[]string) *[]string {
+	if usernames == nil {
+		return nil
+	}
+	mapped := make([]string, 0, len(*usernames))
+	for _, username := range *usernames {
+		if username, ok := m.resolveUsername(username); ok {
+			mapped = append(mapped, username)
+		}
+	}
+	return &mapped
+}
+
+func (m *channelImportMapper) mapReactions(reactions *[]imports.ReactionImportData) *[]imports.ReactionImportData {
+	if reactions == nil {
+		return nil
+	}
+	mapped := make([]imports.ReactionImportData, 0, len(*reactions))
+	for _, reaction := range *reactions {
+		if reaction.User == nil {
+			continue
+		}
+		if username, ok := m.resolveUsername(*reaction.User); ok {
+			reaction.User = &username
+			mapped = append(mapped, reaction)
+		}
+	}
+	return &mapped
+}
@@ -48,6 +48,8 @@ type Channels struct {
 	pluginsEnvironment            *plugin.Environment
 	pluginConfigListenerID        string
 	pluginClusterLeaderListenerID string
+	pluginWatchersLock            sync.Mutex
+	pluginWatchers                map[string]*pluginWatcher
 
 	imageProxy *imageproxy.ImageProxy
 
@@ -83,6 +85,9 @@ type Channels struct {
 	postReminderMut  sync.Mutex
 	postReminderTask *model.ScheduledTask
 
+	membershipRequestExpiryMut  sync.Mutex
+	membershipRequestExpiryTask *model.ScheduledTask
+
 	interruptQuitChan     chan struct{}
 	scheduledPostMut      sync.Mutex
 	scheduledPostTask     *model.ScheduledTask
@@ -176,9 +181,9 @@ func NewChannels(s *Server) (*Channels, error) {
 		})
 	}
 
-	if accessControlServiceInterface != nil {
+	if newAccessControl, _ := accessControlInterfaces(); newAccessControl != nil {
 		app := New(ServerConnector(ch))
-		ch.AccessControl = accessControlServiceInterface(app)
+		ch.AccessControl = newAccessControl(app)
 
 		appErr := ch.AccessControl.Init(request.EmptyContext(s.Log()))
 		if appErr != nil && appErr.StatusCode != http.StatusNotImplemented {
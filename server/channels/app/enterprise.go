@@ -110,6 +110,26 @@ func RegisterJobsAccessControlSyncJobInterface(f func(*Server) ejobs.AccessContr
 	jobsAccessControlSyncJobInterface = f
 }
 
+var builtinAccessControlServiceInterface func(*App) einterfaces.AccessControlServiceInterface
+var builtinJobsAccessControlSyncJobInterface func(*Server) ejobs.AccessControlSyncJobInterface
+
+// RegisterBuiltinAccessControlInterfaces registers the built-in access control service and its
+// sync job. They are only used when no other access control service is registered, whatever
+// the order in which they are registered.
+func RegisterBuiltinAccessControlInterfaces(service func(*App) einterfaces.AccessControlServiceInterface, job func(*Server) ejobs.AccessControlSyncJobInterface) {
+	builtinAccessControlServiceInterface = service
+	builtinJobsAccessControlSyncJobInterface = job
+}
+
+// accessControlInterfaces returns the registered access control service and sync job, falling
+// back to the built-in ones when no access control service is registered.
+func accessControlInterfaces() (func(*App) einterfaces.AccessControlServiceInterface, func(*Server) ejobs.AccessControlSyncJobInterface) {
+	if accessControlServiceInterface == nil {
+		return builtinAccessControlServiceInterface, builtinJobsAccessControlSyncJobInterface
+	}
+	return accessControlServiceInterface, jobsAccessControlSyncJobInterface
+}
+
 var pushProxyInterface func(*App) einterfaces.PushProxyInterface
 
 func RegisterPushProxyInterface(f func(*App) einterfaces.PushProxyInterface) {
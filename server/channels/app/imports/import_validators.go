@@ -372,7 +372,7 @@ func validateAuthService(authService *string) *model.AppError {
 	if authService == nil {
 		return nil
 	}
-	if slices.Contains(validAuthServices, *authService) {
+	if slices.Contains(validAuthServices, *authService) || model.IsOpenIdProviderService(*authService) {
 		return nil
 	}
 
@@ -321,6 +321,10 @@ func (a *App) DoActionRequest(rctx request.CTX, rawURL string, body []byte) (*ht
 		return a.DoLocalRequest(rctx, rawURLPath, body)
 	}
 
+	if rawURLPath == model.MembershipRequestActionURL {
+		return a.doMembershipRequestAction(rctx, body)
+	}
+
 	req, err := http.NewRequestWithContext(rctx.Context(), "POST", rawURL, bytes.NewReader(body))
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
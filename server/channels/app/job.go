@@ -79,6 +79,8 @@ func (a *App) CreateJob(rctx request.CTX, job *model.Job) (*model.Job, *model.Ap
 	case model.JobTypeAccessControlSync:
 		// Route ABAC jobs to specialized deduplication handler
 		return a.CreateAccessControlSyncJob(rctx, job.Data)
+	case model.JobTypeExportAccessReport:
+		return a.StartAccessReportExport(rctx, job.Data)
 	default:
 		return a.Srv().Jobs.CreateJob(rctx, job.Type, job.Data)
 	}
@@ -161,6 +163,8 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		model.JobTypeCloud,
 		model.JobTypeExtractContent:
 		return a.SessionHasPermissionTo(session, model.PermissionManageJobs), model.PermissionManageJobs
+	case model.JobTypeExportAccessReport, model.JobTypeUserSync:
+		return a.SessionHasPermissionTo(session, model.PermissionManageSystem), model.PermissionManageSystem
 	case model.JobTypeAccessControlSync:
 		// Allow system admins OR channel admins to create access control sync jobs
 		hasSystemPermission := a.SessionHasPermissionTo(session, model.PermissionManageSystem)
@@ -212,7 +216,7 @@ func (a *App) SessionHasPermissionToManageJob(session model.Session, job *model.
 		model.JobTypeCloud,
 		model.JobTypeExtractContent:
 		permission = model.PermissionManageJobs
-	case model.JobTypeAccessControlSync:
+	case model.JobTypeAccessControlSync, model.JobTypeExportAccessReport, model.JobTypeUserSync:
 		permission = model.PermissionManageSystem
 	}
 
@@ -249,7 +253,7 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		model.JobTypeMobileSessionMetadata,
 		model.JobTypeExtractContent:
 		return a.SessionHasPermissionTo(session, model.PermissionReadJobs), model.PermissionReadJobs
-	case model.JobTypeAccessControlSync:
+	case model.JobTypeAccessControlSync, model.JobTypeExportAccessReport, model.JobTypeUserSync:
 		return a.SessionHasPermissionTo(session, model.PermissionManageSystem), model.PermissionManageSystem
 	}
 
@@ -0,0 +1,527 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	// membershipRequestMaxReviewers caps the number of admins asked to review a request.
+	membershipRequestMaxReviewers = 20
+	// membershipRequestExpiryBatchSize caps the number of requests expired on each run of the expiry task.
+	membershipRequestExpiryBatchSize = 1000
+)
+
+func (a *App) CreateMembershipRequest(rctx request.CTX, userID string, create *model.CreateMembershipRequest) (*model.MembershipRequest, *model.AppError) {
+	if !*a.Config().MembershipRequestSettings.Enable {
+		return nil, model.NewAppError("CreateMembershipRequest", "app.membership_request.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if user.IsGuest() || user.IsBot {
+		return nil, model.NewAppError("CreateMembershipRequest", "app.membership_request.create.not_allowed.app_error", nil, "user_id="+userID, http.StatusForbidden)
+	}
+
+	team, appErr := a.GetTeam(create.TeamId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var channel *model.Channel
+	if create.ChannelId != "" {
+		channel, appErr = a.GetChannel(rctx, create.ChannelId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if channel.TeamId != team.Id || channel.Type != model.ChannelTypePrivate || channel.DeleteAt != 0 || !channel.AllowMembershipRequests {
+			return nil, model.NewAppError("CreateMembershipRequest", "app.membership_request.create.not_requestable.app_error", nil, "channel_id="+channel.Id, http.StatusForbidden)
+		}
+
+		if member, appErr := a.GetTeamMember(rctx, team.Id, userID); appErr != nil || member.DeleteAt != 0 {
+			return nil, model.NewAppError("CreateMembershipRequest", "app.membership_request.create.not_team_member.app_error", nil, "team_id="+team.Id, http.StatusForbidden)
+		}
+		if _, appErr := a.GetChannelMember(rctx, channel.Id, userID); appErr == nil {
+			return nil, model.NewAppError("CreateMembershipRequest", "app.membership_request.create.already_member.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+		}
+	} else {
+		if team.DeleteAt != 0 || !team.AllowMembershipRequests {
+			return nil, model.NewAppError("CreateMembershipRequest", "app.membership_request.create.not_requestable.app_error", nil, "team_id="+team.Id, http.StatusForbidden)
+		}
+		if member, appErr := a.GetTeamMember(rctx, team.Id, userID); appErr == nil && member.DeleteAt == 0 {
+			return nil, model.NewAppError("CreateMembershipRequest", "app.membership_request.create.already_member.app_error", nil, "team_id="+team.Id, http.StatusBadRequest)
+		}
+	}
+
+	membershipRequest := &model.MembershipRequest{
+		UserId:        userID,
+		TeamId:        create.TeamId,
+		ChannelId:     create.ChannelId,
+		Justification: create.Justification,
+		CreateAt:      model.GetMillis(),
+	}
+	membershipRequest.ExpiresAt = membershipRequest.CreateAt + int64(*a.Config().MembershipRequestSettings.RequestExpiryInHours)*60*60*1000
+
+	membershipRequest, err := a.Srv().Store().MembershipRequest().Save(membershipRequest)
+	if err != nil {
+		var appErr *model.AppError
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("CreateMembershipRequest", "app.membership_request.create.pending_exists.app_error", nil, "", http.StatusConflict).Wrap(err)
+		default:
+			return nil, model.NewAppError("CreateMembershipRequest", "app.membership_request.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	// The request can still be reviewed through the API if the review posts couldn't be sent.
+	if appErr := a.sendMembershipRequestReviewPosts(rctx, membershipRequest, user, team, channel); appErr != nil {
+		rctx.Logger().Warn("Failed to send the posts to review the membership request",
+			mlog.String("membership_request_id", membershipRequest.Id),
+			mlog.Err(appErr))
+	}
+
+	a.publishMembershipRequestUpdated(rctx, membershipRequest)
+
+	return membershipRequest, nil
+}
+
+func (a *App) GetMembershipRequest(rctx request.CTX, id string) (*model.MembershipRequest, *model.AppError) {
+	membershipRequest, err := a.Srv().Store().MembershipRequest().Get(id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetMembershipRequest", "app.membership_request.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetMembershipRequest", "app.membership_request.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return membershipRequest, nil
+}
+
+func (a *App) GetMembershipRequests(rctx request.CTX, opts model.MembershipRequestGetOptions) ([]*model.MembershipRequest, *model.AppError) {
+	membershipRequests, err := a.Srv().Store().MembershipRequest().GetAll(opts)
+	if err != nil {
+		return nil, model.NewAppError("GetMembershipRequests", "app.membership_request.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return membershipRequests, nil
+}
+
+// GetRequestableChannels returns the private channels of the team the user can
+// ask to join.
+func (a *App) GetRequestableChannels(rctx request.CTX, teamID, userID string, page, perPage int) (model.ChannelList, *model.AppError) {
+	channels, err := a.Srv().Store().MembershipRequest().GetRequestableChannels(teamID, userID, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetRequestableChannels", "app.membership_request.get_requestable_channels.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return channels, nil
+}
+
+// GetRequestableTeams returns the teams the user isn't a member of and can
+// ask to join.
+func (a *App) GetRequestableTeams(rctx request.CTX, userID string, page, perPage int) ([]*model.Team, *model.AppError) {
+	teams, err := a.Srv().Store().MembershipRequest().GetRequestableTeams(userID, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetRequestableTeams", "app.membership_request.get_requestable_teams.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return teams, nil
+}
+
+// HasPermissionToReviewMembershipRequest returns whether the user can approve
+// or deny the request, which the admins of the channel or team can.
+func (a *App) HasPermissionToReviewMembershipRequest(rctx request.CTX, userID string, membershipRequest *model.MembershipRequest) bool {
+	if membershipRequest.IsForChannel() {
+		hasPermission, _ := a.HasPermissionToChannel(rctx, userID, membershipRequest.ChannelId, model.PermissionManageChannelRoles)
+		return hasPermission
+	}
+
+	return a.HasPermissionToTeam(rctx, userID, membershipRequest.TeamId, model.PermissionManageTeam)
+}
+
+// ApproveMembershipRequest adds the user to the channel or team of the
+// pending request.
+func (a *App) ApproveMembershipRequest(rctx request.CTX, membershipRequest *model.MembershipRequest, reviewerID string) (*model.MembershipRequest, *model.AppError) {
+	if !membershipRequest.IsPending() {
+		return nil, model.NewAppError("ApproveMembershipRequest", "app.membership_request.not_pending.app_error", nil, "id="+membershipRequest.Id, http.StatusBadRequest)
+	}
+
+	if membershipRequest.IsForChannel() {
+		channel, appErr := a.GetChannel(rctx, membershipRequest.ChannelId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if _, appErr := a.AddChannelMember(rctx, membershipRequest.UserId, channel, ChannelMemberOpts{UserRequestorID: reviewerID}); appErr != nil {
+			return nil, appErr
+		}
+	} else {
+		if _, _, appErr := a.AddUserToTeam(rctx, membershipRequest.TeamId, membershipRequest.UserId, reviewerID); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return a.resolveMembershipRequest(rctx, membershipRequest, model.MembershipRequestStatusApproved, reviewerID)
+}
+
+func (a *App) DenyMembershipRequest(rctx request.CTX, membershipRequest *model.MembershipRequest, reviewerID string) (*model.MembershipRequest, *model.AppError) {
+	return a.resolveMembershipRequest(rctx, membershipRequest, model.MembershipRequestStatusDenied, reviewerID)
+}
+
+// CancelMembershipRequest withdraws the pending request of the user.
+func (a *App) CancelMembershipRequest(rctx request.CTX, membershipRequest *model.MembershipRequest) (*model.MembershipRequest, *model.AppError) {
+	return a.resolveMembershipRequest(rctx, membershipRequest, model.MembershipRequestStatusCancelled, "")
+}
+
+// ExpireMembershipRequests expires the pending requests which weren't reviewed
+// in time.
+func (a *App) ExpireMembershipRequests(rctx request.CTX) {
+	membershipRequests, err := a.Srv().Store().MembershipRequest().GetAll(model.MembershipRequestGetOptions{
+		Status:        model.MembershipRequestStatusPending,
+		ExpiresBefore: model.GetMillis(),
+		PerPage:       membershipRequestExpiryBatchSize,
+	})
+	if err != nil {
+		rctx.Logger().Error("Failed to get the membership requests to expire", mlog.Err(err))
+		return
+	}
+
+	for _, membershipRequest := range membershipRequests {
+		a.expireMembershipRequest(rctx, membershipRequest)
+	}
+}
+
+func (a *App) expireMembershipRequest(rctx request.CTX, membershipRequest *model.MembershipRequest) {
+	auditRec := a.MakeAuditRecord(rctx, model.AuditEventExpireMembershipRequest, model.AuditStatusFail)
+	defer a.LogAuditRec(rctx, auditRec, nil)
+	auditRec.AddEventPriorState(membershipRequest)
+
+	expired, appErr := a.resolveMembershipRequest(rctx, membershipRequest, model.MembershipRequestStatusExpired, "")
+	if appErr != nil {
+		auditRec.AddMeta("err", appErr.Error())
+		rctx.Logger().Warn("Failed to expire the membership request",
+			mlog.String("membership_request_id", membershipRequest.Id),
+			mlog.Err(appErr))
+		return
+	}
+
+	auditRec.AddEventResultState(expired)
+	auditRec.Success()
+}
+
+// resolveMembershipRequest sets the final status of the pending request, and
+// lets the reviewers and the requester know about it.
+func (a *App) resolveMembershipRequest(rctx request.CTX, membershipRequest *model.MembershipRequest, status, reviewerID string) (*model.MembershipRequest, *model.AppError) {
+	if !membershipRequest.IsPending() {
+		return nil, model.NewAppError("resolveMembershipRequest", "app.membership_request.not_pending.app_error", nil, "id="+membershipRequest.Id, http.StatusBadRequest)
+	}
+
+	resolved := *membershipRequest
+	resolved.Status = status
+	resolved.ReviewerId = reviewerID
+	resolved.UpdateAt = model.GetMillis()
+
+	if err := a.Srv().Store().MembershipRequest().UpdateStatus(&resolved); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			// The request was resolved in the meantime.
+			return nil, model.NewAppError("resolveMembershipRequest", "app.membership_request.not_pending.app_error", nil, "id="+membershipRequest.Id, http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("resolveMembershipRequest", "app.membership_request.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	a.updateMembershipRequestReviewPosts(rctx, &resolved)
+	if status != model.MembershipRequestStatusCancelled {
+		a.notifyMembershipRequestResolved(rctx, &resolved)
+	}
+	a.publishMembershipRequestUpdated(rctx, &resolved)
+
+	return &resolved, nil
+}
+
+// getMembershipRequestReviewers returns the admins of the channel of the
+// request, or of its team if the channel has none or the request is for the team.
+func (a *App) getMembershipRequestReviewers(membershipRequest *model.MembershipRequest) ([]*model.User, *model.AppError) {
+	if membershipRequest.IsForChannel() {
+		reviewers, appErr := a.GetUsersInChannel(&model.UserGetOptions{
+			InChannelId:  membershipRequest.ChannelId,
+			ChannelRoles: []string{model.ChannelAdminRoleId},
+			Active:       true,
+			PerPage:      membershipRequestMaxReviewers,
+		})
+		if appErr != nil || len(reviewers) > 0 {
+			return reviewers, appErr
+		}
+	}
+
+	return a.GetUsersInTeam(&model.UserGetOptions{
+		InTeamId:  membershipRequest.TeamId,
+		TeamRoles: []string{model.TeamAdminRoleId},
+		Active:    true,
+		PerPage:   membershipRequestMaxReviewers,
+	})
+}
+
+// sendMembershipRequestReviewPosts sends each reviewer of the request a direct
+// message from the system bot to approve or deny it.
+func (a *App) sendMembershipRequestReviewPosts(rctx request.CTX, membershipRequest *model.MembershipRequest, requester *model.User, team *model.Team, channel *model.Channel) *model.AppError {
+	reviewers, appErr := a.getMembershipRequestReviewers(membershipRequest)
+	if appErr != nil {
+		return appErr
+	}
+	if len(reviewers) == 0 {
+		rctx.Logger().Info("No admin to review the membership request", mlog.String("membership_request_id", membershipRequest.Id))
+		return nil
+	}
+
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		return appErr
+	}
+
+	postIDs := make([]string, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		if reviewer.Id == requester.Id || reviewer.IsBot {
+			continue
+		}
+
+		dmChannel, appErr := a.GetOrCreateDirectChannel(rctx, reviewer.Id, systemBot.UserId)
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to get the direct channel to review the membership request", mlog.String("user_id", reviewer.Id), mlog.Err(appErr))
+			continue
+		}
+
+		T := i18n.GetUserTranslations(reviewer.Locale)
+		var message string
+		if channel != nil {
+			message = T("app.membership_request.review_post.channel", map[string]any{"Username": requester.Username, "ChannelName": channel.Name})
+		} else {
+			message = T("app.membership_request.review_post.team", map[string]any{"Username": requester.Username, "TeamDisplayName": team.DisplayName})
+		}
+
+		post := &model.Post{
+			ChannelId: dmChannel.Id,
+			UserId:    systemBot.UserId,
+			Message:   message,
+		}
+		post.AddProp(model.PostPropsMembershipRequestId, membershipRequest.Id)
+		model.ParseSlackAttachment(post, []*model.SlackAttachment{{
+			Fallback: message,
+			Text:     membershipRequest.Justification,
+			Actions: []*model.PostAction{
+				newMembershipRequestPostAction(membershipRequest, model.MembershipRequestActionApprove, T("app.membership_request.review_post.approve"), "success"),
+				newMembershipRequestPostAction(membershipRequest, model.MembershipRequestActionDeny, T("app.membership_request.review_post.deny"), "danger"),
+			},
+		}})
+
+		post, _, appErr = a.CreatePost(rctx, post, dmChannel, model.CreatePostFlags{SetOnline: true})
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to send the post to review the membership request", mlog.String("user_id", reviewer.Id), mlog.Err(appErr))
+			continue
+		}
+		postIDs = append(postIDs, post.Id)
+	}
+
+	if err := a.Srv().Store().MembershipRequest().UpdatePostIds(membershipRequest.Id, postIDs); err != nil {
+		return model.NewAppError("sendMembershipRequestReviewPosts", "app.membership_request.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	membershipRequest.PostIds = postIDs
+
+	return nil
+}
+
+func newMembershipRequestPostAction(membershipRequest *model.MembershipRequest, action, name, style string) *model.PostAction {
+	return &model.PostAction{
+		Id:    action,
+		Type:  model.PostActionTypeButton,
+		Name:  name,
+		Style: style,
+		Integration: &model.PostActionIntegration{
+			URL: model.MembershipRequestActionURL,
+			Context: map[string]any{
+				model.PostPropsMembershipRequestId: membershipRequest.Id,
+				"action":                           action,
+			},
+		},
+	}
+}
+
+// updateMembershipRequestReviewPosts replaces the buttons of the posts sent to
+// the reviewers with the outcome of the request.
+func (a *App) updateMembershipRequestReviewPosts(rctx request.CTX, membershipRequest *model.MembershipRequest) {
+	var reviewerUsername string
+	if membershipRequest.ReviewerId != "" {
+		if reviewer, appErr := a.GetUser(membershipRequest.ReviewerId); appErr == nil {
+			reviewerUsername = reviewer.Username
+		}
+	}
+
+	for _, postID := range membershipRequest.PostIds {
+		post, appErr := a.GetSinglePost(rctx, postID, false)
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to get the post to review the membership request", mlog.String("post_id", postID), mlog.Err(appErr))
+			continue
+		}
+
+		locale := model.DefaultLocale
+		if dmChannel, appErr := a.GetChannel(rctx, post.ChannelId); appErr == nil {
+			if user, appErr := a.GetUser(dmChannel.GetOtherUserIdForDM(post.UserId)); appErr == nil {
+				locale = user.Locale
+			}
+		}
+		T := i18n.GetUserTranslations(locale)
+
+		updated := post.Clone()
+		model.ParseSlackAttachment(updated, []*model.SlackAttachment{{
+			Fallback: post.Message,
+			Text:     membershipRequest.Justification,
+			Fields: []*model.SlackAttachmentField{{
+				Title: T("app.membership_request.review_post.status"),
+				Value: T("app.membership_request.review_post.status."+membershipRequest.Status, map[string]any{"Username": reviewerUsername}),
+			}},
+		}})
+
+		if _, _, appErr := a.UpdatePost(rctx, updated, &model.UpdatePostOptions{SafeUpdate: false}); appErr != nil {
+			rctx.Logger().Warn("Failed to update the post to review the membership request", mlog.String("post_id", postID), mlog.Err(appErr))
+		}
+	}
+}
+
+// notifyMembershipRequestResolved lets the requester know about the outcome of
+// the request through a direct message from the system bot.
+func (a *App) notifyMembershipRequestResolved(rctx request.CTX, membershipRequest *model.MembershipRequest) {
+	requester, appErr := a.GetUser(membershipRequest.UserId)
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get the user of the membership request", mlog.String("user_id", membershipRequest.UserId), mlog.Err(appErr))
+		return
+	}
+
+	team, appErr := a.GetTeam(membershipRequest.TeamId)
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get the team of the membership request", mlog.String("team_id", membershipRequest.TeamId), mlog.Err(appErr))
+		return
+	}
+	name := team.DisplayName
+	if membershipRequest.IsForChannel() {
+		channel, appErr := a.GetChannel(rctx, membershipRequest.ChannelId)
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to get the channel of the membership request", mlog.String("channel_id", membershipRequest.ChannelId), mlog.Err(appErr))
+			return
+		}
+		name = channel.DisplayName
+	}
+
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get the system bot", mlog.Err(appErr))
+		return
+	}
+
+	dmChannel, appErr := a.GetOrCreateDirectChannel(rctx, requester.Id, systemBot.UserId)
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get the direct channel of the user of the membership request", mlog.String("user_id", requester.Id), mlog.Err(appErr))
+		return
+	}
+
+	T := i18n.GetUserTranslations(requester.Locale)
+	post := &model.Post{
+		ChannelId: dmChannel.Id,
+		UserId:    systemBot.UserId,
+		Message:   T("app.membership_request.resolved_post."+membershipRequest.Status, map[string]any{"Name": name}),
+	}
+	if _, _, appErr := a.CreatePost(rctx, post, dmChannel, model.CreatePostFlags{SetOnline: true}); appErr != nil {
+		rctx.Logger().Warn("Failed to let the user know about the outcome of the membership request", mlog.String("user_id", requester.Id), mlog.Err(appErr))
+	}
+}
+
+func (a *App) publishMembershipRequestUpdated(rctx request.CTX, membershipRequest *model.MembershipRequest) {
+	membershipRequestJSON, err := json.Marshal(membershipRequest)
+	if err != nil {
+		rctx.Logger().Warn("Failed to encode the membership request", mlog.String("membership_request_id", membershipRequest.Id), mlog.Err(err))
+		return
+	}
+
+	message := model.NewWebSocketEvent(model.WebsocketEventMembershipRequestUpdated, "", "", membershipRequest.UserId, nil, "")
+	message.Add("membership_request", string(membershipRequestJSON))
+	a.Publish(message)
+}
+
+// doMembershipRequestAction handles the buttons of the posts asking reviewers
+// to approve or deny a request, as an integration would.
+func (a *App) doMembershipRequestAction(rctx request.CTX, body []byte) (*http.Response, *model.AppError) {
+	var actionRequest model.PostActionIntegrationRequest
+	if err := json.Unmarshal(body, &actionRequest); err != nil {
+		return nil, model.NewAppError("doMembershipRequestAction", "api.post.do_action.action_integration.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	id, _ := actionRequest.Context[model.PostPropsMembershipRequestId].(string)
+	action, _ := actionRequest.Context["action"].(string)
+
+	membershipRequest, appErr := a.GetMembershipRequest(rctx, id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Only the posts sent by the server to review the request can resolve it.
+	if !slices.Contains(membershipRequest.PostIds, actionRequest.PostId) {
+		return nil, model.NewAppError("doMembershipRequestAction", "api.post.do_action.action_integration.app_error", nil, "post_id="+actionRequest.PostId, http.StatusBadRequest)
+	}
+
+	if !a.HasPermissionToReviewMembershipRequest(rctx, actionRequest.UserId, membershipRequest) {
+		return nil, model.NewAppError("doMembershipRequestAction", "app.membership_request.review.permissions.app_error", nil, "user_id="+actionRequest.UserId, http.StatusForbidden)
+	}
+
+	var auditRec *model.AuditRecord
+	switch action {
+	case model.MembershipRequestActionApprove:
+		auditRec = a.MakeAuditRecord(rctx, model.AuditEventApproveMembershipRequest, model.AuditStatusFail)
+		defer a.LogAuditRec(rctx, auditRec, nil)
+		auditRec.AddEventPriorState(membershipRequest)
+		membershipRequest, appErr = a.ApproveMembershipRequest(rctx, membershipRequest, actionRequest.UserId)
+	case model.MembershipRequestActionDeny:
+		auditRec = a.MakeAuditRecord(rctx, model.AuditEventDenyMembershipRequest, model.AuditStatusFail)
+		defer a.LogAuditRec(rctx, auditRec, nil)
+		auditRec.AddEventPriorState(membershipRequest)
+		membershipRequest, appErr = a.DenyMembershipRequest(rctx, membershipRequest, actionRequest.UserId)
+	default:
+		return nil, model.NewAppError("doMembershipRequestAction", "api.post.do_action.action_integration.app_error", nil, "action="+action, http.StatusBadRequest)
+	}
+	if appErr != nil {
+		auditRec.AddMeta("err", appErr.Error())
+		return nil, appErr
+	}
+	auditRec.AddEventResultState(membershipRequest)
+	auditRec.Success()
+
+	// The review posts have already been updated.
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte("{}"))),
+	}, nil
+}
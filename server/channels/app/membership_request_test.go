@@ -0,0 +1,239 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func setupMembershipRequests(tb testing.TB) (*TestHelper, *model.Channel) {
+	th := Setup(tb).InitBasic(tb)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.MembershipRequestSettings.Enable = true
+	})
+
+	// BasicUser creates the channel, so is its admin.
+	channel := th.CreatePrivateChannel(tb, th.BasicTeam, func(channel *model.Channel) {
+		channel.AllowMembershipRequests = true
+	})
+
+	return th, channel
+}
+
+func TestCreateMembershipRequest(t *testing.T) {
+	mainHelper.Parallel(t)
+	th, channel := setupMembershipRequests(t)
+
+	create := &model.CreateMembershipRequest{
+		TeamId:        th.BasicTeam.Id,
+		ChannelId:     channel.Id,
+		Justification: "I work on this project",
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.MembershipRequestSettings.Enable = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.MembershipRequestSettings.Enable = true })
+
+		_, appErr := th.App.CreateMembershipRequest(th.Context, th.BasicUser2.Id, create)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+
+	t.Run("channel not allowing requests", func(t *testing.T) {
+		other := th.CreatePrivateChannel(t, th.BasicTeam)
+		_, appErr := th.App.CreateMembershipRequest(th.Context, th.BasicUser2.Id, &model.CreateMembershipRequest{
+			TeamId:    th.BasicTeam.Id,
+			ChannelId: other.Id,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.membership_request.create.not_requestable.app_error", appErr.Id)
+	})
+
+	t.Run("already a member", func(t *testing.T) {
+		_, appErr := th.App.CreateMembershipRequest(th.Context, th.BasicUser.Id, create)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.membership_request.create.already_member.app_error", appErr.Id)
+	})
+
+	t.Run("guest", func(t *testing.T) {
+		guest := th.CreateGuest(t)
+		th.LinkUserToTeam(t, guest, th.BasicTeam)
+
+		_, appErr := th.App.CreateMembershipRequest(th.Context, guest.Id, create)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("sends review posts to the channel admins", func(t *testing.T) {
+		membershipRequest, appErr := th.App.CreateMembershipRequest(th.Context, th.BasicUser2.Id, create)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.MembershipRequestStatusPending, membershipRequest.Status)
+		assert.Greater(t, membershipRequest.ExpiresAt, membershipRequest.CreateAt)
+
+		require.Len(t, membershipRequest.PostIds, 1)
+		post, appErr := th.App.GetSinglePost(th.Context, membershipRequest.PostIds[0], false)
+		require.Nil(t, appErr)
+		assert.Equal(t, membershipRequest.Id, post.GetProp(model.PostPropsMembershipRequestId))
+		require.Len(t, post.Attachments(), 1)
+		assert.Equal(t, create.Justification, post.Attachments()[0].Text)
+		assert.Len(t, post.Attachments()[0].Actions, 2)
+
+		dmChannel, appErr := th.App.GetChannel(th.Context, post.ChannelId)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicUser.Id, dmChannel.GetOtherUserIdForDM(post.UserId))
+
+		t.Run("pending request already exists", func(t *testing.T) {
+			_, appErr := th.App.CreateMembershipRequest(th.Context, th.BasicUser2.Id, create)
+			require.NotNil(t, appErr)
+			assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+		})
+	})
+}
+
+func TestResolveMembershipRequest(t *testing.T) {
+	mainHelper.Parallel(t)
+	th, channel := setupMembershipRequests(t)
+
+	createRequest := func(t *testing.T) *model.MembershipRequest {
+		membershipRequest, appErr := th.App.CreateMembershipRequest(th.Context, th.BasicUser2.Id, &model.CreateMembershipRequest{
+			TeamId:    th.BasicTeam.Id,
+			ChannelId: channel.Id,
+		})
+		require.Nil(t, appErr)
+		return membershipRequest
+	}
+
+	t.Run("deny", func(t *testing.T) {
+		denied, appErr := th.App.DenyMembershipRequest(th.Context, createRequest(t), th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.MembershipRequestStatusDenied, denied.Status)
+		assert.Equal(t, th.BasicUser.Id, denied.ReviewerId)
+
+		_, appErr = th.App.GetChannelMember(th.Context, channel.Id, th.BasicUser2.Id)
+		require.NotNil(t, appErr)
+
+		post, appErr := th.App.GetSinglePost(th.Context, denied.PostIds[0], false)
+		require.Nil(t, appErr)
+		require.Len(t, post.Attachments(), 1)
+		assert.Empty(t, post.Attachments()[0].Actions)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		cancelled, appErr := th.App.CancelMembershipRequest(th.Context, createRequest(t))
+		require.Nil(t, appErr)
+		assert.Equal(t, model.MembershipRequestStatusCancelled, cancelled.Status)
+
+		_, appErr = th.App.CancelMembershipRequest(th.Context, cancelled)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.membership_request.not_pending.app_error", appErr.Id)
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		membershipRequest := createRequest(t)
+		approved, appErr := th.App.ApproveMembershipRequest(th.Context, membershipRequest, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.MembershipRequestStatusApproved, approved.Status)
+
+		_, appErr = th.App.GetChannelMember(th.Context, channel.Id, th.BasicUser2.Id)
+		require.Nil(t, appErr)
+
+		t.Run("plain channel member can't review", func(t *testing.T) {
+			assert.True(t, th.App.HasPermissionToReviewMembershipRequest(th.Context, th.BasicUser.Id, membershipRequest))
+			assert.False(t, th.App.HasPermissionToReviewMembershipRequest(th.Context, th.BasicUser2.Id, membershipRequest))
+		})
+
+		t.Run("already resolved", func(t *testing.T) {
+			_, appErr := th.App.DenyMembershipRequest(th.Context, membershipRequest, th.BasicUser.Id)
+			require.NotNil(t, appErr)
+			assert.Equal(t, "app.membership_request.not_pending.app_error", appErr.Id)
+		})
+	})
+}
+
+func TestApproveTeamMembershipRequest(t *testing.T) {
+	mainHelper.Parallel(t)
+	th, _ := setupMembershipRequests(t)
+
+	team := th.CreateTeam(t)
+	_, appErr := th.App.PatchTeam(team.Id, &model.TeamPatch{AllowMembershipRequests: model.NewPointer(true)})
+	require.Nil(t, appErr)
+	th.LinkUserToTeam(t, th.BasicUser, team)
+	_, appErr = th.App.UpdateTeamMemberSchemeRoles(th.Context, team.Id, th.BasicUser.Id, false, true, true)
+	require.Nil(t, appErr)
+
+	membershipRequest, appErr := th.App.CreateMembershipRequest(th.Context, th.BasicUser2.Id, &model.CreateMembershipRequest{TeamId: team.Id})
+	require.Nil(t, appErr)
+	require.Len(t, membershipRequest.PostIds, 1)
+
+	assert.True(t, th.App.HasPermissionToReviewMembershipRequest(th.Context, th.BasicUser.Id, membershipRequest))
+	assert.False(t, th.App.HasPermissionToReviewMembershipRequest(th.Context, th.BasicUser2.Id, membershipRequest))
+
+	_, appErr = th.App.ApproveMembershipRequest(th.Context, membershipRequest, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	member, appErr := th.App.GetTeamMember(th.Context, team.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+	assert.Zero(t, member.DeleteAt)
+}
+
+func TestExpireMembershipRequests(t *testing.T) {
+	mainHelper.Parallel(t)
+	th, channel := setupMembershipRequests(t)
+
+	now := model.GetMillis()
+	membershipRequest, err := th.App.Srv().Store().MembershipRequest().Save(&model.MembershipRequest{
+		UserId:    th.BasicUser2.Id,
+		TeamId:    th.BasicTeam.Id,
+		ChannelId: channel.Id,
+		CreateAt:  now - 2000,
+		ExpiresAt: now - 1000,
+	})
+	require.NoError(t, err)
+
+	th.App.ExpireMembershipRequests(th.Context)
+
+	expired, appErr := th.App.GetMembershipRequest(th.Context, membershipRequest.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.MembershipRequestStatusExpired, expired.Status)
+}
+
+func TestDoMembershipRequestAction(t *testing.T) {
+	mainHelper.Parallel(t)
+	th, channel := setupMembershipRequests(t)
+
+	membershipRequest, appErr := th.App.CreateMembershipRequest(th.Context, th.BasicUser2.Id, &model.CreateMembershipRequest{
+		TeamId:    th.BasicTeam.Id,
+		ChannelId: channel.Id,
+	})
+	require.Nil(t, appErr)
+	require.Len(t, membershipRequest.PostIds, 1)
+	postID := membershipRequest.PostIds[0]
+
+	t.Run("not a reviewer", func(t *testing.T) {
+		user := th.CreateUser(t)
+		th.LinkUserToTeam(t, user, th.BasicTeam)
+
+		_, appErr := th.App.DoPostActionWithCookie(th.Context, postID, model.MembershipRequestActionApprove, user.Id, "", nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	_, appErr = th.App.DoPostActionWithCookie(th.Context, postID, model.MembershipRequestActionApprove, th.BasicUser.Id, "", nil)
+	require.Nil(t, appErr)
+
+	approved, appErr := th.App.GetMembershipRequest(th.Context, membershipRequest.Id)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.MembershipRequestStatusApproved, approved.Status)
+	assert.Equal(t, th.BasicUser.Id, approved.ReviewerId)
+
+	_, appErr = th.App.GetChannelMember(th.Context, channel.Id, th.BasicUser2.Id)
+	require.Nil(t, appErr)
+}
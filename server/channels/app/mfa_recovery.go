@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/password/hashers"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+)
+
+// GenerateMfaRecoveryCodes replaces the recovery codes of a user with new ones, which are returned
+// for display and can't be retrieved again.
+func (a *App) GenerateMfaRecoveryCodes(rctx request.CTX, userID string) ([]string, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication || !*a.Config().ServiceSettings.EnableMfaRecoveryCodes {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "api.mfa_recovery_code.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !user.MfaActive {
+		return nil, model.NewAppError("GenerateMfaRecoveryCodes", "api.mfa_recovery_code.mfa_inactive.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	recoveryCodes := make([]string, model.MfaRecoveryCodeCount)
+	codes := make([]*model.MfaRecoveryCode, model.MfaRecoveryCodeCount)
+	for i := range recoveryCodes {
+		recoveryCodes[i] = model.NewMfaRecoveryCode()
+		code, _ := model.NormalizeMfaRecoveryCode(recoveryCodes[i])
+
+		codeHash, err := hashers.Hash(code)
+		if err != nil {
+			return nil, model.NewAppError("GenerateMfaRecoveryCodes", "app.mfa_recovery_code.hash.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		codes[i] = &model.MfaRecoveryCode{CodeHash: codeHash}
+	}
+
+	if err := a.Srv().Store().MfaRecoveryCode().SaveForUser(userID, codes); err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("GenerateMfaRecoveryCodes", "app.mfa_recovery_code.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	rctx.Logger().Info("Generated MFA recovery codes", mlog.String("user_id", userID))
+
+	return recoveryCodes, nil
+}
+
+// checkMfaRecoveryCode uses up the recovery code of the user matching the given normalized code.
+func (a *App) checkMfaRecoveryCode(rctx request.CTX, user *model.User, code string) *model.AppError {
+	codes, err := a.Srv().Store().MfaRecoveryCode().GetUnusedForUser(user.Id)
+	if err != nil {
+		return model.NewAppError("checkMfaRecoveryCode", "app.mfa_recovery_code.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, recoveryCode := range codes {
+		hasher, phc, err := hashers.GetHasherFromPHCString(recoveryCode.CodeHash)
+		if err != nil {
+			rctx.Logger().Warn("Skipping MFA recovery code with an invalid hash", mlog.String("user_id", user.Id), mlog.Err(err))
+			continue
+		}
+
+		if hasher.CompareHashAndPassword(phc, code) != nil {
+			continue
+		}
+
+		if err := a.Srv().Store().MfaRecoveryCode().MarkUsed(recoveryCode.Id, model.GetMillis()); err != nil {
+			var nfErr *store.ErrNotFound
+			switch {
+			case errors.As(err, &nfErr):
+				// Used by a concurrent login.
+				return model.NewAppError("checkMfaRecoveryCode", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+			default:
+				return model.NewAppError("checkMfaRecoveryCode", "app.mfa_recovery_code.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+
+		rctx.Logger().Info("Used an MFA recovery code", mlog.String("user_id", user.Id), mlog.Int("remaining", len(codes)-1))
+		return nil
+	}
+
+	return model.NewAppError("checkMfaRecoveryCode", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized)
+}
+
+// mfaTrustedDeviceDuration is how long a device stays trusted, or zero if devices can't be trusted.
+func (a *App) mfaTrustedDeviceDuration() time.Duration {
+	return time.Duration(*a.Config().ServiceSettings.MfaTrustedDeviceDays) * 24 * time.Hour
+}
+
+// checkMfaTrustedDevice checks that the token belongs to a device the user trusted, logging in
+// again with the same device ID, and that the device is still trusted under the current settings.
+func (a *App) checkMfaTrustedDevice(user *model.User, token, deviceID string) *model.AppError {
+	duration := a.mfaTrustedDeviceDuration()
+	if duration == 0 {
+		return model.NewAppError("checkMfaTrustedDevice", "api.user.check_user_mfa.bad_code.app_error", nil, "trusted devices are disabled", http.StatusUnauthorized)
+	}
+
+	device, err := a.Srv().Store().MfaTrustedDevice().GetByTokenHash(model.HashMfaTrustedDeviceToken(token))
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("checkMfaTrustedDevice", "api.user.check_user_mfa.bad_code.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+		default:
+			return model.NewAppError("checkMfaTrustedDevice", "app.mfa_trusted_device.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	now := model.GetMillis()
+	if device.UserId != user.Id || device.DeviceId != deviceID || device.ExpiresAt <= now || device.CreateAt+duration.Milliseconds() <= now {
+		return model.NewAppError("checkMfaTrustedDevice", "api.user.check_user_mfa.bad_code.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
+	}
+
+	return nil
+}
+
+// TrustMfaDevice trusts the device of a user who just logged in with MFA, so that logging in again
+// from it doesn't require MFA until it expires. It returns the token to hand to the device.
+func (a *App) TrustMfaDevice(rctx request.CTX, userID, deviceID string) (*model.MfaTrustedDevice, string, *model.AppError) {
+	duration := a.mfaTrustedDeviceDuration()
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication || duration == 0 {
+		return nil, "", model.NewAppError("TrustMfaDevice", "api.mfa_trusted_device.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	token := model.NewRandomString(model.TokenSize)
+	now := model.GetMillis()
+	device, err := a.Srv().Store().MfaTrustedDevice().Save(&model.MfaTrustedDevice{
+		UserId:    userID,
+		DeviceId:  deviceID,
+		TokenHash: model.HashMfaTrustedDeviceToken(token),
+		CreateAt:  now,
+		ExpiresAt: now + duration.Milliseconds(),
+	})
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, "", appErr
+		default:
+			return nil, "", model.NewAppError("TrustMfaDevice", "app.mfa_trusted_device.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	rctx.Logger().Info("Trusted a device to skip MFA", mlog.String("user_id", userID), mlog.String("device_id", deviceID))
+
+	return device, token, nil
+}
+
+// AttachMfaTrustedDeviceCookie hands the token of a trusted device to the client logging in.
+func (a *App) AttachMfaTrustedDeviceCookie(w http.ResponseWriter, r *http.Request, device *model.MfaTrustedDevice, token string) {
+	subpath, _ := utils.GetSubpathFromConfig(a.Config())
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     model.MfaTrustedDeviceCookie,
+		Value:    token,
+		Path:     subpath,
+		MaxAge:   int((device.ExpiresAt - device.CreateAt) / 1000),
+		Expires:  time.UnixMilli(device.ExpiresAt),
+		HttpOnly: true,
+		Domain:   a.GetCookieDomain(),
+		Secure:   GetProtocol(r) == "https",
+	})
+}
+
+// RevokeMfaTrustedDevices makes all devices a user trusted require MFA again.
+func (a *App) RevokeMfaTrustedDevices(rctx request.CTX, userID string) *model.AppError {
+	if err := a.Srv().Store().MfaTrustedDevice().DeleteForUser(userID); err != nil {
+		return model.NewAppError("RevokeMfaTrustedDevices", "app.mfa_trusted_device.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
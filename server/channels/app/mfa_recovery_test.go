@@ -0,0 +1,158 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func setupMfaRecovery(t *testing.T) *TestHelper {
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.EnableMfaRecoveryCodes = true
+		*cfg.ServiceSettings.MfaTrustedDeviceDays = 30
+	})
+	return th
+}
+
+func createMfaUser(t *testing.T, th *TestHelper) *model.User {
+	t.Helper()
+
+	user := th.CreateUser(t)
+	secret, appErr := th.App.GenerateMfaSecret(user.Id)
+	require.Nil(t, appErr)
+	require.NoError(t, th.Server.Store().User().UpdateMfaActive(user.Id, true))
+	require.NoError(t, th.Server.Store().User().UpdateMfaSecret(user.Id, secret.Secret))
+	th.App.InvalidateCacheForUser(user.Id)
+
+	user, appErr = th.App.GetUser(user.Id)
+	require.Nil(t, appErr)
+
+	return user
+}
+
+func TestMfaRecoveryCodes(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupMfaRecovery(t)
+	user := createMfaUser(t, th)
+
+	codes, appErr := th.App.GenerateMfaRecoveryCodes(th.Context, user.Id)
+	require.Nil(t, appErr)
+	require.Len(t, codes, model.MfaRecoveryCodeCount)
+
+	t.Run("single use", func(t *testing.T) {
+		appErr := th.App.CheckUserMfa(th.Context, user, codes[0])
+		require.Nil(t, appErr)
+
+		appErr = th.App.CheckUserMfa(th.Context, user, codes[0])
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.check_user_mfa.bad_code.app_error", appErr.Id)
+	})
+
+	t.Run("not of another user", func(t *testing.T) {
+		other := createMfaUser(t, th)
+		appErr := th.App.CheckUserMfa(th.Context, other, codes[1])
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMfaRecoveryCodes = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableMfaRecoveryCodes = true })
+
+		appErr := th.App.CheckUserMfa(th.Context, user, codes[1])
+		require.NotNil(t, appErr)
+
+		_, appErr = th.App.GenerateMfaRecoveryCodes(th.Context, user.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.mfa_recovery_code.disabled.app_error", appErr.Id)
+	})
+
+	t.Run("regenerating replaces codes", func(t *testing.T) {
+		newCodes, appErr := th.App.GenerateMfaRecoveryCodes(th.Context, user.Id)
+		require.Nil(t, appErr)
+
+		appErr = th.App.CheckUserMfa(th.Context, user, codes[2])
+		require.NotNil(t, appErr)
+
+		appErr = th.App.CheckUserMfa(th.Context, user, newCodes[0])
+		require.Nil(t, appErr)
+	})
+
+	t.Run("requires active mfa", func(t *testing.T) {
+		_, appErr := th.App.GenerateMfaRecoveryCodes(th.Context, th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.mfa_recovery_code.mfa_inactive.app_error", appErr.Id)
+	})
+
+	t.Run("deleted when mfa is deactivated", func(t *testing.T) {
+		_, appErr := th.App.GenerateMfaRecoveryCodes(th.Context, user.Id)
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.DeactivateMfa(user.Id))
+
+		remaining, err := th.Server.Store().MfaRecoveryCode().GetUnusedForUser(user.Id)
+		require.NoError(t, err)
+		assert.Empty(t, remaining)
+	})
+}
+
+func TestMfaTrustedDevices(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupMfaRecovery(t)
+	user := createMfaUser(t, th)
+
+	deviceID := "android_rn-v2:" + model.NewId()
+	device, token, appErr := th.App.TrustMfaDevice(th.Context, user.Id, deviceID)
+	require.Nil(t, appErr)
+	assert.Equal(t, device.CreateAt+30*24*60*60*1000, device.ExpiresAt)
+
+	t.Run("trusted device", func(t *testing.T) {
+		appErr := th.App.CheckUserMfa(th.Context, user, model.MfaTrustedDeviceMfaToken(token, deviceID))
+		require.Nil(t, appErr)
+
+		// It isn't used up.
+		appErr = th.App.CheckUserMfa(th.Context, user, model.MfaTrustedDeviceMfaToken(token, deviceID))
+		require.Nil(t, appErr)
+	})
+
+	t.Run("bound to the device", func(t *testing.T) {
+		appErr := th.App.CheckUserMfa(th.Context, user, model.MfaTrustedDeviceMfaToken(token, "ios_rn-v2:"+model.NewId()))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "mfa.validate_token.authenticate.app_error", appErr.Id)
+
+		appErr = th.App.CheckUserMfa(th.Context, user, model.MfaTrustedDeviceMfaToken(token, ""))
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("bound to the user", func(t *testing.T) {
+		other := createMfaUser(t, th)
+		appErr := th.App.CheckUserMfa(th.Context, other, model.MfaTrustedDeviceMfaToken(token, deviceID))
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MfaTrustedDeviceDays = 0 })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MfaTrustedDeviceDays = 30 })
+
+		appErr := th.App.CheckUserMfa(th.Context, user, model.MfaTrustedDeviceMfaToken(token, deviceID))
+		require.NotNil(t, appErr)
+
+		_, _, appErr = th.App.TrustMfaDevice(th.Context, user.Id, deviceID)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.mfa_trusted_device.disabled.app_error", appErr.Id)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		require.Nil(t, th.App.RevokeMfaTrustedDevices(th.Context, user.Id))
+
+		appErr := th.App.CheckUserMfa(th.Context, user, model.MfaTrustedDeviceMfaToken(token, deviceID))
+		require.NotNil(t, appErr)
+	})
+}
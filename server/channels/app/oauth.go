@@ -31,6 +31,7 @@ import (
 const (
 	OAuthCookieMaxAgeSeconds = 30 * 60 // 30 minutes
 	CookieOAuth              = "MMOAUTH"
+	CookieOAuthPKCE          = "MMOAUTHPKCE"
 	OpenIDScope              = "openid"
 )
 
@@ -128,6 +129,10 @@ func (a *App) DeleteOAuthApp(rctx request.CTX, appID string) *model.AppError {
 		return model.NewAppError("DeleteOAuthApp", "app.oauth.delete_app.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if appErr := a.disableOAuthAppBot(rctx, appID); appErr != nil {
+		rctx.Logger().Warn("Failed to deactivate the bot of the OAuth app", mlog.String("app_id", appID), mlog.Err(appErr))
+	}
+
 	if err := a.Srv().InvalidateAllCaches(); err != nil {
 		rctx.Logger().Warn("error in invalidating cache", mlog.Err(err))
 	}
@@ -233,6 +238,17 @@ func (a *App) AllowOAuthAppAccessToUser(rctx request.CTX, userID string, authReq
 		return "", model.NewAppError("AllowOAuthAppAccessToUser", "api.oauth.allow_oauth.redirect_callback.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	// Record the scopes granted rather than those requested, so the tokens issued are
+	// restricted to the scopes of the app when none were requested.
+	scopes, appErr := oauthApp.GrantScopes(authRequest.Scope)
+	if appErr != nil {
+		return "", appErr
+	}
+	authRequest.Scope = model.DefaultScope
+	if len(scopes) > 0 {
+		authRequest.Scope = strings.Join(scopes, " ")
+	}
+
 	// Validate PKCE requirements for public clients
 	if oauthApp.IsPublicClient() && authRequest.ResponseType == model.AuthCodeResponseType && authRequest.CodeChallenge == "" {
 		return "", model.NewAppError("AllowOAuthAppAccessToUser", "api.oauth.allow_oauth.pkce_required_public.app_error", nil, "", http.StatusBadRequest)
@@ -285,7 +301,7 @@ func (a *App) GetOAuthAccessTokenForImplicitFlow(rctx request.CTX, userID string
 		return nil, err
 	}
 
-	session, err := a.newSession(rctx, oauthApp, user)
+	session, err := a.newSession(rctx, oauthApp, user, authRequest.Scope)
 	if err != nil {
 		return nil, err
 	}
@@ -425,6 +441,11 @@ func (a *App) generateAccessTokenResponse(rctx request.CTX, oauthApp *model.OAut
 	}
 
 	if accessData != nil {
+		// A new authorization with other scopes replaces the previous token.
+		if accessData.Scope != scope {
+			accessData.Scope = scope
+			return a.newSessionUpdateToken(rctx, oauthApp, accessData, user, audience)
+		}
 		return a.handleExistingAccessData(rctx, oauthApp, accessData, user, audience)
 	}
 
@@ -448,12 +469,13 @@ func (a *App) handleExistingAccessData(rctx request.CTX, oauthApp *model.OAuthAp
 		TokenType:        model.AccessTokenType,
 		RefreshToken:     refreshToken,
 		ExpiresInSeconds: int32((accessData.ExpiresAt - model.GetMillis()) / 1000),
+		Scope:            accessData.Scope,
 		Audience:         audienceStr,
 	}, nil
 }
 
 func (a *App) createNewAccessData(rctx request.CTX, oauthApp *model.OAuthApp, user *model.User, clientId, redirectURI, scope string, audience string) (*model.AccessResponse, *model.AppError) {
-	session, err := a.newSession(rctx, oauthApp, user)
+	session, err := a.newSession(rctx, oauthApp, user, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -490,11 +512,12 @@ func (a *App) createNewAccessData(rctx request.CTX, oauthApp *model.OAuthApp, us
 		TokenType:        model.AccessTokenType,
 		RefreshToken:     refreshTokenResponse,
 		ExpiresInSeconds: int32(*a.Config().ServiceSettings.SessionLengthSSOInHours * 60 * 60),
+		Scope:            scope,
 		Audience:         audienceStr,
 	}, nil
 }
 
-func (a *App) newSession(rctx request.CTX, app *model.OAuthApp, user *model.User) (*model.Session, *model.AppError) {
+func (a *App) newSession(rctx request.CTX, app *model.OAuthApp, user *model.User, scope string) (*model.Session, *model.AppError) {
 	if err := a.limitNumberOfSessions(rctx, user.Id); err != nil {
 		return nil, model.NewAppError("newSession", "api.oauth.get_access_token.internal_session.app_error", nil,
 			"", http.StatusInternalServerError).Wrap(err)
@@ -509,6 +532,10 @@ func (a *App) newSession(rctx request.CTX, app *model.OAuthApp, user *model.User
 	session.AddProp(model.SessionPropMattermostAppID, app.MattermostAppID)
 	session.AddProp(model.SessionPropOs, "OAuth2")
 	session.AddProp(model.SessionPropBrowser, "OAuth2")
+	session.SetOAuthScopes(model.ParseOAuthScope(scope))
+	if user.IsBot {
+		session.AddProp(model.SessionPropIsBot, model.SessionPropIsBotValue)
+	}
 
 	session, err := a.Srv().Store().Session().Save(rctx, session)
 	if err != nil {
@@ -528,7 +555,7 @@ func (a *App) newSessionUpdateToken(rctx request.CTX, app *model.OAuthApp, acces
 		rctx.Logger().Warn("error removing access data token from session", mlog.Err(err))
 	}
 
-	session, err := a.newSession(rctx, app, user)
+	session, err := a.newSession(rctx, app, user, accessData.Scope)
 	if err != nil {
 		return nil, err
 	}
@@ -556,6 +583,7 @@ func (a *App) newSessionUpdateToken(rctx request.CTX, app *model.OAuthApp, acces
 		RefreshToken:     accessData.RefreshToken,
 		TokenType:        model.AccessTokenType,
 		ExpiresInSeconds: int32(*a.Config().ServiceSettings.SessionLengthSSOInHours * 60 * 60),
+		Scope:            accessData.Scope,
 		Audience:         audienceStr,
 	}
 
@@ -703,24 +731,39 @@ func (a *App) RevokeAccessToken(rctx request.CTX, token string) *model.AppError
 func (a *App) CompleteOAuth(rctx request.CTX, service string, body io.ReadCloser, props map[string]string, tokenUser *model.User) (*model.User, *model.AppError) {
 	defer body.Close()
 
+	userData, err := io.ReadAll(body)
+	if err != nil {
+		return nil, model.NewAppError("CompleteOAuth", "api.user.login_by_oauth.parse.app_error",
+			map[string]any{"Service": service}, "", http.StatusBadRequest).Wrap(err)
+	}
+
 	action := props["action"]
 
 	// Extract invite token or ID from props so we can add the user to the team if needed
 	inviteToken := props["invite_token"]
 	inviteId := props["invite_id"]
 
+	var user *model.User
+	var appErr *model.AppError
 	switch action {
 	case model.OAuthActionSignup:
-		return a.CreateOAuthUser(rctx, service, body, inviteToken, inviteId, tokenUser)
+		user, appErr = a.CreateOAuthUser(rctx, service, bytes.NewReader(userData), inviteToken, inviteId, tokenUser)
 	case model.OAuthActionLogin:
-		return a.LoginByOAuth(rctx, service, body, inviteToken, inviteId, tokenUser)
+		user, appErr = a.LoginByOAuth(rctx, service, bytes.NewReader(userData), inviteToken, inviteId, tokenUser)
 	case model.OAuthActionEmailToSSO:
-		return a.CompleteSwitchWithOAuth(rctx, service, body, props["email"], tokenUser)
+		user, appErr = a.CompleteSwitchWithOAuth(rctx, service, bytes.NewReader(userData), props["email"], tokenUser)
 	case model.OAuthActionSSOToEmail:
-		return a.LoginByOAuth(rctx, service, body, inviteToken, inviteId, tokenUser)
+		user, appErr = a.LoginByOAuth(rctx, service, bytes.NewReader(userData), inviteToken, inviteId, tokenUser)
 	default:
-		return a.LoginByOAuth(rctx, service, body, inviteToken, inviteId, tokenUser)
+		user, appErr = a.LoginByOAuth(rctx, service, bytes.NewReader(userData), inviteToken, inviteId, tokenUser)
 	}
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	a.syncOAuthUserClaims(rctx, service, userData, user)
+
+	return user, nil
 }
 
 func (a *App) getSSOProvider(service string) (einterfaces.OAuthProvider, *model.AppError) {
@@ -729,7 +772,7 @@ func (a *App) getSSOProvider(service string) (einterfaces.OAuthProvider, *model.
 		return nil, model.NewAppError("getSSOProvider", "api.user.authorize_oauth_user.unsupported.app_error", nil, "service="+service, http.StatusNotImplemented)
 	}
 	providerType := service
-	if strings.Contains(*sso.Scope, OpenIDScope) {
+	if !model.IsOpenIdProviderService(service) && strings.Contains(*sso.Scope, OpenIDScope) {
 		providerType = model.ServiceOpenid
 	}
 	provider := einterfaces.GetOAuthProvider(providerType)
@@ -995,6 +1038,27 @@ func (a *App) GetAuthorizationCode(rctx request.CTX, w http.ResponseWriter, r *h
 
 	authURL := endpoint + "?response_type=code&client_id=" + clientId + "&redirect_uri=" + url.QueryEscape(redirectURI) + "&state=" + url.QueryEscape(state)
 
+	if a.usePKCE(service) {
+		// The code verifier is kept by the browser, so that an intercepted
+		// authorization code cannot be exchanged without it.
+		verifier, err := newPKCECodeVerifier()
+		if err != nil {
+			return "", model.NewAppError("GetAuthorizationCode", "api.user.get_authorization_code.pkce.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     CookieOAuthPKCE,
+			Value:    verifier,
+			Path:     subpath,
+			MaxAge:   OAuthCookieMaxAgeSeconds,
+			Expires:  expiresAt,
+			HttpOnly: true,
+			Secure:   secure,
+		})
+
+		authURL += "&code_challenge=" + pkceCodeChallenge(verifier) + "&code_challenge_method=" + model.PKCECodeChallengeMethodS256
+	}
+
 	if scope != "" {
 		authURL += "&scope=" + utils.URLEncode(scope)
 	}
@@ -1075,6 +1139,22 @@ func (a *App) AuthorizeOAuthUser(rctx request.CTX, w http.ResponseWriter, r *htt
 	p.Set("grant_type", model.AccessTokenGrantType)
 	p.Set("redirect_uri", redirectURI)
 
+	if a.usePKCE(service) {
+		verifierCookie, cookieErr := r.Cookie(CookieOAuthPKCE)
+		if cookieErr != nil {
+			return nil, stateProps, nil, model.NewAppError("AuthorizeOAuthUser", "api.user.authorize_oauth_user.invalid_state.app_error", nil, "", http.StatusBadRequest).Wrap(cookieErr)
+		}
+		p.Set("code_verifier", verifierCookie.Value)
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     CookieOAuthPKCE,
+			Value:    "",
+			Path:     subpath,
+			MaxAge:   -1,
+			HttpOnly: true,
+		})
+	}
+
 	req, requestErr := http.NewRequest("POST", *sso.TokenEndpoint, strings.NewReader(p.Encode()))
 	if requestErr != nil {
 		return nil, stateProps, nil, model.NewAppError("AuthorizeOAuthUser", "api.user.authorize_oauth_user.token_failed.app_error", nil, "", http.StatusInternalServerError).Wrap(requestErr)
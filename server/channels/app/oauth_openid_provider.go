@@ -0,0 +1,209 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+// usePKCE returns whether the authorization code of the service is protected
+// with a PKCE code challenge.
+func (a *App) usePKCE(service string) bool {
+	provider := a.Config().OpenIdProviderSettings.GetProvider(service)
+	return provider != nil && *provider.EnablePKCE
+}
+
+func newPKCECodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func pkceCodeChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// syncOAuthUserClaims synchronizes the group memberships and the custom
+// profile attributes of the user with the claims mapped by the provider of
+// the service, if it maps any. Failures are logged without failing the login.
+func (a *App) syncOAuthUserClaims(rctx request.CTX, service string, userData []byte, user *model.User) {
+	provider, appErr := a.getSSOProvider(service)
+	if appErr != nil {
+		return
+	}
+
+	claimsProvider, ok := provider.(einterfaces.OAuthClaimsProvider)
+	if !ok {
+		return
+	}
+
+	logger := rctx.Logger().With(mlog.String("service", service), mlog.String("user_id", user.Id))
+
+	settings, err := provider.GetSSOSettings(rctx, a.Config(), service)
+	if err != nil {
+		logger.Warn("Failed to get the settings to synchronize the OAuth claims", mlog.Err(err))
+		return
+	}
+
+	claims, err := claimsProvider.GetClaimsFromJSON(rctx, bytes.NewReader(userData), settings)
+	if err != nil {
+		logger.Warn("Failed to read the OAuth claims", mlog.Err(err))
+		return
+	}
+	if claims == nil {
+		return
+	}
+
+	if claims.Groups != nil {
+		if appErr := a.syncOAuthUserGroups(rctx, model.GroupSource(service), user.Id, claims.Groups); appErr != nil {
+			logger.Warn("Failed to synchronize the groups of the OAuth user", mlog.Err(appErr))
+		}
+	}
+
+	if len(claims.Attributes) > 0 {
+		if appErr := a.syncOAuthUserAttributes(rctx, user.Id, claims.Attributes); appErr != nil {
+			logger.Warn("Failed to synchronize the custom profile attributes of the OAuth user", mlog.Err(appErr))
+		}
+	}
+}
+
+// syncOAuthUserGroups makes the user a member of exactly the groups of the
+// source with the remote IDs, creating the groups that do not exist yet.
+// Groups that were deleted are not restored.
+func (a *App) syncOAuthUserGroups(rctx request.CTX, source model.GroupSource, userID string, remoteIDs []string) *model.AppError {
+	current, appErr := a.GetGroupsByUserId(userID, model.GroupSearchOpts{})
+	if appErr != nil {
+		return appErr
+	}
+
+	isMember := make(map[string]bool)
+	for _, group := range current {
+		if group.Source == source {
+			isMember[group.Id] = false
+		}
+	}
+
+	for _, remoteID := range remoteIDs {
+		if remoteID == "" || len(remoteID) > model.GroupRemoteIDMaxLength {
+			rctx.Logger().Debug("Skipping a group with an invalid remote ID", mlog.String("remote_id", remoteID))
+			continue
+		}
+
+		group, appErr := a.GetGroupByRemoteID(remoteID, source)
+		if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+			return appErr
+		}
+		if group == nil {
+			displayName := remoteID
+			if len(displayName) > model.GroupDisplayNameMaxLength {
+				displayName = displayName[:model.GroupDisplayNameMaxLength]
+			}
+
+			group, appErr = a.CreateGroup(&model.Group{
+				DisplayName: displayName,
+				Source:      source,
+				RemoteId:    model.NewPointer(remoteID),
+			})
+			if appErr != nil {
+				return appErr
+			}
+		}
+		if group.DeleteAt != 0 {
+			continue
+		}
+
+		if _, ok := isMember[group.Id]; !ok {
+			if _, appErr := a.UpsertGroupMember(group.Id, userID); appErr != nil {
+				return appErr
+			}
+		}
+		isMember[group.Id] = true
+	}
+
+	for groupID, keep := range isMember {
+		if keep {
+			continue
+		}
+		if _, appErr := a.DeleteGroupMember(groupID, userID); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
+
+// syncOAuthUserAttributes sets the custom profile attributes of the user from
+// their values by attribute name. The values of select and multiselect
+// attributes are the names of their options. Unknown attributes and options
+// are skipped.
+func (a *App) syncOAuthUserAttributes(rctx request.CTX, userID string, attributes map[string][]string) *model.AppError {
+	fields, appErr := a.ListCPAFields(anonymousCallerId)
+	if appErr != nil {
+		return appErr
+	}
+
+	values := make(map[string]json.RawMessage, len(attributes))
+	for _, field := range fields {
+		claimValues, ok := attributes[field.Name]
+		if !ok {
+			continue
+		}
+
+		var value any
+		switch field.Type {
+		case model.PropertyFieldTypeText, model.PropertyFieldTypeDate:
+			if len(claimValues) != 1 {
+				continue
+			}
+			value = claimValues[0]
+		case model.PropertyFieldTypeSelect, model.PropertyFieldTypeMultiselect:
+			optionIDs := make([]string, 0, len(claimValues))
+			for _, claimValue := range claimValues {
+				for _, option := range field.Attrs.Options {
+					if option.Name == claimValue {
+						optionIDs = append(optionIDs, option.ID)
+						break
+					}
+				}
+			}
+
+			if field.Type == model.PropertyFieldTypeMultiselect {
+				value = optionIDs
+			} else if len(optionIDs) == 1 {
+				value = optionIDs[0]
+			} else {
+				continue
+			}
+		default:
+			continue
+		}
+
+		rawValue, err := json.Marshal(value)
+		if err != nil {
+			return model.NewAppError("syncOAuthUserAttributes", "app.custom_profile_attributes.validate_value.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		values[field.ID] = rawValue
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	_, appErr = a.PatchCPAValues(anonymousCallerId, userID, values, true)
+	return appErr
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPKCECodeChallenge(t *testing.T) {
+	// Example from RFC 7636, appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuzSobtpW6M", pkceCodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+
+	verifier, err := newPKCECodeVerifier()
+	require.NoError(t, err)
+	assert.Len(t, verifier, 43)
+
+	other, err := newPKCECodeVerifier()
+	require.NoError(t, err)
+	assert.NotEqual(t, verifier, other)
+}
+
+func TestSyncOAuthUserGroups(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	source := model.GroupSource(model.ServiceOpenidProviderPrefix + "staff")
+
+	memberRemoteIDs := func(t *testing.T) []string {
+		t.Helper()
+		groups, appErr := th.App.GetGroupsByUserId(th.BasicUser.Id, model.GroupSearchOpts{})
+		require.Nil(t, appErr)
+
+		var remoteIDs []string
+		for _, group := range groups {
+			if group.Source == source {
+				remoteIDs = append(remoteIDs, *group.RemoteId)
+			}
+		}
+		return remoteIDs
+	}
+
+	t.Run("creates the groups and adds the user", func(t *testing.T) {
+		appErr := th.App.syncOAuthUserGroups(th.Context, source, th.BasicUser.Id, []string{"engineering", "support"})
+		require.Nil(t, appErr)
+
+		assert.ElementsMatch(t, []string{"engineering", "support"}, memberRemoteIDs(t))
+
+		group, appErr := th.App.GetGroupByRemoteID("engineering", source)
+		require.Nil(t, appErr)
+		assert.Equal(t, "engineering", group.DisplayName)
+	})
+
+	t.Run("removes the user from the groups missing from the claim", func(t *testing.T) {
+		appErr := th.App.syncOAuthUserGroups(th.Context, source, th.BasicUser.Id, []string{"support", "sales", ""})
+		require.Nil(t, appErr)
+
+		assert.ElementsMatch(t, []string{"support", "sales"}, memberRemoteIDs(t))
+	})
+
+	t.Run("leaves the groups of other sources untouched", func(t *testing.T) {
+		group, appErr := th.App.CreateGroup(&model.Group{
+			DisplayName:    "custom",
+			Name:           model.NewPointer("custom" + model.NewId()),
+			Source:         model.GroupSourceCustom,
+			AllowReference: true,
+		})
+		require.Nil(t, appErr)
+		_, appErr = th.App.UpsertGroupMember(group.Id, th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		appErr = th.App.syncOAuthUserGroups(th.Context, source, th.BasicUser.Id, []string{})
+		require.Nil(t, appErr)
+
+		assert.Empty(t, memberRemoteIDs(t))
+
+		groups, appErr := th.App.GetGroupsByUserId(th.BasicUser.Id, model.GroupSearchOpts{})
+		require.Nil(t, appErr)
+		require.Len(t, groups, 1)
+		assert.Equal(t, group.Id, groups[0].Id)
+	})
+}
+
+func TestSyncOAuthUserAttributes(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	cpaID, cErr := th.App.CpaGroupID()
+	require.NoError(t, cErr)
+
+	textField, err := model.NewCPAFieldFromPropertyField(&model.PropertyField{
+		GroupID: cpaID,
+		Name:    "Department",
+		Type:    model.PropertyFieldTypeText,
+	})
+	require.NoError(t, err)
+	textField, appErr := th.App.CreateCPAField(anonymousCallerId, textField)
+	require.Nil(t, appErr)
+
+	selectField, err := model.NewCPAFieldFromPropertyField(&model.PropertyField{
+		GroupID: cpaID,
+		Name:    "Location",
+		Type:    model.PropertyFieldTypeMultiselect,
+		Attrs: map[string]any{
+			model.PropertyFieldAttributeOptions: []any{
+				map[string]any{"name": "Berlin"},
+				map[string]any{"name": "Lisbon"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	selectField, appErr = th.App.CreateCPAField(anonymousCallerId, selectField)
+	require.Nil(t, appErr)
+
+	appErr = th.App.syncOAuthUserAttributes(th.Context, th.BasicUser.Id, map[string][]string{
+		"Department": {"Engineering"},
+		"Location":   {"Lisbon", "Unknown"},
+		"Unknown":    {"value"},
+	})
+	require.Nil(t, appErr)
+
+	values, appErr := th.App.ListCPAValues(anonymousCallerId, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	byField := make(map[string]json.RawMessage, len(values))
+	for _, value := range values {
+		byField[value.FieldID] = value.Value
+	}
+
+	assert.JSONEq(t, `"Engineering"`, string(byField[textField.ID]))
+
+	var lisbonID string
+	for _, option := range selectField.Attrs.Options {
+		if option.Name == "Lisbon" {
+			lisbonID = option.ID
+		}
+	}
+	require.NotEmpty(t, lisbonID)
+	assert.JSONEq(t, `["`+lisbonID+`"]`, string(byField[selectField.ID]))
+}
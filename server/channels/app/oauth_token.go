@@ -0,0 +1,262 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const oauthAppBotUsernamePrefix = "oauth-app-"
+
+// GetOAuthAccessTokenForClientCredentials issues an access token acting as the bot user of an app,
+// as per the client credentials grant of RFC 6749. Only trusted confidential apps may use the
+// grant. No refresh token is issued, as the app can always request a new access token, which
+// replaces the previous one.
+func (a *App) GetOAuthAccessTokenForClientCredentials(rctx request.CTX, clientId, secret, scope, resource string) (*model.AccessResponse, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("GetOAuthAccessTokenForClientCredentials", "api.oauth.get_access_token.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	oauthApp, nErr := a.Srv().Store().OAuth().GetApp(clientId)
+	if nErr != nil {
+		return nil, model.NewAppError("GetOAuthAccessTokenForClientCredentials", "api.oauth.get_access_token.credentials.app_error", nil, "", http.StatusNotFound).Wrap(nErr)
+	}
+
+	if err := oauthApp.ValidateForGrantType(model.GrantTypeClientCredentials, secret, ""); err != nil {
+		return nil, err
+	}
+
+	if !oauthApp.IsTrusted {
+		return nil, model.NewAppError("GetOAuthAccessTokenForClientCredentials", "api.oauth.get_access_token.client_credentials_untrusted.app_error", nil, "client_id="+clientId, http.StatusForbidden)
+	}
+
+	if err := model.ValidateResourceParameter(resource, clientId, "GetOAuthAccessTokenForClientCredentials"); err != nil {
+		return nil, err
+	}
+
+	scopes, appErr := oauthApp.GrantScopes(scope)
+	if appErr != nil {
+		return nil, appErr
+	}
+	grantedScope := model.DefaultScope
+	if len(scopes) > 0 {
+		grantedScope = strings.Join(scopes, " ")
+	}
+
+	bot, appErr := a.getOrCreateOAuthAppBot(rctx, oauthApp)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	previous, nErr := a.Srv().Store().OAuth().GetPreviousAccessData(bot.Id, oauthApp.Id)
+	if nErr != nil {
+		return nil, model.NewAppError("GetOAuthAccessTokenForClientCredentials", "api.oauth.get_access_token.internal.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+	if previous != nil {
+		if appErr := a.RevokeAccessToken(rctx, previous.Token); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	session, appErr := a.newSession(rctx, oauthApp, bot, grantedScope)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	accessData := &model.AccessData{
+		ClientId: oauthApp.Id,
+		UserId:   bot.Id,
+		Token:    session.Token,
+		// There is no redirect in this grant, but the access data requires a valid URL.
+		RedirectUri: oauthApp.CallbackUrls[0],
+		ExpiresAt:   session.ExpiresAt,
+		Scope:       grantedScope,
+		Audience:    resource,
+	}
+	if _, nErr := a.Srv().Store().OAuth().SaveAccessData(accessData); nErr != nil {
+		return nil, model.NewAppError("GetOAuthAccessTokenForClientCredentials", "api.oauth.get_access_token.internal_saving.app_error", nil, "", http.StatusInternalServerError).Wrap(nErr)
+	}
+
+	return &model.AccessResponse{
+		AccessToken:      session.Token,
+		TokenType:        model.AccessTokenType,
+		ExpiresInSeconds: int32((session.ExpiresAt - model.GetMillis()) / 1000),
+		Scope:            grantedScope,
+		Audience:         resource,
+	}, nil
+}
+
+// getOrCreateOAuthAppBot returns the bot user owned by the app that its client credentials tokens
+// act as, creating it on first use. Deactivating the bot stops the app from getting tokens.
+func (a *App) getOrCreateOAuthAppBot(rctx request.CTX, oauthApp *model.OAuthApp) (*model.User, *model.AppError) {
+	bots, appErr := a.GetBots(rctx, &model.BotGetOptions{OwnerId: oauthApp.Id, IncludeDeleted: true, PerPage: 1})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var bot *model.Bot
+	if len(bots) > 0 {
+		bot = bots[0]
+	} else {
+		bot, appErr = a.CreateBot(rctx, &model.Bot{
+			Username:    oauthAppBotUsernamePrefix + oauthApp.Id,
+			DisplayName: oauthApp.Name,
+			OwnerId:     oauthApp.Id,
+		})
+		if appErr != nil {
+			return nil, appErr
+		}
+		rctx.Logger().Info("Created the bot of an OAuth app", mlog.String("client_id", oauthApp.Id), mlog.String("bot_user_id", bot.UserId))
+	}
+
+	if bot.DeleteAt != 0 {
+		return nil, model.NewAppError("getOrCreateOAuthAppBot", "api.oauth.get_access_token.client_credentials_bot_disabled.app_error", nil, "client_id="+oauthApp.Id, http.StatusForbidden)
+	}
+
+	return a.GetUser(bot.UserId)
+}
+
+// disableOAuthAppBot deactivates the bot of a deleted app, if any.
+func (a *App) disableOAuthAppBot(rctx request.CTX, appID string) *model.AppError {
+	bots, appErr := a.GetBots(rctx, &model.BotGetOptions{OwnerId: appID, PerPage: 1})
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, bot := range bots {
+		if _, appErr := a.UpdateBotActive(rctx, bot.UserId, false); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
+
+// IntrospectOAuthToken describes an access or refresh token issued to the requesting client, as
+// per RFC 7662. Only confidential clients may introspect tokens.
+func (a *App) IntrospectOAuthToken(rctx request.CTX, clientId, secret, token, tokenTypeHint string) (*model.OAuthIntrospectionResponse, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("IntrospectOAuthToken", "api.oauth.allow_oauth.turn_off.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	oauthApp, appErr := a.authenticateOAuthClient(clientId, secret)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if oauthApp.IsPublicClient() {
+		return nil, model.NewAppError("IntrospectOAuthToken", "api.oauth.introspect.public_client.app_error", nil, "client_id="+clientId, http.StatusUnauthorized)
+	}
+
+	inactive := &model.OAuthIntrospectionResponse{Active: false}
+
+	accessData, isRefreshToken := a.findOAuthAccessData(token, tokenTypeHint)
+	if accessData == nil || accessData.ClientId != oauthApp.Id {
+		return inactive, nil
+	}
+
+	user, appErr := a.GetUser(accessData.UserId)
+	if appErr != nil || user.DeleteAt != 0 {
+		return inactive, nil
+	}
+
+	rsp := &model.OAuthIntrospectionResponse{
+		Active:   true,
+		Scope:    accessData.Scope,
+		ClientId: accessData.ClientId,
+		Username: user.Username,
+		Subject:  user.Id,
+		Audience: accessData.Audience,
+		Issuer:   *a.Config().ServiceSettings.SiteURL,
+	}
+
+	if !isRefreshToken {
+		session, appErr := a.GetSession(accessData.Token)
+		if appErr != nil {
+			return inactive, nil
+		}
+
+		rsp.TokenType = model.AccessTokenType
+		rsp.ExpiresAt = session.ExpiresAt / 1000
+		rsp.IssuedAt = session.CreateAt / 1000
+	}
+
+	return rsp, nil
+}
+
+// RevokeOAuthToken revokes an access or refresh token issued to the requesting client, as per
+// RFC 7009, along with the rest of its grant. Revoking an unknown token succeeds.
+func (a *App) RevokeOAuthToken(rctx request.CTX, clientId, secret, token, tokenTypeHint string) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return model.NewAppError("RevokeOAuthToken", "api.oauth.allow_oauth.turn_off.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	oauthApp, appErr := a.authenticateOAuthClient(clientId, secret)
+	if appErr != nil {
+		return appErr
+	}
+
+	accessData, _ := a.findOAuthAccessData(token, tokenTypeHint)
+	if accessData == nil {
+		return nil
+	}
+
+	if accessData.ClientId != oauthApp.Id {
+		return model.NewAppError("RevokeOAuthToken", "api.oauth.revoke.unauthorized_client.app_error", nil, "client_id="+clientId, http.StatusBadRequest)
+	}
+
+	return a.RevokeAccessToken(rctx, accessData.Token)
+}
+
+// authenticateOAuthClient returns the app with the given client ID, once its client has presented
+// valid credentials.
+func (a *App) authenticateOAuthClient(clientId, secret string) (*model.OAuthApp, *model.AppError) {
+	if !model.IsValidId(clientId) {
+		return nil, model.NewAppError("authenticateOAuthClient", "api.oauth.get_access_token.bad_client_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	oauthApp, nErr := a.Srv().Store().OAuth().GetApp(clientId)
+	if nErr != nil {
+		return nil, model.NewAppError("authenticateOAuthClient", "api.oauth.get_access_token.credentials.app_error", nil, "", http.StatusUnauthorized).Wrap(nErr)
+	}
+
+	if err := oauthApp.Authenticate(secret); err != nil {
+		return nil, err
+	}
+
+	return oauthApp, nil
+}
+
+// findOAuthAccessData returns the access data of an access or refresh token, looking the token up
+// as the hinted type first, and whether it is a refresh token. Unknown tokens return no access
+// data.
+func (a *App) findOAuthAccessData(token, tokenTypeHint string) (*model.AccessData, bool) {
+	if token == "" {
+		return nil, false
+	}
+
+	lookups := []bool{false, true}
+	if tokenTypeHint == model.TokenTypeHintRefreshToken {
+		lookups = []bool{true, false}
+	}
+
+	for _, isRefreshToken := range lookups {
+		var accessData *model.AccessData
+		var err error
+		if isRefreshToken {
+			accessData, err = a.Srv().Store().OAuth().GetAccessDataByRefreshToken(token)
+		} else {
+			accessData, err = a.Srv().Store().OAuth().GetAccessData(token)
+		}
+		if err == nil && accessData != nil {
+			return accessData, isRefreshToken
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,391 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package oauthoidc
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+const idTokenLeeway = 1 * time.Minute
+
+var idTokenSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// OpenIdProvider implements the OAuth provider of one of the OpenID Connect
+// providers configured in model.OpenIdProviderSettings.
+//
+// The endpoints of the provider are read from its discovery endpoint and the
+// ID tokens are validated against its published keys. The settings of the
+// provider are refreshed by GetSSOSettings, which the OAuth flows call before
+// any other method of the provider.
+type OpenIdProvider struct {
+	service string
+
+	mut               sync.Mutex
+	settings          *model.OpenIdProvider
+	metadata          *providerMetadata
+	metadataEndpoint  string
+	metadataFetchedAt time.Time
+	keys              map[string]crypto.PublicKey
+	keysFetchedAt     time.Time
+}
+
+func init() {
+	einterfaces.RegisterOAuthProviderFactory(model.ServiceOpenidProviderPrefix, func(service string) einterfaces.OAuthProvider {
+		return NewOpenIdProvider(service)
+	})
+}
+
+func NewOpenIdProvider(service string) *OpenIdProvider {
+	return &OpenIdProvider{service: service}
+}
+
+func (op *OpenIdProvider) GetSSOSettings(_ request.CTX, config *model.Config, service string) (*model.SSOSettings, error) {
+	settings := config.OpenIdProviderSettings.GetProvider(service)
+	if settings == nil {
+		return nil, fmt.Errorf("no OpenID Connect provider is configured for the %s service", service)
+	}
+
+	metadata, err := op.discover(*settings.DiscoveryEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	op.mut.Lock()
+	op.settings = settings
+	op.mut.Unlock()
+
+	ssoSettings := settings.SSOSettings()
+	ssoSettings.AuthEndpoint = model.NewPointer(metadata.AuthorizationEndpoint)
+	ssoSettings.TokenEndpoint = model.NewPointer(metadata.TokenEndpoint)
+	ssoSettings.UserAPIEndpoint = model.NewPointer(metadata.UserinfoEndpoint)
+
+	return ssoSettings, nil
+}
+
+// discover returns the metadata of the provider, fetching it again once it
+// is older than metadataMaxAge or if the discovery endpoint changed.
+func (op *OpenIdProvider) discover(discoveryEndpoint string) (*providerMetadata, error) {
+	op.mut.Lock()
+	defer op.mut.Unlock()
+
+	if op.metadata != nil && op.metadataEndpoint == discoveryEndpoint && time.Since(op.metadataFetchedAt) < metadataMaxAge {
+		return op.metadata, nil
+	}
+
+	metadata, err := fetchMetadata(discoveryEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover the OpenID Connect provider: %w", err)
+	}
+
+	if op.metadata == nil || op.metadata.JWKSURI != metadata.JWKSURI {
+		op.keys = nil
+	}
+	op.metadata = metadata
+	op.metadataEndpoint = discoveryEndpoint
+	op.metadataFetchedAt = time.Now()
+
+	return metadata, nil
+}
+
+// current returns the settings and the metadata of the provider as of the
+// last call to GetSSOSettings.
+func (op *OpenIdProvider) current() (*model.OpenIdProvider, *providerMetadata, error) {
+	op.mut.Lock()
+	defer op.mut.Unlock()
+
+	if op.settings == nil || op.metadata == nil {
+		return nil, nil, errors.New("the OpenID Connect provider settings have not been loaded")
+	}
+
+	return op.settings, op.metadata, nil
+}
+
+// key returns the public key with the ID, fetching the keys of the provider
+// again if the key is unknown, as the provider may have rotated them.
+func (op *OpenIdProvider) key(kid string) (crypto.PublicKey, error) {
+	op.mut.Lock()
+	defer op.mut.Unlock()
+
+	if op.metadata == nil {
+		return nil, errors.New("the OpenID Connect provider settings have not been loaded")
+	}
+
+	lookup := func() (crypto.PublicKey, bool) {
+		if kid == "" && len(op.keys) == 1 {
+			for _, key := range op.keys {
+				return key, true
+			}
+		}
+		key, ok := op.keys[kid]
+		return key, ok
+	}
+
+	if time.Since(op.keysFetchedAt) < keysMaxAge {
+		if key, ok := lookup(); ok {
+			return key, nil
+		}
+		if op.keys != nil && time.Since(op.keysFetchedAt) < keysMinRefreshInterval {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+	}
+
+	keys, err := fetchKeys(op.metadata.JWKSURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the signing keys: %w", err)
+	}
+	op.keys = keys
+	op.keysFetchedAt = time.Now()
+
+	if key, ok := lookup(); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// GetUserFromIdToken validates the signature, the issuer, the audience and
+// the lifetime of the ID token and returns the user described by its claims.
+func (op *OpenIdProvider) GetUserFromIdToken(rctx request.CTX, idToken string) (*model.User, error) {
+	settings, metadata, err := op.current()
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwt.Parse(idToken, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return op.key(kid)
+	},
+		jwt.WithValidMethods(idTokenSigningMethods),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(*settings.Id),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(idTokenLeeway),
+		jwt.WithJSONNumber(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid ID token claims")
+	}
+
+	// An ID token issued to several audiences must name the client it was
+	// issued to.
+	if aud, _ := claims.GetAudience(); len(aud) > 1 {
+		if azp, _ := claims["azp"].(string); azp != *settings.Id {
+			return nil, errors.New("invalid ID token: the authorized party is not this client")
+		}
+	}
+
+	user := userFromClaims(rctx.Logger(), op.service, settings, claims)
+	if *user.AuthData == "" {
+		return nil, errors.New("invalid ID token: missing subject")
+	}
+
+	return user, nil
+}
+
+// GetUserFromJSON returns the user described by the claims of the userinfo
+// response. The claims missing from the response are taken from the ID token,
+// whose subject must match the one of the response.
+func (op *OpenIdProvider) GetUserFromJSON(rctx request.CTX, data io.Reader, tokenUser *model.User, _ *model.SSOSettings) (*model.User, error) {
+	settings, _, err := op.current()
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := claimsFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	user := userFromClaims(rctx.Logger(), op.service, settings, claims)
+	if *user.AuthData == "" {
+		return nil, errors.New("the user info has no subject")
+	}
+
+	if tokenUser != nil {
+		if tokenUser.AuthData != nil && *tokenUser.AuthData != *user.AuthData {
+			return nil, errors.New("the subject of the user info does not match the one of the ID token")
+		}
+
+		if user.Username == "" {
+			user.Username = tokenUser.Username
+		}
+		if user.Email == "" {
+			user.Email = tokenUser.Email
+		}
+		if user.FirstName == "" && user.LastName == "" {
+			user.FirstName = tokenUser.FirstName
+			user.LastName = tokenUser.LastName
+		}
+		if user.Position == "" {
+			user.Position = tokenUser.Position
+		}
+	}
+
+	if user.Email == "" {
+		return nil, errors.New("user e-mail should not be empty")
+	}
+
+	if user.Username == "" {
+		user.Username = model.CleanUsername(rctx.Logger(), strings.Split(user.Email, "@")[0])
+	}
+
+	return user, nil
+}
+
+func (op *OpenIdProvider) IsSameUser(_ request.CTX, dbUser, oauthUser *model.User) bool {
+	return dbUser.AuthData != nil && oauthUser.AuthData != nil && *dbUser.AuthData == *oauthUser.AuthData
+}
+
+// GetClaimsFromJSON returns the groups and the custom profile attributes of
+// the user from the claims of the userinfo response. Groups is nil if the
+// groups claim is not mapped or missing from the response, so that the
+// memberships are left untouched.
+func (op *OpenIdProvider) GetClaimsFromJSON(_ request.CTX, data io.Reader, _ *model.SSOSettings) (*einterfaces.OAuthClaims, error) {
+	settings, _, err := op.current()
+	if err != nil {
+		return nil, err
+	}
+
+	if *settings.GroupsClaim == "" && len(settings.AttributeClaims) == 0 {
+		return nil, nil
+	}
+
+	claims, err := claimsFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	oauthClaims := &einterfaces.OAuthClaims{
+		Attributes: make(map[string][]string, len(settings.AttributeClaims)),
+	}
+
+	if *settings.GroupsClaim != "" {
+		if value, ok := lookupClaim(claims, *settings.GroupsClaim); ok {
+			oauthClaims.Groups = claimStrings(value)
+		}
+	}
+
+	for attribute, claim := range settings.AttributeClaims {
+		if value, ok := lookupClaim(claims, claim); ok {
+			oauthClaims.Attributes[attribute] = claimStrings(value)
+		}
+	}
+
+	return oauthClaims, nil
+}
+
+func claimsFromJSON(data io.Reader) (map[string]any, error) {
+	decoder := json.NewDecoder(data)
+	decoder.UseNumber()
+
+	var claims map[string]any
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func userFromClaims(logger mlog.LoggerIFace, service string, settings *model.OpenIdProvider, claims map[string]any) *model.User {
+	user := &model.User{}
+
+	if username := claimString(claims, *settings.UsernameClaim); username != "" {
+		// to maintain consistency with the other providers, usernames in the
+		// form of an e-mail address are reduced to their local part
+		user.Username = model.CleanUsername(logger, strings.Split(username, "@")[0])
+	}
+
+	user.Email = strings.ToLower(claimString(claims, *settings.EmailClaim))
+	user.FirstName = claimString(claims, *settings.FirstNameClaim)
+	user.LastName = claimString(claims, *settings.LastNameClaim)
+	user.Position = claimString(claims, *settings.PositionClaim)
+
+	subject := claimString(claims, "sub")
+	user.AuthData = &subject
+	user.AuthService = service
+
+	return user
+}
+
+// lookupClaim returns the value of the claim. Names containing dots also
+// match nested claims, such as realm_access.roles.
+func lookupClaim(claims map[string]any, name string) (any, bool) {
+	if name == "" {
+		return nil, false
+	}
+
+	if value, ok := claims[name]; ok {
+		return value, true
+	}
+
+	head, rest, found := strings.Cut(name, ".")
+	if !found {
+		return nil, false
+	}
+	nested, ok := claims[head].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	return lookupClaim(nested, rest)
+}
+
+// claimString returns the value of the claim if it is a single value.
+func claimString(claims map[string]any, name string) string {
+	value, ok := lookupClaim(claims, name)
+	if !ok {
+		return ""
+	}
+
+	values := claimStrings(value)
+	if _, isList := value.([]any); isList || len(values) != 1 {
+		return ""
+	}
+
+	return strings.TrimSpace(values[0])
+}
+
+// claimStrings returns the values of a claim holding a single value or a
+// list of values. Values that are neither strings, numbers nor booleans are
+// skipped.
+func claimStrings(value any) []string {
+	list, ok := value.([]any)
+	if !ok {
+		list = []any{value}
+	}
+
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		switch v := item.(type) {
+		case string:
+			values = append(values, v)
+		case json.Number:
+			values = append(values, v.String())
+		case bool:
+			values = append(values, fmt.Sprint(v))
+		}
+	}
+
+	return values
+}
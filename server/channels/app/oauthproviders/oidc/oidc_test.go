@@ -0,0 +1,330 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package oauthoidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+type testIdentityProvider struct {
+	server     *httptest.Server
+	key        *rsa.PrivateKey
+	kid        string
+	keyFetches atomic.Int32
+}
+
+func newTestIdentityProvider(t *testing.T) *testIdentityProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &testIdentityProvider{key: key, kid: "key1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/auth",
+			"token_endpoint":         idp.server.URL + "/token",
+			"userinfo_endpoint":      idp.server.URL + "/userinfo",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.keyFetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": idp.kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(idp.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(idp.key.E)).Bytes()),
+			}},
+		})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (idp *testIdentityProvider) config(provider *model.OpenIdProvider) *model.Config {
+	provider.Name = model.NewPointer("staff")
+	provider.Enable = model.NewPointer(true)
+	provider.Id = model.NewPointer("mattermost")
+	provider.Secret = model.NewPointer("secret")
+	provider.DiscoveryEndpoint = model.NewPointer(idp.server.URL + "/.well-known/openid-configuration")
+
+	cfg := &model.Config{}
+	cfg.OpenIdProviderSettings.Providers = []*model.OpenIdProvider{provider}
+	cfg.SetDefaults()
+	return cfg
+}
+
+func (idp *testIdentityProvider) idToken(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = idp.kid
+	signed, err := token.SignedString(idp.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func (idp *testIdentityProvider) claims(subject string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                idp.server.URL,
+		"aud":                "mattermost",
+		"sub":                subject,
+		"iat":                time.Now().Unix(),
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"email":              "Jane@Example.com",
+		"preferred_username": "jane.doe@example.com",
+	}
+}
+
+func TestGetSSOSettings(t *testing.T) {
+	rctx := request.TestContext(t)
+	idp := newTestIdentityProvider(t)
+	cfg := idp.config(&model.OpenIdProvider{})
+	provider := NewOpenIdProvider("openid_staff")
+
+	settings, err := provider.GetSSOSettings(rctx, cfg, "openid_staff")
+	require.NoError(t, err)
+	assert.Equal(t, idp.server.URL+"/auth", *settings.AuthEndpoint)
+	assert.Equal(t, idp.server.URL+"/token", *settings.TokenEndpoint)
+	assert.Equal(t, idp.server.URL+"/userinfo", *settings.UserAPIEndpoint)
+	assert.Equal(t, "mattermost", *settings.Id)
+
+	_, err = provider.GetSSOSettings(rctx, cfg, "openid_contractors")
+	require.Error(t, err)
+
+	t.Run("registered through the factory", func(t *testing.T) {
+		assert.IsType(t, &OpenIdProvider{}, einterfaces.GetOAuthProvider("openid_staff"))
+		assert.Same(t, einterfaces.GetOAuthProvider("openid_staff"), einterfaces.GetOAuthProvider("openid_staff"))
+		assert.Nil(t, einterfaces.GetOAuthProvider(model.ServiceOpenidProviderPrefix))
+	})
+}
+
+func TestGetUserFromIdToken(t *testing.T) {
+	rctx := request.TestContext(t)
+	idp := newTestIdentityProvider(t)
+	cfg := idp.config(&model.OpenIdProvider{PositionClaim: model.NewPointer("title")})
+
+	provider := NewOpenIdProvider("openid_staff")
+
+	t.Run("settings not loaded", func(t *testing.T) {
+		_, err := provider.GetUserFromIdToken(rctx, idp.idToken(t, idp.claims("subject")))
+		require.Error(t, err)
+	})
+
+	_, err := provider.GetSSOSettings(rctx, cfg, "openid_staff")
+	require.NoError(t, err)
+
+	t.Run("valid token", func(t *testing.T) {
+		claims := idp.claims("subject")
+		claims["title"] = "Engineer"
+
+		user, err := provider.GetUserFromIdToken(rctx, idp.idToken(t, claims))
+		require.NoError(t, err)
+		assert.Equal(t, "subject", *user.AuthData)
+		assert.Equal(t, "openid_staff", user.AuthService)
+		assert.Equal(t, "jane@example.com", user.Email)
+		assert.Equal(t, "jane.doe", user.Username)
+		assert.Equal(t, "Engineer", user.Position)
+	})
+
+	t.Run("keys are cached", func(t *testing.T) {
+		fetches := idp.keyFetches.Load()
+		_, err := provider.GetUserFromIdToken(rctx, idp.idToken(t, idp.claims("subject")))
+		require.NoError(t, err)
+		assert.Equal(t, fetches, idp.keyFetches.Load())
+	})
+
+	for name, mutate := range map[string]func(jwt.MapClaims){
+		"wrong issuer":       func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"wrong audience":     func(c jwt.MapClaims) { c["aud"] = "other" },
+		"expired":            func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"missing expiration": func(c jwt.MapClaims) { delete(c, "exp") },
+		"missing subject":    func(c jwt.MapClaims) { delete(c, "sub") },
+		"foreign authorized party": func(c jwt.MapClaims) {
+			c["aud"] = []string{"mattermost", "other"}
+			c["azp"] = "other"
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			claims := idp.claims("subject")
+			mutate(claims)
+
+			_, err := provider.GetUserFromIdToken(rctx, idp.idToken(t, claims))
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("invalid signature", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims("subject"))
+		token.Header["kid"] = idp.kid
+		signed, err := token.SignedString(otherKey)
+		require.NoError(t, err)
+
+		_, err = provider.GetUserFromIdToken(rctx, signed)
+		require.Error(t, err)
+	})
+
+	t.Run("unsigned token", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, idp.claims("subject"))
+		signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = provider.GetUserFromIdToken(rctx, signed)
+		require.Error(t, err)
+	})
+}
+
+func TestGetUserFromJSON(t *testing.T) {
+	rctx := request.TestContext(t)
+	idp := newTestIdentityProvider(t)
+	cfg := idp.config(&model.OpenIdProvider{
+		UsernameClaim:  model.NewPointer("login"),
+		FirstNameClaim: model.NewPointer("profile.first"),
+		PositionClaim:  model.NewPointer("job"),
+	})
+
+	provider := NewOpenIdProvider("openid_staff")
+	settings, err := provider.GetSSOSettings(rctx, cfg, "openid_staff")
+	require.NoError(t, err)
+
+	t.Run("mapped claims", func(t *testing.T) {
+		user, err := provider.GetUserFromJSON(rctx, strings.NewReader(`{
+			"sub": "subject",
+			"login": "jdoe",
+			"email": "JDoe@Example.com",
+			"profile": {"first": "Jane"},
+			"family_name": "Doe",
+			"job": "Engineer"
+		}`), nil, settings)
+		require.NoError(t, err)
+		assert.Equal(t, "subject", *user.AuthData)
+		assert.Equal(t, "openid_staff", user.AuthService)
+		assert.Equal(t, "jdoe", user.Username)
+		assert.Equal(t, "jdoe@example.com", user.Email)
+		assert.Equal(t, "Jane", user.FirstName)
+		assert.Equal(t, "Doe", user.LastName)
+		assert.Equal(t, "Engineer", user.Position)
+	})
+
+	t.Run("missing claims are taken from the ID token", func(t *testing.T) {
+		tokenUser := &model.User{AuthData: model.NewPointer("subject"), Email: "jane@example.com", FirstName: "Jane"}
+
+		user, err := provider.GetUserFromJSON(rctx, strings.NewReader(`{"sub": "subject"}`), tokenUser, settings)
+		require.NoError(t, err)
+		assert.Equal(t, "jane@example.com", user.Email)
+		assert.Equal(t, "Jane", user.FirstName)
+		assert.Equal(t, "jane", user.Username)
+	})
+
+	t.Run("subject must match the ID token", func(t *testing.T) {
+		tokenUser := &model.User{AuthData: model.NewPointer("other")}
+
+		_, err := provider.GetUserFromJSON(rctx, strings.NewReader(`{"sub": "subject", "email": "jane@example.com"}`), tokenUser, settings)
+		require.Error(t, err)
+	})
+
+	t.Run("missing email", func(t *testing.T) {
+		_, err := provider.GetUserFromJSON(rctx, strings.NewReader(`{"sub": "subject"}`), nil, settings)
+		require.Error(t, err)
+	})
+
+	t.Run("missing subject", func(t *testing.T) {
+		_, err := provider.GetUserFromJSON(rctx, strings.NewReader(`{"email": "jane@example.com"}`), nil, settings)
+		require.Error(t, err)
+	})
+}
+
+func TestGetClaimsFromJSON(t *testing.T) {
+	rctx := request.TestContext(t)
+	idp := newTestIdentityProvider(t)
+
+	t.Run("nothing mapped", func(t *testing.T) {
+		provider := NewOpenIdProvider("openid_staff")
+		settings, err := provider.GetSSOSettings(rctx, idp.config(&model.OpenIdProvider{}), "openid_staff")
+		require.NoError(t, err)
+
+		claims, err := provider.GetClaimsFromJSON(rctx, strings.NewReader(`{"groups": ["a"]}`), settings)
+		require.NoError(t, err)
+		assert.Nil(t, claims)
+	})
+
+	provider := NewOpenIdProvider("openid_staff")
+	settings, err := provider.GetSSOSettings(rctx, idp.config(&model.OpenIdProvider{
+		GroupsClaim: model.NewPointer("realm_access.roles"),
+		AttributeClaims: map[string]string{
+			"Department": "department",
+			"Languages":  "languages",
+			"Level":      "level",
+			"Missing":    "missing",
+		},
+	}), "openid_staff")
+	require.NoError(t, err)
+
+	t.Run("mapped claims", func(t *testing.T) {
+		claims, err := provider.GetClaimsFromJSON(rctx, strings.NewReader(`{
+			"realm_access": {"roles": ["staff", "admins"]},
+			"department": "Engineering",
+			"languages": ["Go", "Rust"],
+			"level": 5
+		}`), settings)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"staff", "admins"}, claims.Groups)
+		assert.Equal(t, map[string][]string{
+			"Department": {"Engineering"},
+			"Languages":  {"Go", "Rust"},
+			"Level":      {"5"},
+		}, claims.Attributes)
+	})
+
+	t.Run("missing groups claim", func(t *testing.T) {
+		claims, err := provider.GetClaimsFromJSON(rctx, strings.NewReader(`{}`), settings)
+		require.NoError(t, err)
+		assert.Nil(t, claims.Groups)
+	})
+
+	t.Run("empty groups claim", func(t *testing.T) {
+		claims, err := provider.GetClaimsFromJSON(rctx, strings.NewReader(`{"realm_access": {"roles": []}}`), settings)
+		require.NoError(t, err)
+		assert.NotNil(t, claims.Groups)
+		assert.Empty(t, claims.Groups)
+	})
+}
+
+func TestPublicKey(t *testing.T) {
+	for _, jwk := range []jsonWebKey{
+		{Kty: "RSA", N: "!", E: "AQAB"},
+		{Kty: "EC", Crv: "P-256", X: "AQ", Y: "AQ"},
+		{Kty: "EC", Crv: "secp256k1"},
+		{Kty: "OKP", Crv: "Ed25519", X: "AQ"},
+		{Kty: "oct"},
+	} {
+		_, err := jwk.publicKey()
+		assert.Error(t, err, jwk.Kty)
+	}
+}
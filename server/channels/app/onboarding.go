@@ -57,7 +57,7 @@ func (a *App) CompleteOnboarding(rctx request.CTX, request *model.CompleteOnboar
 			installRequest := &model.InstallMarketplacePluginRequest{
 				Id: id,
 			}
-			_, appErr := a.Channels().InstallMarketplacePlugin(installRequest)
+			_, _, appErr := a.Channels().InstallMarketplacePlugin(installRequest)
 			if appErr != nil {
 				rctx.Logger().Error("Failed to install plugin for onboarding", mlog.String("id", id), mlog.Err(appErr))
 				return
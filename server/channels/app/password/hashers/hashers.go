@@ -28,9 +28,14 @@
 // is needed. Simply update the [latestHasher] varible with the new parameter,
 // and [IsPHCValid] will detect the difference in the parameter.
 //
+// The hasher in use can also be replaced at runtime with [SetLatestHasher],
+// which is how the server applies the hashing method and parameters configured
+// in PasswordSettings, e.g. to switch to [Argon2id].
+//
 // Note that the migration happens in [App.migratePassword], which is triggered
-// whenever the user enters their password and an old hashing method is
-// identified when parsing their stored hashed password.
+// whenever the user enters their password and [NeedsMigration] identifies an
+// older hashing method, or weaker parameters, when parsing their stored hashed
+// password.
 // This means that the older password hashers can *never* be removed, unless all
 // users whose passwords are not migrated are either forced to re-login, or
 // forced to generate a new password.
@@ -43,6 +48,7 @@ package hashers
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/mattermost/mattermost/server/v8/channels/app/password/phcparser"
 )
@@ -80,8 +86,11 @@ const (
 
 var (
 	// latestHasher is the hasher currently in use.
-	// Any password hashed with a different hasher must be migrated to this one.
-	latestHasher PasswordHasher = DefaultPBKDF2()
+	// Any password hashed with an older or weaker hasher must be migrated to
+	// this one. It is guarded by latestHasherMut, since it can be replaced via
+	// [SetLatestHasher].
+	latestHasher    PasswordHasher = DefaultPBKDF2()
+	latestHasherMut sync.RWMutex
 
 	// ErrPasswordTooLong is the error returned when the provided password is
 	// longer than [PasswordMaxLengthBytes].
@@ -114,8 +123,8 @@ func GetHasherFromPHCString(phcString string) (PasswordHasher, phcparser.PHC, er
 	}
 
 	// First check whether PHC conforms to the latest hasher
-	if latestHasher.IsPHCValid(phc) {
-		return latestHasher, phc, nil
+	if latest := currentLatestHasher(); latest.IsPHCValid(phc) {
+		return latest, phc, nil
 	}
 
 	// If not, check the function ID and create a new one depending on it
@@ -126,6 +135,12 @@ func GetHasherFromPHCString(phcString string) (PasswordHasher, phcparser.PHC, er
 			return PBKDF2{}, phcparser.PHC{}, fmt.Errorf("the provided PHC string is PBKDF2, but is not valid: %w", err)
 		}
 		return pbkdf2, phc, nil
+	case Argon2idFunctionId:
+		argon2id, err := NewArgon2idFromPHC(phc)
+		if err != nil {
+			return Argon2id{}, phcparser.PHC{}, fmt.Errorf("the provided PHC string is Argon2id, but is not valid: %w", err)
+		}
+		return argon2id, phc, nil
 	// If the function ID is unknown, return the original hasher
 	default:
 		bcrypt, phc := getOriginalHasher(phcString)
@@ -149,3 +164,61 @@ func CompareHashAndPassword(phc phcparser.PHC, password string) error {
 func IsLatestHasher(hasher PasswordHasher) bool {
 	return getLatestHasher() == hasher
 }
+
+// NeedsMigration verifies whether a password hashed with the provided hasher
+// must be migrated to the latest one: that is, whether it uses an older hashing
+// method, or the same method with weaker parameters.
+//
+// Passwords hashed with a newer method or stronger parameters are never
+// migrated, so that lowering the configured parameters does not downgrade the
+// stored hashes.
+func NeedsMigration(hasher PasswordHasher) bool {
+	latest := getLatestHasher()
+	if hasher == latest {
+		return false
+	}
+
+	if hasherRank, latestRank := rank(hasher), rank(latest); hasherRank != latestRank {
+		return hasherRank < latestRank
+	}
+
+	switch h := hasher.(type) {
+	case PBKDF2:
+		l := latest.(PBKDF2)
+		return h.workFactor < l.workFactor || h.keyLength < l.keyLength
+	case Argon2id:
+		l := latest.(Argon2id)
+		return h.memory < l.memory || h.iterations < l.iterations || h.parallelism < l.parallelism
+	default:
+		return false
+	}
+}
+
+// rank orders the hashing methods from the oldest to the newest one.
+func rank(hasher PasswordHasher) int {
+	switch hasher.(type) {
+	case PBKDF2:
+		return 1
+	case Argon2id:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// SetLatestHasher replaces the hasher used to hash passwords from now on.
+// Passwords already stored are migrated to it whenever [NeedsMigration]
+// reports so.
+func SetLatestHasher(hasher PasswordHasher) {
+	latestHasherMut.Lock()
+	defer latestHasherMut.Unlock()
+	latestHasher = hasher
+}
+
+// currentLatestHasher returns [latestHasher], guarded against concurrent
+// calls to [SetLatestHasher].
+func currentLatestHasher() PasswordHasher {
+	latestHasherMut.RLock()
+	defer latestHasherMut.RUnlock()
+	return latestHasher
+}
@@ -18,7 +18,7 @@ func getLatestHasher() PasswordHasher {
 	if testHasher != nil {
 		return testHasher
 	}
-	return latestHasher
+	return currentLatestHasher()
 }
 
 // SetTestHasher sets a hasher to be used instead of the latestHasher during tests.
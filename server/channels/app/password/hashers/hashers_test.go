@@ -4,6 +4,7 @@
 package hashers
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/mattermost/mattermost/server/v8/channels/app/password/phcparser"
@@ -56,6 +57,35 @@ func TestGetHasherFromPHCString(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			testName: "valid Argon2id",
+			input:    "$argon2id$v=19$m=19456,t=2,p=1$5Zq8TvET7nMrXof49Rp4Sw$d0Mx8467kv+3ylbGrkyu4jTd8O8SP51k4s1RuWb9S/o",
+			expectedHasher: Argon2id{
+				memory:      19456,
+				iterations:  2,
+				parallelism: 1,
+				phcHeader:   "$argon2id$v=19$m=19456,t=2,p=1$",
+			},
+			expectedPHC: phcparser.PHC{
+				Id:      "argon2id",
+				Version: "19",
+				Params: map[string]string{
+					"m": "19456",
+					"t": "2",
+					"p": "1",
+				},
+				Salt: "5Zq8TvET7nMrXof49Rp4Sw",
+				Hash: "d0Mx8467kv+3ylbGrkyu4jTd8O8SP51k4s1RuWb9S/o",
+			},
+			expectedErr: false,
+		},
+		{
+			testName:       "Argon2id with unsupported version",
+			input:          "$argon2id$v=16$m=19456,t=2,p=1$5Zq8TvET7nMrXof49Rp4Sw$d0Mx8467kv+3ylbGrkyu4jTd8O8SP51k4s1RuWb9S/o",
+			expectedHasher: Argon2id{},
+			expectedPHC:    phcparser.PHC{},
+			expectedErr:    true,
+		},
 		{
 			testName:       "valid bcrypt",
 			input:          "$2a$10$z0OlN1MpiLVlLTyE1xtEjOJ6/xV95RAwwIUaYKQBAqoeyvPgLEnUa",
@@ -137,6 +167,64 @@ func TestIsLatestHasher(t *testing.T) {
 	}
 }
 
+func TestNeedsMigration(t *testing.T) {
+	weakerPBKDF2, err := NewPBKDF2(10000, 32)
+	require.NoError(t, err)
+	strongerPBKDF2, err := NewPBKDF2(1000000, 32)
+	require.NoError(t, err)
+	weakerArgon2id, err := NewArgon2id(DefaultArgon2idMemory, 1, 1)
+	require.NoError(t, err)
+	strongerArgon2id, err := NewArgon2id(2*DefaultArgon2idMemory, 2, 1)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		testName       string
+		latestHasher   PasswordHasher
+		inputHasher    PasswordHasher
+		expectedOutput bool
+	}{
+		{"same PBKDF2", DefaultPBKDF2(), DefaultPBKDF2(), false},
+		{"bcrypt to PBKDF2", DefaultPBKDF2(), NewBCrypt(), true},
+		{"weaker PBKDF2", DefaultPBKDF2(), weakerPBKDF2, true},
+		{"stronger PBKDF2", DefaultPBKDF2(), strongerPBKDF2, false},
+		{"Argon2id to PBKDF2", DefaultPBKDF2(), DefaultArgon2id(), false},
+		{"same Argon2id", DefaultArgon2id(), DefaultArgon2id(), false},
+		{"bcrypt to Argon2id", DefaultArgon2id(), NewBCrypt(), true},
+		{"PBKDF2 to Argon2id", DefaultArgon2id(), strongerPBKDF2, true},
+		{"weaker Argon2id", DefaultArgon2id(), weakerArgon2id, true},
+		{"stronger Argon2id", DefaultArgon2id(), strongerArgon2id, false},
+	}
+
+	defer SetLatestHasher(DefaultPBKDF2())
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			SetLatestHasher(tc.latestHasher)
+			require.Equal(t, tc.expectedOutput, NeedsMigration(tc.inputHasher))
+		})
+	}
+}
+
+func TestSetLatestHasher(t *testing.T) {
+	SetLatestHasher(DefaultArgon2id())
+	defer SetLatestHasher(DefaultPBKDF2())
+
+	hash, err := Hash("password")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$"))
+
+	hasher, phc, err := GetHasherFromPHCString(hash)
+	require.NoError(t, err)
+	require.True(t, IsLatestHasher(hasher))
+	require.NoError(t, hasher.CompareHashAndPassword(phc, "password"))
+
+	// Passwords hashed before switching can still be verified
+	SetLatestHasher(DefaultPBKDF2())
+	hasher, phc, err = GetHasherFromPHCString(hash)
+	require.NoError(t, err)
+	require.False(t, IsLatestHasher(hasher))
+	require.NoError(t, hasher.CompareHashAndPassword(phc, "password"))
+}
+
 func BenchmarkDefaultHasher(b *testing.B) {
 	hasher := DefaultPBKDF2()
 	for b.Loop() {
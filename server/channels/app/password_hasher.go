@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/password/hashers"
+)
+
+// placeholderSaltAndHash completes the PHC prefixes returned by the store, so
+// that they can be parsed as a full PHC string.
+const placeholderSaltAndHash = "$c2FsdA$aGFzaA"
+
+// passwordHasherFromConfig returns the hasher configured in PasswordSettings.
+func passwordHasherFromConfig(settings *model.PasswordSettings) (hashers.PasswordHasher, error) {
+	if *settings.Hasher == model.PasswordHasherArgon2id {
+		return hashers.NewArgon2id(*settings.Argon2idMemoryKiB, *settings.Argon2idIterations, *settings.Argon2idParallelism)
+	}
+
+	return hashers.DefaultPBKDF2(), nil
+}
+
+// configurePasswordHasher makes the configured hasher the one used to hash
+// passwords from now on. Passwords hashed with an older hasher or weaker
+// parameters are migrated the next time their users log in.
+func (s *Server) configurePasswordHasher(cfg *model.Config) {
+	hasher, err := passwordHasherFromConfig(&cfg.PasswordSettings)
+	if err != nil {
+		s.Log().Error("Failed to configure the password hasher, keeping the previous one", mlog.Err(err))
+		return
+	}
+
+	hashers.SetLatestHasher(hasher)
+}
+
+// GetPasswordHasherUsage returns how many active users have their password
+// hashed with each hashing function and set of parameters, and whether those
+// passwords are pending a migration to the configured hasher.
+func (a *App) GetPasswordHasherUsage() ([]*model.PasswordHasherUsage, *model.AppError) {
+	counts, err := a.Srv().Store().User().GetPasswordHasherCounts()
+	if err != nil {
+		return nil, model.NewAppError("GetPasswordHasherUsage", "app.user.get_password_hasher_counts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	usageByKey := make(map[string]*model.PasswordHasherUsage, len(counts))
+	for prefix, count := range counts {
+		hasher, _, err := hashers.GetHasherFromPHCString(prefix + placeholderSaltAndHash)
+
+		name, params, _ := strings.Cut(strings.TrimPrefix(prefix, "$"), "$")
+		params = strings.ReplaceAll(params, "$", ",")
+		if _, ok := hasher.(hashers.BCrypt); ok && err == nil {
+			// bcrypt hashes are not PHC-compliant, and their versions are not
+			// worth telling apart
+			name, params = "bcrypt", ""
+		}
+
+		key := name + "$" + params
+		usage, ok := usageByKey[key]
+		if !ok {
+			usage = &model.PasswordHasherUsage{
+				Hasher:     name,
+				Parameters: params,
+				Outdated:   err != nil || hashers.NeedsMigration(hasher),
+			}
+			usageByKey[key] = usage
+		}
+		usage.Users += count
+	}
+
+	usages := make([]*model.PasswordHasherUsage, 0, len(usageByKey))
+	for _, usage := range usageByKey {
+		usages = append(usages, usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Hasher != usages[j].Hasher {
+			return usages[i].Hasher < usages[j].Hasher
+		}
+		return usages[i].Parameters < usages[j].Parameters
+	})
+
+	return usages, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPasswordHasherSettings(t *testing.T) {
+	// Not parallel: the configured hasher is shared by every server in the process.
+	th := Setup(t).InitBasic(t)
+
+	getPassword := func(t *testing.T, userID string) string {
+		t.Helper()
+		user, err := th.Server.Store().User().Get(context.Background(), userID)
+		require.NoError(t, err)
+		return user.Password
+	}
+
+	pbkdf2User := th.CreateUser(t)
+	require.True(t, strings.HasPrefix(getPassword(t, pbkdf2User.Id), "$pbkdf2$"))
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PasswordSettings.Hasher = model.PasswordHasherArgon2id
+		*cfg.PasswordSettings.Argon2idMemoryKiB = 2048
+		*cfg.PasswordSettings.Argon2idIterations = 1
+	})
+	defer th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PasswordSettings.Hasher = model.PasswordHasherPBKDF2
+	})
+
+	argon2idPrefix := "$argon2id$v=19$m=2048,t=1,p=1$"
+
+	t.Run("new passwords use the configured hasher", func(t *testing.T) {
+		user := th.CreateUser(t)
+		assert.True(t, strings.HasPrefix(getPassword(t, user.Id), argon2idPrefix))
+	})
+
+	t.Run("usage report", func(t *testing.T) {
+		usages, appErr := th.App.GetPasswordHasherUsage()
+		require.Nil(t, appErr)
+
+		found := map[string]*model.PasswordHasherUsage{}
+		for _, usage := range usages {
+			found[usage.Hasher+" "+usage.Parameters] = usage
+		}
+
+		require.Contains(t, found, "argon2id v=19,m=2048,t=1,p=1")
+		assert.False(t, found["argon2id v=19,m=2048,t=1,p=1"].Outdated)
+		require.Contains(t, found, "pbkdf2 f=SHA256,w=600000,l=32")
+		assert.True(t, found["pbkdf2 f=SHA256,w=600000,l=32"].Outdated)
+	})
+
+	t.Run("outdated passwords are migrated on login", func(t *testing.T) {
+		user, appErr := th.App.GetUser(pbkdf2User.Id)
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.checkUserPassword(user, "Password1", false))
+		assert.True(t, strings.HasPrefix(getPassword(t, user.Id), argon2idPrefix))
+	})
+
+	t.Run("stronger passwords are not downgraded", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.PasswordSettings.Hasher = model.PasswordHasherPBKDF2
+		})
+
+		user, appErr := th.App.GetUser(pbkdf2User.Id)
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.checkUserPassword(user, "Password1", false))
+		assert.True(t, strings.HasPrefix(getPassword(t, user.Id), argon2idPrefix))
+	})
+}
@@ -23,6 +23,7 @@ func (ps *PlatformService) NewClusterDiscoveryService() *ClusterDiscoveryService
 		ClusterDiscovery: model.ClusterDiscovery{},
 		platform:         ps,
 		stop:             make(chan bool),
+		stopped:          make(chan struct{}),
 	}
 
 	return ds
@@ -228,6 +229,41 @@ func (ps *PlatformService) ListPluginKeys(pluginID string, page, perPage int) ([
 	return data, nil
 }
 
+func (ps *PlatformService) ListPluginKeyValues(pluginID string, options model.PluginKVListOptions) ([]*model.PluginKeyValue, *model.AppError) {
+	if err := options.IsValid(); err != nil {
+		return nil, err
+	}
+
+	kvs, err := ps.Store.Plugin().ListWithOptions(pluginID, options)
+	if err != nil {
+		mlog.Error("Failed to list plugin key values", mlog.String("plugin_id", pluginID), mlog.String("prefix", options.Prefix), mlog.Err(err))
+		return nil, model.NewAppError("ListPluginKeyValues", "app.plugin_store.list.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return kvs, nil
+}
+
+func (ps *PlatformService) SetPluginKeyBatch(pluginID string, operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	if err := model.IsValidPluginKVSetOperations(operations); err != nil {
+		mlog.Debug("Failed to set plugin key value batch", mlog.String("plugin_id", pluginID), mlog.Err(err))
+		return false, err
+	}
+
+	written, err := ps.Store.Plugin().SetBatch(pluginID, operations)
+	if err != nil {
+		mlog.Error("Failed to set plugin key value batch", mlog.String("plugin_id", pluginID), mlog.Int("operations", len(operations)), mlog.Err(err))
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return false, appErr
+		default:
+			return false, model.NewAppError("SetPluginKeyBatch", "app.plugin_store.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return written, nil
+}
+
 func (ps *PlatformService) DeletePluginKey(pluginID string, key string) *model.AppError {
 	if err := ps.Store.Plugin().Delete(pluginID, getKeyHash(key)); err != nil {
 		mlog.Error("Failed to delete plugin key value", mlog.String("plugin_id", pluginID), mlog.String("key", key), mlog.Err(err))
@@ -4,6 +4,8 @@
 package platform
 
 import (
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -18,9 +20,14 @@ type ClusterDiscoveryService struct {
 	model.ClusterDiscovery
 	platform *PlatformService
 	stop     chan bool
+	stopped  chan struct{}
+	started  atomic.Bool
+	stopOnce sync.Once
 }
 
 func (cds *ClusterDiscoveryService) Start() {
+	cds.started.Store(true)
+
 	err := cds.platform.Store.ClusterDiscovery().Cleanup()
 	if err != nil {
 		mlog.Warn("ClusterDiscoveryService failed to cleanup the outdated cluster discovery information", mlog.Err(err))
@@ -37,6 +44,7 @@ func (cds *ClusterDiscoveryService) Start() {
 
 	if err := cds.platform.Store.ClusterDiscovery().Save(&cds.ClusterDiscovery); err != nil {
 		mlog.Error("ClusterDiscoveryService failed to save", mlog.String("ClusterDiscoveryID", cds.ClusterDiscovery.Id), mlog.Err(err))
+		close(cds.stopped)
 		return
 	}
 
@@ -44,6 +52,7 @@ func (cds *ClusterDiscoveryService) Start() {
 		mlog.Debug("ClusterDiscoveryService ping writer started", mlog.String("ClusterDiscoveryID", cds.ClusterDiscovery.Id))
 		ticker := time.NewTicker(DiscoveryServiceWritePing)
 		defer func() {
+			defer close(cds.stopped)
 			ticker.Stop()
 			if _, err := cds.platform.Store.ClusterDiscovery().Delete(&cds.ClusterDiscovery); err != nil {
 				mlog.Warn("ClusterDiscoveryService failed to cleanup", mlog.String("ClusterDiscoveryID", cds.ClusterDiscovery.Id), mlog.Err(err))
@@ -64,8 +73,16 @@ func (cds *ClusterDiscoveryService) Start() {
 	}()
 }
 
+// Stop stops the ping writer and waits for the record to be deleted.
+// It is safe to call more than once, and doesn't block if the service
+// was never started.
 func (cds *ClusterDiscoveryService) Stop() {
-	cds.stop <- true
+	cds.stopOnce.Do(func() {
+		close(cds.stop)
+		if cds.started.Load() {
+			<-cds.stopped
+		}
+	})
 }
 
 func (ps *PlatformService) GetClusterId() string {
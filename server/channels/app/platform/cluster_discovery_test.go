@@ -25,3 +25,24 @@ func TestClusterDiscoveryService(t *testing.T) {
 	ds.Stop()
 	time.Sleep(2 * time.Second)
 }
+
+func TestClusterDiscoveryServiceStop(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+
+	t.Run("never started", func(t *testing.T) {
+		ds := th.Service.NewClusterDiscoveryService()
+		ds.Stop()
+	})
+
+	t.Run("stopped twice", func(t *testing.T) {
+		ds := th.Service.NewClusterDiscoveryService()
+		ds.Type = model.CDSTypeApp
+		ds.ClusterName = "ClusterA"
+		ds.AutoFillHostname()
+
+		ds.Start()
+		ds.Stop()
+		ds.Stop()
+	})
+}
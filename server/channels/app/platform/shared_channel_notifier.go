@@ -23,6 +23,11 @@ var sharedChannelEventsForSync = []model.WebsocketEventType{
 	model.WebsocketEventReactionRemoved,
 	model.WebsocketEventAcknowledgementAdded,
 	model.WebsocketEventAcknowledgementRemoved,
+	model.WebsocketEventChannelBookmarkCreated,
+	model.WebsocketEventChannelBookmarkUpdated,
+	model.WebsocketEventChannelBookmarkDeleted,
+	model.WebsocketEventChannelBookmarkSorted,
+	model.WebsocketEventChannelUpdated,
 }
 
 var sharedChannelEventsForInvitation = []model.WebsocketEventType{
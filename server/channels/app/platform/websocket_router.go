@@ -53,6 +53,11 @@ func (wr *WebSocketRouter) ServeWebSocket(conn *WebConn, r *model.WebSocketReque
 			conn.WebSocket.Close()
 			return
 		}
+		if len(session.GetOAuthScopes()) > 0 {
+			conn.Platform.Log().Warn("Refusing WebSocket authentication with a session restricted to OAuth scopes", mlog.String("user_id", session.UserId))
+			conn.WebSocket.Close()
+			return
+		}
 		conn.SetSession(session)
 		conn.SetSessionToken(session.Token)
 		conn.UserId = session.UserId
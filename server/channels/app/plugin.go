@@ -130,30 +130,34 @@ func (ch *Channels) syncPluginsActiveState() {
 			}(plugin)
 		}
 
-		// Activate any plugins that have been enabled
-		for _, plugin := range enabledPlugins {
-			wg.Add(1)
-			go func(plugin *model.BundleInfo) {
-				defer wg.Done()
+		wg.Wait()
 
-				pluginID := plugin.Manifest.Id
-				logger := ch.srv.Log().With(mlog.String("plugin_id", pluginID), mlog.String("bundle_path", plugin.Path))
+		// Activate any plugins that have been enabled, after the plugins they depend on.
+		for _, wave := range plugin.ActivationWaves(enabledPlugins) {
+			for _, plugin := range wave {
+				wg.Add(1)
+				go func(plugin *model.BundleInfo) {
+					defer wg.Done()
 
-				updatedManifest, activated, err := pluginsEnvironment.Activate(pluginID)
-				if err != nil {
-					logger.Error("Unable to activate plugin", mlog.Err(err))
-					return
-				}
+					pluginID := plugin.Manifest.Id
+					logger := ch.srv.Log().With(mlog.String("plugin_id", pluginID), mlog.String("bundle_path", plugin.Path))
 
-				if activated {
-					// Notify all cluster clients if ready
-					if err := ch.notifyPluginEnabled(updatedManifest); err != nil {
-						logger.Error("Failed to notify cluster on plugin enable", mlog.Err(err))
+					updatedManifest, activated, err := pluginsEnvironment.Activate(pluginID)
+					if err != nil {
+						logger.Error("Unable to activate plugin", mlog.Err(err))
+						return
 					}
-				}
-			}(plugin)
+
+					if activated {
+						// Notify all cluster clients if ready
+						if err := ch.notifyPluginEnabled(updatedManifest); err != nil {
+							logger.Error("Failed to notify cluster on plugin enable", mlog.Err(err))
+						}
+					}
+				}(plugin)
+			}
+			wg.Wait()
 		}
-		wg.Wait()
 	} else { // If plugins are disabled, shutdown plugins.
 		pluginsEnvironment.Shutdown()
 	}
@@ -181,6 +185,10 @@ func (ch *Channels) initPlugins(rctx request.CTX, pluginDir, webappPluginDir str
 	pluginsEnvironment := ch.pluginsEnvironment
 	ch.pluginsLock.RUnlock()
 	if pluginsEnvironment != nil || !*ch.cfgSvc.Config().PluginSettings.Enable {
+		if pluginsEnvironment != nil {
+			pluginsEnvironment.SetResourceLimits(ch.cfgSvc.Config().PluginSettings.ResourceLimits(), ch.cfgSvc.Config().PluginSettings.PluginLimits, *ch.cfgSvc.Config().PluginSettings.CgroupPath)
+			pluginsEnvironment.SetAPIQuotas(ch.cfgSvc.Config().PluginSettings.APIQuotas())
+		}
 		ch.syncPluginsActiveState()
 		if pluginsEnvironment != nil {
 			pluginsEnvironment.TogglePluginHealthCheckJob(*ch.cfgSvc.Config().PluginSettings.EnableHealthCheck)
@@ -220,6 +228,8 @@ func (ch *Channels) initPlugins(rctx request.CTX, pluginDir, webappPluginDir str
 	ch.pluginsEnvironment = env
 	ch.pluginsLock.Unlock()
 
+	ch.pluginsEnvironment.SetResourceLimits(ch.cfgSvc.Config().PluginSettings.ResourceLimits(), ch.cfgSvc.Config().PluginSettings.PluginLimits, *ch.cfgSvc.Config().PluginSettings.CgroupPath)
+	ch.pluginsEnvironment.SetAPIQuotas(ch.cfgSvc.Config().PluginSettings.APIQuotas())
 	ch.pluginsEnvironment.TogglePluginHealthCheckJob(*ch.cfgSvc.Config().PluginSettings.EnableHealthCheck)
 
 	if err := ch.syncPlugins(); err != nil {
@@ -329,10 +339,12 @@ func (ch *Channels) syncPlugins() *model.AppError {
 				}
 				defer signature.Close()
 
-				if appErr = ch.verifyPlugin(logger, bundle, signature); appErr != nil {
+				signer, appErr := ch.verifyPlugin(logger, bundle, signature)
+				if appErr != nil {
 					logger.Error("Failed to validate plugin signature", mlog.Err(appErr))
 					return
 				}
+				logger = logger.With(mlog.String("signer_key_id", signer.KeyId), mlog.String("signer_public_key_path", signer.PublicKeyFile))
 			}
 
 			logger.Info("Syncing plugin from file store")
@@ -357,6 +369,7 @@ func (ch *Channels) ShutDownPlugins() {
 
 	ch.srv.Log().Info("Shutting down plugins")
 
+	ch.stopPluginWatchers()
 	pluginsEnvironment.Shutdown()
 
 	ch.RemoveConfigListener(ch.pluginConfigListenerID)
@@ -1200,7 +1213,7 @@ func (ch *Channels) buildPrepackagedPlugin(logger *mlog.Logger, pluginPath *plug
 	if _, err := pluginFile.Seek(0, io.SeekStart); err != nil {
 		return nil, "", errors.Wrapf(err, "Failed to seek to start of plugin file for signature verification: %s", pluginPath.bundlePath)
 	}
-	if appErr := ch.verifyPlugin(logger, pluginFile, signatureFile); appErr != nil {
+	if _, appErr := ch.verifyPlugin(logger, pluginFile, signatureFile); appErr != nil {
 		return nil, "", errors.Wrapf(appErr, "Prepackaged plugin signature verification failed for %s using %s", pluginPath.bundlePath, pluginPath.signaturePath)
 	}
 
@@ -165,7 +165,7 @@ func (api *PluginAPI) CreateTeam(team *model.Team) (*model.Team, *model.AppError
 }
 
 func (api *PluginAPI) DeleteTeam(teamID string) *model.AppError {
-	return api.app.SoftDeleteTeam(teamID)
+	return api.app.SoftDeleteTeam(api.ctx, teamID)
 }
 
 func (api *PluginAPI) GetTeams() ([]*model.Team, *model.AppError) {
@@ -1059,6 +1059,10 @@ func (api *PluginAPI) KVSetWithOptions(key string, value []byte, options model.P
 	return api.app.SetPluginKeyWithOptions(api.id, key, value, options)
 }
 
+func (api *PluginAPI) KVSetBatch(operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	return api.app.SetPluginKeyBatch(api.id, operations)
+}
+
 func (api *PluginAPI) KVSet(key string, value []byte) *model.AppError {
 	return api.app.SetPluginKey(api.id, key, value)
 }
@@ -1091,6 +1095,10 @@ func (api *PluginAPI) KVList(page, perPage int) ([]string, *model.AppError) {
 	return api.app.ListPluginKeys(api.id, page, perPage)
 }
 
+func (api *PluginAPI) KVListWithOptions(options model.PluginKVListOptions) ([]*model.PluginKeyValue, *model.AppError) {
+	return api.app.ListPluginKeyValues(api.id, options)
+}
+
 func (api *PluginAPI) PublishWebSocketEvent(event string, payload map[string]any, broadcast *model.WebsocketBroadcast) {
 	ev := model.NewWebSocketEvent(model.WebsocketEventType(fmt.Sprintf("custom_%v_%v", api.id, event)), "", "", "", nil, "")
 	ev = ev.SetBroadcast(broadcast).SetData(payload)
@@ -1187,6 +1195,10 @@ func (api *PluginAPI) PublishUserTyping(userID, channelID, parentId string) *mod
 	return api.app.PublishUserTyping(userID, channelID, parentId)
 }
 
+func (api *PluginAPI) CallPlugin(pluginID, method string, args []byte) ([]byte, *model.AppError) {
+	return api.app.CallPlugin(api.ctx, api.manifest, pluginID, method, args)
+}
+
 func (api *PluginAPI) PluginHTTP(request *http.Request) *http.Response {
 	split := strings.SplitN(request.URL.Path, "/", 3)
 	if len(split) != 3 {
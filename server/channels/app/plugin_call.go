@@ -0,0 +1,65 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// CallPlugin calls a method exported by the plugin pluginID on behalf of the plugin described by
+// sourceManifest. The source plugin must declare the target plugin as a dependency.
+func (a *App) CallPlugin(rctx request.CTX, sourceManifest *model.Manifest, pluginID, method string, args []byte) ([]byte, *model.AppError) {
+	params := map[string]any{"PluginId": pluginID, "Method": method}
+
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, model.NewAppError("CallPlugin", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if !sourceManifest.DependsOn(pluginID) {
+		return nil, model.NewAppError("CallPlugin", "app.plugin.call.not_dependency.app_error", params, "source_plugin_id="+sourceManifest.Id, http.StatusForbidden)
+	}
+
+	manifest, ok := pluginsEnvironment.ActiveManifest(pluginID)
+	if !ok {
+		return nil, model.NewAppError("CallPlugin", "app.plugin.call.not_active.app_error", params, "", http.StatusServiceUnavailable)
+	}
+
+	if !manifest.ExportsMethod(method) {
+		return nil, model.NewAppError("CallPlugin", "app.plugin.call.method_not_exported.app_error", params, "", http.StatusNotFound)
+	}
+
+	if !pluginsEnvironment.PluginImplements(pluginID, plugin.OnPluginCallID) {
+		return nil, model.NewAppError("CallPlugin", "app.plugin.call.not_implemented.app_error", params, "", http.StatusNotImplemented)
+	}
+
+	hooks, err := pluginsEnvironment.HooksForPlugin(pluginID)
+	if err != nil {
+		return nil, model.NewAppError("CallPlugin", "app.plugin.call.not_active.app_error", params, "", http.StatusServiceUnavailable).Wrap(err)
+	}
+
+	result, err := hooks.OnPluginCall(pluginContext(rctx), sourceManifest.Id, method, args)
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+
+		rctx.Logger().Debug("Plugin call failed",
+			mlog.String("source_plugin_id", sourceManifest.Id),
+			mlog.String("plugin_id", pluginID),
+			mlog.String("method", method),
+			mlog.Err(err),
+		)
+		return nil, model.NewAppError("CallPlugin", "app.plugin.call.failed.app_error", params, err.Error(), http.StatusInternalServerError)
+	}
+
+	return result, nil
+}
@@ -131,10 +131,12 @@ func (ch *Channels) installPluginFromClusterMessage(pluginID string) {
 		}
 		defer signature.Close()
 
-		if err := ch.verifyPlugin(logger, bundle, signature); err != nil {
+		signer, appErr := ch.verifyPlugin(logger, bundle, signature)
+		if appErr != nil {
 			logger.Error("Failed to validate plugin signature.", mlog.Err(appErr))
 			return
 		}
+		logger = logger.With(mlog.String("signer_key_id", signer.KeyId), mlog.String("signer_public_key_path", signer.PublicKeyFile))
 	}
 
 	manifest, appErr := ch.installPluginLocally(bundle, installPluginLocallyAlways)
@@ -182,6 +184,28 @@ func (a *App) InstallPlugin(pluginFile io.ReadSeeker, replace bool) (*model.Mani
 	return a.ch.installPlugin(pluginFile, nil, installationStrategy)
 }
 
+// InstallPluginWithSignature verifies the signature of a plugin against the trust store of the
+// server before installing it like InstallPlugin, keeping the signature for cluster peers to
+// verify the plugin too.
+func (a *App) InstallPluginWithSignature(pluginFile, signature io.ReadSeeker, replace bool) (*model.Manifest, *model.PluginSigner, *model.AppError) {
+	installationStrategy := installPluginLocallyOnlyIfNew
+	if replace {
+		installationStrategy = installPluginLocallyAlways
+	}
+
+	signer, appErr := a.ch.verifyPlugin(a.Log(), pluginFile, signature)
+	if appErr != nil {
+		return nil, signer, appErr
+	}
+
+	manifest, appErr := a.ch.installPlugin(pluginFile, signature, installationStrategy)
+	if appErr != nil {
+		return nil, signer, appErr
+	}
+
+	return manifest, signer, nil
+}
+
 // installPlugin extracts and installs the given plugin bundle (optionally signed) for the
 // current server, activating the plugin if already enabled, installs it to the filestore for
 // cluster peers to use, and then broadcasts the change to connected websockets.
@@ -260,8 +284,8 @@ func (ch *Channels) installPluginToFilestore(manifest *model.Manifest, bundle, s
 
 // InstallMarketplacePlugin installs a plugin listed in the marketplace server. It will get the
 // plugin bundle from the prepackaged folder, if available, or remotely if EnableRemoteMarketplace
-// is true.
-func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePluginRequest) (*model.Manifest, *model.AppError) {
+// is true. The signer of the plugin is returned along with its manifest.
+func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePluginRequest) (*model.Manifest, *model.PluginSigner, *model.AppError) {
 	logger := ch.srv.Log().With(
 		mlog.String("plugin_id", request.Id),
 		mlog.String("requested_version", request.Version),
@@ -272,18 +296,18 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 
 	prepackagedPlugin, appErr := ch.getPrepackagedPlugin(request.Id, request.Version)
 	if appErr != nil && appErr.Id != "app.plugin.marketplace_plugins.not_found.app_error" {
-		return nil, appErr
+		return nil, nil, appErr
 	}
 	if prepackagedPlugin != nil {
 		fileReader, err := os.Open(prepackagedPlugin.Path)
 		if err != nil {
-			return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.install_marketplace_plugin.app_error", nil, fmt.Sprintf("failed to open prepackaged plugin %s", prepackagedPlugin.Path), http.StatusInternalServerError).Wrap(err)
+			return nil, nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.install_marketplace_plugin.app_error", nil, fmt.Sprintf("failed to open prepackaged plugin %s", prepackagedPlugin.Path), http.StatusInternalServerError).Wrap(err)
 		}
 		defer fileReader.Close()
 
 		signatureReader, err := os.Open(prepackagedPlugin.SignaturePath)
 		if err != nil {
-			return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.install_marketplace_plugin.app_error", nil, fmt.Sprintf("failed to open prepackaged plugin signature %s", prepackagedPlugin.SignaturePath), http.StatusInternalServerError).Wrap(err)
+			return nil, nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.install_marketplace_plugin.app_error", nil, fmt.Sprintf("failed to open prepackaged plugin signature %s", prepackagedPlugin.SignaturePath), http.StatusInternalServerError).Wrap(err)
 		}
 		defer signatureReader.Close()
 
@@ -306,13 +330,13 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 				var err error
 				prepackagedVersion, err = semver.Parse(prepackagedPlugin.Manifest.Version)
 				if err != nil {
-					return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+					return nil, nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 				}
 			}
 
 			marketplaceVersion, err := semver.Parse(plugin.Manifest.Version)
 			if err != nil {
-				return nil, model.NewAppError("InstallMarketplacePlugin", "app.prepackged-plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+				return nil, nil, model.NewAppError("InstallMarketplacePlugin", "app.prepackged-plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 			}
 
 			if prepackagedVersion.LT(marketplaceVersion) { // Always true if no prepackaged plugin was found
@@ -320,11 +344,11 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 
 				downloadedPluginBytes, err := ch.srv.downloadFromURL(plugin.DownloadURL)
 				if err != nil {
-					return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.install_marketplace_plugin.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+					return nil, nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.install_marketplace_plugin.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 				}
 				signature, err := plugin.DecodeSignature()
 				if err != nil {
-					return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.signature_decode.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
+					return nil, nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.signature_decode.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
 				}
 				pluginFile = bytes.NewReader(downloadedPluginBytes)
 				signatureFile = signature
@@ -335,23 +359,23 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 	}
 
 	if pluginFile == nil {
-		return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.marketplace_plugins.not_found.app_error", nil, "", http.StatusInternalServerError)
+		return nil, nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.marketplace_plugins.not_found.app_error", nil, "", http.StatusInternalServerError)
 	}
 	if signatureFile == nil {
-		return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.marketplace_plugins.signature_not_found.app_error", nil, "", http.StatusInternalServerError)
+		return nil, nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.marketplace_plugins.signature_not_found.app_error", nil, "", http.StatusInternalServerError)
 	}
 
-	appErr = ch.verifyPlugin(logger, pluginFile, signatureFile)
+	signer, appErr := ch.verifyPlugin(logger, pluginFile, signatureFile)
 	if appErr != nil {
-		return nil, appErr
+		return nil, signer, appErr
 	}
 
 	manifest, appErr := ch.installPlugin(pluginFile, signatureFile, installPluginLocallyAlways)
 	if appErr != nil {
-		return nil, appErr
+		return nil, signer, appErr
 	}
 
-	return manifest, nil
+	return manifest, signer, nil
 }
 
 type pluginInstallationStrategy int
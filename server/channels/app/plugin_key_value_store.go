@@ -45,6 +45,10 @@ func (a *App) SetPluginKeyWithOptions(pluginID string, key string, value []byte,
 	return a.Srv().Platform().SetPluginKeyWithOptions(pluginID, key, value, options)
 }
 
+func (a *App) SetPluginKeyBatch(pluginID string, operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	return a.Srv().Platform().SetPluginKeyBatch(pluginID, operations)
+}
+
 func (a *App) CompareAndDeletePluginKey(rctx request.CTX, pluginID string, key string, oldValue []byte) (bool, *model.AppError) {
 	kv := &model.PluginKeyValue{
 		PluginId: pluginID,
@@ -124,3 +128,7 @@ func (a *App) DeleteAllExpiredPluginKeys() *model.AppError {
 func (a *App) ListPluginKeys(pluginID string, page, perPage int) ([]string, *model.AppError) {
 	return a.Srv().Platform().ListPluginKeys(pluginID, page, perPage)
 }
+
+func (a *App) ListPluginKeyValues(pluginID string, options model.PluginKVListOptions) ([]*model.PluginKeyValue, *model.AppError) {
+	return a.Srv().Platform().ListPluginKeyValues(pluginID, options)
+}
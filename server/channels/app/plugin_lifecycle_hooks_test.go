@@ -0,0 +1,284 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+)
+
+// lifecycleHooksPlugin rejects changes on objects named "protected" and logs every
+// notification hook with the id of the object it was called for.
+const lifecycleHooksPlugin = `
+	package main
+
+	import (
+		"github.com/mattermost/mattermost/server/public/plugin"
+		"github.com/mattermost/mattermost/server/public/model"
+	)
+
+	type MyPlugin struct {
+		plugin.MattermostPlugin
+	}
+
+	func (p *MyPlugin) ChannelWillBeUpdated(c *plugin.Context, newChannel, oldChannel *model.Channel) string {
+		if oldChannel.Name == "protected" && newChannel.Name != oldChannel.Name {
+			return "protected channels can't be renamed"
+		}
+		return ""
+	}
+
+	func (p *MyPlugin) ChannelHasBeenUpdated(c *plugin.Context, newChannel, oldChannel *model.Channel) {
+		p.API.LogInfo("ChannelHasBeenUpdated", "id", newChannel.Id, "archived", oldChannel.DeleteAt != 0 && newChannel.DeleteAt == 0)
+	}
+
+	func (p *MyPlugin) ChannelWillBeArchived(c *plugin.Context, channel *model.Channel, actor *model.User) string {
+		if channel.Name == "protected" {
+			return "protected channels can't be archived"
+		}
+		return ""
+	}
+
+	func (p *MyPlugin) ChannelHasBeenArchived(c *plugin.Context, channel *model.Channel, actor *model.User) {
+		p.API.LogInfo("ChannelHasBeenArchived", "id", channel.Id, "actor", actor.Id)
+	}
+
+	func (p *MyPlugin) TeamWillBeCreated(c *plugin.Context, team *model.Team) string {
+		if team.Name == "protected" {
+			return "team name is reserved"
+		}
+		return ""
+	}
+
+	func (p *MyPlugin) TeamHasBeenCreated(c *plugin.Context, team *model.Team) {
+		p.API.LogInfo("TeamHasBeenCreated", "id", team.Id)
+	}
+
+	func (p *MyPlugin) TeamWillBeDeleted(c *plugin.Context, team *model.Team) string {
+		if team.DisplayName == "protected" {
+			return "protected teams can't be deleted"
+		}
+		return ""
+	}
+
+	func (p *MyPlugin) TeamHasBeenDeleted(c *plugin.Context, team *model.Team) {
+		p.API.LogInfo("TeamHasBeenDeleted", "id", team.Id)
+	}
+
+	func (p *MyPlugin) UserRolesWillChange(c *plugin.Context, user *model.User, newRoles string) string {
+		if user.Username == "protected" {
+			return "roles are managed externally"
+		}
+		return ""
+	}
+
+	func (p *MyPlugin) UserRolesHaveChanged(c *plugin.Context, user *model.User, oldRoles string) {
+		p.API.LogInfo("UserRolesHaveChanged", "id", user.Id, "old_roles", oldRoles)
+	}
+
+	func (p *MyPlugin) PostWillBePinned(c *plugin.Context, post *model.Post) string {
+		if post.Message == "protected" {
+			return "this post can't be pinned"
+		}
+		return ""
+	}
+
+	func (p *MyPlugin) PostHasBeenPinned(c *plugin.Context, post *model.Post) {
+		p.API.LogInfo("PostHasBeenPinned", "id", post.Id, "pinned", post.IsPinned)
+	}
+
+	func main() {
+		plugin.ClientMain(&MyPlugin{})
+	}
+`
+
+func setupLifecycleHooksPlugin(t *testing.T, th *TestHelper) *plugintest.API {
+	t.Helper()
+
+	var mockAPI plugintest.API
+	mockAPI.On("LoadPluginConfiguration", mock.Anything).Return(nil).Maybe()
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{lifecycleHooksPlugin}, th.App, func(*model.Manifest) plugin.API { return &mockAPI })
+	t.Cleanup(tearDown)
+
+	return &mockAPI
+}
+
+// expectHookLog registers the log line a notification hook is expected to write.
+func expectHookLog(mockAPI *plugintest.API, msg string, keyValuePairs ...any) chan struct{} {
+	called := make(chan struct{})
+	args := append([]any{msg}, keyValuePairs...)
+	mockAPI.On("LogInfo", args...).Run(func(mock.Arguments) { close(called) }).Return().Once()
+	return called
+}
+
+func waitForHook(t *testing.T, called chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-called:
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "hook was not called")
+	}
+}
+
+func TestHookChannelLifecycle(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	protected := th.CreateChannel(t, th.BasicTeam)
+	protected.Name = "protected"
+	protected, appErr := th.App.UpdateChannel(th.Context, protected)
+	require.Nil(t, appErr)
+
+	mockAPI := setupLifecycleHooksPlugin(t, th)
+
+	t.Run("update rejected", func(t *testing.T) {
+		_, appErr := th.App.RenameChannel(th.Context, protected, "renamed", "Renamed")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel.update.rejected_by_plugin", appErr.Id)
+
+		channel, appErr := th.App.GetChannel(th.Context, protected.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "protected", channel.Name)
+	})
+
+	t.Run("archive rejected", func(t *testing.T) {
+		appErr := th.App.DeleteChannel(th.Context, protected, th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel.delete.rejected_by_plugin", appErr.Id)
+	})
+
+	t.Run("update, archive and unarchive notified", func(t *testing.T) {
+		channel := th.CreateChannel(t, th.BasicTeam)
+
+		updated := expectHookLog(mockAPI, "ChannelHasBeenUpdated", "id", channel.Id, "archived", false)
+		_, appErr := th.App.PatchChannel(th.Context, channel, &model.ChannelPatch{Header: model.NewPointer("new header")}, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		waitForHook(t, updated)
+
+		channel, appErr = th.App.GetChannel(th.Context, channel.Id)
+		require.Nil(t, appErr)
+
+		archived := expectHookLog(mockAPI, "ChannelHasBeenArchived", "id", channel.Id, "actor", th.BasicUser.Id)
+		require.Nil(t, th.App.DeleteChannel(th.Context, channel, th.BasicUser.Id))
+		waitForHook(t, archived)
+
+		channel, appErr = th.App.GetChannel(th.Context, channel.Id)
+		require.Nil(t, appErr)
+
+		unarchived := expectHookLog(mockAPI, "ChannelHasBeenUpdated", "id", channel.Id, "archived", true)
+		_, appErr = th.App.RestoreChannel(th.Context, channel, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		waitForHook(t, unarchived)
+	})
+}
+
+func TestHookTeamLifecycle(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	protected := th.CreateTeam(t)
+	protected.DisplayName = "protected"
+	protected, appErr := th.App.UpdateTeam(protected)
+	require.Nil(t, appErr)
+
+	mockAPI := setupLifecycleHooksPlugin(t, th)
+
+	t.Run("creation rejected", func(t *testing.T) {
+		team := &model.Team{DisplayName: "Protected", Name: "protected", Type: model.TeamOpen, Email: "protected@example.com"}
+		_, appErr := th.App.CreateTeam(th.Context, team)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.save.rejected_by_plugin", appErr.Id)
+	})
+
+	t.Run("deletion rejected", func(t *testing.T) {
+		appErr := th.App.SoftDeleteTeam(th.Context, protected.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.delete.rejected_by_plugin", appErr.Id)
+
+		appErr = th.App.PermanentDeleteTeam(th.Context, protected)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.delete.rejected_by_plugin", appErr.Id)
+	})
+
+	t.Run("creation and deletion notified", func(t *testing.T) {
+		team := &model.Team{Id: model.NewId(), DisplayName: "Lifecycle", Name: NewTestId(), Type: model.TeamOpen, Email: "lifecycle@example.com"}
+
+		created := expectHookLog(mockAPI, "TeamHasBeenCreated", "id", team.Id)
+		_, appErr := th.App.CreateTeam(th.Context, team)
+		require.Nil(t, appErr)
+		waitForHook(t, created)
+
+		deleted := expectHookLog(mockAPI, "TeamHasBeenDeleted", "id", team.Id)
+		require.Nil(t, th.App.SoftDeleteTeam(th.Context, team.Id))
+		waitForHook(t, deleted)
+	})
+}
+
+func TestHookUserRolesHaveChanged(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	protected := th.CreateUser(t)
+	protected.Username = "protected"
+	_, appErr := th.App.UpdateUser(th.Context, protected, false)
+	require.Nil(t, appErr)
+
+	mockAPI := setupLifecycleHooksPlugin(t, th)
+
+	t.Run("change rejected", func(t *testing.T) {
+		_, appErr := th.App.UpdateUserRoles(th.Context, protected.Id, model.SystemUserRoleId+" "+model.SystemManagerRoleId, false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.user.update_roles.rejected_by_plugin", appErr.Id)
+
+		user, appErr := th.App.GetUser(protected.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.SystemUserRoleId, user.Roles)
+	})
+
+	t.Run("change notified", func(t *testing.T) {
+		changed := expectHookLog(mockAPI, "UserRolesHaveChanged", "id", th.BasicUser2.Id, "old_roles", th.BasicUser2.Roles)
+		_, appErr := th.App.UpdateUserRoles(th.Context, th.BasicUser2.Id, model.SystemUserRoleId+" "+model.SystemManagerRoleId, false)
+		require.Nil(t, appErr)
+		waitForHook(t, changed)
+	})
+}
+
+func TestHookPostHasBeenPinned(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	mockAPI := setupLifecycleHooksPlugin(t, th)
+
+	t.Run("pin rejected", func(t *testing.T) {
+		post := th.CreateMessagePost(t, th.BasicChannel, "protected")
+
+		_, _, appErr := th.App.PatchPost(th.Context, post.Id, &model.PostPatch{IsPinned: model.NewPointer(true)}, nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.post.pin.rejected_by_plugin", appErr.Id)
+	})
+
+	t.Run("pin and unpin notified", func(t *testing.T) {
+		post := th.CreateMessagePost(t, th.BasicChannel, "pin me")
+
+		pinned := expectHookLog(mockAPI, "PostHasBeenPinned", "id", post.Id, "pinned", true)
+		_, _, appErr := th.App.PatchPost(th.Context, post.Id, &model.PostPatch{IsPinned: model.NewPointer(true)}, nil)
+		require.Nil(t, appErr)
+		waitForHook(t, pinned)
+
+		unpinned := expectHookLog(mockAPI, "PostHasBeenPinned", "id", post.Id, "pinned", false)
+		_, _, appErr = th.App.PatchPost(th.Context, post.Id, &model.PostPatch{IsPinned: model.NewPointer(false)}, nil)
+		require.Nil(t, appErr)
+		waitForHook(t, unpinned)
+	})
+}
@@ -250,6 +250,13 @@ func (ch *Channels) servePluginRequest(w http.ResponseWriter, r *http.Request, h
 		WithLogFields(mlog.String("user_id", session.UserId)).
 		WithSession(session)
 
+	// Plugins don't declare OAuth scopes, so sessions restricted to scopes are treated as unauthenticated
+	if len(session.GetOAuthScopes()) > 0 {
+		rctx.Logger().Debug("Treating session as unauthenticated since it is restricted to OAuth scopes")
+		handler(context, w, r)
+		return
+	}
+
 	// If MFA is required and user has not activated it, treat it as unauthenticated
 	if appErr := app.MFARequired(rctx); appErr != nil {
 		if appErr.StatusCode == http.StatusInternalServerError {
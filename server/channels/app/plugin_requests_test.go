@@ -324,6 +324,29 @@ func TestServePluginRequest(t *testing.T) {
 		require.True(t, handlerCalled)
 	})
 
+	t.Run("session restricted to OAuth scopes - treats as unauthenticated", func(t *testing.T) {
+		scopedSession := &model.Session{UserId: th.BasicUser.Id}
+		scopedSession.SetOAuthScopes([]string{model.OAuthScopePostsRead})
+		scopedSession, appErr := th.App.CreateSession(th.Context, scopedSession)
+		require.Nil(t, appErr)
+
+		req := httptest.NewRequest(http.MethodGet, "/plugins/testplugin/endpoint", nil)
+		req = mux.SetURLVars(req, map[string]string{"plugin_id": "testplugin"})
+		req.Header.Set(model.HeaderAuth, model.HeaderBearer+" "+scopedSession.Token)
+		rr := httptest.NewRecorder()
+
+		handlerCalled := false
+		mockHandler := func(ctx *plugin.Context, w http.ResponseWriter, r *http.Request) {
+			handlerCalled = true
+			assert.Empty(t, r.Header.Get("Mattermost-User-Id"))
+			assert.Empty(t, ctx.SessionId)
+			assert.Empty(t, r.Header.Get(model.HeaderAuth))
+		}
+
+		th.App.ch.servePluginRequest(rr, req, mockHandler)
+		require.True(t, handlerCalled)
+	})
+
 	t.Run("invalid token - treats as unauthenticated", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/plugins/testplugin/endpoint", nil)
 		req = mux.SetURLVars(req, map[string]string{"plugin_id": "testplugin"})
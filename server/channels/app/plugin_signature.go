@@ -5,17 +5,22 @@ package app
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"os"
 	"path/filepath"
 	"slices"
 
 	"github.com/pkg/errors"
-	"golang.org/x/crypto/openpgp"       //nolint:staticcheck
-	"golang.org/x/crypto/openpgp/armor" //nolint:staticcheck
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/armor"  //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
 
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 )
@@ -73,60 +78,208 @@ func (a *App) DeletePublicKey(name string) *model.AppError {
 	return nil
 }
 
-func (ch *Channels) verifyPlugin(logger *mlog.Logger, plugin, signature io.ReadSeeker) *model.AppError {
-	// First try verifying using the hard-coded public key.
-	if err := verifySignature(bytes.NewReader(mattermostPluginPublicKey), plugin, signature); err == nil {
-		logger.Debug("Plugin signature verified using hard-coded public key")
-		return nil
+// pluginSignatureKey is a public key the server may trust to sign plugins.
+type pluginSignatureKey struct {
+	// publicKeyFile is the configured public key file, or empty for the Mattermost public key.
+	publicKeyFile string
+	data          []byte
+}
+
+// pluginSignatureKeys returns the public keys the server may trust to sign plugins, starting
+// with the Mattermost public key unless distrusted.
+func (ch *Channels) pluginSignatureKeys(logger *mlog.Logger) []pluginSignatureKey {
+	settings := ch.srv.Config().PluginSettings
+
+	var keys []pluginSignatureKey
+	if *settings.TrustMattermostSignatureKey {
+		keys = append(keys, pluginSignatureKey{data: mattermostPluginPublicKey})
 	}
 
-	// If that fails, try any of the admin-configured public keys.
-	publicKeys := ch.srv.Config().PluginSettings.SignaturePublicKeyFiles
-	for _, pk := range publicKeys {
+	for _, pk := range settings.SignaturePublicKeyFiles {
 		pkBytes, appErr := ch.srv.getPublicKey(pk)
 		if appErr != nil {
 			logger.Warn("Unable to read configured signature public key file", mlog.String("public_key_path", pk))
 			continue
 		}
-		publicKey := bytes.NewReader(pkBytes)
+		keys = append(keys, pluginSignatureKey{publicKeyFile: pk, data: pkBytes})
+	}
+
+	return keys
+}
+
+// verifyPlugin checks the signature of the given plugin bundle against the trusted public keys
+// and their policies, returning the signer.
+//
+// When the signature matches a key that isn't trusted for the plugin, the signer is returned
+// alongside the error.
+func (ch *Channels) verifyPlugin(logger *mlog.Logger, plugin, signature io.ReadSeeker) (*model.PluginSigner, *model.AppError) {
+	settings := ch.srv.Config().PluginSettings
+
+	var pluginID string
+	var rejectedSigner *model.PluginSigner
+	var rejection *model.AppError
+	for _, key := range ch.pluginSignatureKeys(logger) {
+		keyLogger := logger.With(mlog.String("public_key_path", key.publicKeyFile))
 		if _, err := plugin.Seek(0, io.SeekStart); err != nil {
-			logger.Warn("Unable to seek in public key reader for ", mlog.String("public_key_path", pk))
+			keyLogger.Warn("Unable to seek in plugin bundle")
 			continue
 		}
 		if _, err := signature.Seek(0, io.SeekStart); err != nil {
-			logger.Warn("Unable to seek in signature for public key ", mlog.String("public_key_path", pk))
+			keyLogger.Warn("Unable to seek in signature")
+			continue
+		}
+
+		signer, err := checkSignature(bytes.NewReader(key.data), plugin, signature)
+		if err != nil {
+			continue
+		}
+		signer.PublicKeyFile = key.publicKeyFile
+		keyLogger = keyLogger.With(mlog.String("key_id", signer.KeyId), mlog.String("fingerprint", signer.Fingerprint))
+
+		if idx := slices.IndexFunc(settings.SignatureRevokedKeys, signer.Matches); idx != -1 {
+			keyLogger.Warn("Plugin signed with a revoked key")
+			rejectedSigner = signer
+			rejection = model.NewAppError("VerifyPlugin", "api.plugin.verify_plugin.revoked.app_error", map[string]any{"KeyId": signer.KeyId}, "", http.StatusForbidden)
 			continue
 		}
-		if err := verifySignature(publicKey, plugin, signature); err == nil {
-			logger.Debug("Plugin signature verified using configured public key", mlog.String("public_key_path", pk))
-			return nil
+
+		if policy := settings.SignatureKeyPolicy(key.publicKeyFile); key.publicKeyFile != "" && policy != nil {
+			if policy.IsExpired(model.GetMillis()) {
+				keyLogger.Warn("Plugin signed with an expired key")
+				rejectedSigner = signer
+				rejection = model.NewAppError("VerifyPlugin", "api.plugin.verify_plugin.expired.app_error", map[string]any{"KeyId": signer.KeyId}, "", http.StatusForbidden)
+				continue
+			}
+
+			if len(policy.AllowedPluginIds) > 0 {
+				if pluginID == "" {
+					manifest, appErr := readPluginManifest(plugin)
+					if appErr != nil {
+						return nil, appErr
+					}
+					pluginID = manifest.Id
+				}
+
+				if !policy.AllowsPlugin(pluginID) {
+					keyLogger.Warn("Plugin signed with a key not trusted for the plugin", mlog.String("plugin_id", pluginID))
+					rejectedSigner = signer
+					rejection = model.NewAppError("VerifyPlugin", "api.plugin.verify_plugin.not_allowed.app_error", map[string]any{"KeyId": signer.KeyId, "PluginId": pluginID}, "", http.StatusForbidden)
+					continue
+				}
+			}
+		}
+
+		if signer.IsMattermost() {
+			keyLogger.Debug("Plugin signature verified using hard-coded public key")
+		} else {
+			keyLogger.Debug("Plugin signature verified using configured public key")
 		}
+		return signer, nil
+	}
+
+	if rejection != nil {
+		return rejectedSigner, rejection
+	}
+
+	return nil, model.NewAppError("VerifyPlugin", "api.plugin.verify_plugin.app_error", nil, "", http.StatusInternalServerError)
+}
+
+// VerifyPluginSignature checks the given plugin bundle and signature against the trust store of
+// the server, without installing the plugin.
+func (a *App) VerifyPluginSignature(rctx request.CTX, plugin, signature io.ReadSeeker) (*model.PluginSignatureVerification, *model.AppError) {
+	manifest, appErr := readPluginManifest(plugin)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	verification := &model.PluginSignatureVerification{
+		PluginId:      manifest.Id,
+		PluginVersion: manifest.Version,
+	}
+
+	signer, appErr := a.ch.verifyPlugin(a.Log().With(mlog.String("plugin_id", manifest.Id)), plugin, signature)
+	verification.Signer = signer
+	if appErr != nil {
+		appErr.Translate(rctx.T)
+		verification.Reason = appErr.Message
+		return verification, nil
+	}
+
+	verification.Trusted = true
+	return verification, nil
+}
+
+// readPluginManifest extracts the given plugin bundle to a temporary directory to read its
+// manifest.
+func readPluginManifest(bundle io.ReadSeeker) (*model.Manifest, *model.AppError) {
+	tmpDir, err := os.MkdirTemp("", "pluginmanifest")
+	if err != nil {
+		return nil, model.NewAppError("readPluginManifest", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
+	defer os.RemoveAll(tmpDir)
 
-	return model.NewAppError("VerifyPlugin", "api.plugin.verify_plugin.app_error", nil, "", http.StatusInternalServerError)
+	manifest, _, appErr := extractPlugin(bundle, tmpDir)
+	return manifest, appErr
 }
 
+// verifySignature checks that the given message was signed with the given public key.
 func verifySignature(publicKey, message, signature io.Reader) error {
+	_, err := checkSignature(publicKey, message, signature)
+	return err
+}
+
+// checkSignature checks that the given message was signed with the given public key, returning
+// the signer.
+func checkSignature(publicKey, message, signature io.Reader) (*model.PluginSigner, error) {
 	pk, err := decodeIfArmored(publicKey)
 	if err != nil {
-		return errors.Wrap(err, "can't decode public key")
+		return nil, errors.Wrap(err, "can't decode public key")
 	}
 	s, err := decodeIfArmored(signature)
 	if err != nil {
-		return errors.Wrap(err, "can't decode signature")
+		return nil, errors.Wrap(err, "can't decode signature")
 	}
 	return verifyBinarySignature(pk, message, s)
 }
 
-func verifyBinarySignature(publicKey, signedFile, signature io.Reader) error {
+func verifyBinarySignature(publicKey, signedFile, signature io.Reader) (*model.PluginSigner, error) {
 	keyring, err := openpgp.ReadKeyRing(publicKey)
 	if err != nil {
-		return errors.Wrap(err, "can't read public key")
+		return nil, errors.Wrap(err, "can't read public key")
 	}
-	if _, err = openpgp.CheckDetachedSignature(keyring, signedFile, signature); err != nil {
-		return errors.Wrap(err, "error while checking the signature")
+	signatureBytes, err := io.ReadAll(signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read the signature")
 	}
-	return nil
+	entity, err := openpgp.CheckDetachedSignature(keyring, signedFile, bytes.NewReader(signatureBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "error while checking the signature")
+	}
+
+	return newPluginSigner(entity, signatureBytes), nil
+}
+
+// newPluginSigner describes the given entity, as the signer of the given signature.
+func newPluginSigner(entity *openpgp.Entity, signature []byte) *model.PluginSigner {
+	signer := &model.PluginSigner{
+		KeyId:       fmt.Sprintf("%016X", entity.PrimaryKey.KeyId),
+		Fingerprint: fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint),
+		Identities:  slices.Sorted(maps.Keys(entity.Identities)),
+	}
+
+	// The signature may have been made by a subkey of the signer.
+	if p, err := packet.Read(bytes.NewReader(signature)); err == nil {
+		switch sig := p.(type) {
+		case *packet.Signature:
+			if sig.IssuerKeyId != nil {
+				signer.KeyId = fmt.Sprintf("%016X", *sig.IssuerKeyId)
+			}
+		case *packet.SignatureV3:
+			signer.KeyId = fmt.Sprintf("%016X", sig.IssuerKeyId)
+		}
+	}
+
+	return signer
 }
 
 func decodeIfArmored(reader io.Reader) (io.Reader, error) {
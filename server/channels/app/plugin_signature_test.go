@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -16,8 +17,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
 )
 
-func TestPluginPublicKeys(t *testing.T) {
-	mainHelper.Parallel(t)
+func setupPluginSignatureTest(t *testing.T) *TestHelper {
 	th := SetupWithStoreMock(t)
 
 	mockStore := th.App.Srv().Store().(*mocks.Store)
@@ -35,6 +35,13 @@ func TestPluginPublicKeys(t *testing.T) {
 	mockStore.On("System").Return(&mockSystemStore)
 	mockStore.On("GetDBSchemaVersion").Return(1, nil)
 
+	return th
+}
+
+func TestPluginPublicKeys(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupPluginSignatureTest(t)
+
 	path, _ := fileutils.FindDir("tests")
 	publicKeyFilename := "test-public-key.plugin.gpg"
 	publicKey, err := os.ReadFile(filepath.Join(path, publicKeyFilename))
@@ -120,3 +127,126 @@ func TestVerifySignature(t *testing.T) {
 		require.NoError(t, verifySignature(publicKeyFileReader, pluginFileReader, signatureFileReader))
 	})
 }
+
+func TestVerifyPlugin(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupPluginSignatureTest(t)
+
+	path, _ := fileutils.FindDir("tests")
+	publicKeyFilename := "development-public-key.asc"
+	publicKeyReader, err := os.Open(filepath.Join(path, publicKeyFilename))
+	require.NoError(t, err)
+	defer publicKeyReader.Close()
+	require.Nil(t, th.App.AddPublicKey(publicKeyFilename, publicKeyReader))
+
+	pluginReader, err := os.Open(filepath.Join(path, "testplugin.tar.gz"))
+	require.NoError(t, err)
+	defer pluginReader.Close()
+	signatureReader, err := os.Open(filepath.Join(path, "testplugin.tar.gz.sig"))
+	require.NoError(t, err)
+	defer signatureReader.Close()
+
+	setPolicy := func(policies []*model.PluginSignatureKeyPolicy, revokedKeys []string) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.PluginSettings.SignatureKeyPolicies = policies
+			cfg.PluginSettings.SignatureRevokedKeys = revokedKeys
+		})
+	}
+
+	t.Run("trusted signer", func(t *testing.T) {
+		setPolicy(nil, nil)
+
+		signer, appErr := th.App.ch.verifyPlugin(th.App.Log(), pluginReader, signatureReader)
+		require.Nil(t, appErr)
+		assert.Equal(t, publicKeyFilename, signer.PublicKeyFile)
+		assert.Len(t, signer.KeyId, 16)
+		assert.Len(t, signer.Fingerprint, 40)
+		assert.NotEmpty(t, signer.Identities)
+		assert.False(t, signer.IsMattermost())
+	})
+
+	t.Run("unknown signer", func(t *testing.T) {
+		setPolicy(nil, nil)
+
+		otherSignatureReader, err := os.Open(filepath.Join(path, "testplugin2.tar.gz.sig"))
+		require.NoError(t, err)
+		defer otherSignatureReader.Close()
+
+		signer, appErr := th.App.ch.verifyPlugin(th.App.Log(), pluginReader, otherSignatureReader)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.plugin.verify_plugin.app_error", appErr.Id)
+		assert.Nil(t, signer)
+	})
+
+	t.Run("allowed plugin", func(t *testing.T) {
+		setPolicy([]*model.PluginSignatureKeyPolicy{{
+			PublicKeyFile:    model.NewPointer(publicKeyFilename),
+			AllowedPluginIds: []string{"testplugin"},
+			ExpiresAt:        model.NewPointer(model.GetMillis() + 60*1000),
+		}}, nil)
+
+		_, appErr := th.App.ch.verifyPlugin(th.App.Log(), pluginReader, signatureReader)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("plugin not allowed", func(t *testing.T) {
+		setPolicy([]*model.PluginSignatureKeyPolicy{{
+			PublicKeyFile:    model.NewPointer(publicKeyFilename),
+			AllowedPluginIds: []string{"com.example.other"},
+			ExpiresAt:        model.NewPointer(int64(0)),
+		}}, nil)
+
+		signer, appErr := th.App.ch.verifyPlugin(th.App.Log(), pluginReader, signatureReader)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.plugin.verify_plugin.not_allowed.app_error", appErr.Id)
+		require.NotNil(t, signer)
+		assert.Equal(t, publicKeyFilename, signer.PublicKeyFile)
+	})
+
+	t.Run("expired key", func(t *testing.T) {
+		setPolicy([]*model.PluginSignatureKeyPolicy{{
+			PublicKeyFile:    model.NewPointer(publicKeyFilename),
+			AllowedPluginIds: []string{},
+			ExpiresAt:        model.NewPointer(model.GetMillis() - 1),
+		}}, nil)
+
+		_, appErr := th.App.ch.verifyPlugin(th.App.Log(), pluginReader, signatureReader)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.plugin.verify_plugin.expired.app_error", appErr.Id)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		setPolicy(nil, nil)
+		signer, appErr := th.App.ch.verifyPlugin(th.App.Log(), pluginReader, signatureReader)
+		require.Nil(t, appErr)
+
+		setPolicy(nil, []string{signer.Fingerprint})
+
+		_, appErr = th.App.ch.verifyPlugin(th.App.Log(), pluginReader, signatureReader)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.plugin.verify_plugin.revoked.app_error", appErr.Id)
+	})
+
+	t.Run("verification report", func(t *testing.T) {
+		setPolicy([]*model.PluginSignatureKeyPolicy{{
+			PublicKeyFile:    model.NewPointer(publicKeyFilename),
+			AllowedPluginIds: []string{"com.example.other"},
+			ExpiresAt:        model.NewPointer(int64(0)),
+		}}, nil)
+
+		verification, appErr := th.App.VerifyPluginSignature(th.Context, pluginReader, signatureReader)
+		require.Nil(t, appErr)
+		assert.Equal(t, "testplugin", verification.PluginId)
+		assert.Equal(t, "0.0.1", verification.PluginVersion)
+		assert.False(t, verification.Trusted)
+		assert.NotEmpty(t, verification.Reason)
+		require.NotNil(t, verification.Signer)
+
+		setPolicy(nil, nil)
+
+		verification, appErr = th.App.VerifyPluginSignature(th.Context, pluginReader, signatureReader)
+		require.Nil(t, appErr)
+		assert.True(t, verification.Trusted)
+		assert.Empty(t, verification.Reason)
+	})
+}
@@ -0,0 +1,453 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/utils"
+)
+
+const (
+	// pluginWatchInterval is how often a watched plugin source directory is scanned for changes.
+	pluginWatchInterval = time.Second
+
+	// pluginWatchBuildTimeout bounds the time spent running the build command of a watched plugin.
+	pluginWatchBuildTimeout = 5 * time.Minute
+)
+
+// pluginWatchIgnoredDirs are not scanned for source changes. Build outputs are ignored so that a
+// build doesn't trigger another build.
+var pluginWatchIgnoredDirs = []string{"node_modules", "dist", "vendor"}
+
+// pluginWatcher reloads a plugin whenever its local source directory changes.
+//
+// Changes are only acted upon once the directory has been stable for one scan, so that a
+// half-written executable is never loaded.
+type pluginWatcher struct {
+	ch           *Channels
+	logger       *mlog.Logger
+	pluginID     string
+	directory    string
+	buildCommand []string
+
+	sourceFingerprint string
+	pendingSource     string
+	bundleFingerprint string
+	pendingBundle     string
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// WatchPlugin installs the plugin found in a local source directory and reloads it whenever its
+// manifest, server executable or webapp bundle changes. The plugin's key value store is kept
+// across reloads. Only available when developer mode is enabled.
+func (a *App) WatchPlugin(request *model.PluginWatchRequest) (*model.Manifest, *model.AppError) {
+	return a.ch.watchPlugin(request)
+}
+
+// UnwatchPlugin stops watching the source directory of a plugin. The plugin stays installed.
+func (a *App) UnwatchPlugin(pluginID string) *model.AppError {
+	return a.ch.unwatchPlugin(pluginID)
+}
+
+func (ch *Channels) watchPlugin(request *model.PluginWatchRequest) (*model.Manifest, *model.AppError) {
+	if !*ch.cfgSvc.Config().ServiceSettings.EnableDeveloper {
+		return nil, model.NewAppError("WatchPlugin", "app.plugin.watch.developer_mode.app_error", nil, "", http.StatusForbidden)
+	}
+
+	if ch.GetPluginsEnvironment() == nil {
+		return nil, model.NewAppError("WatchPlugin", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	manifest, _, err := model.FindManifest(request.Directory)
+	if err != nil {
+		return nil, model.NewAppError("WatchPlugin", "app.plugin.watch.manifest.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	w := &pluginWatcher{
+		ch:           ch,
+		logger:       ch.srv.Log().With(mlog.String("plugin_id", manifest.Id), mlog.String("directory", request.Directory)),
+		pluginID:     manifest.Id,
+		directory:    request.Directory,
+		buildCommand: request.BuildCommand,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	// Replace any previous watcher of the same plugin before installing.
+	ch.stopPluginWatcher(manifest.Id)
+
+	if len(w.buildCommand) > 0 {
+		if w.sourceFingerprint, err = w.fingerprintSource(); err != nil {
+			return nil, model.NewAppError("WatchPlugin", "app.plugin.watch.scan.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+	if w.bundleFingerprint, err = w.fingerprintBundle(); err != nil {
+		return nil, model.NewAppError("WatchPlugin", "app.plugin.watch.scan.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	w.pendingSource, w.pendingBundle = w.sourceFingerprint, w.bundleFingerprint
+
+	manifest, appErr := w.reload()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if state := ch.cfgSvc.Config().PluginSettings.PluginStates[manifest.Id]; state == nil || !state.Enable {
+		if appErr = ch.enablePlugin(manifest.Id); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	// A concurrent request for the same plugin may have registered its watcher in the meantime;
+	// the last one registered wins and the others are stopped.
+	ch.pluginWatchersLock.Lock()
+	if ch.pluginWatchers == nil {
+		ch.pluginWatchers = make(map[string]*pluginWatcher)
+	}
+	previous := ch.pluginWatchers[manifest.Id]
+	ch.pluginWatchers[manifest.Id] = w
+	ch.pluginWatchersLock.Unlock()
+
+	go w.run()
+
+	if previous != nil {
+		previous.stopAndWait()
+	}
+
+	w.logger.Info("Watching plugin source directory")
+
+	return manifest, nil
+}
+
+func (ch *Channels) unwatchPlugin(pluginID string) *model.AppError {
+	if !ch.stopPluginWatcher(pluginID) {
+		return model.NewAppError("UnwatchPlugin", "app.plugin.watch.not_watched.app_error", map[string]any{"PluginId": pluginID}, "", http.StatusNotFound)
+	}
+
+	return nil
+}
+
+// stopPluginWatcher stops the watcher of the given plugin, if any, and returns whether there was one.
+func (ch *Channels) stopPluginWatcher(pluginID string) bool {
+	ch.pluginWatchersLock.Lock()
+	w, ok := ch.pluginWatchers[pluginID]
+	delete(ch.pluginWatchers, pluginID)
+	ch.pluginWatchersLock.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	w.stopAndWait()
+	return true
+}
+
+// stopAndWait stops the watcher and waits for it to exit. It must not be called while holding
+// pluginWatchersLock, since a watcher stopping by itself takes the lock.
+func (w *pluginWatcher) stopAndWait() {
+	close(w.stop)
+	<-w.done
+}
+
+// stopPluginWatchers stops all plugin watchers.
+func (ch *Channels) stopPluginWatchers() {
+	ch.pluginWatchersLock.Lock()
+	pluginIDs := make([]string, 0, len(ch.pluginWatchers))
+	for pluginID := range ch.pluginWatchers {
+		pluginIDs = append(pluginIDs, pluginID)
+	}
+	ch.pluginWatchersLock.Unlock()
+
+	for _, pluginID := range pluginIDs {
+		ch.stopPluginWatcher(pluginID)
+	}
+}
+
+// removePluginWatcher forgets a watcher that stopped by itself.
+func (ch *Channels) removePluginWatcher(w *pluginWatcher) {
+	ch.pluginWatchersLock.Lock()
+	defer ch.pluginWatchersLock.Unlock()
+
+	if ch.pluginWatchers[w.pluginID] == w {
+		delete(ch.pluginWatchers, w.pluginID)
+	}
+}
+
+func (w *pluginWatcher) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(pluginWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			w.logger.Info("Stopped watching plugin source directory")
+			return
+		case <-ticker.C:
+		}
+
+		if !*w.ch.cfgSvc.Config().ServiceSettings.EnableDeveloper {
+			w.logger.Info("Developer mode disabled, stopped watching plugin source directory")
+			w.ch.removePluginWatcher(w)
+			return
+		}
+
+		w.poll()
+	}
+}
+
+// poll scans the source directory once, running the build command after a source change and
+// reloading the plugin after a bundle change.
+func (w *pluginWatcher) poll() {
+	if len(w.buildCommand) > 0 {
+		source, err := w.fingerprintSource()
+		if err != nil {
+			w.logger.Warn("Failed to scan plugin source directory", mlog.Err(err))
+			return
+		}
+		if source != w.sourceFingerprint && source == w.pendingSource {
+			w.sourceFingerprint = source
+			w.build()
+		}
+		w.pendingSource = source
+	}
+
+	bundle, err := w.fingerprintBundle()
+	if err != nil {
+		w.logger.Warn("Failed to scan plugin bundle", mlog.Err(err))
+		return
+	}
+	if bundle != w.bundleFingerprint && bundle == w.pendingBundle {
+		w.bundleFingerprint = bundle
+		if _, appErr := w.reload(); appErr != nil {
+			w.logger.Error("Failed to reload plugin", mlog.Err(appErr))
+		}
+	}
+	w.pendingBundle = bundle
+}
+
+// build runs the build command in the source directory. The watcher can be stopped while the
+// build is running.
+func (w *pluginWatcher) build() {
+	ctx, cancel := context.WithTimeout(context.Background(), pluginWatchBuildTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-w.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	w.logger.Info("Building plugin", mlog.String("command", strings.Join(w.buildCommand, " ")))
+
+	cmd := exec.CommandContext(ctx, w.buildCommand[0], w.buildCommand[1:]...)
+	cmd.Dir = w.directory
+	if output, err := cmd.CombinedOutput(); err != nil {
+		w.logger.Error("Failed to build plugin", mlog.String("output", string(output)), mlog.Err(err))
+	}
+}
+
+// reload installs the current bundle of the watched plugin, restarting it if it is enabled.
+// Installing a plugin doesn't touch its key value store.
+func (w *pluginWatcher) reload() (*model.Manifest, *model.AppError) {
+	manifest, _, err := model.FindManifest(w.directory)
+	if err != nil {
+		return nil, model.NewAppError("reload", "app.plugin.watch.manifest.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	if manifest.Id != w.pluginID {
+		return nil, model.NewAppError("reload", "app.plugin.watch.id_changed.app_error", map[string]any{"PluginId": w.pluginID}, "", http.StatusBadRequest)
+	}
+
+	stagingDir, err := os.MkdirTemp("", "plugin-watch")
+	if err != nil {
+		return nil, model.NewAppError("reload", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	bundleDir := filepath.Join(stagingDir, manifest.Id)
+	if err = stagePluginBundle(w.directory, manifest, bundleDir); err != nil {
+		return nil, model.NewAppError("reload", "app.plugin.watch.stage.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	manifest, appErr := w.ch.installExtractedPlugin(manifest, bundleDir, installPluginLocallyAlways)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if err := w.ch.notifyPluginEnabled(manifest); err != nil {
+		w.logger.Warn("Failed to notify clients of reloaded plugin", mlog.Err(err))
+	}
+
+	w.logger.Info("Reloaded plugin", mlog.String("version", manifest.Version))
+
+	return manifest, nil
+}
+
+// fingerprintSource summarizes the source files of the watched plugin.
+func (w *pluginWatcher) fingerprintSource() (string, error) {
+	var fingerprint strings.Builder
+	err := filepath.WalkDir(w.directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != w.directory && (strings.HasPrefix(d.Name(), ".") || slices.Contains(pluginWatchIgnoredDirs, d.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		return writeFileFingerprint(&fingerprint, path, d)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fingerprint.String(), nil
+}
+
+// fingerprintBundle summarizes the files loaded by the server for the watched plugin.
+func (w *pluginWatcher) fingerprintBundle() (string, error) {
+	manifest, manifestPath, err := model.FindManifest(w.directory)
+	if err != nil {
+		return "", err
+	}
+
+	paths := []string{manifestPath}
+	if manifest.HasServer() {
+		if executable := manifest.GetExecutableForRuntime(runtime.GOOS, runtime.GOARCH); executable != "" {
+			paths = append(paths, filepath.Join(w.directory, executable))
+		}
+	}
+	if manifest.HasWebapp() && manifest.Webapp.BundlePath != "" {
+		paths = append(paths, filepath.Join(w.directory, manifest.Webapp.BundlePath))
+	}
+
+	var fingerprint strings.Builder
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			// Not built yet.
+			fmt.Fprintf(&fingerprint, "%s:missing\n", path)
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&fingerprint, "%s:%d:%d\n", path, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return fingerprint.String(), nil
+}
+
+func writeFileFingerprint(fingerprint *strings.Builder, path string, d fs.DirEntry) error {
+	info, err := d.Info()
+	if os.IsNotExist(err) {
+		// Removed while scanning, which will show in the next scan.
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(fingerprint, "%s:%d:%d\n", path, info.Size(), info.ModTime().UnixNano())
+	return nil
+}
+
+// stagePluginBundle copies the files of a plugin bundle from its source directory to bundleDir:
+// the manifest, the server executable for this platform, the webapp bundle directory, and the
+// assets and public directories.
+func stagePluginBundle(sourceDir string, manifest *model.Manifest, bundleDir string) error {
+	_, manifestPath, err := model.FindManifest(sourceDir)
+	if err != nil {
+		return err
+	}
+	if err = utils.CopyFile(manifestPath, filepath.Join(bundleDir, filepath.Base(manifestPath))); err != nil {
+		return errors.Wrap(err, "failed to copy manifest")
+	}
+
+	if manifest.HasServer() {
+		executable := manifest.GetExecutableForRuntime(runtime.GOOS, runtime.GOARCH)
+		if executable == "" {
+			return errors.Errorf("no server executable for %s-%s", runtime.GOOS, runtime.GOARCH)
+		}
+		if err = copyBundleFile(sourceDir, bundleDir, executable); err != nil {
+			return errors.Wrap(err, "failed to copy server executable")
+		}
+	}
+
+	if manifest.HasWebapp() {
+		if webappDir := filepath.Dir(manifest.Webapp.BundlePath); webappDir == "." {
+			err = copyBundleFile(sourceDir, bundleDir, manifest.Webapp.BundlePath)
+		} else {
+			err = copyBundleDir(sourceDir, bundleDir, webappDir)
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to copy webapp bundle")
+		}
+	}
+
+	for _, dir := range []string{"assets", "public"} {
+		if _, err = os.Stat(filepath.Join(sourceDir, dir)); os.IsNotExist(err) {
+			continue
+		}
+		if err = copyBundleDir(sourceDir, bundleDir, dir); err != nil {
+			return errors.Wrapf(err, "failed to copy %s", dir)
+		}
+	}
+
+	return nil
+}
+
+// copyBundleFile copies a file given relative to the source directory, refusing paths that
+// escape it.
+func copyBundleFile(sourceDir, bundleDir, path string) error {
+	path, err := bundleRelativePath(path)
+	if err != nil {
+		return err
+	}
+
+	return utils.CopyFile(filepath.Join(sourceDir, path), filepath.Join(bundleDir, path))
+}
+
+// copyBundleDir copies a directory given relative to the source directory, refusing paths that
+// escape it.
+func copyBundleDir(sourceDir, bundleDir, path string) error {
+	path, err := bundleRelativePath(path)
+	if err != nil {
+		return err
+	}
+
+	destination := filepath.Join(bundleDir, path)
+	if err = os.MkdirAll(filepath.Dir(destination), 0700); err != nil {
+		return err
+	}
+
+	return utils.CopyDir(filepath.Join(sourceDir, path), destination)
+}
+
+func bundleRelativePath(path string) (string, error) {
+	path = filepath.Clean(path)
+	if path == "." || filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("invalid bundle path %q", path)
+	}
+
+	return path, nil
+}
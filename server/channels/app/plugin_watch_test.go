@@ -0,0 +1,171 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func writeWatchedPlugin(t *testing.T, dir string, manifest *model.Manifest, files map[string]string) {
+	t.Helper()
+
+	manifestJSON, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.json"), manifestJSON, 0600))
+
+	for name, body := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0700))
+	}
+}
+
+func TestStagePluginBundle(t *testing.T) {
+	executable := fmt.Sprintf("server/dist/plugin-%s-%s", runtime.GOOS, runtime.GOARCH)
+	manifest := &model.Manifest{
+		Id: "com.mattermost.watched",
+		Server: &model.ManifestServer{
+			Executables: map[string]string{fmt.Sprintf("%s-%s", runtime.GOOS, runtime.GOARCH): executable},
+		},
+		Webapp: &model.ManifestWebapp{BundlePath: "webapp/dist/main.js"},
+	}
+
+	t.Run("copies the bundle files only", func(t *testing.T) {
+		sourceDir := t.TempDir()
+		writeWatchedPlugin(t, sourceDir, manifest, map[string]string{
+			executable:                 "binary",
+			"webapp/dist/main.js":      "bundle",
+			"webapp/src/index.js":      "source",
+			"assets/icon.svg":          "icon",
+			"server/plugin.go":         "source",
+			"node_modules/dep/dep.js":  "dependency",
+			"public/hello.html":        "hello",
+			"server/dist/plugin-other": "other",
+		})
+
+		bundleDir := filepath.Join(t.TempDir(), manifest.Id)
+		require.NoError(t, stagePluginBundle(sourceDir, manifest, bundleDir))
+
+		for _, name := range []string{"plugin.json", executable, "webapp/dist/main.js", "assets/icon.svg", "public/hello.html"} {
+			assert.FileExists(t, filepath.Join(bundleDir, name))
+		}
+		for _, name := range []string{"webapp/src/index.js", "server/plugin.go", "node_modules", "server/dist/plugin-other"} {
+			assert.NoFileExists(t, filepath.Join(bundleDir, name))
+		}
+	})
+
+	t.Run("missing executable", func(t *testing.T) {
+		sourceDir := t.TempDir()
+		writeWatchedPlugin(t, sourceDir, manifest, map[string]string{
+			"webapp/dist/main.js": "bundle",
+		})
+
+		require.Error(t, stagePluginBundle(sourceDir, manifest, filepath.Join(t.TempDir(), manifest.Id)))
+	})
+
+	t.Run("executable outside of the source directory", func(t *testing.T) {
+		manifest := &model.Manifest{
+			Id:     "com.mattermost.watched",
+			Server: &model.ManifestServer{Executable: "../plugin"},
+		}
+		sourceDir := t.TempDir()
+		writeWatchedPlugin(t, sourceDir, manifest, nil)
+
+		require.Error(t, stagePluginBundle(sourceDir, manifest, filepath.Join(t.TempDir(), manifest.Id)))
+	})
+}
+
+func TestPluginWatcherFingerprint(t *testing.T) {
+	manifest := &model.Manifest{
+		Id:     "com.mattermost.watched",
+		Server: &model.ManifestServer{Executable: "server/dist/plugin"},
+	}
+	sourceDir := t.TempDir()
+	writeWatchedPlugin(t, sourceDir, manifest, map[string]string{
+		"server/plugin.go":        "source",
+		"node_modules/dep/dep.js": "dependency",
+	})
+	w := &pluginWatcher{directory: sourceDir}
+
+	touch := func(name string) {
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(filepath.Join(sourceDir, name), later, later))
+	}
+
+	t.Run("bundle", func(t *testing.T) {
+		missing, err := w.fingerprintBundle()
+		require.NoError(t, err)
+
+		writeWatchedPlugin(t, sourceDir, manifest, map[string]string{"server/dist/plugin": "binary"})
+		built, err := w.fingerprintBundle()
+		require.NoError(t, err)
+		assert.NotEqual(t, missing, built)
+
+		touch("server/plugin.go")
+		unchanged, err := w.fingerprintBundle()
+		require.NoError(t, err)
+		assert.Equal(t, built, unchanged)
+
+		touch("server/dist/plugin")
+		rebuilt, err := w.fingerprintBundle()
+		require.NoError(t, err)
+		assert.NotEqual(t, built, rebuilt)
+	})
+
+	t.Run("source", func(t *testing.T) {
+		before, err := w.fingerprintSource()
+		require.NoError(t, err)
+
+		touch("node_modules/dep/dep.js")
+		touch("server/dist/plugin")
+		ignored, err := w.fingerprintSource()
+		require.NoError(t, err)
+		assert.Equal(t, before, ignored)
+
+		touch("server/plugin.go")
+		changed, err := w.fingerprintSource()
+		require.NoError(t, err)
+		assert.NotEqual(t, before, changed)
+	})
+}
+
+func TestWatchPlugin(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+
+	t.Run("requires developer mode", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableDeveloper = false })
+
+		_, appErr := th.App.WatchPlugin(&model.PluginWatchRequest{Directory: t.TempDir()})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.watch.developer_mode.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("missing manifest", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableDeveloper = true })
+
+		_, appErr := th.App.WatchPlugin(&model.PluginWatchRequest{Directory: t.TempDir()})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.watch.manifest.app_error", appErr.Id)
+	})
+
+	t.Run("unwatch a plugin that is not watched", func(t *testing.T) {
+		appErr := th.App.UnwatchPlugin("com.mattermost.watched")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
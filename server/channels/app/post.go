@@ -880,6 +880,17 @@ func (a *App) UpdatePost(rctx request.CTX, receivedUpdatedPost *model.Post, upda
 
 	var rejectionReason string
 	pluginContext := pluginContext(rctx)
+	pinChanged := newPost.IsPinned != oldPost.IsPinned
+	if pinChanged {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			rejectionReason = hooks.PostWillBePinned(pluginContext, newPost.ForPlugin())
+			return rejectionReason == ""
+		}, plugin.PostWillBePinnedID)
+		if rejectionReason != "" {
+			return nil, false, model.NewAppError("UpdatePost", "app.post.pin.rejected_by_plugin", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+		}
+	}
+
 	if newPost.Type != model.PostTypeBurnOnRead {
 		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			newPost, rejectionReason = hooks.MessageWillBeUpdated(pluginContext, newPost.ForPlugin(), oldPost.ForPlugin())
@@ -921,6 +932,14 @@ func (a *App) UpdatePost(rctx request.CTX, receivedUpdatedPost *model.Post, upda
 			}, plugin.MessageHasBeenUpdatedID)
 		})
 	}
+	if pinChanged {
+		a.Srv().Go(func() {
+			a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+				hooks.PostHasBeenPinned(pluginContext, pluginNewPost)
+				return true
+			}, plugin.PostHasBeenPinnedID)
+		})
+	}
 
 	rpost = a.PreparePostForClientWithEmbedsAndImages(rctx, rpost, &model.PreparePostForClientOpts{IsEditPost: true, IncludePriority: true})
 
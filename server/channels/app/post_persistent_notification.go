@@ -75,6 +75,10 @@ func (a *App) ResolvePersistentNotification(rctx request.CTX, post *model.Post,
 		return model.NewAppError("ResolvePersistentNotification", "app.post_priority.delete_persistent_notification_post.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if scs, _ := a.getSharedChannelsService(false); scs != nil {
+		scs.NotifyPersistentNotificationResolved(post)
+	}
+
 	return nil
 }
 
@@ -6,6 +6,7 @@ package app
 import (
 	"bytes"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -22,6 +23,8 @@ func (a *App) SaveReportChunk(format string, prefix string, count int, reportDat
 	switch format {
 	case "csv":
 		return a.saveCSVChunk(prefix, count, reportData)
+	case "json":
+		return a.saveJSONChunk(prefix, count, reportData)
 	}
 	return model.NewAppError("SaveReportChunk", "app.save_report_chunk.unsupported_format", nil, "unsupported report format", http.StatusBadRequest)
 }
@@ -45,10 +48,28 @@ func (a *App) saveCSVChunk(prefix string, count int, reportData []model.Reportab
 	return appErr
 }
 
+// saveJSONChunk writes every object of the chunk as a JSON document on
+// its own line, so that chunks can be merged without decoding them.
+func (a *App) saveJSONChunk(prefix string, count int, reportData []model.ReportableObject) *model.AppError {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+
+	for _, report := range reportData {
+		if err := enc.Encode(report); err != nil {
+			return model.NewAppError("saveJSONChunk", "app.save_json_chunk.write_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	_, appErr := a.WriteFile(&buf, makeFilePath(prefix, count, "json"))
+	return appErr
+}
+
 func (a *App) CompileReportChunks(format string, prefix string, numberOfChunks int, headers []string) *model.AppError {
 	switch format {
 	case "csv":
 		return a.compileCSVChunks(prefix, numberOfChunks, headers)
+	case "json":
+		return a.compileJSONChunks(prefix, numberOfChunks)
 	}
 	return model.NewAppError("CompileReportChunks", "app.compile_report_chunks.unsupported_format", nil, "", http.StatusBadRequest)
 }
@@ -87,13 +108,43 @@ func (a *App) compileCSVChunks(prefix string, numberOfChunks int, headers []stri
 	return nil
 }
 
+// compileJSONChunks merges the chunks into a single JSON array.
+func (a *App) compileJSONChunks(prefix string, numberOfChunks int) *model.AppError {
+	var compiledBuf bytes.Buffer
+	compiledBuf.WriteString("[")
+
+	first := true
+	for i := range numberOfChunks {
+		chunk, appErr := a.ReadFile(makeFilePath(prefix, i, "json"))
+		if appErr != nil {
+			return appErr
+		}
+		for line := range bytes.Lines(chunk) {
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				continue
+			}
+			if !first {
+				compiledBuf.WriteString(",")
+			}
+			compiledBuf.WriteString("\n")
+			compiledBuf.Write(line)
+			first = false
+		}
+	}
+	compiledBuf.WriteString("\n]\n")
+
+	_, appErr := a.WriteFile(&compiledBuf, makeCompiledFilePath(prefix, "json"))
+	return appErr
+}
+
 func (a *App) SendReportToUser(rctx request.CTX, job *model.Job, format string) *model.AppError {
 	requestingUserId := job.Data["requesting_user_id"]
 	if requestingUserId == "" {
 		return model.NewAppError("SendReportToUser", "app.report.send_report_to_user.missing_user_id", nil, "", http.StatusInternalServerError)
 	}
 	dateRange := job.Data["date_range"]
-	if dateRange == "" {
+	if dateRange == "" && job.Type != model.JobTypeExportAccessReport {
 		return model.NewAppError("SendReportToUser", "app.report.send_report_to_user.missing_date_range", nil, "", http.StatusInternalServerError)
 	}
 
@@ -128,14 +179,18 @@ func (a *App) SendReportToUser(rctx request.CTX, job *model.Job, format string)
 		return err
 	}
 	T := i18n.GetUserTranslations(user.Locale)
+	message := T("app.report.send_report_to_user.export_finished", map[string]string{
+		"DateRange": getTranslatedDateRange(dateRange),
+	})
+	if job.Type == model.JobTypeExportAccessReport {
+		message = T("app.report.send_report_to_user.access_report_finished")
+	}
 	post := &model.Post{
 		ChannelId: channel.Id,
-		Message: T("app.report.send_report_to_user.export_finished", map[string]string{
-			"DateRange": getTranslatedDateRange(dateRange),
-		}),
-		Type:    model.PostTypeDefault,
-		UserId:  systemBot.UserId,
-		FileIds: []string{fileInfo.Id},
+		Message:   message,
+		Type:      model.PostTypeDefault,
+		UserId:    systemBot.UserId,
+		FileIds:   []string{fileInfo.Id},
 	}
 
 	_, _, err = a.CreatePost(rctx, post, channel, model.CreatePostFlags{SetOnline: true})
@@ -144,15 +199,15 @@ func (a *App) SendReportToUser(rctx request.CTX, job *model.Job, format string)
 
 func (a *App) CleanupReportChunks(format string, prefix string, numberOfChunks int) *model.AppError {
 	switch format {
-	case "csv":
-		return a.cleanupCSVChunks(prefix, numberOfChunks)
+	case "csv", "json":
+		return a.cleanupReportChunks(prefix, numberOfChunks, format)
 	}
 	return model.NewAppError("CompileReportChunks", "app.compile_report_chunks.unsupported_format", nil, "", http.StatusBadRequest)
 }
 
-func (a *App) cleanupCSVChunks(prefix string, numberOfChunks int) *model.AppError {
+func (a *App) cleanupReportChunks(prefix string, numberOfChunks int, extension string) *model.AppError {
 	for i := range numberOfChunks {
-		chunkFilePath := makeFilePath(prefix, i, "csv")
+		chunkFilePath := makeFilePath(prefix, i, extension)
 		if err := a.RemoveFile(chunkFilePath); err != nil {
 			return err
 		}
@@ -4,6 +4,7 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"testing"
@@ -61,6 +62,17 @@ func TestSaveReportChunk(t *testing.T) {
 		require.Equal(t, "some-name,400,2024-01-01\n", string(bytes))
 	})
 
+	t.Run("should write JSON chunk to file", func(t *testing.T) {
+		prefix := model.NewId()
+		err := th.App.SaveReportChunk("json", prefix, 999, []model.ReportableObject{testData[0]})
+		require.Nil(t, err)
+
+		filePath := fmt.Sprintf("admin_reports/batch_report_%s__999.json", prefix)
+		bytes, err := th.App.ReadFile(filePath)
+		require.Nil(t, err)
+		require.Contains(t, string(bytes), `"TestField1":"some-name","TestField2":400`)
+	})
+
 	t.Run("should fail if the report format is not supported", func(t *testing.T) {
 		err := th.App.SaveReportChunk("zzz", model.NewId(), 999, []model.ReportableObject{testData[0]})
 		require.NotNil(t, err)
@@ -105,6 +117,26 @@ some-other-other-name,600,2022-01-01
 		err = th.App.CompileReportChunks("csv", prefix, 4, []string{"Name", "NumPosts", "StartDate"})
 		require.NotNil(t, err)
 	})
+
+	t.Run("should compile JSON chunks into an array", func(t *testing.T) {
+		jsonPrefix := model.NewId()
+		appErr := th.App.SaveReportChunk("json", jsonPrefix, 0, []model.ReportableObject{testData[0], testData[1]})
+		require.Nil(t, appErr)
+		appErr = th.App.SaveReportChunk("json", jsonPrefix, 1, []model.ReportableObject{testData[2]})
+		require.Nil(t, appErr)
+
+		appErr = th.App.CompileReportChunks("json", jsonPrefix, 2, nil)
+		require.Nil(t, appErr)
+
+		bytes, appErr := th.App.ReadFile(fmt.Sprintf("admin_reports/batch_report_%s.json", jsonPrefix))
+		require.Nil(t, appErr)
+
+		var compiled []*MockReportable
+		require.NoError(t, json.Unmarshal(bytes, &compiled))
+		require.Len(t, compiled, 3)
+		require.Equal(t, "some-name", compiled[0].TestField1)
+		require.Equal(t, "some-other-other-name", compiled[2].TestField1)
+	})
 }
 
 func TestCheckForExistingJobs(t *testing.T) {
@@ -0,0 +1,801 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// scimUserFields are the user fields the attributes of SCIM users can be
+// filtered on map to.
+var scimUserFields = map[string]string{
+	"id":              "Id",
+	"username":        "Username",
+	"emails":          "Email",
+	"emails.value":    "Email",
+	"name.givenname":  "FirstName",
+	"name.familyname": "LastName",
+	"nickname":        "Nickname",
+	"title":           "Position",
+}
+
+func (a *App) scimLocation(resource, id string) string {
+	return a.GetSiteURL() + model.APIURLSuffix + model.ScimURLPath + "/" + resource + "/" + id
+}
+
+// getScimManagedUser returns the user, unless it is a bot or a remote user,
+// which aren't provisioned by identity providers.
+func (a *App) getScimManagedUser(userID string) (*model.User, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if user.IsBot || user.IsRemote() {
+		return nil, model.NewAppError("getScimManagedUser", MissingAccountError, nil, "user_id="+userID, http.StatusNotFound)
+	}
+
+	return user, nil
+}
+
+func (a *App) scimUserFromUser(user *model.User, groups []*model.Group) *model.ScimUser {
+	scimUser := &model.ScimUser{
+		Schemas:     []string{model.ScimSchemaUser},
+		Id:          user.Id,
+		UserName:    user.Username,
+		DisplayName: user.GetFullName(),
+		NickName:    user.Nickname,
+		Title:       user.Position,
+		Active:      model.NewPointer(user.DeleteAt == 0),
+		Emails:      []model.ScimMultiValuedAttribute{{Value: user.Email, Type: "work", Primary: true}},
+		Meta: &model.ScimMeta{
+			ResourceType: model.ScimResourceTypeUser,
+			Created:      model.ScimTime(user.CreateAt),
+			LastModified: model.ScimTime(user.UpdateAt),
+			Location:     a.scimLocation("Users", user.Id),
+		},
+	}
+
+	if user.FirstName != "" || user.LastName != "" {
+		scimUser.Name = &model.ScimName{
+			Formatted:  user.GetFullName(),
+			GivenName:  user.FirstName,
+			FamilyName: user.LastName,
+		}
+	}
+
+	if authService := *a.Config().ScimSettings.AuthService; authService != "" && user.AuthService == authService && user.AuthData != nil {
+		scimUser.ExternalId = *user.AuthData
+	}
+
+	for _, group := range groups {
+		if !group.IsProvisioned() || group.DeleteAt != 0 {
+			continue
+		}
+		scimUser.Groups = append(scimUser.Groups, model.ScimMultiValuedAttribute{
+			Value:   group.Id,
+			Display: group.DisplayName,
+			Ref:     a.scimLocation("Groups", group.Id),
+		})
+	}
+
+	return scimUser
+}
+
+func (a *App) scimUserWithGroups(user *model.User) (*model.ScimUser, *model.AppError) {
+	groups, appErr := a.GetGroupsByUserId(user.Id, model.GroupSearchOpts{Source: model.GroupSourceCustom})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.scimUserFromUser(user, groups), nil
+}
+
+// applyScimUser sets the attributes of the user from those of the SCIM user.
+func applyScimUser(user *model.User, scimUser *model.ScimUser) *model.AppError {
+	username := strings.ToLower(strings.TrimSpace(scimUser.UserName))
+	if username == "" {
+		return model.NewAppError("applyScimUser", "app.scim.missing_attribute.app_error", map[string]any{"Attribute": "userName"}, "", http.StatusBadRequest)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(scimUser.PrimaryEmail()))
+	if email == "" {
+		return model.NewAppError("applyScimUser", "app.scim.missing_attribute.app_error", map[string]any{"Attribute": "emails"}, "", http.StatusBadRequest)
+	}
+
+	user.Username = username
+	user.Email = email
+	user.FirstName = ""
+	user.LastName = ""
+	if scimUser.Name != nil {
+		user.FirstName = scimUser.Name.GivenName
+		user.LastName = scimUser.Name.FamilyName
+	}
+	user.Nickname = scimUser.NickName
+	user.Position = scimUser.Title
+
+	return nil
+}
+
+// GetScimUser returns the user as a SCIM resource.
+func (a *App) GetScimUser(userID string) (*model.ScimUser, *model.AppError) {
+	user, appErr := a.getScimManagedUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.scimUserWithGroups(user)
+}
+
+// ListScimUsers returns the page of users matching the filter, if any, along
+// with the total number of matching users. The startIndex is 1-based, as
+// defined by SCIM.
+func (a *App) ListScimUsers(filter *model.ScimFilter, startIndex, count int) (*model.ScimListResponse, *model.AppError) {
+	var userFilter *store.UserFieldFilter
+	if filter != nil {
+		var appErr *model.AppError
+		if userFilter, appErr = a.scimUserFieldFilter(filter); appErr != nil {
+			return nil, appErr
+		}
+
+		users, lookedUp, appErr := a.lookUpScimUsers(filter)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if lookedUp {
+			return a.listLookedUpScimUsers(filter, users, startIndex, count)
+		}
+	}
+
+	total, err := a.Srv().Store().User().CountByFieldFilter(userFilter)
+	if err != nil {
+		return nil, model.NewAppError("ListScimUsers", "app.user.get_total_users_count.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var users []*model.User
+	if count > 0 {
+		users, err = a.Srv().Store().User().GetByFieldFilter(userFilter, startIndex-1, count)
+		if err != nil {
+			return nil, model.NewAppError("ListScimUsers", "app.user.get_profiles.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	resources := make([]any, 0, len(users))
+	for _, user := range users {
+		scimUser, appErr := a.scimUserWithGroups(user)
+		if appErr != nil {
+			return nil, appErr
+		}
+		resources = append(resources, scimUser)
+	}
+
+	return model.NewScimListResponse(resources, int(total), startIndex), nil
+}
+
+// scimUserFieldFilter translates the filter into a query on the fields of
+// users. Filters on attributes users can't be queried on, such as groups, or
+// using operators other than those comparing strings, are rejected.
+func (a *App) scimUserFieldFilter(filter *model.ScimFilter) (*store.UserFieldFilter, *model.AppError) {
+	expr, ok := filter.Expr()
+	if !ok {
+		return nil, model.NewAppError("scimUserFieldFilter", "app.scim.unsupported_filter.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return a.scimUserFieldFilterFromExpr(expr)
+}
+
+func (a *App) scimUserFieldFilterFromExpr(expr *model.ScimFilterExpr) (*store.UserFieldFilter, *model.AppError) {
+	unsupported := func() *model.AppError {
+		return model.NewAppError("scimUserFieldFilter", "app.scim.unsupported_filter.app_error", nil, "attribute="+expr.Attr+" operator="+expr.Op, http.StatusBadRequest)
+	}
+
+	switch expr.Op {
+	case "and", "or":
+		userFilter := &store.UserFieldFilter{Op: store.UserFieldFilterAnd}
+		if expr.Op == "or" {
+			userFilter.Op = store.UserFieldFilterOr
+		}
+		for _, e := range expr.Exprs {
+			f, appErr := a.scimUserFieldFilterFromExpr(e)
+			if appErr != nil {
+				return nil, appErr
+			}
+			userFilter.Filters = append(userFilter.Filters, f)
+		}
+		return userFilter, nil
+	case "eq", "ne", "co", "sw", "ew":
+	default:
+		return nil, unsupported()
+	}
+
+	if expr.Attr == "active" {
+		if _, ok := expr.Value.(bool); !ok || (expr.Op != "eq" && expr.Op != "ne") {
+			return nil, unsupported()
+		}
+		return &store.UserFieldFilter{Op: expr.Op, Field: store.UserFieldActive, Value: expr.Value}, nil
+	}
+
+	if _, ok := expr.Value.(string); !ok {
+		return nil, unsupported()
+	}
+
+	if expr.Attr == "externalid" {
+		authService := *a.Config().ScimSettings.AuthService
+		if authService == "" {
+			// Users have no external id then, so only ne matches them, as it
+			// matches all users, while an empty or matches none.
+			if expr.Op == "ne" {
+				return &store.UserFieldFilter{Op: store.UserFieldFilterAnd}, nil
+			}
+			return &store.UserFieldFilter{Op: store.UserFieldFilterOr}, nil
+		}
+		return &store.UserFieldFilter{Op: store.UserFieldFilterAnd, Filters: []*store.UserFieldFilter{
+			{Op: store.UserFieldFilterEquals, Field: "AuthService", Value: authService},
+			{Op: expr.Op, Field: "AuthData", Value: expr.Value},
+		}}, nil
+	}
+
+	field, ok := scimUserFields[expr.Attr]
+	if !ok {
+		return nil, unsupported()
+	}
+
+	return &store.UserFieldFilter{Op: expr.Op, Field: field, Value: expr.Value}, nil
+}
+
+// lookUpScimUsers looks up the user the filter requires an attribute to be
+// equal to the value of, as identity providers send to find whether a user
+// exists, if the filter requires it.
+func (a *App) lookUpScimUsers(filter *model.ScimFilter) ([]*model.User, bool, *model.AppError) {
+	var user *model.User
+	var appErr *model.AppError
+
+	if id, ok := filter.EqualityValue("id"); ok {
+		user, appErr = a.GetUser(id)
+	} else if username, ok := filter.EqualityValue("userName"); ok {
+		user, appErr = a.GetUserByUsername(strings.ToLower(username))
+	} else if email, ok := filter.EqualityValue("emails.value"); ok {
+		user, appErr = a.GetUserByEmail(email)
+	} else if email, ok := filter.EqualityValue("emails"); ok {
+		user, appErr = a.GetUserByEmail(email)
+	} else if externalID, ok := filter.EqualityValue("externalId"); ok && *a.Config().ScimSettings.AuthService != "" {
+		user, appErr = a.GetUserByAuth(&externalID, *a.Config().ScimSettings.AuthService)
+	} else {
+		return nil, false, nil
+	}
+
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound || appErr.StatusCode == http.StatusBadRequest {
+			return nil, true, nil
+		}
+		return nil, false, appErr
+	}
+
+	return []*model.User{user}, true, nil
+}
+
+// listLookedUpScimUsers returns the page of the looked up users matching the
+// rest of the filter.
+func (a *App) listLookedUpScimUsers(filter *model.ScimFilter, users []*model.User, startIndex, count int) (*model.ScimListResponse, *model.AppError) {
+	var matched []*model.User
+	for _, user := range users {
+		if user.IsBot || user.IsRemote() {
+			continue
+		}
+		resource, err := model.ScimResourceMap(a.scimUserFromUser(user, nil))
+		if err != nil {
+			return nil, model.NewAppError("listLookedUpScimUsers", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if filter.Matches(resource) {
+			matched = append(matched, user)
+		}
+	}
+
+	start := min(startIndex-1, len(matched))
+	end := min(start+count, len(matched))
+
+	resources := make([]any, 0, end-start)
+	for _, user := range matched[start:end] {
+		scimUser, appErr := a.scimUserWithGroups(user)
+		if appErr != nil {
+			return nil, appErr
+		}
+		resources = append(resources, scimUser)
+	}
+
+	return model.NewScimListResponse(resources, len(matched), startIndex), nil
+}
+
+// CreateScimUser creates the user provisioned by an identity provider. Users
+// are created with the authentication service of the SCIM settings, so that
+// they sign in through the identity provider, or else with the password given
+// by the identity provider or a random one they have to reset.
+func (a *App) CreateScimUser(rctx request.CTX, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	user := &model.User{EmailVerified: true}
+	if appErr := applyScimUser(user, scimUser); appErr != nil {
+		return nil, appErr
+	}
+
+	if authService := *a.Config().ScimSettings.AuthService; authService != "" {
+		authData := scimUser.ExternalId
+		if authData == "" {
+			authData = user.Email
+		}
+		user.AuthService = authService
+		user.AuthData = &authData
+	} else {
+		user.Password = scimUser.Password
+		if user.Password == "" {
+			password, err := generatePassword(max(*a.Config().PasswordSettings.MinimumLength, 16))
+			if err != nil {
+				return nil, model.NewAppError("CreateScimUser", "app.scim.generate_password.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			user.Password = password
+		}
+	}
+
+	ruser, appErr := a.CreateUserAsAdmin(rctx, user, "")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if scimUser.Active != nil && !*scimUser.Active {
+		if appErr := a.UpdateUserActive(rctx, ruser.Id, false); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return a.GetScimUser(ruser.Id)
+}
+
+// ReplaceScimUser replaces the attributes of the user with those of the SCIM
+// user. Changes to the email are applied right away, as they come from the
+// identity provider rather than from the user.
+func (a *App) ReplaceScimUser(rctx request.CTX, userID string, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	user, appErr := a.getScimManagedUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := applyScimUser(user, scimUser); appErr != nil {
+		return nil, appErr
+	}
+	email := user.Email
+
+	if authService := *a.Config().ScimSettings.AuthService; authService != "" && user.AuthService == authService && scimUser.ExternalId != "" {
+		user.AuthData = model.NewPointer(scimUser.ExternalId)
+	}
+
+	updatedUser, appErr := a.UpdateUser(rctx, user, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if updatedUser.Email != email {
+		if appErr := a.VerifyUserEmail(userID, email); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if scimUser.Password != "" && updatedUser.AuthService == "" {
+		if appErr := a.UpdatePassword(rctx, updatedUser, scimUser.Password); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if scimUser.Active != nil && *scimUser.Active != (updatedUser.DeleteAt == 0) {
+		if appErr := a.UpdateUserActive(rctx, userID, *scimUser.Active); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return a.GetScimUser(userID)
+}
+
+// PatchScimUser applies the PATCH operations to the user.
+func (a *App) PatchScimUser(rctx request.CTX, userID string, operations []*model.ScimPatchOperation) (*model.ScimUser, *model.AppError) {
+	current, appErr := a.GetScimUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	resource, err := model.ScimResourceMap(current)
+	if err != nil {
+		return nil, model.NewAppError("PatchScimUser", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, op := range operations {
+		if appErr := model.ApplyScimPatchOperation(resource, op); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	patched, err := model.ScimUserFromResourceMap(resource)
+	if err != nil {
+		return nil, model.NewAppError("PatchScimUser", "model.scim.invalid_value.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return a.ReplaceScimUser(rctx, userID, patched)
+}
+
+// DeleteScimUser deactivates the user, as users are never deleted through
+// SCIM so that their content is kept.
+func (a *App) DeleteScimUser(rctx request.CTX, userID string) *model.AppError {
+	user, appErr := a.getScimManagedUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if user.DeleteAt != 0 {
+		return nil
+	}
+
+	return a.UpdateUserActive(rctx, userID, false)
+}
+
+// getScimGroup returns the group, if it was provisioned through SCIM.
+func (a *App) getScimGroup(groupID string) (*model.Group, *model.AppError) {
+	group, appErr := a.GetGroup(groupID, nil, nil)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !group.IsProvisioned() || group.DeleteAt != 0 {
+		return nil, model.NewAppError("getScimGroup", "app.group.no_rows", nil, "group_id="+groupID, http.StatusNotFound)
+	}
+
+	return group, nil
+}
+
+func (a *App) scimGroupFromGroup(group *model.Group, members []*model.User) *model.ScimGroup {
+	scimGroup := &model.ScimGroup{
+		Schemas:     []string{model.ScimSchemaGroup},
+		Id:          group.Id,
+		ExternalId:  group.GetRemoteId(),
+		DisplayName: group.DisplayName,
+		Meta: &model.ScimMeta{
+			ResourceType: model.ScimResourceTypeGroup,
+			Created:      model.ScimTime(group.CreateAt),
+			LastModified: model.ScimTime(group.UpdateAt),
+			Location:     a.scimLocation("Groups", group.Id),
+		},
+	}
+
+	for _, member := range members {
+		scimGroup.Members = append(scimGroup.Members, model.ScimMultiValuedAttribute{
+			Value:   member.Id,
+			Display: member.Username,
+			Ref:     a.scimLocation("Users", member.Id),
+		})
+	}
+
+	return scimGroup
+}
+
+func (a *App) scimGroupWithMembers(group *model.Group) (*model.ScimGroup, *model.AppError) {
+	members, appErr := a.GetGroupMemberUsers(group.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.scimGroupFromGroup(group, members), nil
+}
+
+// GetScimGroup returns the group as a SCIM resource.
+func (a *App) GetScimGroup(groupID string, excludeMembers bool) (*model.ScimGroup, *model.AppError) {
+	group, appErr := a.getScimGroup(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if excludeMembers {
+		return a.scimGroupFromGroup(group, nil), nil
+	}
+
+	return a.scimGroupWithMembers(group)
+}
+
+// ListScimGroups returns the page of provisioned groups matching the filter,
+// if any, along with the total number of matching groups. Filtering on the
+// members of the groups isn't supported.
+func (a *App) ListScimGroups(filter *model.ScimFilter, startIndex, count int, excludeMembers bool) (*model.ScimListResponse, *model.AppError) {
+	var externalID string
+	var lookUp bool
+	if filter != nil {
+		externalID, lookUp = filter.EqualityValue("externalId")
+	}
+
+	var groups []*model.Group
+	if lookUp {
+		group, appErr := a.GetGroupByRemoteID(externalID, model.GroupSourceCustom)
+		if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+			return nil, appErr
+		}
+		if group != nil {
+			groups = append(groups, group)
+		}
+	} else {
+		var appErr *model.AppError
+		groups, appErr = a.GetGroupsBySource(model.GroupSourceCustom)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	var matched []*model.Group
+	for _, group := range groups {
+		if !group.IsProvisioned() || group.DeleteAt != 0 {
+			continue
+		}
+		if filter != nil {
+			resource, err := model.ScimResourceMap(a.scimGroupFromGroup(group, nil))
+			if err != nil {
+				return nil, model.NewAppError("ListScimGroups", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			if !filter.Matches(resource) {
+				continue
+			}
+		}
+		matched = append(matched, group)
+	}
+
+	start := min(startIndex-1, len(matched))
+	end := min(start+count, len(matched))
+
+	resources := make([]any, 0, end-start)
+	for _, group := range matched[start:end] {
+		if excludeMembers {
+			resources = append(resources, a.scimGroupFromGroup(group, nil))
+			continue
+		}
+		scimGroup, appErr := a.scimGroupWithMembers(group)
+		if appErr != nil {
+			return nil, appErr
+		}
+		resources = append(resources, scimGroup)
+	}
+
+	return model.NewScimListResponse(resources, len(matched), startIndex), nil
+}
+
+// CreateScimGroup creates the custom group provisioned by an identity provider.
+// The group keeps the external ID as its remote ID, which marks it as
+// provisioned, so that it can be linked to teams and channels like LDAP groups.
+func (a *App) CreateScimGroup(rctx request.CTX, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	remoteID := scimGroup.ExternalId
+	if remoteID == "" {
+		remoteID = model.NewId()
+	}
+
+	if _, appErr := a.GetGroupByRemoteID(remoteID, model.GroupSourceCustom); appErr == nil {
+		return nil, model.NewAppError("CreateScimGroup", "app.scim.group_exists.app_error", map[string]any{"ExternalId": remoteID}, "", http.StatusConflict)
+	} else if appErr.StatusCode != http.StatusNotFound {
+		return nil, appErr
+	}
+
+	group := &model.Group{
+		DisplayName:    strings.TrimSpace(scimGroup.DisplayName),
+		Source:         model.GroupSourceCustom,
+		RemoteId:       model.NewPointer(remoteID),
+		AllowReference: false,
+	}
+	if appErr := group.IsValidForCreate(); appErr != nil {
+		return nil, appErr
+	}
+
+	group, appErr := a.CreateGroup(group)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.setScimGroupMembers(rctx, group.Id, scimGroup.MemberIds()); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.GetScimGroup(group.Id, false)
+}
+
+// ReplaceScimGroup replaces the display name, external ID and members of the
+// group with those of the SCIM group.
+func (a *App) ReplaceScimGroup(rctx request.CTX, groupID string, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	group, appErr := a.getScimGroup(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	displayName := strings.TrimSpace(scimGroup.DisplayName)
+	if l := len(displayName); l == 0 || l > model.GroupDisplayNameMaxLength {
+		return nil, model.NewAppError("ReplaceScimGroup", "model.group.display_name.app_error", map[string]any{"GroupDisplayNameMaxLength": model.GroupDisplayNameMaxLength}, "", http.StatusBadRequest)
+	}
+
+	remoteID := group.GetRemoteId()
+	if scimGroup.ExternalId != "" && scimGroup.ExternalId != remoteID {
+		if len(scimGroup.ExternalId) > model.GroupRemoteIDMaxLength {
+			return nil, model.NewAppError("ReplaceScimGroup", "model.group.remote_id.app_error", nil, "", http.StatusBadRequest)
+		}
+		if existing, appErr := a.GetGroupByRemoteID(scimGroup.ExternalId, model.GroupSourceCustom); appErr == nil && existing.Id != group.Id {
+			return nil, model.NewAppError("ReplaceScimGroup", "app.scim.group_exists.app_error", map[string]any{"ExternalId": scimGroup.ExternalId}, "", http.StatusConflict)
+		} else if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+			return nil, appErr
+		}
+		remoteID = scimGroup.ExternalId
+	}
+
+	if displayName != group.DisplayName || remoteID != group.GetRemoteId() {
+		group.DisplayName = displayName
+		group.RemoteId = model.NewPointer(remoteID)
+		if _, appErr := a.UpdateGroup(group); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if appErr := a.setScimGroupMembers(rctx, group.Id, scimGroup.MemberIds()); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.GetScimGroup(group.Id, false)
+}
+
+// PatchScimGroup applies the PATCH operations to the group. Removing members
+// with a value, as some identity providers do instead of using a filter,
+// removes the given members only.
+func (a *App) PatchScimGroup(rctx request.CTX, groupID string, operations []*model.ScimPatchOperation) (*model.ScimGroup, *model.AppError) {
+	current, appErr := a.GetScimGroup(groupID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	resource, err := model.ScimResourceMap(current)
+	if err != nil {
+		return nil, model.NewAppError("PatchScimGroup", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, op := range operations {
+		if strings.EqualFold(op.Op, model.ScimPatchOpRemove) && strings.EqualFold(op.Path, "members") && op.Value != nil {
+			removeScimMembers(resource, op.Value)
+			continue
+		}
+		if appErr := model.ApplyScimPatchOperation(resource, op); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	patched, err := model.ScimGroupFromResourceMap(resource)
+	if err != nil {
+		return nil, model.NewAppError("PatchScimGroup", "model.scim.invalid_value.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return a.ReplaceScimGroup(rctx, groupID, patched)
+}
+
+func removeScimMembers(resource map[string]any, value any) {
+	var removed []string
+	values, ok := value.([]any)
+	if !ok {
+		values = []any{value}
+	}
+	for _, v := range values {
+		if member, ok := v.(map[string]any); ok {
+			for key, id := range member {
+				if strings.EqualFold(key, "value") {
+					if id, ok := id.(string); ok {
+						removed = append(removed, id)
+					}
+				}
+			}
+		}
+	}
+
+	members, _ := resource["members"].([]any)
+	kept := make([]any, 0, len(members))
+	for _, m := range members {
+		if member, ok := m.(map[string]any); ok {
+			if id, ok := member["value"].(string); ok && slices.Contains(removed, id) {
+				continue
+			}
+		}
+		kept = append(kept, m)
+	}
+	resource["members"] = kept
+}
+
+// DeleteScimGroup deletes the group and removes its members from the teams
+// and channels constrained to it.
+func (a *App) DeleteScimGroup(rctx request.CTX, groupID string) *model.AppError {
+	if _, appErr := a.getScimGroup(groupID); appErr != nil {
+		return appErr
+	}
+
+	if _, appErr := a.DeleteGroup(groupID); appErr != nil {
+		return appErr
+	}
+
+	// The memberships are updated after the request ends, so not with its context.
+	a.Srv().Go(func() {
+		rctx := request.EmptyContext(a.Log())
+		if err := a.DeleteGroupConstrainedMemberships(rctx); err != nil {
+			rctx.Logger().Error("Failed to remove group constrained memberships after deleting SCIM group", mlog.String("group_id", groupID), mlog.Err(err))
+		}
+	})
+
+	return nil
+}
+
+// setScimGroupMembers sets the members of the group, then adds the new
+// members to the teams and channels synced with the group and removes the
+// former members from those constrained to it.
+func (a *App) setScimGroupMembers(rctx request.CTX, groupID string, userIDs []string) *model.AppError {
+	current, appErr := a.GetGroupMemberUsers(groupID)
+	if appErr != nil {
+		return appErr
+	}
+
+	currentIDs := make(map[string]bool, len(current))
+	for _, user := range current {
+		currentIDs[user.Id] = true
+	}
+
+	wanted := make(map[string]bool, len(userIDs))
+	var added []string
+	for _, userID := range userIDs {
+		if wanted[userID] {
+			continue
+		}
+		wanted[userID] = true
+		if currentIDs[userID] {
+			continue
+		}
+		if _, appErr := a.getScimManagedUser(userID); appErr != nil {
+			return model.NewAppError("setScimGroupMembers", "app.scim.invalid_member.app_error", map[string]any{"UserId": userID}, "", http.StatusBadRequest).Wrap(appErr)
+		}
+		added = append(added, userID)
+	}
+
+	var removed []string
+	for _, user := range current {
+		if !wanted[user.Id] {
+			removed = append(removed, user.Id)
+		}
+	}
+
+	since := model.GetMillis()
+	if len(added) > 0 {
+		if _, appErr := a.UpsertGroupMembers(groupID, added); appErr != nil {
+			return appErr
+		}
+	}
+	if len(removed) > 0 {
+		if _, appErr := a.DeleteGroupMembers(groupID, removed); appErr != nil {
+			return appErr
+		}
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	// The memberships are updated after the request ends, so not with its context.
+	a.Srv().Go(func() {
+		rctx := request.EmptyContext(a.Log())
+		if len(added) > 0 {
+			if err := a.CreateDefaultMemberships(rctx, model.CreateDefaultMembershipParams{Since: since, ReAddRemovedMembers: false}); err != nil {
+				rctx.Logger().Error("Failed to add SCIM group members to synced teams and channels", mlog.String("group_id", groupID), mlog.Err(err))
+			}
+		}
+		if len(removed) > 0 {
+			if err := a.DeleteGroupConstrainedMemberships(rctx); err != nil {
+				rctx.Logger().Error("Failed to remove former SCIM group members from group constrained teams and channels", mlog.String("group_id", groupID), mlog.Err(err))
+			}
+		}
+	})
+
+	return nil
+}
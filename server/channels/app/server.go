@@ -46,6 +46,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_expired_posts"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_orphan_drafts_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/expirynotify"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_access_report"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_delete"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_process"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_users_to_csv"
@@ -65,6 +66,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/refresh_materialized_views"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/resend_invitation_email"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/s3_path_migration"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/user_sync"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/config"
@@ -125,6 +127,10 @@ type Server struct {
 	clusterLeaderListenerId string
 	loggerLicenseListenerId string
 
+	// nodeDiscovery advertises that the server is running, see
+	// model.CDSTypeNode.
+	nodeDiscovery *platform.ClusterDiscoveryService
+
 	platform              *platform.PlatformService
 	platformOptions       []platform.Option
 	telemetryService      *telemetry.TelemetryService
@@ -402,6 +408,12 @@ func NewServer(options ...Option) (*Server, error) {
 		s.EmailService.InitEmailBatching()
 	})
 
+	// Hash passwords with the configured hasher, migrating existing ones on login
+	s.configurePasswordHasher(s.platform.Config())
+	s.platform.AddConfigListener(func(_, newCfg *model.Config) {
+		s.configurePasswordHasher(newCfg)
+	})
+
 	pwd, _ := os.Getwd()
 	mlog.Info("Printing current working", mlog.String("directory", pwd))
 	mlog.Info("Loaded config", mlog.String("source", s.platform.DescribeConfig()))
@@ -502,6 +514,7 @@ func (s *Server) runJobs() {
 		appInstance := New(ServerConnector(s.Channels()))
 		runDNDStatusExpireJob(appInstance)
 		runPostReminderJob(appInstance)
+		runMembershipRequestExpiryJob(appInstance)
 		runScheduledPostJob(appInstance)
 	})
 	s.Go(func() {
@@ -724,6 +737,10 @@ func (s *Server) Shutdown() {
 		s.Log().Warn("Unable to cleanly stop channels", mlog.Err(err))
 	}
 
+	if s.nodeDiscovery != nil {
+		s.nodeDiscovery.Stop()
+	}
+
 	if err = s.platform.Shutdown(); err != nil {
 		s.Log().Warn("Failed to stop platform", mlog.Err(err))
 	}
@@ -841,6 +858,17 @@ func (s *Server) Start() error {
 		mlog.Error("Error to reset the server status.", mlog.Err(err))
 	}
 
+	// Offline tools refuse to write to the database while a node is
+	// running, see mmctl offline.
+	s.nodeDiscovery = s.platform.NewClusterDiscoveryService()
+	s.nodeDiscovery.Type = model.CDSTypeNode
+	s.nodeDiscovery.ClusterName = model.CDSNodeClusterName
+	s.nodeDiscovery.AutoFillHostname()
+	// Records are keyed by hostname, the process ID tells apart the nodes
+	// running on the same host.
+	s.nodeDiscovery.Hostname = fmt.Sprintf("%s:%d", s.nodeDiscovery.Hostname, os.Getpid())
+	s.nodeDiscovery.Start()
+
 	if s.MailServiceConfig().SendEmailNotifications {
 		if err := mail.TestConnection(s.MailServiceConfig()); err != nil {
 			mlog.Error("Mail server connection test failed", mlog.Err(err))
@@ -1484,8 +1512,8 @@ func (s *Server) initJobs() {
 		s.Jobs.RegisterJobType(model.JobTypeLdapSync, builder.MakeWorker(), builder.MakeScheduler())
 	}
 
-	if jobsAccessControlSyncJobInterface != nil {
-		builder := jobsAccessControlSyncJobInterface(s)
+	if _, newAccessControlSyncJob := accessControlInterfaces(); newAccessControlSyncJob != nil {
+		builder := newAccessControlSyncJob(s)
 		s.Jobs.RegisterJobType(model.JobTypeAccessControlSync, builder.MakeWorker(), builder.MakeScheduler())
 	}
 
@@ -1647,6 +1675,18 @@ func (s *Server) initJobs() {
 		nil,
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeExportAccessReport,
+		export_access_report.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeUserSync,
+		user_sync.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeDeleteDmsPreferencesMigration,
 		delete_dms_preferences_migration.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
@@ -1850,6 +1890,32 @@ func runPostReminderJob(a *App) {
 	})
 }
 
+func runMembershipRequestExpiryJob(a *App) {
+	if a.IsLeader() {
+		rctx := request.EmptyContext(a.Log())
+		withMut(&a.ch.membershipRequestExpiryMut, func() {
+			fn := func() { a.ExpireMembershipRequests(rctx) }
+			a.ch.membershipRequestExpiryTask = model.CreateRecurringTaskFromNextIntervalTime("Expire Membership requests", fn, 5*time.Minute)
+		})
+	} else {
+		mlog.Debug("Skipping membership request expiry job startup since this is not the leader node")
+	}
+
+	a.ch.srv.AddClusterLeaderChangedListener(func() {
+		mlog.Info("Cluster leader changed. Determining if membership request expiry task should be running", mlog.Bool("isLeader", a.IsLeader()))
+		if a.IsLeader() {
+			rctx := request.EmptyContext(a.Log())
+			withMut(&a.ch.membershipRequestExpiryMut, func() {
+				fn := func() { a.ExpireMembershipRequests(rctx) }
+				a.ch.membershipRequestExpiryTask = model.CreateRecurringTaskFromNextIntervalTime("Expire Membership requests", fn, 5*time.Minute)
+			})
+		} else {
+			mlog.Debug("This is no longer leader node. Cancelling the membership request expiry task", mlog.Bool("isLeader", a.IsLeader()))
+			cancelTask(&a.ch.membershipRequestExpiryMut, &a.ch.membershipRequestExpiryTask)
+		}
+	})
+}
+
 func runScheduledPostJob(a *App) {
 	if a.IsLeader() {
 		doRunScheduledPostJob(a)
@@ -37,6 +37,10 @@ func (a *App) CreateSession(rctx request.CTX, session *model.Session) (*model.Se
 		return nil, model.NewAppError("login", "api.user.login.remote_users.login.error", nil, "", http.StatusUnauthorized)
 	}
 
+	if appErr := a.applySessionPolicies(rctx, session); appErr != nil {
+		return nil, appErr
+	}
+
 	session, err := a.ch.srv.platform.CreateSession(rctx, session)
 	if err != nil {
 		var invErr *store.ErrInvalidInput
@@ -113,11 +117,7 @@ func (a *App) GetSession(token string) (*model.Session, *model.AppError) {
 		return nil, model.NewAppError("GetSession", "api.context.invalid_token.error", map[string]any{"Token": token, "Error": ""}, "session is either nil or expired", http.StatusUnauthorized)
 	}
 
-	if *a.Config().ServiceSettings.SessionIdleTimeoutInMinutes > 0 &&
-		!session.IsOAuth && !session.IsMobileApp() &&
-		session.Props[model.SessionPropType] != model.SessionTypeUserAccessToken &&
-		!*a.Config().ServiceSettings.ExtendSessionLengthWithActivity {
-		timeout := int64(*a.Config().ServiceSettings.SessionIdleTimeoutInMinutes) * 1000 * 60
+	if timeout := a.getSessionIdleTimeout(session); timeout > 0 {
 		if (model.GetMillis() - session.LastActivityAt) > timeout {
 			// Revoking the session is an asynchronous task anyways since we are not checking
 			// for the return value of the call before returning the error.
@@ -131,6 +131,18 @@ func (a *App) GetSession(token string) (*model.Session, *model.AppError) {
 				if err != nil {
 					rctx.Logger().Warn("Error while revoking session", mlog.Err(err))
 				}
+
+				if a.isSessionPolicyApplicable(session) {
+					auditRec := a.MakeAuditRecord(rctx, model.AuditEventRevokeIdleSession, model.AuditStatusFail)
+					auditRec.AddEventPriorState(session)
+					auditRec.AddMeta("idle_timeout_in_minutes", timeout/(1000*60))
+					if err != nil {
+						auditRec.AddMeta("err", err.Error())
+					} else {
+						auditRec.Success()
+					}
+					a.LogAuditRec(rctx, auditRec, nil)
+				}
 			})
 			return nil, model.NewAppError("GetSession", "api.context.invalid_token.error", map[string]any{"Token": token, "Error": ""}, "idle timeout", http.StatusUnauthorized)
 		}
@@ -466,6 +478,7 @@ func (a *App) createSessionForUserAccessToken(rctx request.CTX, tokenString stri
 
 	session.AddProp(model.SessionPropUserAccessTokenId, token.Id)
 	session.AddProp(model.SessionPropType, model.SessionTypeUserAccessToken)
+	session.SetOAuthScopes(token.Scopes)
 	if user.IsBot {
 		session.AddProp(model.SessionPropIsBot, model.SessionPropIsBotValue)
 	}
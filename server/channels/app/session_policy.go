@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"cmp"
+	"net"
+	"net/http"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// isSessionPolicyApplicable returns whether the session policies apply to the
+// session, which they do to the sessions of users logging in.
+func (a *App) isSessionPolicyApplicable(session *model.Session) bool {
+	return *a.Config().SessionPolicySettings.Enable && !session.IsIntegration() && !session.Local
+}
+
+// applySessionPolicies prepares the new session for the session policies, and
+// revokes the oldest sessions of the user over the limits to make room for it.
+func (a *App) applySessionPolicies(rctx request.CTX, session *model.Session) *model.AppError {
+	if !a.isSessionPolicyApplicable(session) {
+		return nil
+	}
+
+	settings := a.Config().SessionPolicySettings
+	if *settings.BindToIPSubnet && rctx.IPAddress() != "" {
+		session.AddProp(model.SessionPropIPAddress, rctx.IPAddress())
+	}
+
+	maxSessions := *settings.MaxSessionsPerUser
+	maxSessionsPerDeviceType := *settings.MaxSessionsPerDeviceType
+	if maxSessions == 0 && maxSessionsPerDeviceType == 0 {
+		return nil
+	}
+
+	sessions, appErr := a.GetSessions(rctx, session.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	sessions = slices.DeleteFunc(sessions, func(s *model.Session) bool {
+		return !a.isSessionPolicyApplicable(s) || s.IsExpired()
+	})
+	slices.SortFunc(sessions, func(s1, s2 *model.Session) int {
+		return cmp.Compare(s1.CreateAt, s2.CreateAt)
+	})
+
+	// The new session is to be added, so the others must stay below the limits.
+	var evicted []*model.Session
+	if maxSessionsPerDeviceType > 0 {
+		var sameDeviceType []*model.Session
+		for _, s := range sessions {
+			if s.DeviceType() == session.DeviceType() {
+				sameDeviceType = append(sameDeviceType, s)
+			}
+		}
+		if over := len(sameDeviceType) - maxSessionsPerDeviceType + 1; over > 0 {
+			evicted = append(evicted, sameDeviceType[:over]...)
+		}
+	}
+	if maxSessions > 0 {
+		remaining := slices.DeleteFunc(slices.Clone(sessions), func(s *model.Session) bool {
+			return slices.Contains(evicted, s)
+		})
+		if over := len(remaining) - maxSessions + 1; over > 0 {
+			evicted = append(evicted, remaining[:over]...)
+		}
+	}
+
+	for _, s := range evicted {
+		if appErr := a.evictSessionOverLimit(rctx, s); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
+
+func (a *App) evictSessionOverLimit(rctx request.CTX, session *model.Session) *model.AppError {
+	auditRec := a.MakeAuditRecord(rctx, model.AuditEventEvictSessionOverLimit, model.AuditStatusFail)
+	defer a.LogAuditRec(rctx, auditRec, nil)
+	auditRec.AddEventPriorState(session)
+	auditRec.AddMeta("device_type", session.DeviceType())
+
+	if appErr := a.RevokeSession(rctx, session); appErr != nil {
+		auditRec.AddMeta("err", appErr.Error())
+		return appErr
+	}
+
+	auditRec.Success()
+	rctx.Logger().Debug("Session revoked as the user was over the session policy limits",
+		mlog.String("user_id", session.UserId),
+		mlog.String("session_id", session.Id),
+		mlog.String("device_type", session.DeviceType()))
+
+	return nil
+}
+
+// getSessionIdleTimeout returns the time in milliseconds after which the
+// session is revoked when idle, or zero if it never is. The idle timeouts of
+// the session policies take precedence over that of the service settings,
+// which only applies when sessions aren't extended with activity.
+func (a *App) getSessionIdleTimeout(session *model.Session) int64 {
+	if session.IsOAuth || session.IsMobileApp() || session.Props[model.SessionPropType] == model.SessionTypeUserAccessToken {
+		return 0
+	}
+
+	if a.isSessionPolicyApplicable(session) {
+		settings := a.Config().SessionPolicySettings
+		if model.IsInRole(session.Roles, model.SystemAdminRoleId) && *settings.AdminIdleTimeoutInMinutes > 0 {
+			return int64(*settings.AdminIdleTimeoutInMinutes) * 1000 * 60
+		}
+		if model.IsInRole(session.Roles, model.SystemGuestRoleId) && *settings.GuestIdleTimeoutInMinutes > 0 {
+			return int64(*settings.GuestIdleTimeoutInMinutes) * 1000 * 60
+		}
+	}
+
+	if *a.Config().ServiceSettings.SessionIdleTimeoutInMinutes > 0 && !*a.Config().ServiceSettings.ExtendSessionLengthWithActivity {
+		return int64(*a.Config().ServiceSettings.SessionIdleTimeoutInMinutes) * 1000 * 60
+	}
+
+	return 0
+}
+
+// CheckSessionIPSubnet revokes the session if it is bound to the IP subnet it
+// was created from, and used from another one, as its token may have been
+// stolen.
+func (a *App) CheckSessionIPSubnet(rctx request.CTX, session *model.Session) *model.AppError {
+	if !a.isSessionPolicyApplicable(session) || !*a.Config().SessionPolicySettings.BindToIPSubnet {
+		return nil
+	}
+
+	createdFrom := session.Props[model.SessionPropIPAddress]
+	if createdFrom == "" || rctx.IPAddress() == "" {
+		return nil
+	}
+
+	settings := a.Config().SessionPolicySettings
+	if isSameIPSubnet(createdFrom, rctx.IPAddress(), *settings.IPv4SubnetPrefixLength, *settings.IPv6SubnetPrefixLength) {
+		return nil
+	}
+
+	auditRec := a.MakeAuditRecord(rctx, model.AuditEventRevokeSessionOutsideSubnet, model.AuditStatusFail)
+	defer a.LogAuditRec(rctx, auditRec, nil)
+	auditRec.Actor.IpAddress = rctx.IPAddress()
+	auditRec.AddEventPriorState(session)
+	auditRec.AddMeta("created_from", createdFrom)
+
+	if appErr := a.RevokeSession(rctx, session); appErr != nil {
+		auditRec.AddMeta("err", appErr.Error())
+		return appErr
+	}
+
+	auditRec.Success()
+
+	return model.NewAppError("CheckSessionIPSubnet", "app.session.ip_subnet_mismatch.app_error", nil, "session_id="+session.Id, http.StatusUnauthorized)
+}
+
+// isSameIPSubnet returns whether both IP addresses are in the same subnet of
+// the given prefix length. Addresses of different families never are.
+func isSameIPSubnet(ip1, ip2 string, ipv4PrefixLength, ipv6PrefixLength int) bool {
+	a, b := net.ParseIP(ip1), net.ParseIP(ip2)
+	if a == nil || b == nil {
+		return ip1 == ip2
+	}
+
+	if a4, b4 := a.To4(), b.To4(); a4 != nil || b4 != nil {
+		if a4 == nil || b4 == nil {
+			return false
+		}
+		mask := net.CIDRMask(ipv4PrefixLength, 32)
+		return a4.Mask(mask).Equal(b4.Mask(mask))
+	}
+
+	mask := net.CIDRMask(ipv6PrefixLength, 128)
+	return a.Mask(mask).Equal(b.Mask(mask))
+}
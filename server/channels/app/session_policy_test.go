@@ -0,0 +1,74 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestIsSameIPSubnet(t *testing.T) {
+	for name, test := range map[string]struct {
+		IP1, IP2 string
+		Expected bool
+	}{
+		"same IPv4":                 {"192.168.1.10", "192.168.1.10", true},
+		"same IPv4 subnet":          {"192.168.1.10", "192.168.1.200", true},
+		"other IPv4 subnet":         {"192.168.1.10", "192.168.2.10", false},
+		"same IPv6 subnet":          {"2001:db8:1:2::1", "2001:db8:1:2:ffff::1", true},
+		"other IPv6 subnet":         {"2001:db8:1:2::1", "2001:db8:1:3::1", false},
+		"IPv4-mapped IPv6 and IPv4": {"::ffff:192.168.1.10", "192.168.1.20", true},
+		"IPv4 and IPv6":             {"192.168.1.10", "2001:db8::1", false},
+		"unparsable":                {"unknown", "192.168.1.10", false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, isSameIPSubnet(test.IP1, test.IP2, 24, 64))
+		})
+	}
+}
+
+func TestApplySessionPolicies(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.SessionPolicySettings.Enable = true
+		*cfg.SessionPolicySettings.MaxSessionsPerUser = 3
+		*cfg.SessionPolicySettings.MaxSessionsPerDeviceType = 2
+	})
+
+	createSession := func(browser string) *model.Session {
+		session := &model.Session{UserId: th.BasicUser.Id, Roles: model.SystemUserRoleId}
+		session.AddProp(model.SessionPropBrowser, browser)
+		session, appErr := th.App.CreateSession(th.Context, session)
+		require.Nil(t, appErr)
+		// Make sure the sessions are ordered by creation time.
+		time.Sleep(time.Millisecond)
+		return session
+	}
+
+	web1 := createSession("Chrome/120.0")
+	web2 := createSession("Chrome/120.0")
+	desktop := createSession("Desktop App/5.8.0")
+
+	// A third web session evicts the oldest web session.
+	web3 := createSession("Firefox/121.0")
+	_, appErr := th.App.GetSession(web1.Token)
+	require.NotNil(t, appErr)
+
+	// A second desktop session evicts the oldest session over the limit per user.
+	createSession("Desktop App/5.8.0")
+	_, appErr = th.App.GetSession(web2.Token)
+	require.NotNil(t, appErr)
+
+	for _, session := range []*model.Session{desktop, web3} {
+		_, appErr = th.App.GetSession(session.Token)
+		require.Nil(t, appErr)
+	}
+}
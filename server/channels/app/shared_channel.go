@@ -126,6 +126,26 @@ func (a *App) UninviteRemoteFromChannel(channelID, remoteID string) error {
 	return ssService.UninviteRemoteFromChannel(channelID, remoteID)
 }
 
+// CompareSharedChannelWithRemote compares the posts created in a shared channel within
+// [since, until] with the posts held by the remote.
+func (a *App) CompareSharedChannelWithRemote(channelID, remoteID string, since, until int64) (*model.SharedChannelSyncComparison, error) {
+	scService, err := a.getSharedChannelsService(true)
+	if err != nil {
+		return nil, err
+	}
+	return scService.CompareWithRemote(channelID, remoteID, since, until)
+}
+
+// ResyncSharedChannelRemote sends the posts created or edited in a shared channel since the
+// timestamp to the remote again.
+func (a *App) ResyncSharedChannelRemote(channelID, remoteID string, since int64) error {
+	scService, err := a.getSharedChannelsService(true)
+	if err != nil {
+		return err
+	}
+	return scService.ResyncRemote(channelID, remoteID, since)
+}
+
 func (a *App) SaveSharedChannelRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
 	if err := a.checkChannelIsShared(remote.ChannelId); err != nil {
 		return nil, err
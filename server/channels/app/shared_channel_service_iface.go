@@ -17,6 +17,7 @@ type SharedChannelServiceIFace interface {
 	NotifyChannelChanged(channelId string)
 	NotifyUserProfileChanged(userID string)
 	NotifyUserStatusChanged(status *model.Status)
+	NotifyPersistentNotificationResolved(post *model.Post)
 	SendChannelInvite(channel *model.Channel, userId string, rc *model.RemoteCluster, options ...sharedchannel.InviteOption) error
 	Active() bool
 	InviteRemoteToChannel(channelID, remoteID, userID string, shareIfNotShared bool) error
@@ -29,6 +30,9 @@ type SharedChannelServiceIFace interface {
 	CheckCanInviteToSharedChannel(channelId string) error
 	HandleMembershipChange(channelID, userID string, isAdd bool, remoteID string)
 	IsRemoteClusterDirectlyConnected(remoteId string) bool
+	CompareWithRemote(channelID, remoteID string, since, until int64) (*model.SharedChannelSyncComparison, error)
+	ResyncRemote(channelID, remoteID string, since int64) error
+	GetRecentSyncComparisons() []*model.SharedChannelSyncComparison
 	TransformMentionsOnReceiveForTesting(rctx request.CTX, post *model.Post, targetChannel *model.Channel, rc *model.RemoteCluster, mentionTransforms map[string]string)
 }
 
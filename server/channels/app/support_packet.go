@@ -27,6 +27,7 @@ func (a *App) GenerateSupportPacket(rctx request.CTX, options *model.SupportPack
 		"permissions": a.getSupportPacketPermissionsInfo,
 		"plugins":     a.getPluginsFile,
 		"schema":      a.getSupportPacketDatabaseSchema,
+		"shared":      a.getSupportPacketSharedChannels,
 	}
 
 	var (
@@ -328,6 +329,61 @@ func (a *App) getSupportPacketPermissionsInfo(_ request.CTX) (*model.FileData, e
 	return fileData, rErr.ErrorOrNil()
 }
 
+// getSupportPacketSharedChannels returns the sync cursors of shared channel remotes and the
+// latest sync comparisons. No file is generated if shared channels are disabled.
+func (a *App) getSupportPacketSharedChannels(_ request.CTX) (*model.FileData, error) {
+	const maxRemotes = 1000
+
+	scService := a.Srv().GetSharedChannelSyncService()
+	if scService == nil {
+		return nil, nil
+	}
+
+	var (
+		rErr   *multierror.Error
+		shared model.SupportPacketSharedChannels
+	)
+
+	remotes, err := a.Srv().Store().SharedChannel().GetRemotes(0, maxRemotes, model.SharedChannelRemoteFilterOpts{IncludeUnconfirmed: true})
+	if err != nil {
+		rErr = multierror.Append(errors.Wrap(err, "failed to get shared channel remotes"))
+	}
+
+	lastPostAt := make(map[string]int64)
+	for _, scr := range remotes {
+		if _, ok := lastPostAt[scr.ChannelId]; !ok {
+			channel, err := a.Srv().Store().Channel().Get(scr.ChannelId, true)
+			if err != nil {
+				rErr = multierror.Append(rErr, errors.Wrapf(err, "failed to get shared channel %s", scr.ChannelId))
+			} else {
+				lastPostAt[scr.ChannelId] = channel.LastPostAt
+			}
+		}
+
+		shared.Remotes = append(shared.Remotes, &model.SupportPacketSharedChannelRemote{
+			ChannelId:         scr.ChannelId,
+			RemoteId:          scr.RemoteId,
+			IsInviteConfirmed: scr.IsInviteConfirmed,
+			LastPostCreateAt:  scr.LastPostCreateAt,
+			LastPostUpdateAt:  scr.LastPostUpdateAt,
+			LastMembersSyncAt: scr.LastMembersSyncAt,
+			SyncLag:           max(lastPostAt[scr.ChannelId]-max(scr.LastPostCreateAt, scr.LastPostUpdateAt), 0),
+		})
+	}
+	shared.Comparisons = scService.GetRecentSyncComparisons()
+
+	b, err := yaml.Marshal(&shared)
+	if err != nil {
+		rErr = multierror.Append(rErr, errors.Wrap(err, "failed to marshal shared channels into yaml"))
+	}
+
+	fileData := &model.FileData{
+		Filename: "shared_channels.yaml",
+		Body:     b,
+	}
+	return fileData, rErr.ErrorOrNil()
+}
+
 func (a *App) getPluginsFile(_ request.CTX) (*model.FileData, error) {
 	// Getting the plugins installed on the server, prettify it, and then add them to the file data array
 	plugins, appErr := a.GetPlugins()
@@ -342,6 +398,9 @@ func (a *App) getPluginsFile(_ request.CTX) (*model.FileData, error) {
 	for _, p := range plugins.Inactive {
 		pluginList.Disabled = append(pluginList.Disabled, p.Manifest)
 	}
+	if pluginsEnvironment := a.GetPluginsEnvironment(); pluginsEnvironment != nil {
+		pluginList.APIUsage = pluginsEnvironment.APIUsage()
+	}
 
 	pluginsPrettyJSON, err := json.MarshalIndent(pluginList, "", "    ")
 	if err != nil {
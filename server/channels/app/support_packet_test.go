@@ -321,6 +321,10 @@ func TestGetPluginsFile(t *testing.T) {
 		assert.Equal(t, "testplugin", pl.Enabled[0].Id)
 		require.Len(t, pl.Disabled, 1)
 		assert.Equal(t, "testplugin2", pl.Disabled[0].Id)
+
+		// Only started plugins are accounted for.
+		require.Len(t, pl.APIUsage, 1)
+		assert.Equal(t, "testplugin", pl.APIUsage[0].PluginId)
 	})
 
 	t.Run("error if plugin are disabled", func(t *testing.T) {
@@ -70,7 +70,7 @@ func (a *App) AdjustTeamsFromProductLimits(teamLimits *model.TeamsLimits) *model
 			if err != nil {
 				return err
 			}
-			err = a.SoftDeleteTeam(team.Id)
+			err = a.SoftDeleteTeam(request.EmptyContext(a.Log()), team.Id)
 			if err != nil {
 				return err
 			}
@@ -104,6 +104,16 @@ func (a *App) AdjustTeamsFromProductLimits(teamLimits *model.TeamsLimits) *model
 }
 
 func (a *App) CreateTeam(rctx request.CTX, team *model.Team) (*model.Team, *model.AppError) {
+	var rejectionReason string
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.TeamWillBeCreated(pluginContext, team)
+		return rejectionReason == ""
+	}, plugin.TeamWillBeCreatedID)
+	if rejectionReason != "" {
+		return nil, model.NewAppError("CreateTeam", "app.team.save.rejected_by_plugin", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
 	rteam, err := a.ch.srv.teamService.CreateTeam(rctx, team)
 	if err != nil {
 		var invErr *store.ErrInvalidInput
@@ -136,6 +146,13 @@ func (a *App) CreateTeam(rctx request.CTX, team *model.Team) (*model.Team, *mode
 		}
 	}
 
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.TeamHasBeenCreated(pluginContext, rteam)
+			return true
+		}, plugin.TeamHasBeenCreatedID)
+	})
+
 	return rteam, nil
 }
 
@@ -1765,6 +1782,10 @@ func (a *App) PermanentDeleteTeamId(rctx request.CTX, teamID string) *model.AppE
 }
 
 func (a *App) PermanentDeleteTeam(rctx request.CTX, team *model.Team) *model.AppError {
+	if appErr := a.runTeamWillBeDeletedHook(rctx, team); appErr != nil {
+		return appErr
+	}
+
 	team.DeleteAt = model.GetMillis()
 	if _, err := a.Srv().Store().Team().Update(team); err != nil {
 		var invErr *store.ErrInvalidInput
@@ -1808,15 +1829,21 @@ func (a *App) PermanentDeleteTeam(rctx request.CTX, team *model.Team) *model.App
 		return appErr
 	}
 
+	a.runTeamHasBeenDeletedHook(rctx, team)
+
 	return nil
 }
 
-func (a *App) SoftDeleteTeam(teamID string) *model.AppError {
+func (a *App) SoftDeleteTeam(rctx request.CTX, teamID string) *model.AppError {
 	team, err := a.GetTeam(teamID)
 	if err != nil {
 		return err
 	}
 
+	if appErr := a.runTeamWillBeDeletedHook(rctx, team); appErr != nil {
+		return appErr
+	}
+
 	if err := a.Srv().Store().PostPersistentNotification().DeleteByTeam([]string{team.Id}); err != nil {
 		return model.NewAppError("SoftDeleteTeam", "app.post_persistent_notification.delete_by_team.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -1840,9 +1867,34 @@ func (a *App) SoftDeleteTeam(teamID string) *model.AppError {
 		return appErr
 	}
 
+	a.runTeamHasBeenDeletedHook(rctx, team)
+
+	return nil
+}
+
+func (a *App) runTeamWillBeDeletedHook(rctx request.CTX, team *model.Team) *model.AppError {
+	var rejectionReason string
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.TeamWillBeDeleted(pluginContext, team)
+		return rejectionReason == ""
+	}, plugin.TeamWillBeDeletedID)
+	if rejectionReason != "" {
+		return model.NewAppError("DeleteTeam", "app.team.delete.rejected_by_plugin", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
 	return nil
 }
 
+func (a *App) runTeamHasBeenDeletedHook(rctx request.CTX, team *model.Team) {
+	pluginContext := pluginContext(rctx)
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.TeamHasBeenDeleted(pluginContext, team)
+			return true
+		}, plugin.TeamHasBeenDeletedID)
+	})
+}
+
 func (a *App) RestoreTeam(teamID string) *model.AppError {
 	team, err := a.GetTeam(teamID)
 	if err != nil {
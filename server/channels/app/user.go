@@ -932,6 +932,18 @@ func (a *App) DeactivateMfa(userID string) *model.AppError {
 		return model.NewAppError("DeactivateMfa", "mfa.deactivate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().WebAuthnCredential().DeleteForUser(userID); err != nil {
+		return model.NewAppError("DeactivateMfa", "app.webauthn_credential.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().MfaRecoveryCode().DeleteForUser(userID); err != nil {
+		return model.NewAppError("DeactivateMfa", "app.mfa_recovery_code.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().MfaTrustedDevice().DeleteForUser(userID); err != nil {
+		return model.NewAppError("DeactivateMfa", "app.mfa_trusted_device.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	// Make sure old MFA status is not cached locally or in cluster nodes.
 	a.InvalidateCacheForUser(userID)
 
@@ -1589,14 +1601,24 @@ func (a *App) updateUserNotifyProps(userID string, props map[string]string) *mod
 	return nil
 }
 
-func (a *App) UpdateMfa(rctx request.CTX, activate bool, userID, token string) *model.AppError {
+// UpdateMfa activates or deactivates MFA for a user, returning the user's new recovery codes when
+// activating with recovery codes enabled.
+func (a *App) UpdateMfa(rctx request.CTX, activate bool, userID, token string) ([]string, *model.AppError) {
+	var recoveryCodes []string
 	if activate {
 		if err := a.ActivateMfa(userID, token); err != nil {
-			return err
+			return nil, err
+		}
+
+		if *a.Config().ServiceSettings.EnableMfaRecoveryCodes {
+			var err *model.AppError
+			if recoveryCodes, err = a.GenerateMfaRecoveryCodes(rctx, userID); err != nil {
+				return nil, err
+			}
 		}
 	} else {
 		if err := a.DeactivateMfa(userID); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -1612,7 +1634,7 @@ func (a *App) UpdateMfa(rctx request.CTX, activate bool, userID, token string) *
 		}
 	})
 
-	return nil
+	return recoveryCodes, nil
 }
 
 func (a *App) UpdatePasswordByUserIdSendEmail(rctx request.CTX, userID, newPassword, method string) *model.AppError {
@@ -1952,6 +1974,17 @@ func (a *App) UpdateUserRolesWithUser(rctx request.CTX, user *model.User, newRol
 		}
 	}
 
+	var rejectionReason string
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.UserRolesWillChange(pluginContext, user, newRoles)
+		return rejectionReason == ""
+	}, plugin.UserRolesWillChangeID)
+	if rejectionReason != "" {
+		return nil, model.NewAppError("UpdateUserRoles", "app.user.update_roles.rejected_by_plugin", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
+	oldRoles := user.Roles
 	user.Roles = newRoles
 	uchan := make(chan store.StoreResult[*model.UserUpdate], 1)
 	go func() {
@@ -1997,6 +2030,14 @@ func (a *App) UpdateUserRolesWithUser(rctx request.CTX, user *model.User, newRol
 		a.Publish(message)
 	}
 
+	pluginUser := ruser.DeepCopy()
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.UserRolesHaveChanged(pluginContext, pluginUser, oldRoles)
+			return true
+		}, plugin.UserRolesHaveChangedID)
+	})
+
 	return ruser, nil
 }
 
@@ -2018,6 +2059,18 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		return model.NewAppError("PermanentDeleteUser", "app.user_access_token.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().WebAuthnCredential().DeleteForUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.webauthn_credential.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().MfaRecoveryCode().DeleteForUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.mfa_recovery_code.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().MfaTrustedDevice().DeleteForUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.mfa_trusted_device.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	if err := a.Srv().Store().OAuth().PermanentDeleteAuthDataByUser(user.Id); err != nil {
 		return model.NewAppError("PermanentDeleteUser", "app.oauth.permanent_delete_auth_data_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -2486,6 +2539,11 @@ func (a *App) UpdateOAuthUserAttrs(rctx request.CTX, userData io.Reader, user *m
 		}
 	}
 
+	if oauthUser.Position != "" && oauthUser.Position != user.Position {
+		user.Position = oauthUser.Position
+		userAttrsChanged = true
+	}
+
 	if user.DeleteAt > 0 {
 		// Make sure they are not disabled
 		user.DeleteAt = 0
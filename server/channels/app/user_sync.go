@@ -0,0 +1,529 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const userSyncProgressInterval = 100
+
+// SyncUsers creates, updates and deactivates users, and sets their
+// system roles and their team and channel memberships, from a CSV file
+// with the columns of model.UserSyncColumns. Each row is applied on its
+// own: a row that fails is reported and the sync moves on to the next
+// one. Rows that match the current state of the user don't change
+// anything, so the same file can be synced again safely.
+//
+// A row of the report is written to reportWriter for every row of the
+// file. With dryRun, the changes are computed and reported but not
+// applied. progress, if not nil, is called after each row.
+func (a *App) SyncUsers(rctx request.CTX, reader io.Reader, reportWriter io.Writer, dryRun bool, progress func(processed int)) (*model.UserSyncResult, *model.AppError) {
+	syncReader, appErr := model.NewUserSyncReader(reader)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	report := csv.NewWriter(reportWriter)
+	if err := report.Write(model.UserSyncReportHeaders); err != nil {
+		return nil, model.NewAppError("SyncUsers", "app.user_sync.write_report.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	result := &model.UserSyncResult{}
+	for processed := 1; ; processed++ {
+		row, err := syncReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if row == nil {
+			return nil, model.NewAppError("SyncUsers", "app.user_sync.read.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		reportRow := &model.UserSyncReportRow{
+			Line:     row.Line,
+			Username: row.Username,
+			Action:   model.UserSyncActionError,
+		}
+		if row.Email != nil {
+			reportRow.Email = *row.Email
+		}
+
+		if err != nil {
+			reportRow.Error = userSyncErrorMessage(rctx, err)
+		} else if action, changes, appErr := a.syncUser(rctx, row, dryRun); appErr != nil {
+			reportRow.Changes = changes
+			reportRow.Error = userSyncErrorMessage(rctx, appErr)
+		} else {
+			reportRow.Action = action
+			reportRow.Changes = changes
+		}
+
+		if reportRow.Error != "" {
+			rctx.Logger().Warn("Failed to sync user", mlog.Int("line", row.Line), mlog.String("username", row.Username), mlog.String("error", reportRow.Error))
+		}
+
+		result.Add(reportRow)
+		if err := report.Write(reportRow.ToReport()); err != nil {
+			return nil, model.NewAppError("SyncUsers", "app.user_sync.write_report.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if progress != nil {
+			progress(processed)
+		}
+	}
+
+	report.Flush()
+	if err := report.Error(); err != nil {
+		return nil, model.NewAppError("SyncUsers", "app.user_sync.write_report.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return result, nil
+}
+
+// SyncUsersForJob syncs the users of the CSV file at path in the file
+// store, see SyncUsers, and saves the report of the sync as the report
+// of the job, to be downloaded like the other reports.
+func (a *App) SyncUsersForJob(rctx request.CTX, job *model.Job, path string, dryRun bool) (*model.UserSyncResult, *model.AppError) {
+	file, appErr := a.FileReader(path)
+	if appErr != nil {
+		return nil, appErr
+	}
+	defer file.Close()
+
+	var report bytes.Buffer
+	result, appErr := a.SyncUsers(rctx, file, &report, dryRun, func(processed int) {
+		if processed%userSyncProgressInterval == 0 {
+			updateJobProgress(rctx.Logger(), a.Srv().Store(), job, "rows_processed", processed)
+		}
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if _, appErr := a.WriteFile(&report, makeCompiledFilePath(job.Id, "csv")); appErr != nil {
+		return nil, appErr
+	}
+
+	return result, nil
+}
+
+func userSyncErrorMessage(rctx request.CTX, err error) string {
+	var appErr *model.AppError
+	if errors.As(err, &appErr) {
+		appErr.Translate(rctx.T)
+		if appErr.DetailedError != "" {
+			return appErr.Message + " " + appErr.DetailedError
+		}
+		return appErr.Message
+	}
+	return err.Error()
+}
+
+// syncUser applies a row to the matching user, and returns the action
+// taken along with the list of changes.
+func (a *App) syncUser(rctx request.CTX, row *model.UserSyncRow, dryRun bool) (string, []string, *model.AppError) {
+	user, appErr := a.getUserForSync(row)
+	if appErr != nil {
+		return "", nil, appErr
+	}
+
+	if user == nil {
+		// There is nothing to deactivate.
+		if row.Active != nil && !*row.Active {
+			return model.UserSyncActionNone, nil, nil
+		}
+
+		user, appErr = a.createUserForSync(rctx, row, dryRun)
+		if appErr != nil {
+			return "", nil, appErr
+		}
+		changes, appErr := a.syncUserMemberships(rctx, user, row, dryRun)
+		if appErr != nil {
+			return "", append([]string{"created user"}, changes...), appErr
+		}
+		return model.UserSyncActionCreate, append([]string{"created user"}, changes...), nil
+	}
+
+	if row.Active != nil && !*row.Active {
+		if user.DeleteAt != 0 {
+			return model.UserSyncActionNone, nil, nil
+		}
+		if !dryRun {
+			if _, appErr := a.UpdateActive(rctx, user, false); appErr != nil {
+				return "", nil, appErr
+			}
+		}
+		return model.UserSyncActionDeactivate, []string{"deactivated user"}, nil
+	}
+
+	var changes []string
+	if row.Active != nil && user.DeleteAt != 0 {
+		if !dryRun {
+			if user, appErr = a.UpdateActive(rctx, user, true); appErr != nil {
+				return "", nil, appErr
+			}
+		}
+		changes = append(changes, "activated user")
+	}
+
+	fieldChanges, appErr := a.updateUserForSync(rctx, user, row, dryRun)
+	changes = append(changes, fieldChanges...)
+	if appErr != nil {
+		return "", changes, appErr
+	}
+
+	membershipChanges, appErr := a.syncUserMemberships(rctx, user, row, dryRun)
+	changes = append(changes, membershipChanges...)
+	if appErr != nil {
+		return "", changes, appErr
+	}
+
+	if len(changes) == 0 {
+		return model.UserSyncActionNone, nil, nil
+	}
+	return model.UserSyncActionUpdate, changes, nil
+}
+
+// getUserForSync returns the user matching the email of the row, or
+// else its username, or nil if there is none.
+func (a *App) getUserForSync(row *model.UserSyncRow) (*model.User, *model.AppError) {
+	getUser := func(fn func() (*model.User, *model.AppError)) (*model.User, *model.AppError) {
+		user, appErr := fn()
+		if appErr != nil && appErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return user, appErr
+	}
+
+	byUsername, appErr := getUser(func() (*model.User, *model.AppError) { return a.GetUserByUsername(row.Username) })
+	if appErr != nil {
+		return nil, appErr
+	}
+	if row.Email == nil {
+		return byUsername, nil
+	}
+
+	byEmail, appErr := getUser(func() (*model.User, *model.AppError) { return a.GetUserByEmail(*row.Email) })
+	if appErr != nil {
+		return nil, appErr
+	}
+	if byEmail != nil && byUsername != nil && byEmail.Id != byUsername.Id {
+		return nil, model.NewAppError("getUserForSync", "app.user_sync.conflict.app_error", nil, "", http.StatusBadRequest)
+	}
+	if byEmail != nil {
+		return byEmail, nil
+	}
+	return byUsername, nil
+}
+
+func (a *App) createUserForSync(rctx request.CTX, row *model.UserSyncRow, dryRun bool) (*model.User, *model.AppError) {
+	if row.Email == nil {
+		return nil, model.NewAppError("createUserForSync", "app.user_sync.missing_email.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	user := &model.User{
+		Username: row.Username,
+		Email:    *row.Email,
+		// Users are provisioned by an admin, their email is trusted.
+		EmailVerified: true,
+	}
+	for field, value := range map[*string]*string{
+		&user.FirstName: row.FirstName,
+		&user.LastName:  row.LastName,
+		&user.Nickname:  row.Nickname,
+		&user.Position:  row.Position,
+		&user.Locale:    row.Locale,
+	} {
+		if value != nil {
+			*field = *value
+		}
+	}
+	if row.AuthService != nil {
+		user.AuthService = *row.AuthService
+	}
+
+	switch {
+	case row.AuthData != nil:
+		user.AuthData = row.AuthData
+	case row.Password != nil:
+		user.Password = *row.Password
+	case user.AuthService == "":
+		password, err := generatePassword(*a.Config().PasswordSettings.MinimumLength)
+		if err != nil {
+			return nil, model.NewAppError("createUserForSync", "app.import.generate_password.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		user.Password = password
+	}
+
+	if dryRun {
+		if user.Password != "" {
+			if appErr := a.IsPasswordValid(rctx, user.Password); appErr != nil {
+				return nil, appErr
+			}
+		}
+		if row.Roles != nil {
+			user.Roles = *row.Roles
+		}
+		return user, nil
+	}
+
+	var created *model.User
+	var appErr *model.AppError
+	if row.Roles != nil && slices.Contains(strings.Fields(*row.Roles), model.SystemGuestRoleId) {
+		created, appErr = a.CreateGuest(rctx, user)
+	} else {
+		created, appErr = a.CreateUser(rctx, user)
+	}
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if row.Roles != nil && !sameRoles(created.Roles, *row.Roles) {
+		if created, appErr = a.UpdateUserRoles(rctx, created.Id, *row.Roles, false); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return created, nil
+}
+
+// updateUserForSync updates the fields of the user that differ from
+// the row. The password is only used when the user is created.
+func (a *App) updateUserForSync(rctx request.CTX, user *model.User, row *model.UserSyncRow, dryRun bool) ([]string, *model.AppError) {
+	updated := user.DeepCopy()
+	var changes []string
+	for _, field := range []struct {
+		name  string
+		value *string
+		field *string
+	}{
+		{"username", &row.Username, &updated.Username},
+		{"email", row.Email, &updated.Email},
+		{"first_name", row.FirstName, &updated.FirstName},
+		{"last_name", row.LastName, &updated.LastName},
+		{"nickname", row.Nickname, &updated.Nickname},
+		{"position", row.Position, &updated.Position},
+		{"locale", row.Locale, &updated.Locale},
+	} {
+		if field.value != nil && *field.value != *field.field {
+			*field.field = *field.value
+			changes = append(changes, "updated "+field.name)
+		}
+	}
+
+	authChanged := false
+	if row.AuthService != nil && *row.AuthService != updated.AuthService {
+		updated.AuthService = *row.AuthService
+		authChanged = true
+	}
+	if row.AuthData != nil && (updated.AuthData == nil || *row.AuthData != *updated.AuthData) {
+		updated.AuthData = row.AuthData
+		authChanged = true
+	}
+
+	if !dryRun && len(changes) > 0 {
+		var appErr *model.AppError
+		if updated, appErr = a.UpdateUser(rctx, updated, false); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if authChanged {
+		if !dryRun {
+			if _, appErr := a.UpdateUserAuth(rctx, updated.Id, &model.UserAuth{AuthService: updated.AuthService, AuthData: updated.AuthData}); appErr != nil {
+				return changes, appErr
+			}
+		}
+		changes = append(changes, "updated authentication")
+	}
+
+	if row.Roles != nil && !sameRoles(updated.Roles, *row.Roles) {
+		if !dryRun {
+			if _, appErr := a.UpdateUserRoles(rctx, updated.Id, *row.Roles, true); appErr != nil {
+				return changes, appErr
+			}
+		}
+		changes = append(changes, fmt.Sprintf("set roles to %q", *row.Roles))
+	}
+
+	return changes, nil
+}
+
+func sameRoles(a, b string) bool {
+	rolesA, rolesB := strings.Fields(a), strings.Fields(b)
+	slices.Sort(rolesA)
+	slices.Sort(rolesB)
+	return slices.Equal(rolesA, rolesB)
+}
+
+// syncUserMemberships makes the user a member of exactly the teams and
+// channels of the row. The default channel of a team is never left, as
+// all the members of a team belong to it.
+func (a *App) syncUserMemberships(rctx request.CTX, user *model.User, row *model.UserSyncRow, dryRun bool) ([]string, *model.AppError) {
+	if row.Teams == nil && row.Channels == nil {
+		return nil, nil
+	}
+
+	var changes []string
+
+	// The teams of the user once the teams of the row are applied, by name.
+	teams := map[string]*model.Team{}
+	teamMembers := map[string]*model.TeamMember{}
+	if user.Id != "" {
+		current, appErr := a.GetTeamsForUser(user.Id)
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, team := range current {
+			teams[team.Name] = team
+		}
+		members, appErr := a.GetTeamMembersForUser(rctx, user.Id, "", false)
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, member := range members {
+			teamMembers[member.TeamId] = member
+		}
+	}
+
+	if row.Teams != nil {
+		for _, membership := range row.Teams {
+			team, ok := teams[membership.Team]
+			if !ok {
+				var appErr *model.AppError
+				if team, appErr = a.GetTeamByName(membership.Team); appErr != nil {
+					return changes, appErr
+				}
+				if !dryRun {
+					member, appErr := a.JoinUserToTeam(rctx, team, user, "")
+					if appErr != nil {
+						return changes, appErr
+					}
+					teamMembers[team.Id] = member
+				}
+				teams[team.Name] = team
+				changes = append(changes, "joined team "+team.Name)
+			}
+
+			member := teamMembers[team.Id]
+			isAdmin := member != nil && member.SchemeAdmin
+			if membership.Admin != isAdmin && (member != nil || membership.Admin) {
+				if !dryRun {
+					if _, appErr := a.UpdateTeamMemberSchemeRoles(rctx, team.Id, user.Id, member.SchemeGuest, member.SchemeUser, membership.Admin); appErr != nil {
+						return changes, appErr
+					}
+				}
+				changes = append(changes, fmt.Sprintf("set team admin of %s to %t", team.Name, membership.Admin))
+			}
+		}
+
+		for name, team := range teams {
+			if slices.ContainsFunc(row.Teams, func(membership *model.UserSyncMembership) bool { return membership.Team == name }) {
+				continue
+			}
+			if !dryRun {
+				if appErr := a.RemoveUserFromTeam(rctx, team.Id, user.Id, user.Id); appErr != nil {
+					return changes, appErr
+				}
+			}
+			delete(teams, name)
+			changes = append(changes, "left team "+name)
+		}
+	}
+
+	if row.Channels == nil {
+		return changes, nil
+	}
+
+	for _, membership := range row.Channels {
+		if _, ok := teams[membership.Team]; !ok {
+			return changes, model.NewAppError("syncUserMemberships", "app.user_sync.channel_team.app_error", map[string]any{"Channel": membership.Team + "/" + membership.Channel}, "", http.StatusBadRequest)
+		}
+	}
+
+	for teamName, team := range teams {
+		channels := map[string]*model.Channel{}
+		channelMembers := map[string]*model.ChannelMember{}
+		if teamMembers[team.Id] != nil {
+			current, appErr := a.GetChannelsForTeamForUser(rctx, team.Id, user.Id, &model.ChannelSearchOpts{})
+			if appErr != nil {
+				return changes, appErr
+			}
+			for _, channel := range current {
+				if channel.TeamId == team.Id && (channel.Type == model.ChannelTypeOpen || channel.Type == model.ChannelTypePrivate) {
+					channels[channel.Name] = channel
+				}
+			}
+			members, appErr := a.GetChannelMembersForUser(rctx, team.Id, user.Id)
+			if appErr != nil {
+				return changes, appErr
+			}
+			for i := range members {
+				channelMembers[members[i].ChannelId] = &members[i]
+			}
+		}
+
+		var wanted []*model.UserSyncMembership
+		for _, membership := range row.Channels {
+			if membership.Team == teamName {
+				wanted = append(wanted, membership)
+			}
+		}
+
+		for _, membership := range wanted {
+			channel, ok := channels[membership.Channel]
+			if !ok {
+				var appErr *model.AppError
+				if channel, appErr = a.GetChannelByName(rctx, membership.Channel, team.Id, false); appErr != nil {
+					return changes, appErr
+				}
+				if !dryRun {
+					member, appErr := a.AddChannelMember(rctx, user.Id, channel, ChannelMemberOpts{})
+					if appErr != nil {
+						return changes, appErr
+					}
+					channelMembers[channel.Id] = member
+				}
+				channels[channel.Name] = channel
+				changes = append(changes, fmt.Sprintf("joined channel %s/%s", teamName, channel.Name))
+			}
+
+			member := channelMembers[channel.Id]
+			isAdmin := member != nil && member.SchemeAdmin
+			if membership.Admin != isAdmin && (member != nil || membership.Admin) {
+				if !dryRun {
+					if _, appErr := a.UpdateChannelMemberSchemeRoles(rctx, channel.Id, user.Id, member.SchemeGuest, member.SchemeUser, membership.Admin); appErr != nil {
+						return changes, appErr
+					}
+				}
+				changes = append(changes, fmt.Sprintf("set channel admin of %s/%s to %t", teamName, channel.Name, membership.Admin))
+			}
+		}
+
+		for name, channel := range channels {
+			if name == model.DefaultChannelName || slices.ContainsFunc(wanted, func(membership *model.UserSyncMembership) bool { return membership.Channel == name }) {
+				continue
+			}
+			if !dryRun {
+				if appErr := a.RemoveUserFromChannel(rctx, user.Id, user.Id, channel); appErr != nil {
+					return changes, appErr
+				}
+			}
+			changes = append(changes, fmt.Sprintf("left channel %s/%s", teamName, name))
+		}
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSyncUsers(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	otherTeam := th.CreateTeam(t)
+	channel := th.CreateChannel(t, th.BasicTeam)
+
+	syncUsers := func(t *testing.T, content string, dryRun bool) (*model.UserSyncResult, [][]string) {
+		t.Helper()
+		var report bytes.Buffer
+		result, appErr := th.App.SyncUsers(th.Context, strings.NewReader(content), &report, dryRun, nil)
+		require.Nil(t, appErr)
+		rows, err := csv.NewReader(&report).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, model.UserSyncReportHeaders, rows[0])
+		return result, rows[1:]
+	}
+
+	username := "sync" + model.NewUsername()
+	email := username + "@example.com"
+	content := fmt.Sprintf("username,email,first_name,teams,channels\n%s,%s,First,%s:admin;%s,%s/%s\n",
+		username, email, th.BasicTeam.Name, otherTeam.Name, th.BasicTeam.Name, channel.Name)
+
+	t.Run("dry run doesn't create the user", func(t *testing.T) {
+		result, rows := syncUsers(t, content, true)
+		assert.Equal(t, &model.UserSyncResult{Created: 1}, result)
+		require.Len(t, rows, 1)
+		assert.Equal(t, model.UserSyncActionCreate, rows[0][3])
+
+		_, appErr := th.App.GetUserByUsername(username)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("create the user with its memberships", func(t *testing.T) {
+		result, rows := syncUsers(t, content, false)
+		assert.Equal(t, &model.UserSyncResult{Created: 1}, result)
+		require.Len(t, rows, 1)
+		assert.Empty(t, rows[0][5])
+
+		user, appErr := th.App.GetUserByUsername(username)
+		require.Nil(t, appErr)
+		assert.Equal(t, "First", user.FirstName)
+		assert.True(t, user.EmailVerified)
+
+		member, appErr := th.App.GetTeamMember(th.Context, th.BasicTeam.Id, user.Id)
+		require.Nil(t, appErr)
+		assert.True(t, member.SchemeAdmin)
+		_, appErr = th.App.GetTeamMember(th.Context, otherTeam.Id, user.Id)
+		require.Nil(t, appErr)
+		_, appErr = th.App.GetChannelMember(th.Context, channel.Id, user.Id)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("re-running the sync changes nothing", func(t *testing.T) {
+		result, rows := syncUsers(t, content, false)
+		assert.Equal(t, &model.UserSyncResult{Unchanged: 1}, result)
+		require.Len(t, rows, 1)
+		assert.Equal(t, model.UserSyncActionNone, rows[0][3])
+	})
+
+	t.Run("update the fields and the memberships", func(t *testing.T) {
+		content := fmt.Sprintf("username,email,first_name,teams,channels\n%s,%s,Updated,%s,\n", username, email, th.BasicTeam.Name)
+		result, _ := syncUsers(t, content, false)
+		assert.Equal(t, &model.UserSyncResult{Updated: 1}, result)
+
+		user, appErr := th.App.GetUserByUsername(username)
+		require.Nil(t, appErr)
+		assert.Equal(t, "Updated", user.FirstName)
+
+		member, appErr := th.App.GetTeamMember(th.Context, th.BasicTeam.Id, user.Id)
+		require.Nil(t, appErr)
+		assert.False(t, member.SchemeAdmin)
+		member, appErr = th.App.GetTeamMember(th.Context, otherTeam.Id, user.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, member.DeleteAt)
+	})
+
+	t.Run("deactivate the user", func(t *testing.T) {
+		content := fmt.Sprintf("username,active\n%s,false\n", username)
+		result, _ := syncUsers(t, content, false)
+		assert.Equal(t, &model.UserSyncResult{Deactivated: 1}, result)
+
+		user, appErr := th.App.GetUserByUsername(username)
+		require.Nil(t, appErr)
+		assert.NotZero(t, user.DeleteAt)
+
+		result, _ = syncUsers(t, content, false)
+		assert.Equal(t, &model.UserSyncResult{Unchanged: 1}, result)
+	})
+
+	t.Run("report the rows that fail", func(t *testing.T) {
+		content := fmt.Sprintf("username,email,teams\n%s,,\n%s,%s,unknownteam\n", model.NewUsername(), model.NewUsername(), "new"+model.NewId()+"@example.com")
+		result, rows := syncUsers(t, content, false)
+		assert.Equal(t, 2, result.Failed)
+		require.Len(t, rows, 2)
+		for _, row := range rows {
+			assert.Equal(t, model.UserSyncActionError, row[3])
+			assert.NotEmpty(t, row[5])
+		}
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		_, appErr := th.App.SyncUsers(th.Context, strings.NewReader("unknown\n"), &bytes.Buffer{}, false, nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.user_sync.unknown_column.app_error", appErr.Id)
+	})
+
+	t.Run("abort on read error", func(t *testing.T) {
+		reader := io.MultiReader(strings.NewReader("username\n"), iotest.ErrReader(errors.New("read failed")))
+		_, appErr := th.App.SyncUsers(th.Context, reader, &bytes.Buffer{}, false, nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.user_sync.read.app_error", appErr.Id)
+	})
+}
@@ -0,0 +1,395 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa"
+)
+
+func (a *App) webAuthn() (*mfa.WebAuthn, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableMultifactorAuthentication || !*a.Config().ServiceSettings.EnableWebAuthn {
+		return nil, model.NewAppError("webAuthn", "api.webauthn.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	w, err := mfa.NewWebAuthn(a.GetSiteURL())
+	if err != nil {
+		return nil, model.NewAppError("webAuthn", "api.webauthn.site_url.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
+	}
+
+	return w, nil
+}
+
+// newWebAuthnChallenge stores a single use challenge for a WebAuthn ceremony, bound to the given
+// user when there is one, and returns it encoded for the browser.
+func (a *App) newWebAuthnChallenge(tokenType, userID string) (string, *model.AppError) {
+	token := model.NewToken(tokenType, userID)
+	if err := a.Srv().Store().Token().Save(token); err != nil {
+		return "", model.NewAppError("newWebAuthnChallenge", "app.recover.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(token.Token)), nil
+}
+
+// consumeWebAuthnChallenge consumes the challenge a browser responded to, returning the user it
+// was issued for, if any.
+func (a *App) consumeWebAuthnChallenge(tokenType, clientDataJSON string) (string, string, *model.AppError) {
+	challenge, err := mfa.WebAuthnChallenge(clientDataJSON)
+	if err != nil {
+		return "", "", model.NewAppError("consumeWebAuthnChallenge", "api.webauthn.invalid_response.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+	}
+
+	token, err := a.Srv().Store().Token().ConsumeOnce(tokenType, challenge)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return "", "", model.NewAppError("consumeWebAuthnChallenge", "api.webauthn.invalid_challenge.app_error", nil, "", http.StatusUnauthorized)
+		}
+		return "", "", model.NewAppError("consumeWebAuthnChallenge", "app.recover.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if token.IsExpired() {
+		return "", "", model.NewAppError("consumeWebAuthnChallenge", "api.webauthn.invalid_challenge.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	return challenge, token.Extra, nil
+}
+
+func (a *App) GetWebAuthnCredentials(userID string) ([]*model.WebAuthnCredential, *model.AppError) {
+	credentials, err := a.Srv().Store().WebAuthnCredential().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetWebAuthnCredentials", "app.webauthn_credential.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return credentials, nil
+}
+
+// BeginWebAuthnRegistration starts the registration of a security key or passkey for the user.
+func (a *App) BeginWebAuthnRegistration(rctx request.CTX, userID string) (*model.WebAuthnCreationOptions, *model.AppError) {
+	w, appErr := a.webAuthn()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if user.AuthService != "" && user.AuthService != model.UserAuthServiceLdap {
+		return nil, model.NewAppError("BeginWebAuthnRegistration", "api.user.activate_mfa.email_and_ldap_only.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(credentials) >= model.WebAuthnMaxCredentialsPerUser {
+		return nil, model.NewAppError("BeginWebAuthnRegistration", "api.webauthn.register.too_many.app_error", map[string]any{"Max": model.WebAuthnMaxCredentialsPerUser}, "", http.StatusBadRequest)
+	}
+
+	challenge, appErr := a.newWebAuthnChallenge(model.TokenTypeWebAuthnRegistration, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	exclude := make([]model.WebAuthnCredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		exclude = append(exclude, credential.Descriptor())
+	}
+
+	return &model.WebAuthnCreationOptions{
+		Challenge:    challenge,
+		RelyingParty: model.WebAuthnRelyingParty{Id: w.RPID(), Name: *a.Config().TeamSettings.SiteName},
+		User: model.WebAuthnUserEntity{
+			Id:          model.WebAuthnUserHandle(user.Id),
+			Name:        user.Username,
+			DisplayName: user.GetDisplayName(model.ShowFullName),
+		},
+		PubKeyCredParams:   mfa.WebAuthnCredentialParameters(),
+		Timeout:            model.WebAuthnTimeout,
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: model.WebAuthnAuthenticatorSelection{
+			ResidentKey:      model.WebAuthnResidentKeyPreferred,
+			UserVerification: model.WebAuthnUserVerificationPreferred,
+		},
+		Attestation: model.WebAuthnAttestationNone,
+	}, nil
+}
+
+// FinishWebAuthnRegistration verifies and saves the credential created by the browser. The first
+// credential activates MFA for the user.
+func (a *App) FinishWebAuthnRegistration(rctx request.CTX, userID string, registration *model.WebAuthnRegistrationRequest) (*model.WebAuthnCredential, *model.AppError) {
+	w, appErr := a.webAuthn()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if registration.Credential == nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "api.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	challenge, challengeUserID, appErr := a.consumeWebAuthnChallenge(model.TokenTypeWebAuthnRegistration, registration.Credential.Response.ClientDataJSON)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if challengeUserID != userID {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "api.webauthn.invalid_challenge.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	verified, err := w.VerifyRegistration(challenge, registration.Credential, false)
+	if err != nil {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "api.webauthn.invalid_response.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	credentials, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if len(credentials) >= model.WebAuthnMaxCredentialsPerUser {
+		return nil, model.NewAppError("FinishWebAuthnRegistration", "api.webauthn.register.too_many.app_error", map[string]any{"Max": model.WebAuthnMaxCredentialsPerUser}, "", http.StatusBadRequest)
+	}
+
+	credential := &model.WebAuthnCredential{
+		UserId:       userID,
+		Name:         registration.Name,
+		CredentialId: verified.CredentialId,
+		PublicKey:    verified.PublicKey,
+		SignCount:    int64(verified.SignCount),
+		AAGUID:       verified.AAGUID,
+		Transports:   registration.Credential.Response.Transports,
+	}
+
+	credential, err = a.Srv().Store().WebAuthnCredential().Save(credential)
+	if err != nil {
+		var appErr *model.AppError
+		var conflictErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &conflictErr):
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "api.webauthn.register.duplicate.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "app.webauthn_credential.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if !user.MfaActive {
+		if err := a.Srv().Store().User().UpdateMfaActive(userID, true); err != nil {
+			return nil, model.NewAppError("FinishWebAuthnRegistration", "mfa.activate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		// Make sure old MFA status is not cached locally or in cluster nodes.
+		a.InvalidateCacheForUser(userID)
+	}
+
+	// Registering a credential proves possession of it, so the current session counts as
+	// verified with one.
+	if session := rctx.Session(); session != nil && session.UserId == userID {
+		a.MarkSessionMfaMethod(rctx, session, model.SessionPropMfaMethodWebAuthn)
+	}
+
+	return credential, nil
+}
+
+// DeleteWebAuthnCredential removes one of the user's credentials. Removing the last one
+// deactivates MFA, unless the user also has an authenticator app set up.
+func (a *App) DeleteWebAuthnCredential(rctx request.CTX, userID, credentialID string) *model.AppError {
+	credential, err := a.Srv().Store().WebAuthnCredential().Get(credentialID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn_credential.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn_credential.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if credential.UserId != userID {
+		return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn_credential.get.not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if err = a.Srv().Store().WebAuthnCredential().Delete(credential.Id); err != nil {
+		return model.NewAppError("DeleteWebAuthnCredential", "app.webauthn_credential.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	remaining, appErr := a.GetWebAuthnCredentials(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if len(remaining) == 0 && user.MfaActive && user.MfaSecret == "" {
+		if err := a.Srv().Store().User().UpdateMfaActive(userID, false); err != nil {
+			return model.NewAppError("DeleteWebAuthnCredential", "mfa.deactivate.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		// Make sure old MFA status is not cached locally or in cluster nodes.
+		a.InvalidateCacheForUser(userID)
+	}
+
+	return nil
+}
+
+// BeginWebAuthnLogin starts an authentication ceremony, either to provide the second factor of a
+// password login or for a passwordless login. Given a login ID, the user's credentials are
+// allowed; otherwise, or if there is no such user, any discoverable credential is.
+func (a *App) BeginWebAuthnLogin(rctx request.CTX, loginID string) (*model.WebAuthnRequestOptions, *model.AppError) {
+	w, appErr := a.webAuthn()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var userID string
+	allow := []model.WebAuthnCredentialDescriptor{}
+	if loginID != "" {
+		if user, appErr := a.GetUserForLogin(rctx, "", loginID); appErr == nil {
+			credentials, appErr := a.GetWebAuthnCredentials(user.Id)
+			if appErr != nil {
+				return nil, appErr
+			}
+
+			userID = user.Id
+			for _, credential := range credentials {
+				allow = append(allow, credential.Descriptor())
+			}
+		}
+		// Unknown users and users without credentials get a stable credential
+		// that doesn't exist, so that the response doesn't tell them apart
+		// from the users with credentials.
+		if len(allow) == 0 {
+			allow = append(allow, a.fakeWebAuthnCredentialDescriptor(loginID))
+		}
+	}
+
+	challenge, appErr := a.newWebAuthnChallenge(model.TokenTypeWebAuthnLogin, userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return &model.WebAuthnRequestOptions{
+		Challenge:        challenge,
+		Timeout:          model.WebAuthnTimeout,
+		RPId:             w.RPID(),
+		AllowCredentials: allow,
+		UserVerification: model.WebAuthnUserVerificationPreferred,
+	}, nil
+}
+
+// fakeWebAuthnCredentialDescriptor derives a credential descriptor from the login id, keyed by
+// a secret of the server so that it can't be told apart from the descriptor of a real credential.
+func (a *App) fakeWebAuthnCredentialDescriptor(loginID string) model.WebAuthnCredentialDescriptor {
+	mac := hmac.New(sha256.New, a.PostActionCookieSecret())
+	mac.Write([]byte("webauthn_credential:" + strings.ToLower(loginID))) // Write never returns an error
+	return model.WebAuthnCredentialDescriptor{
+		Type: model.WebAuthnCredentialType,
+		Id:   base64.RawURLEncoding.EncodeToString(mac.Sum(nil)),
+	}
+}
+
+// verifyWebAuthnAssertion verifies a credential asserted by the browser, for the given user if
+// any, and records its use.
+func (a *App) verifyWebAuthnAssertion(userID string, assertion *model.WebAuthnAssertionResponse, requireUserVerification bool) (*model.WebAuthnCredential, *model.AppError) {
+	w, appErr := a.webAuthn()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	challenge, challengeUserID, appErr := a.consumeWebAuthnChallenge(model.TokenTypeWebAuthnLogin, assertion.Response.ClientDataJSON)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	credential, err := a.Srv().Store().WebAuthnCredential().GetByCredentialId(assertion.RawId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("verifyWebAuthnAssertion", "api.webauthn.invalid_response.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+		}
+		return nil, model.NewAppError("verifyWebAuthnAssertion", "app.webauthn_credential.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if (userID != "" && credential.UserId != userID) || (challengeUserID != "" && credential.UserId != challengeUserID) {
+		return nil, model.NewAppError("verifyWebAuthnAssertion", "api.webauthn.invalid_response.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	signCount, err := w.VerifyAssertion(challenge, assertion, credential, requireUserVerification)
+	if err != nil {
+		return nil, model.NewAppError("verifyWebAuthnAssertion", "api.webauthn.invalid_response.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+	}
+
+	credential.SignCount = int64(signCount)
+	credential.LastUsedAt = model.GetMillis()
+	if err := a.Srv().Store().WebAuthnCredential().UpdateLastUsed(credential.Id, credential.SignCount, credential.LastUsedAt); err != nil {
+		return nil, model.NewAppError("verifyWebAuthnAssertion", "app.webauthn_credential.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return credential, nil
+}
+
+// AuthenticateUserForWebAuthn authenticates a passwordless login with a passkey, which must have
+// verified the user itself.
+func (a *App) AuthenticateUserForWebAuthn(rctx request.CTX, assertion *model.WebAuthnAssertionResponse) (*model.User, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableWebAuthnPasswordless {
+		return nil, model.NewAppError("AuthenticateUserForWebAuthn", "api.webauthn.passwordless_disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	credential, appErr := a.verifyWebAuthnAssertion("", assertion, true)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.GetUser(credential.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if err := checkUserNotDisabled(user); err != nil {
+		return nil, err
+	}
+
+	if err := checkUserNotBot(user); err != nil {
+		return nil, err
+	}
+
+	if err := a.CheckUserPostflightAuthenticationCriteria(rctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// MarkSessionMfaMethod records how the session's user completed MFA, such as by verifying a
+// security key or passkey, as required when it is enforced.
+func (a *App) MarkSessionMfaMethod(rctx request.CTX, session *model.Session, method string) {
+	if appErr := a.SetExtraSessionProps(session, map[string]string{
+		model.SessionPropMfaMethod: method,
+	}); appErr != nil {
+		rctx.Logger().Warn("Failed to record the MFA method of the session", mlog.Err(appErr))
+		return
+	}
+
+	a.ClearSessionCacheForUser(session.UserId)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa/webauthntest"
+)
+
+const webAuthnTestSiteURL = "http://localhost:8065"
+
+func setupWebAuthn(t *testing.T) *TestHelper {
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.SiteURL = webAuthnTestSiteURL
+		*cfg.ServiceSettings.EnableMultifactorAuthentication = true
+		*cfg.ServiceSettings.EnableWebAuthn = true
+		*cfg.ServiceSettings.EnableWebAuthnPasswordless = true
+	})
+	return th
+}
+
+func registerWebAuthnCredential(t *testing.T, th *TestHelper, authenticator *webauthntest.Authenticator, user *model.User) *model.WebAuthnCredential {
+	t.Helper()
+
+	options, appErr := th.App.BeginWebAuthnRegistration(th.Context, user.Id)
+	require.Nil(t, appErr)
+
+	attestation, err := authenticator.Create(options)
+	require.NoError(t, err)
+
+	credential, appErr := th.App.FinishWebAuthnRegistration(th.Context, user.Id, &model.WebAuthnRegistrationRequest{
+		Name:       "Security key",
+		Credential: attestation,
+	})
+	require.Nil(t, appErr)
+
+	return credential
+}
+
+func TestWebAuthnRegistration(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupWebAuthn(t)
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebAuthn = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebAuthn = true })
+
+		_, appErr := th.App.BeginWebAuthnRegistration(th.Context, th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.webauthn.disabled.app_error", appErr.Id)
+	})
+
+	t.Run("activates and deactivates mfa", func(t *testing.T) {
+		user := th.CreateUser(t)
+		authenticator := webauthntest.NewAuthenticator(webAuthnTestSiteURL)
+
+		credential := registerWebAuthnCredential(t, th, authenticator, user)
+		assert.Equal(t, "Security key", credential.Name)
+
+		user, appErr := th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.True(t, user.MfaActive)
+
+		credentials, appErr := th.App.GetWebAuthnCredentials(user.Id)
+		require.Nil(t, appErr)
+		require.Len(t, credentials, 1)
+
+		appErr = th.App.DeleteWebAuthnCredential(th.Context, user.Id, credential.Id)
+		require.Nil(t, appErr)
+
+		user, appErr = th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.False(t, user.MfaActive)
+	})
+
+	t.Run("challenge is single use and bound to the user", func(t *testing.T) {
+		authenticator := webauthntest.NewAuthenticator(webAuthnTestSiteURL)
+		options, appErr := th.App.BeginWebAuthnRegistration(th.Context, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		attestation, err := authenticator.Create(options)
+		require.NoError(t, err)
+
+		_, appErr = th.App.FinishWebAuthnRegistration(th.Context, th.BasicUser2.Id, &model.WebAuthnRegistrationRequest{Name: "key", Credential: attestation})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.webauthn.invalid_challenge.app_error", appErr.Id)
+
+		_, appErr = th.App.FinishWebAuthnRegistration(th.Context, th.BasicUser.Id, &model.WebAuthnRegistrationRequest{Name: "key", Credential: attestation})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.webauthn.invalid_challenge.app_error", appErr.Id)
+	})
+
+	t.Run("cannot delete another user's credential", func(t *testing.T) {
+		user := th.CreateUser(t)
+		credential := registerWebAuthnCredential(t, th, webauthntest.NewAuthenticator(webAuthnTestSiteURL), user)
+
+		appErr := th.App.DeleteWebAuthnCredential(th.Context, th.BasicUser2.Id, credential.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
+
+func TestWebAuthnLogin(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := setupWebAuthn(t)
+
+	user := th.CreateUser(t)
+	authenticator := webauthntest.NewAuthenticator(webAuthnTestSiteURL)
+	registerWebAuthnCredential(t, th, authenticator, user)
+	user, appErr := th.App.GetUser(user.Id)
+	require.Nil(t, appErr)
+
+	t.Run("second factor", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnLogin(th.Context, user.Username)
+		require.Nil(t, appErr)
+		require.Len(t, options.AllowCredentials, 1)
+
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		appErr = th.App.CheckUserMfa(th.Context, user, assertion.ToMfaToken())
+		require.Nil(t, appErr)
+
+		// The challenge can't be replayed.
+		appErr = th.App.CheckUserMfa(th.Context, user, assertion.ToMfaToken())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.check_user_mfa.bad_code.app_error", appErr.Id)
+	})
+
+	t.Run("second factor of another user", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnLogin(th.Context, user.Username)
+		require.Nil(t, appErr)
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		other := th.CreateUser(t)
+		other.MfaActive = true
+		appErr = th.App.CheckUserMfa(th.Context, other, assertion.ToMfaToken())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.check_user_mfa.bad_code.app_error", appErr.Id)
+	})
+
+	t.Run("unknown login id", func(t *testing.T) {
+		loginID := model.NewUsername()
+		options, appErr := th.App.BeginWebAuthnLogin(th.Context, loginID)
+		require.Nil(t, appErr)
+		require.Len(t, options.AllowCredentials, 1)
+		assert.Equal(t, model.WebAuthnCredentialType, options.AllowCredentials[0].Type)
+
+		// The same credential is returned every time, like for a real user.
+		again, appErr := th.App.BeginWebAuthnLogin(th.Context, loginID)
+		require.Nil(t, appErr)
+		assert.Equal(t, options.AllowCredentials, again.AllowCredentials)
+		assert.NotEqual(t, options.Challenge, again.Challenge)
+
+		other, appErr := th.App.BeginWebAuthnLogin(th.Context, model.NewUsername())
+		require.Nil(t, appErr)
+		require.Len(t, other.AllowCredentials, 1)
+		assert.NotEqual(t, options.AllowCredentials, other.AllowCredentials)
+	})
+
+	t.Run("user without credentials", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnLogin(th.Context, th.BasicUser.Username)
+		require.Nil(t, appErr)
+		require.Len(t, options.AllowCredentials, 1)
+
+		_, err := th.App.Srv().Store().WebAuthnCredential().GetByCredentialId(options.AllowCredentials[0].Id)
+		require.Error(t, err)
+	})
+
+	t.Run("passwordless", func(t *testing.T) {
+		options, appErr := th.App.BeginWebAuthnLogin(th.Context, "")
+		require.Nil(t, appErr)
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		loggedIn, appErr := th.App.AuthenticateUserForWebAuthn(th.Context, assertion)
+		require.Nil(t, appErr)
+		assert.Equal(t, user.Id, loggedIn.Id)
+	})
+
+	t.Run("passwordless requires user verification", func(t *testing.T) {
+		authenticator.UserVerified = false
+		defer func() { authenticator.UserVerified = true }()
+
+		options, appErr := th.App.BeginWebAuthnLogin(th.Context, "")
+		require.Nil(t, appErr)
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		_, appErr = th.App.AuthenticateUserForWebAuthn(th.Context, assertion)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.webauthn.invalid_response.app_error", appErr.Id)
+	})
+
+	t.Run("passwordless disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebAuthnPasswordless = false })
+		defer th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableWebAuthnPasswordless = true })
+
+		_, appErr := th.App.AuthenticateUserForWebAuthn(th.Context, &model.WebAuthnAssertionResponse{})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.webauthn.passwordless_disabled.app_error", appErr.Id)
+	})
+}
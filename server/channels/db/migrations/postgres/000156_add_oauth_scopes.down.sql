@@ -0,0 +1,4 @@
+-- Remove the scopes of OAuth apps and personal access tokens
+
+ALTER TABLE useraccesstokens DROP COLUMN IF EXISTS scopes;
+ALTER TABLE oauthapps DROP COLUMN IF EXISTS scopes;
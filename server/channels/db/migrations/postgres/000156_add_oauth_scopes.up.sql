@@ -0,0 +1,4 @@
+-- Add the scopes restricting what OAuth apps and personal access tokens may access
+
+ALTER TABLE oauthapps ADD COLUMN IF NOT EXISTS scopes VARCHAR(1024) DEFAULT '[]';
+ALTER TABLE useraccesstokens ADD COLUMN IF NOT EXISTS scopes VARCHAR(1024) DEFAULT '[]';
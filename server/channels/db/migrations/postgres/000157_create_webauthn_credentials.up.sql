@@ -0,0 +1,15 @@
+-- WebAuthnCredentials table: stores the security keys and passkeys registered by users
+CREATE TABLE IF NOT EXISTS WebAuthnCredentials (
+    Id VARCHAR(26) PRIMARY KEY,
+    UserId VARCHAR(26) NOT NULL,
+    Name VARCHAR(64) NOT NULL,
+    CredentialId VARCHAR(1400) NOT NULL UNIQUE,
+    PublicKey BYTEA NOT NULL,
+    SignCount BIGINT DEFAULT 0 NOT NULL,
+    AAGUID VARCHAR(32) DEFAULT '' NOT NULL,
+    Transports VARCHAR(256) DEFAULT '[]' NOT NULL,
+    CreateAt BIGINT NOT NULL,
+    LastUsedAt BIGINT DEFAULT 0 NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webauthncredentials_user_id ON WebAuthnCredentials(UserId);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_mfarecoverycodes_user_id;
+DROP TABLE IF EXISTS MfaRecoveryCodes;
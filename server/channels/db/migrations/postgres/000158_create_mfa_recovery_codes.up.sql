@@ -0,0 +1,10 @@
+-- MfaRecoveryCodes table: stores the hashed one-time codes users can log in with in place of an MFA code
+CREATE TABLE IF NOT EXISTS MfaRecoveryCodes (
+    Id VARCHAR(26) PRIMARY KEY,
+    UserId VARCHAR(26) NOT NULL,
+    CodeHash VARCHAR(128) NOT NULL,
+    CreateAt BIGINT NOT NULL,
+    UsedAt BIGINT DEFAULT 0 NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfarecoverycodes_user_id ON MfaRecoveryCodes(UserId);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_mfatrusteddevices_user_id;
+DROP TABLE IF EXISTS MfaTrustedDevices;
@@ -0,0 +1,11 @@
+-- MfaTrustedDevices table: stores the devices users chose to trust to skip MFA on login
+CREATE TABLE IF NOT EXISTS MfaTrustedDevices (
+    Id VARCHAR(26) PRIMARY KEY,
+    UserId VARCHAR(26) NOT NULL,
+    DeviceId VARCHAR(512) NOT NULL,
+    TokenHash VARCHAR(128) NOT NULL UNIQUE,
+    CreateAt BIGINT NOT NULL,
+    ExpiresAt BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_mfatrusteddevices_user_id ON MfaTrustedDevices(UserId);
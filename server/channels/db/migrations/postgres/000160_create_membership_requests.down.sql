@@ -0,0 +1,8 @@
+ALTER TABLE Teams DROP COLUMN IF EXISTS AllowMembershipRequests;
+ALTER TABLE Channels DROP COLUMN IF EXISTS AllowMembershipRequests;
+
+DROP INDEX IF EXISTS idx_membershiprequests_pending_unique;
+DROP INDEX IF EXISTS idx_membershiprequests_status_expires_at;
+DROP INDEX IF EXISTS idx_membershiprequests_team_id_channel_id;
+DROP INDEX IF EXISTS idx_membershiprequests_user_id;
+DROP TABLE IF EXISTS MembershipRequests;
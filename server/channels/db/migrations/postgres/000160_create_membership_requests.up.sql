@@ -0,0 +1,23 @@
+-- MembershipRequests table: stores the requests of users to join private channels and teams
+CREATE TABLE IF NOT EXISTS MembershipRequests (
+    Id VARCHAR(26) PRIMARY KEY,
+    UserId VARCHAR(26) NOT NULL,
+    TeamId VARCHAR(26) NOT NULL,
+    ChannelId VARCHAR(26) DEFAULT '' NOT NULL,
+    Justification VARCHAR(1024) DEFAULT '' NOT NULL,
+    Status VARCHAR(32) NOT NULL,
+    ReviewerId VARCHAR(26) DEFAULT '' NOT NULL,
+    PostIds VARCHAR(1024) DEFAULT '[]' NOT NULL,
+    CreateAt BIGINT NOT NULL,
+    UpdateAt BIGINT NOT NULL,
+    ExpiresAt BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_membershiprequests_user_id ON MembershipRequests(UserId);
+CREATE INDEX IF NOT EXISTS idx_membershiprequests_team_id_channel_id ON MembershipRequests(TeamId, ChannelId);
+CREATE INDEX IF NOT EXISTS idx_membershiprequests_status_expires_at ON MembershipRequests(Status, ExpiresAt);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_membershiprequests_pending_unique ON MembershipRequests(UserId, TeamId, ChannelId) WHERE Status = 'pending';
+
+-- Channels and teams only accept requests when their admins allow them
+ALTER TABLE Channels ADD COLUMN IF NOT EXISTS AllowMembershipRequests BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE Teams ADD COLUMN IF NOT EXISTS AllowMembershipRequests BOOLEAN NOT NULL DEFAULT FALSE;
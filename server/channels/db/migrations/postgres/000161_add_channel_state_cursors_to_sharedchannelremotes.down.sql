@@ -0,0 +1,2 @@
+ALTER TABLE sharedchannelremotes DROP COLUMN IF EXISTS lastchannelinfosyncat;
+ALTER TABLE sharedchannelremotes DROP COLUMN IF EXISTS lastbookmarkssyncat;
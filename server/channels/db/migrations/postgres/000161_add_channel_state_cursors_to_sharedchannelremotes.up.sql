@@ -0,0 +1,2 @@
+ALTER TABLE sharedchannelremotes ADD COLUMN IF NOT EXISTS lastbookmarkssyncat bigint DEFAULT 0;
+ALTER TABLE sharedchannelremotes ADD COLUMN IF NOT EXISTS lastchannelinfosyncat bigint DEFAULT 0;
@@ -104,7 +104,7 @@ func (worker *BatchReportWorker) processChunk(job *model.Job, reportData []model
 		return err
 	}
 
-	appErr := worker.app.SaveReportChunk(worker.reportFormat, job.Id, fileCount, reportData)
+	appErr := worker.app.SaveReportChunk(worker.formatForJob(job), job.Id, fileCount, reportData)
 	if appErr != nil {
 		return err
 	}
@@ -121,20 +121,37 @@ func (worker *BatchReportWorker) complete(rctx request.CTX, job *model.Job) erro
 		return err
 	}
 
-	appErr := worker.app.CompileReportChunks(worker.reportFormat, job.Id, fileCount, worker.headers)
+	format := worker.formatForJob(job)
+	appErr := worker.app.CompileReportChunks(format, job.Id, fileCount, worker.headers)
 	if appErr != nil {
 		return appErr
 	}
 
 	defer func() {
-		if err := worker.app.CleanupReportChunks(worker.reportFormat, job.Id, fileCount); err != nil {
+		if err := worker.app.CleanupReportChunks(format, job.Id, fileCount); err != nil {
 			worker.logger.Error("Worker: Failed to cleanup report chunks", mlog.Err(err))
 		}
 	}()
 
-	if appErr = worker.app.SendReportToUser(rctx, job, worker.reportFormat); appErr != nil {
+	// Jobs created without a session, e.g. through local mode, have
+	// nobody to send the report to. Their report stays available for
+	// download through the job.
+	if job.Data["requesting_user_id"] == "" {
+		return nil
+	}
+
+	if appErr = worker.app.SendReportToUser(rctx, job, format); appErr != nil {
 		return appErr
 	}
 
 	return nil
 }
+
+// formatForJob returns the format requested in the job data, falling
+// back to the default format of the worker.
+func (worker *BatchReportWorker) formatForJob(job *model.Job) string {
+	if format := job.Data["format"]; format != "" {
+		return format
+	}
+	return worker.reportFormat
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package export_access_report
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	timeBetweenBatches = 1 * time.Second
+	pageSize           = 50
+)
+
+type ExportAccessReportAppIFace interface {
+	jobs.BatchReportWorkerAppIFace
+	GetUsersForAccessReport(rctx request.CTX, options *model.AccessReportOptions) ([]*model.UserAccessReport, *model.AppError)
+}
+
+// MakeWorker creates a batch report worker to generate the access review report.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app ExportAccessReportAppIFace) model.Worker {
+	return jobs.MakeBatchReportWorker(
+		jobServer,
+		store,
+		app,
+		timeBetweenBatches,
+		"csv",
+		model.AccessReportHeaders,
+		getData(jobServer, app),
+	)
+}
+
+// parseJobMetadata parses the opaque job metadata to return the information needed to decide which
+// batch to process next.
+func parseJobMetadata(data model.StringMap) (*model.AccessReportOptions, error) {
+	inactiveSince := time.Now().AddDate(0, 0, -model.AccessReportDefaultInactiveDays).UnixMilli()
+	if val, ok := data["inactive_since"]; ok && val != "" {
+		var err error
+		inactiveSince, err = strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse inactive_since")
+		}
+	}
+
+	return &model.AccessReportOptions{
+		FromUserId:    data["last_user_id"],
+		PageSize:      pageSize,
+		InactiveSince: inactiveSince,
+	}, nil
+}
+
+func getData(jobServer *jobs.JobServer, app ExportAccessReportAppIFace) func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+	return func(jobData model.StringMap) ([]model.ReportableObject, model.StringMap, bool, error) {
+		options, err := parseJobMetadata(jobData)
+		if err != nil {
+			return nil, nil, false, errors.Wrap(err, "failed to parse job metadata")
+		}
+
+		rctx := request.EmptyContext(jobServer.Logger())
+		reports, appErr := app.GetUsersForAccessReport(rctx, options)
+		if appErr != nil {
+			return nil, nil, false, errors.Wrapf(appErr, "failed to get the next batch (user_id=%v)", options.FromUserId)
+		}
+
+		if len(reports) == 0 {
+			return nil, nil, true, nil
+		}
+
+		reportableObjects := make([]model.ReportableObject, 0, len(reports))
+		for _, report := range reports {
+			reportableObjects = append(reportableObjects, report)
+		}
+
+		jobData["last_user_id"] = reports[len(reports)-1].UserId
+		return reportableObjects, jobData, false, nil
+	}
+}
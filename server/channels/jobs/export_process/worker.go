@@ -19,6 +19,7 @@ type AppIface interface {
 	configservice.ConfigService
 	WriteExportFileContext(ctx context.Context, fr io.Reader, path string) (int64, *model.AppError)
 	BulkExport(rctx request.CTX, writer io.Writer, outPath string, job *model.Job, opts model.BulkExportOpts) *model.AppError
+	BulkExportChannel(rctx request.CTX, writer io.Writer, job *model.Job, channelID string, opts model.BulkExportOpts) *model.AppError
 	Log() *mlog.Logger
 }
 
@@ -69,7 +70,13 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			}
 		}()
 
-		appErr := app.BulkExport(request.EmptyContext(logger), wr, outPath, job, opts)
+		var appErr *model.AppError
+		if channelID := job.Data["channel_id"]; channelID != "" {
+			// The export of a single channel is always a self-contained archive.
+			appErr = app.BulkExportChannel(request.EmptyContext(logger), wr, job, channelID, opts)
+		} else {
+			appErr = app.BulkExport(request.EmptyContext(logger), wr, outPath, job, opts)
+		}
 		wr.Close() // Close never returns an error
 
 		if appErr != nil {
@@ -30,6 +30,7 @@ type AppIface interface {
 	FileSize(path string) (int64, *model.AppError)
 	FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
 	BulkImportWithPath(rctx request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun, extractContent bool, workers int, importPath string) (int, *model.AppError)
+	BulkImportChannel(rctx request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, opts model.ChannelImportOpts, extractContent bool, workers int, importPath string) (int, *model.AppError)
 	Log() *mlog.Logger
 }
 
@@ -125,7 +126,15 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 
 		extractContent := job.Data["extract_content"] == "true"
 		// do the actual import.
-		lineNumber, appErr := app.BulkImportWithPath(appContext, jsonFile, importZipReader, false, extractContent, runtime.NumCPU(), model.ExportDataDir)
+		var lineNumber int
+		var appErr *model.AppError
+		if job.Data["team"] != "" {
+			// The archive of a single channel, imported into an existing team.
+			opts := model.ChannelImportOptsFromJobData(job.Data)
+			lineNumber, appErr = app.BulkImportChannel(appContext, jsonFile, importZipReader, opts, extractContent, runtime.NumCPU(), model.ExportDataDir)
+		} else {
+			lineNumber, appErr = app.BulkImportWithPath(appContext, jsonFile, importZipReader, false, extractContent, runtime.NumCPU(), model.ExportDataDir)
+		}
 		if appErr != nil {
 			job.Data["line_number"] = strconv.Itoa(lineNumber)
 			return appErr
@@ -0,0 +1,65 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package user_sync
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/configservice"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+type AppIface interface {
+	configservice.ConfigService
+	RemoveFile(path string) *model.AppError
+	FileExists(path string) (bool, *model.AppError)
+	SyncUsersForJob(rctx request.CTX, job *model.Job, path string, dryRun bool) (*model.UserSyncResult, *model.AppError)
+}
+
+// MakeWorker creates the worker syncing the users of an uploaded CSV
+// file. The file is removed once processed, and the report of the sync
+// can be downloaded from the job.
+func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
+	const workerName = "UserSync"
+
+	isEnabled := func(cfg *model.Config) bool {
+		return true
+	}
+	execute := func(logger mlog.LoggerIFace, job *model.Job) error {
+		defer jobServer.HandleJobPanic(logger, job)
+
+		syncFileName, ok := job.Data["sync_file"]
+		if !ok || syncFileName == "" {
+			return model.NewAppError("UserSyncWorker", "user_sync.worker.do_job.missing_file", nil, "", http.StatusBadRequest)
+		}
+
+		syncFilePath := filepath.Join(*app.Config().ImportSettings.Directory, filepath.Base(syncFileName))
+		if ok, appErr := app.FileExists(syncFilePath); appErr != nil {
+			return appErr
+		} else if !ok {
+			return model.NewAppError("UserSyncWorker", "user_sync.worker.do_job.file_exists", nil, "", http.StatusBadRequest)
+		}
+
+		rctx := request.EmptyContext(logger)
+		result, appErr := app.SyncUsersForJob(rctx, job, syncFilePath, job.Data["dry_run"] == "true")
+		if appErr != nil {
+			return appErr
+		}
+
+		for key, value := range result.ToJobData() {
+			job.Data[key] = value
+		}
+		if appErr := jobServer.UpdateInProgressJobData(job); appErr != nil {
+			return appErr
+		}
+
+		// Remove the uploaded file, as it may contain passwords.
+		return app.RemoveFile(syncFilePath)
+	}
+	return jobs.NewSimpleWorker(workerName, jobServer, execute, isEnabled)
+}
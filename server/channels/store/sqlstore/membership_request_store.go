@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+var membershipRequestColumns = []string{
+	"Id",
+	"UserId",
+	"TeamId",
+	"ChannelId",
+	"Justification",
+	"Status",
+	"ReviewerId",
+	"PostIds",
+	"CreateAt",
+	"UpdateAt",
+	"ExpiresAt",
+}
+
+type SqlMembershipRequestStore struct {
+	*SqlStore
+
+	membershipRequestSelectQuery sq.SelectBuilder
+}
+
+func newSqlMembershipRequestStore(sqlStore *SqlStore) store.MembershipRequestStore {
+	s := &SqlMembershipRequestStore{
+		SqlStore: sqlStore,
+	}
+
+	s.membershipRequestSelectQuery = s.getQueryBuilder().
+		Select(membershipRequestColumns...).
+		From("MembershipRequests")
+
+	return s
+}
+
+func (s *SqlMembershipRequestStore) Save(request *model.MembershipRequest) (*model.MembershipRequest, error) {
+	request.PreSave()
+	if appErr := request.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if _, err := s.GetMaster().NamedExec(`INSERT INTO MembershipRequests
+	(Id, UserId, TeamId, ChannelId, Justification, Status, ReviewerId, PostIds, CreateAt, UpdateAt, ExpiresAt)
+	VALUES
+	(:Id, :UserId, :TeamId, :ChannelId, :Justification, :Status, :ReviewerId, :PostIds, :CreateAt, :UpdateAt, :ExpiresAt)`, request); err != nil {
+		if IsUniqueConstraintError(err, []string{"idx_membershiprequests_pending_unique"}) {
+			return nil, store.NewErrConflict("MembershipRequest", err, "user_id="+request.UserId)
+		}
+		return nil, errors.Wrap(err, "failed to save MembershipRequest")
+	}
+
+	return request, nil
+}
+
+func (s *SqlMembershipRequestStore) Get(id string) (*model.MembershipRequest, error) {
+	query := s.membershipRequestSelectQuery.Where(sq.Eq{"Id": id})
+
+	request := &model.MembershipRequest{}
+	if err := s.GetReplica().GetBuilder(request, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("MembershipRequest", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get MembershipRequest with id=%s", id)
+	}
+
+	return request, nil
+}
+
+func (s *SqlMembershipRequestStore) GetAll(opts model.MembershipRequestGetOptions) ([]*model.MembershipRequest, error) {
+	query := s.membershipRequestSelectQuery.OrderBy("CreateAt DESC", "Id")
+
+	if opts.UserId != "" {
+		query = query.Where(sq.Eq{"UserId": opts.UserId})
+	}
+	if opts.TeamId != "" {
+		query = query.Where(sq.Eq{"TeamId": opts.TeamId})
+	}
+	if opts.ChannelId != "" {
+		query = query.Where(sq.Eq{"ChannelId": opts.ChannelId})
+	} else if opts.TeamOnly {
+		query = query.Where(sq.Eq{"ChannelId": ""})
+	}
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{"Status": opts.Status})
+	}
+	if opts.ExpiresBefore > 0 {
+		query = query.Where(sq.Lt{"ExpiresAt": opts.ExpiresBefore})
+	}
+	if opts.PerPage > 0 {
+		query = query.Limit(uint64(opts.PerPage)).Offset(uint64(opts.Page * opts.PerPage))
+	}
+
+	requests := []*model.MembershipRequest{}
+	if err := s.GetReplica().SelectBuilder(&requests, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get MembershipRequests")
+	}
+
+	return requests, nil
+}
+
+func (s *SqlMembershipRequestStore) UpdateStatus(request *model.MembershipRequest) error {
+	query := s.getQueryBuilder().
+		Update("MembershipRequests").
+		Set("Status", request.Status).
+		Set("ReviewerId", request.ReviewerId).
+		Set("UpdateAt", request.UpdateAt).
+		Where(sq.Eq{"Id": request.Id, "Status": model.MembershipRequestStatusPending})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update MembershipRequest with id=%s", request.Id)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get rows_affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("MembershipRequest", request.Id)
+	}
+
+	return nil
+}
+
+func (s *SqlMembershipRequestStore) UpdatePostIds(id string, postIDs []string) error {
+	query := s.getQueryBuilder().
+		Update("MembershipRequests").
+		Set("PostIds", model.StringArray(postIDs)).
+		Where(sq.Eq{"Id": id})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to update MembershipRequest with id=%s", id)
+	}
+
+	return nil
+}
+
+func (s *SqlMembershipRequestStore) GetRequestableChannels(teamID, userID string, offset, limit int) (model.ChannelList, error) {
+	query := s.getQueryBuilder().
+		Select(channelSliceColumns(true, "Channels")...).
+		From("Channels").
+		Where(sq.Eq{
+			"Channels.TeamId":                  teamID,
+			"Channels.Type":                    model.ChannelTypePrivate,
+			"Channels.DeleteAt":                0,
+			"Channels.AllowMembershipRequests": true,
+		}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM ChannelMembers cm WHERE cm.ChannelId = Channels.Id AND cm.UserId = ?)", userID)).
+		OrderBy("Channels.DisplayName", "Channels.Id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	channels := model.ChannelList{}
+	if err := s.GetReplica().SelectBuilder(&channels, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to find requestable channels with teamId=%s", teamID)
+	}
+
+	return channels, nil
+}
+
+func (s *SqlMembershipRequestStore) GetRequestableTeams(userID string, offset, limit int) ([]*model.Team, error) {
+	query := s.getQueryBuilder().
+		Select(teamSliceColumns()...).
+		From("Teams").
+		Where(sq.Eq{
+			"Teams.DeleteAt":                0,
+			"Teams.AllowMembershipRequests": true,
+		}).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM TeamMembers tm WHERE tm.TeamId = Teams.Id AND tm.UserId = ? AND tm.DeleteAt = 0)", userID)).
+		OrderBy("Teams.DisplayName", "Teams.Id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	teams := []*model.Team{}
+	if err := s.GetReplica().SelectBuilder(&teams, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find requestable teams")
+	}
+
+	return teams, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlMfaRecoveryCodeStore struct {
+	*SqlStore
+}
+
+func newSqlMfaRecoveryCodeStore(sqlStore *SqlStore) store.MfaRecoveryCodeStore {
+	return &SqlMfaRecoveryCodeStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlMfaRecoveryCodeStore) SaveForUser(userID string, codes []*model.MfaRecoveryCode) (err error) {
+	for _, code := range codes {
+		code.UserId = userID
+		code.PreSave()
+		if appErr := code.IsValid(); appErr != nil {
+			return appErr
+		}
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if _, err = transaction.ExecBuilder(s.getQueryBuilder().Delete("MfaRecoveryCodes").Where(sq.Eq{"UserId": userID})); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaRecoveryCodes with userId=%s", userID)
+	}
+
+	if len(codes) > 0 {
+		query := s.getQueryBuilder().
+			Insert("MfaRecoveryCodes").
+			Columns("Id", "UserId", "CodeHash", "CreateAt", "UsedAt")
+		for _, code := range codes {
+			query = query.Values(code.Id, code.UserId, code.CodeHash, code.CreateAt, code.UsedAt)
+		}
+
+		if _, err = transaction.ExecBuilder(query); err != nil {
+			return errors.Wrapf(err, "failed to save MfaRecoveryCodes with userId=%s", userID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlMfaRecoveryCodeStore) GetUnusedForUser(userID string) ([]*model.MfaRecoveryCode, error) {
+	codes := []*model.MfaRecoveryCode{}
+	query := s.getQueryBuilder().
+		Select("Id", "UserId", "CodeHash", "CreateAt", "UsedAt").
+		From("MfaRecoveryCodes").
+		Where(sq.Eq{"UserId": userID, "UsedAt": 0}).
+		OrderBy("CreateAt", "Id")
+
+	// Read from the master so that a code just used can't be used again from a lagging replica.
+	if err := s.GetMaster().SelectBuilder(&codes, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get MfaRecoveryCodes with userId=%s", userID)
+	}
+
+	return codes, nil
+}
+
+func (s *SqlMfaRecoveryCodeStore) MarkUsed(id string, usedAt int64) error {
+	query := s.getQueryBuilder().
+		Update("MfaRecoveryCodes").
+		Set("UsedAt", usedAt).
+		Where(sq.Eq{"Id": id, "UsedAt": 0})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update MfaRecoveryCode with id=%s", id)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get rows affected updating MfaRecoveryCode with id=%s", id)
+	}
+	if rows == 0 {
+		return store.NewErrNotFound("MfaRecoveryCode", id)
+	}
+
+	return nil
+}
+
+func (s *SqlMfaRecoveryCodeStore) DeleteForUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("MfaRecoveryCodes").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaRecoveryCodes with userId=%s", userID)
+	}
+
+	return nil
+}
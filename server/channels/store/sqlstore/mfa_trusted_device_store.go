@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlMfaTrustedDeviceStore struct {
+	*SqlStore
+}
+
+func newSqlMfaTrustedDeviceStore(sqlStore *SqlStore) store.MfaTrustedDeviceStore {
+	return &SqlMfaTrustedDeviceStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlMfaTrustedDeviceStore) Save(device *model.MfaTrustedDevice) (_ *model.MfaTrustedDevice, err error) {
+	device.PreSave()
+	if appErr := device.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	stale := sq.Or{sq.LtOrEq{"ExpiresAt": device.CreateAt}}
+	if device.DeviceId != "" {
+		stale = append(stale, sq.Eq{"DeviceId": device.DeviceId})
+	}
+	query := s.getQueryBuilder().
+		Delete("MfaTrustedDevices").
+		Where(sq.And{sq.Eq{"UserId": device.UserId}, stale})
+	if _, err = transaction.ExecBuilder(query); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete MfaTrustedDevices with userId=%s", device.UserId)
+	}
+
+	if _, err = transaction.NamedExec(`INSERT INTO MfaTrustedDevices
+	(Id, UserId, DeviceId, TokenHash, CreateAt, ExpiresAt)
+	VALUES
+	(:Id, :UserId, :DeviceId, :TokenHash, :CreateAt, :ExpiresAt)`, device); err != nil {
+		if IsUniqueConstraintError(err, []string{"TokenHash", "mfatrusteddevices_tokenhash_key"}) {
+			return nil, store.NewErrConflict("MfaTrustedDevice", err, "id="+device.Id)
+		}
+		return nil, errors.Wrap(err, "failed to save MfaTrustedDevice")
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return device, nil
+}
+
+func (s *SqlMfaTrustedDeviceStore) GetByTokenHash(tokenHash string) (*model.MfaTrustedDevice, error) {
+	query := s.getQueryBuilder().
+		Select("Id", "UserId", "DeviceId", "TokenHash", "CreateAt", "ExpiresAt").
+		From("MfaTrustedDevices").
+		Where(sq.Eq{"TokenHash": tokenHash})
+
+	device := &model.MfaTrustedDevice{}
+	if err := s.GetMaster().GetBuilder(device, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("MfaTrustedDevice", "token_hash")
+		}
+		return nil, errors.Wrap(err, "failed to get MfaTrustedDevice")
+	}
+
+	return device, nil
+}
+
+func (s *SqlMfaTrustedDeviceStore) DeleteForUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("MfaTrustedDevices").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete MfaTrustedDevices with userId=%s", userID)
+	}
+
+	return nil
+}
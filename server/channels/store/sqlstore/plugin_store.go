@@ -4,8 +4,11 @@
 package sqlstore
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
 
 	sq "github.com/mattermost/squirrel"
 	"github.com/pkg/errors"
@@ -314,3 +317,137 @@ func (ps SqlPluginStore) List(pluginId string, offset int, limit int) ([]string,
 
 	return keys, nil
 }
+
+// likePrefixReplacer escapes the LIKE wildcards of a prefix, using the default escape character.
+var likePrefixReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (ps SqlPluginStore) ListWithOptions(pluginId string, options model.PluginKVListOptions) ([]*model.PluginKeyValue, error) {
+	if err := options.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := ps.getQueryBuilder().
+		Select("PluginId", "PKey", "PValue", "ExpireAt").
+		From("PluginKeyValueStore").
+		Where(sq.Eq{"PluginId": pluginId}).
+		Where(sq.Or{
+			sq.Eq{"ExpireAt": int(0)},
+			sq.Gt{"ExpireAt": model.GetMillis()},
+		}).
+		// Keys are compared byte by byte, whatever the collation of the database,
+		// so that they are listed in the same order as the other KV stores.
+		OrderBy(`PKey COLLATE "C"`).
+		Limit(uint64(options.Limit))
+
+	if options.Prefix != "" {
+		query = query.Where(sq.Like{"PKey": likePrefixReplacer.Replace(options.Prefix) + "%"})
+	}
+	if options.AfterKey != "" {
+		query = query.Where(sq.Expr(`PKey COLLATE "C" > ?`, options.AfterKey))
+	}
+
+	kvs := []*model.PluginKeyValue{}
+	if err := ps.GetReplica().SelectBuilder(&kvs, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to list PluginKeyValues with pluginId=%s", pluginId)
+	}
+
+	return kvs, nil
+}
+
+// SetBatch applies the operations in a single transaction. If the current value of any atomic
+// operation doesn't match its old value, nothing is written and false is returned.
+func (ps SqlPluginStore) SetBatch(pluginId string, operations []*model.PluginKVSetOperation) (_ bool, err error) {
+	if appErr := model.IsValidPluginKVSetOperations(operations); appErr != nil {
+		return false, appErr
+	}
+
+	// Lock the rows in key order to avoid deadlocks between concurrent batches.
+	operations = slices.Clone(operations)
+	slices.SortFunc(operations, func(a, b *model.PluginKVSetOperation) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	transaction, err := ps.GetMaster().Beginx()
+	if err != nil {
+		return false, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	currentTime := model.GetMillis()
+	for _, op := range operations {
+		kv, appErr := model.NewPluginKeyValueFromOptions(pluginId, op.Key, op.Value, op.Options)
+		if appErr != nil {
+			return false, appErr
+		}
+		if appErr = kv.IsValid(); appErr != nil {
+			return false, appErr
+		}
+
+		if op.Options.Atomic {
+			var current []byte
+			query := ps.getQueryBuilder().
+				Select("PValue").
+				From("PluginKeyValueStore").
+				Where(sq.Eq{"PluginId": pluginId}).
+				Where(sq.Eq{"PKey": op.Key}).
+				Where(sq.Or{
+					sq.Eq{"ExpireAt": int(0)},
+					sq.Gt{"ExpireAt": currentTime},
+				}).
+				Suffix("FOR UPDATE")
+			exists := true
+			if err = transaction.GetBuilder(&current, query); errors.Is(err, sql.ErrNoRows) {
+				exists = false
+			} else if err != nil {
+				return false, errors.Wrapf(err, "failed to get PluginKeyValue with pluginId=%s and key=%s", pluginId, op.Key)
+			}
+
+			if exists != (op.Options.OldValue != nil) || !bytes.Equal(current, op.Options.OldValue) {
+				return false, nil
+			}
+		}
+
+		if op.CheckOnly {
+			continue
+		}
+
+		if kv.Value == nil {
+			query := ps.getQueryBuilder().
+				Delete("PluginKeyValueStore").
+				Where(sq.Eq{"PluginId": pluginId}).
+				Where(sq.Eq{"PKey": op.Key})
+			if _, err = transaction.ExecBuilder(query); err != nil {
+				return false, errors.Wrapf(err, "failed to delete PluginKeyValue with pluginId=%s and key=%s", pluginId, op.Key)
+			}
+			continue
+		}
+
+		query := ps.getQueryBuilder().
+			Insert("PluginKeyValueStore").
+			Columns("PluginId", "PKey", "PValue", "ExpireAt").
+			Values(kv.PluginId, kv.Key, kv.Value, kv.ExpireAt)
+		if op.Options.Atomic && op.Options.OldValue == nil {
+			// The key didn't exist when checked, so only replace an expired value: a value
+			// inserted concurrently since then means the batch lost the race.
+			query = query.SuffixExpr(sq.Expr("ON CONFLICT (pluginid, pkey) DO UPDATE SET PValue = ?, ExpireAt = ? WHERE PluginKeyValueStore.ExpireAt <> 0 AND PluginKeyValueStore.ExpireAt <= ?", kv.Value, kv.ExpireAt, currentTime))
+		} else {
+			query = query.SuffixExpr(sq.Expr("ON CONFLICT (pluginid, pkey) DO UPDATE SET PValue = ?, ExpireAt = ?", kv.Value, kv.ExpireAt))
+		}
+
+		result, err := transaction.ExecBuilder(query)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to upsert PluginKeyValue with pluginId=%s and key=%s", pluginId, op.Key)
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return false, errors.Wrap(err, "unable to get rows affected")
+		} else if rowsAffected == 0 {
+			return false, nil
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return false, errors.Wrap(err, "commit_transaction")
+	}
+
+	return true, nil
+}
@@ -417,6 +417,8 @@ func sharedChannelRemoteFields(prefix string) []string {
 		prefix + "LastPostUpdateAt",
 		"COALESCE(" + prefix + "LastPostId,'') AS LastPostUpdateID",
 		prefix + "LastMembersSyncAt",
+		prefix + "LastBookmarksSyncAt",
+		prefix + "LastChannelInfoSyncAt",
 	}
 }
 
@@ -642,6 +644,30 @@ func (s SqlSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPos
 	return nil
 }
 
+// UpdateRemoteChannelStateCursor updates the bookmark and channel info cursors for the specified
+// SharedChannelRemote. Each cursor only moves forward; a zero value leaves it unchanged.
+func (s SqlSharedChannelStore) UpdateRemoteChannelStateCursor(id string, bookmarksSyncAt, channelInfoSyncAt int64) error {
+	query := s.getQueryBuilder().
+		Update("SharedChannelRemotes").
+		Set("LastBookmarksSyncAt", sq.Expr("GREATEST(LastBookmarksSyncAt, ?)", bookmarksSyncAt)).
+		Set("LastChannelInfoSyncAt", sq.Expr("GREATEST(LastChannelInfoSyncAt, ?)", channelInfoSyncAt)).
+		Where(sq.Eq{"Id": id})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrap(err, "failed to update channel state cursor for SharedChannelRemote")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine rows affected")
+	}
+	if count == 0 {
+		return fmt.Errorf("id not found: %s", id)
+	}
+	return nil
+}
+
 // DeleteRemote deletes a single shared channel remote.
 // Returns true if remote found and deleted, false if not found.
 func (s SqlSharedChannelStore) DeleteRemote(id string) (bool, error) {
@@ -115,6 +115,10 @@ type SqlStoreStores struct {
 	recap                      store.RecapStore
 	readReceipt                store.ReadReceiptStore
 	temporaryPost              store.TemporaryPostStore
+	webAuthnCredential         store.WebAuthnCredentialStore
+	mfaRecoveryCode            store.MfaRecoveryCodeStore
+	mfaTrustedDevice           store.MfaTrustedDeviceStore
+	membershipRequest          store.MembershipRequestStore
 }
 
 type SqlStore struct {
@@ -289,6 +293,10 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.recap = newSqlRecapStore(store)
 	store.stores.readReceipt = newSqlReadReceiptStore(store, metrics)
 	store.stores.temporaryPost = newSqlTemporaryPostStore(store, metrics)
+	store.stores.webAuthnCredential = newSqlWebAuthnCredentialStore(store)
+	store.stores.mfaRecoveryCode = newSqlMfaRecoveryCodeStore(store)
+	store.stores.mfaTrustedDevice = newSqlMfaTrustedDeviceStore(store)
+	store.stores.membershipRequest = newSqlMembershipRequestStore(store)
 
 	store.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
 
@@ -897,6 +905,22 @@ func (ss *SqlStore) TemporaryPost() store.TemporaryPostStore {
 	return ss.stores.temporaryPost
 }
 
+func (ss *SqlStore) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return ss.stores.webAuthnCredential
+}
+
+func (ss *SqlStore) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return ss.stores.mfaRecoveryCode
+}
+
+func (ss *SqlStore) MfaTrustedDevice() store.MfaTrustedDeviceStore {
+	return ss.stores.mfaTrustedDevice
+}
+
+func (ss *SqlStore) MembershipRequest() store.MembershipRequestStore {
+	return ss.stores.membershipRequest
+}
+
 func (ss *SqlStore) DropAllTables() {
 	ss.masterX.Exec(`DO
 		$func$
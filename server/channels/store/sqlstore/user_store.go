@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -1487,6 +1488,32 @@ func (us SqlUserStore) AnalyticsActiveCount(timePeriod int64, options model.User
 	return v, nil
 }
 
+func (us SqlUserStore) GetPasswordHasherCounts() (map[string]int64, error) {
+	// Strip the salt and the hash, the last two segments of the PHC string
+	query := us.getQueryBuilder().
+		Select(`regexp_replace(Password, '\$[^$]*\$[^$]*$', '') AS Prefix`, "COUNT(*) AS Count").
+		From("Users").
+		Where(sq.And{
+			sq.Eq{"DeleteAt": 0},
+			sq.NotEq{"Password": ""},
+		}).
+		GroupBy("Prefix")
+
+	var results []struct {
+		Prefix string
+		Count  int64
+	}
+	if err := us.GetReplica().SelectBuilder(&results, query); err != nil {
+		return nil, errors.Wrap(err, "failed to count Users by password hasher")
+	}
+
+	counts := make(map[string]int64, len(results))
+	for _, result := range results {
+		counts[result.Prefix] = result.Count
+	}
+	return counts, nil
+}
+
 func (us SqlUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int32, error) {
 	query := us.getQueryBuilder().Select("COUNT(*)").From("Status AS s").Where("LastActivityAt > ? AND LastActivityAt <= ?", startTime, endTime)
 
@@ -2030,6 +2057,120 @@ func (us SqlUserStore) GetUsersBatchForIndexing(startTime int64, startFileID str
 	return usersForIndexing, nil
 }
 
+// userFieldFilterColumns are the columns of the Users table user field filters may compare.
+var userFieldFilterColumns = []string{"Id", "Username", "Email", "FirstName", "LastName", "Nickname", "Position", "AuthService", "AuthData"}
+
+func userFieldFilterCondition(filter *store.UserFieldFilter) (sq.Sqlizer, error) {
+	switch filter.Op {
+	case store.UserFieldFilterAnd, store.UserFieldFilterOr:
+		conditions := make([]sq.Sqlizer, 0, len(filter.Filters))
+		for _, f := range filter.Filters {
+			condition, err := userFieldFilterCondition(f)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition)
+		}
+		if filter.Op == store.UserFieldFilterAnd {
+			return sq.And(conditions), nil
+		}
+		return sq.Or(conditions), nil
+	}
+
+	if filter.Field == store.UserFieldActive {
+		active, ok := filter.Value.(bool)
+		if !ok || (filter.Op != store.UserFieldFilterEquals && filter.Op != store.UserFieldFilterNotEquals) {
+			return nil, errors.Errorf("invalid filter on field %s", filter.Field)
+		}
+		if active == (filter.Op == store.UserFieldFilterEquals) {
+			return sq.Eq{"Users.DeleteAt": 0}, nil
+		}
+		return sq.NotEq{"Users.DeleteAt": 0}, nil
+	}
+
+	value, ok := filter.Value.(string)
+	if !ok || !slices.Contains(userFieldFilterColumns, filter.Field) {
+		return nil, errors.Errorf("invalid filter on field %s", filter.Field)
+	}
+
+	column := "LOWER(COALESCE(Users." + filter.Field + ", ''))"
+	switch filter.Op {
+	case store.UserFieldFilterEquals:
+		return sq.Expr(column+" = LOWER(?)", value), nil
+	case store.UserFieldFilterNotEquals:
+		return sq.Expr(column+" <> LOWER(?)", value), nil
+	case store.UserFieldFilterContains:
+		return sq.Expr(column+" LIKE LOWER(?)", "%"+sanitizeSearchTerm(value, "\\")+"%"), nil
+	case store.UserFieldFilterStartsWith:
+		return sq.Expr(column+" LIKE LOWER(?)", sanitizeSearchTerm(value, "\\")+"%"), nil
+	case store.UserFieldFilterEndsWith:
+		return sq.Expr(column+" LIKE LOWER(?)", "%"+sanitizeSearchTerm(value, "\\")), nil
+	}
+
+	return nil, errors.Errorf("invalid filter operator %s", filter.Op)
+}
+
+// applyUserFieldFilter restricts the query, which must join the Bots table as b, to the users
+// other than bots and remote users matching the filter.
+func applyUserFieldFilter(query sq.SelectBuilder, filter *store.UserFieldFilter) (sq.SelectBuilder, error) {
+	query = query.
+		Where("b.UserId IS NULL").
+		Where(sq.Or{sq.Eq{"Users.RemoteId": ""}, sq.Eq{"Users.RemoteId": nil}})
+
+	if filter == nil {
+		return query, nil
+	}
+
+	condition, err := userFieldFilterCondition(filter)
+	if err != nil {
+		return query, err
+	}
+
+	return query.Where(condition), nil
+}
+
+func (us SqlUserStore) GetByFieldFilter(filter *store.UserFieldFilter, offset, limit int) ([]*model.User, error) {
+	query, err := applyUserFieldFilter(us.usersQuery, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query = query.
+		OrderBy("Users.Username ASC").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	users := []*model.User{}
+	if err := us.GetReplica().SelectBuilder(&users, query); err != nil {
+		return nil, errors.Wrap(err, "failed to get Users by field filter")
+	}
+
+	for _, u := range users {
+		u.Sanitize(map[string]bool{})
+	}
+
+	return users, nil
+}
+
+func (us SqlUserStore) CountByFieldFilter(filter *store.UserFieldFilter) (int64, error) {
+	query := us.getQueryBuilder().
+		Select("COUNT(*)").
+		From("Users").
+		LeftJoin("Bots b ON ( b.UserId = Users.Id )")
+
+	query, err := applyUserFieldFilter(query, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := us.GetReplica().GetBuilder(&count, query); err != nil {
+		return 0, errors.Wrap(err, "failed to count Users by field filter")
+	}
+
+	return count, nil
+}
+
 func (us SqlUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, error) {
 	query := applyTeamGroupConstrainedFilter(us.usersQuery, teamID)
 
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlWebAuthnCredentialStore struct {
+	*SqlStore
+
+	tableSelectQuery sq.SelectBuilder
+}
+
+func newSqlWebAuthnCredentialStore(sqlStore *SqlStore) store.WebAuthnCredentialStore {
+	s := SqlWebAuthnCredentialStore{
+		SqlStore: sqlStore,
+	}
+
+	s.tableSelectQuery = s.getQueryBuilder().
+		Select("Id", "UserId", "Name", "CredentialId", "PublicKey", "SignCount", "AAGUID", "Transports", "CreateAt", "LastUsedAt").
+		From("WebAuthnCredentials")
+
+	return &s
+}
+
+func (s *SqlWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
+	credential.PreSave()
+	if err := credential.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().NamedExec(`INSERT INTO WebAuthnCredentials
+	(Id, UserId, Name, CredentialId, PublicKey, SignCount, AAGUID, Transports, CreateAt, LastUsedAt)
+	VALUES
+	(:Id, :UserId, :Name, :CredentialId, :PublicKey, :SignCount, :AAGUID, :Transports, :CreateAt, :LastUsedAt)`, credential); err != nil {
+		if IsUniqueConstraintError(err, []string{"CredentialId", "webauthncredentials_credentialid_key"}) {
+			return nil, store.NewErrConflict("WebAuthnCredential", err, "credential_id="+credential.CredentialId)
+		}
+		return nil, errors.Wrap(err, "failed to save WebAuthnCredential")
+	}
+
+	return credential, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) get(query sq.SelectBuilder, key string) (*model.WebAuthnCredential, error) {
+	credential := &model.WebAuthnCredential{}
+	if err := s.GetReplica().GetBuilder(credential, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("WebAuthnCredential", key)
+		}
+		return nil, errors.Wrapf(err, "failed to get WebAuthnCredential with %s", key)
+	}
+
+	return credential, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
+	return s.get(s.tableSelectQuery.Where(sq.Eq{"Id": id}), "id="+id)
+}
+
+func (s *SqlWebAuthnCredentialStore) GetByCredentialId(credentialID string) (*model.WebAuthnCredential, error) {
+	return s.get(s.tableSelectQuery.Where(sq.Eq{"CredentialId": credentialID}), "credential_id="+credentialID)
+}
+
+func (s *SqlWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
+	credentials := []*model.WebAuthnCredential{}
+	query := s.tableSelectQuery.Where(sq.Eq{"UserId": userID}).OrderBy("CreateAt", "Id")
+
+	if err := s.GetReplica().SelectBuilder(&credentials, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get WebAuthnCredentials with userId=%s", userID)
+	}
+
+	return credentials, nil
+}
+
+func (s *SqlWebAuthnCredentialStore) UpdateLastUsed(id string, signCount, lastUsedAt int64) error {
+	query := s.getQueryBuilder().
+		Update("WebAuthnCredentials").
+		Set("SignCount", signCount).
+		Set("LastUsedAt", lastUsedAt).
+		Where(sq.Eq{"Id": id})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to update WebAuthnCredential with id=%s", id)
+	}
+
+	return nil
+}
+
+func (s *SqlWebAuthnCredentialStore) Delete(id string) error {
+	query := s.getQueryBuilder().
+		Delete("WebAuthnCredentials").
+		Where(sq.Eq{"Id": id})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete WebAuthnCredential with id=%s", id)
+	}
+
+	return nil
+}
+
+func (s *SqlWebAuthnCredentialStore) DeleteForUser(userID string) error {
+	query := s.getQueryBuilder().
+		Delete("WebAuthnCredentials").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to delete WebAuthnCredentials with userId=%s", userID)
+	}
+
+	return nil
+}
@@ -101,6 +101,10 @@ type Store interface {
 	Recap() RecapStore
 	ReadReceipt() ReadReceiptStore
 	TemporaryPost() TemporaryPostStore
+	WebAuthnCredential() WebAuthnCredentialStore
+	MfaRecoveryCode() MfaRecoveryCodeStore
+	MfaTrustedDevice() MfaTrustedDeviceStore
+	MembershipRequest() MembershipRequestStore
 }
 
 type RetentionPolicyStore interface {
@@ -475,6 +479,9 @@ type UserStore interface {
 	GetSystemAdminProfiles() (map[string]*model.User, error)
 	PermanentDelete(rctx request.CTX, userID string) error
 	AnalyticsActiveCount(timestamp int64, options model.UserCountOptions) (int64, error)
+	// GetPasswordHasherCounts returns the number of active users with a password, keyed by the
+	// PHC prefix of their hashed password: the hashing function and its parameters.
+	GetPasswordHasherCounts() (map[string]int64, error)
 	AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int32, error)
 	GetUnreadCount(userID string, isCRTEnabled bool) (int64, error)
 	GetUnreadCountForChannel(userID string, channelID string) (int64, error)
@@ -499,6 +506,10 @@ type UserStore interface {
 	GetAllAfter(limit int, afterID string) ([]*model.User, error)
 	GetUsersBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.UserForIndexing, error)
 	Count(options model.UserCountOptions) (int64, error)
+	// GetByFieldFilter returns the users, other than bots and remote users, matching the filter,
+	// ordered by username. A nil filter matches all of them.
+	GetByFieldFilter(filter *UserFieldFilter, offset, limit int) ([]*model.User, error)
+	CountByFieldFilter(filter *UserFieldFilter) (int64, error)
 	GetTeamGroupUsers(teamID string) ([]*model.User, error)
 	GetChannelGroupUsers(channelID string) ([]*model.User, error)
 	PromoteGuestToUser(userID string) error
@@ -719,6 +730,51 @@ type DesktopTokensStore interface {
 	DeleteOlderThan(minCreatedAt int64) error
 }
 
+type WebAuthnCredentialStore interface {
+	Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error)
+	Get(id string) (*model.WebAuthnCredential, error)
+	GetByCredentialId(credentialID string) (*model.WebAuthnCredential, error)
+	GetForUser(userID string) ([]*model.WebAuthnCredential, error)
+	UpdateLastUsed(id string, signCount, lastUsedAt int64) error
+	Delete(id string) error
+	DeleteForUser(userID string) error
+}
+
+type MembershipRequestStore interface {
+	// Save fails with an ErrConflict if the user already has a pending request to join the
+	// same channel or team.
+	Save(request *model.MembershipRequest) (*model.MembershipRequest, error)
+	Get(id string) (*model.MembershipRequest, error)
+	GetAll(opts model.MembershipRequestGetOptions) ([]*model.MembershipRequest, error)
+	// UpdateStatus resolves a pending request, failing with an ErrNotFound if it is no
+	// longer pending.
+	UpdateStatus(request *model.MembershipRequest) error
+	UpdatePostIds(id string, postIDs []string) error
+	// GetRequestableChannels returns the private channels of the team that accept requests
+	// and the user isn't a member of.
+	GetRequestableChannels(teamID, userID string, offset, limit int) (model.ChannelList, error)
+	// GetRequestableTeams returns the teams that accept requests and the user isn't a
+	// member of.
+	GetRequestableTeams(userID string, offset, limit int) ([]*model.Team, error)
+}
+
+type MfaRecoveryCodeStore interface {
+	// SaveForUser replaces all recovery codes of a user with the given ones.
+	SaveForUser(userID string, codes []*model.MfaRecoveryCode) error
+	GetUnusedForUser(userID string) ([]*model.MfaRecoveryCode, error)
+	// MarkUsed returns a not found error if the code doesn't exist or was already used.
+	MarkUsed(id string, usedAt int64) error
+	DeleteForUser(userID string) error
+}
+
+type MfaTrustedDeviceStore interface {
+	// Save replaces any trusted device of the user with the same, non-empty, device id, and
+	// removes the user's expired ones.
+	Save(device *model.MfaTrustedDevice) (*model.MfaTrustedDevice, error)
+	GetByTokenHash(tokenHash string) (*model.MfaTrustedDevice, error)
+	DeleteForUser(userID string) error
+}
+
 type EmojiStore interface {
 	Save(emoji *model.Emoji) (*model.Emoji, error)
 	Get(rctx request.CTX, id string, allowFromCache bool) (*model.Emoji, error)
@@ -841,6 +897,8 @@ type PluginStore interface {
 	DeleteAllForPlugin(PluginID string) error
 	DeleteAllExpired() error
 	List(pluginID string, page, perPage int) ([]string, error)
+	ListWithOptions(pluginID string, options model.PluginKVListOptions) ([]*model.PluginKeyValue, error)
+	SetBatch(pluginID string, operations []*model.PluginKVSetOperation) (bool, error)
 }
 
 type RoleStore interface {
@@ -1032,6 +1090,7 @@ type SharedChannelStore interface {
 	GetRemotes(offset, limit int, opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error)
 	UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error
 	UpdateRemoteMembershipCursor(id string, syncTime int64) error
+	UpdateRemoteChannelStateCursor(id string, bookmarksSyncAt, channelInfoSyncAt int64) error
 	DeleteRemote(remoteID string) (bool, error)
 	GetRemotesStatus(channelID string) ([]*model.SharedChannelRemoteStatus, error)
 
@@ -1266,6 +1325,31 @@ type UserGetByIdsOpts struct {
 	Since int64
 }
 
+const (
+	UserFieldFilterAnd        = "and"
+	UserFieldFilterOr         = "or"
+	UserFieldFilterEquals     = "eq"
+	UserFieldFilterNotEquals  = "ne"
+	UserFieldFilterContains   = "co"
+	UserFieldFilterStartsWith = "sw"
+	UserFieldFilterEndsWith   = "ew"
+
+	// UserFieldActive is compared with a boolean rather than a string, and matches the users
+	// that aren't deactivated.
+	UserFieldActive = "Active"
+)
+
+// UserFieldFilter is a condition on the fields of users, such as the SCIM filters translate into:
+// either the comparison of the field, a column of the Users table, with the value, or when Op is
+// UserFieldFilterAnd or UserFieldFilterOr, the combination of the filters. Strings are compared
+// case insensitively.
+type UserFieldFilter struct {
+	Op      string
+	Field   string
+	Value   any
+	Filters []*UserFieldFilter
+}
+
 // ThreadMembershipOpts defines some properties to be passed to
 // ThreadStore.MaintainMembership()
 type ThreadMembershipOpts struct {
@@ -0,0 +1,207 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestMembershipRequestStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testMembershipRequestSaveAndGet(t, rctx, ss) })
+	t.Run("GetAll", func(t *testing.T) { testMembershipRequestGetAll(t, rctx, ss) })
+	t.Run("UpdateStatus", func(t *testing.T) { testMembershipRequestUpdateStatus(t, rctx, ss) })
+	t.Run("GetRequestableChannels", func(t *testing.T) { testMembershipRequestGetRequestableChannels(t, rctx, ss) })
+	t.Run("GetRequestableTeams", func(t *testing.T) { testMembershipRequestGetRequestableTeams(t, rctx, ss) })
+}
+
+func newTestMembershipRequest(userID, teamID, channelID string) *model.MembershipRequest {
+	return &model.MembershipRequest{
+		UserId:        userID,
+		TeamId:        teamID,
+		ChannelId:     channelID,
+		Justification: "I work on this project",
+		ExpiresAt:     model.GetMillis() + 24*60*60*1000,
+	}
+}
+
+func testMembershipRequestSaveAndGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	request, err := ss.MembershipRequest().Save(newTestMembershipRequest(model.NewId(), model.NewId(), model.NewId()))
+	require.NoError(t, err)
+	require.NotEmpty(t, request.Id)
+	assert.Equal(t, model.MembershipRequestStatusPending, request.Status)
+
+	got, err := ss.MembershipRequest().Get(request.Id)
+	require.NoError(t, err)
+	assert.Equal(t, request, got)
+
+	t.Run("pending request already exists", func(t *testing.T) {
+		_, err := ss.MembershipRequest().Save(newTestMembershipRequest(request.UserId, request.TeamId, request.ChannelId))
+		var cErr *store.ErrConflict
+		require.ErrorAs(t, err, &cErr)
+	})
+
+	t.Run("post ids", func(t *testing.T) {
+		postIDs := []string{model.NewId(), model.NewId()}
+		require.NoError(t, ss.MembershipRequest().UpdatePostIds(request.Id, postIDs))
+
+		got, err := ss.MembershipRequest().Get(request.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.StringArray(postIDs), got.PostIds)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ss.MembershipRequest().Save(newTestMembershipRequest(model.NewId(), "", ""))
+		require.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.MembershipRequest().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testMembershipRequestGetAll(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	teamID := model.NewId()
+	channelID := model.NewId()
+
+	teamRequest, err := ss.MembershipRequest().Save(newTestMembershipRequest(userID, teamID, ""))
+	require.NoError(t, err)
+	channelRequest, err := ss.MembershipRequest().Save(newTestMembershipRequest(userID, teamID, channelID))
+	require.NoError(t, err)
+	expired := newTestMembershipRequest(model.NewId(), teamID, channelID)
+	expired.ExpiresAt = model.GetMillis() - 1
+	expired.CreateAt = expired.ExpiresAt - 1
+	expired, err = ss.MembershipRequest().Save(expired)
+	require.NoError(t, err)
+
+	ids := func(requests []*model.MembershipRequest) []string {
+		ids := make([]string, 0, len(requests))
+		for _, request := range requests {
+			ids = append(ids, request.Id)
+		}
+		return ids
+	}
+
+	requests, err := ss.MembershipRequest().GetAll(model.MembershipRequestGetOptions{UserId: userID})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{teamRequest.Id, channelRequest.Id}, ids(requests))
+
+	requests, err = ss.MembershipRequest().GetAll(model.MembershipRequestGetOptions{TeamId: teamID, TeamOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{teamRequest.Id}, ids(requests))
+
+	requests, err = ss.MembershipRequest().GetAll(model.MembershipRequestGetOptions{ChannelId: channelID, PerPage: 1})
+	require.NoError(t, err)
+	assert.Len(t, requests, 1)
+
+	requests, err = ss.MembershipRequest().GetAll(model.MembershipRequestGetOptions{
+		TeamId:        teamID,
+		Status:        model.MembershipRequestStatusPending,
+		ExpiresBefore: model.GetMillis(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{expired.Id}, ids(requests))
+}
+
+func testMembershipRequestUpdateStatus(t *testing.T, rctx request.CTX, ss store.Store) {
+	request, err := ss.MembershipRequest().Save(newTestMembershipRequest(model.NewId(), model.NewId(), model.NewId()))
+	require.NoError(t, err)
+
+	request.Status = model.MembershipRequestStatusApproved
+	request.ReviewerId = model.NewId()
+	request.UpdateAt = model.GetMillis()
+	require.NoError(t, ss.MembershipRequest().UpdateStatus(request))
+
+	got, err := ss.MembershipRequest().Get(request.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.MembershipRequestStatusApproved, got.Status)
+	assert.Equal(t, request.ReviewerId, got.ReviewerId)
+
+	t.Run("only pending requests are updated", func(t *testing.T) {
+		request.Status = model.MembershipRequestStatusDenied
+		err := ss.MembershipRequest().UpdateStatus(request)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("a new request can be made once resolved", func(t *testing.T) {
+		_, err := ss.MembershipRequest().Save(newTestMembershipRequest(request.UserId, request.TeamId, request.ChannelId))
+		require.NoError(t, err)
+	})
+}
+
+func testMembershipRequestGetRequestableChannels(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+	userID := model.NewId()
+
+	newChannel := func(channelType model.ChannelType, allowMembershipRequests bool) *model.Channel {
+		channel, err := ss.Channel().Save(rctx, &model.Channel{
+			TeamId:                  teamID,
+			DisplayName:             "Channel " + model.NewId(),
+			Name:                    NewTestID(),
+			Type:                    channelType,
+			AllowMembershipRequests: allowMembershipRequests,
+		}, -1)
+		require.NoError(t, err)
+		return channel
+	}
+
+	requestable := newChannel(model.ChannelTypePrivate, true)
+	newChannel(model.ChannelTypePrivate, false)
+	newChannel(model.ChannelTypeOpen, true)
+	member := newChannel(model.ChannelTypePrivate, true)
+	_, err := ss.Channel().SaveMember(rctx, &model.ChannelMember{
+		ChannelId:   member.Id,
+		UserId:      userID,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	channels, err := ss.MembershipRequest().GetRequestableChannels(teamID, userID, 0, 100)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	assert.Equal(t, requestable.Id, channels[0].Id)
+	assert.True(t, channels[0].AllowMembershipRequests)
+}
+
+func testMembershipRequestGetRequestableTeams(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+
+	newTeam := func(allowMembershipRequests bool) *model.Team {
+		team, err := ss.Team().Save(&model.Team{
+			DisplayName:             "Team " + model.NewId(),
+			Name:                    NewTestID(),
+			Email:                   MakeEmail(),
+			Type:                    model.TeamInvite,
+			AllowMembershipRequests: allowMembershipRequests,
+		})
+		require.NoError(t, err)
+		return team
+	}
+
+	requestable := newTeam(true)
+	newTeam(false)
+	member := newTeam(true)
+	_, err := ss.Team().SaveMember(rctx, &model.TeamMember{TeamId: member.Id, UserId: userID}, -1)
+	require.NoError(t, err)
+
+	teams, err := ss.MembershipRequest().GetRequestableTeams(userID, 0, 1000)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, team := range teams {
+		ids = append(ids, team.Id)
+	}
+	assert.Contains(t, ids, requestable.Id)
+	assert.NotContains(t, ids, member.Id)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestMfaRecoveryCodeStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveForUser", func(t *testing.T) { testMfaRecoveryCodeSaveForUser(t, rctx, ss) })
+	t.Run("MarkUsed", func(t *testing.T) { testMfaRecoveryCodeMarkUsed(t, rctx, ss) })
+	t.Run("DeleteForUser", func(t *testing.T) { testMfaRecoveryCodeDeleteForUser(t, rctx, ss) })
+}
+
+func newTestMfaRecoveryCodes(n int) []*model.MfaRecoveryCode {
+	codes := make([]*model.MfaRecoveryCode, n)
+	for i := range codes {
+		codes[i] = &model.MfaRecoveryCode{CodeHash: model.NewId()}
+	}
+	return codes
+}
+
+func testMfaRecoveryCodeSaveForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, newTestMfaRecoveryCodes(3)))
+
+	codes, err := ss.MfaRecoveryCode().GetUnusedForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, codes, 3)
+	for _, code := range codes {
+		assert.Equal(t, userID, code.UserId)
+		assert.NotEmpty(t, code.CodeHash)
+	}
+
+	t.Run("replaces existing codes", func(t *testing.T) {
+		replacement := newTestMfaRecoveryCodes(2)
+		require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, replacement))
+
+		codes, err := ss.MfaRecoveryCode().GetUnusedForUser(userID)
+		require.NoError(t, err)
+		require.Len(t, codes, 2)
+		assert.ElementsMatch(t, []string{replacement[0].Id, replacement[1].Id}, []string{codes[0].Id, codes[1].Id})
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := ss.MfaRecoveryCode().SaveForUser(userID, []*model.MfaRecoveryCode{{}})
+		require.Error(t, err)
+
+		codes, err := ss.MfaRecoveryCode().GetUnusedForUser(userID)
+		require.NoError(t, err)
+		assert.Len(t, codes, 2)
+	})
+}
+
+func testMfaRecoveryCodeMarkUsed(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	codes := newTestMfaRecoveryCodes(2)
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, codes))
+
+	require.NoError(t, ss.MfaRecoveryCode().MarkUsed(codes[0].Id, model.GetMillis()))
+
+	unused, err := ss.MfaRecoveryCode().GetUnusedForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, unused, 1)
+	assert.Equal(t, codes[1].Id, unused[0].Id)
+
+	var nfErr *store.ErrNotFound
+	err = ss.MfaRecoveryCode().MarkUsed(codes[0].Id, model.GetMillis())
+	require.ErrorAs(t, err, &nfErr)
+
+	err = ss.MfaRecoveryCode().MarkUsed(model.NewId(), model.GetMillis())
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testMfaRecoveryCodeDeleteForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(userID, newTestMfaRecoveryCodes(2)))
+	require.NoError(t, ss.MfaRecoveryCode().SaveForUser(otherUserID, newTestMfaRecoveryCodes(1)))
+
+	require.NoError(t, ss.MfaRecoveryCode().DeleteForUser(userID))
+
+	codes, err := ss.MfaRecoveryCode().GetUnusedForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, codes)
+
+	codes, err = ss.MfaRecoveryCode().GetUnusedForUser(otherUserID)
+	require.NoError(t, err)
+	assert.Len(t, codes, 1)
+}
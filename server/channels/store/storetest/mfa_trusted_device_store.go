@@ -0,0 +1,115 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestMfaTrustedDeviceStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testMfaTrustedDeviceSaveAndGet(t, rctx, ss) })
+	t.Run("Save replaces device", func(t *testing.T) { testMfaTrustedDeviceSaveReplaces(t, rctx, ss) })
+	t.Run("DeleteForUser", func(t *testing.T) { testMfaTrustedDeviceDeleteForUser(t, rctx, ss) })
+}
+
+func newTestMfaTrustedDevice(userID, deviceID string) *model.MfaTrustedDevice {
+	now := model.GetMillis()
+	return &model.MfaTrustedDevice{
+		UserId:    userID,
+		DeviceId:  deviceID,
+		TokenHash: model.NewId() + model.NewId(),
+		CreateAt:  now,
+		ExpiresAt: now + 24*60*60*1000,
+	}
+}
+
+func testMfaTrustedDeviceSaveAndGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	device, err := ss.MfaTrustedDevice().Save(newTestMfaTrustedDevice(model.NewId(), model.NewId()))
+	require.NoError(t, err)
+	require.NotEmpty(t, device.Id)
+
+	got, err := ss.MfaTrustedDevice().GetByTokenHash(device.TokenHash)
+	require.NoError(t, err)
+	assert.Equal(t, device, got)
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := newTestMfaTrustedDevice(model.NewId(), model.NewId())
+		invalid.TokenHash = ""
+		_, err := ss.MfaTrustedDevice().Save(invalid)
+		require.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.MfaTrustedDevice().GetByTokenHash(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testMfaTrustedDeviceSaveReplaces(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	deviceID := model.NewId()
+
+	first, err := ss.MfaTrustedDevice().Save(newTestMfaTrustedDevice(userID, deviceID))
+	require.NoError(t, err)
+
+	expired := newTestMfaTrustedDevice(userID, model.NewId())
+	expired.CreateAt = first.CreateAt - 2*24*60*60*1000
+	expired.ExpiresAt = first.CreateAt - 1
+	expired, err = ss.MfaTrustedDevice().Save(expired)
+	require.NoError(t, err)
+
+	other, err := ss.MfaTrustedDevice().Save(newTestMfaTrustedDevice(userID, model.NewId()))
+	require.NoError(t, err)
+
+	second, err := ss.MfaTrustedDevice().Save(newTestMfaTrustedDevice(userID, deviceID))
+	require.NoError(t, err)
+
+	var nfErr *store.ErrNotFound
+	_, err = ss.MfaTrustedDevice().GetByTokenHash(first.TokenHash)
+	require.ErrorAs(t, err, &nfErr)
+	_, err = ss.MfaTrustedDevice().GetByTokenHash(expired.TokenHash)
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = ss.MfaTrustedDevice().GetByTokenHash(second.TokenHash)
+	require.NoError(t, err)
+	_, err = ss.MfaTrustedDevice().GetByTokenHash(other.TokenHash)
+	require.NoError(t, err)
+
+	t.Run("browsers are not replaced", func(t *testing.T) {
+		firstBrowser, err := ss.MfaTrustedDevice().Save(newTestMfaTrustedDevice(userID, ""))
+		require.NoError(t, err)
+		secondBrowser, err := ss.MfaTrustedDevice().Save(newTestMfaTrustedDevice(userID, ""))
+		require.NoError(t, err)
+
+		_, err = ss.MfaTrustedDevice().GetByTokenHash(firstBrowser.TokenHash)
+		require.NoError(t, err)
+		_, err = ss.MfaTrustedDevice().GetByTokenHash(secondBrowser.TokenHash)
+		require.NoError(t, err)
+	})
+}
+
+func testMfaTrustedDeviceDeleteForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	device, err := ss.MfaTrustedDevice().Save(newTestMfaTrustedDevice(userID, model.NewId()))
+	require.NoError(t, err)
+	otherDevice, err := ss.MfaTrustedDevice().Save(newTestMfaTrustedDevice(model.NewId(), model.NewId()))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.MfaTrustedDevice().DeleteForUser(userID))
+
+	var nfErr *store.ErrNotFound
+	_, err = ss.MfaTrustedDevice().GetByTokenHash(device.TokenHash)
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = ss.MfaTrustedDevice().GetByTokenHash(otherDevice.TokenHash)
+	require.NoError(t, err)
+}
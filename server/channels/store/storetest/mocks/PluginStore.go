@@ -184,6 +184,36 @@ func (_m *PluginStore) List(pluginID string, page int, perPage int) ([]string, e
 	return r0, r1
 }
 
+// ListWithOptions provides a mock function with given fields: pluginID, options
+func (_m *PluginStore) ListWithOptions(pluginID string, options model.PluginKVListOptions) ([]*model.PluginKeyValue, error) {
+	ret := _m.Called(pluginID, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWithOptions")
+	}
+
+	var r0 []*model.PluginKeyValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, model.PluginKVListOptions) ([]*model.PluginKeyValue, error)); ok {
+		return rf(pluginID, options)
+	}
+	if rf, ok := ret.Get(0).(func(string, model.PluginKVListOptions) []*model.PluginKeyValue); ok {
+		r0 = rf(pluginID, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PluginKeyValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, model.PluginKVListOptions) error); ok {
+		r1 = rf(pluginID, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveOrUpdate provides a mock function with given fields: keyVal
 func (_m *PluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 	ret := _m.Called(keyVal)
@@ -214,6 +244,34 @@ func (_m *PluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.Plugin
 	return r0, r1
 }
 
+// SetBatch provides a mock function with given fields: pluginID, operations
+func (_m *PluginStore) SetBatch(pluginID string, operations []*model.PluginKVSetOperation) (bool, error) {
+	ret := _m.Called(pluginID, operations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetBatch")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []*model.PluginKVSetOperation) (bool, error)); ok {
+		return rf(pluginID, operations)
+	}
+	if rf, ok := ret.Get(0).(func(string, []*model.PluginKVSetOperation) bool); ok {
+		r0 = rf(pluginID, operations)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []*model.PluginKVSetOperation) error); ok {
+		r1 = rf(pluginID, operations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetWithOptions provides a mock function with given fields: pluginID, key, value, options
 func (_m *PluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 	ret := _m.Called(pluginID, key, value, options)
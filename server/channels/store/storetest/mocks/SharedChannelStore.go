@@ -712,6 +712,24 @@ func (_m *SharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*
 	return r0, r1
 }
 
+// UpdateRemoteChannelStateCursor provides a mock function with given fields: id, bookmarksSyncAt, channelInfoSyncAt
+func (_m *SharedChannelStore) UpdateRemoteChannelStateCursor(id string, bookmarksSyncAt int64, channelInfoSyncAt int64) error {
+	ret := _m.Called(id, bookmarksSyncAt, channelInfoSyncAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRemoteChannelStateCursor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, int64) error); ok {
+		r0 = rf(id, bookmarksSyncAt, channelInfoSyncAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateRemoteCursor provides a mock function with given fields: id, cursor
 func (_m *SharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
 	ret := _m.Called(id, cursor)
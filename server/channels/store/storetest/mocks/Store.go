@@ -686,6 +686,66 @@ func (_m *Store) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// MembershipRequest provides a mock function with no fields
+func (_m *Store) MembershipRequest() store.MembershipRequestStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MembershipRequest")
+	}
+
+	var r0 store.MembershipRequestStore
+	if rf, ok := ret.Get(0).(func() store.MembershipRequestStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MembershipRequestStore)
+		}
+	}
+
+	return r0
+}
+
+// MfaRecoveryCode provides a mock function with no fields
+func (_m *Store) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MfaRecoveryCode")
+	}
+
+	var r0 store.MfaRecoveryCodeStore
+	if rf, ok := ret.Get(0).(func() store.MfaRecoveryCodeStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MfaRecoveryCodeStore)
+		}
+	}
+
+	return r0
+}
+
+// MfaTrustedDevice provides a mock function with no fields
+func (_m *Store) MfaTrustedDevice() store.MfaTrustedDeviceStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MfaTrustedDevice")
+	}
+
+	var r0 store.MfaTrustedDeviceStore
+	if rf, ok := ret.Get(0).(func() store.MfaTrustedDeviceStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.MfaTrustedDeviceStore)
+		}
+	}
+
+	return r0
+}
+
 // NotifyAdmin provides a mock function with no fields
 func (_m *Store) NotifyAdmin() store.NotifyAdminStore {
 	ret := _m.Called()
@@ -1466,6 +1526,26 @@ func (_m *Store) UserTermsOfService() store.UserTermsOfServiceStore {
 	return r0
 }
 
+// WebAuthnCredential provides a mock function with no fields
+func (_m *Store) WebAuthnCredential() store.WebAuthnCredentialStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebAuthnCredential")
+	}
+
+	var r0 store.WebAuthnCredentialStore
+	if rf, ok := ret.Get(0).(func() store.WebAuthnCredentialStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.WebAuthnCredentialStore)
+		}
+	}
+
+	return r0
+}
+
 // Webhook provides a mock function with no fields
 func (_m *Store) Webhook() store.WebhookStore {
 	ret := _m.Called()
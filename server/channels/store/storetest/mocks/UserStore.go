@@ -269,6 +269,34 @@ func (_m *UserStore) Count(options model.UserCountOptions) (int64, error) {
 	return r0, r1
 }
 
+// CountByFieldFilter provides a mock function with given fields: filter
+func (_m *UserStore) CountByFieldFilter(filter *store.UserFieldFilter) (int64, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByFieldFilter")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*store.UserFieldFilter) (int64, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*store.UserFieldFilter) int64); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*store.UserFieldFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeactivateGuests provides a mock function with no fields
 func (_m *UserStore) DeactivateGuests() ([]string, error) {
 	ret := _m.Called()
@@ -657,6 +685,36 @@ func (_m *UserStore) GetByEmail(email string) (*model.User, error) {
 	return r0, r1
 }
 
+// GetByFieldFilter provides a mock function with given fields: filter, offset, limit
+func (_m *UserStore) GetByFieldFilter(filter *store.UserFieldFilter, offset int, limit int) ([]*model.User, error) {
+	ret := _m.Called(filter, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByFieldFilter")
+	}
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*store.UserFieldFilter, int, int) ([]*model.User, error)); ok {
+		return rf(filter, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(*store.UserFieldFilter, int, int) []*model.User); ok {
+		r0 = rf(filter, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*store.UserFieldFilter, int, int) error); ok {
+		r1 = rf(filter, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByRemoteID provides a mock function with given fields: remoteID
 func (_m *UserStore) GetByRemoteID(remoteID string) (*model.User, error) {
 	ret := _m.Called(remoteID)
@@ -951,6 +1009,36 @@ func (_m *UserStore) GetNewUsersForTeam(teamID string, offset int, limit int, vi
 	return r0, r1
 }
 
+// GetPasswordHasherCounts provides a mock function with no fields
+func (_m *UserStore) GetPasswordHasherCounts() (map[string]int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPasswordHasherCounts")
+	}
+
+	var r0 map[string]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (map[string]int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() map[string]int64); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProfileByGroupChannelIdsForUser provides a mock function with given fields: userID, channelIds
 func (_m *UserStore) GetProfileByGroupChannelIdsForUser(userID string, channelIds []string) (map[string][]*model.User, error) {
 	ret := _m.Called(userID, channelIds)
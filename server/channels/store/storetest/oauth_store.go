@@ -66,6 +66,7 @@ func testOAuthStoreGetApp(t *testing.T, rctx request.CTX, ss store.Store) {
 	a1.Name = "TestApp" + model.NewId()
 	a1.CallbackUrls = []string{"https://nowhere.com"}
 	a1.Homepage = "https://nowhere.com"
+	a1.Scopes = model.StringArray{model.OAuthScopePostsRead, model.OAuthScopeUsersRead}
 	_, err := ss.OAuth().SaveApp(&a1)
 	require.NoError(t, err)
 
@@ -73,8 +74,9 @@ func testOAuthStoreGetApp(t *testing.T, rctx request.CTX, ss store.Store) {
 	_, err = ss.OAuth().GetApp("fake0123456789abcderfgret1")
 	require.Error(t, err, "Should have failed. App does not exists")
 
-	_, err = ss.OAuth().GetApp(a1.Id)
+	app, err := ss.OAuth().GetApp(a1.Id)
 	require.NoError(t, err)
+	require.Equal(t, a1.Scopes, app.Scopes)
 
 	// Lets try and get the app from a user that hasn't created any apps
 	apps, err := ss.OAuth().GetAppByUser("fake0123456789abcderfgret1", 0, 1000)
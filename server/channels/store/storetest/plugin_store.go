@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,6 +25,8 @@ func TestPluginStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore)
 	t.Run("DeleteAllForPlugin", func(t *testing.T) { testPluginDeleteAllForPlugin(t, rctx, ss) })
 	t.Run("DeleteAllExpired", func(t *testing.T) { testPluginDeleteAllExpired(t, rctx, ss) })
 	t.Run("List", func(t *testing.T) { testPluginList(t, rctx, ss) })
+	t.Run("ListWithOptions", func(t *testing.T) { testPluginListWithOptions(t, rctx, ss) })
+	t.Run("SetBatch", func(t *testing.T) { testPluginSetBatch(t, rctx, ss) })
 }
 
 func setupKVs(t *testing.T, rctx request.CTX, ss store.Store) (string, func()) {
@@ -1358,3 +1361,210 @@ func testPluginList(t *testing.T, rctx request.CTX, ss store.Store) {
 		})
 	})
 }
+
+func testPluginListWithOptions(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("invalid options", func(t *testing.T) {
+		pluginID, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		_, err := ss.Plugin().ListWithOptions(pluginID, model.PluginKVListOptions{Limit: 0})
+		require.Error(t, err)
+
+		_, err = ss.Plugin().ListWithOptions(pluginID, model.PluginKVListOptions{Limit: model.PluginKVListLimitMax + 1})
+		require.Error(t, err)
+	})
+
+	t.Run("prefix and pagination", func(t *testing.T) {
+		pluginID, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		for _, key := range []string{"user_c", "user_a", "user_b", "user%", "user", "other_a"} {
+			_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: key, Value: []byte(key)})
+			require.NoError(t, err)
+		}
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "user_expired", Value: []byte("expired"), ExpireAt: 1})
+		require.NoError(t, err)
+
+		kvs, err := ss.Plugin().ListWithOptions(pluginID, model.PluginKVListOptions{Prefix: "user_", Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, kvs, 2)
+		assert.Equal(t, "user_a", kvs[0].Key)
+		assert.Equal(t, []byte("user_a"), kvs[0].Value)
+		assert.Equal(t, "user_b", kvs[1].Key)
+
+		kvs, err = ss.Plugin().ListWithOptions(pluginID, model.PluginKVListOptions{Prefix: "user_", AfterKey: "user_b", Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, kvs, 1)
+		assert.Equal(t, "user_c", kvs[0].Key)
+
+		// Wildcards in the prefix are matched literally.
+		kvs, err = ss.Plugin().ListWithOptions(pluginID, model.PluginKVListOptions{Prefix: "user%", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, kvs, 1)
+		assert.Equal(t, "user%", kvs[0].Key)
+	})
+
+	t.Run("no prefix", func(t *testing.T) {
+		_, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		// Ignore the pluginID setup by setupKVs
+		pluginID := model.NewId()
+		for _, key := range []string{"b", "a"} {
+			_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: key, Value: []byte(key)})
+			require.NoError(t, err)
+		}
+
+		kvs, err := ss.Plugin().ListWithOptions(pluginID, model.PluginKVListOptions{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, kvs, 2)
+		assert.Equal(t, "a", kvs[0].Key)
+		assert.Equal(t, "b", kvs[1].Key)
+	})
+
+	t.Run("keys are ordered byte by byte", func(t *testing.T) {
+		_, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		pluginID := model.NewId()
+		keys := []string{"b", "B", "a", "A", "é", "e", "É", "Z", "_", "ab", "aB"}
+		for _, key := range keys {
+			_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: key, Value: []byte(key)})
+			require.NoError(t, err)
+		}
+		slices.Sort(keys)
+
+		var listed []string
+		afterKey := ""
+		for {
+			kvs, err := ss.Plugin().ListWithOptions(pluginID, model.PluginKVListOptions{AfterKey: afterKey, Limit: 3})
+			require.NoError(t, err)
+			if len(kvs) == 0 {
+				break
+			}
+			for _, kv := range kvs {
+				listed = append(listed, kv.Key)
+			}
+			afterKey = kvs[len(kvs)-1].Key
+		}
+		assert.Equal(t, keys, listed)
+	})
+}
+
+func testPluginSetBatch(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("invalid operations", func(t *testing.T) {
+		pluginID, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		_, err := ss.Plugin().SetBatch(pluginID, nil)
+		require.Error(t, err)
+
+		_, err = ss.Plugin().SetBatch(pluginID, []*model.PluginKVSetOperation{
+			{Key: "key", Value: []byte("1")},
+			{Key: "key", Value: []byte("2")},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("all operations applied", func(t *testing.T) {
+		pluginID, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "existing", Value: []byte("old")})
+		require.NoError(t, err)
+		_, err = ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "deleted", Value: []byte("value")})
+		require.NoError(t, err)
+		_, err = ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "checked", Value: []byte("value")})
+		require.NoError(t, err)
+
+		ok, err := ss.Plugin().SetBatch(pluginID, []*model.PluginKVSetOperation{
+			{Key: "new", Value: []byte("new"), Options: model.PluginKVSetOptions{Atomic: true}},
+			{Key: "existing", Value: []byte("updated"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("old")}},
+			{Key: "deleted", Value: nil},
+			{Key: "checked", Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("value")}, CheckOnly: true},
+			{Key: "expiring", Value: []byte("expiring"), Options: model.PluginKVSetOptions{ExpireInSeconds: 60}},
+		})
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		kv, err := ss.Plugin().Get(pluginID, "new")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("new"), kv.Value)
+
+		kv, err = ss.Plugin().Get(pluginID, "existing")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("updated"), kv.Value)
+
+		_, err = ss.Plugin().Get(pluginID, "deleted")
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		kv, err = ss.Plugin().Get(pluginID, "checked")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), kv.Value)
+
+		kv, err = ss.Plugin().Get(pluginID, "expiring")
+		require.NoError(t, err)
+		assert.NotZero(t, kv.ExpireAt)
+	})
+
+	t.Run("failed comparison applies nothing", func(t *testing.T) {
+		pluginID, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "existing", Value: []byte("current")})
+		require.NoError(t, err)
+
+		ok, err := ss.Plugin().SetBatch(pluginID, []*model.PluginKVSetOperation{
+			{Key: "new", Value: []byte("new")},
+			{Key: "existing", Value: []byte("updated"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("stale")}},
+		})
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		_, err = ss.Plugin().Get(pluginID, "new")
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		kv, err := ss.Plugin().Get(pluginID, "existing")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("current"), kv.Value)
+	})
+
+	t.Run("failed check applies nothing", func(t *testing.T) {
+		pluginID, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "checked", Value: []byte("current")})
+		require.NoError(t, err)
+
+		ok, err := ss.Plugin().SetBatch(pluginID, []*model.PluginKVSetOperation{
+			{Key: "checked", Options: model.PluginKVSetOptions{Atomic: true}, CheckOnly: true},
+			{Key: "new", Value: []byte("new")},
+		})
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		_, err = ss.Plugin().Get(pluginID, "new")
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("atomic insert over an expired key", func(t *testing.T) {
+		pluginID, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "expired", Value: []byte("expired"), ExpireAt: 1})
+		require.NoError(t, err)
+
+		ok, err := ss.Plugin().SetBatch(pluginID, []*model.PluginKVSetOperation{
+			{Key: "expired", Value: []byte("new"), Options: model.PluginKVSetOptions{Atomic: true}},
+		})
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		kv, err := ss.Plugin().Get(pluginID, "expired")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("new"), kv.Value)
+	})
+}
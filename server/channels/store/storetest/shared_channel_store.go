@@ -32,6 +32,7 @@ func TestSharedChannelStore(t *testing.T, rctx request.CTX, ss store.Store, s Sq
 	t.Run("HasRemote", func(t *testing.T) { testHasRemote(t, rctx, ss) })
 	t.Run("GetRemoteForUser", func(t *testing.T) { testGetRemoteForUser(t, rctx, ss) })
 	t.Run("UpdateSharedChannelRemoteNextSyncAt", func(t *testing.T) { testUpdateSharedChannelRemoteCursor(t, rctx, ss) })
+	t.Run("UpdateSharedChannelRemoteChannelStateCursor", func(t *testing.T) { testUpdateSharedChannelRemoteChannelStateCursor(t, rctx, ss) })
 	t.Run("UpdateGlobalUserSyncCursor", func(t *testing.T) { testUpdateGlobalUserSyncCursor(t, rctx, ss) })
 	t.Run("DeleteSharedChannelRemote", func(t *testing.T) { testDeleteSharedChannelRemote(t, rctx, ss) })
 
@@ -933,6 +934,47 @@ func testUpdateSharedChannelRemoteCursor(t *testing.T, rctx request.CTX, ss stor
 	})
 }
 
+func testUpdateSharedChannelRemoteChannelStateCursor(t *testing.T, rctx request.CTX, ss store.Store) {
+	channel, err := createTestChannel(ss, rctx, "test_remote_update_channel_state_cursor")
+	require.NoError(t, err)
+
+	remote := &model.SharedChannelRemote{
+		ChannelId: channel.Id,
+		CreatorId: model.NewId(),
+		RemoteId:  model.NewId(),
+	}
+
+	remoteSaved, err := ss.SharedChannel().SaveRemote(remote)
+	require.NoError(t, err, "couldn't save remote", err)
+	require.Zero(t, remoteSaved.LastBookmarksSyncAt)
+	require.Zero(t, remoteSaved.LastChannelInfoSyncAt)
+
+	t.Run("Update both cursors", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteChannelStateCursor(remoteSaved.Id, 2000, 3000)
+		require.NoError(t, err)
+
+		r, err := ss.SharedChannel().GetRemote(remoteSaved.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(2000), r.LastBookmarksSyncAt)
+		require.Equal(t, int64(3000), r.LastChannelInfoSyncAt)
+	})
+
+	t.Run("Cursors only move forward", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteChannelStateCursor(remoteSaved.Id, 1000, 0)
+		require.NoError(t, err)
+
+		r, err := ss.SharedChannel().GetRemote(remoteSaved.Id)
+		require.NoError(t, err)
+		require.Equal(t, int64(2000), r.LastBookmarksSyncAt)
+		require.Equal(t, int64(3000), r.LastChannelInfoSyncAt)
+	})
+
+	t.Run("Update cursor for non-existent shared channel remote", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteChannelStateCursor(model.NewId(), 1000, 1000)
+		require.Error(t, err)
+	})
+}
+
 func testUpdateGlobalUserSyncCursor(t *testing.T, rctx request.CTX, ss store.Store) {
 	// Create a remote cluster first
 	rc := &model.RemoteCluster{
@@ -74,6 +74,10 @@ type Store struct {
 	RecapStore                      mocks.RecapStore
 	ReadReceiptStore                mocks.ReadReceiptStore
 	TemporaryPostStore              mocks.TemporaryPostStore
+	WebAuthnCredentialStore         mocks.WebAuthnCredentialStore
+	MfaRecoveryCodeStore            mocks.MfaRecoveryCodeStore
+	MfaTrustedDeviceStore           mocks.MfaTrustedDeviceStore
+	MembershipRequestStore          mocks.MembershipRequestStore
 }
 
 func (s *Store) Logger() mlog.LoggerIFace                      { return s.logger }
@@ -179,6 +183,18 @@ func (s *Store) ReadReceipt() store.ReadReceiptStore {
 func (s *Store) TemporaryPost() store.TemporaryPostStore {
 	return &s.TemporaryPostStore
 }
+func (s *Store) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return &s.WebAuthnCredentialStore
+}
+func (s *Store) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return &s.MfaRecoveryCodeStore
+}
+func (s *Store) MfaTrustedDevice() store.MfaTrustedDeviceStore {
+	return &s.MfaTrustedDeviceStore
+}
+func (s *Store) MembershipRequest() store.MembershipRequestStore {
+	return &s.MembershipRequestStore
+}
 func (s *Store) GetSchemaDefinition() (*model.SupportPacketDatabaseSchema, error) {
 	return &model.SupportPacketDatabaseSchema{
 		Tables: []model.DatabaseTable{},
@@ -234,5 +250,9 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.RecapStore,
 		&s.ReadReceiptStore,
 		&s.TemporaryPostStore,
+		&s.WebAuthnCredentialStore,
+		&s.MfaRecoveryCodeStore,
+		&s.MfaTrustedDeviceStore,
+		&s.MembershipRequestStore,
 	)
 }
@@ -25,6 +25,7 @@ func testUserAccessTokenSaveGetDelete(t *testing.T, rctx request.CTX, ss store.S
 		Token:       model.NewId(),
 		UserId:      model.NewId(),
 		Description: "testtoken",
+		Scopes:      model.StringArray{model.OAuthScopePostsRead},
 	}
 
 	s1 := &model.Session{}
@@ -44,6 +45,7 @@ func testUserAccessTokenSaveGetDelete(t *testing.T, rctx request.CTX, ss store.S
 	received, err2 := ss.UserAccessToken().GetByToken(uat.Token)
 	require.NoError(t, err2)
 	require.Equal(t, received.Token, uat.Token, "received incorrect token after save")
+	require.Equal(t, uat.Scopes, received.Scopes)
 
 	_, nErr = ss.UserAccessToken().GetByToken("notarealtoken")
 	require.Error(t, nErr, "should have failed on bad token")
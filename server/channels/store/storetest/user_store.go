@@ -42,6 +42,7 @@ func TestUserStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 
 	t.Run("IsEmpty", func(t *testing.T) { testIsEmpty(t, rctx, ss) })
 	t.Run("Count", func(t *testing.T) { testCount(t, rctx, ss) })
+	t.Run("GetByFieldFilter", func(t *testing.T) { testUserStoreGetByFieldFilter(t, rctx, ss) })
 	t.Run("AnalyticsActiveCount", func(t *testing.T) { testUserStoreAnalyticsActiveCount(t, rctx, ss, s) })
 	t.Run("AnalyticsActiveCountForPeriod", func(t *testing.T) { testUserStoreAnalyticsActiveCountForPeriod(t, rctx, ss, s) })
 	t.Run("AnalyticsGetInactiveUsersCount", func(t *testing.T) { testUserStoreAnalyticsGetInactiveUsersCount(t, rctx, ss) })
@@ -72,6 +73,7 @@ func TestUserStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("GetByUsername", func(t *testing.T) { testUserStoreGetByUsername(t, rctx, ss) })
 	t.Run("GetForLogin", func(t *testing.T) { testUserStoreGetForLogin(t, rctx, ss) })
 	t.Run("UpdatePassword", func(t *testing.T) { testUserStoreUpdatePassword(t, rctx, ss) })
+	t.Run("GetPasswordHasherCounts", func(t *testing.T) { testUserStoreGetPasswordHasherCounts(t, rctx, ss) })
 	t.Run("Delete", func(t *testing.T) { testUserStoreDelete(t, rctx, ss) })
 	t.Run("UpdateAuthData", func(t *testing.T) { testUserStoreUpdateAuthData(t, rctx, ss) })
 	t.Run("ResetAuthDataToEmailForUsers", func(t *testing.T) { testUserStoreResetAuthDataToEmailForUsers(t, rctx, ss) })
@@ -2382,6 +2384,47 @@ func testUserStoreUpdatePassword(t *testing.T, rctx request.CTX, ss store.Store)
 	require.Equal(t, user.Password, hashedPassword, "Password was not updated correctly")
 }
 
+func testUserStoreGetPasswordHasherCounts(t *testing.T, rctx request.CTX, ss store.Store) {
+	const (
+		bcryptPrefix   = "$2a"
+		pbkdf2Prefix   = "$pbkdf2$f=SHA256,w=600000,l=32"
+		argon2idPrefix = "$argon2id$v=19$m=19456,t=2,p=1"
+	)
+
+	before, err := ss.User().GetPasswordHasherCounts()
+	require.NoError(t, err)
+
+	for _, password := range []string{
+		"$2a$10$z0OlN1MpiLVlLTyE1xtEjOJ6/xV95RAwwIUaYKQBAqoeyvPgLEnUa",
+		pbkdf2Prefix + "$5Zq8TvET7nMrXof49Rp4Sw$d0Mx8467kv+3ylbGrkyu4jTd8O8SP51k4s1RuWb9S/o",
+		argon2idPrefix + "$5Zq8TvET7nMrXof49Rp4Sw$d0Mx8467kv+3ylbGrkyu4jTd8O8SP51k4s1RuWb9S/o",
+		argon2idPrefix + "$Zq8TvET7nMrXof49Rp4Sw5$0Mx8467kv+3ylbGrkyu4jTd8O8SP51k4s1RuWb9S/od",
+		"",
+	} {
+		u := &model.User{Email: MakeEmail(), Username: model.NewUsername()}
+		_, err = ss.User().Save(rctx, u)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u.Id)) }()
+
+		require.NoError(t, ss.User().UpdatePassword(u.Id, password))
+	}
+
+	// Deactivated users are not counted
+	deactivated := &model.User{Email: MakeEmail(), Username: model.NewUsername(), DeleteAt: model.GetMillis()}
+	_, err = ss.User().Save(rctx, deactivated)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, deactivated.Id)) }()
+	require.NoError(t, ss.User().UpdatePassword(deactivated.Id, pbkdf2Prefix+"$5Zq8TvET7nMrXof49Rp4Sw$d0Mx8467kv+3ylbGrkyu4jTd8O8SP51k4s1RuWb9S/o"))
+
+	after, err := ss.User().GetPasswordHasherCounts()
+	require.NoError(t, err)
+
+	assert.Equal(t, before[bcryptPrefix]+1, after[bcryptPrefix])
+	assert.Equal(t, before[pbkdf2Prefix]+1, after[pbkdf2Prefix])
+	assert.Equal(t, before[argon2idPrefix]+2, after[argon2idPrefix])
+	assert.NotContains(t, after, "")
+}
+
 func testUserStoreDelete(t *testing.T, rctx request.CTX, ss store.Store) {
 	u1 := &model.User{}
 	u1.Email = MakeEmail()
@@ -3956,6 +3999,89 @@ func testUserStoreSearchNotInGroup(t *testing.T, rctx request.CTX, ss store.Stor
 	}
 }
 
+func testUserStoreGetByFieldFilter(t *testing.T, rctx request.CTX, ss store.Store) {
+	prefix := "ff" + model.NewId()[:8]
+	saveUser := func(user *model.User) *model.User {
+		t.Helper()
+		user.Email = MakeEmail()
+		saved, err := ss.User().Save(rctx, user)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, ss.User().PermanentDelete(rctx, saved.Id)) })
+		return saved
+	}
+
+	u1 := saveUser(&model.User{Username: prefix + "a", FirstName: "Barbara", LastName: "Jensen", AuthService: model.UserAuthServiceSaml, AuthData: model.NewPointer("Ext-1")})
+	u2 := saveUser(&model.User{Username: prefix + "b", LastName: "Smith"})
+	u2.DeleteAt = model.GetMillis()
+	_, err := ss.User().Update(rctx, u2, true)
+	require.NoError(t, err)
+
+	bot := saveUser(&model.User{Username: prefix + "bot"})
+	_, err = ss.Bot().Save(&model.Bot{UserId: bot.Id, Username: bot.Username, OwnerId: u1.Id})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, ss.Bot().PermanentDelete(bot.Id)) })
+
+	saveUser(&model.User{Username: prefix + "remote", RemoteId: model.NewPointer(model.NewId())})
+
+	usernames := func(users []*model.User) []string {
+		var names []string
+		for _, user := range users {
+			names = append(names, user.Username)
+		}
+		return names
+	}
+	startsWithPrefix := &store.UserFieldFilter{Op: store.UserFieldFilterStartsWith, Field: "Username", Value: prefix}
+
+	t.Run("excludes bots and remote users", func(t *testing.T) {
+		users, err := ss.User().GetByFieldFilter(startsWithPrefix, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{u1.Username, u2.Username}, usernames(users))
+
+		count, err := ss.User().CountByFieldFilter(startsWithPrefix)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		users, err := ss.User().GetByFieldFilter(startsWithPrefix, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{u2.Username}, usernames(users))
+	})
+
+	t.Run("combines filters", func(t *testing.T) {
+		filter := &store.UserFieldFilter{Op: store.UserFieldFilterAnd, Filters: []*store.UserFieldFilter{
+			startsWithPrefix,
+			{Op: store.UserFieldFilterEquals, Field: store.UserFieldActive, Value: true},
+		}}
+		users, err := ss.User().GetByFieldFilter(filter, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{u1.Username}, usernames(users))
+
+		filter = &store.UserFieldFilter{Op: store.UserFieldFilterOr, Filters: []*store.UserFieldFilter{
+			{Op: store.UserFieldFilterEquals, Field: "LastName", Value: "SMITH"},
+			{Op: store.UserFieldFilterEquals, Field: "AuthData", Value: "ext-1"},
+		}}
+		count, err := ss.User().CountByFieldFilter(&store.UserFieldFilter{Op: store.UserFieldFilterAnd, Filters: []*store.UserFieldFilter{startsWithPrefix, filter}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("compares substrings", func(t *testing.T) {
+		users, err := ss.User().GetByFieldFilter(&store.UserFieldFilter{Op: store.UserFieldFilterContains, Field: "Username", Value: prefix[2:] + "a"}, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{u1.Username}, usernames(users))
+
+		users, err = ss.User().GetByFieldFilter(&store.UserFieldFilter{Op: store.UserFieldFilterEndsWith, Field: "Username", Value: prefix[2:] + "b"}, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []string{u2.Username}, usernames(users))
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		_, err := ss.User().GetByFieldFilter(&store.UserFieldFilter{Op: store.UserFieldFilterEquals, Field: "Password", Value: "x"}, 0, 10)
+		require.Error(t, err)
+	})
+}
+
 func testCount(t *testing.T, rctx request.CTX, ss store.Store) {
 	// Regular
 	teamID := model.NewId()
@@ -0,0 +1,127 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestWebAuthnCredentialStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("SaveAndGet", func(t *testing.T) { testWebAuthnCredentialSaveAndGet(t, rctx, ss) })
+	t.Run("GetForUser", func(t *testing.T) { testWebAuthnCredentialGetForUser(t, rctx, ss) })
+	t.Run("UpdateLastUsed", func(t *testing.T) { testWebAuthnCredentialUpdateLastUsed(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testWebAuthnCredentialDelete(t, rctx, ss) })
+}
+
+func newTestWebAuthnCredential(userID string) *model.WebAuthnCredential {
+	return &model.WebAuthnCredential{
+		UserId:       userID,
+		Name:         "Security key",
+		CredentialId: model.NewId() + model.NewId(),
+		PublicKey:    []byte{0xa5, 0x01, 0x02},
+		SignCount:    1,
+		Transports:   model.StringArray{"usb", "nfc"},
+	}
+}
+
+func testWebAuthnCredentialSaveAndGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	credential, err := ss.WebAuthnCredential().Save(newTestWebAuthnCredential(model.NewId()))
+	require.NoError(t, err)
+	require.NotEmpty(t, credential.Id)
+
+	got, err := ss.WebAuthnCredential().Get(credential.Id)
+	require.NoError(t, err)
+	assert.Equal(t, credential, got)
+
+	got, err = ss.WebAuthnCredential().GetByCredentialId(credential.CredentialId)
+	require.NoError(t, err)
+	assert.Equal(t, credential.Id, got.Id)
+
+	t.Run("duplicate credential id", func(t *testing.T) {
+		duplicate := newTestWebAuthnCredential(model.NewId())
+		duplicate.CredentialId = credential.CredentialId
+		_, err := ss.WebAuthnCredential().Save(duplicate)
+		var conflictErr *store.ErrConflict
+		require.ErrorAs(t, err, &conflictErr)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := newTestWebAuthnCredential(model.NewId())
+		invalid.PublicKey = nil
+		_, err := ss.WebAuthnCredential().Save(invalid)
+		require.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.WebAuthnCredential().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+
+		_, err = ss.WebAuthnCredential().GetByCredentialId(model.NewId())
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testWebAuthnCredentialGetForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	first, err := ss.WebAuthnCredential().Save(newTestWebAuthnCredential(userID))
+	require.NoError(t, err)
+	second := newTestWebAuthnCredential(userID)
+	second.CreateAt = first.CreateAt + 1
+	second, err = ss.WebAuthnCredential().Save(second)
+	require.NoError(t, err)
+	_, err = ss.WebAuthnCredential().Save(newTestWebAuthnCredential(model.NewId()))
+	require.NoError(t, err)
+
+	credentials, err := ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, credentials, 2)
+	assert.Equal(t, first.Id, credentials[0].Id)
+	assert.Equal(t, second.Id, credentials[1].Id)
+
+	credentials, err = ss.WebAuthnCredential().GetForUser(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+}
+
+func testWebAuthnCredentialUpdateLastUsed(t *testing.T, rctx request.CTX, ss store.Store) {
+	credential, err := ss.WebAuthnCredential().Save(newTestWebAuthnCredential(model.NewId()))
+	require.NoError(t, err)
+
+	lastUsedAt := model.GetMillis()
+	err = ss.WebAuthnCredential().UpdateLastUsed(credential.Id, 42, lastUsedAt)
+	require.NoError(t, err)
+
+	got, err := ss.WebAuthnCredential().Get(credential.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), got.SignCount)
+	assert.Equal(t, lastUsedAt, got.LastUsedAt)
+}
+
+func testWebAuthnCredentialDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	first, err := ss.WebAuthnCredential().Save(newTestWebAuthnCredential(userID))
+	require.NoError(t, err)
+	_, err = ss.WebAuthnCredential().Save(newTestWebAuthnCredential(userID))
+	require.NoError(t, err)
+
+	err = ss.WebAuthnCredential().Delete(first.Id)
+	require.NoError(t, err)
+	credentials, err := ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, credentials, 1)
+
+	err = ss.WebAuthnCredential().DeleteForUser(userID)
+	require.NoError(t, err)
+	credentials, err = ss.WebAuthnCredential().GetForUser(userID)
+	require.NoError(t, err)
+	require.Empty(t, credentials)
+}
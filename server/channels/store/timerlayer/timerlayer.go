@@ -40,6 +40,9 @@ type TimerLayer struct {
 	JobStore                        store.JobStore
 	LicenseStore                    store.LicenseStore
 	LinkMetadataStore               store.LinkMetadataStore
+	MembershipRequestStore          store.MembershipRequestStore
+	MfaRecoveryCodeStore            store.MfaRecoveryCodeStore
+	MfaTrustedDeviceStore           store.MfaTrustedDeviceStore
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
@@ -74,6 +77,7 @@ type TimerLayer struct {
 	UserStore                       store.UserStore
 	UserAccessTokenStore            store.UserAccessTokenStore
 	UserTermsOfServiceStore         store.UserTermsOfServiceStore
+	WebAuthnCredentialStore         store.WebAuthnCredentialStore
 	WebhookStore                    store.WebhookStore
 }
 
@@ -161,6 +165,18 @@ func (s *TimerLayer) LinkMetadata() store.LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *TimerLayer) MembershipRequest() store.MembershipRequestStore {
+	return s.MembershipRequestStore
+}
+
+func (s *TimerLayer) MfaRecoveryCode() store.MfaRecoveryCodeStore {
+	return s.MfaRecoveryCodeStore
+}
+
+func (s *TimerLayer) MfaTrustedDevice() store.MfaTrustedDeviceStore {
+	return s.MfaTrustedDeviceStore
+}
+
 func (s *TimerLayer) NotifyAdmin() store.NotifyAdminStore {
 	return s.NotifyAdminStore
 }
@@ -297,6 +313,10 @@ func (s *TimerLayer) UserTermsOfService() store.UserTermsOfServiceStore {
 	return s.UserTermsOfServiceStore
 }
 
+func (s *TimerLayer) WebAuthnCredential() store.WebAuthnCredentialStore {
+	return s.WebAuthnCredentialStore
+}
+
 func (s *TimerLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
@@ -406,6 +426,21 @@ type TimerLayerLinkMetadataStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerMembershipRequestStore struct {
+	store.MembershipRequestStore
+	Root *TimerLayer
+}
+
+type TimerLayerMfaRecoveryCodeStore struct {
+	store.MfaRecoveryCodeStore
+	Root *TimerLayer
+}
+
+type TimerLayerMfaTrustedDeviceStore struct {
+	store.MfaTrustedDeviceStore
+	Root *TimerLayer
+}
+
 type TimerLayerNotifyAdminStore struct {
 	store.NotifyAdminStore
 	Root *TimerLayer
@@ -576,6 +611,11 @@ type TimerLayerUserTermsOfServiceStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerWebAuthnCredentialStore struct {
+	store.WebAuthnCredentialStore
+	Root *TimerLayer
+}
+
 type TimerLayerWebhookStore struct {
 	store.WebhookStore
 	Root *TimerLayer
@@ -5732,6 +5772,230 @@ func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 	return result, err
 }
 
+func (s *TimerLayerMembershipRequestStore) Get(id string) (*model.MembershipRequest, error) {
+	start := time.Now()
+
+	result, err := s.MembershipRequestStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MembershipRequestStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerMembershipRequestStore) GetAll(opts model.MembershipRequestGetOptions) ([]*model.MembershipRequest, error) {
+	start := time.Now()
+
+	result, err := s.MembershipRequestStore.GetAll(opts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MembershipRequestStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerMembershipRequestStore) GetRequestableChannels(teamID string, userID string, offset int, limit int) (model.ChannelList, error) {
+	start := time.Now()
+
+	result, err := s.MembershipRequestStore.GetRequestableChannels(teamID, userID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MembershipRequestStore.GetRequestableChannels", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerMembershipRequestStore) GetRequestableTeams(userID string, offset int, limit int) ([]*model.Team, error) {
+	start := time.Now()
+
+	result, err := s.MembershipRequestStore.GetRequestableTeams(userID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MembershipRequestStore.GetRequestableTeams", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerMembershipRequestStore) Save(request *model.MembershipRequest) (*model.MembershipRequest, error) {
+	start := time.Now()
+
+	result, err := s.MembershipRequestStore.Save(request)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MembershipRequestStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerMembershipRequestStore) UpdatePostIds(id string, postIDs []string) error {
+	start := time.Now()
+
+	err := s.MembershipRequestStore.UpdatePostIds(id, postIDs)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MembershipRequestStore.UpdatePostIds", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerMembershipRequestStore) UpdateStatus(request *model.MembershipRequest) error {
+	start := time.Now()
+
+	err := s.MembershipRequestStore.UpdateStatus(request)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MembershipRequestStore.UpdateStatus", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerMfaRecoveryCodeStore) DeleteForUser(userID string) error {
+	start := time.Now()
+
+	err := s.MfaRecoveryCodeStore.DeleteForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.DeleteForUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerMfaRecoveryCodeStore) GetUnusedForUser(userID string) ([]*model.MfaRecoveryCode, error) {
+	start := time.Now()
+
+	result, err := s.MfaRecoveryCodeStore.GetUnusedForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.GetUnusedForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerMfaRecoveryCodeStore) MarkUsed(id string, usedAt int64) error {
+	start := time.Now()
+
+	err := s.MfaRecoveryCodeStore.MarkUsed(id, usedAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.MarkUsed", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerMfaRecoveryCodeStore) SaveForUser(userID string, codes []*model.MfaRecoveryCode) error {
+	start := time.Now()
+
+	err := s.MfaRecoveryCodeStore.SaveForUser(userID, codes)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaRecoveryCodeStore.SaveForUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerMfaTrustedDeviceStore) DeleteForUser(userID string) error {
+	start := time.Now()
+
+	err := s.MfaTrustedDeviceStore.DeleteForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaTrustedDeviceStore.DeleteForUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerMfaTrustedDeviceStore) GetByTokenHash(tokenHash string) (*model.MfaTrustedDevice, error) {
+	start := time.Now()
+
+	result, err := s.MfaTrustedDeviceStore.GetByTokenHash(tokenHash)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaTrustedDeviceStore.GetByTokenHash", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerMfaTrustedDeviceStore) Save(device *model.MfaTrustedDevice) (*model.MfaTrustedDevice, error) {
+	start := time.Now()
+
+	result, err := s.MfaTrustedDeviceStore.Save(device)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("MfaTrustedDeviceStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
 	start := time.Now()
 
@@ -6340,6 +6604,22 @@ func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]
 	return result, err
 }
 
+func (s *TimerLayerPluginStore) ListWithOptions(pluginID string, options model.PluginKVListOptions) ([]*model.PluginKeyValue, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.ListWithOptions(pluginID, options)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.ListWithOptions", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 	start := time.Now()
 
@@ -6356,6 +6636,22 @@ func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*mod
 	return result, err
 }
 
+func (s *TimerLayerPluginStore) SetBatch(pluginID string, operations []*model.PluginKVSetOperation) (bool, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.SetBatch(pluginID, operations)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetBatch", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 	start := time.Now()
 
@@ -10241,6 +10537,22 @@ func (s *TimerLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelR
 	return result, err
 }
 
+func (s *TimerLayerSharedChannelStore) UpdateRemoteChannelStateCursor(id string, bookmarksSyncAt int64, channelInfoSyncAt int64) error {
+	start := time.Now()
+
+	err := s.SharedChannelStore.UpdateRemoteChannelStateCursor(id, bookmarksSyncAt, channelInfoSyncAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemoteChannelStateCursor", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
 	start := time.Now()
 
@@ -12348,6 +12660,22 @@ func (s *TimerLayerUserStore) Count(options model.UserCountOptions) (int64, erro
 	return result, err
 }
 
+func (s *TimerLayerUserStore) CountByFieldFilter(filter *store.UserFieldFilter) (int64, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.CountByFieldFilter(filter)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.CountByFieldFilter", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) DeactivateGuests() ([]string, error) {
 	start := time.Now()
 
@@ -12556,6 +12884,22 @@ func (s *TimerLayerUserStore) GetByEmail(email string) (*model.User, error) {
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetByFieldFilter(filter *store.UserFieldFilter, offset int, limit int) ([]*model.User, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.GetByFieldFilter(filter, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetByFieldFilter", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) GetByRemoteID(remoteID string) (*model.User, error) {
 	start := time.Now()
 
@@ -12732,6 +13076,22 @@ func (s *TimerLayerUserStore) GetNewUsersForTeam(teamID string, offset int, limi
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetPasswordHasherCounts() (map[string]int64, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.GetPasswordHasherCounts()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetPasswordHasherCounts", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) GetProfileByGroupChannelIdsForUser(userID string, channelIds []string) (map[string][]*model.User, error) {
 	start := time.Now()
 
@@ -13769,6 +14129,118 @@ func (s *TimerLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserT
 	return result, err
 }
 
+func (s *TimerLayerWebAuthnCredentialStore) Delete(id string) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.Delete(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) DeleteForUser(userID string) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.DeleteForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.DeleteForUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) Get(id string) (*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) GetByCredentialId(credentialID string) (*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.GetByCredentialId(credentialID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.GetByCredentialId", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) GetForUser(userID string) ([]*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.GetForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) Save(credential *model.WebAuthnCredential) (*model.WebAuthnCredential, error) {
+	start := time.Now()
+
+	result, err := s.WebAuthnCredentialStore.Save(credential)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWebAuthnCredentialStore) UpdateLastUsed(id string, signCount int64, lastUsedAt int64) error {
+	start := time.Now()
+
+	err := s.WebAuthnCredentialStore.UpdateLastUsed(id, signCount, lastUsedAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WebAuthnCredentialStore.UpdateLastUsed", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerWebhookStore) AnalyticsIncomingCount(teamID string, userID string) (int64, error) {
 	start := time.Now()
 
@@ -14258,6 +14730,9 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &TimerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.MembershipRequestStore = &TimerLayerMembershipRequestStore{MembershipRequestStore: childStore.MembershipRequest(), Root: &newStore}
+	newStore.MfaRecoveryCodeStore = &TimerLayerMfaRecoveryCodeStore{MfaRecoveryCodeStore: childStore.MfaRecoveryCode(), Root: &newStore}
+	newStore.MfaTrustedDeviceStore = &TimerLayerMfaTrustedDeviceStore{MfaTrustedDeviceStore: childStore.MfaTrustedDevice(), Root: &newStore}
 	newStore.NotifyAdminStore = &TimerLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &TimerLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
@@ -14292,6 +14767,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.UserStore = &TimerLayerUserStore{UserStore: childStore.User(), Root: &newStore}
 	newStore.UserAccessTokenStore = &TimerLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &TimerLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebAuthnCredentialStore = &TimerLayerWebAuthnCredentialStore{WebAuthnCredentialStore: childStore.WebAuthnCredential(), Root: &newStore}
 	newStore.WebhookStore = &TimerLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
 	return &newStore
 }
@@ -141,6 +141,19 @@ func (c *Context) SessionRequired() {
 	}
 }
 
+// OAuthScopeRequired denies sessions restricted to OAuth scopes that don't include the given scope,
+// or any scope when empty.
+func (c *Context) OAuthScopeRequired(scope string) {
+	session := c.AppContext.Session()
+	if len(session.GetOAuthScopes()) == 0 {
+		return
+	}
+
+	if scope == "" || !session.HasOAuthScope(scope) {
+		c.Err = model.NewAppError("", "api.context.oauth_scope.app_error", nil, "required_scope="+scope, http.StatusForbidden)
+	}
+}
+
 func (c *Context) CloudKeyRequired() {
 	if license := c.App.Channels().License(); license == nil || !license.IsCloud() || c.AppContext.Session().Props[model.SessionPropType] != model.SessionTypeCloudKey {
 		c.Err = model.NewAppError("", "api.context.session_expired.app_error", nil, "TokenRequired", http.StatusUnauthorized)
@@ -779,6 +792,17 @@ func (c *Context) RequireRecapId() *Context {
 	return c
 }
 
+func (c *Context) RequireMembershipRequestId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.MembershipRequestId) {
+		c.SetInvalidURLParam("membership_request_id")
+	}
+	return c
+}
+
 func (c *Context) GetRemoteID(r *http.Request) string {
 	return r.Header.Get(model.HeaderRemoteclusterId)
 }
@@ -79,6 +79,10 @@ type Handler struct {
 	DisableWhenBusy           bool
 	FileAPI                   bool
 
+	// OAuthScope is the scope sessions restricted to OAuth scopes need to call the handler. Such
+	// sessions can't call handlers without one.
+	OAuthScope string
+
 	cspShaDirective string
 }
 
@@ -256,6 +260,11 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	if token != "" && tokenLocation != app.TokenLocationCloudHeader && tokenLocation != app.TokenLocationRemoteClusterHeader {
 		session, err := c.App.GetSession(token)
+		if err == nil {
+			if err = c.App.CheckSessionIPSubnet(c.AppContext, session); err != nil {
+				session = nil
+			}
+		}
 
 		if err != nil {
 			c.Logger.Info("Invalid session", mlog.Err(err))
@@ -328,6 +337,10 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		c.MfaRequired()
 	}
 
+	if c.Err == nil && c.AppContext.Session().Id != "" {
+		c.OAuthScopeRequired(h.OAuthScope)
+	}
+
 	if c.Err == nil && h.DisableWhenBusy && c.App.Srv().Platform().Busy.IsBusy() {
 		c.SetServerBusyError()
 	}
@@ -37,20 +37,22 @@ func (w *Web) InitOAuth() {
 	w.MainRouter.Handle(model.OAuthAuthorizeEndpoint, w.APISessionRequired(authorizeOAuthApp)).Methods(http.MethodPost)
 	w.MainRouter.Handle(model.OAuthDeauthorizeEndpoint, w.APISessionRequired(deauthorizeOAuthApp)).Methods(http.MethodPost)
 	w.MainRouter.Handle(model.OAuthAccessTokenEndpoint, w.APIHandlerTrustRequester(getAccessToken)).Methods(http.MethodPost)
+	w.MainRouter.Handle(model.OAuthIntrospectEndpoint, w.APIHandlerTrustRequester(introspectOAuthToken)).Methods(http.MethodPost)
+	w.MainRouter.Handle(model.OAuthRevokeEndpoint, w.APIHandlerTrustRequester(revokeOAuthToken)).Methods(http.MethodPost)
 
 	// API version independent OAuth as a client endpoints
-	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9]+}/complete", w.APIHandler(completeOAuth)).Methods(http.MethodGet)
-	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9]+}/login", w.APIHandler(loginWithOAuth)).Methods(http.MethodGet)
-	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9]+}/mobile_login", w.APIHandler(mobileLoginWithOAuth)).Methods(http.MethodGet)
-	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9]+}/signup", w.APIHandler(signupWithOAuth)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9_]+}/complete", w.APIHandler(completeOAuth)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9_]+}/login", w.APIHandler(loginWithOAuth)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9_]+}/mobile_login", w.APIHandler(mobileLoginWithOAuth)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9_]+}/signup", w.APIHandler(signupWithOAuth)).Methods(http.MethodGet)
 
 	// Intune MAM authentication endpoint
 	w.MainRouter.Handle("/oauth/intune", w.APIHandler(loginByIntune)).Methods(http.MethodPost)
 
 	// Old endpoints for backwards compatibility, needed to not break SSO for any old setups
-	w.MainRouter.Handle("/api/v3/oauth/{service:[A-Za-z0-9]+}/complete", w.APIHandler(completeOAuth)).Methods(http.MethodGet)
-	w.MainRouter.Handle("/signup/{service:[A-Za-z0-9]+}/complete", w.APIHandler(completeOAuth)).Methods(http.MethodGet)
-	w.MainRouter.Handle("/login/{service:[A-Za-z0-9]+}/complete", w.APIHandler(completeOAuth)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/api/v3/oauth/{service:[A-Za-z0-9_]+}/complete", w.APIHandler(completeOAuth)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/signup/{service:[A-Za-z0-9_]+}/complete", w.APIHandler(completeOAuth)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/login/{service:[A-Za-z0-9_]+}/complete", w.APIHandler(completeOAuth)).Methods(http.MethodGet)
 	w.MainRouter.Handle("/api/v4/oauth_test", w.APISessionRequired(testHandler)).Methods(http.MethodGet)
 }
 
@@ -251,6 +253,7 @@ func getAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 			c.Err = model.NewAppError("getAccessToken", "api.oauth.get_access_token.missing_refresh_token.app_error", nil, "", http.StatusBadRequest)
 			return
 		}
+	case model.GrantTypeClientCredentials:
 	default:
 		c.Err = model.NewAppError("getAccessToken", "api.oauth.get_access_token.bad_grant.app_error", nil, "", http.StatusBadRequest)
 		return
@@ -278,7 +281,13 @@ func getAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("client_id", clientId)
 	c.LogAudit("attempt")
 
-	accessRsp, err := c.App.GetOAuthAccessTokenForCodeFlow(c.AppContext, clientId, grantType, redirectURI, code, secret, refreshToken, codeVerifier, resource)
+	var accessRsp *model.AccessResponse
+	var err *model.AppError
+	if grantType == model.GrantTypeClientCredentials {
+		accessRsp, err = c.App.GetOAuthAccessTokenForClientCredentials(c.AppContext, clientId, secret, r.FormValue("scope"), resource)
+	} else {
+		accessRsp, err = c.App.GetOAuthAccessTokenForCodeFlow(c.AppContext, clientId, grantType, redirectURI, code, secret, refreshToken, codeVerifier, resource)
+	}
 	if err != nil {
 		c.Err = err
 		return
@@ -296,6 +305,54 @@ func getAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// introspectOAuthToken implements the token introspection endpoint of RFC 7662.
+func introspectOAuthToken(c *Context, w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		c.Err = model.NewAppError("introspectOAuthToken", "api.oauth.get_access_token.bad_request.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	introspection, err := c.App.IntrospectOAuthToken(c.AppContext, r.FormValue("client_id"), r.FormValue("client_secret"), r.FormValue("token"), r.FormValue("token_type_hint"))
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	if err := json.NewEncoder(w).Encode(introspection); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
+// revokeOAuthToken implements the token revocation endpoint of RFC 7009.
+func revokeOAuthToken(c *Context, w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		c.Err = model.NewAppError("revokeOAuthToken", "api.oauth.get_access_token.bad_request.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	clientId := r.FormValue("client_id")
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRevokeOAuthToken, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("client_id", clientId)
+	auditRec.AddMeta("token_type_hint", r.FormValue("token_type_hint"))
+
+	if err := c.App.RevokeOAuthToken(c.AppContext, clientId, r.FormValue("client_secret"), r.FormValue("token"), r.FormValue("token_type_hint")); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	ReturnStatusOK(w)
+}
+
 func completeOAuth(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireService()
 	if c.Err != nil {
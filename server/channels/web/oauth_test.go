@@ -399,6 +399,195 @@ func TestOAuthAccessToken(t *testing.T) {
 	apiClient.ClearOAuthToken()
 }
 
+func TestOAuthClientCredentials(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oauthApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "TestApp" + model.NewId(),
+		Homepage:     "https://nowhere.com",
+		CallbackUrls: []string{"https://nowhere.com"},
+		CreatorId:    th.SystemAdminUser.Id,
+		ClientSecret: model.NewId(),
+		Scopes:       model.StringArray{model.OAuthScopePostsWrite},
+	})
+	require.Nil(t, appErr)
+
+	data := url.Values{"grant_type": []string{model.GrantTypeClientCredentials}, "client_id": []string{oauthApp.Id}, "client_secret": []string{oauthApp.ClientSecret}}
+
+	t.Run("untrusted app", func(t *testing.T) {
+		_, resp, err := apiClient.GetOAuthAccessToken(context.Background(), data)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	oauthApp.IsTrusted = true
+	_, appErr = th.App.UpdateOAuthApp(oauthApp, oauthApp)
+	require.Nil(t, appErr)
+
+	t.Run("wrong secret", func(t *testing.T) {
+		badData := url.Values{"grant_type": []string{model.GrantTypeClientCredentials}, "client_id": []string{oauthApp.Id}, "client_secret": []string{model.NewId()}}
+		_, resp, err := apiClient.GetOAuthAccessToken(context.Background(), badData)
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+
+	t.Run("scope outside of the app's scopes", func(t *testing.T) {
+		scopedData := url.Values{"grant_type": []string{model.GrantTypeClientCredentials}, "client_id": []string{oauthApp.Id}, "client_secret": []string{oauthApp.ClientSecret}, "scope": []string{model.OAuthScopeUsersRead}}
+		_, resp, err := apiClient.GetOAuthAccessToken(context.Background(), scopedData)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("token acts as the bot of the app", func(t *testing.T) {
+		rsp, _, err := apiClient.GetOAuthAccessToken(context.Background(), data)
+		require.NoError(t, err)
+		require.NotEmpty(t, rsp.AccessToken)
+		require.Empty(t, rsp.RefreshToken)
+		require.Equal(t, model.OAuthScopePostsWrite, rsp.Scope)
+
+		session, appErr := th.App.GetSession(rsp.AccessToken)
+		require.Nil(t, appErr)
+		require.True(t, session.IsBotUser())
+
+		bot, appErr := th.App.GetBot(th.Context, session.UserId, false)
+		require.Nil(t, appErr)
+		require.Equal(t, oauthApp.Id, bot.OwnerId)
+
+		// A new token replaces the previous one
+		rsp2, _, err := apiClient.GetOAuthAccessToken(context.Background(), data)
+		require.NoError(t, err)
+		require.NotEqual(t, rsp.AccessToken, rsp2.AccessToken)
+
+		_, appErr = th.App.GetSession(rsp.AccessToken)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("deleting the app deactivates its bot", func(t *testing.T) {
+		appErr := th.App.DeleteOAuthApp(th.Context, oauthApp.Id)
+		require.Nil(t, appErr)
+
+		bots, appErr := th.App.GetBots(th.Context, &model.BotGetOptions{OwnerId: oauthApp.Id, IncludeDeleted: true, PerPage: 1})
+		require.Nil(t, appErr)
+		require.Len(t, bots, 1)
+		require.NotZero(t, bots[0].DeleteAt)
+	})
+}
+
+func TestOAuthIntrospectAndRevokeToken(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oauthApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "TestApp" + model.NewId(),
+		Homepage:     "https://nowhere.com",
+		CallbackUrls: []string{"https://nowhere.com"},
+		CreatorId:    th.SystemAdminUser.Id,
+		ClientSecret: model.NewId(),
+	})
+	require.Nil(t, appErr)
+
+	otherApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "TestApp" + model.NewId(),
+		Homepage:     "https://nowhere.com",
+		CallbackUrls: []string{"https://nowhere.com"},
+		CreatorId:    th.SystemAdminUser.Id,
+		ClientSecret: model.NewId(),
+	})
+	require.Nil(t, appErr)
+
+	th.Login(t, apiClient, th.BasicUser)
+	redirect, _, err := apiClient.AuthorizeOAuthApp(context.Background(), &model.AuthorizeRequest{
+		ResponseType: model.AuthCodeResponseType,
+		ClientId:     oauthApp.Id,
+		RedirectURI:  oauthApp.CallbackUrls[0],
+		State:        "123",
+	})
+	require.NoError(t, err)
+	rurl, err := url.Parse(redirect)
+	require.NoError(t, err)
+
+	rsp, _, err := apiClient.GetOAuthAccessToken(context.Background(), url.Values{
+		"grant_type":    []string{model.AccessTokenGrantType},
+		"client_id":     []string{oauthApp.Id},
+		"client_secret": []string{oauthApp.ClientSecret},
+		"code":          []string{rurl.Query().Get("code")},
+		"redirect_uri":  []string{oauthApp.CallbackUrls[0]},
+	})
+	require.NoError(t, err)
+
+	clientData := func(app *model.OAuthApp, token, hint string) url.Values {
+		return url.Values{"client_id": []string{app.Id}, "client_secret": []string{app.ClientSecret}, "token": []string{token}, "token_type_hint": []string{hint}}
+	}
+
+	t.Run("introspect access token", func(t *testing.T) {
+		introspection, _, err := apiClient.IntrospectOAuthToken(context.Background(), clientData(oauthApp, rsp.AccessToken, ""))
+		require.NoError(t, err)
+		require.True(t, introspection.Active)
+		require.Equal(t, oauthApp.Id, introspection.ClientId)
+		require.Equal(t, th.BasicUser.Username, introspection.Username)
+		require.Equal(t, th.BasicUser.Id, introspection.Subject)
+		require.Equal(t, model.AccessTokenType, introspection.TokenType)
+		require.NotZero(t, introspection.ExpiresAt)
+	})
+
+	t.Run("introspect refresh token", func(t *testing.T) {
+		introspection, _, err := apiClient.IntrospectOAuthToken(context.Background(), clientData(oauthApp, rsp.RefreshToken, model.TokenTypeHintRefreshToken))
+		require.NoError(t, err)
+		require.True(t, introspection.Active)
+		require.Empty(t, introspection.TokenType)
+	})
+
+	t.Run("introspect unknown token or token of another client", func(t *testing.T) {
+		introspection, _, err := apiClient.IntrospectOAuthToken(context.Background(), clientData(oauthApp, model.NewId(), ""))
+		require.NoError(t, err)
+		require.False(t, introspection.Active)
+
+		introspection, _, err = apiClient.IntrospectOAuthToken(context.Background(), clientData(otherApp, rsp.AccessToken, ""))
+		require.NoError(t, err)
+		require.False(t, introspection.Active)
+	})
+
+	t.Run("introspect with a wrong secret", func(t *testing.T) {
+		data := clientData(oauthApp, rsp.AccessToken, "")
+		data.Set("client_secret", model.NewId())
+		_, resp, err := apiClient.IntrospectOAuthToken(context.Background(), data)
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+
+	t.Run("revoke token of another client", func(t *testing.T) {
+		resp, err := apiClient.RevokeOAuthToken(context.Background(), clientData(otherApp, rsp.AccessToken, ""))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("revoke unknown token", func(t *testing.T) {
+		_, err := apiClient.RevokeOAuthToken(context.Background(), clientData(oauthApp, model.NewId(), ""))
+		require.NoError(t, err)
+	})
+
+	t.Run("revoke refresh token", func(t *testing.T) {
+		_, err := apiClient.RevokeOAuthToken(context.Background(), clientData(oauthApp, rsp.RefreshToken, model.TokenTypeHintRefreshToken))
+		require.NoError(t, err)
+
+		introspection, _, err := apiClient.IntrospectOAuthToken(context.Background(), clientData(oauthApp, rsp.AccessToken, ""))
+		require.NoError(t, err)
+		require.False(t, introspection.Active)
+
+		_, appErr := th.App.GetSession(rsp.AccessToken)
+		require.NotNil(t, appErr)
+	})
+}
+
 func TestMobileLoginWithOAuth(t *testing.T) {
 	th := Setup(t).InitBasic(t)
 
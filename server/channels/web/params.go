@@ -56,6 +56,7 @@ type Params struct {
 	JobId                              string
 	JobType                            string
 	RecapId                            string
+	MembershipRequestId                string
 	ActionId                           string
 	RoleId                             string
 	RoleName                           string
@@ -171,6 +172,7 @@ func ParamsFromRequest(r *http.Request) *Params {
 	params.JobId = props["job_id"]
 	params.JobType = props["job_type"]
 	params.RecapId = props["recap_id"]
+	params.MembershipRequestId = props["membership_request_id"]
 	params.ActionId = props["action_id"]
 	params.RoleId = props["role_id"]
 	params.RoleName = props["role_name"]
@@ -59,10 +59,13 @@ type Client interface {
 	PatchRole(ctx context.Context, roleID string, patch *model.RolePatch) (*model.Role, *model.Response, error)
 	UploadPlugin(ctx context.Context, file io.Reader) (*model.Manifest, *model.Response, error)
 	UploadPluginForced(ctx context.Context, file io.Reader) (*model.Manifest, *model.Response, error)
+	UploadSignedPlugin(ctx context.Context, file, signature io.Reader, force bool) (*model.Manifest, *model.Response, error)
+	VerifyPlugin(ctx context.Context, file, signature io.Reader) (*model.PluginSignatureVerification, *model.Response, error)
 	RemovePlugin(ctx context.Context, id string) (*model.Response, error)
 	EnablePlugin(ctx context.Context, id string) (*model.Response, error)
 	DisablePlugin(ctx context.Context, id string) (*model.Response, error)
 	GetPlugins(ctx context.Context) (*model.PluginsResponse, *model.Response, error)
+	GetPluginStatuses(ctx context.Context) (model.PluginStatuses, *model.Response, error)
 	GetUser(ctx context.Context, userID, etag string) (*model.User, *model.Response, error)
 	GetUserByUsername(ctx context.Context, userName, etag string) (*model.User, *model.Response, error)
 	GetUserByEmail(ctx context.Context, email, etag string) (*model.User, *model.Response, error)
@@ -76,6 +79,7 @@ type Client interface {
 	UpdateUserRoles(ctx context.Context, userID, roles string) (*model.Response, error)
 	InviteUsersToTeam(ctx context.Context, teamID string, userEmails []string) (*model.Response, error)
 	SendPasswordResetEmail(ctx context.Context, email string) (*model.Response, error)
+	GetPasswordHasherUsage(ctx context.Context) ([]*model.PasswordHasherUsage, *model.Response, error)
 	UpdateUser(ctx context.Context, user *model.User) (*model.User, *model.Response, error)
 	UpdateUserAuth(ctx context.Context, userId string, userAuth *model.UserAuth) (*model.UserAuth, *model.Response, error)
 	UpdateUserMfa(ctx context.Context, userID, code string, activate bool) (*model.Response, error)
@@ -127,6 +131,8 @@ type Client interface {
 	GetPing(ctx context.Context) (string, *model.Response, error)
 	GetPingWithFullServerStatus(ctx context.Context) (map[string]any, *model.Response, error)
 	GetPingWithOptions(ctx context.Context, options model.SystemPingOptions) (map[string]any, *model.Response, error)
+	GetClusterStatus(ctx context.Context) ([]*model.ClusterInfo, *model.Response, error)
+	GetAnalyticsOld(ctx context.Context, name, teamID string) (model.AnalyticsRows, *model.Response, error)
 	CreateUpload(ctx context.Context, us *model.UploadSession) (*model.UploadSession, *model.Response, error)
 	GetUpload(ctx context.Context, uploadID string) (*model.UploadSession, *model.Response, error)
 	GetUploadsForUser(ctx context.Context, userID string) ([]*model.UploadSession, *model.Response, error)
@@ -157,6 +163,7 @@ type Client interface {
 	DeleteExport(ctx context.Context, name string) (*model.Response, error)
 	DownloadExport(ctx context.Context, name string, wr io.Writer, offset int64) (int64, *model.Response, error)
 	DownloadComplianceExport(ctx context.Context, jobID string, wr io.Writer) (string, error)
+	DownloadJob(ctx context.Context, jobID string) ([]byte, *model.Response, error)
 	GeneratePresignedURL(ctx context.Context, name string) (*model.PresignURLResponse, *model.Response, error)
 	ResetSamlAuthDataToEmail(ctx context.Context, includeDeleted bool, dryRun bool, userIDs []string) (int64, *model.Response, error)
 	GenerateSupportPacket(ctx context.Context) (io.ReadCloser, string, *model.Response, error)
@@ -176,4 +183,6 @@ type Client interface {
 	PatchCPAValues(ctx context.Context, values map[string]json.RawMessage) (map[string]json.RawMessage, *model.Response, error)
 	PatchCPAValuesForUser(ctx context.Context, userID string, values map[string]json.RawMessage) (map[string]json.RawMessage, *model.Response, error)
 	GetPostsForReporting(ctx context.Context, options model.ReportPostOptions, cursor model.ReportPostOptionsCursor) (*model.ReportPostListResponse, *model.Response, error)
+	CompareSharedChannelWithRemote(ctx context.Context, remoteId, channelId string, since, until int64) (*model.SharedChannelSyncComparison, *model.Response, error)
+	ResyncSharedChannelRemote(ctx context.Context, remoteId, channelId string, since int64) (*model.Response, error)
 }
@@ -0,0 +1,223 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var ChannelExportCmd = &cobra.Command{
+	Use:   "export [channel] [filepath]",
+	Short: "Export a channel to an archive",
+	Long: `Export a channel, with its history, its members and the files attached to its posts, to a self-contained archive that can be imported into another server with "channel import".
+
+The archive uses the bulk import format. It is generated on the server by an export job, and the command waits for the job to finish to download it, unless --no-wait is set. In that case the archive can be downloaded later with "export download".`,
+	Example: `  # export a channel to mychannel.zip
+  $ mmctl channel export myteam:mychannel
+
+  # export a channel without its file attachments
+  $ mmctl channel export myteam:mychannel mychannel_export.zip --no-attachments`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: withClient(channelExportCmdF),
+}
+
+var ChannelImportCmd = &cobra.Command{
+	Use:   "import [filepath] [team]",
+	Short: "Import a channel archive into a team",
+	Long: `Import the archive of a channel, created with "channel export" on another server, into a team.
+
+The users of the archive are matched with the users of the server by email or username. Users that match keep their account and are added to the channel. The users that don't match are created by default. They can instead be remapped to a single user with --unknown-users remap --remap-to [username], or skipped along with their posts, replies and reactions with --unknown-users skip.
+
+Bots are not part of the archive: their content is imported only if a bot with the same username exists on the server, and is otherwise remapped or skipped.`,
+	Example: `  # import a channel, matching users by email and creating the unknown ones
+  $ mmctl channel import mychannel.zip myteam
+
+  # import a channel, matching users by username and remapping the unknown ones
+  $ mmctl channel import mychannel.zip myteam --user-mapping username --unknown-users remap --remap-to archived-user`,
+	Args: cobra.ExactArgs(2),
+	RunE: withClient(channelImportCmdF),
+}
+
+func init() {
+	ChannelExportCmd.Flags().Bool("no-attachments", false, "Exclude file attachments from the archive.")
+	ChannelExportCmd.Flags().Bool("no-wait", false, "Start the export job and exit without downloading the archive.")
+	ChannelExportCmd.Flags().Int("num-retries", 5, "Number of retries to do to resume a download.")
+	ChannelExportCmd.Flags().Duration("poll-interval", 2*time.Second, "Interval between two checks of the export job status.")
+
+	ChannelImportCmd.Flags().String("user-mapping", model.ChannelImportUserMappingEmail, "User field used to match the users of the archive with the users of the server (email or username).")
+	ChannelImportCmd.Flags().String("unknown-users", model.ChannelImportUnknownUsersCreate, "What to do with the users of the archive that don't match any user of the server (create, remap or skip).")
+	ChannelImportCmd.Flags().String("remap-to", "", "Username of the user that the content of unknown users is remapped to. Required with --unknown-users remap.")
+	ChannelImportCmd.Flags().Bool("extract-content", true, "If this is set, document attachments will be extracted and indexed during the import process.")
+	ChannelImportCmd.Flags().Bool("no-wait", false, "Start the import job and exit without waiting for it to finish.")
+	ChannelImportCmd.Flags().Duration("poll-interval", 2*time.Second, "Interval between two checks of the import job status.")
+
+	ChannelCmd.AddCommand(
+		ChannelExportCmd,
+		ChannelImportCmd,
+	)
+}
+
+func channelExportCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+	if channel.IsGroupOrDirect() {
+		return errors.New("direct and group message channels can't be exported")
+	}
+
+	path := channel.Name + ".zip"
+	if len(args) > 1 {
+		path = args[1]
+	}
+
+	data := map[string]string{"channel_id": channel.Id}
+	if noAttachments, _ := cmd.Flags().GetBool("no-attachments"); !noAttachments {
+		data["include_attachments"] = "true"
+	}
+
+	job, _, err := c.CreateJob(context.TODO(), &model.Job{
+		Type: model.JobTypeExportProcess,
+		Data: data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create channel export job: %w", err)
+	}
+
+	if noWait, _ := cmd.Flags().GetBool("no-wait"); noWait {
+		printer.PrintT("Channel export job successfully created, ID: {{.Id}}", job)
+		return nil
+	}
+
+	pollInterval, _ := cmd.Flags().GetDuration("poll-interval")
+	job, err = waitForJob(c, job, pollInterval)
+	if err != nil {
+		return fmt.Errorf("failed to get channel export job: %w", err)
+	}
+	if job.Status != model.JobStatusSuccess {
+		return fmt.Errorf("channel export job %s finished with status %s", job.Id, job.Status)
+	}
+
+	exportName := job.Id + "_export.zip"
+	retries, _ := cmd.Flags().GetInt("num-retries")
+	downloadFn := func(outFile *os.File) (string, error) {
+		off, err := outFile.Seek(0, io.SeekEnd)
+		if err != nil {
+			return "", fmt.Errorf("failed to seek file: %w", err)
+		}
+
+		_, _, err = c.DownloadExport(context.TODO(), exportName, outFile, off)
+		return "", err
+	}
+	if _, err := downloadFile(path, downloadFn, retries, "export"); err != nil {
+		return err
+	}
+
+	if _, err := c.DeleteExport(context.TODO(), exportName); err != nil {
+		printer.PrintWarning(fmt.Sprintf("Failed to delete export file %q from the server: %s", exportName, err))
+	}
+
+	printer.Print(fmt.Sprintf("Channel exported to %q", path))
+	return nil
+}
+
+func channelImportCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	userMapping, _ := cmd.Flags().GetString("user-mapping")
+	unknownUsers, _ := cmd.Flags().GetString("unknown-users")
+	remapTo, _ := cmd.Flags().GetString("remap-to")
+
+	team := getTeamFromTeamArg(c, args[1])
+	if team == nil {
+		return errors.Errorf("unable to find team %q", args[1])
+	}
+
+	opts := model.ChannelImportOpts{
+		Team:          team.Name,
+		UserMapping:   userMapping,
+		UnknownUsers:  unknownUsers,
+		RemapUsername: remapTo,
+	}
+	if appErr := opts.IsValid(); appErr != nil {
+		return errors.New(appErr.Message)
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open channel archive: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat channel archive: %w", err)
+	}
+
+	userID := "me"
+	if isLocal, _ := cmd.Flags().GetBool("local"); isLocal {
+		userID = model.UploadNoUserID
+	}
+
+	us, _, err := c.CreateUpload(context.TODO(), &model.UploadSession{
+		Filename: info.Name(),
+		FileSize: info.Size(),
+		Type:     model.UploadTypeImport,
+		UserId:   userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create upload session: %w", err)
+	}
+
+	finfo, _, err := c.UploadData(context.TODO(), us.Id, file)
+	if err != nil {
+		return fmt.Errorf("failed to upload data: %w", err)
+	}
+
+	extractContent, _ := cmd.Flags().GetBool("extract-content")
+	job, _, err := c.CreateJob(context.TODO(), &model.Job{
+		Type: model.JobTypeImportProcess,
+		Data: map[string]string{
+			"import_file":     us.Id + "_" + finfo.Name,
+			"extract_content": strconv.FormatBool(extractContent),
+			"team":            opts.Team,
+			"user_mapping":    opts.UserMapping,
+			"unknown_users":   opts.UnknownUsers,
+			"remap_user":      opts.RemapUsername,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create channel import job: %w", err)
+	}
+
+	if noWait, _ := cmd.Flags().GetBool("no-wait"); noWait {
+		printer.PrintT("Channel import job successfully created, ID: {{.Id}}", job)
+		return nil
+	}
+
+	pollInterval, _ := cmd.Flags().GetDuration("poll-interval")
+	job, err = waitForJob(c, job, pollInterval)
+	if err != nil {
+		return fmt.Errorf("failed to get channel import job: %w", err)
+	}
+	if job.Status != model.JobStatusSuccess {
+		if job.Data["error"] != "" {
+			return fmt.Errorf("channel import job %s finished with status %s: %s", job.Id, job.Status, job.Data["error"])
+		}
+		return fmt.Errorf("channel import job %s finished with status %s", job.Id, job.Status)
+	}
+
+	printer.Print(fmt.Sprintf("Channel imported into team %q", team.Name))
+	return nil
+}
@@ -0,0 +1,281 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestChannelExportCmdF() {
+	channelArg := teamID + ":" + channelName
+	mockTeam := model.Team{Id: teamID}
+	mockChannel := model.Channel{Id: channelID, Name: channelName, TeamId: teamID, Type: model.ChannelTypeOpen}
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("no-attachments", false, "")
+		cmd.Flags().Bool("no-wait", false, "")
+		cmd.Flags().Int("num-retries", 0, "")
+		cmd.Flags().Duration("poll-interval", time.Millisecond, "")
+		return cmd
+	}
+
+	expectChannel := func() {
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamID, "").
+			Return(&mockTeam, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannelByNameIncludeDeleted(context.TODO(), channelName, teamID, "").
+			Return(&mockChannel, &model.Response{}, nil).
+			Times(1)
+	}
+
+	s.Run("unknown channel", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamID, "").
+			Return(&mockTeam, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannelByNameIncludeDeleted(context.TODO(), channelName, teamID, "").
+			Return(nil, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channelName).
+			Return(nil, &model.Response{}, nil).
+			Times(1)
+
+		err := channelExportCmdF(s.client, newCmd(), []string{channelArg})
+		s.Require().EqualError(err, `unable to find channel "`+channelArg+`"`)
+	})
+
+	s.Run("start the export without waiting", func() {
+		printer.Clean()
+		mockJob := &model.Job{Id: model.NewId(), Type: model.JobTypeExportProcess, Status: model.JobStatusPending}
+
+		expectChannel()
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), &model.Job{
+				Type: model.JobTypeExportProcess,
+				Data: map[string]string{"channel_id": channelID},
+			}).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("no-attachments", "true"))
+		s.Require().NoError(cmd.Flags().Set("no-wait", "true"))
+
+		err := channelExportCmdF(s.client, cmd, []string{channelArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(mockJob, printer.GetLines()[0])
+	})
+
+	s.Run("wait for the export and download the archive", func() {
+		printer.Clean()
+		jobID := model.NewId()
+		path := filepath.Join(s.T().TempDir(), "channel.zip")
+
+		expectChannel()
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), &model.Job{
+				Type: model.JobTypeExportProcess,
+				Data: map[string]string{"channel_id": channelID, "include_attachments": "true"},
+			}).
+			Return(&model.Job{Id: jobID, Status: model.JobStatusPending}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), jobID).
+			Return(&model.Job{Id: jobID, Status: model.JobStatusSuccess}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DownloadExport(context.TODO(), jobID+"_export.zip", gomock.Any(), int64(0)).
+			Return(int64(0), &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteExport(context.TODO(), jobID+"_export.zip").
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := channelExportCmdF(s.client, newCmd(), []string{channelArg, path})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(`Channel exported to "`+path+`"`, printer.GetLines()[0])
+	})
+
+	s.Run("failed export", func() {
+		printer.Clean()
+		jobID := model.NewId()
+
+		expectChannel()
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), gomock.Any()).
+			Return(&model.Job{Id: jobID, Status: model.JobStatusError}, &model.Response{}, nil).
+			Times(1)
+
+		err := channelExportCmdF(s.client, newCmd(), []string{channelArg})
+		s.Require().EqualError(err, "channel export job "+jobID+" finished with status error")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestChannelImportCmdF() {
+	mockTeam := model.Team{Id: teamID, Name: "myteam"}
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("user-mapping", model.ChannelImportUserMappingEmail, "")
+		cmd.Flags().String("unknown-users", model.ChannelImportUnknownUsersCreate, "")
+		cmd.Flags().String("remap-to", "", "")
+		cmd.Flags().Bool("extract-content", true, "")
+		cmd.Flags().Bool("no-wait", false, "")
+		cmd.Flags().Duration("poll-interval", time.Millisecond, "")
+		return cmd
+	}
+
+	s.Run("unknown team", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "unknown", "").
+			Return(nil, &model.Response{}, nil).
+			Times(1)
+
+		err := channelImportCmdF(s.client, newCmd(), []string{"channel.zip", "unknown"})
+		s.Require().EqualError(err, `unable to find team "unknown"`)
+	})
+
+	s.Run("remap without a user", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamID, "").
+			Return(&mockTeam, &model.Response{}, nil).
+			Times(1)
+
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("unknown-users", model.ChannelImportUnknownUsersRemap))
+
+		err := channelImportCmdF(s.client, cmd, []string{"channel.zip", teamID})
+		s.Require().Error(err)
+	})
+
+	s.Run("upload the archive and wait for the import", func() {
+		printer.Clean()
+		path := filepath.Join(s.T().TempDir(), "channel.zip")
+		s.Require().NoError(os.WriteFile(path, []byte("archive"), 0600))
+		uploadID := model.NewId()
+		jobID := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamID, "").
+			Return(&mockTeam, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateUpload(context.TODO(), &model.UploadSession{
+				Filename: "channel.zip",
+				FileSize: 7,
+				Type:     model.UploadTypeImport,
+				UserId:   "me",
+			}).
+			Return(&model.UploadSession{Id: uploadID}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UploadData(context.TODO(), uploadID, gomock.Any()).
+			Return(&model.FileInfo{Name: "channel.zip"}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), &model.Job{
+				Type: model.JobTypeImportProcess,
+				Data: map[string]string{
+					"import_file":     uploadID + "_channel.zip",
+					"extract_content": "true",
+					"team":            "myteam",
+					"user_mapping":    model.ChannelImportUserMappingUsername,
+					"unknown_users":   model.ChannelImportUnknownUsersSkip,
+					"remap_user":      "",
+				},
+			}).
+			Return(&model.Job{Id: jobID, Status: model.JobStatusPending}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), jobID).
+			Return(&model.Job{Id: jobID, Status: model.JobStatusSuccess}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("user-mapping", model.ChannelImportUserMappingUsername))
+		s.Require().NoError(cmd.Flags().Set("unknown-users", model.ChannelImportUnknownUsersSkip))
+
+		err := channelImportCmdF(s.client, cmd, []string{path, teamID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(`Channel imported into team "myteam"`, printer.GetLines()[0])
+	})
+
+	s.Run("failed import", func() {
+		printer.Clean()
+		path := filepath.Join(s.T().TempDir(), "channel.zip")
+		s.Require().NoError(os.WriteFile(path, []byte("archive"), 0600))
+		jobID := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamID, "").
+			Return(&mockTeam, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateUpload(context.TODO(), gomock.Any()).
+			Return(&model.UploadSession{Id: model.NewId()}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UploadData(context.TODO(), gomock.Any(), gomock.Any()).
+			Return(&model.FileInfo{Name: "channel.zip"}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), gomock.Any()).
+			Return(&model.Job{Id: jobID, Status: model.JobStatusError, Data: model.StringMap{"error": "username conflict"}}, &model.Response{}, nil).
+			Times(1)
+
+		err := channelImportCmdF(s.client, newCmd(), []string{path, teamID})
+		s.Require().EqualError(err, "channel import job "+jobID+" finished with status error: username conflict")
+	})
+}
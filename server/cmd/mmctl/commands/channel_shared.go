@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var ChannelSharedCmd = &cobra.Command{
+	Use:   "shared",
+	Short: "Troubleshooting of shared channels",
+}
+
+var ChannelSharedCompareCmd = &cobra.Command{
+	Use:   "compare [channel] [remote-id]",
+	Short: "Compare the posts of a shared channel with a remote",
+	Long: `Compare the ids of the posts created in a shared channel within a time range with the posts held by a remote, and list the posts missing on either side.
+
+System messages and deleted posts are not compared. At most 10000 posts are compared per side; narrow the range with --since and --until if the result is truncated.`,
+	Example: `  # compare the posts of the last 24 hours
+  $ mmctl channel shared compare myteam:mychannel 4xp9fdt77pncbef59f4k1qe83o
+
+  # compare the posts of a given period
+  $ mmctl channel shared compare myteam:mychannel 4xp9fdt77pncbef59f4k1qe83o --since 2026-10-01T00:00:00+00:00 --until 2026-10-02T00:00:00+00:00`,
+	Args: cobra.ExactArgs(2),
+	RunE: withClient(channelSharedCompareCmdF),
+}
+
+var ChannelSharedResyncCmd = &cobra.Command{
+	Use:   "resync [channel] [remote-id]",
+	Short: "Resend the posts of a shared channel to a remote",
+	Long: `Reset the sync cursor of a shared channel remote to a timestamp, so that the posts created or edited since then are sent again.
+
+Posts already present on the remote are updated in place. The timestamp can't be later than the current cursor of the remote.`,
+	Example: `  $ mmctl channel shared resync myteam:mychannel 4xp9fdt77pncbef59f4k1qe83o --since 2026-10-01T00:00:00+00:00`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    withClient(channelSharedResyncCmdF),
+}
+
+func init() {
+	ChannelSharedCompareCmd.Flags().String("since", "", "Compare the posts created after this time (ISO 8601). Defaults to 24 hours ago.")
+	ChannelSharedCompareCmd.Flags().String("until", "", "Compare the posts created before this time (ISO 8601). Defaults to now.")
+	ChannelSharedCompareCmd.Flags().Bool("show-ids", false, "List the ids of the missing posts.")
+
+	ChannelSharedResyncCmd.Flags().String("since", "", "Resend the posts created or edited after this time (ISO 8601).")
+	_ = ChannelSharedResyncCmd.MarkFlagRequired("since")
+
+	ChannelSharedCmd.AddCommand(
+		ChannelSharedCompareCmd,
+		ChannelSharedResyncCmd,
+	)
+
+	ChannelCmd.AddCommand(ChannelSharedCmd)
+}
+
+func getMillisFromTimeFlag(cmd *cobra.Command, name string, defaultValue time.Time) (int64, error) {
+	value, _ := cmd.Flags().GetString(name)
+	if value == "" {
+		return model.GetMillisForTime(defaultValue), nil
+	}
+
+	t, err := time.Parse(ISO8601Layout, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s time %q", name, value)
+	}
+	return model.GetMillisForTime(t), nil
+}
+
+func channelSharedCompareCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	now := time.Now()
+	since, err := getMillisFromTimeFlag(cmd, "since", now.Add(-24*time.Hour))
+	if err != nil {
+		return err
+	}
+	until, err := getMillisFromTimeFlag(cmd, "until", now)
+	if err != nil {
+		return err
+	}
+
+	comparison, _, err := c.CompareSharedChannelWithRemote(context.TODO(), args[1], channel.Id, since, until)
+	if err != nil {
+		return fmt.Errorf("failed to compare channel %q with remote %s: %w", args[0], args[1], err)
+	}
+
+	template := "Posts compared: {{.LocalCount}} local, {{.RemoteCount}} remote. Missing on remote: {{len .MissingOnRemote}}, missing locally: {{len .MissingLocally}}"
+	if showIds, _ := cmd.Flags().GetBool("show-ids"); showIds {
+		template += "{{range .MissingOnRemote}}\n  missing on remote: {{.}}{{end}}{{range .MissingLocally}}\n  missing locally: {{.}}{{end}}"
+	}
+	if comparison.InSync() {
+		template += "\nThe channel is in sync with the remote"
+	}
+	if comparison.Truncated {
+		template += "\nThe range holds too many posts to be compared at once, narrow it with --since and --until"
+	}
+	printer.PrintT(template, comparison)
+
+	return nil
+}
+
+func channelSharedResyncCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	since, err := getMillisFromTimeFlag(cmd, "since", time.Time{})
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.ResyncSharedChannelRemote(context.TODO(), args[1], channel.Id, since); err != nil {
+		return fmt.Errorf("failed to resync channel %q with remote %s: %w", args[0], args[1], err)
+	}
+
+	printer.Print(fmt.Sprintf("Channel %q is being resynced with remote %s", args[0], args[1]))
+	return nil
+}
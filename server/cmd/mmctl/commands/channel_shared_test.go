@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestChannelSharedCompareCmdF() {
+	channelArg := teamID + ":" + channelName
+	mockTeam := model.Team{Id: teamID}
+	mockChannel := model.Channel{Id: channelID, Name: channelName}
+	remoteID := model.NewId()
+
+	expectChannel := func() {
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamID, "").
+			Return(&mockTeam, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannelByNameIncludeDeleted(context.TODO(), channelName, teamID, "").
+			Return(&mockChannel, &model.Response{}, nil).
+			Times(1)
+	}
+
+	s.Run("Compare a time range", func() {
+		printer.Clean()
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since", "2026-10-01T00:00:00+00:00", "")
+		cmd.Flags().String("until", "2026-10-02T00:00:00+00:00", "")
+		cmd.Flags().Bool("show-ids", true, "")
+
+		expectChannel()
+		comparison := &model.SharedChannelSyncComparison{
+			LocalCount:      3,
+			RemoteCount:     2,
+			MissingOnRemote: []string{"post1"},
+			MissingLocally:  []string{},
+		}
+		s.client.
+			EXPECT().
+			CompareSharedChannelWithRemote(context.TODO(), remoteID, channelID, int64(1790812800000), int64(1790899200000)).
+			Return(comparison, &model.Response{}, nil).
+			Times(1)
+
+		err := channelSharedCompareCmdF(s.client, cmd, []string{channelArg, remoteID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Equal(comparison, printer.GetLines()[0])
+	})
+
+	s.Run("Invalid time", func() {
+		printer.Clean()
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since", "yesterday", "")
+		cmd.Flags().String("until", "", "")
+
+		expectChannel()
+
+		err := channelSharedCompareCmdF(s.client, cmd, []string{channelArg, remoteID})
+		s.EqualError(err, `invalid since time "yesterday"`)
+		s.Len(printer.GetLines(), 0)
+	})
+
+	s.Run("Compare fails", func() {
+		printer.Clean()
+		cmd := &cobra.Command{}
+
+		expectChannel()
+		s.client.
+			EXPECT().
+			CompareSharedChannelWithRemote(context.TODO(), remoteID, channelID, gomock.Any(), gomock.Any()).
+			Return(nil, &model.Response{}, errors.New("remote offline")).
+			Times(1)
+
+		err := channelSharedCompareCmdF(s.client, cmd, []string{channelArg, remoteID})
+		s.ErrorContains(err, "remote offline")
+		s.Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestChannelSharedResyncCmdF() {
+	channelArg := teamID + ":" + channelName
+	mockTeam := model.Team{Id: teamID}
+	mockChannel := model.Channel{Id: channelID, Name: channelName}
+	remoteID := model.NewId()
+
+	s.Run("Resync from a timestamp", func() {
+		printer.Clean()
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since", "2026-10-01T00:00:00+00:00", "")
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), teamID, "").
+			Return(&mockTeam, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannelByNameIncludeDeleted(context.TODO(), channelName, teamID, "").
+			Return(&mockChannel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			ResyncSharedChannelRemote(context.TODO(), remoteID, channelID, int64(1790812800000)).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := channelSharedResyncCmdF(s.client, cmd, []string{channelArg, remoteID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Contains(printer.GetLines()[0], "is being resynced")
+	})
+}
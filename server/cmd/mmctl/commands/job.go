@@ -182,6 +182,20 @@ func jobListCmdF(c client.Client, command *cobra.Command, jobType string, status
 	return nil
 }
 
+// waitForJob polls the job until it is neither pending nor in progress
+// and returns its final state.
+func waitForJob(c client.Client, job *model.Job, pollInterval time.Duration) (*model.Job, error) {
+	var err error
+	for job.Status == model.JobStatusPending || job.Status == model.JobStatusInProgress {
+		time.Sleep(pollInterval)
+		job, _, err = c.GetJob(context.TODO(), job.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return job, nil
+}
+
 func printJob(job *model.Job) {
 	if job.StartAt > 0 {
 		printer.PrintT(fmt.Sprintf(`  ID: {{.Id}}
@@ -0,0 +1,424 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/password/hashers"
+	"github.com/mattermost/mattermost/server/v8/channels/app/users"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/sqlstore"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+	"github.com/mattermost/mattermost/server/v8/config"
+)
+
+var OfflineCmd = &cobra.Command{
+	Use:   "offline",
+	Short: "Management of a stopped server",
+	Long: `Commands that work directly on the database and the configuration of a server that is stopped, for instance because it doesn't start anymore with its current configuration.
+
+The configuration is read from --server-config, which is either the path of a config.json file or a database DSN, as the MM_CONFIG environment variable of the server. Database migrations are not run.
+
+The commands refuse to run while a node of the server is running. A node that stopped unexpectedly is considered stopped three minutes after its last heartbeat.`,
+}
+
+var OfflineConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration of a stopped server",
+}
+
+var OfflineConfigGetCmd = &cobra.Command{
+	Use:     "get [key]",
+	Short:   "Get config setting",
+	Long:    "Gets the value of a config setting by its name in dot notation. The configuration is read even if it is invalid.",
+	Example: `offline config get SqlSettings.DriverName --server-config /opt/mattermost/config/config.json`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withOfflineStore(true, offlineConfigGetCmdF),
+}
+
+var OfflineConfigSetCmd = &cobra.Command{
+	Use:     "set [key] [values]",
+	Short:   "Set config setting",
+	Long:    "Sets the value of a config setting by its name in dot notation. Accepts multiple values for array settings. The configuration is saved only if it is valid once the setting changed.",
+	Example: `offline config set ServiceSettings.SiteURL https://mattermost.example.com --server-config /opt/mattermost/config/config.json`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withOfflineStore(false, offlineConfigSetCmdF),
+}
+
+var OfflineConfigShowCmd = &cobra.Command{
+	Use:     "show",
+	Short:   "Writes the server configuration to STDOUT",
+	Long:    "Prints the configuration of the stopped server to the standard output, along with the reason why it is invalid if it is.",
+	Example: "offline config show --server-config /opt/mattermost/config/config.json",
+	Args:    cobra.NoArgs,
+	RunE:    withOfflineStore(true, offlineConfigShowCmdF),
+}
+
+var OfflineUserCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Management of the users of a stopped server",
+}
+
+var OfflineUserChangePasswordCmd = &cobra.Command{
+	Use:   "change-password [user]",
+	Short: "Changes a user's password",
+	Long:  "Changes the password of a user. If the password is not provided with --password, it is asked interactively. The sessions of the user are revoked if ServiceSettings.TerminateSessionsOnPasswordChange is enabled.",
+	Example: `  $ mmctl offline user change-password john_doe --password new-password
+
+  # the password can be provided already hashed
+  $ mmctl offline user change-password john_doe --password HASHED_PASSWORD --hashed`,
+	Args: cobra.ExactArgs(1),
+	RunE: withOfflineStore(true, offlineUserChangePasswordCmdF),
+}
+
+var OfflineSessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Management of the sessions of a stopped server",
+}
+
+var OfflineSessionRevokeCmd = &cobra.Command{
+	Use:   "revoke [users]",
+	Short: "Revoke the sessions of users",
+	Long:  "Revokes all the sessions of the given users, or of every user with --all.",
+	Example: `  $ mmctl offline session revoke john_doe jane@example.com
+
+  $ mmctl offline session revoke --all`,
+	RunE: withOfflineStore(true, offlineSessionRevokeCmdF),
+}
+
+var OfflinePluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Management of the plugins of a stopped server",
+}
+
+var OfflinePluginDisableCmd = &cobra.Command{
+	Use:     "disable [plugins]",
+	Short:   "Disable plugins",
+	Long:    "Disable plugins in the configuration, so they are not started with the server.",
+	Example: `offline plugin disable com.example.plugin`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withOfflineStore(false, offlinePluginDisableCmdF),
+}
+
+var OfflineJobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "Management of the jobs of a stopped server",
+}
+
+var OfflineJobCancelCmd = &cobra.Command{
+	Use:     "cancel [jobs]",
+	Short:   "Cancel jobs",
+	Long:    "Cancel pending and in progress jobs, so they are not resumed when the server starts.",
+	Example: `offline job cancel o98rj3ur83dp5dppfyk5yk6osy`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withOfflineStore(true, offlineJobCancelCmdF),
+}
+
+func init() {
+	OfflineCmd.PersistentFlags().String("server-config", "", "Path of the config.json file or DSN of the configuration of the server. Defaults to the MM_CONFIG environment variable, then to config.json.")
+
+	OfflineUserChangePasswordCmd.Flags().StringP("password", "p", "", "The new password for the user")
+	OfflineUserChangePasswordCmd.Flags().Bool("hashed", false, "The supplied password is already hashed")
+
+	OfflineSessionRevokeCmd.Flags().Bool("all", false, "Revoke the sessions of all the users")
+
+	OfflineConfigCmd.AddCommand(
+		OfflineConfigGetCmd,
+		OfflineConfigSetCmd,
+		OfflineConfigShowCmd,
+	)
+	OfflineUserCmd.AddCommand(OfflineUserChangePasswordCmd)
+	OfflineSessionCmd.AddCommand(OfflineSessionRevokeCmd)
+	OfflinePluginCmd.AddCommand(OfflinePluginDisableCmd)
+	OfflineJobCmd.AddCommand(OfflineJobCancelCmd)
+
+	OfflineCmd.AddCommand(
+		OfflineConfigCmd,
+		OfflineUserCmd,
+		OfflineSessionCmd,
+		OfflinePluginCmd,
+		OfflineJobCmd,
+	)
+	RootCmd.AddCommand(OfflineCmd)
+}
+
+// withOfflineStore opens the configuration and the database of the
+// server, and makes sure that no node is running before calling fn. The
+// configuration is opened read-only unless fn changes it.
+func withOfflineStore(readOnlyConfig bool, fn func(ss store.Store, configStore *config.Store, cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		dsn, _ := cmd.Flags().GetString("server-config")
+		if dsn == "" {
+			dsn = os.Getenv("MM_CONFIG")
+		}
+		if dsn == "" {
+			dsn = "config.json"
+		}
+
+		// The configuration is first opened read-only, as loading a
+		// writable configuration may save it.
+		configStore, err := config.NewRecoveryStoreFromDSN(dsn, true)
+		if err != nil {
+			return errors.Wrap(err, "failed to load the server configuration")
+		}
+		defer func() {
+			configStore.Close()
+		}()
+
+		logger, err := mlog.NewLogger()
+		if err != nil {
+			return errors.Wrap(err, "failed to create logger")
+		}
+		defer func() {
+			_ = logger.Shutdown()
+		}()
+
+		ss, err := sqlstore.New(configStore.Get().SqlSettings, logger, nil, sqlstore.SkipMigrations())
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to the database")
+		}
+		defer ss.Close()
+
+		if err := checkNoLiveNode(ss); err != nil {
+			return err
+		}
+
+		if !readOnlyConfig {
+			configStore.Close()
+			if configStore, err = config.NewRecoveryStoreFromDSN(dsn, false); err != nil {
+				return errors.Wrap(err, "failed to load the server configuration")
+			}
+		}
+
+		return fn(ss, configStore, cmd, args)
+	}
+}
+
+// checkNoLiveNode returns an error if a node of the server has sent a
+// heartbeat recently, see model.CDSTypeNode.
+func checkNoLiveNode(ss store.Store) error {
+	nodes, err := ss.ClusterDiscovery().GetAll(model.CDSTypeNode, model.CDSNodeClusterName)
+	if err != nil {
+		return errors.Wrap(err, "failed to check for running nodes")
+	}
+
+	var live []string
+	for _, node := range nodes {
+		if node.LastPingAt > model.GetMillis()-model.CDSNodeLiveAfterMillis {
+			live = append(live, node.Hostname)
+		}
+	}
+	if len(live) > 0 {
+		return fmt.Errorf("the server is running on %s, stop all the nodes before running offline commands", strings.Join(live, ", "))
+	}
+
+	return nil
+}
+
+func getOfflineUser(ss store.Store, arg string) (*model.User, error) {
+	var user *model.User
+	var err error
+	switch {
+	case model.IsValidId(arg):
+		user, err = ss.User().Get(context.Background(), arg)
+	case strings.Contains(arg, "@") && !strings.HasPrefix(arg, "@"):
+		user, err = ss.User().GetByEmail(arg)
+	default:
+		user, err = ss.User().GetByUsername(strings.TrimPrefix(arg, "@"))
+	}
+
+	var nfErr *store.ErrNotFound
+	if errors.As(err, &nfErr) {
+		return nil, fmt.Errorf("user %q not found", arg)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to get user %q", arg)
+	}
+
+	return user, nil
+}
+
+func offlineConfigGetCmdF(_ store.Store, configStore *config.Store, _ *cobra.Command, args []string) error {
+	printer.SetSingle(true)
+	printer.SetFormat(printer.FormatJSON)
+
+	val, ok := getValue(strings.Split(args[0], "."), *configStore.Get())
+	if !ok {
+		return errors.New("invalid key")
+	}
+
+	printer.Print(val)
+	return nil
+}
+
+func offlineConfigSetCmdF(_ store.Store, configStore *config.Store, _ *cobra.Command, args []string) error {
+	cfg := configStore.GetNoEnv()
+	if err := setConfigValue(parseConfigPath(args[0]), cfg, args[1:]); err != nil {
+		return err
+	}
+
+	if _, _, err := configStore.Set(cfg); err != nil {
+		return errors.Wrap(err, "failed to save the configuration")
+	}
+
+	printer.Print("Value changed successfully")
+	return nil
+}
+
+func offlineConfigShowCmdF(_ store.Store, configStore *config.Store, _ *cobra.Command, _ []string) error {
+	printer.SetSingle(true)
+	printer.SetFormat(printer.FormatJSON)
+
+	cfg := configStore.Get()
+	if appErr := cfg.IsValid(); appErr != nil {
+		printer.PrintWarning("The configuration is invalid: " + appErr.Error())
+	}
+
+	printer.Print(cfg)
+	return nil
+}
+
+func offlineUserChangePasswordCmdF(ss store.Store, configStore *config.Store, cmd *cobra.Command, args []string) error {
+	password, _ := cmd.Flags().GetString("password")
+	hashed, _ := cmd.Flags().GetBool("hashed")
+
+	user, err := getOfflineUser(ss, args[0])
+	if err != nil {
+		return err
+	}
+	if user.IsRemote() {
+		return fmt.Errorf("user %q is a remote user, their password can't be changed", user.Username)
+	}
+	if user.IsMagicLinkEnabled() {
+		return fmt.Errorf("user %q logs in with magic links, their password can't be changed", user.Username)
+	}
+
+	if password == "" {
+		fmt.Printf("New password: ")
+		if password, err = getPasswordFromStdin(); err != nil {
+			return errors.Wrap(err, "couldn't read password")
+		}
+	}
+
+	cfg := configStore.Get()
+	if !hashed {
+		if err := users.IsPasswordValidWithSettings(password, &cfg.PasswordSettings); err != nil {
+			return errors.Wrap(err, "invalid password")
+		}
+		if password, err = hashers.Hash(password); err != nil {
+			return errors.Wrap(err, "failed to hash password")
+		}
+	}
+
+	if err := ss.User().UpdatePassword(user.Id, password); err != nil {
+		return errors.Wrap(err, "changing user password failed")
+	}
+
+	if *cfg.ServiceSettings.TerminateSessionsOnPasswordChange {
+		if err := ss.Session().PermanentDeleteSessionsByUser(user.Id); err != nil {
+			return errors.Wrap(err, "failed to revoke the sessions of the user")
+		}
+	}
+
+	printer.PrintT("Password for user {{.Username}} successfully changed", user)
+	return nil
+}
+
+func offlineSessionRevokeCmdF(ss store.Store, _ *config.Store, cmd *cobra.Command, args []string) error {
+	if all, _ := cmd.Flags().GetBool("all"); all {
+		if len(args) > 0 {
+			return errors.New("users can't be given along with --all")
+		}
+		if err := ss.Session().RemoveAllSessions(); err != nil {
+			return errors.Wrap(err, "failed to revoke all the sessions")
+		}
+		printer.Print("All the sessions were revoked")
+		return nil
+	}
+
+	if len(args) == 0 {
+		return errors.New("expected at least one user, or --all")
+	}
+
+	var result *multierror.Error
+	for _, arg := range args {
+		user, err := getOfflineUser(ss, arg)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		if err := ss.Session().PermanentDeleteSessionsByUser(user.Id); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to revoke the sessions of user %q: %w", user.Username, err))
+			continue
+		}
+		printer.PrintT("Sessions of user {{.Username}} revoked", user)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func offlinePluginDisableCmdF(_ store.Store, configStore *config.Store, _ *cobra.Command, args []string) error {
+	cfg := configStore.GetNoEnv()
+	if cfg.PluginSettings.PluginStates == nil {
+		cfg.PluginSettings.PluginStates = make(map[string]*model.PluginState)
+	}
+	for _, pluginID := range args {
+		cfg.PluginSettings.PluginStates[pluginID] = &model.PluginState{Enable: false}
+	}
+
+	if _, _, err := configStore.Set(cfg); err != nil {
+		return errors.Wrap(err, "failed to save the configuration")
+	}
+
+	for _, pluginID := range args {
+		printer.Print("Disabled plugin: " + pluginID)
+	}
+	return nil
+}
+
+func offlineJobCancelCmdF(ss store.Store, _ *config.Store, _ *cobra.Command, args []string) error {
+	logger, err := mlog.NewLogger()
+	if err != nil {
+		return errors.Wrap(err, "failed to create logger")
+	}
+	defer func() {
+		_ = logger.Shutdown()
+	}()
+	rctx := request.EmptyContext(logger)
+
+	var result *multierror.Error
+	for _, jobID := range args {
+		job, err := ss.Job().Get(rctx, jobID)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to get job %q: %w", jobID, err))
+			continue
+		}
+
+		switch job.Status {
+		case model.JobStatusPending, model.JobStatusInProgress, model.JobStatusCancelRequested:
+		default:
+			result = multierror.Append(result, fmt.Errorf("job %q can't be canceled, its status is %s", jobID, job.Status))
+			continue
+		}
+
+		if _, err := ss.Job().UpdateStatus(job.Id, model.JobStatusCanceled); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to cancel job %q: %w", jobID, err))
+			continue
+		}
+		printer.Print("Canceled job: " + jobID)
+	}
+
+	return result.ErrorOrNil()
+}
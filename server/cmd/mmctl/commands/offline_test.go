@@ -0,0 +1,185 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+	"github.com/mattermost/mattermost/server/v8/config"
+)
+
+func (s *MmctlUnitTestSuite) TestCheckNoLiveNode() {
+	s.Run("no node", func() {
+		mockStore, cdStore := &mocks.Store{}, &mocks.ClusterDiscoveryStore{}
+		mockStore.On("ClusterDiscovery").Return(cdStore)
+		cdStore.On("GetAll", model.CDSTypeNode, model.CDSNodeClusterName).Return([]*model.ClusterDiscovery{}, nil)
+
+		s.Require().NoError(checkNoLiveNode(mockStore))
+	})
+
+	s.Run("stale node", func() {
+		mockStore, cdStore := &mocks.Store{}, &mocks.ClusterDiscoveryStore{}
+		mockStore.On("ClusterDiscovery").Return(cdStore)
+		cdStore.On("GetAll", model.CDSTypeNode, model.CDSNodeClusterName).Return([]*model.ClusterDiscovery{
+			{Hostname: "node1:42", LastPingAt: model.GetMillis() - 2*model.CDSNodeLiveAfterMillis},
+		}, nil)
+
+		s.Require().NoError(checkNoLiveNode(mockStore))
+	})
+
+	s.Run("live node", func() {
+		mockStore, cdStore := &mocks.Store{}, &mocks.ClusterDiscoveryStore{}
+		mockStore.On("ClusterDiscovery").Return(cdStore)
+		cdStore.On("GetAll", model.CDSTypeNode, model.CDSNodeClusterName).Return([]*model.ClusterDiscovery{
+			{Hostname: "node1:42", LastPingAt: model.GetMillis() - 2*model.CDSNodeLiveAfterMillis},
+			{Hostname: "node2:42", LastPingAt: model.GetMillis()},
+		}, nil)
+
+		err := checkNoLiveNode(mockStore)
+		s.Require().EqualError(err, "the server is running on node2:42, stop all the nodes before running offline commands")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestOfflineConfigSetCmdF() {
+	s.Run("set a valid value", func() {
+		printer.Clean()
+		configStore := config.NewTestMemoryStore()
+
+		err := offlineConfigSetCmdF(nil, configStore, &cobra.Command{}, []string{"ServiceSettings.SiteURL", "https://example.com"})
+		s.Require().NoError(err)
+		s.Require().Equal("https://example.com", *configStore.Get().ServiceSettings.SiteURL)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("refuse an invalid value", func() {
+		printer.Clean()
+		configStore := config.NewTestMemoryStore()
+
+		err := offlineConfigSetCmdF(nil, configStore, &cobra.Command{}, []string{"ServiceSettings.SiteURL", "invalid"})
+		s.Require().Error(err)
+		s.Require().Empty(*configStore.Get().ServiceSettings.SiteURL)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestOfflinePluginDisableCmdF() {
+	printer.Clean()
+	configStore := config.NewTestMemoryStore()
+
+	err := offlinePluginDisableCmdF(nil, configStore, &cobra.Command{}, []string{"com.example.plugin"})
+	s.Require().NoError(err)
+	s.Require().False(configStore.Get().PluginSettings.PluginStates["com.example.plugin"].Enable)
+	s.Require().Equal([]any{"Disabled plugin: com.example.plugin"}, printer.GetLines())
+}
+
+func (s *MmctlUnitTestSuite) TestOfflineUserChangePasswordCmdF() {
+	mockUserID := model.NewId()
+	mockUser := &model.User{Id: mockUserID, Username: "user"}
+
+	newCmd := func(password string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("password", password, "")
+		cmd.Flags().Bool("hashed", false, "")
+		return cmd
+	}
+
+	s.Run("change the password and revoke the sessions", func() {
+		printer.Clean()
+		configStore := config.NewTestMemoryStore()
+		mockStore, userStore, sessionStore := &mocks.Store{}, &mocks.UserStore{}, &mocks.SessionStore{}
+		mockStore.On("User").Return(userStore)
+		mockStore.On("Session").Return(sessionStore)
+		userStore.On("Get", mock.Anything, mockUserID).Return(mockUser, nil)
+		userStore.On("UpdatePassword", mockUserID, mock.MatchedBy(func(hash string) bool {
+			return hash != "" && hash != "new-password"
+		})).Return(nil)
+		sessionStore.On("PermanentDeleteSessionsByUser", mockUserID).Return(nil)
+
+		err := offlineUserChangePasswordCmdF(mockStore, configStore, newCmd("new-password"), []string{mockUserID})
+		s.Require().NoError(err)
+		userStore.AssertExpectations(s.T())
+		sessionStore.AssertExpectations(s.T())
+	})
+
+	s.Run("refuse a weak password", func() {
+		printer.Clean()
+		configStore := config.NewTestMemoryStore()
+		mockStore, userStore := &mocks.Store{}, &mocks.UserStore{}
+		mockStore.On("User").Return(userStore)
+		userStore.On("GetByUsername", "user").Return(mockUser, nil)
+
+		err := offlineUserChangePasswordCmdF(mockStore, configStore, newCmd("a"), []string{"@user"})
+		s.Require().Error(err)
+		userStore.AssertNotCalled(s.T(), "UpdatePassword", mock.Anything, mock.Anything)
+	})
+
+	s.Run("unknown user", func() {
+		printer.Clean()
+		mockStore, userStore := &mocks.Store{}, &mocks.UserStore{}
+		mockStore.On("User").Return(userStore)
+		userStore.On("GetByEmail", userEmail).Return(nil, store.NewErrNotFound("User", userEmail))
+
+		err := offlineUserChangePasswordCmdF(mockStore, config.NewTestMemoryStore(), newCmd("new-password"), []string{userEmail})
+		s.Require().EqualError(err, `user "`+userEmail+`" not found`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestOfflineSessionRevokeCmdF() {
+	newCmd := func(all bool) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("all", all, "")
+		return cmd
+	}
+
+	s.Run("revoke the sessions of a user", func() {
+		printer.Clean()
+		mockStore, userStore, sessionStore := &mocks.Store{}, &mocks.UserStore{}, &mocks.SessionStore{}
+		mockStore.On("User").Return(userStore)
+		mockStore.On("Session").Return(sessionStore)
+		userStore.On("GetByUsername", "user").Return(&model.User{Id: userID, Username: "user"}, nil)
+		sessionStore.On("PermanentDeleteSessionsByUser", userID).Return(nil)
+
+		err := offlineSessionRevokeCmdF(mockStore, nil, newCmd(false), []string{"user"})
+		s.Require().NoError(err)
+		sessionStore.AssertExpectations(s.T())
+	})
+
+	s.Run("revoke all the sessions", func() {
+		printer.Clean()
+		mockStore, sessionStore := &mocks.Store{}, &mocks.SessionStore{}
+		mockStore.On("Session").Return(sessionStore)
+		sessionStore.On("RemoveAllSessions").Return(nil)
+
+		err := offlineSessionRevokeCmdF(mockStore, nil, newCmd(true), nil)
+		s.Require().NoError(err)
+		sessionStore.AssertExpectations(s.T())
+	})
+
+	s.Run("users along with all", func() {
+		printer.Clean()
+
+		err := offlineSessionRevokeCmdF(&mocks.Store{}, nil, newCmd(true), []string{"user"})
+		s.Require().EqualError(err, "users can't be given along with --all")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestOfflineJobCancelCmdF() {
+	pendingJobID, doneJobID := model.NewId(), model.NewId()
+
+	printer.Clean()
+	mockStore, jobStore := &mocks.Store{}, &mocks.JobStore{}
+	mockStore.On("Job").Return(jobStore)
+	jobStore.On("Get", mock.Anything, pendingJobID).Return(&model.Job{Id: pendingJobID, Status: model.JobStatusInProgress}, nil)
+	jobStore.On("Get", mock.Anything, doneJobID).Return(&model.Job{Id: doneJobID, Status: model.JobStatusSuccess}, nil)
+	jobStore.On("UpdateStatus", pendingJobID, model.JobStatusCanceled).Return(&model.Job{}, nil)
+
+	err := offlineJobCancelCmdF(mockStore, nil, &cobra.Command{}, []string{pendingJobID, doneJobID})
+	s.Require().ErrorContains(err, `job "`+doneJobID+`" can't be canceled, its status is success`)
+	s.Require().Equal([]any{"Canceled job: " + pendingJobID}, printer.GetLines())
+	jobStore.AssertNotCalled(s.T(), "UpdateStatus", doneJobID, mock.Anything)
+}
@@ -6,6 +6,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
@@ -22,12 +23,25 @@ var PluginCmd = &cobra.Command{
 }
 
 var PluginAddCmd = &cobra.Command{
-	Use:     "add [plugins]",
-	Short:   "Add plugins",
-	Long:    "Add plugins to your Mattermost server.",
-	Example: `  plugin add hovercardexample.tar.gz pluginexample.tar.gz`,
-	RunE:    withClient(pluginAddCmdF),
-	Args:    cobra.MinimumNArgs(1),
+	Use:   "add [plugins]",
+	Short: "Add plugins",
+	Long:  "Add plugins to your Mattermost server.",
+	Example: `  plugin add hovercardexample.tar.gz pluginexample.tar.gz
+
+  # Upload the signatures of the plugins, found in hovercardexample.tar.gz.sig and pluginexample.tar.gz.sig
+  plugin add --signed hovercardexample.tar.gz pluginexample.tar.gz`,
+	RunE: withClient(pluginAddCmdF),
+	Args: cobra.MinimumNArgs(1),
+}
+
+var PluginVerifyCmd = &cobra.Command{
+	Use:   "verify <plugin> <signature>",
+	Short: "Verify the signature of a plugin",
+	Long:  "Check a plugin compressed in a .tar.gz file and its signature against the public keys and trust policies of your Mattermost server, reporting the signer. The plugin isn't installed.",
+	Example: `  # Check who signed a plugin, and whether the server trusts the signer for it
+  $ mmctl plugin verify mattermost-plugin.tar.gz mattermost-plugin.tar.gz.sig`,
+	RunE: withClient(pluginVerifyCmdF),
+	Args: cobra.ExactArgs(2),
 }
 
 var PluginInstallURLCmd = &cobra.Command{
@@ -80,6 +94,7 @@ var PluginListCmd = &cobra.Command{
 
 func init() {
 	PluginAddCmd.Flags().BoolP("force", "f", false, "overwrite a previously installed plugin with the same ID, if any")
+	PluginAddCmd.Flags().Bool("signed", false, "upload the signature of each plugin, read from the plugin path with a .sig extension, for the server to verify")
 	PluginInstallURLCmd.Flags().BoolP("force", "f", false, "overwrite a previously installed plugin with the same ID, if any")
 
 	PluginCmd.AddCommand(
@@ -89,12 +104,14 @@ func init() {
 		PluginEnableCmd,
 		PluginDisableCmd,
 		PluginListCmd,
+		PluginVerifyCmd,
 	)
 	RootCmd.AddCommand(PluginCmd)
 }
 
 func pluginAddCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
+	signed, _ := cmd.Flags().GetBool("signed")
 	var multiErr *multierror.Error
 
 	for i, plugin := range args {
@@ -103,9 +120,12 @@ func pluginAddCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		if force {
+		switch {
+		case signed:
+			err = uploadSignedPlugin(c, fileReader, plugin+".sig", force)
+		case force:
 			_, _, err = c.UploadPluginForced(context.TODO(), fileReader)
-		} else {
+		default:
 			_, _, err = c.UploadPlugin(context.TODO(), fileReader)
 		}
 
@@ -121,6 +141,51 @@ func pluginAddCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	return multiErr.ErrorOrNil()
 }
 
+func uploadSignedPlugin(c client.Client, plugin io.Reader, signaturePath string, force bool) error {
+	signatureReader, err := os.Open(signaturePath)
+	if err != nil {
+		return err
+	}
+	defer signatureReader.Close()
+
+	_, _, err = c.UploadSignedPlugin(context.TODO(), plugin, signatureReader, force)
+	return err
+}
+
+func pluginVerifyCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	pluginReader, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer pluginReader.Close()
+
+	signatureReader, err := os.Open(args[1])
+	if err != nil {
+		return err
+	}
+	defer signatureReader.Close()
+
+	verification, _, err := c.VerifyPlugin(context.TODO(), pluginReader, signatureReader)
+	if err != nil {
+		return fmt.Errorf("unable to verify plugin: %w", err)
+	}
+
+	printer.PrintT(`Plugin: {{.PluginId}} {{.PluginVersion}}
+{{- with .Signer}}
+Signer: {{range $i, $identity := .Identities}}{{if $i}}, {{end}}{{$identity}}{{end}}
+Key ID: {{.KeyId}}
+Fingerprint: {{.Fingerprint}}
+Public key: {{if .PublicKeyFile}}{{.PublicKeyFile}}{{else}}Mattermost{{end}}
+{{- end}}
+Trusted: {{.Trusted}}{{if .Reason}} ({{.Reason}}){{end}}`, verification)
+
+	if !verification.Trusted {
+		return errors.New("the plugin signature is not trusted by the server")
+	}
+
+	return nil
+}
+
 func pluginInstallURLCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
 	var multiErr *multierror.Error
@@ -125,6 +125,121 @@ func (s *MmctlUnitTestSuite) TestPluginAddCmd() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestPluginAddSignedCmd() {
+	s.Run("Add 1 signed plugin", func() {
+		printer.Clean()
+		tmpFile, err := os.CreateTemp("", "tmpPlugin")
+		s.Require().Nil(err)
+		defer os.Remove(tmpFile.Name())
+		signatureFile, err := os.Create(tmpFile.Name() + ".sig")
+		s.Require().Nil(err)
+		defer os.Remove(signatureFile.Name())
+
+		pluginName := tmpFile.Name()
+
+		s.client.
+			EXPECT().
+			UploadSignedPlugin(context.TODO(), gomock.AssignableToTypeOf(tmpFile), gomock.AssignableToTypeOf(signatureFile), false).
+			Return(&model.Manifest{}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("signed", true, "")
+
+		err = pluginAddCmdF(s.client, cmd, []string{pluginName})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("Added plugin: "+pluginName, printer.GetLines()[0])
+	})
+
+	s.Run("Add 1 signed plugin without signature", func() {
+		printer.Clean()
+		tmpFile, err := os.CreateTemp("", "tmpPlugin")
+		s.Require().Nil(err)
+		defer os.Remove(tmpFile.Name())
+
+		pluginName := tmpFile.Name()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("signed", true, "")
+
+		err = pluginAddCmdF(s.client, cmd, []string{pluginName})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+		s.Require().Len(printer.GetErrorLines(), 1)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestPluginVerifyCmd() {
+	tmpFile, err := os.CreateTemp("", "tmpPlugin")
+	s.Require().Nil(err)
+	defer os.Remove(tmpFile.Name())
+	signatureFile, err := os.CreateTemp("", "tmpPluginSignature")
+	s.Require().Nil(err)
+	defer os.Remove(signatureFile.Name())
+	args := []string{tmpFile.Name(), signatureFile.Name()}
+
+	s.Run("Trusted plugin", func() {
+		printer.Clean()
+		verification := &model.PluginSignatureVerification{
+			PluginId:      "com.example.internal",
+			PluginVersion: "1.0.0",
+			Signer: &model.PluginSigner{
+				PublicKeyFile: "internal.plugin.gpg",
+				KeyId:         "4C7C6562C192CC1F",
+				Fingerprint:   "F3FACE45E0DE642C8BD6A8E64C7C6562C192CC1F",
+				Identities:    []string{"Example <plugins@example.com>"},
+			},
+			Trusted: true,
+		}
+
+		s.client.
+			EXPECT().
+			VerifyPlugin(context.TODO(), gomock.AssignableToTypeOf(tmpFile), gomock.AssignableToTypeOf(signatureFile)).
+			Return(verification, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginVerifyCmdF(s.client, &cobra.Command{}, args)
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(verification, printer.GetLines()[0])
+	})
+
+	s.Run("Untrusted plugin", func() {
+		printer.Clean()
+		verification := &model.PluginSignatureVerification{
+			PluginId:      "com.example.internal",
+			PluginVersion: "1.0.0",
+			Reason:        "The signature doesn't match any trusted public key.",
+		}
+
+		s.client.
+			EXPECT().
+			VerifyPlugin(context.TODO(), gomock.AssignableToTypeOf(tmpFile), gomock.AssignableToTypeOf(signatureFile)).
+			Return(verification, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginVerifyCmdF(s.client, &cobra.Command{}, args)
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(verification, printer.GetLines()[0])
+	})
+
+	s.Run("Server error", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			VerifyPlugin(context.TODO(), gomock.AssignableToTypeOf(tmpFile), gomock.AssignableToTypeOf(signatureFile)).
+			Return(nil, &model.Response{StatusCode: http.StatusForbidden}, errors.New("mock error")).
+			Times(1)
+
+		err := pluginVerifyCmdF(s.client, &cobra.Command{}, args)
+		s.Require().ErrorContains(err, "mock error")
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestPluginInstallUrlCmd() {
 	s.Run("Install multiple plugins", func() {
 		printer.Clean()
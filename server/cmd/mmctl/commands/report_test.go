@@ -7,6 +7,9 @@ package commands
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/pkg/errors"
@@ -362,3 +365,136 @@ func (s *MmctlUnitTestSuite) TestReportPostsCmdF() {
 		s.Len(printer.GetErrorLines(), 0)
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestReportAccessCmdF() {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("format", "csv", "")
+		cmd.Flags().Int("inactive-days", model.AccessReportDefaultInactiveDays, "")
+		cmd.Flags().String("output-file", "", "")
+		cmd.Flags().Bool("no-wait", false, "")
+		cmd.Flags().String("job-id", "", "")
+		cmd.Flags().Duration("poll-interval", time.Millisecond, "")
+		return cmd
+	}
+
+	s.Run("invalid format", func() {
+		printer.Clean()
+
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("format", "xml"))
+
+		err := reportAccessCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, "format must be one of [csv json]")
+	})
+
+	s.Run("invalid inactive-days", func() {
+		printer.Clean()
+
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("inactive-days", "-1"))
+
+		err := reportAccessCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, "inactive-days must be a positive number")
+	})
+
+	s.Run("start the job without waiting", func() {
+		printer.Clean()
+		mockJob := &model.Job{Id: model.NewId(), Type: model.JobTypeExportAccessReport, Status: model.JobStatusPending}
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), &model.Job{
+				Type: model.JobTypeExportAccessReport,
+				Data: map[string]string{"format": "json", "inactive_days": "30"},
+			}).
+			Return(mockJob, &model.Response{}, nil).
+			Times(1)
+
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("format", "json"))
+		s.Require().NoError(cmd.Flags().Set("inactive-days", "30"))
+		s.Require().NoError(cmd.Flags().Set("no-wait", "true"))
+
+		err := reportAccessCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(mockJob, printer.GetLines()[0])
+	})
+
+	s.Run("wait for the job and download the report", func() {
+		printer.Clean()
+		jobID := model.NewId()
+		outputFile := filepath.Join(s.T().TempDir(), "report.csv")
+
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), &model.Job{
+				Type: model.JobTypeExportAccessReport,
+				Data: map[string]string{"format": "csv", "inactive_days": "90"},
+			}).
+			Return(&model.Job{Id: jobID, Type: model.JobTypeExportAccessReport, Status: model.JobStatusPending}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), jobID).
+			Return(&model.Job{Id: jobID, Type: model.JobTypeExportAccessReport, Status: model.JobStatusInProgress}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), jobID).
+			Return(&model.Job{Id: jobID, Type: model.JobTypeExportAccessReport, Status: model.JobStatusSuccess}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DownloadJob(context.TODO(), jobID).
+			Return([]byte("Id,Username\n"), &model.Response{}, nil).
+			Times(1)
+
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("output-file", outputFile))
+
+		err := reportAccessCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+
+		data, err := os.ReadFile(outputFile)
+		s.Require().NoError(err)
+		s.Require().Equal("Id,Username\n", string(data))
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(map[string]string{"filename": outputFile}, printer.GetLines()[0])
+	})
+
+	s.Run("existing job that failed", func() {
+		printer.Clean()
+		jobID := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), jobID).
+			Return(&model.Job{Id: jobID, Type: model.JobTypeExportAccessReport, Status: model.JobStatusError}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("job-id", jobID))
+
+		err := reportAccessCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, "access report job "+jobID+" finished with status error")
+	})
+
+	s.Run("existing job of another type", func() {
+		printer.Clean()
+		jobID := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), jobID).
+			Return(&model.Job{Id: jobID, Type: model.JobTypeExportUsersToCSV}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := newCmd()
+		s.Require().NoError(cmd.Flags().Set("job-id", jobID))
+
+		err := reportAccessCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, "job "+jobID+" is not an access report job")
+	})
+}
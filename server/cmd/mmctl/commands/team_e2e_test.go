@@ -487,7 +487,7 @@ func (s *MmctlE2ETestSuite) TestRestoreTeamsCmd() {
 		printer.Clean()
 
 		team := s.th.CreateTeam(s.T())
-		appErr := s.th.App.SoftDeleteTeam(team.Id)
+		appErr := s.th.App.SoftDeleteTeam(s.th.Context, team.Id)
 		s.Require().Nil(appErr)
 
 		err := restoreTeamsCmdF(c, &cobra.Command{}, []string{team.Name})
@@ -515,7 +515,7 @@ func (s *MmctlE2ETestSuite) TestRestoreTeamsCmd() {
 		printer.Clean()
 
 		team := s.th.CreateTeamWithClient(s.T(), s.th.SystemAdminClient)
-		appErr := s.th.App.SoftDeleteTeam(team.Id)
+		appErr := s.th.App.SoftDeleteTeam(s.th.Context, team.Id)
 		s.Require().Nil(appErr)
 
 		err := restoreTeamsCmdF(s.th.Client, &cobra.Command{}, []string{team.Name})
@@ -0,0 +1,398 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer/human"
+)
+
+const (
+	topJobsPerPage         = 200
+	topMinRefreshInterval  = time.Second
+	topWebsocketRowName    = "total_websocket_connections"
+	topClearScreenSequence = "\033[H\033[2J"
+)
+
+var TopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Display a live dashboard of the server health",
+	Long: `Display a live terminal dashboard with the cluster nodes, the job queue, the number of websocket connections, the health of the plugins and the latest server logs.
+
+The dashboard is refreshed periodically and every time the server sends a websocket event that affects one of its sections. Press "r" to refresh it immediately and "q" or CTRL+C to exit.`,
+	Example: `  # Start the dashboard, refreshing every 10 seconds
+  top
+
+  # Refresh every 30 seconds and show the last 20 log lines
+  top --interval 30s --log-lines 20
+
+  # Print a single snapshot and exit
+  top --once`,
+	Args: cobra.NoArgs,
+	RunE: withClient(topCmdF),
+}
+
+func init() {
+	TopCmd.Flags().Duration("interval", 10*time.Second, "Interval between two refreshes of the dashboard.")
+	TopCmd.Flags().Int("log-lines", 10, "Number of log lines to display.")
+	TopCmd.Flags().Bool("once", false, "Print a single snapshot of the dashboard and exit.")
+
+	RootCmd.AddCommand(TopCmd)
+}
+
+// topSnapshot holds the state of every section of the dashboard at a
+// given point in time. A section that couldn't be fetched keeps the
+// error in its own field so the rest of the dashboard can still be
+// displayed.
+type topSnapshot struct {
+	Timestamp            int64                `json:"timestamp"`
+	Nodes                []*model.ClusterInfo `json:"nodes"`
+	NodesError           string               `json:"nodes_error,omitempty"`
+	Jobs                 []*model.Job         `json:"jobs"`
+	JobsError            string               `json:"jobs_error,omitempty"`
+	WebsocketConnections int64                `json:"websocket_connections"`
+	WebsocketError       string               `json:"websocket_error,omitempty"`
+	Plugins              model.PluginStatuses `json:"plugins"`
+	PluginsError         string               `json:"plugins_error,omitempty"`
+	Logs                 []string             `json:"logs"`
+	LogsError            string               `json:"logs_error,omitempty"`
+	Events               map[string]int       `json:"events,omitempty"`
+}
+
+func topCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval < topMinRefreshInterval {
+		return fmt.Errorf("interval must be at least %s", topMinRefreshInterval)
+	}
+	logLines, _ := cmd.Flags().GetInt("log-lines")
+	if logLines < 0 {
+		return errors.New("log-lines must be a positive number")
+	}
+
+	if once, _ := cmd.Flags().GetBool("once"); once {
+		printer.SetSingle(true)
+		printer.PrintT("{{.}}", collectTopSnapshot(context.TODO(), c, logLines))
+		return nil
+	}
+
+	if err := checkInteractiveTerminal(); err != nil {
+		return errors.Wrap(err, "the dashboard needs an interactive terminal, use --once to print a single snapshot")
+	}
+
+	return runTopDashboard(c, interval, logLines)
+}
+
+func runTopDashboard(c client.Client, interval time.Duration, logLines int) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// raw mode lets us read single key presses; if stdin is not a
+	// terminal the dashboard still works, only without key bindings
+	keys := make(chan byte)
+	if oldState, err := term.MakeRaw(int(os.Stdin.Fd())); err == nil {
+		defer func() {
+			_ = term.Restore(int(os.Stdin.Fd()), oldState)
+		}()
+		go readTopKeys(ctx, keys)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	events := make(map[string]int)
+	var wsEvents chan *model.WebSocketEvent
+	// websockets are not available through the local socket
+	if !viper.GetBool("local") {
+		if wsClient, err := InitWebSocketClient(); err == nil {
+			if appErr := wsClient.Connect(); appErr == nil {
+				wsClient.Listen()
+				defer wsClient.Close()
+				wsEvents = wsClient.EventChannel
+			}
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	draw := func() {
+		snapshot := collectTopSnapshot(ctx, c, logLines)
+		snapshot.Events = events
+		header := fmt.Sprintf("mmctl top - refresh every %s - press r to refresh, q to quit\n\n", interval)
+		// in raw mode the terminal doesn't translate newlines
+		out := strings.ReplaceAll(header+snapshot.String(), "\n", "\r\n")
+		fmt.Fprint(os.Stdout, topClearScreenSequence+out)
+	}
+
+	draw()
+	for {
+		select {
+		case <-signals:
+			return nil
+		case key := <-keys:
+			switch key {
+			case 'q', 'Q', 3: // 3 is CTRL+C in raw mode
+				return nil
+			case 'r', 'R':
+				draw()
+			}
+		case event, ok := <-wsEvents:
+			if !ok {
+				wsEvents = nil
+				continue
+			}
+			events[string(event.EventType())]++
+			if isTopRefreshEvent(event.EventType()) {
+				draw()
+			}
+		case <-ticker.C:
+			draw()
+		}
+	}
+}
+
+// readTopKeys forwards the key presses read from stdin until ctx is
+// cancelled. A read that is blocked when the dashboard exits returns
+// with the next key press or when stdin is closed.
+func readTopKeys(ctx context.Context, keys chan<- byte) {
+	buf := make([]byte, 1)
+	for ctx.Err() == nil {
+		if _, err := os.Stdin.Read(buf); err != nil {
+			return
+		}
+		select {
+		case keys <- buf[0]:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// isTopRefreshEvent returns true for the websocket events that change
+// the information displayed in one of the dashboard sections.
+func isTopRefreshEvent(eventType model.WebsocketEventType) bool {
+	switch eventType {
+	case model.WebsocketEventPluginStatusesChanged,
+		model.WebsocketEventPluginEnabled,
+		model.WebsocketEventPluginDisabled,
+		model.WebsocketEventConfigChanged,
+		model.WebsocketEventLicenseChanged:
+		return true
+	}
+	return false
+}
+
+func collectTopSnapshot(ctx context.Context, c client.Client, logLines int) *topSnapshot {
+	snapshot := &topSnapshot{Timestamp: model.GetMillis()}
+
+	if nodes, _, err := c.GetClusterStatus(ctx); err != nil {
+		snapshot.NodesError = err.Error()
+	} else {
+		snapshot.Nodes = nodes
+	}
+
+	for _, status := range []string{model.JobStatusPending, model.JobStatusInProgress, model.JobStatusCancelRequested} {
+		jobs, _, err := c.GetJobs(ctx, "", status, 0, topJobsPerPage)
+		if err != nil {
+			snapshot.JobsError = err.Error()
+			break
+		}
+		snapshot.Jobs = append(snapshot.Jobs, jobs...)
+	}
+
+	if rows, _, err := c.GetAnalyticsOld(ctx, "standard", ""); err != nil {
+		snapshot.WebsocketError = err.Error()
+	} else {
+		for _, row := range rows {
+			if row.Name == topWebsocketRowName {
+				snapshot.WebsocketConnections = int64(row.Value)
+			}
+		}
+	}
+
+	if plugins, _, err := c.GetPluginStatuses(ctx); err != nil {
+		snapshot.PluginsError = err.Error()
+	} else {
+		sort.Slice(plugins, func(i, j int) bool {
+			if plugins[i].PluginId == plugins[j].PluginId {
+				return plugins[i].ClusterId < plugins[j].ClusterId
+			}
+			return plugins[i].PluginId < plugins[j].PluginId
+		})
+		snapshot.Plugins = plugins
+	}
+
+	if logLines > 0 {
+		if logs, _, err := c.GetLogs(ctx, 0, logLines); err != nil {
+			snapshot.LogsError = err.Error()
+		} else {
+			snapshot.Logs = logs
+		}
+	}
+
+	return snapshot
+}
+
+// String renders the snapshot as the text of the dashboard.
+func (s *topSnapshot) String() string {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Updated at %s\n\n", time.UnixMilli(s.Timestamp).Format(time.DateTime))
+
+	fmt.Fprintf(w, "CLUSTER NODES (%d)\n", len(s.Nodes))
+	switch {
+	case s.NodesError != "":
+		fmt.Fprintf(w, "  unable to fetch cluster status: %s\n", s.NodesError)
+	case len(s.Nodes) == 0:
+		fmt.Fprintln(w, "  single node, clustering is disabled")
+	default:
+		fmt.Fprintln(w, "  HOSTNAME\tIP ADDRESS\tVERSION\tSCHEMA\tCONFIG HASH")
+		for _, node := range s.Nodes {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", node.Hostname, node.IPAddress, node.Version, node.SchemaVersion, node.ConfigHash)
+		}
+	}
+	fmt.Fprintln(w)
+
+	s.writeJobs(w)
+	fmt.Fprintln(w)
+
+	if s.WebsocketError != "" {
+		fmt.Fprintf(w, "WEBSOCKET CONNECTIONS\n  unable to fetch websocket connections: %s\n", s.WebsocketError)
+	} else {
+		fmt.Fprintf(w, "WEBSOCKET CONNECTIONS: %d\n", s.WebsocketConnections)
+	}
+	fmt.Fprintln(w)
+
+	s.writePlugins(w)
+	fmt.Fprintln(w)
+
+	if len(s.Events) > 0 {
+		fmt.Fprintln(w, "WEBSOCKET EVENTS RECEIVED")
+		eventTypes := make([]string, 0, len(s.Events))
+		for eventType := range s.Events {
+			eventTypes = append(eventTypes, eventType)
+		}
+		sort.Strings(eventTypes)
+		for _, eventType := range eventTypes {
+			fmt.Fprintf(w, "  %s\t%d\n", eventType, s.Events[eventType])
+		}
+		fmt.Fprintln(w)
+	}
+	_ = w.Flush()
+
+	fmt.Fprintf(&b, "LOGS (%d)\n", len(s.Logs))
+	if s.LogsError != "" {
+		fmt.Fprintf(&b, "  unable to fetch logs: %s\n", s.LogsError)
+	} else {
+		human.ProcessLogs(strings.NewReader(strings.Join(s.Logs, "")), human.NewSimpleWriter(&b))
+	}
+
+	return b.String()
+}
+
+func (s *topSnapshot) writeJobs(w *tabwriter.Writer) {
+	if s.JobsError != "" {
+		fmt.Fprintf(w, "JOB QUEUE\n  unable to fetch jobs: %s\n", s.JobsError)
+		return
+	}
+
+	countsByType := make(map[string]map[string]int)
+	for _, job := range s.Jobs {
+		if countsByType[job.Type] == nil {
+			countsByType[job.Type] = make(map[string]int)
+		}
+		countsByType[job.Type][job.Status]++
+	}
+
+	fmt.Fprintf(w, "JOB QUEUE (%d)\n", len(s.Jobs))
+	if len(s.Jobs) == 0 {
+		fmt.Fprintln(w, "  no pending or running jobs")
+		return
+	}
+
+	jobTypes := make([]string, 0, len(countsByType))
+	for jobType := range countsByType {
+		jobTypes = append(jobTypes, jobType)
+	}
+	sort.Strings(jobTypes)
+
+	fmt.Fprintln(w, "  TYPE\tPENDING\tIN PROGRESS\tCANCEL REQUESTED")
+	for _, jobType := range jobTypes {
+		counts := countsByType[jobType]
+		fmt.Fprintf(w, "  %s\t%d\t%d\t%d\n", jobType, counts[model.JobStatusPending], counts[model.JobStatusInProgress], counts[model.JobStatusCancelRequested])
+	}
+
+	for _, job := range s.Jobs {
+		if job.Status != model.JobStatusInProgress {
+			continue
+		}
+		fmt.Fprintf(w, "  > %s\t%s\t%d%%\tstarted %s\n", job.Id, job.Type, job.Progress, time.UnixMilli(job.StartAt).Format(time.DateTime))
+	}
+}
+
+func (s *topSnapshot) writePlugins(w *tabwriter.Writer) {
+	if s.PluginsError != "" {
+		fmt.Fprintf(w, "PLUGINS\n  unable to fetch plugin statuses: %s\n", s.PluginsError)
+		return
+	}
+
+	running := 0
+	for _, plugin := range s.Plugins {
+		if plugin.State == model.PluginStateRunning {
+			running++
+		}
+	}
+
+	fmt.Fprintf(w, "PLUGINS (%d running, %d not running)\n", running, len(s.Plugins)-running)
+	if len(s.Plugins) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "  ID\tVERSION\tNODE\tSTATE")
+	for _, plugin := range s.Plugins {
+		state := pluginStateName(plugin.State)
+		if plugin.Error != "" {
+			state += ": " + plugin.Error
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", plugin.PluginId, plugin.Version, plugin.ClusterId, state)
+	}
+}
+
+func pluginStateName(state int) string {
+	switch state {
+	case model.PluginStateNotRunning:
+		return "not running"
+	case model.PluginStateStarting:
+		return "starting"
+	case model.PluginStateRunning:
+		return "running"
+	case model.PluginStateFailedToStart:
+		return "failed to start"
+	case model.PluginStateFailedToStayRunning:
+		return "failed to stay running"
+	case model.PluginStateStopping:
+		return "stopping"
+	}
+	return fmt.Sprintf("unknown (%d)", state)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestTopCmdF() {
+	newTopCmd := func(interval time.Duration, logLines int) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Duration("interval", interval, "")
+		cmd.Flags().Int("log-lines", logLines, "")
+		cmd.Flags().Bool("once", true, "")
+		return cmd
+	}
+
+	expectJobs := func(jobs []*model.Job) {
+		for _, status := range []string{model.JobStatusPending, model.JobStatusInProgress, model.JobStatusCancelRequested} {
+			var jobsForStatus []*model.Job
+			for _, job := range jobs {
+				if job.Status == status {
+					jobsForStatus = append(jobsForStatus, job)
+				}
+			}
+			s.client.
+				EXPECT().
+				GetJobs(context.TODO(), "", status, 0, topJobsPerPage).
+				Return(jobsForStatus, &model.Response{}, nil).
+				Times(1)
+		}
+	}
+
+	s.Run("should collect every section of the dashboard", func() {
+		printer.Clean()
+
+		nodes := []*model.ClusterInfo{{Id: model.NewId(), Hostname: "node1"}, {Id: model.NewId(), Hostname: "node2"}}
+		jobs := []*model.Job{
+			{Id: model.NewId(), Type: model.JobTypeDataRetention, Status: model.JobStatusPending},
+			{Id: model.NewId(), Type: model.JobTypeLdapSync, Status: model.JobStatusInProgress, Progress: 42},
+		}
+		plugins := model.PluginStatuses{
+			{PluginId: "plugin2", ClusterId: "node1", State: model.PluginStateFailedToStayRunning},
+			{PluginId: "plugin1", ClusterId: "node1", State: model.PluginStateRunning},
+		}
+		logs := []string{"log line 1\n", "log line 2\n"}
+
+		s.client.
+			EXPECT().
+			GetClusterStatus(context.TODO()).
+			Return(nodes, &model.Response{}, nil).
+			Times(1)
+		expectJobs(jobs)
+		s.client.
+			EXPECT().
+			GetAnalyticsOld(context.TODO(), "standard", "").
+			Return(model.AnalyticsRows{{Name: "post_count", Value: 100}, {Name: topWebsocketRowName, Value: 12}}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetPluginStatuses(context.TODO()).
+			Return(plugins, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetLogs(context.TODO(), 0, 2).
+			Return(logs, &model.Response{}, nil).
+			Times(1)
+
+		err := topCmdF(s.client, newTopCmd(time.Minute, 2), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Empty(printer.GetErrorLines())
+
+		snapshot := printer.GetLines()[0].(*topSnapshot)
+		s.Equal(nodes, snapshot.Nodes)
+		s.Equal(jobs, snapshot.Jobs)
+		s.Equal(int64(12), snapshot.WebsocketConnections)
+		s.Equal(logs, snapshot.Logs)
+		s.Require().Len(snapshot.Plugins, 2)
+		s.Equal("plugin1", snapshot.Plugins[0].PluginId)
+		s.Equal("plugin2", snapshot.Plugins[1].PluginId)
+
+		rendered := snapshot.String()
+		s.Contains(rendered, "CLUSTER NODES (2)")
+		s.Contains(rendered, "JOB QUEUE (2)")
+		s.Contains(rendered, "WEBSOCKET CONNECTIONS: 12")
+		s.Contains(rendered, "PLUGINS (1 running, 1 not running)")
+		s.Contains(rendered, "failed to stay running")
+	})
+
+	s.Run("should keep displaying the dashboard when a section fails", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetClusterStatus(context.TODO()).
+			Return(nil, &model.Response{}, errors.New("cluster error")).
+			Times(1)
+		expectJobs(nil)
+		s.client.
+			EXPECT().
+			GetAnalyticsOld(context.TODO(), "standard", "").
+			Return(nil, &model.Response{}, errors.New("analytics error")).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetPluginStatuses(context.TODO()).
+			Return(model.PluginStatuses{}, &model.Response{}, nil).
+			Times(1)
+
+		err := topCmdF(s.client, newTopCmd(time.Minute, 0), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		snapshot := printer.GetLines()[0].(*topSnapshot)
+		s.Equal("cluster error", snapshot.NodesError)
+		s.Equal("analytics error", snapshot.WebsocketError)
+		s.Empty(snapshot.JobsError)
+		s.Empty(snapshot.Logs)
+
+		rendered := snapshot.String()
+		s.Contains(rendered, "unable to fetch cluster status: cluster error")
+		s.Contains(rendered, "no pending or running jobs")
+	})
+
+	s.Run("should fail with an interval too short", func() {
+		printer.Clean()
+
+		err := topCmdF(s.client, newTopCmd(time.Millisecond, 10), []string{})
+		s.Require().EqualError(err, "interval must be at least 1s")
+		s.Empty(printer.GetLines())
+	})
+}
@@ -194,6 +194,15 @@ var ListUsersCmd = &cobra.Command{
 	Args:    cobra.NoArgs,
 }
 
+var UserPasswordHashersCmd = &cobra.Command{
+	Use:     "password-hashers",
+	Short:   "Report the password hashers in use",
+	Long:    "Show how many active users have their password hashed with each hashing method and set of parameters, and whether those passwords will be migrated to the configured hasher on their next login.",
+	Example: "  user password-hashers",
+	RunE:    withClient(userPasswordHashersCmdF),
+	Args:    cobra.NoArgs,
+}
+
 var VerifyUserEmailWithoutTokenCmd = &cobra.Command{
 	Use:     "verify [users]",
 	Short:   "Mark user's email as verified",
@@ -430,6 +439,7 @@ Global Flags:
 		DeleteAllUsersCmd,
 		SearchUserCmd,
 		ListUsersCmd,
+		UserPasswordHashersCmd,
 		VerifyUserEmailWithoutTokenCmd,
 		UserConvertCmd,
 		MigrateAuthCmd,
@@ -874,6 +884,20 @@ func listUsersCmdF(c client.Client, command *cobra.Command, args []string) error
 	return nil
 }
 
+func userPasswordHashersCmdF(c client.Client, command *cobra.Command, args []string) error {
+	usages, _, err := c.GetPasswordHasherUsage(context.TODO())
+	if err != nil {
+		return errors.Wrap(err, "Failed to fetch the password hashers usage")
+	}
+
+	tpl := `{{.Hasher}}{{if .Parameters}} ({{.Parameters}}){{end}}: {{.Users}} users{{if .Outdated}}, migrated on next login{{end}}`
+	for _, usage := range usages {
+		printer.PrintT(tpl, usage)
+	}
+
+	return nil
+}
+
 func verifyUserEmailWithoutTokenCmdF(c client.Client, cmd *cobra.Command, userArgs []string) error {
 	var result *multierror.Error
 	users, err := getUsersFromArgs(c, userArgs)
@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var UserSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync users from a CSV file",
+	Long: `Create, update and deactivate users, and set their roles and their team and channel memberships, from a CSV file.
+
+The first line of the file is a header naming the columns, among: username, email, password, first_name, last_name, nickname, position, locale, auth_service, auth_data, roles, active, teams and channels. The username column is required. Users are matched by email, or else by username. An empty cell, or a missing column, leaves the field of existing users untouched.
+
+The teams and channels columns list the exact memberships of the user, separated by ";". Channels are written as team/channel, and an ":admin" suffix makes the user an admin of the team or channel. Users are never removed from the default channel of their teams. Setting active to false deactivates the user.
+
+The sync runs on the server as a job. Each row is applied on its own, and a report with the outcome of every row is written once the job is finished. Rows already matching the state of the server are left unchanged, so the same file can be synced again safely.`,
+	Example: `  # sync the users of users.csv
+  $ mmctl user sync --csv users.csv
+
+  # check what the sync would change, without applying it
+  $ mmctl user sync --csv users.csv --dry-run --report report.csv`,
+	Args: cobra.NoArgs,
+	RunE: withClient(userSyncCmdF),
+}
+
+func init() {
+	UserSyncCmd.Flags().String("csv", "", "Path to the CSV file of the users to sync.")
+	_ = UserSyncCmd.MarkFlagRequired("csv")
+	UserSyncCmd.Flags().Bool("dry-run", false, "Report the changes without applying them.")
+	UserSyncCmd.Flags().String("report", "", "Path of the report of the sync. Defaults to user_sync_<job id>.csv.")
+	UserSyncCmd.Flags().Bool("no-wait", false, "Start the sync job and exit without waiting for it to finish.")
+	UserSyncCmd.Flags().Duration("poll-interval", 2*time.Second, "Interval between two checks of the sync job status.")
+
+	UserCmd.AddCommand(UserSyncCmd)
+}
+
+func userSyncCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	csvPath, _ := cmd.Flags().GetString("csv")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	file, err := os.Open(csvPath)
+	if err != nil {
+		return fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer file.Close()
+
+	// Check the header before uploading the file.
+	if _, appErr := model.NewUserSyncReader(file); appErr != nil {
+		return errors.New(appErr.Message)
+	}
+	if _, err = file.Seek(0, 0); err != nil {
+		return fmt.Errorf("failed to read CSV file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat CSV file: %w", err)
+	}
+
+	userID := "me"
+	if isLocal, _ := cmd.Flags().GetBool("local"); isLocal {
+		userID = model.UploadNoUserID
+	}
+
+	us, _, err := c.CreateUpload(context.TODO(), &model.UploadSession{
+		Filename: info.Name(),
+		FileSize: info.Size(),
+		Type:     model.UploadTypeImport,
+		UserId:   userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create upload session: %w", err)
+	}
+
+	finfo, _, err := c.UploadData(context.TODO(), us.Id, file)
+	if err != nil {
+		return fmt.Errorf("failed to upload data: %w", err)
+	}
+
+	job, _, err := c.CreateJob(context.TODO(), &model.Job{
+		Type: model.JobTypeUserSync,
+		Data: map[string]string{
+			"sync_file": us.Id + "_" + finfo.Name,
+			"dry_run":   strconv.FormatBool(dryRun),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user sync job: %w", err)
+	}
+
+	if noWait, _ := cmd.Flags().GetBool("no-wait"); noWait {
+		printer.PrintT("User sync job successfully created, ID: {{.Id}}", job)
+		return nil
+	}
+
+	pollInterval, _ := cmd.Flags().GetDuration("poll-interval")
+	job, err = waitForJob(c, job, pollInterval)
+	if err != nil {
+		return fmt.Errorf("failed to get user sync job: %w", err)
+	}
+	if job.Status != model.JobStatusSuccess {
+		if job.Data["error"] != "" {
+			return fmt.Errorf("user sync job %s finished with status %s: %s", job.Id, job.Status, job.Data["error"])
+		}
+		return fmt.Errorf("user sync job %s finished with status %s", job.Id, job.Status)
+	}
+
+	data, _, err := c.DownloadJob(context.TODO(), job.Id)
+	if err != nil {
+		return fmt.Errorf("failed to download the report of the sync: %w", err)
+	}
+	reportPath, _ := cmd.Flags().GetString("report")
+	if reportPath == "" {
+		reportPath = fmt.Sprintf("user_sync_%s.csv", job.Id)
+	}
+	if err := os.WriteFile(reportPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write the report of the sync: %w", err)
+	}
+
+	summary := map[string]string{
+		"created":     job.Data["created"],
+		"updated":     job.Data["updated"],
+		"deactivated": job.Data["deactivated"],
+		"unchanged":   job.Data["unchanged"],
+		"failed":      job.Data["failed"],
+		"report":      reportPath,
+	}
+	template := "Users created: {{.created}}, updated: {{.updated}}, deactivated: {{.deactivated}}, unchanged: {{.unchanged}}, failed: {{.failed}}. Report written to {{.report}}"
+	if dryRun {
+		template = "Dry run, no change applied. " + template
+	}
+	printer.PrintT(template, summary)
+
+	if job.Data["failed"] != "0" {
+		return fmt.Errorf("%s rows failed to sync, see the report for details", job.Data["failed"])
+	}
+	return nil
+}
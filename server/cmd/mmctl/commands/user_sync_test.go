@@ -0,0 +1,171 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestUserSyncCmdF() {
+	newCmd := func(csvPath string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("csv", csvPath, "")
+		cmd.Flags().Bool("dry-run", false, "")
+		cmd.Flags().String("report", "", "")
+		cmd.Flags().Bool("no-wait", false, "")
+		cmd.Flags().Duration("poll-interval", time.Millisecond, "")
+		return cmd
+	}
+
+	writeCSV := func(content string) string {
+		path := filepath.Join(s.T().TempDir(), "users.csv")
+		s.Require().NoError(os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	s.Run("invalid header", func() {
+		printer.Clean()
+		path := writeCSV("username,unknown\nuser1,value\n")
+
+		err := userSyncCmdF(s.client, newCmd(path), nil)
+		s.Require().EqualError(err, "model.user_sync.unknown_column.app_error")
+	})
+
+	s.Run("sync the users and download the report", func() {
+		printer.Clean()
+		content := "username,email,teams\nuser1,user1@example.com,myteam\n"
+		path := writeCSV(content)
+		reportPath := filepath.Join(s.T().TempDir(), "report.csv")
+		uploadID := model.NewId()
+		jobID := model.NewId()
+		jobData := model.StringMap{"created": "1", "updated": "0", "deactivated": "0", "unchanged": "0", "failed": "0"}
+
+		s.client.
+			EXPECT().
+			CreateUpload(context.TODO(), &model.UploadSession{
+				Filename: "users.csv",
+				FileSize: int64(len(content)),
+				Type:     model.UploadTypeImport,
+				UserId:   "me",
+			}).
+			Return(&model.UploadSession{Id: uploadID}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UploadData(context.TODO(), uploadID, gomock.Any()).
+			Return(&model.FileInfo{Name: "users.csv"}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), &model.Job{
+				Type: model.JobTypeUserSync,
+				Data: map[string]string{
+					"sync_file": uploadID + "_users.csv",
+					"dry_run":   "true",
+				},
+			}).
+			Return(&model.Job{Id: jobID, Status: model.JobStatusPending}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetJob(context.TODO(), jobID).
+			Return(&model.Job{Id: jobID, Status: model.JobStatusSuccess, Data: jobData}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DownloadJob(context.TODO(), jobID).
+			Return([]byte("report"), &model.Response{}, nil).
+			Times(1)
+
+		cmd := newCmd(path)
+		s.Require().NoError(cmd.Flags().Set("dry-run", "true"))
+		s.Require().NoError(cmd.Flags().Set("report", reportPath))
+
+		err := userSyncCmdF(s.client, cmd, nil)
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(map[string]string{
+			"created":     "1",
+			"updated":     "0",
+			"deactivated": "0",
+			"unchanged":   "0",
+			"failed":      "0",
+			"report":      reportPath,
+		}, printer.GetLines()[0])
+
+		report, err := os.ReadFile(reportPath)
+		s.Require().NoError(err)
+		s.Require().Equal("report", string(report))
+	})
+
+	s.Run("failed rows", func() {
+		printer.Clean()
+		path := writeCSV("username\nuser1\n")
+		reportPath := filepath.Join(s.T().TempDir(), "report.csv")
+		jobID := model.NewId()
+		jobData := model.StringMap{"created": "0", "updated": "0", "deactivated": "0", "unchanged": "0", "failed": "1"}
+
+		s.client.
+			EXPECT().
+			CreateUpload(context.TODO(), gomock.Any()).
+			Return(&model.UploadSession{Id: model.NewId()}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UploadData(context.TODO(), gomock.Any(), gomock.Any()).
+			Return(&model.FileInfo{Name: "users.csv"}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), gomock.Any()).
+			Return(&model.Job{Id: jobID, Status: model.JobStatusSuccess, Data: jobData}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DownloadJob(context.TODO(), jobID).
+			Return([]byte("report"), &model.Response{}, nil).
+			Times(1)
+
+		cmd := newCmd(path)
+		s.Require().NoError(cmd.Flags().Set("report", reportPath))
+
+		err := userSyncCmdF(s.client, cmd, nil)
+		s.Require().EqualError(err, "1 rows failed to sync, see the report for details")
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("failed job", func() {
+		printer.Clean()
+		path := writeCSV("username\nuser1\n")
+		jobID := model.NewId()
+
+		s.client.
+			EXPECT().
+			CreateUpload(context.TODO(), gomock.Any()).
+			Return(&model.UploadSession{Id: model.NewId()}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UploadData(context.TODO(), gomock.Any(), gomock.Any()).
+			Return(&model.FileInfo{Name: "users.csv"}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateJob(context.TODO(), gomock.Any()).
+			Return(&model.Job{Id: jobID, Status: model.JobStatusError, Data: model.StringMap{"error": "file not found"}}, &model.Response{}, nil).
+			Times(1)
+
+		err := userSyncCmdF(s.client, newCmd(path), nil)
+		s.Require().EqualError(err, "user sync job "+jobID+" finished with status error: file not found")
+	})
+}
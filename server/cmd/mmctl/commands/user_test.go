@@ -1678,6 +1678,44 @@ func (s *MmctlUnitTestSuite) TestResetUserMfaCmd() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestUserPasswordHashersCmdF() {
+	s.Run("Report the password hashers in use", func() {
+		printer.Clean()
+
+		usages := []*model.PasswordHasherUsage{
+			{Hasher: "bcrypt", Users: 2, Outdated: true},
+			{Hasher: "pbkdf2", Parameters: "f=SHA256,w=600000,l=32", Users: 10},
+		}
+
+		s.client.
+			EXPECT().
+			GetPasswordHasherUsage(context.TODO()).
+			Return(usages, &model.Response{}, nil).
+			Times(1)
+
+		err := userPasswordHashersCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(usages[0], printer.GetLines()[0])
+		s.Require().Equal(usages[1], printer.GetLines()[1])
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Fail to fetch the report", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetPasswordHasherUsage(context.TODO()).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := userPasswordHashersCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestListUserCmdF() {
 	s.Run("Listing users with paging", func() {
 		printer.Clean()
@@ -135,6 +135,22 @@ func (mr *MockClientMockRecorder) ClearServerBusy(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearServerBusy", reflect.TypeOf((*MockClient)(nil).ClearServerBusy), arg0)
 }
 
+// CompareSharedChannelWithRemote mocks base method.
+func (m *MockClient) CompareSharedChannelWithRemote(arg0 context.Context, arg1, arg2 string, arg3, arg4 int64) (*model.SharedChannelSyncComparison, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareSharedChannelWithRemote", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.SharedChannelSyncComparison)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CompareSharedChannelWithRemote indicates an expected call of CompareSharedChannelWithRemote.
+func (mr *MockClientMockRecorder) CompareSharedChannelWithRemote(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareSharedChannelWithRemote", reflect.TypeOf((*MockClient)(nil).CompareSharedChannelWithRemote), arg0, arg1, arg2, arg3, arg4)
+}
+
 // ConvertBotToUser mocks base method.
 func (m *MockClient) ConvertBotToUser(arg0 context.Context, arg1 string, arg2 *model.UserPatch, arg3 bool) (*model.User, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -586,6 +602,22 @@ func (mr *MockClientMockRecorder) DownloadExport(arg0, arg1, arg2, arg3 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadExport", reflect.TypeOf((*MockClient)(nil).DownloadExport), arg0, arg1, arg2, arg3)
 }
 
+// DownloadJob mocks base method.
+func (m *MockClient) DownloadJob(arg0 context.Context, arg1 string) ([]byte, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadJob", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DownloadJob indicates an expected call of DownloadJob.
+func (mr *MockClientMockRecorder) DownloadJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadJob", reflect.TypeOf((*MockClient)(nil).DownloadJob), arg0, arg1)
+}
+
 // EnableBot mocks base method.
 func (m *MockClient) EnableBot(arg0 context.Context, arg1 string) (*model.Bot, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -666,6 +698,22 @@ func (mr *MockClientMockRecorder) GetAllTeams(arg0, arg1, arg2, arg3 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTeams", reflect.TypeOf((*MockClient)(nil).GetAllTeams), arg0, arg1, arg2, arg3)
 }
 
+// GetAnalyticsOld mocks base method.
+func (m *MockClient) GetAnalyticsOld(arg0 context.Context, arg1, arg2 string) (model.AnalyticsRows, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalyticsOld", arg0, arg1, arg2)
+	ret0, _ := ret[0].(model.AnalyticsRows)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAnalyticsOld indicates an expected call of GetAnalyticsOld.
+func (mr *MockClientMockRecorder) GetAnalyticsOld(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalyticsOld", reflect.TypeOf((*MockClient)(nil).GetAnalyticsOld), arg0, arg1, arg2)
+}
+
 // GetBots mocks base method.
 func (m *MockClient) GetBots(arg0 context.Context, arg1, arg2 int, arg3 string) ([]*model.Bot, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -810,6 +858,22 @@ func (mr *MockClientMockRecorder) GetClientConfig(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientConfig", reflect.TypeOf((*MockClient)(nil).GetClientConfig), arg0, arg1)
 }
 
+// GetClusterStatus mocks base method.
+func (m *MockClient) GetClusterStatus(arg0 context.Context) ([]*model.ClusterInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClusterStatus", arg0)
+	ret0, _ := ret[0].([]*model.ClusterInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetClusterStatus indicates an expected call of GetClusterStatus.
+func (mr *MockClientMockRecorder) GetClusterStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterStatus", reflect.TypeOf((*MockClient)(nil).GetClusterStatus), arg0)
+}
+
 // GetCommandById mocks base method.
 func (m *MockClient) GetCommandById(arg0 context.Context, arg1 string) (*model.Command, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1148,6 +1212,22 @@ func (mr *MockClientMockRecorder) GetOutgoingWebhooksForTeam(arg0, arg1, arg2, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutgoingWebhooksForTeam", reflect.TypeOf((*MockClient)(nil).GetOutgoingWebhooksForTeam), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetPasswordHasherUsage mocks base method.
+func (m *MockClient) GetPasswordHasherUsage(arg0 context.Context) ([]*model.PasswordHasherUsage, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordHasherUsage", arg0)
+	ret0, _ := ret[0].([]*model.PasswordHasherUsage)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPasswordHasherUsage indicates an expected call of GetPasswordHasherUsage.
+func (mr *MockClientMockRecorder) GetPasswordHasherUsage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordHasherUsage", reflect.TypeOf((*MockClient)(nil).GetPasswordHasherUsage), arg0)
+}
+
 // GetPing mocks base method.
 func (m *MockClient) GetPing(arg0 context.Context) (string, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1196,6 +1276,22 @@ func (mr *MockClientMockRecorder) GetPingWithOptions(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPingWithOptions", reflect.TypeOf((*MockClient)(nil).GetPingWithOptions), arg0, arg1)
 }
 
+// GetPluginStatuses mocks base method.
+func (m *MockClient) GetPluginStatuses(arg0 context.Context) (model.PluginStatuses, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPluginStatuses", arg0)
+	ret0, _ := ret[0].(model.PluginStatuses)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPluginStatuses indicates an expected call of GetPluginStatuses.
+func (mr *MockClientMockRecorder) GetPluginStatuses(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPluginStatuses", reflect.TypeOf((*MockClient)(nil).GetPluginStatuses), arg0)
+}
+
 // GetPlugins mocks base method.
 func (m *MockClient) GetPlugins(arg0 context.Context) (*model.PluginsResponse, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2171,6 +2267,21 @@ func (mr *MockClientMockRecorder) RestoreTeam(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTeam", reflect.TypeOf((*MockClient)(nil).RestoreTeam), arg0, arg1)
 }
 
+// ResyncSharedChannelRemote mocks base method.
+func (m *MockClient) ResyncSharedChannelRemote(arg0 context.Context, arg1, arg2 string, arg3 int64) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResyncSharedChannelRemote", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResyncSharedChannelRemote indicates an expected call of ResyncSharedChannelRemote.
+func (mr *MockClientMockRecorder) ResyncSharedChannelRemote(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResyncSharedChannelRemote", reflect.TypeOf((*MockClient)(nil).ResyncSharedChannelRemote), arg0, arg1, arg2, arg3)
+}
+
 // RevealPost mocks base method.
 func (m *MockClient) RevealPost(arg0 context.Context, arg1 string) (*model.Post, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2590,6 +2701,38 @@ func (mr *MockClientMockRecorder) UploadPluginForced(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPluginForced", reflect.TypeOf((*MockClient)(nil).UploadPluginForced), arg0, arg1)
 }
 
+// UploadSignedPlugin mocks base method.
+func (m *MockClient) UploadSignedPlugin(arg0 context.Context, arg1, arg2 io.Reader, arg3 bool) (*model.Manifest, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadSignedPlugin", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Manifest)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UploadSignedPlugin indicates an expected call of UploadSignedPlugin.
+func (mr *MockClientMockRecorder) UploadSignedPlugin(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadSignedPlugin", reflect.TypeOf((*MockClient)(nil).UploadSignedPlugin), arg0, arg1, arg2, arg3)
+}
+
+// VerifyPlugin mocks base method.
+func (m *MockClient) VerifyPlugin(arg0 context.Context, arg1, arg2 io.Reader) (*model.PluginSignatureVerification, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyPlugin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.PluginSignatureVerification)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VerifyPlugin indicates an expected call of VerifyPlugin.
+func (mr *MockClientMockRecorder) VerifyPlugin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyPlugin", reflect.TypeOf((*MockClient)(nil).VerifyPlugin), arg0, arg1, arg2)
+}
+
 // VerifyUserEmailWithoutToken mocks base method.
 func (m *MockClient) VerifyUserEmailWithoutToken(arg0 context.Context, arg1 string) (*model.User, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -4,6 +4,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -24,6 +25,7 @@ func GenerateClientConfig(c *model.Config, telemetryID string, license *model.Li
 	props["LockTeammateNameDisplay"] = strconv.FormatBool(*c.TeamSettings.LockTeammateNameDisplay)
 	props["ExperimentalPrimaryTeam"] = *c.TeamSettings.ExperimentalPrimaryTeam
 	props["EnableJoinLeaveMessageByDefault"] = strconv.FormatBool(*c.TeamSettings.EnableJoinLeaveMessageByDefault)
+	props["EnableMembershipRequests"] = strconv.FormatBool(*c.MembershipRequestSettings.Enable)
 
 	props["EnableBotAccountCreation"] = strconv.FormatBool(*c.ServiceSettings.EnableBotAccountCreation)
 	props["EnableDesktopLandingPage"] = strconv.FormatBool(*c.ServiceSettings.EnableDesktopLandingPage)
@@ -361,12 +363,18 @@ func GenerateLimitedClientConfig(c *model.Config, telemetryID string, license *m
 	props["EnableSignUpWithOpenId"] = "false"
 	props["OpenIdButtonText"] = ""
 	props["OpenIdButtonColor"] = ""
+	props["OpenIdProviders"] = "[]"
 	props["CWSURL"] = ""
 	props["EnableCustomBrand"] = strconv.FormatBool(*c.TeamSettings.EnableCustomBrand)
 	props["CustomBrandText"] = *c.TeamSettings.CustomBrandText
 	props["CustomDescriptionText"] = *c.TeamSettings.CustomDescriptionText
 	props["EnableMultifactorAuthentication"] = strconv.FormatBool(*c.ServiceSettings.EnableMultifactorAuthentication)
 	props["EnforceMultifactorAuthentication"] = "false"
+	props["EnableWebAuthn"] = strconv.FormatBool(*c.ServiceSettings.EnableWebAuthn)
+	props["EnableWebAuthnPasswordless"] = strconv.FormatBool(*c.ServiceSettings.EnableWebAuthnPasswordless)
+	props["EnforceWebAuthn"] = "false"
+	props["EnableMfaRecoveryCodes"] = strconv.FormatBool(*c.ServiceSettings.EnableMfaRecoveryCodes)
+	props["MfaTrustedDeviceDays"] = strconv.Itoa(*c.ServiceSettings.MfaTrustedDeviceDays)
 	props["EnableGuestAccounts"] = strconv.FormatBool(*c.GuestAccountsSettings.Enable)
 	props["HideGuestTags"] = strconv.FormatBool(*c.GuestAccountsSettings.HideTags)
 	props["GuestAccountsEnforceMultifactorAuthentication"] = strconv.FormatBool(*c.GuestAccountsSettings.EnforceMultifactorAuthentication)
@@ -396,6 +404,7 @@ func GenerateLimitedClientConfig(c *model.Config, telemetryID string, license *m
 
 		if *license.Features.MFA {
 			props["EnforceMultifactorAuthentication"] = strconv.FormatBool(*c.ServiceSettings.EnforceMultifactorAuthentication)
+			props["EnforceWebAuthn"] = strconv.FormatBool(*c.ServiceSettings.EnforceWebAuthn)
 		}
 
 		if license.IsCloud() {
@@ -419,6 +428,7 @@ func GenerateLimitedClientConfig(c *model.Config, telemetryID string, license *m
 			props["EnableSignUpWithGitLab"] = strconv.FormatBool(*c.GitLabSettings.Enable)
 			props["GitLabButtonColor"] = *c.GitLabSettings.ButtonColor
 			props["GitLabButtonText"] = *c.GitLabSettings.ButtonText
+			props["OpenIdProviders"] = openIdProvidersClientConfig(c)
 		}
 
 		if model.MinimumEnterpriseLicense(license) {
@@ -465,3 +475,23 @@ func getGiphySdkKey(ss model.ServiceSettings) string {
 
 	return ""
 }
+
+// openIdProvidersClientConfig returns the login buttons of the enabled
+// OpenID Connect providers as a JSON array.
+func openIdProvidersClientConfig(c *model.Config) string {
+	buttons := []model.OpenIdProviderButton{}
+	for _, provider := range c.OpenIdProviderSettings.EnabledProviders() {
+		buttons = append(buttons, model.OpenIdProviderButton{
+			Service:     provider.Service(),
+			ButtonText:  *provider.ButtonText,
+			ButtonColor: *provider.ButtonColor,
+		})
+	}
+
+	b, err := json.Marshal(buttons)
+	if err != nil {
+		return "[]"
+	}
+
+	return string(b)
+}
@@ -35,6 +35,10 @@ type Store struct {
 
 	readOnly   bool
 	readOnlyFF bool
+
+	// skipLoadValidation allows loading an invalid configuration, so that
+	// it can be fixed. The configuration is still validated when it is set.
+	skipLoadValidation bool
 }
 
 // BackingStore defines the behaviour exposed by the underlying store
@@ -69,11 +73,16 @@ type BackingStore interface {
 
 // NewStoreFromBacking creates and returns a new config store given a backing store.
 func NewStoreFromBacking(backingStore BackingStore, customDefaults *model.Config, readOnly bool) (*Store, error) {
+	return newStoreFromBacking(backingStore, customDefaults, readOnly, false)
+}
+
+func newStoreFromBacking(backingStore BackingStore, customDefaults *model.Config, readOnly, skipLoadValidation bool) (*Store, error) {
 	store := &Store{
 		backingStore:         backingStore,
 		configCustomDefaults: customDefaults,
 		readOnly:             readOnly,
 		readOnlyFF:           true,
+		skipLoadValidation:   skipLoadValidation,
 	}
 
 	if err := store.Load(); err != nil {
@@ -86,6 +95,17 @@ func NewStoreFromBacking(backingStore BackingStore, customDefaults *model.Config
 // NewStoreFromDSN creates and returns a new config store backed by either a database or file store
 // depending on the value of the given data source name string.
 func NewStoreFromDSN(dsn string, readOnly bool, customDefaults *model.Config, createFileIfNotExist bool) (*Store, error) {
+	return newStoreFromDSN(dsn, readOnly, customDefaults, createFileIfNotExist, false)
+}
+
+// NewRecoveryStoreFromDSN creates and returns a config store like NewStoreFromDSN, except that
+// the configuration is loaded even if it is invalid. It is meant for tools that fix the
+// configuration while the server can't start, and it never creates the configuration file.
+func NewRecoveryStoreFromDSN(dsn string, readOnly bool) (*Store, error) {
+	return newStoreFromDSN(dsn, readOnly, nil, false, true)
+}
+
+func newStoreFromDSN(dsn string, readOnly bool, customDefaults *model.Config, createFileIfNotExist, skipLoadValidation bool) (*Store, error) {
 	var err error
 	var backingStore BackingStore
 	if IsDatabaseDSN(dsn) {
@@ -97,7 +117,7 @@ func NewStoreFromDSN(dsn string, readOnly bool, customDefaults *model.Config, cr
 		return nil, err
 	}
 
-	store, err := NewStoreFromBacking(backingStore, customDefaults, readOnly)
+	store, err := newStoreFromBacking(backingStore, customDefaults, readOnly, skipLoadValidation)
 	if err != nil {
 		backingStore.Close()
 		return nil, errors.Wrap(err, "failed to create store")
@@ -291,7 +311,7 @@ func (s *Store) Load() error {
 
 	loadedCfg = applyEnvironmentMap(loadedCfg, GetEnvironment())
 	fixConfig(loadedCfg)
-	if appErr := loadedCfg.IsValid(); appErr != nil {
+	if appErr := loadedCfg.IsValid(); appErr != nil && !s.skipLoadValidation {
 		// Translating the error before displaying it in the console.
 		// Defaulting to english for server side language.
 		appErr.Translate(i18n.GetUserTranslations("en"))
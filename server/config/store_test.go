@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
 )
 
 func TestNewStoreFromDSN(t *testing.T) {
@@ -101,3 +103,43 @@ func TestNewStoreReadOnly(t *testing.T) {
 		fs.Close()
 	})
 }
+
+func TestNewRecoveryStoreFromDSN(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"ServiceSettings": {"SiteURL": "invalid"}}`), 0600))
+
+	_, err := NewStoreFromDSN(path, true, nil, false)
+	require.Error(t, err)
+
+	t.Run("loads an invalid config", func(t *testing.T) {
+		fs, err := NewRecoveryStoreFromDSN(path, true)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		require.Equal(t, "invalid", *fs.Get().ServiceSettings.SiteURL)
+	})
+
+	t.Run("validates the fixed config", func(t *testing.T) {
+		fs, err := NewRecoveryStoreFromDSN(path, false)
+		require.NoError(t, err)
+		defer fs.Close()
+
+		cfg := fs.Get()
+		_, _, err = fs.Set(cfg)
+		require.Error(t, err)
+
+		cfg.ServiceSettings.SiteURL = model.NewPointer("http://localhost:8065")
+		_, _, err = fs.Set(cfg)
+		require.NoError(t, err)
+
+		fixed, err := NewStoreFromDSN(path, true, nil, false)
+		require.NoError(t, err)
+		defer fixed.Close()
+		require.Equal(t, "http://localhost:8065", *fixed.Get().ServiceSettings.SiteURL)
+	})
+
+	t.Run("doesn't create a missing file", func(t *testing.T) {
+		_, err := NewRecoveryStoreFromDSN(filepath.Join(t.TempDir(), "missing.json"), true)
+		require.Error(t, err)
+	})
+}
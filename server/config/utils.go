@@ -54,6 +54,15 @@ func desanitize(actual, target *model.Config) {
 		target.OpenIdSettings.Secret = actual.OpenIdSettings.Secret
 	}
 
+	for _, provider := range target.OpenIdProviderSettings.Providers {
+		if provider == nil || provider.Secret == nil || *provider.Secret != model.FakeSetting {
+			continue
+		}
+		if actualProvider := actual.OpenIdProviderSettings.GetProvider(provider.Service()); actualProvider != nil {
+			provider.Secret = actualProvider.Secret
+		}
+	}
+
 	if *target.SqlSettings.DataSource == model.FakeSetting {
 		*target.SqlSettings.DataSource = *actual.SqlSettings.DataSource
 	}
@@ -72,6 +72,10 @@ type MetricsInterface interface {
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
 	ObservePluginMultiHookDuration(elapsed float64)
 	ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64)
+	SetPluginProcessMemory(pluginID string, bytes float64)
+	SetPluginProcessCPU(pluginID string, percent float64)
+	IncrementPluginAPIQuotaExceeded(pluginID, apiName string)
+	ObservePluginDatabaseQueryDuration(pluginID string, elapsed float64)
 
 	ObserveEnabledUsers(users int64)
 	GetLoggerMetricsCollector() mlog.MetricsCollector
@@ -90,6 +94,7 @@ type MetricsInterface interface {
 	ObserveSharedChannelsSyncSendDuration(remoteID string, elapsed float64)
 	ObserveSharedChannelsSyncCollectionStepDuration(remoteID string, step string, elapsed float64)
 	ObserveSharedChannelsSyncSendStepDuration(remoteID string, step string, elapsed float64)
+	ObserveSharedChannelsSyncLag(remoteID string, elapsed float64)
 
 	IncrementJobActive(jobType string)
 	DecrementJobActive(jobType string)
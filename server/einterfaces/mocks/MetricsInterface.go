@@ -218,6 +218,11 @@ func (_m *MetricsInterface) IncrementNotificationUnsupportedCounter(notification
 	_m.Called(notificationType, notSentReason, platform)
 }
 
+// IncrementPluginAPIQuotaExceeded provides a mock function with given fields: pluginID, apiName
+func (_m *MetricsInterface) IncrementPluginAPIQuotaExceeded(pluginID string, apiName string) {
+	_m.Called(pluginID, apiName)
+}
+
 // IncrementPostBroadcast provides a mock function with no fields
 func (_m *MetricsInterface) IncrementPostBroadcast() {
 	_m.Called()
@@ -513,6 +518,11 @@ func (_m *MetricsInterface) ObservePluginAPIDuration(pluginID string, apiName st
 	_m.Called(pluginID, apiName, success, elapsed)
 }
 
+// ObservePluginDatabaseQueryDuration provides a mock function with given fields: pluginID, elapsed
+func (_m *MetricsInterface) ObservePluginDatabaseQueryDuration(pluginID string, elapsed float64) {
+	_m.Called(pluginID, elapsed)
+}
+
 // ObservePluginHookDuration provides a mock function with given fields: pluginID, hookName, success, elapsed
 func (_m *MetricsInterface) ObservePluginHookDuration(pluginID string, hookName string, success bool, elapsed float64) {
 	_m.Called(pluginID, hookName, success, elapsed)
@@ -568,6 +578,11 @@ func (_m *MetricsInterface) ObserveSharedChannelsSyncCollectionStepDuration(remo
 	_m.Called(remoteID, step, elapsed)
 }
 
+// ObserveSharedChannelsSyncLag provides a mock function with given fields: remoteID, elapsed
+func (_m *MetricsInterface) ObserveSharedChannelsSyncLag(remoteID string, elapsed float64) {
+	_m.Called(remoteID, elapsed)
+}
+
 // ObserveSharedChannelsSyncSendDuration provides a mock function with given fields: remoteID, elapsed
 func (_m *MetricsInterface) ObserveSharedChannelsSyncSendDuration(remoteID string, elapsed float64) {
 	_m.Called(remoteID, elapsed)
@@ -603,6 +618,16 @@ func (_m *MetricsInterface) SetAutoTranslateQueueDepth(depth float64) {
 	_m.Called(depth)
 }
 
+// SetPluginProcessCPU provides a mock function with given fields: pluginID, percent
+func (_m *MetricsInterface) SetPluginProcessCPU(pluginID string, percent float64) {
+	_m.Called(pluginID, percent)
+}
+
+// SetPluginProcessMemory provides a mock function with given fields: pluginID, bytes
+func (_m *MetricsInterface) SetPluginProcessMemory(pluginID string, bytes float64) {
+	_m.Called(pluginID, bytes)
+}
+
 // SetReplicaLagAbsolute provides a mock function with given fields: node, value
 func (_m *MetricsInterface) SetReplicaLagAbsolute(node string, value float64) {
 	_m.Called(node, value)
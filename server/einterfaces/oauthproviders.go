@@ -5,6 +5,8 @@ package einterfaces
 
 import (
 	"io"
+	"strings"
+	"sync"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/request"
@@ -17,16 +19,76 @@ type OAuthProvider interface {
 	IsSameUser(c request.CTX, dbUser, oAuthUser *model.User) bool
 }
 
-var oauthProviders = make(map[string]OAuthProvider)
+// OAuthClaims holds the claims of an OAuth user that are not part of the
+// model.User returned by the provider.
+type OAuthClaims struct {
+	// Groups lists the remote IDs of the groups of the user.
+	Groups []string
+	// Attributes maps the names of custom profile attributes to their values.
+	Attributes map[string][]string
+}
+
+// OAuthClaimsProvider is implemented by the OAuth providers that map the
+// claims of their users to group memberships and custom profile attributes.
+type OAuthClaimsProvider interface {
+	// GetClaimsFromJSON returns the claims of the user, or nil if the provider
+	// is configured not to map any.
+	GetClaimsFromJSON(c request.CTX, data io.Reader, settings *model.SSOSettings) (*OAuthClaims, error)
+}
+
+// OAuthProviderFactory creates the provider of a service that is configured
+// at runtime rather than registered upfront.
+type OAuthProviderFactory func(service string) OAuthProvider
+
+var (
+	oauthProvidersMut         sync.RWMutex
+	oauthProviders            = make(map[string]OAuthProvider)
+	oauthProviderFactories    = make(map[string]OAuthProviderFactory)
+	oauthProvidersFromFactory = make(map[string]OAuthProvider)
+)
 
 func RegisterOAuthProvider(name string, newProvider OAuthProvider) {
+	oauthProvidersMut.Lock()
+	defer oauthProvidersMut.Unlock()
+
 	oauthProviders[name] = newProvider
 }
 
+// RegisterOAuthProviderFactory registers the factory creating the providers
+// of the services starting with the prefix. Each service gets its own
+// provider, created the first time it is requested.
+func RegisterOAuthProviderFactory(prefix string, factory OAuthProviderFactory) {
+	oauthProvidersMut.Lock()
+	defer oauthProvidersMut.Unlock()
+
+	oauthProviderFactories[prefix] = factory
+}
+
 func GetOAuthProvider(name string) OAuthProvider {
+	oauthProvidersMut.RLock()
 	provider, ok := oauthProviders[name]
+	if !ok {
+		provider, ok = oauthProvidersFromFactory[name]
+	}
+	oauthProvidersMut.RUnlock()
 	if ok {
 		return provider
 	}
+
+	oauthProvidersMut.Lock()
+	defer oauthProvidersMut.Unlock()
+
+	if provider, ok := oauthProvidersFromFactory[name]; ok {
+		return provider
+	}
+
+	for prefix, factory := range oauthProviderFactories {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			provider := factory(name)
+			oauthProvidersFromFactory[name] = provider
+			return provider
+		}
+	}
+
 	return nil
 }
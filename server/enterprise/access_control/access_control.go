@@ -0,0 +1,570 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	// anyAction matches the rules of a policy regardless of their actions.
+	anyAction = "*"
+
+	membersToRemovePageSize = 1000
+)
+
+// AccessControlService implements the policy administration and decision
+// points on top of the custom profile attributes of the users, as exposed by
+// the AttributeView.
+type AccessControlService struct {
+	app *app.App
+
+	mut   sync.RWMutex
+	ready bool
+}
+
+// attributeFields indexes the custom profile attribute fields that policy
+// expressions can reference.
+type attributeFields struct {
+	// types maps the name of each field to its type.
+	types map[string]string
+	// names maps the ID of each field to its name.
+	names map[string]string
+}
+
+func NewAccessControlService(a *app.App) *AccessControlService {
+	return &AccessControlService{app: a}
+}
+
+func (s *AccessControlService) Init(rctx request.CTX) *model.AppError {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if !model.MinimumEnterpriseAdvancedLicense(s.app.License()) {
+		s.ready = false
+		return model.NewAppError("Init", "app.pap.init.app_error", nil, "license does not support attribute based access control", http.StatusNotImplemented)
+	}
+
+	s.ready = true
+	return nil
+}
+
+func (s *AccessControlService) checkReady(where string) *model.AppError {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+
+	if !s.ready {
+		return model.NewAppError(where, "app.pap.is_ready.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return nil
+}
+
+func (s *AccessControlService) AccessEvaluation(rctx request.CTX, accessRequest model.AccessRequest) (model.AccessDecision, *model.AppError) {
+	if appErr := s.checkReady("AccessEvaluation"); appErr != nil {
+		return model.AccessDecision{}, appErr
+	}
+
+	if accessRequest.Resource.Type != model.AccessControlPolicyTypeChannel {
+		return model.AccessDecision{}, model.NewAppError("AccessEvaluation", "app.pdp.access_evaluation.app_error", nil, "unsupported resource type "+accessRequest.Resource.Type, http.StatusBadRequest)
+	}
+
+	policy, err := s.app.Srv().Store().AccessControlPolicy().Get(rctx, accessRequest.Resource.ID)
+	var nfErr *store.ErrNotFound
+	if errors.As(err, &nfErr) {
+		// Resources without a policy are not restricted.
+		return model.AccessDecision{Decision: true}, nil
+	} else if err != nil {
+		return model.AccessDecision{}, model.NewAppError("AccessEvaluation", "app.pdp.access_evaluation.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	fields, appErr := s.attributeFields()
+	if appErr != nil {
+		return model.AccessDecision{}, appErr
+	}
+
+	e, err := s.policyExpression(rctx, policy, accessRequest.Action, fields, map[string]bool{})
+	if err != nil {
+		return model.AccessDecision{}, model.NewAppError("AccessEvaluation", "app.pdp.access_evaluation.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return model.AccessDecision{Decision: e == nil || evaluate(e, accessRequest.Subject.Attributes)}, nil
+}
+
+func (s *AccessControlService) GetPolicyRuleAttributes(rctx request.CTX, policyID string, action string) (map[string][]string, *model.AppError) {
+	if appErr := s.checkReady("GetPolicyRuleAttributes"); appErr != nil {
+		return nil, appErr
+	}
+
+	policy, appErr := s.GetPolicy(rctx, policyID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	fields, appErr := s.attributeFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	e, err := s.policyExpression(rctx, policy, action, fields, map[string]bool{})
+	if err != nil {
+		return nil, model.NewAppError("GetPolicyRuleAttributes", "app.pap.get_policy_attributes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	attributes := map[string][]string{}
+	add := func(attribute string, values []string) {
+		for _, value := range values {
+			if !slices.Contains(attributes[attribute], value) {
+				attributes[attribute] = append(attributes[attribute], value)
+			}
+		}
+	}
+	walkConditions(e, func(e expr) {
+		condition, ok := e.(*compareExpr)
+		if !ok || condition.op == tokenNeq {
+			return
+		}
+		switch {
+		case condition.right.kind == operandAttribute && condition.left.kind != operandAttribute:
+			add(condition.right.attribute, condition.left.values)
+		case condition.right.kind != operandAttribute:
+			add(condition.left.attribute, condition.right.values)
+		}
+	})
+
+	return attributes, nil
+}
+
+func (s *AccessControlService) CheckExpression(rctx request.CTX, expression string) ([]model.CELExpressionError, *model.AppError) {
+	if appErr := s.checkReady("CheckExpression"); appErr != nil {
+		return nil, appErr
+	}
+
+	fields, appErr := s.attributeFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if err := s.checkExpression(expression, fields); err != nil {
+		var celErr *celError
+		if !errors.As(err, &celErr) {
+			return nil, model.NewAppError("CheckExpression", "app.pap.check_expression.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return []model.CELExpressionError{{Line: celErr.line, Column: celErr.column, Message: celErr.message}}, nil
+	}
+
+	return []model.CELExpressionError{}, nil
+}
+
+// checkExpression parses the expression and verifies that every attribute it
+// references is a custom profile attribute.
+func (s *AccessControlService) checkExpression(expression string, fields *attributeFields) error {
+	e, err := parse(expression)
+	if err != nil {
+		return err
+	}
+
+	for _, attribute := range attributeOperands(e) {
+		if _, ok := fields.types[attribute.attribute]; !ok {
+			return errorAt(attribute.at, "undeclared reference to '%s%s'", userAttributesPrefix, attribute.attribute)
+		}
+	}
+
+	return nil
+}
+
+func (s *AccessControlService) ExpressionToVisualAST(rctx request.CTX, expression string) (*model.VisualExpression, *model.AppError) {
+	if appErr := s.checkReady("ExpressionToVisualAST"); appErr != nil {
+		return nil, appErr
+	}
+
+	if strings.TrimSpace(expression) == "" {
+		return &model.VisualExpression{Conditions: []model.Condition{}}, nil
+	}
+
+	fields, appErr := s.attributeFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	e, err := parse(normalizeExpression(expression, fields))
+	if err != nil {
+		return nil, model.NewAppError("ExpressionToVisualAST", "app.pap.expression_to_visual_ast.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	visual, err := toVisualExpression(e, fields.types)
+	if err != nil {
+		return nil, model.NewAppError("ExpressionToVisualAST", "app.pap.expression_to_visual_ast.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return visual, nil
+}
+
+func (s *AccessControlService) NormalizePolicy(rctx request.CTX, policy *model.AccessControlPolicy) (*model.AccessControlPolicy, *model.AppError) {
+	if appErr := s.checkReady("NormalizePolicy"); appErr != nil {
+		return nil, appErr
+	}
+
+	fields, appErr := s.attributeFields()
+	if appErr != nil {
+		return nil, model.NewAppError("NormalizePolicy", "app.pap.normalize_policy.app_error", nil, "", http.StatusInternalServerError).Wrap(appErr)
+	}
+
+	normalized := *policy
+	normalized.Rules = make([]model.AccessControlPolicyRule, 0, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		normalized.Rules = append(normalized.Rules, model.AccessControlPolicyRule{
+			Actions:    slices.Clone(rule.Actions),
+			Expression: normalizeExpression(rule.Expression, fields),
+		})
+	}
+
+	return &normalized, nil
+}
+
+func (s *AccessControlService) QueryUsersForExpression(rctx request.CTX, expression string, opts model.SubjectSearchOptions) ([]*model.User, int64, *model.AppError) {
+	if appErr := s.checkReady("QueryUsersForExpression"); appErr != nil {
+		return nil, 0, appErr
+	}
+
+	fields, appErr := s.attributeFields()
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	e, err := parse(normalizeExpression(expression, fields))
+	if err != nil {
+		return nil, 0, model.NewAppError("QueryUsersForExpression", "app.pap.query_expression.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	e, err = resolvePolicyRefs(e, func(ref *policyRef) (expr, error) {
+		return s.referencedExpression(rctx, ref, anyAction, fields, map[string]bool{})
+	})
+	if err != nil {
+		return nil, 0, model.NewAppError("QueryUsersForExpression", "app.pap.query_expression.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return s.searchUsers(rctx, "QueryUsersForExpression", e, opts)
+}
+
+func (s *AccessControlService) QueryUsersForResource(rctx request.CTX, resourceID, action string, opts model.SubjectSearchOptions) ([]*model.User, int64, *model.AppError) {
+	if appErr := s.checkReady("QueryUsersForResource"); appErr != nil {
+		return nil, 0, appErr
+	}
+
+	policy, appErr := s.GetPolicy(rctx, resourceID)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	fields, appErr := s.attributeFields()
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	e, err := s.policyExpression(rctx, policy, action, fields, map[string]bool{})
+	if err != nil {
+		return nil, 0, model.NewAppError("QueryUsersForResource", "app.pap.query_expression.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return s.searchUsers(rctx, "QueryUsersForResource", e, opts)
+}
+
+func (s *AccessControlService) searchUsers(rctx request.CTX, where string, e expr, opts model.SubjectSearchOptions) ([]*model.User, int64, *model.AppError) {
+	opts.Query, opts.Args = "", nil
+	if e != nil {
+		opts.Query, opts.Args = compileSQL(e)
+	}
+
+	users, total, err := s.app.Srv().Store().Attributes().SearchUsers(rctx, opts)
+	if err != nil {
+		return nil, 0, model.NewAppError(where, "app.pap.query_expression.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return users, total, nil
+}
+
+func (s *AccessControlService) GetChannelMembersToRemove(rctx request.CTX, channelID string) ([]*model.ChannelMember, *model.AppError) {
+	if appErr := s.checkReady("GetChannelMembersToRemove"); appErr != nil {
+		return nil, appErr
+	}
+
+	policy, appErr := s.GetPolicy(rctx, channelID)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return []*model.ChannelMember{}, nil
+		}
+		return nil, appErr
+	}
+
+	fields, appErr := s.attributeFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	e, err := s.policyExpression(rctx, policy, anyAction, fields, map[string]bool{})
+	if err != nil {
+		return nil, model.NewAppError("GetChannelMembersToRemove", "app.pap.get_channel_members_to_remove.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	members := []*model.ChannelMember{}
+	if e == nil {
+		return members, nil
+	}
+
+	opts := model.SubjectSearchOptions{Limit: membersToRemovePageSize}
+	opts.Query, opts.Args = compileSQL(e)
+	for {
+		page, err := s.app.Srv().Store().Attributes().GetChannelMembersToRemove(rctx, channelID, opts)
+		if err != nil {
+			return nil, model.NewAppError("GetChannelMembersToRemove", "app.pap.get_channel_members_to_remove.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		members = append(members, page...)
+		if len(page) < membersToRemovePageSize {
+			return members, nil
+		}
+		opts.Cursor.TargetID = page[len(page)-1].UserId
+	}
+}
+
+func (s *AccessControlService) SavePolicy(rctx request.CTX, policy *model.AccessControlPolicy) (*model.AccessControlPolicy, *model.AppError) {
+	if appErr := s.checkReady("SavePolicy"); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := policy.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	fields, appErr := s.attributeFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	for _, rule := range policy.Rules {
+		if err := s.checkExpression(normalizeExpression(rule.Expression, fields), fields); err != nil {
+			return nil, model.NewAppError("SavePolicy", "app.pap.save_policy.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	for _, id := range policy.Imports {
+		parent, appErr := s.GetPolicy(rctx, id)
+		if appErr != nil {
+			return nil, model.NewAppError("SavePolicy", "app.pap.save_policy.app_error", nil, "", appErr.StatusCode).Wrap(appErr)
+		}
+		if parent.Type != model.AccessControlPolicyTypeParent {
+			return nil, model.NewAppError("SavePolicy", "app.pap.save_policy.app_error", nil, "imported policy "+id+" is not of type parent", http.StatusBadRequest)
+		}
+	}
+
+	saved, err := s.app.Srv().Store().AccessControlPolicy().Save(rctx, policy)
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("SavePolicy", "app.pap.save_policy.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if saved.Type == model.AccessControlPolicyTypeChannel {
+		// Channels are cached with whether a policy is enforced on them.
+		s.app.Srv().Store().Channel().InvalidateChannel(saved.ID)
+	}
+
+	return saved, nil
+}
+
+func (s *AccessControlService) GetPolicy(rctx request.CTX, id string) (*model.AccessControlPolicy, *model.AppError) {
+	if appErr := s.checkReady("GetPolicy"); appErr != nil {
+		return nil, appErr
+	}
+
+	policy, err := s.app.Srv().Store().AccessControlPolicy().Get(rctx, id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetPolicy", "app.pap.get_policy.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, model.NewAppError("GetPolicy", "app.pap.get_policy.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return policy, nil
+}
+
+func (s *AccessControlService) DeletePolicy(rctx request.CTX, id string) *model.AppError {
+	if appErr := s.checkReady("DeletePolicy"); appErr != nil {
+		return appErr
+	}
+
+	policy, appErr := s.GetPolicy(rctx, id)
+	if appErr != nil {
+		return appErr
+	}
+
+	// Channels stop inheriting from a deleted parent policy; the ones left
+	// without any rule are no longer access controlled.
+	if policy.Type == model.AccessControlPolicyTypeParent {
+		children, _, err := s.app.Srv().Store().AccessControlPolicy().SearchPolicies(rctx, model.AccessControlPolicySearch{
+			Type:     model.AccessControlPolicyTypeChannel,
+			ParentID: id,
+			Limit:    1000,
+		})
+		if err != nil {
+			return model.NewAppError("DeletePolicy", "app.pap.delete_policy.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, child := range children {
+			child.Imports = slices.DeleteFunc(child.Imports, func(importID string) bool { return importID == id })
+			if len(child.Imports) == 0 && (len(child.Rules) == 0 || child.Version == model.AccessControlPolicyVersionV0_1) {
+				if err := s.app.Srv().Store().AccessControlPolicy().Delete(rctx, child.ID); err != nil {
+					return model.NewAppError("DeletePolicy", "app.pap.delete_policy.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+				}
+			} else if _, err := s.app.Srv().Store().AccessControlPolicy().Save(rctx, child); err != nil {
+				return model.NewAppError("DeletePolicy", "app.pap.delete_policy.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			s.app.Srv().Store().Channel().InvalidateChannel(child.ID)
+		}
+	}
+
+	if err := s.app.Srv().Store().AccessControlPolicy().Delete(rctx, id); err != nil {
+		return model.NewAppError("DeletePolicy", "app.pap.delete_policy.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if policy.Type == model.AccessControlPolicyTypeChannel {
+		s.app.Srv().Store().Channel().InvalidateChannel(id)
+	}
+
+	return nil
+}
+
+// attributeFields returns the custom profile attribute fields that policy
+// expressions can reference.
+func (s *AccessControlService) attributeFields() (*attributeFields, *model.AppError) {
+	cpaFields, appErr := s.app.ListCPAFields("")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	fields := &attributeFields{
+		types: make(map[string]string, len(cpaFields)),
+		names: make(map[string]string, len(cpaFields)),
+	}
+	for _, field := range cpaFields {
+		fields.types[field.Name] = string(field.Type)
+		fields.names[field.ID] = field.Name
+	}
+
+	return fields, nil
+}
+
+// policyExpression returns the expression a subject must satisfy to perform
+// the action on the resource of the policy: its own rules for the action,
+// combined with the ones of the policies it imports. A nil expression means
+// the action is not restricted.
+//
+// seen holds the policies already combined, to guard against import cycles.
+func (s *AccessControlService) policyExpression(rctx request.CTX, policy *model.AccessControlPolicy, action string, fields *attributeFields, seen map[string]bool) (expr, error) {
+	if seen[policy.ID] {
+		return nil, nil
+	}
+	seen[policy.ID] = true
+
+	var exprs []expr
+	for _, rule := range policy.Rules {
+		if action != anyAction && !slices.Contains(rule.Actions, anyAction) && !slices.Contains(rule.Actions, action) {
+			continue
+		}
+
+		e, err := parse(normalizeExpression(rule.Expression, fields))
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression in policy %s: %w", policy.ID, err)
+		}
+
+		e, err = resolvePolicyRefs(e, func(ref *policyRef) (expr, error) {
+			// Channel policies of version v0.1 reference themselves to
+			// stand for the policies they import, combined below.
+			if ref.id == policy.ID {
+				return nil, nil
+			}
+			return s.referencedExpression(rctx, ref, action, fields, seen)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		exprs = append(exprs, e)
+	}
+
+	for _, id := range policy.Imports {
+		parent, err := s.app.Srv().Store().AccessControlPolicy().Get(rctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get policy %s imported by %s: %w", id, policy.ID, err)
+		}
+
+		e, err := s.policyExpression(rctx, parent, action, fields, seen)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+
+	return and(exprs...), nil
+}
+
+func (s *AccessControlService) referencedExpression(rctx request.CTX, ref *policyRef, action string, fields *attributeFields, seen map[string]bool) (expr, error) {
+	policy, err := s.app.Srv().Store().AccessControlPolicy().Get(rctx, ref.id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, errorAt(ref.at, "undeclared reference to 'policies.%s%s'", policyRefPrefix, ref.id)
+		}
+		return nil, fmt.Errorf("failed to get policy %s: %w", ref.id, err)
+	}
+
+	return s.policyExpression(rctx, policy, action, fields, seen)
+}
+
+// normalizeExpression replaces the user attributes referenced by the ID of
+// their field with the name of the field.
+func normalizeExpression(expression string, fields *attributeFields) string {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return expression
+	}
+
+	var normalized strings.Builder
+	last := 0
+	for i := 0; i+4 < len(tokens); i++ {
+		if tokens[i].kind != tokenIdent || tokens[i].value != "user" ||
+			tokens[i+1].kind != tokenDot ||
+			tokens[i+2].kind != tokenIdent || tokens[i+2].value != "attributes" ||
+			tokens[i+3].kind != tokenDot ||
+			tokens[i+4].kind != tokenIdent {
+			continue
+		}
+
+		attribute := tokens[i+4]
+		name, ok := fields.names[attribute.value]
+		if !ok {
+			continue
+		}
+
+		normalized.WriteString(expression[last:attribute.offset])
+		normalized.WriteString(name)
+		last = attribute.offset + len(attribute.value)
+	}
+	normalized.WriteString(expression[last:])
+
+	return normalized.String()
+}
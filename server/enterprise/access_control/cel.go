@@ -0,0 +1,643 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The policy expressions are written in the subset of the Common Expression
+// Language (CEL) that the system console editors produce:
+//
+//	user.attributes.Team == "Engineering"
+//	user.attributes.Team != "Sales"
+//	user.attributes.Team in ["Engineering", "Sales"]
+//	["Go", "Rust"] in user.attributes.Languages
+//	user.attributes.Email.endsWith("@example.com")
+//	user.attributes.Team == user.attributes.Manager
+//
+// Conditions can be combined with &&, || and !, and grouped with parentheses.
+// Policies created with version v0.1 also reference other policies with the
+// policies.id_<id> identifier.
+
+const (
+	userAttributesPrefix = "user.attributes."
+	policyRefPrefix      = "id_"
+
+	// maxExpressionLength and maxExpressionDepth bound the size and the
+	// nesting of parentheses and negations of an expression, so that parsing
+	// and evaluating it can't exhaust the stack.
+	maxExpressionLength = 16384
+	maxExpressionDepth  = 100
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+	tokenDot
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenEq
+	tokenNeq
+	tokenIn
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	// offset is the byte offset of the token in the expression.
+	offset int
+	// line is 1-based and column is 0-based, as reported by the CEL compiler.
+	line   int
+	column int
+}
+
+// celError is a syntax or semantic error found in an expression.
+type celError struct {
+	line    int
+	column  int
+	message string
+}
+
+func (e *celError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.line, e.column, e.message)
+}
+
+func errorAt(t token, format string, args ...any) *celError {
+	return &celError{line: t.line, column: t.column, message: fmt.Sprintf(format, args...)}
+}
+
+// tokenize splits the expression into tokens, always ending with a tokenEOF.
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	line, lineStart := 1, 0
+
+	for i := 0; i < len(expression); {
+		r, size := utf8.DecodeRuneInString(expression[i:])
+		t := token{offset: i, line: line, column: utf8.RuneCountInString(expression[lineStart:i])}
+
+		switch {
+		case r == '\n':
+			i += size
+			line, lineStart = line+1, i
+			continue
+		case unicode.IsSpace(r):
+			i += size
+			continue
+		case r == '"' || r == '\'':
+			value, n, err := unquote(expression[i:])
+			if err != nil {
+				return nil, errorAt(t, "%s", err.Error())
+			}
+			t.kind, t.value = tokenString, value
+			i += n
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			j := i
+			for j < len(expression) {
+				r, size := utf8.DecodeRuneInString(expression[j:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				j += size
+			}
+			t.kind, t.value = tokenIdent, expression[i:j]
+			if t.value == "in" {
+				t.kind = tokenIn
+			}
+			i = j
+		default:
+			two := ""
+			if i+2 <= len(expression) {
+				two = expression[i : i+2]
+			}
+			switch {
+			case two == "&&":
+				t.kind = tokenAnd
+			case two == "||":
+				t.kind = tokenOr
+			case two == "==":
+				t.kind = tokenEq
+			case two == "!=":
+				t.kind = tokenNeq
+			case r == '!':
+				t.kind = tokenNot
+			case r == '(':
+				t.kind = tokenLParen
+			case r == ')':
+				t.kind = tokenRParen
+			case r == '[':
+				t.kind = tokenLBracket
+			case r == ']':
+				t.kind = tokenRBracket
+			case r == ',':
+				t.kind = tokenComma
+			case r == '.':
+				t.kind = tokenDot
+			default:
+				return nil, errorAt(t, "Syntax error: token recognition error at: '%c'", r)
+			}
+			if t.kind == tokenAnd || t.kind == tokenOr || t.kind == tokenEq || t.kind == tokenNeq {
+				t.value = two
+				i += 2
+			} else {
+				t.value = string(r)
+				i += size
+			}
+		}
+
+		tokens = append(tokens, t)
+	}
+
+	return append(tokens, token{
+		kind:   tokenEOF,
+		offset: len(expression),
+		line:   line,
+		column: utf8.RuneCountInString(expression[lineStart:]),
+	}), nil
+}
+
+// unquote reads the string literal at the start of s, returning its value and
+// the number of bytes it spans.
+func unquote(s string) (string, int, error) {
+	quote := s[0]
+	var value strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case quote:
+			return value.String(), i + 1, nil
+		case '\n':
+			return "", 0, fmt.Errorf("Syntax error: unterminated string literal")
+		case '\\':
+			if i+1 == len(s) {
+				return "", 0, fmt.Errorf("Syntax error: unterminated string literal")
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			case '\\', '"', '\'':
+				value.WriteByte(s[i])
+			default:
+				return "", 0, fmt.Errorf("Syntax error: invalid escape sequence '\\%c'", s[i])
+			}
+		default:
+			value.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("Syntax error: unterminated string literal")
+}
+
+// expr is a boolean node of a parsed expression.
+type expr interface {
+	isExpr()
+}
+
+// logicalExpr combines two expressions with && or ||.
+type logicalExpr struct {
+	op          tokenKind
+	left, right expr
+}
+
+// notExpr negates an expression.
+type notExpr struct {
+	operand expr
+}
+
+// boolLiteral is the true or false constant.
+type boolLiteral struct {
+	value bool
+}
+
+// policyRef references the expression of another policy.
+type policyRef struct {
+	id string
+	at token
+}
+
+// compareExpr compares a user attribute with a literal or another attribute.
+//
+// For ==, != and attribute-in-list comparisons, left is always the attribute.
+// For the list-in-attribute comparison, used with multiselect attributes,
+// right is the attribute and the comparison holds if any of the values of
+// left is one of its values.
+type compareExpr struct {
+	op          tokenKind
+	left, right operand
+}
+
+// callExpr is a string method called on a user attribute.
+type callExpr struct {
+	target operand
+	method string
+	arg    string
+}
+
+func (*logicalExpr) isExpr() {}
+func (*notExpr) isExpr()     {}
+func (*boolLiteral) isExpr() {}
+func (*policyRef) isExpr()   {}
+func (*compareExpr) isExpr() {}
+func (*callExpr) isExpr()    {}
+
+type operandKind int
+
+const (
+	operandAttribute operandKind = iota
+	operandString
+	operandList
+)
+
+type operand struct {
+	kind operandKind
+	// attribute is the name of the user attribute, for operandAttribute.
+	attribute string
+	// values holds the literal values: one for operandString, any number
+	// for operandList.
+	values []string
+	at     token
+}
+
+var stringMethods = []string{"startsWith", "endsWith", "contains"}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// parse parses the expression into its boolean syntax tree.
+func parse(expression string) (expr, error) {
+	if len(expression) > maxExpressionLength {
+		return nil, &celError{line: 1, column: 0, message: fmt.Sprintf("expression exceeds the maximum length of %d bytes", maxExpressionLength)}
+	}
+
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, errorAt(p.peek(), "Syntax error: expression is empty")
+	}
+
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, errorAt(t, "Syntax error: extraneous input '%s'", t.value)
+	}
+
+	return e, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		if t.kind == tokenEOF {
+			return t, errorAt(t, "Syntax error: missing %s at end of expression", what)
+		}
+		return t, errorAt(t, "Syntax error: expected %s, found '%s'", what, t.value)
+	}
+	return t, nil
+}
+
+// nest is called before parsing a nested expression at t. The returned
+// function must be called once the nested expression is parsed.
+func (p *parser) nest(t token) (func(), error) {
+	if p.depth >= maxExpressionDepth {
+		return nil, errorAt(t, "expression exceeds the maximum nesting depth of %d", maxExpressionDepth)
+	}
+	p.depth++
+	return func() { p.depth-- }, nil
+}
+
+func (p *parser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: tokenOr, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalExpr{op: tokenAnd, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.peek().kind == tokenNot {
+		unnest, err := p.nest(p.next())
+		if err != nil {
+			return nil, err
+		}
+		defer unnest()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{operand: operand}, nil
+	}
+
+	return p.parseRelation()
+}
+
+func (p *parser) parseRelation() (expr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	op := p.peek()
+	if op.kind != tokenEq && op.kind != tokenNeq && op.kind != tokenIn {
+		if left.expr == nil {
+			return nil, errorAt(left.operand.at, "expression must evaluate to a boolean")
+		}
+		return left.expr, nil
+	}
+	p.next()
+
+	right, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	if left.expr != nil || right.expr != nil {
+		return nil, errorAt(op, "found no matching overload for '%s' applied to boolean operands", op.value)
+	}
+	l, r := *left.operand, *right.operand
+
+	switch op.kind {
+	case tokenEq, tokenNeq:
+		if l.kind != operandAttribute {
+			l, r = r, l
+		}
+		if l.kind != operandAttribute || r.kind == operandList {
+			return nil, errorAt(op, "found no matching overload for '%s', a user attribute must be compared to a string or another user attribute", op.value)
+		}
+	case tokenIn:
+		switch {
+		case l.kind == operandAttribute && r.kind == operandList:
+		case l.kind != operandAttribute && r.kind == operandAttribute:
+		default:
+			return nil, errorAt(op, "found no matching overload for 'in', expected a user attribute and a list of strings")
+		}
+	}
+
+	return &compareExpr{op: op.kind, left: l, right: r}, nil
+}
+
+// term is either a boolean expression or an operand of a comparison.
+type term struct {
+	expr    expr
+	operand *operand
+}
+
+func (p *parser) parseTerm() (term, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenLParen:
+		unnest, err := p.nest(t)
+		if err != nil {
+			return term{}, err
+		}
+		defer unnest()
+
+		e, err := p.parseOr()
+		if err != nil {
+			return term{}, err
+		}
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return term{}, err
+		}
+		return term{expr: e}, nil
+	case tokenString:
+		return term{operand: &operand{kind: operandString, values: []string{t.value}, at: t}}, nil
+	case tokenLBracket:
+		values := []string{}
+		if p.peek().kind == tokenRBracket {
+			p.next()
+			return term{operand: &operand{kind: operandList, values: values, at: t}}, nil
+		}
+		for {
+			value, err := p.expect(tokenString, "string literal")
+			if err != nil {
+				return term{}, err
+			}
+			values = append(values, value.value)
+
+			sep := p.next()
+			if sep.kind == tokenRBracket {
+				break
+			}
+			if sep.kind != tokenComma {
+				if sep.kind == tokenEOF {
+					return term{}, errorAt(sep, "Syntax error: missing ']' at end of expression")
+				}
+				return term{}, errorAt(sep, "Syntax error: expected ',' or ']', found '%s'", sep.value)
+			}
+		}
+		return term{operand: &operand{kind: operandList, values: values, at: t}}, nil
+	case tokenIdent:
+		return p.parseReference(t)
+	case tokenEOF:
+		return term{}, errorAt(t, "Syntax error: mismatched input '<EOF>'")
+	default:
+		return term{}, errorAt(t, "Syntax error: mismatched input '%s'", t.value)
+	}
+}
+
+// parseReference parses a dotted identifier starting at t, optionally followed
+// by a method call.
+func (p *parser) parseReference(t token) (term, error) {
+	parts := []string{t.value}
+	var method token
+	hasMethod := false
+
+	for p.peek().kind == tokenDot {
+		p.next()
+		ident, err := p.expect(tokenIdent, "identifier")
+		if err != nil {
+			return term{}, err
+		}
+		if p.peek().kind == tokenLParen {
+			method, hasMethod = ident, true
+			break
+		}
+		parts = append(parts, ident.value)
+	}
+
+	var ref term
+	path := strings.Join(parts, ".")
+	switch {
+	case path == "true" || path == "false":
+		ref.expr = &boolLiteral{value: path == "true"}
+	case len(parts) == 3 && parts[0] == "user" && parts[1] == "attributes":
+		ref.operand = &operand{kind: operandAttribute, attribute: parts[2], at: t}
+	case len(parts) == 2 && parts[0] == "policies" && strings.HasPrefix(parts[1], policyRefPrefix):
+		ref.expr = &policyRef{id: strings.TrimPrefix(parts[1], policyRefPrefix), at: t}
+	default:
+		return term{}, errorAt(t, "undeclared reference to '%s'", path)
+	}
+
+	if !hasMethod {
+		return ref, nil
+	}
+
+	p.next()
+	if ref.operand == nil {
+		return term{}, errorAt(method, "found no matching overload for '%s' applied to '%s'", method.value, path)
+	}
+	if !slices.Contains(stringMethods, method.value) {
+		return term{}, errorAt(method, "undeclared reference to '%s'", method.value)
+	}
+
+	arg, err := p.expect(tokenString, "string literal")
+	if err != nil {
+		return term{}, err
+	}
+	if _, err := p.expect(tokenRParen, "')'"); err != nil {
+		return term{}, err
+	}
+
+	return term{expr: &callExpr{target: *ref.operand, method: method.value, arg: arg.value}}, nil
+}
+
+// and combines the expressions with &&, returning nil if there are none.
+func and(exprs ...expr) expr {
+	var result expr
+	for _, e := range exprs {
+		if e == nil {
+			continue
+		}
+		if result == nil {
+			result = e
+		} else {
+			result = &logicalExpr{op: tokenAnd, left: result, right: e}
+		}
+	}
+	return result
+}
+
+// resolvePolicyRefs returns a copy of e with every policy reference replaced
+// by the expression returned by resolve. A nil expression stands for a policy
+// without restrictions.
+func resolvePolicyRefs(e expr, resolve func(ref *policyRef) (expr, error)) (expr, error) {
+	switch e := e.(type) {
+	case *logicalExpr:
+		left, err := resolvePolicyRefs(e.left, resolve)
+		if err != nil {
+			return nil, err
+		}
+		right, err := resolvePolicyRefs(e.right, resolve)
+		if err != nil {
+			return nil, err
+		}
+		return &logicalExpr{op: e.op, left: left, right: right}, nil
+	case *notExpr:
+		operand, err := resolvePolicyRefs(e.operand, resolve)
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{operand: operand}, nil
+	case *policyRef:
+		resolved, err := resolve(e)
+		if err != nil {
+			return nil, err
+		}
+		if resolved == nil {
+			return &boolLiteral{value: true}, nil
+		}
+		return resolved, nil
+	default:
+		return e, nil
+	}
+}
+
+// walkConditions calls fn for every comparison and method call in e.
+func walkConditions(e expr, fn func(e expr)) {
+	switch e := e.(type) {
+	case *logicalExpr:
+		walkConditions(e.left, fn)
+		walkConditions(e.right, fn)
+	case *notExpr:
+		walkConditions(e.operand, fn)
+	case *compareExpr, *callExpr:
+		fn(e)
+	}
+}
+
+// attributeOperands returns the user attributes referenced by e.
+func attributeOperands(e expr) []operand {
+	var attributes []operand
+	walkConditions(e, func(e expr) {
+		switch e := e.(type) {
+		case *compareExpr:
+			for _, o := range []operand{e.left, e.right} {
+				if o.kind == operandAttribute {
+					attributes = append(attributes, o)
+				}
+			}
+		case *callExpr:
+			attributes = append(attributes, e.target)
+		}
+	})
+	return attributes
+}
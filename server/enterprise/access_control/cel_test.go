@@ -0,0 +1,178 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("valid expressions", func(t *testing.T) {
+		for _, expression := range []string{
+			`user.attributes.Team == "Engineering"`,
+			`"Engineering" == user.attributes.Team`,
+			`user.attributes.Team != 'Sales'`,
+			`user.attributes.Team in ["Engineering", "Sales"]`,
+			`user.attributes.Team in []`,
+			`["Go", "Rust"] in user.attributes.Languages`,
+			`"Go" in user.attributes.Languages`,
+			`user.attributes.Email.endsWith("@example.com")`,
+			`user.attributes.Team == user.attributes.ManagerTeam`,
+			`!(user.attributes.Team == "Sales") || (user.attributes.Level.startsWith("L") && true)`,
+			"user.attributes.Team == \"a \\\"quoted\\\" team\"\n&& user.attributes.Level.contains(\"5\")",
+			`policies.id_` + model.NewId(),
+		} {
+			_, err := parse(expression)
+			assert.NoError(t, err, expression)
+		}
+	})
+
+	t.Run("precedence", func(t *testing.T) {
+		e, err := parse(`user.attributes.A == "a" || user.attributes.B == "b" && !user.attributes.C.contains("c")`)
+		require.NoError(t, err)
+
+		or, ok := e.(*logicalExpr)
+		require.True(t, ok)
+		assert.Equal(t, tokenOr, or.op)
+
+		and, ok := or.right.(*logicalExpr)
+		require.True(t, ok)
+		assert.Equal(t, tokenAnd, and.op)
+		assert.IsType(t, &notExpr{}, and.right)
+	})
+
+	t.Run("attribute is always the left operand", func(t *testing.T) {
+		e, err := parse(`"Engineering" == user.attributes.Team`)
+		require.NoError(t, err)
+
+		compare := e.(*compareExpr)
+		assert.Equal(t, operandAttribute, compare.left.kind)
+		assert.Equal(t, "Team", compare.left.attribute)
+		assert.Equal(t, []string{"Engineering"}, compare.right.values)
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		for _, tc := range []struct {
+			expression string
+			line       int
+			column     int
+		}{
+			{"", 1, 0},
+			{`user.attributes.Team == `, 1, 24},
+			{`user.attributes.Team = "a"`, 1, 21},
+			{`user.attributes.Team == "a`, 1, 24},
+			{`user.attributes.Team`, 1, 0},
+			{`"a" == "b"`, 1, 4},
+			{`user.attributes.Team in "a"`, 1, 21},
+			{`user.attributes.Team == ["a"]`, 1, 21},
+			{`user.name == "a"`, 1, 0},
+			{`user.attributes.Team.matches("a.*")`, 1, 21},
+			{`user.attributes.Team.startsWith(user.attributes.Other)`, 1, 32},
+			{"user.attributes.Team == \"a\" &&\n  (user.attributes.Level == 5)", 2, 28},
+			{`(user.attributes.Team == "a"`, 1, 28},
+			{`user.attributes.Team == "a")`, 1, 27},
+		} {
+			_, err := parse(tc.expression)
+			require.Error(t, err, tc.expression)
+
+			celErr, ok := err.(*celError)
+			require.True(t, ok, tc.expression)
+			assert.Equal(t, tc.line, celErr.line, tc.expression)
+			assert.Equal(t, tc.column, celErr.column, tc.expression)
+		}
+	})
+
+	t.Run("limits", func(t *testing.T) {
+		condition := `user.attributes.Team == "a"`
+
+		_, err := parse(strings.Repeat("(", maxExpressionDepth) + condition + strings.Repeat(")", maxExpressionDepth))
+		require.NoError(t, err)
+		_, err = parse(strings.Repeat("!", maxExpressionDepth) + condition)
+		require.NoError(t, err)
+
+		for _, expression := range []string{
+			strings.Repeat("(", maxExpressionDepth+1) + condition + strings.Repeat(")", maxExpressionDepth+1),
+			strings.Repeat("!(", maxExpressionDepth) + condition + strings.Repeat(")", maxExpressionDepth),
+			strings.Repeat("(", 1000000),
+			condition + strings.Repeat(" && "+condition, maxExpressionLength/len(condition)),
+		} {
+			_, err := parse(expression)
+			require.Error(t, err)
+			assert.IsType(t, &celError{}, err)
+		}
+	})
+}
+
+func TestResolvePolicyRefs(t *testing.T) {
+	parentID := model.NewId()
+	e, err := parse(`policies.id_` + parentID + ` && !policies.id_` + parentID)
+	require.NoError(t, err)
+
+	parent, err := parse(`user.attributes.Team == "Engineering"`)
+	require.NoError(t, err)
+
+	resolved, err := resolvePolicyRefs(e, func(ref *policyRef) (expr, error) {
+		assert.Equal(t, parentID, ref.id)
+		return parent, nil
+	})
+	require.NoError(t, err)
+
+	assert.False(t, evaluate(resolved, map[string]any{"Team": "Engineering"}))
+
+	resolved, err = resolvePolicyRefs(e, func(ref *policyRef) (expr, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.False(t, evaluate(resolved, map[string]any{}))
+}
+
+func TestNormalizeExpression(t *testing.T) {
+	fieldID := model.NewId()
+	fields := &attributeFields{
+		types: map[string]string{"Team": "select"},
+		names: map[string]string{fieldID: "Team"},
+	}
+
+	assert.Equal(t,
+		`user.attributes.Team == "`+fieldID+`" && user.attributes.Team.startsWith("E")`,
+		normalizeExpression(`user.attributes.`+fieldID+` == "`+fieldID+`" && user.attributes.`+fieldID+`.startsWith("E")`, fields),
+	)
+	assert.Equal(t, `user.attributes.Other == "a"`, normalizeExpression(`user.attributes.Other == "a"`, fields))
+	assert.Equal(t, `user.attributes.`, normalizeExpression(`user.attributes.`, fields))
+}
+
+func TestToVisualExpression(t *testing.T) {
+	types := map[string]string{"Team": "select", "Languages": "multiselect", "Email": "text"}
+
+	e, err := parse(`user.attributes.Team == "Engineering" && ["Go", "Rust"] in user.attributes.Languages && user.attributes.Email.endsWith("@example.com") && user.attributes.Team != user.attributes.Email && user.attributes.Team in ["A", "B"]`)
+	require.NoError(t, err)
+
+	visual, err := toVisualExpression(e, types)
+	require.NoError(t, err)
+	assert.Equal(t, []model.Condition{
+		{Attribute: "user.attributes.Team", Operator: "==", Value: "Engineering", ValueType: model.LiteralValue, AttributeType: "select"},
+		{Attribute: "user.attributes.Languages", Operator: "in", Value: []string{"Go", "Rust"}, ValueType: model.LiteralValue, AttributeType: "multiselect"},
+		{Attribute: "user.attributes.Email", Operator: "endsWith", Value: "@example.com", ValueType: model.LiteralValue, AttributeType: "text"},
+		{Attribute: "user.attributes.Team", Operator: "!=", Value: "user.attributes.Email", ValueType: model.AttrValue, AttributeType: "select"},
+		{Attribute: "user.attributes.Team", Operator: "in", Value: []string{"A", "B"}, ValueType: model.LiteralValue, AttributeType: "select"},
+	}, visual.Conditions)
+
+	for _, expression := range []string{
+		`user.attributes.Team == "A" || user.attributes.Team == "B"`,
+		`!(user.attributes.Team == "A")`,
+		`true`,
+	} {
+		e, err := parse(expression)
+		require.NoError(t, err)
+
+		_, err = toVisualExpression(e, types)
+		assert.ErrorIs(t, err, errNotVisual, expression)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"slices"
+	"strings"
+)
+
+// evaluate evaluates the expression against the attributes of a subject, as
+// read from the AttributeView. A condition on a missing attribute, or on an
+// attribute of an unexpected type, never holds.
+//
+// The expression must have its policy references resolved beforehand.
+func evaluate(e expr, attributes map[string]any) bool {
+	switch e := e.(type) {
+	case *logicalExpr:
+		if e.op == tokenAnd {
+			return evaluate(e.left, attributes) && evaluate(e.right, attributes)
+		}
+		return evaluate(e.left, attributes) || evaluate(e.right, attributes)
+	case *notExpr:
+		return !evaluate(e.operand, attributes)
+	case *boolLiteral:
+		return e.value
+	case *compareExpr:
+		return evaluateCompare(e, attributes)
+	case *callExpr:
+		value, ok := stringAttribute(attributes, e.target.attribute)
+		if !ok {
+			return false
+		}
+		switch e.method {
+		case "startsWith":
+			return strings.HasPrefix(value, e.arg)
+		case "endsWith":
+			return strings.HasSuffix(value, e.arg)
+		case "contains":
+			return strings.Contains(value, e.arg)
+		}
+	}
+
+	return false
+}
+
+func evaluateCompare(e *compareExpr, attributes map[string]any) bool {
+	if e.op == tokenIn && e.right.kind == operandAttribute {
+		values, ok := listAttribute(attributes, e.right.attribute)
+		if !ok {
+			return false
+		}
+		for _, value := range e.left.values {
+			if slices.Contains(values, value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	left, ok := stringAttribute(attributes, e.left.attribute)
+	if !ok {
+		return false
+	}
+
+	if e.op == tokenIn {
+		return slices.Contains(e.right.values, left)
+	}
+
+	var right string
+	if e.right.kind == operandAttribute {
+		if right, ok = stringAttribute(attributes, e.right.attribute); !ok {
+			return false
+		}
+	} else {
+		right = e.right.values[0]
+	}
+
+	if e.op == tokenEq {
+		return left == right
+	}
+	return left != right
+}
+
+func stringAttribute(attributes map[string]any, name string) (string, bool) {
+	value, ok := attributes[name].(string)
+	return value, ok
+}
+
+func listAttribute(attributes map[string]any, name string) ([]string, bool) {
+	switch value := attributes[name].(type) {
+	case []string:
+		return value, true
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values, true
+	}
+	return nil, false
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	attributes := map[string]any{
+		"Team":      "Engineering",
+		"Email":     "jane@example.com",
+		"Manager":   "Engineering",
+		"Languages": []any{"Go", "TypeScript"},
+	}
+
+	for _, tc := range []struct {
+		expression string
+		expected   bool
+	}{
+		{`user.attributes.Team == "Engineering"`, true},
+		{`user.attributes.Team == "Sales"`, false},
+		{`"Engineering" == user.attributes.Team`, true},
+		{`user.attributes.Team != "Sales"`, true},
+		{`user.attributes.Team in ["Sales", "Engineering"]`, true},
+		{`user.attributes.Team in ["Sales"]`, false},
+		{`user.attributes.Team in []`, false},
+		{`"Go" in user.attributes.Languages`, true},
+		{`["Rust", "TypeScript"] in user.attributes.Languages`, true},
+		{`["Rust", "Java"] in user.attributes.Languages`, false},
+		{`user.attributes.Email.endsWith("@example.com")`, true},
+		{`user.attributes.Email.startsWith("john")`, false},
+		{`user.attributes.Email.contains("@")`, true},
+		{`user.attributes.Team == user.attributes.Manager`, true},
+		{`user.attributes.Team != user.attributes.Email`, true},
+		{`user.attributes.Team == "Sales" || user.attributes.Email.contains("jane")`, true},
+		{`user.attributes.Team == "Engineering" && !(user.attributes.Email.contains("jane"))`, false},
+		{`true && !false`, true},
+
+		// Conditions on missing attributes or on attributes of another type
+		// never hold.
+		{`user.attributes.Missing == "a"`, false},
+		{`user.attributes.Missing != "a"`, false},
+		{`!(user.attributes.Missing == "a")`, true},
+		{`user.attributes.Languages == "Go"`, false},
+		{`user.attributes.Languages != "Go"`, false},
+		{`"Engineering" in user.attributes.Team`, false},
+		{`user.attributes.Missing.contains("")`, false},
+		{`user.attributes.Team == user.attributes.Missing`, false},
+	} {
+		e, err := parse(tc.expression)
+		require.NoError(t, err, tc.expression)
+		assert.Equal(t, tc.expected, evaluate(e, attributes), tc.expression)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	ejobs "github.com/mattermost/mattermost/server/v8/einterfaces/jobs"
+)
+
+// The built-in policy decision point is only used when no other access
+// control service, such as the enterprise one, is registered.
+func init() {
+	app.RegisterBuiltinAccessControlInterfaces(
+		func(a *app.App) einterfaces.AccessControlServiceInterface {
+			return NewAccessControlService(a)
+		},
+		func(s *app.Server) ejobs.AccessControlSyncJobInterface {
+			return &AccessControlSyncJob{server: s}
+		},
+	)
+}
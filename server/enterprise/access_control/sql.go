@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sqlCompiler translates an expression into a condition over the Attributes
+// column of the AttributeView, matching the semantics of evaluate.
+//
+// Every value, including the attribute names, is passed as a positional
+// argument. The condition is meant to be used as the Query of a
+// model.SubjectSearchOptions, so the arguments are numbered from $1.
+type sqlCompiler struct {
+	args []any
+}
+
+// compileSQL returns the condition and the arguments for the expression,
+// which must have its policy references resolved beforehand.
+func compileSQL(e expr) (string, []any) {
+	c := &sqlCompiler{}
+	return c.compile(e), c.args
+}
+
+func (c *sqlCompiler) arg(value string) string {
+	c.args = append(c.args, value)
+	return fmt.Sprintf("$%d::text", len(c.args))
+}
+
+// text returns the value of a string attribute, or NULL if the attribute is
+// missing or is not a string.
+func (c *sqlCompiler) text(attribute string) string {
+	key := c.arg(attribute)
+	return fmt.Sprintf("(CASE WHEN jsonb_typeof(AttributeView.Attributes -> %[1]s) = 'string' THEN AttributeView.Attributes ->> %[1]s END)", key)
+}
+
+func (c *sqlCompiler) compile(e expr) string {
+	switch e := e.(type) {
+	case *logicalExpr:
+		op := "AND"
+		if e.op == tokenOr {
+			op = "OR"
+		}
+		return fmt.Sprintf("(%s %s %s)", c.compile(e.left), op, c.compile(e.right))
+	case *notExpr:
+		return fmt.Sprintf("(NOT %s)", c.compile(e.operand))
+	case *boolLiteral:
+		if e.value {
+			return "TRUE"
+		}
+		return "FALSE"
+	case *compareExpr:
+		// Conditions on missing attributes evaluate to NULL, which is coalesced
+		// to FALSE so that negations behave as in evaluate.
+		return fmt.Sprintf("COALESCE(%s, FALSE)", c.compileCompare(e))
+	case *callExpr:
+		value := c.text(e.target.attribute)
+		arg := c.arg(e.arg)
+		switch e.method {
+		case "startsWith":
+			return fmt.Sprintf("COALESCE(left(%s, char_length(%s)) = %s, FALSE)", value, arg, arg)
+		case "endsWith":
+			return fmt.Sprintf("COALESCE(right(%s, char_length(%s)) = %s, FALSE)", value, arg, arg)
+		case "contains":
+			return fmt.Sprintf("COALESCE(strpos(%s, %s) > 0, FALSE)", value, arg)
+		}
+	}
+
+	return "FALSE"
+}
+
+func (c *sqlCompiler) compileCompare(e *compareExpr) string {
+	if e.op == tokenIn && e.right.kind == operandAttribute {
+		if len(e.left.values) == 0 {
+			return "FALSE"
+		}
+		key := c.arg(e.right.attribute)
+		conditions := make([]string, 0, len(e.left.values))
+		for _, value := range e.left.values {
+			conditions = append(conditions, fmt.Sprintf("AttributeView.Attributes -> %s @> jsonb_build_array(%s)", key, c.arg(value)))
+		}
+		return "(" + strings.Join(conditions, " OR ") + ")"
+	}
+
+	if e.op == tokenIn && len(e.right.values) == 0 {
+		return "FALSE"
+	}
+
+	left := c.text(e.left.attribute)
+
+	if e.op == tokenIn {
+		placeholders := make([]string, 0, len(e.right.values))
+		for _, value := range e.right.values {
+			placeholders = append(placeholders, c.arg(value))
+		}
+		return fmt.Sprintf("%s IN (%s)", left, strings.Join(placeholders, ", "))
+	}
+
+	var right string
+	if e.right.kind == operandAttribute {
+		right = c.text(e.right.attribute)
+	} else {
+		right = c.arg(e.right.values[0])
+	}
+
+	if e.op == tokenEq {
+		return fmt.Sprintf("%s = %s", left, right)
+	}
+	return fmt.Sprintf("%s <> %s", left, right)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileSQL(t *testing.T) {
+	const text1 = "(CASE WHEN jsonb_typeof(AttributeView.Attributes -> $1::text) = 'string' THEN AttributeView.Attributes ->> $1::text END)"
+
+	for _, tc := range []struct {
+		name       string
+		expression string
+		query      string
+		args       []any
+	}{
+		{
+			name:       "equals",
+			expression: `user.attributes.Team == "Engineering"`,
+			query:      "COALESCE(" + text1 + " = $2::text, FALSE)",
+			args:       []any{"Team", "Engineering"},
+		},
+		{
+			name:       "not equals",
+			expression: `user.attributes.Team != "Sales"`,
+			query:      "COALESCE(" + text1 + " <> $2::text, FALSE)",
+			args:       []any{"Team", "Sales"},
+		},
+		{
+			name:       "attribute in list",
+			expression: `user.attributes.Team in ["A", "B"]`,
+			query:      "COALESCE(" + text1 + " IN ($2::text, $3::text), FALSE)",
+			args:       []any{"Team", "A", "B"},
+		},
+		{
+			name:       "attribute in empty list",
+			expression: `user.attributes.Team in []`,
+			query:      "COALESCE(FALSE, FALSE)",
+		},
+		{
+			name:       "list in attribute",
+			expression: `["Go", "Rust"] in user.attributes.Languages`,
+			query:      "COALESCE((AttributeView.Attributes -> $1::text @> jsonb_build_array($2::text) OR AttributeView.Attributes -> $1::text @> jsonb_build_array($3::text)), FALSE)",
+			args:       []any{"Languages", "Go", "Rust"},
+		},
+		{
+			name:       "starts with",
+			expression: `user.attributes.Team.startsWith("Eng")`,
+			query:      "COALESCE(left(" + text1 + ", char_length($2::text)) = $2::text, FALSE)",
+			args:       []any{"Team", "Eng"},
+		},
+		{
+			name:       "contains",
+			expression: `user.attributes.Team.contains("gin")`,
+			query:      "COALESCE(strpos(" + text1 + ", $2::text) > 0, FALSE)",
+			args:       []any{"Team", "gin"},
+		},
+		{
+			name:       "logical operators",
+			expression: `!(user.attributes.Team == "A") || true && false`,
+			query:      "((NOT COALESCE(" + text1 + " = $2::text, FALSE)) OR (TRUE AND FALSE))",
+			args:       []any{"Team", "A"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := parse(tc.expression)
+			require.NoError(t, err)
+
+			query, args := compileSQL(e)
+			assert.Equal(t, tc.query, query)
+			assert.Equal(t, tc.args, args)
+		})
+	}
+
+	t.Run("attribute to attribute", func(t *testing.T) {
+		e, err := parse(`user.attributes.Team == user.attributes.Manager`)
+		require.NoError(t, err)
+
+		query, args := compileSQL(e)
+		assert.Contains(t, query, "AttributeView.Attributes ->> $1::text END) = (CASE")
+		assert.Contains(t, query, "AttributeView.Attributes ->> $2::text END)")
+		assert.Equal(t, []any{"Team", "Manager"}, args)
+	})
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	ejobs "github.com/mattermost/mattermost/server/v8/einterfaces/jobs"
+)
+
+const (
+	syncJobName     = "AccessControlSync"
+	syncJobInterval = 1 * time.Hour
+
+	policiesPageSize = 100
+	usersPageSize    = 100
+)
+
+// AccessControlSyncJob removes the members of access controlled channels that
+// no longer satisfy the policy of the channel and, for policies with
+// automatic membership, adds the users that do.
+//
+// The job runs for the policy in the policy_id job data if set, or for every
+// channel policy otherwise. A parent policy ID runs it for every channel
+// inheriting from it.
+type AccessControlSyncJob struct {
+	server *app.Server
+}
+
+func (j *AccessControlSyncJob) MakeWorker() model.Worker {
+	return jobs.NewSimpleWorker(syncJobName, j.server.Jobs, j.execute, j.isEnabled)
+}
+
+func (j *AccessControlSyncJob) MakeScheduler() ejobs.Scheduler {
+	return jobs.NewPeriodicScheduler(j.server.Jobs, model.JobTypeAccessControlSync, syncJobInterval, j.isEnabled)
+}
+
+func (j *AccessControlSyncJob) isEnabled(cfg *model.Config) bool {
+	return model.MinimumEnterpriseAdvancedLicense(j.server.License()) && *cfg.AccessControlSettings.EnableAttributeBasedAccessControl
+}
+
+func (j *AccessControlSyncJob) execute(logger mlog.LoggerIFace, job *model.Job) error {
+	defer j.server.Jobs.HandleJobPanic(logger, job)
+
+	rctx := request.EmptyContext(logger)
+	a := app.New(app.ServerConnector(j.server.Channels()))
+
+	acs := j.server.Channels().AccessControl
+	if acs == nil {
+		return model.NewAppError("AccessControlSyncJob", "ent.access_control.sync_job.app_error", nil, "access control service is not available", http.StatusNotImplemented)
+	}
+
+	// The attributes of the users are read from a materialized view.
+	if err := j.server.Store().Attributes().RefreshAttributes(); err != nil {
+		return model.NewAppError("AccessControlSyncJob", "ent.access_control.sync_job.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	policies, appErr := j.policiesToSync(rctx, job.Data["policy_id"])
+	if appErr != nil {
+		return appErr
+	}
+
+	var removed, added int
+	for _, policy := range policies {
+		r, ad, appErr := j.syncChannel(rctx, a, policy)
+		if appErr != nil {
+			return appErr
+		}
+		removed += r
+		added += ad
+	}
+
+	if job.Data == nil {
+		job.Data = make(model.StringMap)
+	}
+	job.Data["synced_channels"] = strconv.Itoa(len(policies))
+	job.Data["removed_members"] = strconv.Itoa(removed)
+	job.Data["added_members"] = strconv.Itoa(added)
+
+	return nil
+}
+
+// policiesToSync returns the channel policies covered by the job.
+func (j *AccessControlSyncJob) policiesToSync(rctx request.CTX, policyID string) ([]*model.AccessControlPolicy, *model.AppError) {
+	search := model.AccessControlPolicySearch{
+		Type:  model.AccessControlPolicyTypeChannel,
+		Limit: policiesPageSize,
+	}
+
+	if policyID != "" {
+		policy, appErr := j.server.Channels().AccessControl.GetPolicy(rctx, policyID)
+		if appErr != nil {
+			if appErr.StatusCode == http.StatusNotFound {
+				// The policy was deleted since the job was created.
+				return nil, nil
+			}
+			return nil, appErr
+		}
+		if policy.Type == model.AccessControlPolicyTypeChannel {
+			return []*model.AccessControlPolicy{policy}, nil
+		}
+		search.ParentID = policy.ID
+	}
+
+	var policies []*model.AccessControlPolicy
+	for {
+		page, _, err := j.server.Store().AccessControlPolicy().SearchPolicies(rctx, search)
+		if err != nil {
+			return nil, model.NewAppError("AccessControlSyncJob", "ent.access_control.sync_job.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		policies = append(policies, page...)
+		if len(page) < policiesPageSize {
+			return policies, nil
+		}
+		search.Cursor.ID = page[len(page)-1].ID
+	}
+}
+
+// syncChannel enforces the policy on the members of its channel, returning
+// how many members were removed and added.
+func (j *AccessControlSyncJob) syncChannel(rctx request.CTX, a *app.App, policy *model.AccessControlPolicy) (int, int, *model.AppError) {
+	acs := j.server.Channels().AccessControl
+	logger := rctx.Logger().With(mlog.String("channel_id", policy.ID))
+
+	channel, appErr := a.GetChannel(rctx, policy.ID)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return 0, 0, nil
+		}
+		return 0, 0, appErr
+	}
+	if channel.DeleteAt != 0 {
+		return 0, 0, nil
+	}
+
+	members, appErr := acs.GetChannelMembersToRemove(rctx, channel.Id)
+	if appErr != nil {
+		return 0, 0, appErr
+	}
+
+	removed := 0
+	for _, member := range members {
+		if appErr := a.RemoveUserFromChannel(rctx, member.UserId, "", channel); appErr != nil {
+			logger.Warn("Failed to remove a non-compliant channel member", mlog.String("user_id", member.UserId), mlog.Err(appErr))
+			continue
+		}
+		removed++
+	}
+
+	autoAdd, appErr := j.autoAddMembers(rctx, policy)
+	if appErr != nil || !autoAdd {
+		return removed, 0, appErr
+	}
+
+	added := 0
+	opts := model.SubjectSearchOptions{
+		TeamID:                channel.TeamId,
+		ExcludeChannelMembers: channel.Id,
+		Limit:                 usersPageSize,
+		IgnoreCount:           true,
+	}
+	for {
+		users, _, appErr := acs.QueryUsersForResource(rctx, channel.Id, anyAction, opts)
+		if appErr != nil {
+			return removed, added, appErr
+		}
+
+		for _, user := range users {
+			if _, appErr := a.AddChannelMember(rctx, user.Id, channel, app.ChannelMemberOpts{}); appErr != nil {
+				logger.Warn("Failed to add a compliant user to the channel", mlog.String("user_id", user.Id), mlog.Err(appErr))
+				continue
+			}
+			added++
+		}
+
+		if len(users) < usersPageSize {
+			return removed, added, nil
+		}
+		opts.Cursor.TargetID = users[len(users)-1].Id
+	}
+}
+
+// autoAddMembers returns whether the compliant users should be added to the
+// channel of the policy, which is the case if the policy or any of the
+// policies it imports is active.
+func (j *AccessControlSyncJob) autoAddMembers(rctx request.CTX, policy *model.AccessControlPolicy) (bool, *model.AppError) {
+	if policy.Active {
+		return true, nil
+	}
+
+	for _, id := range policy.Imports {
+		parent, appErr := j.server.Channels().AccessControl.GetPolicy(rctx, id)
+		if appErr != nil {
+			return false, appErr
+		}
+		if parent.Active {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
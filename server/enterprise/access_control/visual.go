@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+var errNotVisual = errors.New("only conditions combined with && can be represented visually")
+
+// toVisualExpression converts the expression into the list of conditions shown
+// by the table editor of the system console. attributeTypes maps the name of
+// each user attribute to the type of its property field.
+func toVisualExpression(e expr, attributeTypes map[string]string) (*model.VisualExpression, error) {
+	visual := &model.VisualExpression{Conditions: []model.Condition{}}
+
+	var visit func(e expr) error
+	visit = func(e expr) error {
+		switch e := e.(type) {
+		case *logicalExpr:
+			if e.op != tokenAnd {
+				return errNotVisual
+			}
+			if err := visit(e.left); err != nil {
+				return err
+			}
+			return visit(e.right)
+		case *compareExpr:
+			visual.Conditions = append(visual.Conditions, compareCondition(e, attributeTypes))
+			return nil
+		case *callExpr:
+			visual.Conditions = append(visual.Conditions, model.Condition{
+				Attribute:     userAttributesPrefix + e.target.attribute,
+				Operator:      e.method,
+				Value:         e.arg,
+				ValueType:     model.LiteralValue,
+				AttributeType: attributeTypes[e.target.attribute],
+			})
+			return nil
+		default:
+			return errNotVisual
+		}
+	}
+
+	if err := visit(e); err != nil {
+		return nil, err
+	}
+
+	return visual, nil
+}
+
+func compareCondition(e *compareExpr, attributeTypes map[string]string) model.Condition {
+	if e.op == tokenIn && e.right.kind == operandAttribute {
+		return model.Condition{
+			Attribute:     userAttributesPrefix + e.right.attribute,
+			Operator:      "in",
+			Value:         e.left.values,
+			ValueType:     model.LiteralValue,
+			AttributeType: attributeTypes[e.right.attribute],
+		}
+	}
+
+	condition := model.Condition{
+		Attribute:     userAttributesPrefix + e.left.attribute,
+		ValueType:     model.LiteralValue,
+		AttributeType: attributeTypes[e.left.attribute],
+	}
+
+	switch e.op {
+	case tokenIn:
+		condition.Operator = "in"
+		condition.Value = e.right.values
+	case tokenEq:
+		condition.Operator = "=="
+	case tokenNeq:
+		condition.Operator = "!="
+	}
+
+	if e.op != tokenIn {
+		if e.right.kind == operandAttribute {
+			condition.Value = userAttributesPrefix + e.right.attribute
+			condition.ValueType = model.AttrValue
+		} else {
+			condition.Value = e.right.values[0]
+		}
+	}
+
+	return condition
+}
@@ -171,6 +171,10 @@ type MetricsInterfaceImpl struct {
 	PluginMultiHookTimeHistogram       *prometheus.HistogramVec
 	PluginMultiHookServerTimeHistogram prometheus.Histogram
 	PluginAPITimeHistogram             *prometheus.HistogramVec
+	PluginProcessMemoryGauge           *prometheus.GaugeVec
+	PluginProcessCPUGauge              *prometheus.GaugeVec
+	PluginAPIQuotaExceededCounter      *prometheus.CounterVec
+	PluginDatabaseQueryTimeHistogram   *prometheus.HistogramVec
 
 	LoggerQueueGauge      *DynamicGauge
 	LoggerLoggedCounters  *DynamicCounter
@@ -192,6 +196,7 @@ type MetricsInterfaceImpl struct {
 	SharedChannelsSyncSendHistogram           *prometheus.HistogramVec
 	SharedChannelsSyncCollectionStepHistogram *prometheus.HistogramVec
 	SharedChannelsSyncSendStepHistogram       *prometheus.HistogramVec
+	SharedChannelsSyncLagHistogram            *prometheus.HistogramVec
 
 	ServerStartTime prometheus.Gauge
 
@@ -902,6 +907,53 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.PluginAPITimeHistogram)
 
+	m.PluginProcessMemoryGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "process_resident_memory_bytes",
+			Help:        "Resident memory size of the plugin server process in bytes.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginProcessMemoryGauge)
+
+	m.PluginProcessCPUGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "process_cpu_percent",
+			Help:        "CPU usage of the plugin server process since the last health check, as a percentage of a single core.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginProcessCPUGauge)
+
+	m.PluginAPIQuotaExceededCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "api_quota_exceeded_total",
+			Help:        "Total number of plugin API calls rejected because the plugin exceeded its quota.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id", "api_name"},
+	)
+	m.Registry.MustRegister(m.PluginAPIQuotaExceededCounter)
+
+	m.PluginDatabaseQueryTimeHistogram = prometheus.NewHistogramVec(
+		withLabels(prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: MetricsSubsystemPlugin,
+			Name:      "db_query_time",
+			Help:      "Time to execute queries made by plugins through the database driver in seconds.",
+		}),
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginDatabaseQueryTimeHistogram)
+
 	// Logging subsystem
 
 	m.LoggerQueueGauge = NewDynamicGauge(
@@ -1110,6 +1162,18 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.SharedChannelsSyncSendStepHistogram)
 
+	m.SharedChannelsSyncLagHistogram = prometheus.NewHistogramVec(
+		withLabels(prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: MetricsSubsystemSharedChannels,
+			Name:      "sync_lag_seconds",
+			Help:      "Time between the latest post change sent to a remote and the sync cursor update (seconds)",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 15, 60, 300, 900, 3600, 21600, 86400},
+		}),
+		[]string{"remote_id"},
+	)
+	m.Registry.MustRegister(m.SharedChannelsSyncLagHistogram)
+
 	m.ServerStartTime = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace:   MetricsNamespace,
 		Subsystem:   MetricsSubsystemSystem,
@@ -2000,6 +2064,22 @@ func (mi *MetricsInterfaceImpl) ObservePluginAPIDuration(pluginID, apiName strin
 	mi.PluginAPITimeHistogram.With(prometheus.Labels{"plugin_id": pluginID, "api_name": apiName, "success": strconv.FormatBool(success)}).Observe(elapsed)
 }
 
+func (mi *MetricsInterfaceImpl) SetPluginProcessMemory(pluginID string, bytes float64) {
+	mi.PluginProcessMemoryGauge.With(prometheus.Labels{"plugin_id": pluginID}).Set(bytes)
+}
+
+func (mi *MetricsInterfaceImpl) SetPluginProcessCPU(pluginID string, percent float64) {
+	mi.PluginProcessCPUGauge.With(prometheus.Labels{"plugin_id": pluginID}).Set(percent)
+}
+
+func (mi *MetricsInterfaceImpl) IncrementPluginAPIQuotaExceeded(pluginID, apiName string) {
+	mi.PluginAPIQuotaExceededCounter.With(prometheus.Labels{"plugin_id": pluginID, "api_name": apiName}).Inc()
+}
+
+func (mi *MetricsInterfaceImpl) ObservePluginDatabaseQueryDuration(pluginID string, elapsed float64) {
+	mi.PluginDatabaseQueryTimeHistogram.With(prometheus.Labels{"plugin_id": pluginID}).Observe(elapsed)
+}
+
 func (mi *MetricsInterfaceImpl) GetLoggerMetricsCollector() mlog.MetricsCollector {
 	return &LoggerMetricsCollector{
 		queueGauge:      mi.LoggerQueueGauge,
@@ -2088,6 +2168,12 @@ func (mi *MetricsInterfaceImpl) ObserveSharedChannelsSyncSendStepDuration(remote
 	}).Observe(elapsed)
 }
 
+func (mi *MetricsInterfaceImpl) ObserveSharedChannelsSyncLag(remoteID string, elapsed float64) {
+	mi.SharedChannelsSyncLagHistogram.With(prometheus.Labels{
+		"remote_id": remoteID,
+	}).Observe(elapsed)
+}
+
 // SetReplicaLagAbsolute sets the absolute replica lag for a given node.
 func (mi *MetricsInterfaceImpl) SetReplicaLagAbsolute(node string, value float64) {
 	mi.DbReplicaLagGaugeAbs.With(prometheus.Labels{"node": node}).Set(value)
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package remotecluster
+
+import (
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// SetRemoteFeatures records the optional features advertised by a remote cluster
+// in its most recent ping.
+func (rcs *Service) SetRemoteFeatures(remoteID string, features []string) {
+	rcs.featuresMux.Lock()
+	defer rcs.featuresMux.Unlock()
+
+	rcs.remoteFeatures[remoteID] = slices.Clone(features)
+}
+
+// RemoteSupportsFeature returns true if the remote cluster advertised the feature in
+// its last ping. It never blocks on the network: if the remote's features are not
+// known yet, a ping is sent in the background and false is returned until it answers.
+// Plugin remotes never support optional features.
+func (rcs *Service) RemoteSupportsFeature(rc *model.RemoteCluster, feature string) bool {
+	if rc.IsPlugin() {
+		return false
+	}
+
+	features, ok := rcs.getRemoteFeatures(rc.RemoteId)
+	if !ok {
+		rcs.pingForFeatures(rc)
+		return false
+	}
+	return slices.Contains(features, feature)
+}
+
+// pingForFeatures pings the remote in the background so its features become known,
+// unless such a ping is already in flight.
+func (rcs *Service) pingForFeatures(rc *model.RemoteCluster) {
+	rcs.featuresMux.Lock()
+	defer rcs.featuresMux.Unlock()
+
+	if rcs.pendingFeatures[rc.RemoteId] {
+		return
+	}
+	rcs.pendingFeatures[rc.RemoteId] = true
+
+	// the ping updates the remote it is given, so it gets its own copy.
+	rcCopy := *rc
+	go func() {
+		defer func() {
+			rcs.featuresMux.Lock()
+			delete(rcs.pendingFeatures, rcCopy.RemoteId)
+			rcs.featuresMux.Unlock()
+		}()
+		rcs.PingNow(&rcCopy)
+	}()
+}
+
+func (rcs *Service) getRemoteFeatures(remoteID string) ([]string, bool) {
+	rcs.featuresMux.RLock()
+	defer rcs.featuresMux.RUnlock()
+
+	features, ok := rcs.remoteFeatures[remoteID]
+	return features, ok
+}
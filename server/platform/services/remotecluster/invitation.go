@@ -43,29 +43,30 @@ func (rcs *Service) AcceptInvitation(invite *model.RemoteClusterInvite, name str
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/%s", rcSaved.SiteURL, ConfirmInviteURL)
-
 	// for the invite confirm message, we need to use the token that
 	// the originating server sent in the invite instead of the one
 	// we're storing as a refresh
 	rc.RemoteToken = invite.Token
 
-	resp, err := rcs.sendFrameToRemote(PingTimeout, rc, frame, url)
+	resp, err := rcs.sendFrameToRemote(PingTimeout, rc, frame, ConfirmInviteURL)
 	if err != nil {
 		rcs.server.GetStore().RemoteCluster().Delete(rcSaved.RemoteId)
 		return nil, err
 	}
 
-	var response Response
-	err = json.Unmarshal(resp, &response)
-	if err != nil {
-		rcs.server.GetStore().RemoteCluster().Delete(rcSaved.RemoteId)
-		return nil, fmt.Errorf("invalid response from remote server: %w", err)
-	}
-
-	if !response.IsSuccess() {
-		rcs.server.GetStore().RemoteCluster().Delete(rcSaved.RemoteId)
-		return nil, errors.New(response.Err)
+	// store-and-forward transports accept the confirmation without a reply from the remote.
+	if len(resp) != 0 {
+		var response Response
+		err = json.Unmarshal(resp, &response)
+		if err != nil {
+			rcs.server.GetStore().RemoteCluster().Delete(rcSaved.RemoteId)
+			return nil, fmt.Errorf("invalid response from remote server: %w", err)
+		}
+
+		if !response.IsSuccess() {
+			rcs.server.GetStore().RemoteCluster().Delete(rcSaved.RemoteId)
+			return nil, errors.New(response.Err)
+		}
 	}
 
 	// issue the first ping right away. The goroutine will exit when ping completes or PingTimeout exceeded.
@@ -6,7 +6,6 @@ package remotecluster
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -120,20 +119,34 @@ func (rcs *Service) pingRemote(rc *model.RemoteCluster) error {
 		if err != nil {
 			return err
 		}
-		url := fmt.Sprintf("%s/%s", rc.SiteURL, PingURL)
-
-		resp, err := rcs.sendFrameToRemote(PingTimeout, rc, frame, url)
+		resp, err := rcs.sendFrameToRemote(PingTimeout, rc, frame, PingURL)
 		if err != nil {
 			return err
 		}
 		rc.LastPingAt = model.GetMillis()
 
+		if len(resp) == 0 {
+			// store-and-forward transports don't get a reply. The remote's features are learned
+			// from its own pings, if any arrive; until then no optional features are used.
+			if _, ok := rcs.getRemoteFeatures(rc.RemoteId); !ok {
+				rcs.SetRemoteFeatures(rc.RemoteId, nil)
+			}
+			return rcs.recordPing(rc, nil)
+		}
+
 		err = json.Unmarshal(resp, &ping)
 		if err != nil {
 			return err
 		}
+		rcs.SetRemoteFeatures(rc.RemoteId, ping.Features)
 	}
 
+	return rcs.recordPing(rc, &ping)
+}
+
+// recordPing updates LastPingAt for the remote and records ping metrics. `ping` is nil when
+// the transport did not return a reply.
+func (rcs *Service) recordPing(rc *model.RemoteCluster, ping *model.RemoteClusterPing) error {
 	if err := rcs.server.GetStore().RemoteCluster().SetLastPingAt(rc.RemoteId); err != nil {
 		rcs.server.Log().Log(mlog.LvlRemoteClusterServiceError, "Failed to update LastPingAt for remote cluster",
 			mlog.String("remote", rc.DisplayName),
@@ -142,6 +155,14 @@ func (rcs *Service) pingRemote(rc *model.RemoteCluster) error {
 		)
 	}
 
+	if ping == nil {
+		rcs.server.Log().Log(mlog.LvlRemoteClusterServiceDebug, "Remote cluster ping spooled",
+			mlog.String("remote", rc.DisplayName),
+			mlog.String("remoteId", rc.RemoteId),
+		)
+		return nil
+	}
+
 	if metrics := rcs.server.GetMetrics(); metrics != nil {
 		sentAt := time.Unix(0, ping.SentAt*int64(time.Millisecond))
 		elapsed := time.Since(sentAt).Seconds()
@@ -165,7 +186,8 @@ func (rcs *Service) pingRemote(rc *model.RemoteCluster) error {
 
 func makePingFrame(rc *model.RemoteCluster) (*model.RemoteClusterFrame, error) {
 	ping := model.RemoteClusterPing{
-		SentAt: model.GetMillis(),
+		SentAt:   model.GetMillis(),
+		Features: model.RemoteClusterFeatures,
 	}
 	pingRaw, err := json.Marshal(ping)
 	if err != nil {
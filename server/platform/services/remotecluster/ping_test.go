@@ -159,6 +159,58 @@ func TestPing(t *testing.T) {
 		assert.NoError(t, merr.ErrorOrNil())
 	})
 
+	t.Run("Feature negotiation", func(t *testing.T) {
+		merr := merror.New()
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var frame model.RemoteClusterFrame
+			if err := json.NewDecoder(r.Body).Decode(&frame); err != nil {
+				merr.Append(err)
+				return
+			}
+
+			var ping model.RemoteClusterPing
+			if err := json.Unmarshal(frame.Msg.Payload, &ping); err != nil {
+				merr.Append(err)
+				return
+			}
+			if !assert.ElementsMatch(t, model.RemoteClusterFeatures, ping.Features) {
+				merr.Append(fmt.Errorf("ping should advertise local features, got %v", ping.Features))
+			}
+
+			ping.RecvAt = model.GetMillis()
+			ping.Features = []string{model.RemoteClusterFeatureBookmarks}
+			if err := json.NewEncoder(w).Encode(ping); err != nil {
+				merr.Append(err)
+			}
+		}))
+		defer ts.Close()
+
+		remotes := makeRemoteClusters(1, ts.URL, false)
+		mockServer := newMockServer(t, remotes)
+		mockApp := newMockApp(t, nil)
+
+		service, err := NewRemoteClusterService(mockServer, mockApp)
+		require.NoError(t, err)
+
+		// features are unknown until the first ping, which is sent in the background.
+		assert.False(t, service.RemoteSupportsFeature(remotes[0], model.RemoteClusterFeatureBookmarks))
+		assert.Eventually(t, func() bool {
+			return service.RemoteSupportsFeature(remotes[0], model.RemoteClusterFeatureBookmarks)
+		}, 5*time.Second, 50*time.Millisecond)
+		assert.False(t, service.RemoteSupportsFeature(remotes[0], model.RemoteClusterFeatureChannelInfo))
+		assert.NoError(t, merr.ErrorOrNil())
+
+		// a remote that stops advertising a feature no longer gets it.
+		service.SetRemoteFeatures(remotes[0].RemoteId, nil)
+		assert.False(t, service.RemoteSupportsFeature(remotes[0], model.RemoteClusterFeatureBookmarks))
+
+		// plugin remotes never support optional features.
+		plugin := makeRemoteClusters(1, model.NewId(), true)[0]
+		service.SetRemoteFeatures(plugin.RemoteId, model.RemoteClusterFeatures)
+		assert.False(t, service.RemoteSupportsFeature(plugin, model.RemoteClusterFeatureBookmarks))
+	})
+
 	t.Run("Plugin ping", func(t *testing.T) {
 		mockServer := newMockServer(t, makeRemoteClusters(NumRemotes, model.NewId(), true))
 		offline := []string{mockServer.remotes[0].PluginID, mockServer.remotes[1].PluginID}
@@ -4,10 +4,15 @@
 package remotecluster
 
 import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 )
 
 // ReceiveIncomingMsg is called by the Rest API layer, or websocket layer (future), when a Remote Cluster
@@ -42,6 +47,69 @@ func (rcs *Service) ReceiveIncomingMsg(rc *model.RemoteCluster, msg model.Remote
 	return response
 }
 
+// receiveFrame is the InboundFrameHandler for transports that don't deliver frames through the
+// Rest API. It applies the same checks as the Rest API handlers before routing the frame.
+// Since the sender gets no reply, failures reported by topic listeners are only logged.
+func (rcs *Service) receiveFrame(in *InboundFrame) error {
+	frame := in.Frame
+	if appErr := frame.IsValid(); appErr != nil {
+		return fmt.Errorf("%w: %s", ErrFrameRejected, appErr.Error())
+	}
+
+	rc, err := rcs.server.GetStore().RemoteCluster().Get(frame.RemoteId, false)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return fmt.Errorf("%w: unknown remote %s", ErrFrameRejected, frame.RemoteId)
+		}
+		return fmt.Errorf("cannot fetch remote %s: %w", frame.RemoteId, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(in.Token), []byte(rc.Token)) != 1 {
+		return fmt.Errorf("%w: invalid token for remote %s", ErrFrameRejected, frame.RemoteId)
+	}
+
+	switch in.URLPath {
+	case SendMsgURL:
+		response := rcs.ReceiveIncomingMsg(rc, frame.Msg)
+		if !response.IsSuccess() {
+			rcs.server.Log().Log(mlog.LvlRemoteClusterServiceWarn, "Remote cluster message from transport failed",
+				mlog.String("remote", rc.DisplayName),
+				mlog.String("msgId", frame.Msg.Id),
+				mlog.String("topic", frame.Msg.Topic),
+				mlog.String("error", response.Err),
+			)
+		}
+
+	case PingURL:
+		var ping model.RemoteClusterPing
+		if err := json.Unmarshal(frame.Msg.Payload, &ping); err != nil {
+			return fmt.Errorf("%w: invalid ping payload: %w", ErrFrameRejected, err)
+		}
+		// a ping from a remote proves it is alive, even though no reply can be sent.
+		if err := rcs.server.GetStore().RemoteCluster().SetLastPingAt(rc.RemoteId); err != nil {
+			return fmt.Errorf("cannot update LastPingAt for remote %s: %w", rc.RemoteId, err)
+		}
+		rcs.SetRemoteFeatures(rc.RemoteId, ping.Features)
+
+	case ConfirmInviteURL:
+		if time.Since(model.GetTimeForMillis(rc.CreateAt)) > InviteExpiresAfter {
+			return fmt.Errorf("%w: invitation for remote %s expired", ErrFrameRejected, rc.RemoteId)
+		}
+		var confirm model.RemoteClusterInvite
+		if err := json.Unmarshal(frame.Msg.Payload, &confirm); err != nil {
+			return fmt.Errorf("%w: invalid invite confirmation payload: %w", ErrFrameRejected, err)
+		}
+		if _, err := rcs.ReceiveInviteConfirmation(confirm); err != nil {
+			return fmt.Errorf("%w: %w", ErrFrameRejected, err)
+		}
+
+	default:
+		return fmt.Errorf("%w: unsupported endpoint %q", ErrFrameRejected, in.URLPath)
+	}
+	return nil
+}
+
 func callback(listener TopicListener, msg model.RemoteClusterMsg, rc *model.RemoteCluster, resp *Response) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -12,6 +12,10 @@ type Response struct {
 	Status  string          `json:"status"`
 	Err     string          `json:"err"`
 	Payload json.RawMessage `json:"payload"`
+
+	// Accepted is set when a store-and-forward transport accepted the message for delivery.
+	// Such transports never carry a reply from the remote, so there is no payload.
+	Accepted bool `json:"-"`
 }
 
 // IsSuccess returns true if the response status indicates success.
@@ -7,9 +7,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"path"
 	"time"
 
@@ -101,37 +98,15 @@ func (rcs *Service) sendFileToRemote(timeout time.Duration, task sendFileTask) (
 	}
 	defer r.Close()
 
-	u, err := url.Parse(task.rc.SiteURL)
-	if err != nil {
-		return nil, fmt.Errorf("invalid siteURL while sending file to remote %s: %w", task.rc.RemoteId, err)
-	}
-	u.Path = path.Join(u.Path, model.APIURLSuffix, "remotecluster", "upload", task.us.Id)
-
-	req, err := http.NewRequest("POST", u.String(), r)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set(model.HeaderRemoteclusterId, task.rc.RemoteId)
-	req.Header.Set(model.HeaderRemoteclusterToken, task.rc.RemoteToken)
+	urlPath := path.Join(model.APIURLSuffix, "remotecluster", "upload", task.us.Id)
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	resp, err := rcs.httpClient.Do(req.WithContext(ctx))
+	body, err := rcs.transport.SendUpload(ctx, task.rc, urlPath, "", r)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected response: %d - %s", resp.StatusCode, resp.Status)
-	}
 
 	// body should be a FileInfo
 	var fi model.FileInfo
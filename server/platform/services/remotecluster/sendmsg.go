@@ -4,15 +4,9 @@
 package remotecluster
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"os"
-	"path"
 	"time"
 
 	"github.com/wiggin77/merror"
@@ -97,19 +91,7 @@ func (rcs *Service) sendMsg(task sendMsgTask) {
 		Msg:      task.msg,
 	}
 
-	u, err := url.Parse(task.rc.SiteURL)
-	if err != nil {
-		rcs.server.Log().Log(mlog.LvlRemoteClusterServiceError, "Invalid siteURL while sending message to remote",
-			mlog.String("remote", task.rc.DisplayName),
-			mlog.String("msgId", task.msg.Id),
-			mlog.Err(err),
-		)
-		errResp = err
-		return
-	}
-	u.Path = path.Join(u.Path, SendMsgURL)
-
-	respJSON, err := rcs.sendFrameToRemote(SendTimeout, task.rc, frame, u.String())
+	respJSON, err := rcs.sendFrameToRemote(SendTimeout, task.rc, frame, SendMsgURL)
 
 	if err != nil {
 		rcs.server.Log().Log(mlog.LvlRemoteClusterServiceError, "Remote Cluster send message failed",
@@ -124,6 +106,13 @@ func (rcs *Service) sendMsg(task sendMsgTask) {
 			mlog.String("msgId", task.msg.Id),
 		)
 
+		if len(respJSON) == 0 {
+			// store-and-forward transports accept the message without a reply from the remote.
+			response.Status = ResponseStatusOK
+			response.Accepted = true
+			return
+		}
+
 		if err = json.Unmarshal(respJSON, &response); err != nil {
 			rcs.server.Log().Error("Invalid response sending message to remote cluster",
 				mlog.String("remote", task.rc.DisplayName),
@@ -134,42 +123,18 @@ func (rcs *Service) sendMsg(task sendMsgTask) {
 	}
 }
 
-func (rcs *Service) sendFrameToRemote(timeout time.Duration, rc *model.RemoteCluster, frame *model.RemoteClusterFrame, url string) ([]byte, error) {
-	body, err := json.Marshal(frame)
-	if err != nil {
-		return nil, err
-	}
-
+// sendFrameToRemote sends a frame to the remote endpoint at urlPath using the configured transport.
+func (rcs *Service) sendFrameToRemote(timeout time.Duration, rc *model.RemoteCluster, frame *model.RemoteClusterFrame, urlPath string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set(model.HeaderRemoteclusterId, rc.RemoteId)
-	req.Header.Set(model.HeaderRemoteclusterToken, rc.RemoteToken)
-
-	resp, err := rcs.httpClient.Do(req.WithContext(ctx))
+	body, err := rcs.transport.SendFrame(ctx, rc, frame, urlPath)
 	if metrics := rcs.server.GetMetrics(); metrics != nil {
-		if err != nil || resp.StatusCode != http.StatusOK {
+		if err != nil {
 			metrics.IncrementRemoteClusterMsgErrorsCounter(frame.RemoteId, os.IsTimeout(err))
 		} else {
 			metrics.IncrementRemoteClusterMsgSentCounter(frame.RemoteId)
 		}
 	}
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	body, err = io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return body, fmt.Errorf("unexpected response: %d - %s", resp.StatusCode, resp.Status)
-	}
-	return body, nil
+	return body, err
 }
@@ -9,8 +9,6 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
-	"net/http"
-	"net/url"
 	"path"
 	"time"
 
@@ -101,11 +99,7 @@ func (rcs *Service) sendProfileImageToRemote(timeout time.Duration, task sendPro
 		return fmt.Errorf("error fetching profile image for user (%s) while sending to remote %s: %w", task.userID, task.rc.RemoteId, appErr)
 	}
 
-	u, err := url.Parse(task.rc.SiteURL)
-	if err != nil {
-		return fmt.Errorf("invalid siteURL while sending file to remote %s: %w", task.rc.RemoteId, err)
-	}
-	u.Path = path.Join(u.Path, model.APIURLSuffix, "remotecluster", task.userID, "image")
+	urlPath := path.Join(model.APIURLSuffix, "remotecluster", task.userID, "image")
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -123,30 +117,9 @@ func (rcs *Service) sendProfileImageToRemote(timeout time.Duration, task sendPro
 		return err
 	}
 
-	req, err := http.NewRequest("POST", u.String(), body)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.Header.Set(model.HeaderRemoteclusterId, task.rc.RemoteId)
-	req.Header.Set(model.HeaderRemoteclusterToken, task.rc.RemoteToken)
-
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	resp, err := rcs.httpClient.Do(req.WithContext(ctx))
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	_, err = io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unexpected response: %d - %s", resp.StatusCode, resp.Status)
-	}
-	return nil
+	_, err = rcs.transport.SendUpload(ctx, task.rc, urlPath, writer.FormDataContentType(), body)
+	return err
 }
@@ -72,6 +72,8 @@ type RemoteClusterServiceIFace interface {
 	ReceiveIncomingMsg(rc *model.RemoteCluster, msg model.RemoteClusterMsg) Response
 	ReceiveInviteConfirmation(invite model.RemoteClusterInvite) (*model.RemoteCluster, error)
 	PingNow(rc *model.RemoteCluster)
+	SetRemoteFeatures(remoteID string, features []string)
+	RemoteSupportsFeature(rc *model.RemoteCluster, feature string) bool
 }
 
 // TopicListener is a callback signature used to listen for incoming messages for
@@ -83,10 +85,15 @@ type ConnectionStateListener func(rc *model.RemoteCluster, online bool)
 
 // Service provides inter-cluster communication via topic based messages. In product these are called "Secured Connections".
 type Service struct {
-	server     ServerIface
-	app        AppIface
-	httpClient *http.Client
-	send       []chan any
+	server    ServerIface
+	app       AppIface
+	transport Transport
+	send      []chan any
+
+	// remote features are guarded separately since pings run while `mux` is held.
+	featuresMux     sync.RWMutex
+	remoteFeatures  map[string][]string // maps remote id to features advertised via ping
+	pendingFeatures map[string]bool     // remote ids with a ping in flight to learn their features
 
 	// everything below guarded by `mux`
 	mux                      sync.RWMutex
@@ -121,12 +128,19 @@ func NewRemoteClusterService(server ServerIface, app AppIface) (*Service, error)
 		Timeout:   SendTimeout,
 	}
 
+	msgTransport, err := newTransport(server.Config(), client)
+	if err != nil {
+		return nil, err
+	}
+
 	service := &Service{
 		server:                   server,
 		app:                      app,
-		httpClient:               client,
+		transport:                msgTransport,
 		topicListeners:           make(map[string]map[string]TopicListener),
 		connectionStateListeners: make(map[string]ConnectionStateListener),
+		remoteFeatures:           make(map[string][]string),
+		pendingFeatures:          make(map[string]bool),
 	}
 
 	service.send = make([]chan any, MaxConcurrentSends)
@@ -273,6 +287,9 @@ func (rcs *Service) resume() {
 		go rcs.sendLoop(i, rcs.done)
 	}
 
+	// receive frames from transports that don't use the REST API.
+	go rcs.transport.Receive(rcs.receiveFrame, rcs.done)
+
 	rcs.server.Log().Debug("Remote Cluster Service active")
 }
 
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package remotecluster
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+var (
+	// ErrTransportUnsupported is returned by transports that cannot carry a particular kind of request.
+	ErrTransportUnsupported = errors.New("operation not supported by remote cluster transport")
+
+	// ErrFrameRejected is returned by an InboundFrameHandler when a frame can never be processed
+	// (unknown remote, bad token, malformed frame). Rejected frames are not redelivered.
+	ErrFrameRejected = errors.New("remote cluster frame rejected")
+)
+
+// Transport moves frames between this server and remote clusters. The default transport posts
+// frames directly to the remote's SiteURL. Other transports allow remotes to be reached through
+// segmented networks, e.g. via a spool directory drained by a message broker.
+//
+// Ordering is provided by the send loop, which hands frames for a given remote to the transport
+// one at a time; transports must deliver frames in the order they are sent.
+type Transport interface {
+	// Name returns the transport's name as used in config.
+	Name() string
+
+	// SendFrame delivers a frame to the remote endpoint at urlPath (e.g. `SendMsgURL`) and returns
+	// the response body. Store-and-forward transports return an empty body once the frame has been
+	// durably accepted for delivery.
+	SendFrame(ctx context.Context, rc *model.RemoteCluster, frame *model.RemoteClusterFrame, urlPath string) ([]byte, error)
+
+	// SendUpload streams a file attachment or profile image to the remote endpoint at urlPath.
+	SendUpload(ctx context.Context, rc *model.RemoteCluster, urlPath string, contentType string, body io.Reader) ([]byte, error)
+
+	// Receive delivers inbound frames to the handler until done is closed. Transports whose inbound
+	// frames arrive through the REST API return immediately.
+	Receive(handler InboundFrameHandler, done <-chan struct{})
+}
+
+// InboundFrame is a frame received by a transport, along with the token and endpoint it was
+// addressed to.
+type InboundFrame struct {
+	Frame   model.RemoteClusterFrame
+	Token   string
+	URLPath string
+}
+
+// InboundFrameHandler processes a frame received by a transport. Returning an error that wraps
+// `ErrFrameRejected` discards the frame; any other error leaves it to be redelivered.
+type InboundFrameHandler func(in *InboundFrame) error
+
+// newTransport creates the transport selected in config, defaulting to HTTP.
+func newTransport(cfg *model.Config, client *http.Client) (Transport, error) {
+	if cfg == nil || cfg.ConnectedWorkspacesSettings.Transport == nil {
+		return newHTTPTransport(client), nil
+	}
+
+	switch *cfg.ConnectedWorkspacesSettings.Transport {
+	case model.ConnectedWorkspacesTransportSpool:
+		spool, err := NewSpoolTransport(*cfg.ConnectedWorkspacesSettings.SpoolDirectory)
+		if err != nil {
+			return nil, err
+		}
+		return spool, nil
+	default:
+		return newHTTPTransport(client), nil
+	}
+}
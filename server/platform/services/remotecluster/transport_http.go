@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package remotecluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// httpTransport posts frames directly to the remote's SiteURL. Inbound frames arrive through
+// the REST API, see `api4/remote_cluster.go`.
+type httpTransport struct {
+	client *http.Client
+}
+
+func newHTTPTransport(client *http.Client) *httpTransport {
+	return &httpTransport{
+		client: client,
+	}
+}
+
+func (ht *httpTransport) Name() string {
+	return model.ConnectedWorkspacesTransportHTTP
+}
+
+func (ht *httpTransport) SendFrame(ctx context.Context, rc *model.RemoteCluster, frame *model.RemoteClusterFrame, urlPath string) ([]byte, error) {
+	body, err := json.Marshal(frame)
+	if err != nil {
+		return nil, err
+	}
+	return ht.post(ctx, rc, urlPath, "application/json", bytes.NewReader(body))
+}
+
+func (ht *httpTransport) SendUpload(ctx context.Context, rc *model.RemoteCluster, urlPath string, contentType string, body io.Reader) ([]byte, error) {
+	return ht.post(ctx, rc, urlPath, contentType, body)
+}
+
+func (ht *httpTransport) Receive(_ InboundFrameHandler, _ <-chan struct{}) {
+	// inbound frames are received by the REST API.
+}
+
+func (ht *httpTransport) post(ctx context.Context, rc *model.RemoteCluster, urlPath string, contentType string, body io.Reader) ([]byte, error) {
+	u, err := url.Parse(rc.SiteURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid siteURL for remote %s: %w", rc.RemoteId, err)
+	}
+	u.Path = path.Join(u.Path, urlPath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set(model.HeaderRemoteclusterId, rc.RemoteId)
+	req.Header.Set(model.HeaderRemoteclusterToken, rc.RemoteToken)
+
+	resp, err := ht.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return respBody, fmt.Errorf("unexpected response: %d - %s", resp.StatusCode, resp.Status)
+	}
+	return respBody, nil
+}
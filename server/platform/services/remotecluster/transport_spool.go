@@ -0,0 +1,198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package remotecluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	SpoolOutboundDir   = "outbound"
+	SpoolInboundDir    = "inbound"
+	SpoolRejectedDir   = "rejected"
+	SpoolFileExt       = ".json"
+	SpoolPollFrequency = time.Second * 5
+)
+
+// spoolEnvelope is the content of each spool file. It carries what the HTTP transport sends as
+// URL and headers.
+type spoolEnvelope struct {
+	RemoteId  string                    `json:"remote_id"`
+	Token     string                    `json:"token"`
+	URLPath   string                    `json:"url_path"`
+	SpooledAt int64                     `json:"spooled_at"`
+	Frame     *model.RemoteClusterFrame `json:"frame"`
+}
+
+// SpoolTransport is a store-and-forward transport for networks where remotes cannot reach each
+// other directly. Outbound frames are written as files to `<dir>/outbound`; an external process
+// (message broker, file drop) is responsible for moving them to the remote's `<dir>/inbound`.
+// Inbound files are processed in file name order, which matches the order they were spooled.
+//
+// Sends succeed once the frame is written to disk, and remotes never reply. File attachments and
+// profile images are not supported.
+type SpoolTransport struct {
+	dir      string
+	seq      atomic.Uint64
+	pollFreq time.Duration
+}
+
+// NewSpoolTransport creates a spool transport rooted at dir, creating its sub-directories if needed.
+func NewSpoolTransport(dir string) (*SpoolTransport, error) {
+	if dir == "" {
+		return nil, errors.New("spool directory not configured")
+	}
+
+	for _, sub := range []string{SpoolOutboundDir, SpoolInboundDir, SpoolRejectedDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return nil, fmt.Errorf("cannot create spool directory: %w", err)
+		}
+	}
+
+	return &SpoolTransport{
+		dir:      dir,
+		pollFreq: SpoolPollFrequency,
+	}, nil
+}
+
+func (st *SpoolTransport) Name() string {
+	return model.ConnectedWorkspacesTransportSpool
+}
+
+// SendFrame writes the frame to the outbound directory. The file is written under a temporary
+// name and renamed once complete, so readers never see partial files.
+func (st *SpoolTransport) SendFrame(ctx context.Context, rc *model.RemoteCluster, frame *model.RemoteClusterFrame, urlPath string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	env := spoolEnvelope{
+		RemoteId:  rc.RemoteId,
+		Token:     rc.RemoteToken,
+		URLPath:   urlPath,
+		SpooledAt: model.GetMillis(),
+		Frame:     frame,
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return nil, err
+	}
+
+	// zero padded so lexical order is spool order.
+	name := fmt.Sprintf("%020d-%010d-%s%s", time.Now().UnixNano(), st.seq.Add(1)%1e10, frame.Msg.Id, SpoolFileExt)
+	outDir := filepath.Join(st.dir, SpoolOutboundDir)
+
+	tmp, err := os.CreateTemp(outDir, ".spool-*")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create spool file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("cannot write spool file: %w", err)
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("cannot sync spool file: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return nil, fmt.Errorf("cannot close spool file: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), filepath.Join(outDir, name)); err != nil {
+		return nil, fmt.Errorf("cannot commit spool file: %w", err)
+	}
+	return nil, nil
+}
+
+func (st *SpoolTransport) SendUpload(_ context.Context, _ *model.RemoteCluster, _ string, _ string, _ io.Reader) ([]byte, error) {
+	return nil, ErrTransportUnsupported
+}
+
+// Receive polls the inbound directory until done is closed.
+func (st *SpoolTransport) Receive(handler InboundFrameHandler, done <-chan struct{}) {
+	ticker := time.NewTicker(st.pollFreq)
+	defer ticker.Stop()
+
+	for {
+		// errors are retried on the next poll.
+		_, _ = st.ProcessInbound(handler)
+
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+	}
+}
+
+// ProcessInbound processes all files currently in the inbound directory, in order, and returns
+// the number of frames handled. Processed files are removed and rejected files are moved to the
+// rejected directory. Processing stops at the first frame that should be retried so later frames
+// are never handled before it.
+func (st *SpoolTransport) ProcessInbound(handler InboundFrameHandler) (int, error) {
+	inDir := filepath.Join(st.dir, SpoolInboundDir)
+
+	entries, err := os.ReadDir(inDir) // sorted by file name
+	if err != nil {
+		return 0, fmt.Errorf("cannot read spool inbound directory: %w", err)
+	}
+
+	var count int
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != SpoolFileExt {
+			continue
+		}
+
+		path := filepath.Join(inDir, name)
+		err := st.processFile(path, handler)
+		switch {
+		case err == nil:
+			if rmErr := os.Remove(path); rmErr != nil {
+				return count, fmt.Errorf("cannot remove processed spool file %s: %w", name, rmErr)
+			}
+			count++
+		case errors.Is(err, ErrFrameRejected):
+			if mvErr := os.Rename(path, filepath.Join(st.dir, SpoolRejectedDir, name)); mvErr != nil {
+				return count, fmt.Errorf("cannot move rejected spool file %s: %w", name, mvErr)
+			}
+		default:
+			return count, fmt.Errorf("cannot process spool file %s: %w", name, err)
+		}
+	}
+	return count, nil
+}
+
+func (st *SpoolTransport) processFile(path string, handler InboundFrameHandler) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var env spoolEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return fmt.Errorf("%w: invalid spool file: %w", ErrFrameRejected, err)
+	}
+	if env.Frame == nil || env.Frame.RemoteId != env.RemoteId {
+		return fmt.Errorf("%w: spool file frame does not match remote %s", ErrFrameRejected, env.RemoteId)
+	}
+
+	return handler(&InboundFrame{
+		Frame:   *env.Frame,
+		Token:   env.Token,
+		URLPath: env.URLPath,
+	})
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package remotecluster
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+// forwardSpool plays the part of the message broker by moving all outbound files of one spool
+// to the inbound directory of another.
+func forwardSpool(t *testing.T, from, to string) int {
+	t.Helper()
+
+	entries, err := os.ReadDir(filepath.Join(from, SpoolOutboundDir))
+	require.NoError(t, err)
+
+	var count int
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != SpoolFileExt {
+			continue
+		}
+		err := os.Rename(filepath.Join(from, SpoolOutboundDir, entry.Name()), filepath.Join(to, SpoolInboundDir, entry.Name()))
+		require.NoError(t, err)
+		count++
+	}
+	return count
+}
+
+func countSpoolFiles(t *testing.T, dir string) int {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	return len(entries)
+}
+
+func TestSpoolTransport(t *testing.T) {
+	rc := makeRemoteCluster("spool remote", "http://spool.example.com", TestTopics)
+	rc.RemoteToken = model.NewId()
+
+	sendFrames := func(t *testing.T, st *SpoolTransport, num int) []string {
+		t.Helper()
+
+		ids := make([]string, 0, num)
+		for range num {
+			frame := &model.RemoteClusterFrame{
+				RemoteId: rc.RemoteId,
+				Msg:      makeRemoteClusterMsg(model.NewId(), NoteContent),
+			}
+			resp, err := st.SendFrame(context.Background(), rc, frame, SendMsgURL)
+			require.NoError(t, err)
+			assert.Empty(t, resp)
+			ids = append(ids, frame.Msg.Id)
+		}
+		return ids
+	}
+
+	t.Run("Frames are received in order", func(t *testing.T) {
+		sender, err := NewSpoolTransport(t.TempDir())
+		require.NoError(t, err)
+		receiver, err := NewSpoolTransport(t.TempDir())
+		require.NoError(t, err)
+
+		ids := sendFrames(t, sender, 25)
+		require.Equal(t, 25, forwardSpool(t, sender.dir, receiver.dir))
+
+		var received []string
+		count, err := receiver.ProcessInbound(func(in *InboundFrame) error {
+			assert.Equal(t, rc.RemoteId, in.Frame.RemoteId)
+			assert.Equal(t, rc.RemoteToken, in.Token)
+			assert.Equal(t, SendMsgURL, in.URLPath)
+			received = append(received, in.Frame.Msg.Id)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 25, count)
+		assert.Equal(t, ids, received)
+		assert.Zero(t, countSpoolFiles(t, filepath.Join(receiver.dir, SpoolInboundDir)))
+	})
+
+	t.Run("Rejected frames are moved aside", func(t *testing.T) {
+		sender, err := NewSpoolTransport(t.TempDir())
+		require.NoError(t, err)
+		receiver, err := NewSpoolTransport(t.TempDir())
+		require.NoError(t, err)
+
+		ids := sendFrames(t, sender, 3)
+		forwardSpool(t, sender.dir, receiver.dir)
+
+		// a file that isn't a spool envelope is rejected without reaching the handler.
+		err = os.WriteFile(filepath.Join(receiver.dir, SpoolInboundDir, "0-bogus"+SpoolFileExt), []byte("not json"), 0600)
+		require.NoError(t, err)
+
+		var received []string
+		count, err := receiver.ProcessInbound(func(in *InboundFrame) error {
+			if in.Frame.Msg.Id == ids[1] {
+				return ErrFrameRejected
+			}
+			received = append(received, in.Frame.Msg.Id)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, []string{ids[0], ids[2]}, received)
+		assert.Zero(t, countSpoolFiles(t, filepath.Join(receiver.dir, SpoolInboundDir)))
+		assert.Equal(t, 2, countSpoolFiles(t, filepath.Join(receiver.dir, SpoolRejectedDir)))
+	})
+
+	t.Run("Retryable errors stop processing", func(t *testing.T) {
+		sender, err := NewSpoolTransport(t.TempDir())
+		require.NoError(t, err)
+		receiver, err := NewSpoolTransport(t.TempDir())
+		require.NoError(t, err)
+
+		ids := sendFrames(t, sender, 3)
+		forwardSpool(t, sender.dir, receiver.dir)
+
+		var received []string
+		fail := true
+		handler := func(in *InboundFrame) error {
+			if in.Frame.Msg.Id == ids[1] && fail {
+				return errors.New("database unavailable")
+			}
+			received = append(received, in.Frame.Msg.Id)
+			return nil
+		}
+
+		count, err := receiver.ProcessInbound(handler)
+		require.Error(t, err)
+		assert.Equal(t, 1, count)
+		assert.Equal(t, 2, countSpoolFiles(t, filepath.Join(receiver.dir, SpoolInboundDir)))
+
+		fail = false
+		count, err = receiver.ProcessInbound(handler)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, ids, received)
+	})
+
+	t.Run("Uploads are not supported", func(t *testing.T) {
+		st, err := NewSpoolTransport(t.TempDir())
+		require.NoError(t, err)
+
+		_, err = st.SendUpload(context.Background(), rc, "api/v4/remotecluster/upload/"+model.NewId(), "", nil)
+		assert.ErrorIs(t, err, ErrTransportUnsupported)
+	})
+
+	t.Run("Directory required", func(t *testing.T) {
+		st, err := NewSpoolTransport("")
+		require.Error(t, err)
+		assert.Nil(t, st)
+	})
+}
+
+func TestReceiveFrame(t *testing.T) {
+	disablePing = true
+
+	// remote ids are shared by both sides; the sender's RemoteToken is the receiver's Token.
+	rc := makeRemoteCluster("sender", "http://sender.example.com", TestTopics)
+
+	remoteClusterStoreMock := &mocks.RemoteClusterStore{}
+	remoteClusterStoreMock.On("Get", rc.RemoteId, false).Return(rc, nil)
+	remoteClusterStoreMock.On("Get", "unknownremoteid00000000000", false).Return(nil, store.NewErrNotFound("RemoteCluster", "unknownremoteid00000000000"))
+	remoteClusterStoreMock.On("SetLastPingAt", rc.RemoteId).Return(nil)
+
+	storeMock := &mocks.Store{}
+	storeMock.On("RemoteCluster").Return(remoteClusterStoreMock)
+
+	mockServer := newMockServerWithStore(t, storeMock)
+	service, err := NewRemoteClusterService(mockServer, newMockApp(t, nil))
+	require.NoError(t, err)
+
+	t.Run("Spooled messages reach topic listeners in order", func(t *testing.T) {
+		sender, err := NewSpoolTransport(t.TempDir())
+		require.NoError(t, err)
+		receiver, err := NewSpoolTransport(t.TempDir())
+		require.NoError(t, err)
+
+		var mux sync.Mutex
+		var received []string
+		listenerID := service.AddTopicListener(TestTopic, func(msg model.RemoteClusterMsg, remote *model.RemoteCluster, resp *Response) error {
+			mux.Lock()
+			defer mux.Unlock()
+			assert.Equal(t, rc.RemoteId, remote.RemoteId)
+			received = append(received, msg.Id)
+			return nil
+		})
+		defer service.RemoveTopicListener(listenerID)
+
+		senderRC := *rc
+		senderRC.RemoteToken = rc.Token
+
+		var ids []string
+		for range 10 {
+			frame := &model.RemoteClusterFrame{
+				RemoteId: senderRC.RemoteId,
+				Msg:      makeRemoteClusterMsg(model.NewId(), NoteContent),
+			}
+			_, err = sender.SendFrame(context.Background(), &senderRC, frame, SendMsgURL)
+			require.NoError(t, err)
+			ids = append(ids, frame.Msg.Id)
+		}
+		forwardSpool(t, sender.dir, receiver.dir)
+
+		count, err := receiver.ProcessInbound(service.receiveFrame)
+		require.NoError(t, err)
+		assert.Equal(t, 10, count)
+
+		mux.Lock()
+		defer mux.Unlock()
+		assert.Equal(t, ids, received)
+	})
+
+	t.Run("Ping records remote as alive", func(t *testing.T) {
+		frame, err := makePingFrame(rc)
+		require.NoError(t, err)
+
+		err = service.receiveFrame(&InboundFrame{Frame: *frame, Token: rc.Token, URLPath: PingURL})
+		require.NoError(t, err)
+		remoteClusterStoreMock.AssertCalled(t, "SetLastPingAt", rc.RemoteId)
+	})
+
+	t.Run("Invalid frames are rejected", func(t *testing.T) {
+		msg := makeRemoteClusterMsg(model.NewId(), NoteContent)
+
+		testCases := []struct {
+			name string
+			in   *InboundFrame
+		}{
+			{"bad token", &InboundFrame{Frame: model.RemoteClusterFrame{RemoteId: rc.RemoteId, Msg: msg}, Token: model.NewId(), URLPath: SendMsgURL}},
+			{"unknown remote", &InboundFrame{Frame: model.RemoteClusterFrame{RemoteId: "unknownremoteid00000000000", Msg: msg}, Token: rc.Token, URLPath: SendMsgURL}},
+			{"invalid frame", &InboundFrame{Frame: model.RemoteClusterFrame{RemoteId: rc.RemoteId}, Token: rc.Token, URLPath: SendMsgURL}},
+			{"unsupported endpoint", &InboundFrame{Frame: model.RemoteClusterFrame{RemoteId: rc.RemoteId, Msg: msg}, Token: rc.Token, URLPath: "api/v4/remotecluster/upload"}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := service.receiveFrame(tc.in)
+				assert.ErrorIs(t, err, ErrFrameRejected)
+			})
+		}
+	})
+
+	t.Run("Receive stops when done is closed", func(t *testing.T) {
+		st, err := NewSpoolTransport(t.TempDir())
+		require.NoError(t, err)
+		st.pollFreq = time.Millisecond * 10
+
+		done := make(chan struct{})
+		stopped := make(chan struct{})
+		go func() {
+			st.Receive(service.receiveFrame, done)
+			close(stopped)
+		}()
+		close(done)
+
+		select {
+		case <-stopped:
+		case <-time.After(time.Second * 5):
+			require.Fail(t, "Receive did not stop")
+		}
+	})
+}
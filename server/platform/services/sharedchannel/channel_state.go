@@ -0,0 +1,193 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// remoteSupportsFeature returns true if the remote advertised the optional feature in its
+// last ping. Remotes running older versions never receive optional sync data.
+func (scs *Service) remoteSupportsFeature(rc *model.RemoteCluster, feature string) bool {
+	rcs := scs.server.GetRemoteClusterService()
+	if rcs == nil {
+		return false
+	}
+	return rcs.RemoteSupportsFeature(rc, feature)
+}
+
+// NotifyPersistentNotificationResolved is called when persistent notifications for a post
+// have been stopped, so remotes sharing the post's channel can stop them as well.
+func (scs *Service) NotifyPersistentNotificationResolved(post *model.Post) {
+	if rcs := scs.server.GetRemoteClusterService(); rcs == nil {
+		return
+	}
+
+	if _, err := scs.server.GetStore().SharedChannel().Get(post.ChannelId); err != nil {
+		if !isNotFoundError(err) {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Failed to fetch shared channel for persistent notification sync",
+				mlog.String("channel_id", post.ChannelId),
+				mlog.String("post_id", post.Id),
+				mlog.Err(err),
+			)
+		}
+		return
+	}
+
+	existingMsg := &model.SyncMsg{
+		ChannelId:                       post.ChannelId,
+		ResolvedPersistentNotifications: []string{post.Id},
+	}
+
+	// the post id keeps the task from replacing a pending channel sync task.
+	task := newSyncTask(post.ChannelId, post.Id, "", existingMsg, nil)
+	task.schedule = time.Now().Add(NotifyMinimumDelay)
+	scs.addTask(task)
+}
+
+// fetchBookmarksForSync populates the sync data with any channel bookmarks created, edited or
+// deleted since the last bookmark sync. File bookmarks are not synchronized.
+func (scs *Service) fetchBookmarksForSync(sd *syncData) error {
+	if !scs.remoteSupportsFeature(sd.rc, model.RemoteClusterFeatureBookmarks) {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		if metrics := scs.server.GetMetrics(); metrics != nil {
+			metrics.ObserveSharedChannelsSyncCollectionStepDuration(sd.rc.RemoteId, "Bookmarks", time.Since(start).Seconds())
+		}
+	}()
+
+	since := sd.scr.LastBookmarksSyncAt
+	bookmarks, err := scs.server.GetStore().ChannelBookmark().GetBookmarksForChannelSince(sd.task.channelID, since)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range bookmarks {
+		// the store includes bookmarks changed exactly at the cursor, which were already sent.
+		changedAt := max(b.UpdateAt, b.DeleteAt)
+		if since > 0 && changedAt <= since {
+			continue
+		}
+		sd.bookmarksCursor = max(sd.bookmarksCursor, changedAt)
+
+		if b.Type == model.ChannelBookmarkFile {
+			continue
+		}
+		sd.bookmarks = append(sd.bookmarks, b.ChannelBookmark)
+	}
+
+	// only file bookmarks changed; there is nothing to send but the cursor can move on.
+	if len(sd.bookmarks) == 0 && sd.bookmarksCursor != 0 {
+		scs.updateChannelStateCursorForRemote(sd, sd.bookmarksCursor, 0)
+	}
+	return nil
+}
+
+// fetchChannelInfoForSync populates the sync data with the channel header and purpose if the
+// channel was modified since the last channel info sync.
+func (scs *Service) fetchChannelInfoForSync(sd *syncData) error {
+	if !scs.remoteSupportsFeature(sd.rc, model.RemoteClusterFeatureChannelInfo) {
+		return nil
+	}
+
+	channel, err := scs.server.GetStore().Channel().Get(sd.task.channelID, true)
+	if err != nil {
+		return err
+	}
+
+	if channel.UpdateAt <= sd.scr.LastChannelInfoSyncAt {
+		return nil
+	}
+
+	sd.channelInfo = &model.SyncChannelInfo{
+		Header:   channel.Header,
+		Purpose:  channel.Purpose,
+		UpdateAt: channel.UpdateAt,
+	}
+	return nil
+}
+
+// updateChannelStateCursorForRemote moves the bookmark and channel info cursors of the shared
+// channel remote forward. A zero value leaves the corresponding cursor unchanged.
+func (scs *Service) updateChannelStateCursorForRemote(sd *syncData, bookmarksSyncAt, channelInfoSyncAt int64) {
+	if err := scs.server.GetStore().SharedChannel().UpdateRemoteChannelStateCursor(sd.scr.Id, bookmarksSyncAt, channelInfoSyncAt); err != nil {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "error updating channel state cursor for shared channel remote",
+			mlog.String("remote", sd.rc.DisplayName),
+			mlog.String("channel_id", sd.task.channelID),
+			mlog.Err(err),
+		)
+	}
+}
+
+// sendBookmarkSyncData sends the collected bookmark updates to the remote cluster.
+func (scs *Service) sendBookmarkSyncData(sd *syncData) error {
+	start := time.Now()
+	defer func() {
+		if metrics := scs.server.GetMetrics(); metrics != nil {
+			metrics.ObserveSharedChannelsSyncSendStepDuration(sd.rc.RemoteId, "Bookmarks", time.Since(start).Seconds())
+		}
+	}()
+
+	msg := model.NewSyncMsg(sd.task.channelID)
+	msg.Bookmarks = sd.bookmarks
+
+	return scs.sendSyncMsgToRemote(msg, sd.rc, func(syncResp model.SyncResponse, errResp error) {
+		// failed bookmarks are logged rather than retried, like reactions.
+		if errResp == nil && sd.bookmarksCursor != 0 {
+			scs.updateChannelStateCursorForRemote(sd, sd.bookmarksCursor, 0)
+		}
+		if len(syncResp.BookmarkErrors) != 0 {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Response indicates error for bookmark(s) sync",
+				mlog.String("channel_id", sd.task.channelID),
+				mlog.String("remote_id", sd.rc.RemoteId),
+				mlog.Array("bookmarks", syncResp.BookmarkErrors),
+			)
+		}
+	})
+}
+
+// sendChannelInfoSyncData sends the channel header and purpose to the remote cluster.
+func (scs *Service) sendChannelInfoSyncData(sd *syncData) error {
+	msg := model.NewSyncMsg(sd.task.channelID)
+	msg.ChannelInfo = sd.channelInfo
+
+	return scs.sendSyncMsgToRemote(msg, sd.rc, func(syncResp model.SyncResponse, errResp error) {
+		if errResp == nil && sd.channelInfo.UpdateAt != 0 {
+			scs.updateChannelStateCursorForRemote(sd, 0, sd.channelInfo.UpdateAt)
+		}
+		if syncResp.ChannelInfoError != "" {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Response indicates error for channel info sync",
+				mlog.String("channel_id", sd.task.channelID),
+				mlog.String("remote_id", sd.rc.RemoteId),
+				mlog.String("error", syncResp.ChannelInfoError),
+			)
+		}
+	})
+}
+
+// sendPersistentNotificationSyncData sends the resolved persistent notifications to the remote cluster.
+func (scs *Service) sendPersistentNotificationSyncData(sd *syncData) error {
+	if !scs.remoteSupportsFeature(sd.rc, model.RemoteClusterFeaturePersistentNotifications) {
+		return nil
+	}
+
+	msg := model.NewSyncMsg(sd.task.channelID)
+	msg.ResolvedPersistentNotifications = sd.resolvedPersistentNotifications
+
+	return scs.sendSyncMsgToRemote(msg, sd.rc, func(syncResp model.SyncResponse, errResp error) {
+		if len(syncResp.PersistentNotificationErrors) != 0 {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Response indicates error for persistent notification(s) sync",
+				mlog.String("channel_id", sd.task.channelID),
+				mlog.String("remote_id", sd.rc.RemoteId),
+				mlog.Array("posts", syncResp.PersistentNotificationErrors),
+			)
+		}
+	})
+}
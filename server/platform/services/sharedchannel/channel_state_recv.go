@@ -0,0 +1,168 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// upsertSyncBookmark creates, updates or deletes a channel bookmark received from a remote.
+// Bookmarks keep the same id on every cluster. Edits are applied only when they are at least
+// as recent as the local copy (last writer wins), and identical bookmarks are ignored so
+// updates don't bounce between remotes. Deletes always win.
+func (scs *Service) upsertSyncBookmark(bookmark *model.ChannelBookmark, targetChannel *model.Channel, rc *model.RemoteCluster) error {
+	if bookmark.ChannelId != targetChannel.Id {
+		return fmt.Errorf("bookmark sync failed: %w", ErrChannelIDMismatch)
+	}
+	if bookmark.Type != model.ChannelBookmarkLink {
+		return fmt.Errorf("bookmark sync failed: unsupported bookmark type %q", bookmark.Type)
+	}
+
+	existing, err := scs.server.GetStore().ChannelBookmark().Get(bookmark.Id, true)
+	if err != nil && !isNotFoundError(err) {
+		return fmt.Errorf("error fetching bookmark for sync: %w", err)
+	}
+
+	if existing == nil {
+		if bookmark.DeleteAt != 0 {
+			return nil
+		}
+		saved, err := scs.server.GetStore().ChannelBookmark().Save(bookmark.Clone(), false)
+		if err != nil {
+			return fmt.Errorf("error saving bookmark for sync: %w", err)
+		}
+		scs.publishBookmarkEvent(model.WebsocketEventChannelBookmarkCreated, "bookmark", targetChannel.Id, saved)
+		return nil
+	}
+
+	if existing.ChannelId != targetChannel.Id {
+		return fmt.Errorf("bookmark sync failed: %w", ErrChannelIDMismatch)
+	}
+
+	if existing.DeleteAt != 0 {
+		return nil
+	}
+
+	if bookmark.DeleteAt != 0 {
+		if err := scs.server.GetStore().ChannelBookmark().Delete(bookmark.Id, false); err != nil {
+			return fmt.Errorf("error deleting bookmark for sync: %w", err)
+		}
+		existing.DeleteAt = bookmark.DeleteAt
+		scs.publishBookmarkEvent(model.WebsocketEventChannelBookmarkDeleted, "bookmark", targetChannel.Id, existing)
+		return nil
+	}
+
+	if bookmark.UpdateAt < existing.UpdateAt || isSameBookmark(bookmark, existing.ChannelBookmark) {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Ignoring bookmark sync; local copy is newer or unchanged",
+			mlog.String("remote", rc.Name),
+			mlog.String("bookmark_id", bookmark.Id),
+			mlog.Int("update_at", bookmark.UpdateAt),
+			mlog.Int("local_update_at", existing.UpdateAt),
+		)
+		return nil
+	}
+
+	updated := existing.ChannelBookmark.Clone()
+	updated.DisplayName = bookmark.DisplayName
+	updated.SortOrder = bookmark.SortOrder
+	updated.LinkUrl = bookmark.LinkUrl
+	updated.ImageUrl = bookmark.ImageUrl
+	updated.Emoji = bookmark.Emoji
+	if err := scs.server.GetStore().ChannelBookmark().Update(updated); err != nil {
+		return fmt.Errorf("error updating bookmark for sync: %w", err)
+	}
+
+	response := &model.UpdateChannelBookmarkResponse{Updated: updated.ToBookmarkWithFileInfo(nil)}
+	scs.publishBookmarkEvent(model.WebsocketEventChannelBookmarkUpdated, "bookmarks", targetChannel.Id, response)
+	return nil
+}
+
+func isSameBookmark(a, b *model.ChannelBookmark) bool {
+	return a.DisplayName == b.DisplayName &&
+		a.SortOrder == b.SortOrder &&
+		a.LinkUrl == b.LinkUrl &&
+		a.ImageUrl == b.ImageUrl &&
+		a.Emoji == b.Emoji
+}
+
+func (scs *Service) publishBookmarkEvent(event model.WebsocketEventType, key string, channelID string, data any) {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Cannot marshal bookmark for websocket event",
+			mlog.String("channel_id", channelID),
+			mlog.Err(err),
+		)
+		return
+	}
+	message := model.NewWebSocketEvent(event, "", channelID, "", nil, "")
+	message.Add(key, string(dataJSON))
+	scs.app.Publish(message)
+}
+
+// syncChannelInfo applies the channel header and purpose received from a remote. The change
+// is skipped when the local channel was modified more recently or already matches.
+func (scs *Service) syncChannelInfo(rctx request.CTX, info *model.SyncChannelInfo, targetChannel *model.Channel, rc *model.RemoteCluster) error {
+	if info.Header == targetChannel.Header && info.Purpose == targetChannel.Purpose {
+		return nil
+	}
+
+	if info.UpdateAt < targetChannel.UpdateAt {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Ignoring channel info sync; local channel is newer",
+			mlog.String("remote", rc.Name),
+			mlog.String("channel_id", targetChannel.Id),
+			mlog.Int("update_at", info.UpdateAt),
+			mlog.Int("local_update_at", targetChannel.UpdateAt),
+		)
+		return nil
+	}
+
+	channel := targetChannel.DeepCopy()
+	channel.Header = info.Header
+	channel.Purpose = info.Purpose
+
+	updated, err := scs.server.GetStore().Channel().Update(rctx, channel)
+	if err != nil {
+		return fmt.Errorf("error updating channel info for sync: %w", err)
+	}
+	scs.platform.InvalidateCacheForChannel(updated)
+
+	channelJSON, err := json.Marshal(updated)
+	if err != nil {
+		return fmt.Errorf("error marshaling channel for sync: %w", err)
+	}
+	message := model.NewWebSocketEvent(model.WebsocketEventChannelUpdated, "", updated.Id, "", nil, "")
+	message.Add("channel", string(channelJSON))
+	scs.app.Publish(message)
+
+	return nil
+}
+
+// resolveSyncPersistentNotification stops persistent notifications for a post after they were
+// stopped on a remote. Resolution is final, so there is nothing to reconcile.
+func (scs *Service) resolveSyncPersistentNotification(rctx request.CTX, postID string, targetChannel *model.Channel) error {
+	post, err := scs.server.GetStore().Post().GetSingle(rctx, postID, true)
+	if err != nil {
+		return fmt.Errorf("error fetching post for persistent notification sync: %w", err)
+	}
+	if post.ChannelId != targetChannel.Id {
+		return fmt.Errorf("persistent notification sync failed: %w", ErrChannelIDMismatch)
+	}
+
+	if _, err := scs.server.GetStore().PostPersistentNotification().GetSingle(postID); err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("error fetching persistent notification for sync: %w", err)
+	}
+
+	if err := scs.server.GetStore().PostPersistentNotification().Delete([]string{postID}); err != nil {
+		return fmt.Errorf("error deleting persistent notification for sync: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+type testPlatform struct {
+	invalidatedChannels []string
+}
+
+func (p *testPlatform) InvalidateCacheForUser(userID string) {}
+
+func (p *testPlatform) InvalidateCacheForChannel(channel *model.Channel) {
+	p.invalidatedChannels = append(p.invalidatedChannels, channel.Id)
+}
+
+func setupChannelStateTest(t *testing.T) (*Service, *MockAppIface, *mocks.Store, *testPlatform) {
+	t.Helper()
+
+	mockServer := &MockServerIface{}
+	mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+	mockApp := &MockAppIface{}
+	mockStore := &mocks.Store{}
+	mockServer.On("GetStore").Return(mockStore)
+	platform := &testPlatform{}
+
+	scs := &Service{
+		server:   mockServer,
+		app:      mockApp,
+		platform: platform,
+	}
+	return scs, mockApp, mockStore, platform
+}
+
+func TestUpsertSyncBookmark(t *testing.T) {
+	channel := &model.Channel{Id: model.NewId(), Type: model.ChannelTypeOpen}
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), Name: "remote"}
+
+	newBookmark := func() *model.ChannelBookmark {
+		return &model.ChannelBookmark{
+			Id:          model.NewId(),
+			ChannelId:   channel.Id,
+			OwnerId:     model.NewId(),
+			DisplayName: "docs",
+			LinkUrl:     "https://example.com/docs",
+			Type:        model.ChannelBookmarkLink,
+			CreateAt:    1000,
+			UpdateAt:    2000,
+		}
+	}
+
+	t.Run("creates unknown bookmark with the same id", func(t *testing.T) {
+		scs, mockApp, mockStore, _ := setupChannelStateTest(t)
+		bookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockStore.On("ChannelBookmark").Return(bookmarkStore)
+
+		bookmark := newBookmark()
+		bookmarkStore.On("Get", bookmark.Id, true).Return(nil, store.NewErrNotFound("ChannelBookmark", bookmark.Id))
+		bookmarkStore.On("Save", mock.MatchedBy(func(b *model.ChannelBookmark) bool { return b.Id == bookmark.Id }), false).
+			Return(bookmark.ToBookmarkWithFileInfo(nil), nil)
+		mockApp.On("Publish", mock.MatchedBy(func(ev *model.WebSocketEvent) bool {
+			return ev.EventType() == model.WebsocketEventChannelBookmarkCreated
+		})).Once()
+
+		require.NoError(t, scs.upsertSyncBookmark(bookmark, channel, rc))
+		bookmarkStore.AssertExpectations(t)
+		mockApp.AssertExpectations(t)
+	})
+
+	t.Run("ignores unknown deleted bookmark", func(t *testing.T) {
+		scs, _, mockStore, _ := setupChannelStateTest(t)
+		bookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockStore.On("ChannelBookmark").Return(bookmarkStore)
+
+		bookmark := newBookmark()
+		bookmark.DeleteAt = 3000
+		bookmarkStore.On("Get", bookmark.Id, true).Return(nil, store.NewErrNotFound("ChannelBookmark", bookmark.Id))
+
+		require.NoError(t, scs.upsertSyncBookmark(bookmark, channel, rc))
+		bookmarkStore.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("applies newer edit", func(t *testing.T) {
+		scs, mockApp, mockStore, _ := setupChannelStateTest(t)
+		bookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockStore.On("ChannelBookmark").Return(bookmarkStore)
+
+		existing := newBookmark()
+		bookmark := existing.Clone()
+		bookmark.DisplayName = "new docs"
+		bookmark.UpdateAt = existing.UpdateAt + 1
+
+		bookmarkStore.On("Get", bookmark.Id, true).Return(existing.ToBookmarkWithFileInfo(nil), nil)
+		bookmarkStore.On("Update", mock.MatchedBy(func(b *model.ChannelBookmark) bool { return b.DisplayName == "new docs" })).Return(nil)
+		mockApp.On("Publish", mock.MatchedBy(func(ev *model.WebSocketEvent) bool {
+			return ev.EventType() == model.WebsocketEventChannelBookmarkUpdated
+		})).Once()
+
+		require.NoError(t, scs.upsertSyncBookmark(bookmark, channel, rc))
+		bookmarkStore.AssertExpectations(t)
+		mockApp.AssertExpectations(t)
+	})
+
+	t.Run("ignores older or identical edit", func(t *testing.T) {
+		scs, _, mockStore, _ := setupChannelStateTest(t)
+		bookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockStore.On("ChannelBookmark").Return(bookmarkStore)
+
+		existing := newBookmark()
+		older := existing.Clone()
+		older.DisplayName = "stale"
+		older.UpdateAt = existing.UpdateAt - 1
+		identical := existing.Clone()
+		identical.UpdateAt = existing.UpdateAt + 1
+
+		bookmarkStore.On("Get", existing.Id, true).Return(existing.ToBookmarkWithFileInfo(nil), nil)
+
+		require.NoError(t, scs.upsertSyncBookmark(older, channel, rc))
+		require.NoError(t, scs.upsertSyncBookmark(identical, channel, rc))
+		bookmarkStore.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("deletes existing bookmark", func(t *testing.T) {
+		scs, mockApp, mockStore, _ := setupChannelStateTest(t)
+		bookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockStore.On("ChannelBookmark").Return(bookmarkStore)
+
+		existing := newBookmark()
+		bookmark := existing.Clone()
+		bookmark.DeleteAt = 3000
+
+		bookmarkStore.On("Get", bookmark.Id, true).Return(existing.ToBookmarkWithFileInfo(nil), nil)
+		bookmarkStore.On("Delete", bookmark.Id, false).Return(nil)
+		mockApp.On("Publish", mock.MatchedBy(func(ev *model.WebSocketEvent) bool {
+			return ev.EventType() == model.WebsocketEventChannelBookmarkDeleted
+		})).Once()
+
+		require.NoError(t, scs.upsertSyncBookmark(bookmark, channel, rc))
+		bookmarkStore.AssertExpectations(t)
+		mockApp.AssertExpectations(t)
+	})
+
+	t.Run("rejects bookmark for another channel", func(t *testing.T) {
+		scs, _, _, _ := setupChannelStateTest(t)
+
+		bookmark := newBookmark()
+		bookmark.ChannelId = model.NewId()
+
+		err := scs.upsertSyncBookmark(bookmark, channel, rc)
+		assert.ErrorIs(t, err, ErrChannelIDMismatch)
+	})
+
+	t.Run("rejects file bookmark", func(t *testing.T) {
+		scs, _, _, _ := setupChannelStateTest(t)
+
+		bookmark := newBookmark()
+		bookmark.Type = model.ChannelBookmarkFile
+		bookmark.LinkUrl = ""
+		bookmark.FileId = model.NewId()
+
+		require.Error(t, scs.upsertSyncBookmark(bookmark, channel, rc))
+	})
+}
+
+func TestSyncChannelInfo(t *testing.T) {
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), Name: "remote"}
+	rctx := request.TestContext(t)
+
+	t.Run("applies newer header and purpose", func(t *testing.T) {
+		scs, mockApp, mockStore, platform := setupChannelStateTest(t)
+		channelStore := &mocks.ChannelStore{}
+		mockStore.On("Channel").Return(channelStore)
+
+		channel := &model.Channel{Id: model.NewId(), Type: model.ChannelTypeOpen, Header: "old", UpdateAt: 1000}
+		info := &model.SyncChannelInfo{Header: "new", Purpose: "purpose", UpdateAt: 2000}
+
+		channelStore.On("Update", mock.Anything, mock.MatchedBy(func(c *model.Channel) bool {
+			return c.Id == channel.Id && c.Header == "new" && c.Purpose == "purpose"
+		})).Return(func(_ request.CTX, c *model.Channel) (*model.Channel, error) { return c, nil })
+		mockApp.On("Publish", mock.MatchedBy(func(ev *model.WebSocketEvent) bool {
+			return ev.EventType() == model.WebsocketEventChannelUpdated
+		})).Once()
+
+		require.NoError(t, scs.syncChannelInfo(rctx, info, channel, rc))
+		assert.Equal(t, "old", channel.Header, "target channel must not be modified in place")
+		assert.Equal(t, []string{channel.Id}, platform.invalidatedChannels)
+		channelStore.AssertExpectations(t)
+		mockApp.AssertExpectations(t)
+	})
+
+	t.Run("ignores older or unchanged values", func(t *testing.T) {
+		scs, _, mockStore, platform := setupChannelStateTest(t)
+		channelStore := &mocks.ChannelStore{}
+		mockStore.On("Channel").Return(channelStore)
+
+		channel := &model.Channel{Id: model.NewId(), Type: model.ChannelTypeOpen, Header: "current", UpdateAt: 2000}
+
+		require.NoError(t, scs.syncChannelInfo(rctx, &model.SyncChannelInfo{Header: "stale", UpdateAt: 1000}, channel, rc))
+		require.NoError(t, scs.syncChannelInfo(rctx, &model.SyncChannelInfo{Header: "current", UpdateAt: 3000}, channel, rc))
+		channelStore.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		assert.Empty(t, platform.invalidatedChannels)
+	})
+}
+
+func TestResolveSyncPersistentNotification(t *testing.T) {
+	rctx := request.TestContext(t)
+	channel := &model.Channel{Id: model.NewId(), Type: model.ChannelTypeOpen}
+
+	t.Run("deletes pending persistent notification", func(t *testing.T) {
+		scs, _, mockStore, _ := setupChannelStateTest(t)
+		postStore := &mocks.PostStore{}
+		ppnStore := &mocks.PostPersistentNotificationStore{}
+		mockStore.On("Post").Return(postStore)
+		mockStore.On("PostPersistentNotification").Return(ppnStore)
+
+		post := &model.Post{Id: model.NewId(), ChannelId: channel.Id}
+		postStore.On("GetSingle", mock.Anything, post.Id, true).Return(post, nil)
+		ppnStore.On("GetSingle", post.Id).Return(&model.PostPersistentNotifications{PostId: post.Id}, nil)
+		ppnStore.On("Delete", []string{post.Id}).Return(nil)
+
+		require.NoError(t, scs.resolveSyncPersistentNotification(rctx, post.Id, channel))
+		ppnStore.AssertExpectations(t)
+	})
+
+	t.Run("already resolved is a no-op", func(t *testing.T) {
+		scs, _, mockStore, _ := setupChannelStateTest(t)
+		postStore := &mocks.PostStore{}
+		ppnStore := &mocks.PostPersistentNotificationStore{}
+		mockStore.On("Post").Return(postStore)
+		mockStore.On("PostPersistentNotification").Return(ppnStore)
+
+		post := &model.Post{Id: model.NewId(), ChannelId: channel.Id}
+		postStore.On("GetSingle", mock.Anything, post.Id, true).Return(post, nil)
+		ppnStore.On("GetSingle", post.Id).Return(nil, store.NewErrNotFound("PostPersistentNotifications", post.Id))
+
+		require.NoError(t, scs.resolveSyncPersistentNotification(rctx, post.Id, channel))
+		ppnStore.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("rejects post from another channel", func(t *testing.T) {
+		scs, _, mockStore, _ := setupChannelStateTest(t)
+		postStore := &mocks.PostStore{}
+		mockStore.On("Post").Return(postStore)
+
+		post := &model.Post{Id: model.NewId(), ChannelId: model.NewId()}
+		postStore.On("GetSingle", mock.Anything, post.Id, true).Return(post, nil)
+
+		err := scs.resolveSyncPersistentNotification(rctx, post.Id, channel)
+		assert.ErrorIs(t, err, ErrChannelIDMismatch)
+	})
+}
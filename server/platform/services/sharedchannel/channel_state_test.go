@@ -0,0 +1,136 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
+)
+
+// featureRemoteClusterService only implements the feature lookup of the remote cluster service.
+type featureRemoteClusterService struct {
+	remotecluster.RemoteClusterServiceIFace
+	features []string
+}
+
+func (rcs *featureRemoteClusterService) RemoteSupportsFeature(rc *model.RemoteCluster, feature string) bool {
+	return slices.Contains(rcs.features, feature)
+}
+
+func setupChannelStateSendTest(t *testing.T, scr *model.SharedChannelRemote) (*Service, *mocks.Store, *syncData) {
+	t.Helper()
+
+	mockServer := &MockServerIface{}
+	mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+	mockServer.On("GetMetrics").Return(nil)
+	mockServer.On("GetRemoteClusterService").Return(&featureRemoteClusterService{features: model.RemoteClusterFeatures})
+	mockStore := &mocks.Store{}
+	mockServer.On("GetStore").Return(mockStore)
+
+	scs := &Service{
+		server: mockServer,
+		app:    &MockAppIface{},
+	}
+
+	rc := &model.RemoteCluster{RemoteId: scr.RemoteId, Name: "remote"}
+	sd := newSyncData(newSyncTask(scr.ChannelId, "", scr.RemoteId, nil, nil), rc, scr)
+	return scs, mockStore, sd
+}
+
+func TestFetchBookmarksForSync(t *testing.T) {
+	newRemote := func() *model.SharedChannelRemote {
+		return &model.SharedChannelRemote{
+			Id:                  model.NewId(),
+			ChannelId:           model.NewId(),
+			RemoteId:            model.NewId(),
+			LastPostUpdateAt:    9000,
+			LastBookmarksSyncAt: 2000,
+		}
+	}
+	newBookmark := func(bookmarkType model.ChannelBookmarkType, updateAt int64) *model.ChannelBookmarkWithFileInfo {
+		return &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{
+			Id:       model.NewId(),
+			OwnerId:  model.NewId(),
+			Type:     bookmarkType,
+			UpdateAt: updateAt,
+		}}
+	}
+
+	t.Run("uses the bookmark cursor", func(t *testing.T) {
+		scr := newRemote()
+		scs, mockStore, sd := setupChannelStateSendTest(t, scr)
+		bookmarkStore := &mocks.ChannelBookmarkStore{}
+		mockStore.On("ChannelBookmark").Return(bookmarkStore)
+
+		alreadySent := newBookmark(model.ChannelBookmarkLink, 2000)
+		edited := newBookmark(model.ChannelBookmarkLink, 3000)
+		file := newBookmark(model.ChannelBookmarkFile, 4000)
+		bookmarkStore.On("GetBookmarksForChannelSince", scr.ChannelId, int64(2000)).
+			Return([]*model.ChannelBookmarkWithFileInfo{alreadySent, edited, file}, nil)
+
+		require.NoError(t, scs.fetchBookmarksForSync(sd))
+		require.Len(t, sd.bookmarks, 1)
+		assert.Equal(t, edited.Id, sd.bookmarks[0].Id)
+		assert.Equal(t, int64(4000), sd.bookmarksCursor)
+	})
+
+	t.Run("moves the cursor past file bookmarks", func(t *testing.T) {
+		scr := newRemote()
+		scs, mockStore, sd := setupChannelStateSendTest(t, scr)
+		bookmarkStore := &mocks.ChannelBookmarkStore{}
+		sharedChannelStore := &mocks.SharedChannelStore{}
+		mockStore.On("ChannelBookmark").Return(bookmarkStore)
+		mockStore.On("SharedChannel").Return(sharedChannelStore)
+
+		bookmarkStore.On("GetBookmarksForChannelSince", scr.ChannelId, int64(2000)).
+			Return([]*model.ChannelBookmarkWithFileInfo{newBookmark(model.ChannelBookmarkFile, 4000)}, nil)
+		sharedChannelStore.On("UpdateRemoteChannelStateCursor", scr.Id, int64(4000), int64(0)).Return(nil).Once()
+
+		require.NoError(t, scs.fetchBookmarksForSync(sd))
+		assert.Empty(t, sd.bookmarks)
+		sharedChannelStore.AssertExpectations(t)
+	})
+}
+
+func TestFetchChannelInfoForSync(t *testing.T) {
+	scr := &model.SharedChannelRemote{
+		Id:                    model.NewId(),
+		ChannelId:             model.NewId(),
+		RemoteId:              model.NewId(),
+		LastPostUpdateAt:      1000,
+		LastChannelInfoSyncAt: 5000,
+	}
+
+	for name, tc := range map[string]struct {
+		updateAt int64
+		expected bool
+	}{
+		"unchanged since the last channel info sync": {updateAt: 5000, expected: false},
+		"changed since the last channel info sync":   {updateAt: 6000, expected: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			scs, mockStore, sd := setupChannelStateSendTest(t, scr)
+			channelStore := &mocks.ChannelStore{}
+			mockStore.On("Channel").Return(channelStore)
+			channelStore.On("Get", scr.ChannelId, true).Return(&model.Channel{Id: scr.ChannelId, Header: "header", UpdateAt: tc.updateAt}, nil)
+
+			require.NoError(t, scs.fetchChannelInfoForSync(sd))
+			if !tc.expected {
+				assert.Nil(t, sd.channelInfo)
+				return
+			}
+			require.NotNil(t, sd.channelInfo)
+			assert.Equal(t, "header", sd.channelInfo.Header)
+			assert.Equal(t, tc.updateAt, sd.channelInfo.UpdateAt)
+		})
+	}
+}
@@ -26,6 +26,7 @@ const (
 	TopicUploadCreate            = "sharedchannel_upload"
 	TopicChannelMembership       = "sharedchannel_membership"
 	TopicGlobalUserSync          = "sharedchannel_global_user_sync"
+	TopicSyncCompare             = "sharedchannel_sync_compare"
 	MaxRetries                   = 3
 	MaxUsersPerSync              = 25
 	NotifyRemoteOfflineThreshold = time.Second * 10
@@ -110,7 +111,9 @@ type Service struct {
 	inviteTopicListenerId     string
 	uploadTopicListenerId     string
 	globalSyncTopicListenerId string
+	compareTopicListenerId    string
 	siteURL                   *url.URL
+	comparisons               []*model.SharedChannelSyncComparison // latest sync comparison results, oldest first
 }
 
 // NewSharedChannelService creates a RemoteClusterService instance.
@@ -143,6 +146,7 @@ func (scs *Service) Start() error {
 	scs.inviteTopicListenerId = rcs.AddTopicListener(TopicChannelInvite, scs.onReceiveChannelInvite)
 	scs.uploadTopicListenerId = rcs.AddTopicListener(TopicUploadCreate, scs.onReceiveUploadCreate)
 	scs.globalSyncTopicListenerId = rcs.AddTopicListener(TopicGlobalUserSync, scs.onReceiveSyncMessage)
+	scs.compareTopicListenerId = rcs.AddTopicListener(TopicSyncCompare, scs.onReceiveSyncCompare)
 	scs.connectionStateListenerId = rcs.AddConnectionStateListener(scs.onConnectionStateChange)
 	scs.mux.Unlock()
 
@@ -166,6 +170,8 @@ func (scs *Service) Shutdown() error {
 	scs.syncTopicListenerId = ""
 	rcs.RemoveTopicListener(scs.inviteTopicListenerId)
 	scs.inviteTopicListenerId = ""
+	rcs.RemoveTopicListener(scs.compareTopicListenerId)
+	scs.compareTopicListenerId = ""
 	rcs.RemoveConnectionStateListener(scs.connectionStateListenerId)
 	scs.connectionStateListenerId = ""
 	scs.mux.Unlock()
@@ -0,0 +1,284 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
+)
+
+const (
+	MaxSyncComparePosts     = 10000 // maximum number of posts compared per request
+	MaxSyncComparisonsKept  = 20    // number of comparison results kept for the support packet
+	syncComparePostsPerPage = 1000
+)
+
+// CompareWithRemote compares the posts of a shared channel with the copy held by a remote,
+// for posts created within [since, until]. The remote replies with the ids of its posts,
+// so the comparison is synchronous and bounded by `remotecluster.SendTimeout`.
+func (scs *Service) CompareWithRemote(channelID, remoteID string, since, until int64) (*model.SharedChannelSyncComparison, error) {
+	req := model.SharedChannelSyncCompareRequest{
+		ChannelId: channelID,
+		Since:     since,
+		Until:     until,
+	}
+	if appErr := req.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	scr, rc, err := scs.getRemoteForRepair("CompareWithRemote", channelID, remoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rc.IsPlugin() || !rc.IsOnline() {
+		return nil, model.NewAppError("CompareWithRemote", "app.shared_channel.sync_compare.remote_offline.app_error",
+			map[string]any{"RemoteId": remoteID}, "", http.StatusBadRequest)
+	}
+
+	rcs := scs.server.GetRemoteClusterService()
+	if rcs == nil {
+		return nil, model.NewAppError("CompareWithRemote", "api.command_share.service_disabled", nil, "", http.StatusBadRequest)
+	}
+	if !rcs.RemoteSupportsFeature(rc, model.RemoteClusterFeatureSyncCompare) {
+		return nil, model.NewAppError("CompareWithRemote", "app.shared_channel.sync_compare.not_supported.app_error",
+			map[string]any{"RemoteId": remoteID}, "", http.StatusBadRequest)
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	msg := model.NewRemoteClusterMsg(TopicSyncCompare, payload)
+
+	ctx, cancel := context.WithTimeout(context.Background(), remotecluster.SendTimeout)
+	defer cancel()
+
+	type result struct {
+		remotePosts model.SharedChannelSyncComparePosts
+		err         error
+	}
+	resultChan := make(chan result, 1)
+
+	err = rcs.SendMsg(ctx, msg, rc, func(_ model.RemoteClusterMsg, rc *model.RemoteCluster, resp *remotecluster.Response, err error) {
+		var res result
+		switch {
+		case err != nil:
+			res.err = err
+		case !resp.IsSuccess():
+			res.err = fmt.Errorf("remote %s failed to list posts: %s", rc.DisplayName, resp.Err)
+		default:
+			res.err = json.Unmarshal(resp.Payload, &res.remotePosts)
+		}
+		resultChan <- res
+	})
+	if err != nil {
+		return nil, model.NewAppError("CompareWithRemote", "app.shared_channel.sync_compare.send.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var res result
+	select {
+	case res = <-resultChan:
+	case <-ctx.Done():
+		res.err = ctx.Err()
+	}
+	if res.err != nil {
+		return nil, model.NewAppError("CompareWithRemote", "app.shared_channel.sync_compare.send.app_error", nil, "", http.StatusInternalServerError).Wrap(res.err)
+	}
+
+	localIDs, truncated, err := scs.getPostIDsForCompare(channelID, since, until)
+	if err != nil {
+		return nil, model.NewAppError("CompareWithRemote", "app.shared_channel.sync_compare.get_posts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	comparison := &model.SharedChannelSyncComparison{
+		ChannelId:        channelID,
+		RemoteId:         remoteID,
+		Since:            since,
+		Until:            until,
+		ComparedAt:       model.GetMillis(),
+		LocalCount:       len(localIDs),
+		RemoteCount:      len(res.remotePosts.PostIds),
+		MissingOnRemote:  diffPostIDs(localIDs, res.remotePosts.PostIds),
+		MissingLocally:   diffPostIDs(res.remotePosts.PostIds, localIDs),
+		Truncated:        truncated || res.remotePosts.Truncated,
+		LastPostCreateAt: scr.LastPostCreateAt,
+		LastPostUpdateAt: scr.LastPostUpdateAt,
+	}
+	scs.addComparison(comparison)
+
+	scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Compared shared channel with remote",
+		mlog.String("remote", rc.DisplayName),
+		mlog.String("channel_id", channelID),
+		mlog.Int("missing_on_remote", len(comparison.MissingOnRemote)),
+		mlog.Int("missing_locally", len(comparison.MissingLocally)),
+		mlog.Bool("truncated", comparison.Truncated),
+	)
+
+	return comparison, nil
+}
+
+// ResyncRemote moves the sync cursor of a shared channel remote back to since, so that all posts
+// created or edited since then are sent again. Posts already on the remote are updated in place.
+// Offline remotes are resynced once they are back online.
+func (scs *Service) ResyncRemote(channelID, remoteID string, since int64) error {
+	scr, rc, err := scs.getRemoteForRepair("ResyncRemote", channelID, remoteID)
+	if err != nil {
+		return err
+	}
+
+	// moving the cursor forward would skip posts that were never sent.
+	if since <= 0 || since > scr.LastPostCreateAt || since > scr.LastPostUpdateAt {
+		return model.NewAppError("ResyncRemote", "app.shared_channel.resync.invalid_since.app_error",
+			map[string]any{"Since": since}, "", http.StatusBadRequest)
+	}
+
+	cursor := model.GetPostsSinceForSyncCursor{
+		LastPostCreateAt: since,
+		LastPostUpdateAt: since,
+	}
+	if err := scs.server.GetStore().SharedChannel().UpdateRemoteCursor(scr.Id, cursor); err != nil {
+		return model.NewAppError("ResyncRemote", "app.shared_channel.resync.update_cursor.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	scs.server.Log().Info("Shared channel remote cursor reset for resync",
+		mlog.String("remote", rc.DisplayName),
+		mlog.String("channel_id", channelID),
+		mlog.Int("since", since),
+	)
+
+	scs.addTask(newSyncTask(channelID, "", remoteID, nil, nil))
+	return nil
+}
+
+// GetRecentSyncComparisons returns the results of the latest comparisons run on this node,
+// most recent first.
+func (scs *Service) GetRecentSyncComparisons() []*model.SharedChannelSyncComparison {
+	scs.mux.RLock()
+	defer scs.mux.RUnlock()
+
+	comparisons := slices.Clone(scs.comparisons)
+	slices.Reverse(comparisons)
+	return comparisons
+}
+
+func (scs *Service) addComparison(comparison *model.SharedChannelSyncComparison) {
+	scs.mux.Lock()
+	defer scs.mux.Unlock()
+
+	scs.comparisons = append(scs.comparisons, comparison)
+	if len(scs.comparisons) > MaxSyncComparisonsKept {
+		scs.comparisons = scs.comparisons[len(scs.comparisons)-MaxSyncComparisonsKept:]
+	}
+}
+
+func (scs *Service) getRemoteForRepair(where, channelID, remoteID string) (*model.SharedChannelRemote, *model.RemoteCluster, error) {
+	scr, err := scs.server.GetStore().SharedChannel().GetRemoteByIds(channelID, remoteID)
+	if err != nil || scr.DeleteAt != 0 {
+		return nil, nil, model.NewAppError(where, "api.command_share.channel_remote_id_not_exists",
+			map[string]any{"RemoteId": remoteID}, "", http.StatusBadRequest)
+	}
+
+	rc, err := scs.server.GetStore().RemoteCluster().Get(remoteID, false)
+	if err != nil {
+		return nil, nil, model.NewAppError(where, "api.command_share.remote_id_invalid.error",
+			map[string]any{"Error": err.Error()}, "", http.StatusBadRequest).Wrap(err)
+	}
+	return scr, rc, nil
+}
+
+// onReceiveSyncCompare replies to a comparison request with the ids of the local posts in range.
+func (scs *Service) onReceiveSyncCompare(msg model.RemoteClusterMsg, rc *model.RemoteCluster, response *remotecluster.Response) error {
+	if msg.Topic != TopicSyncCompare {
+		return fmt.Errorf("wrong topic, expected `%s`, got `%s`", TopicSyncCompare, msg.Topic)
+	}
+
+	var req model.SharedChannelSyncCompareRequest
+	if err := json.Unmarshal(msg.Payload, &req); err != nil {
+		return fmt.Errorf("invalid sync compare request: %w", err)
+	}
+	if appErr := req.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	// only remotes the channel is shared with may list its posts.
+	scr, err := scs.server.GetStore().SharedChannel().GetRemoteByIds(req.ChannelId, rc.RemoteId)
+	if err != nil || scr.DeleteAt != 0 {
+		return fmt.Errorf("cannot compare channel %s: %w", req.ChannelId, ErrChannelNotShared)
+	}
+
+	postIDs, truncated, err := scs.getPostIDsForCompare(req.ChannelId, req.Since, req.Until)
+	if err != nil {
+		return err
+	}
+
+	return response.SetPayload(model.SharedChannelSyncComparePosts{
+		PostIds:   postIDs,
+		Truncated: truncated,
+	})
+}
+
+// getPostIDsForCompare returns the ids of the posts created in the channel within [since, until],
+// oldest first. Deleted posts and system messages are skipped as they are not expected to match
+// between clusters.
+func (scs *Service) getPostIDsForCompare(channelID string, since, until int64) ([]string, bool, error) {
+	params := model.ReportPostQueryParams{
+		ChannelId:          channelID,
+		CursorTime:         since,
+		TimeField:          model.ReportingTimeFieldCreateAt,
+		SortDirection:      model.ReportingSortDirectionAsc,
+		ExcludeSystemPosts: true,
+		PerPage:            syncComparePostsPerPage,
+	}
+	rctx := request.EmptyContext(scs.server.Log())
+
+	var postIDs []string
+	for {
+		result, err := scs.server.GetStore().Post().GetPostsForReporting(rctx, params)
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot get posts for channel %s: %w", channelID, err)
+		}
+
+		for _, post := range result.Posts {
+			if post.CreateAt > until {
+				return postIDs, false, nil
+			}
+			if len(postIDs) == MaxSyncComparePosts {
+				return postIDs, true, nil
+			}
+			postIDs = append(postIDs, post.Id)
+		}
+
+		if result.NextCursor == nil || len(result.Posts) == 0 {
+			return postIDs, false, nil
+		}
+		last := result.Posts[len(result.Posts)-1]
+		params.CursorTime = last.CreateAt
+		params.CursorId = last.Id
+	}
+}
+
+// diffPostIDs returns the ids in a that are not in b, preserving the order of a.
+func diffPostIDs(a, b []string) []string {
+	set := make(map[string]struct{}, len(b))
+	for _, id := range b {
+		set[id] = struct{}{}
+	}
+
+	diff := []string{}
+	for _, id := range a {
+		if _, ok := set[id]; !ok {
+			diff = append(diff, id)
+		}
+	}
+	return diff
+}
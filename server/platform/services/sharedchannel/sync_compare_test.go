@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
+)
+
+func setupSyncCompareTest(t *testing.T) (*Service, *mocks.Store) {
+	t.Helper()
+
+	mockServer := &MockServerIface{}
+	mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+	mockStore := &mocks.Store{}
+	mockServer.On("GetStore").Return(mockStore)
+
+	scs := &Service{
+		server: mockServer,
+		tasks:  make(map[string]syncTask),
+	}
+	return scs, mockStore
+}
+
+// mockPostsForCompare serves posts through GetPostsForReporting, honouring the cursor and page size.
+func mockPostsForCompare(mockStore *mocks.Store, posts []*model.Post) *mocks.PostStore {
+	postStore := &mocks.PostStore{}
+	mockStore.On("Post").Return(postStore)
+	postStore.On("GetPostsForReporting", mock.Anything, mock.AnythingOfType("model.ReportPostQueryParams")).Return(
+		func(_ request.CTX, params model.ReportPostQueryParams) *model.ReportPostListResponse {
+			var page []*model.Post
+			for _, post := range posts {
+				if post.CreateAt < params.CursorTime || (post.CreateAt == params.CursorTime && post.Id <= params.CursorId) {
+					continue
+				}
+				page = append(page, post)
+				if len(page) == params.PerPage {
+					break
+				}
+			}
+			result := &model.ReportPostListResponse{Posts: page}
+			if len(page) == params.PerPage {
+				result.NextCursor = &model.ReportPostOptionsCursor{Cursor: page[len(page)-1].Id}
+			}
+			return result
+		}, nil)
+	return postStore
+}
+
+func makePostsForCompare(channelID string, num int, start int64) []*model.Post {
+	posts := make([]*model.Post, 0, num)
+	for i := range num {
+		posts = append(posts, &model.Post{
+			Id:        model.NewId(),
+			ChannelId: channelID,
+			CreateAt:  start + int64(i),
+		})
+	}
+	return posts
+}
+
+func TestGetPostIDsForCompare(t *testing.T) {
+	channelID := model.NewId()
+
+	t.Run("pages through posts until the end of the range", func(t *testing.T) {
+		scs, mockStore := setupSyncCompareTest(t)
+		posts := makePostsForCompare(channelID, syncComparePostsPerPage*2+10, 1000)
+		mockPostsForCompare(mockStore, posts)
+
+		until := posts[syncComparePostsPerPage+4].CreateAt
+		ids, truncated, err := scs.getPostIDsForCompare(channelID, 1000, until)
+		require.NoError(t, err)
+		assert.False(t, truncated)
+		require.Len(t, ids, syncComparePostsPerPage+5)
+		assert.Equal(t, posts[0].Id, ids[0])
+		assert.Equal(t, posts[syncComparePostsPerPage+4].Id, ids[len(ids)-1])
+	})
+
+	t.Run("empty channel", func(t *testing.T) {
+		scs, mockStore := setupSyncCompareTest(t)
+		mockPostsForCompare(mockStore, nil)
+
+		ids, truncated, err := scs.getPostIDsForCompare(channelID, 0, model.GetMillis())
+		require.NoError(t, err)
+		assert.False(t, truncated)
+		assert.Empty(t, ids)
+	})
+}
+
+func TestOnReceiveSyncCompare(t *testing.T) {
+	channelID := model.NewId()
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), Name: "remote"}
+
+	newMsg := func(t *testing.T, req model.SharedChannelSyncCompareRequest) model.RemoteClusterMsg {
+		payload, err := json.Marshal(req)
+		require.NoError(t, err)
+		return model.NewRemoteClusterMsg(TopicSyncCompare, payload)
+	}
+
+	t.Run("replies with the ids of the posts in range", func(t *testing.T) {
+		scs, mockStore := setupSyncCompareTest(t)
+		sharedChannelStore := &mocks.SharedChannelStore{}
+		mockStore.On("SharedChannel").Return(sharedChannelStore)
+		sharedChannelStore.On("GetRemoteByIds", channelID, rc.RemoteId).Return(&model.SharedChannelRemote{ChannelId: channelID, RemoteId: rc.RemoteId}, nil)
+		posts := makePostsForCompare(channelID, 5, 1000)
+		mockPostsForCompare(mockStore, posts)
+
+		var response remotecluster.Response
+		err := scs.onReceiveSyncCompare(newMsg(t, model.SharedChannelSyncCompareRequest{ChannelId: channelID, Since: 1000, Until: 1002}), rc, &response)
+		require.NoError(t, err)
+
+		var reply model.SharedChannelSyncComparePosts
+		require.NoError(t, json.Unmarshal(response.Payload, &reply))
+		assert.Equal(t, []string{posts[0].Id, posts[1].Id, posts[2].Id}, reply.PostIds)
+		assert.False(t, reply.Truncated)
+	})
+
+	t.Run("rejects channels not shared with the remote", func(t *testing.T) {
+		scs, mockStore := setupSyncCompareTest(t)
+		sharedChannelStore := &mocks.SharedChannelStore{}
+		mockStore.On("SharedChannel").Return(sharedChannelStore)
+		sharedChannelStore.On("GetRemoteByIds", channelID, rc.RemoteId).Return(nil, store.NewErrNotFound("SharedChannelRemote", channelID))
+
+		var response remotecluster.Response
+		err := scs.onReceiveSyncCompare(newMsg(t, model.SharedChannelSyncCompareRequest{ChannelId: channelID, Until: 1000}), rc, &response)
+		require.ErrorIs(t, err, ErrChannelNotShared)
+		assert.Empty(t, response.Payload)
+	})
+
+	t.Run("rejects invalid ranges", func(t *testing.T) {
+		scs, _ := setupSyncCompareTest(t)
+
+		var response remotecluster.Response
+		err := scs.onReceiveSyncCompare(newMsg(t, model.SharedChannelSyncCompareRequest{ChannelId: channelID, Since: 2000, Until: 1000}), rc, &response)
+		require.Error(t, err)
+	})
+}
+
+func TestResyncRemote(t *testing.T) {
+	channelID := model.NewId()
+	remoteID := model.NewId()
+
+	setup := func(t *testing.T) (*Service, *mocks.SharedChannelStore) {
+		scs, mockStore := setupSyncCompareTest(t)
+		sharedChannelStore := &mocks.SharedChannelStore{}
+		remoteClusterStore := &mocks.RemoteClusterStore{}
+		mockStore.On("SharedChannel").Return(sharedChannelStore)
+		mockStore.On("RemoteCluster").Return(remoteClusterStore)
+
+		scr := &model.SharedChannelRemote{Id: model.NewId(), ChannelId: channelID, RemoteId: remoteID, LastPostCreateAt: 5000, LastPostUpdateAt: 6000}
+		sharedChannelStore.On("GetRemoteByIds", channelID, remoteID).Return(scr, nil)
+		remoteClusterStore.On("Get", remoteID, false).Return(&model.RemoteCluster{RemoteId: remoteID, Name: "remote"}, nil)
+		return scs, sharedChannelStore
+	}
+
+	t.Run("resets the cursor and schedules a sync", func(t *testing.T) {
+		scs, sharedChannelStore := setup(t)
+		sharedChannelStore.On("UpdateRemoteCursor", mock.AnythingOfType("string"), model.GetPostsSinceForSyncCursor{LastPostCreateAt: 4000, LastPostUpdateAt: 4000}).Return(nil).Once()
+
+		require.NoError(t, scs.ResyncRemote(channelID, remoteID, 4000))
+		sharedChannelStore.AssertExpectations(t)
+		assert.Len(t, scs.tasks, 1)
+	})
+
+	t.Run("rejects timestamps past the cursor", func(t *testing.T) {
+		scs, sharedChannelStore := setup(t)
+
+		var appErr *model.AppError
+		require.ErrorAs(t, scs.ResyncRemote(channelID, remoteID, 5500), &appErr)
+		assert.Equal(t, "app.shared_channel.resync.invalid_since.app_error", appErr.Id)
+		sharedChannelStore.AssertNotCalled(t, "UpdateRemoteCursor", mock.Anything, mock.Anything)
+		assert.Empty(t, scs.tasks)
+	})
+}
+
+func TestDiffPostIDs(t *testing.T) {
+	assert.Equal(t, []string{"a", "c"}, diffPostIDs([]string{"a", "b", "c"}, []string{"b", "d"}))
+	assert.Empty(t, diffPostIDs([]string{"a"}, []string{"a", "b"}))
+	assert.Empty(t, diffPostIDs(nil, []string{"a"}))
+}
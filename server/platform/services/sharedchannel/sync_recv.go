@@ -107,6 +107,9 @@ func (scs *Service) processSyncMessage(rctx request.CTX, syncMsg *model.SyncMsg,
 		mlog.Int("acknowledgement_count", len(syncMsg.Acknowledgements)),
 		mlog.Int("status_count", len(syncMsg.Statuses)),
 		mlog.Int("membership_change_count", len(syncMsg.MembershipChanges)),
+		mlog.Int("bookmark_count", len(syncMsg.Bookmarks)),
+		mlog.Bool("channel_info", syncMsg.ChannelInfo != nil),
+		mlog.Int("persistent_notification_count", len(syncMsg.ResolvedPersistentNotifications)),
 	)
 
 	// Check if this is a global user sync message (no channel ID and only users)
@@ -272,6 +275,41 @@ func (scs *Service) processSyncMessage(rctx request.CTX, syncMsg *model.SyncMsg,
 		}
 	}
 
+	for _, bookmark := range syncMsg.Bookmarks {
+		if err := scs.upsertSyncBookmark(bookmark, targetChannel, rc); err != nil {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error upserting sync bookmark",
+				mlog.String("remote", rc.Name),
+				mlog.String("channel_id", syncMsg.ChannelId),
+				mlog.String("bookmark_id", bookmark.Id),
+				mlog.Err(err),
+			)
+			syncResp.BookmarkErrors = append(syncResp.BookmarkErrors, bookmark.Id)
+		}
+	}
+
+	if syncMsg.ChannelInfo != nil {
+		if err := scs.syncChannelInfo(rctx, syncMsg.ChannelInfo, targetChannel, rc); err != nil {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error syncing channel info",
+				mlog.String("remote", rc.Name),
+				mlog.String("channel_id", syncMsg.ChannelId),
+				mlog.Err(err),
+			)
+			syncResp.ChannelInfoError = err.Error()
+		}
+	}
+
+	for _, postID := range syncMsg.ResolvedPersistentNotifications {
+		if err := scs.resolveSyncPersistentNotification(rctx, postID, targetChannel); err != nil {
+			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Error resolving sync persistent notification",
+				mlog.String("remote", rc.Name),
+				mlog.String("channel_id", syncMsg.ChannelId),
+				mlog.String("post_id", postID),
+				mlog.Err(err),
+			)
+			syncResp.PersistentNotificationErrors = append(syncResp.PersistentNotificationErrors, postID)
+		}
+	}
+
 	// Process membership changes after users have been synced
 	if hasMembershipChanges && membershipSyncEnabled {
 		if err := scs.onReceiveMembershipChanges(syncMsg, rc, response); err != nil {
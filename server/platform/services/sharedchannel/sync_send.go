@@ -547,6 +547,15 @@ func (scs *Service) updateCursorForRemote(scrId string, rc *model.RemoteCluster,
 		)
 		return
 	}
+
+	// the cursor points at the latest post change sent to the remote, so its age is the sync lag.
+	if metrics := scs.server.GetMetrics(); metrics != nil {
+		if last := max(cursor.LastPostCreateAt, cursor.LastPostUpdateAt); last > 0 {
+			lag := time.Since(model.GetTimeForMillis(last)).Seconds()
+			metrics.ObserveSharedChannelsSyncLag(rc.RemoteId, max(lag, 0))
+		}
+	}
+
 	scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "updated cursor for remote",
 		mlog.String("remote_id", rc.RemoteId),
 		mlog.String("remote", rc.DisplayName),
@@ -41,6 +41,11 @@ type syncData struct {
 	attachments       []attachment
 	mentionTransforms map[string]string
 
+	bookmarks                       []*model.ChannelBookmark
+	bookmarksCursor                 int64
+	channelInfo                     *model.SyncChannelInfo
+	resolvedPersistentNotifications []string
+
 	resultRepeat                bool
 	resultNextCursor            model.GetPostsSinceForSyncCursor
 	GlobalUserSyncLastTimestamp int64
@@ -62,7 +67,8 @@ func newSyncData(task syncTask, rc *model.RemoteCluster, scr *model.SharedChanne
 }
 
 func (sd *syncData) isEmpty() bool {
-	return len(sd.users) == 0 && len(sd.profileImages) == 0 && len(sd.posts) == 0 && len(sd.reactions) == 0 && len(sd.acknowledgements) == 0 && len(sd.attachments) == 0
+	return len(sd.users) == 0 && len(sd.profileImages) == 0 && len(sd.posts) == 0 && len(sd.reactions) == 0 && len(sd.acknowledgements) == 0 && len(sd.attachments) == 0 &&
+		len(sd.bookmarks) == 0 && sd.channelInfo == nil && len(sd.resolvedPersistentNotifications) == 0
 }
 
 func (sd *syncData) isCursorChanged() bool {
@@ -80,6 +86,9 @@ func (sd *syncData) setDataFromMsg(msg *model.SyncMsg) {
 	sd.reactions = msg.Reactions
 	sd.acknowledgements = msg.Acknowledgements
 	sd.statuses = msg.Statuses
+	sd.bookmarks = msg.Bookmarks
+	sd.channelInfo = msg.ChannelInfo
+	sd.resolvedPersistentNotifications = msg.ResolvedPersistentNotifications
 }
 
 // syncForRemote updates a remote cluster with any new posts/reactions for a specific
@@ -194,7 +203,17 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		return fmt.Errorf("cannot fetch acknowledgements for sync %v: %w", sd, err)
 	}
 
-	// fetch users associated with posts & reactions
+	// fetch bookmarks for the channel
+	if err := scs.fetchBookmarksForSync(sd); err != nil {
+		return fmt.Errorf("cannot fetch bookmarks for sync %v: %w", sd, err)
+	}
+
+	// fetch channel header and purpose
+	if err := scs.fetchChannelInfoForSync(sd); err != nil {
+		return fmt.Errorf("cannot fetch channel info for sync %v: %w", sd, err)
+	}
+
+	// fetch users associated with posts, reactions & bookmarks
 	if err := scs.fetchPostUsersForSync(sd); err != nil {
 		return fmt.Errorf("cannot fetch post users for sync %v: %w", sd, err)
 	}
@@ -229,6 +248,8 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		mlog.Int("reactions", len(sd.reactions)),
 		mlog.Int("acknowledgements", len(sd.acknowledgements)),
 		mlog.Int("attachments", len(sd.attachments)),
+		mlog.Int("bookmarks", len(sd.bookmarks)),
+		mlog.Bool("channel_info", sd.channelInfo != nil),
 	)
 
 	if !metricsRecorded && metrics != nil {
@@ -445,6 +466,10 @@ func (scs *Service) fetchPostUsersForSync(sd *syncData) error {
 		userIDs[acknowledgement.UserId] = p2mm{}
 	}
 
+	for _, bookmark := range sd.bookmarks {
+		userIDs[bookmark.OwnerId] = p2mm{}
+	}
+
 	for _, post := range sd.posts {
 		// get mentions and users for each mention
 		mentionMap := scs.app.MentionsToTeamMembers(request.EmptyContext(scs.server.Log()), post.Message, sc.TeamId)
@@ -630,6 +655,27 @@ func (scs *Service) sendSyncData(sd *syncData) error {
 		}
 	}
 
+	// send bookmarks
+	if len(sd.bookmarks) != 0 {
+		if err := scs.sendBookmarkSyncData(sd); err != nil {
+			merr.Append(fmt.Errorf("cannot send bookmark sync data: %w", err))
+		}
+	}
+
+	// send channel header and purpose
+	if sd.channelInfo != nil {
+		if err := scs.sendChannelInfoSyncData(sd); err != nil {
+			merr.Append(fmt.Errorf("cannot send channel info sync data: %w", err))
+		}
+	}
+
+	// send resolved persistent notifications
+	if len(sd.resolvedPersistentNotifications) != 0 {
+		if err := scs.sendPersistentNotificationSyncData(sd); err != nil {
+			merr.Append(fmt.Errorf("cannot send persistent notification sync data: %w", err))
+		}
+	}
+
 	// send user profile images
 	if len(sd.profileImages) != 0 {
 		scs.sendProfileImageSyncData(sd)
@@ -1030,7 +1076,13 @@ func (scs *Service) sendSyncMsgToRemote(msg *model.SyncMsg, rc *model.RemoteClus
 
 		var syncResp model.SyncResponse
 		if errResp == nil {
-			if rcResp != nil && len(rcResp.Payload) > 0 {
+			if rcResp != nil && rcResp.Accepted {
+				// store-and-forward transports deliver later and never reply; treat the
+				// message as delivered so the cursors move on.
+				if f != nil {
+					f(newAcceptedSyncResponse(msg), nil)
+				}
+			} else if rcResp != nil && len(rcResp.Payload) > 0 {
 				if err2 := json.Unmarshal(rcResp.Payload, &syncResp); err2 != nil {
 					scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "Invalid sync msg response from remote cluster",
 						mlog.String("remote", rc.Name),
@@ -1057,6 +1109,23 @@ func (scs *Service) sendSyncMsgToRemote(msg *model.SyncMsg, rc *model.RemoteClus
 	return err
 }
 
+// newAcceptedSyncResponse returns the response for a sync message accepted by a store-and-forward
+// transport. No reply will come from the remote, so everything sent is assumed to be synchronized.
+func newAcceptedSyncResponse(msg *model.SyncMsg) model.SyncResponse {
+	var syncResp model.SyncResponse
+	for id, user := range msg.Users {
+		syncResp.UsersSyncd = append(syncResp.UsersSyncd, id)
+		syncResp.UsersLastUpdateAt = max(syncResp.UsersLastUpdateAt, user.UpdateAt)
+	}
+	for _, post := range msg.Posts {
+		syncResp.PostsLastUpdateAt = max(syncResp.PostsLastUpdateAt, post.UpdateAt)
+	}
+	for _, reaction := range msg.Reactions {
+		syncResp.ReactionsLastUpdateAt = max(syncResp.ReactionsLastUpdateAt, reaction.UpdateAt)
+	}
+	return syncResp
+}
+
 // sendSyncMsgToRemote synchronously sends the sync message to a plugin.
 func (scs *Service) sendSyncMsgToPlugin(msg *model.SyncMsg, rc *model.RemoteCluster, f sendSyncMsgResultFunc) error {
 	syncResp, errResp := scs.app.OnSharedChannelsSyncMsg(msg, rc)
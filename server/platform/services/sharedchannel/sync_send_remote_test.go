@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
+)
+
+type pingApp struct{}
+
+func (pingApp) OnSharedChannelsPing(rc *model.RemoteCluster) bool { return true }
+
+func TestSendSyncDataOverSpoolTransport(t *testing.T) {
+	spoolDir := t.TempDir()
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.ConnectedWorkspacesSettings.Transport = model.NewPointer(model.ConnectedWorkspacesTransportSpool)
+	cfg.ConnectedWorkspacesSettings.SpoolDirectory = model.NewPointer(spoolDir)
+
+	mockStore := &mocks.Store{}
+	remoteClusterStore := &mocks.RemoteClusterStore{}
+	remoteClusterStore.On("GetAll", mock.Anything, mock.Anything, mock.Anything).Return([]*model.RemoteCluster{}, nil)
+	sharedChannelStore := &mocks.SharedChannelStore{}
+	mockStore.On("RemoteCluster").Return(remoteClusterStore)
+	mockStore.On("SharedChannel").Return(sharedChannelStore)
+
+	mockServer := &MockServerIface{}
+	mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+	mockServer.On("Config").Return(cfg)
+	mockServer.On("GetMetrics").Return(nil)
+	mockServer.On("GetStore").Return(mockStore)
+	mockServer.On("IsLeader").Return(true)
+	mockServer.On("AddClusterLeaderChangedListener", mock.Anything).Return(model.NewId())
+	mockServer.On("RemoveClusterLeaderChangedListener", mock.Anything).Return()
+
+	rcs, err := remotecluster.NewRemoteClusterService(mockServer, pingApp{})
+	require.NoError(t, err)
+	require.NoError(t, rcs.Start())
+	defer func() {
+		require.NoError(t, rcs.Shutdown())
+	}()
+	mockServer.On("GetRemoteClusterService").Return(rcs)
+
+	scs := &Service{
+		server: mockServer,
+		app:    &MockAppIface{},
+	}
+
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), Name: "spooled", RemoteToken: model.NewId()}
+	scr := &model.SharedChannelRemote{Id: model.NewId(), ChannelId: model.NewId(), RemoteId: rc.RemoteId}
+	user := &model.User{Id: model.NewId(), Username: "user1", UpdateAt: 1000}
+	post := &model.Post{Id: model.NewId(), ChannelId: scr.ChannelId, UserId: user.Id, CreateAt: 2000, UpdateAt: 2000}
+	cursor := model.GetPostsSinceForSyncCursor{LastPostCreateAt: 2000, LastPostCreateID: post.Id, LastPostUpdateAt: 2000, LastPostUpdateID: post.Id}
+
+	sharedChannelStore.On("UpdateUserLastSyncAt", user.Id, scr.ChannelId, rc.RemoteId).Return(nil).Once()
+	sharedChannelStore.On("UpdateRemoteCursor", scr.Id, cursor).Return(nil).Once()
+
+	sd := newSyncData(newSyncTask(scr.ChannelId, "", rc.RemoteId, nil, nil), rc, scr)
+	sd.users = map[string]*model.User{user.Id: user}
+	sd.posts = []*model.Post{post}
+	sd.resultNextCursor = cursor
+
+	require.NoError(t, scs.sendSyncData(sd))
+
+	// the remote never replies; the cursors move once the messages are spooled.
+	sharedChannelStore.AssertExpectations(t)
+
+	spooled, err := os.ReadDir(filepath.Join(spoolDir, remotecluster.SpoolOutboundDir))
+	require.NoError(t, err)
+	assert.Len(t, spooled, 2)
+}
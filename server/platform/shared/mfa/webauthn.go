@@ -0,0 +1,402 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+)
+
+// InvalidWebAuthnResponse indicates that a credential returned by a browser failed verification.
+var InvalidWebAuthnResponse = errors.New("invalid webauthn response")
+
+const (
+	webAuthnTypeCreate = "webauthn.create"
+	webAuthnTypeGet    = "webauthn.get"
+
+	authDataFlagUserPresent        = 0x01
+	authDataFlagUserVerified       = 0x04
+	authDataFlagAttestedCredential = 0x40
+
+	// authDataMinLength is the length of the RP ID hash, flags and signature counter.
+	authDataMinLength = 37
+
+	coseKeyType       = 1
+	coseKeyAlg        = 3
+	coseKeyCurve      = -1
+	coseKeyX          = -2
+	coseKeyY          = -3
+	coseKeyRSAModulus = -1
+	coseKeyRSAExp     = -2
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+)
+
+// WebAuthnCredentialParameters lists the public key algorithms accepted for new credentials, in
+// order of preference.
+func WebAuthnCredentialParameters() []model.WebAuthnCredentialParameter {
+	return []model.WebAuthnCredentialParameter{
+		{Type: model.WebAuthnCredentialType, Alg: model.WebAuthnAlgES256},
+		{Type: model.WebAuthnCredentialType, Alg: model.WebAuthnAlgEdDSA},
+		{Type: model.WebAuthnCredentialType, Alg: model.WebAuthnAlgRS256},
+	}
+}
+
+// WebAuthn verifies the credentials created and asserted by browsers for a relying party, which
+// is identified by the host of the site URL.
+type WebAuthn struct {
+	rpID   string
+	origin string
+}
+
+// NewWebAuthn returns a verifier for the relying party of the given site URL.
+func NewWebAuthn(siteURL string) (*WebAuthn, error) {
+	u, err := url.Parse(siteURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid site url")
+	}
+	if u.Scheme == "" || u.Hostname() == "" {
+		return nil, errors.New("the site url must be an absolute url")
+	}
+
+	return &WebAuthn{
+		rpID:   strings.ToLower(u.Hostname()),
+		origin: webAuthnOrigin(u),
+	}, nil
+}
+
+// webAuthnOrigin serializes the scheme, host and port of a URL as browsers do for an origin, so
+// that the default port of the scheme is omitted.
+func webAuthnOrigin(u *url.URL) string {
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	port := u.Port()
+	if port == "" || (scheme == "https" && port == "443") || (scheme == "http" && port == "80") {
+		return scheme + "://" + host
+	}
+
+	return scheme + "://" + host + ":" + port
+}
+
+// RPID returns the ID of the relying party, passed to browsers.
+func (w *WebAuthn) RPID() string {
+	return w.rpID
+}
+
+// WebAuthnRegistration is a credential whose creation has been verified.
+type WebAuthnRegistration struct {
+	CredentialId string
+	PublicKey    []byte
+	SignCount    uint32
+	AAGUID       string
+}
+
+type webAuthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+func decodeBase64URL(s string) ([]byte, error) {
+	// Tolerate padding, which some clients add.
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// WebAuthnChallenge returns the challenge a credential was created or asserted for, so that the
+// caller can look up the ceremony it belongs to.
+func WebAuthnChallenge(clientDataJSON string) (string, error) {
+	clientData, err := parseWebAuthnClientData(clientDataJSON)
+	if err != nil {
+		return "", err
+	}
+
+	challenge, err := decodeBase64URL(clientData.Challenge)
+	if err != nil {
+		return "", errors.Wrap(InvalidWebAuthnResponse, "malformed challenge")
+	}
+
+	return string(challenge), nil
+}
+
+func parseWebAuthnClientData(clientDataJSON string) (*webAuthnClientData, error) {
+	raw, err := decodeBase64URL(clientDataJSON)
+	if err != nil {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "malformed client data")
+	}
+
+	var clientData webAuthnClientData
+	if err := json.Unmarshal(raw, &clientData); err != nil {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "malformed client data")
+	}
+
+	return &clientData, nil
+}
+
+// verifyClientData checks the client data of a ceremony, and returns its hash as signed by the
+// authenticator.
+func (w *WebAuthn) verifyClientData(clientDataJSON, ceremonyType, challenge string) ([]byte, error) {
+	clientData, err := parseWebAuthnClientData(clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientData.Type != ceremonyType {
+		return nil, errors.Wrapf(InvalidWebAuthnResponse, "unexpected ceremony type %q", clientData.Type)
+	}
+
+	got, err := decodeBase64URL(clientData.Challenge)
+	if err != nil || subtle.ConstantTimeCompare(got, []byte(challenge)) != 1 {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "challenge mismatch")
+	}
+
+	origin, err := url.Parse(clientData.Origin)
+	if err != nil || origin.Host == "" || webAuthnOrigin(origin) != w.origin {
+		return nil, errors.Wrapf(InvalidWebAuthnResponse, "unexpected origin %q", clientData.Origin)
+	}
+
+	raw, _ := decodeBase64URL(clientDataJSON)
+	hash := sha256.Sum256(raw)
+	return hash[:], nil
+}
+
+// verifyAuthenticatorData checks the RP ID hash and flags of authenticator data, and returns its
+// flags and signature counter.
+func (w *WebAuthn) verifyAuthenticatorData(authData []byte, requireUserVerification bool) (byte, uint32, error) {
+	if len(authData) < authDataMinLength {
+		return 0, 0, errors.Wrap(InvalidWebAuthnResponse, "authenticator data too short")
+	}
+
+	rpIDHash := sha256.Sum256([]byte(w.rpID))
+	if subtle.ConstantTimeCompare(authData[:32], rpIDHash[:]) != 1 {
+		return 0, 0, errors.Wrap(InvalidWebAuthnResponse, "relying party mismatch")
+	}
+
+	flags := authData[32]
+	if flags&authDataFlagUserPresent == 0 {
+		return 0, 0, errors.Wrap(InvalidWebAuthnResponse, "user not present")
+	}
+	if requireUserVerification && flags&authDataFlagUserVerified == 0 {
+		return 0, 0, errors.Wrap(InvalidWebAuthnResponse, "user not verified")
+	}
+
+	return flags, binary.BigEndian.Uint32(authData[33:37]), nil
+}
+
+// VerifyRegistration verifies a credential created by a browser for the given challenge. The
+// attestation statement isn't verified, as registration requests no attestation: the credential
+// is trusted because the signed-in user created it.
+func (w *WebAuthn) VerifyRegistration(challenge string, credential *model.WebAuthnAttestationResponse, requireUserVerification bool) (*WebAuthnRegistration, error) {
+	if credential == nil || credential.Type != model.WebAuthnCredentialType {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "unexpected credential type")
+	}
+
+	if _, err := w.verifyClientData(credential.Response.ClientDataJSON, webAuthnTypeCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	rawAttestation, err := decodeBase64URL(credential.Response.AttestationObject)
+	if err != nil {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "malformed attestation object")
+	}
+	decoded, _, err := decodeCBOR(rawAttestation)
+	if err != nil {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, err.Error())
+	}
+	attestation, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "malformed attestation object")
+	}
+	authData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "missing authenticator data")
+	}
+
+	flags, signCount, err := w.verifyAuthenticatorData(authData, requireUserVerification)
+	if err != nil {
+		return nil, err
+	}
+	if flags&authDataFlagAttestedCredential == 0 {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "missing attested credential data")
+	}
+
+	// The attested credential data is the AAGUID, the length of the credential ID, the
+	// credential ID and the COSE encoded public key, possibly followed by extensions.
+	rest := authData[authDataMinLength:]
+	if len(rest) < 18 {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "attested credential data too short")
+	}
+	aaguid := rest[:16]
+	idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if idLength == 0 || len(rest) < idLength {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "malformed credential id")
+	}
+	credentialID := rest[:idLength]
+	rest = rest[idLength:]
+
+	if rawID, err := decodeBase64URL(credential.RawId); err != nil || !bytes.Equal(rawID, credentialID) {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "credential id mismatch")
+	}
+
+	_, keyLength, err := decodeCBOR(rest)
+	if err != nil {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, err.Error())
+	}
+	publicKey := append([]byte(nil), rest[:keyLength]...)
+	if _, err := parseCOSEKey(publicKey); err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnRegistration{
+		CredentialId: base64.RawURLEncoding.EncodeToString(credentialID),
+		PublicKey:    publicKey,
+		SignCount:    signCount,
+		AAGUID:       hex.EncodeToString(aaguid),
+	}, nil
+}
+
+// VerifyAssertion verifies an assertion made by a browser for the given challenge with the given
+// credential, and returns the new signature counter of the credential.
+func (w *WebAuthn) VerifyAssertion(challenge string, assertion *model.WebAuthnAssertionResponse, credential *model.WebAuthnCredential, requireUserVerification bool) (uint32, error) {
+	if assertion == nil || assertion.Type != model.WebAuthnCredentialType {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "unexpected credential type")
+	}
+
+	if rawID, err := decodeBase64URL(assertion.RawId); err != nil || base64.RawURLEncoding.EncodeToString(rawID) != credential.CredentialId {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "credential id mismatch")
+	}
+
+	if assertion.Response.UserHandle != "" && assertion.Response.UserHandle != model.WebAuthnUserHandle(credential.UserId) {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "user handle mismatch")
+	}
+
+	clientDataHash, err := w.verifyClientData(assertion.Response.ClientDataJSON, webAuthnTypeGet, challenge)
+	if err != nil {
+		return 0, err
+	}
+
+	authData, err := decodeBase64URL(assertion.Response.AuthenticatorData)
+	if err != nil {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "malformed authenticator data")
+	}
+	_, signCount, err := w.verifyAuthenticatorData(authData, requireUserVerification)
+	if err != nil {
+		return 0, err
+	}
+
+	signature, err := decodeBase64URL(assertion.Response.Signature)
+	if err != nil {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "malformed signature")
+	}
+
+	key, err := parseCOSEKey(credential.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	if err := key.verify(append(authData, clientDataHash...), signature); err != nil {
+		return 0, err
+	}
+
+	// A counter that doesn't increase means the authenticator may have been cloned.
+	if (signCount != 0 || credential.SignCount != 0) && int64(signCount) <= credential.SignCount {
+		return 0, errors.Wrap(InvalidWebAuthnResponse, "signature counter did not increase")
+	}
+
+	return signCount, nil
+}
+
+type coseKey struct {
+	alg       int64
+	publicKey crypto.PublicKey
+}
+
+func parseCOSEKey(data []byte) (*coseKey, error) {
+	decoded, _, err := decodeCBOR(data)
+	if err != nil {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, err.Error())
+	}
+	m, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, errors.Wrap(InvalidWebAuthnResponse, "malformed public key")
+	}
+
+	kty, _ := m[int64(coseKeyType)].(int64)
+	alg, _ := m[int64(coseKeyAlg)].(int64)
+
+	switch {
+	case kty == coseKeyTypeEC2 && alg == model.WebAuthnAlgES256:
+		crv, _ := m[int64(coseKeyCurve)].(int64)
+		x, _ := m[int64(coseKeyX)].([]byte)
+		y, _ := m[int64(coseKeyY)].([]byte)
+		if crv != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, errors.Wrap(InvalidWebAuthnResponse, "malformed ec2 public key")
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.Wrap(InvalidWebAuthnResponse, "ec2 public key not on curve")
+		}
+		return &coseKey{alg: alg, publicKey: key}, nil
+	case kty == coseKeyTypeOKP && alg == model.WebAuthnAlgEdDSA:
+		crv, _ := m[int64(coseKeyCurve)].(int64)
+		x, _ := m[int64(coseKeyX)].([]byte)
+		if crv != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, errors.Wrap(InvalidWebAuthnResponse, "malformed okp public key")
+		}
+		return &coseKey{alg: alg, publicKey: ed25519.PublicKey(x)}, nil
+	case kty == coseKeyTypeRSA && alg == model.WebAuthnAlgRS256:
+		n, _ := m[int64(coseKeyRSAModulus)].([]byte)
+		e, _ := m[int64(coseKeyRSAExp)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, errors.Wrap(InvalidWebAuthnResponse, "malformed rsa public key")
+		}
+		return &coseKey{alg: alg, publicKey: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}}, nil
+	default:
+		return nil, errors.Wrapf(InvalidWebAuthnResponse, "unsupported public key algorithm %d", alg)
+	}
+}
+
+func (k *coseKey) verify(signed, signature []byte) error {
+	var valid bool
+	switch key := k.publicKey.(type) {
+	case *ecdsa.PublicKey:
+		hash := sha256.Sum256(signed)
+		valid = ecdsa.VerifyASN1(key, hash[:], signature)
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, signed, signature)
+	case *rsa.PublicKey:
+		hash := sha256.Sum256(signed)
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature) == nil
+	}
+
+	if !valid {
+		return errors.Wrap(InvalidWebAuthnResponse, "invalid signature")
+	}
+
+	return nil
+}
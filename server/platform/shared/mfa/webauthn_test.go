@@ -0,0 +1,275 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mfa
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/mfa/webauthntest"
+)
+
+func newWebAuthnTestOptions(userID string) (*model.WebAuthnCreationOptions, string) {
+	challenge := model.NewRandomString(model.TokenSize)
+	return &model.WebAuthnCreationOptions{
+		Challenge:    base64.RawURLEncoding.EncodeToString([]byte(challenge)),
+		RelyingParty: model.WebAuthnRelyingParty{Id: "mattermost.example.com", Name: "Mattermost"},
+		User:         model.WebAuthnUserEntity{Id: model.WebAuthnUserHandle(userID), Name: "user"},
+	}, challenge
+}
+
+func registerWebAuthnTestCredential(t *testing.T, w *WebAuthn, authenticator *webauthntest.Authenticator, userID string) *model.WebAuthnCredential {
+	t.Helper()
+
+	options, challenge := newWebAuthnTestOptions(userID)
+	attestation, err := authenticator.Create(options)
+	require.NoError(t, err)
+
+	registration, err := w.VerifyRegistration(challenge, attestation, true)
+	require.NoError(t, err)
+
+	return &model.WebAuthnCredential{
+		UserId:       userID,
+		CredentialId: registration.CredentialId,
+		PublicKey:    registration.PublicKey,
+		SignCount:    int64(registration.SignCount),
+	}
+}
+
+func TestNewWebAuthn(t *testing.T) {
+	w, err := NewWebAuthn("https://mattermost.example.com:8443/subpath")
+	require.NoError(t, err)
+	assert.Equal(t, "mattermost.example.com", w.RPID())
+	assert.Equal(t, "https://mattermost.example.com:8443", w.origin)
+
+	w, err = NewWebAuthn("HTTPS://Mattermost.Example.com:443")
+	require.NoError(t, err)
+	assert.Equal(t, "mattermost.example.com", w.RPID())
+	assert.Equal(t, "https://mattermost.example.com", w.origin)
+
+	_, err = NewWebAuthn("")
+	require.Error(t, err)
+}
+
+func TestWebAuthnRegistration(t *testing.T) {
+	w, err := NewWebAuthn("https://mattermost.example.com")
+	require.NoError(t, err)
+	userID := model.NewId()
+
+	t.Run("valid", func(t *testing.T) {
+		authenticator := webauthntest.NewAuthenticator("https://mattermost.example.com")
+		options, challenge := newWebAuthnTestOptions(userID)
+		attestation, err := authenticator.Create(options)
+		require.NoError(t, err)
+
+		gotChallenge, err := WebAuthnChallenge(attestation.Response.ClientDataJSON)
+		require.NoError(t, err)
+		assert.Equal(t, challenge, gotChallenge)
+
+		registration, err := w.VerifyRegistration(challenge, attestation, true)
+		require.NoError(t, err)
+		assert.Equal(t, attestation.RawId, registration.CredentialId)
+		assert.NotEmpty(t, registration.PublicKey)
+		assert.Equal(t, "00000000000000000000000000000000", registration.AAGUID)
+	})
+
+	t.Run("wrong challenge", func(t *testing.T) {
+		authenticator := webauthntest.NewAuthenticator("https://mattermost.example.com")
+		options, _ := newWebAuthnTestOptions(userID)
+		attestation, err := authenticator.Create(options)
+		require.NoError(t, err)
+
+		_, err = w.VerifyRegistration(model.NewRandomString(model.TokenSize), attestation, true)
+		require.ErrorIs(t, err, InvalidWebAuthnResponse)
+	})
+
+	t.Run("wrong origin", func(t *testing.T) {
+		authenticator := webauthntest.NewAuthenticator("https://phishing.example.com")
+		options, challenge := newWebAuthnTestOptions(userID)
+		attestation, err := authenticator.Create(options)
+		require.NoError(t, err)
+
+		_, err = w.VerifyRegistration(challenge, attestation, true)
+		require.ErrorIs(t, err, InvalidWebAuthnResponse)
+	})
+
+	t.Run("origin must match the scheme and port of the site url", func(t *testing.T) {
+		for _, origin := range []string{"http://mattermost.example.com", "https://mattermost.example.com:8443"} {
+			authenticator := webauthntest.NewAuthenticator(origin)
+			options, challenge := newWebAuthnTestOptions(userID)
+			attestation, err := authenticator.Create(options)
+			require.NoError(t, err)
+
+			_, err = w.VerifyRegistration(challenge, attestation, true)
+			require.ErrorIs(t, err, InvalidWebAuthnResponse, origin)
+		}
+
+		authenticator := webauthntest.NewAuthenticator("https://mattermost.example.com:443")
+		options, challenge := newWebAuthnTestOptions(userID)
+		attestation, err := authenticator.Create(options)
+		require.NoError(t, err)
+
+		_, err = w.VerifyRegistration(challenge, attestation, true)
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong relying party", func(t *testing.T) {
+		authenticator := webauthntest.NewAuthenticator("https://mattermost.example.com")
+		options, challenge := newWebAuthnTestOptions(userID)
+		options.RelyingParty.Id = "example.com"
+		attestation, err := authenticator.Create(options)
+		require.NoError(t, err)
+
+		_, err = w.VerifyRegistration(challenge, attestation, true)
+		require.ErrorIs(t, err, InvalidWebAuthnResponse)
+	})
+
+	t.Run("user verification", func(t *testing.T) {
+		authenticator := webauthntest.NewAuthenticator("https://mattermost.example.com")
+		authenticator.UserVerified = false
+		options, challenge := newWebAuthnTestOptions(userID)
+		attestation, err := authenticator.Create(options)
+		require.NoError(t, err)
+
+		_, err = w.VerifyRegistration(challenge, attestation, true)
+		require.ErrorIs(t, err, InvalidWebAuthnResponse)
+
+		_, err = w.VerifyRegistration(challenge, attestation, false)
+		require.NoError(t, err)
+	})
+
+	t.Run("malformed attestation object", func(t *testing.T) {
+		authenticator := webauthntest.NewAuthenticator("https://mattermost.example.com")
+		options, challenge := newWebAuthnTestOptions(userID)
+		attestation, err := authenticator.Create(options)
+		require.NoError(t, err)
+
+		raw, err := base64.RawURLEncoding.DecodeString(attestation.Response.AttestationObject)
+		require.NoError(t, err)
+		attestation.Response.AttestationObject = base64.RawURLEncoding.EncodeToString(raw[:len(raw)/2])
+
+		_, err = w.VerifyRegistration(challenge, attestation, true)
+		require.ErrorIs(t, err, InvalidWebAuthnResponse)
+	})
+}
+
+func TestWebAuthnAssertion(t *testing.T) {
+	w, err := NewWebAuthn("https://mattermost.example.com")
+	require.NoError(t, err)
+	userID := model.NewId()
+
+	authenticator := webauthntest.NewAuthenticator("https://mattermost.example.com")
+	credential := registerWebAuthnTestCredential(t, w, authenticator, userID)
+
+	newRequestOptions := func() (*model.WebAuthnRequestOptions, string) {
+		challenge := model.NewRandomString(model.TokenSize)
+		return &model.WebAuthnRequestOptions{
+			Challenge:        base64.RawURLEncoding.EncodeToString([]byte(challenge)),
+			RPId:             w.RPID(),
+			AllowCredentials: []model.WebAuthnCredentialDescriptor{credential.Descriptor()},
+		}, challenge
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		options, challenge := newRequestOptions()
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		signCount, err := w.VerifyAssertion(challenge, assertion, credential, true)
+		require.NoError(t, err)
+		assert.Greater(t, int64(signCount), credential.SignCount)
+		credential.SignCount = int64(signCount)
+	})
+
+	t.Run("replayed signature counter", func(t *testing.T) {
+		options, challenge := newRequestOptions()
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		cloned := *credential
+		cloned.SignCount += 10
+		_, err = w.VerifyAssertion(challenge, assertion, &cloned, true)
+		require.ErrorIs(t, err, InvalidWebAuthnResponse)
+	})
+
+	t.Run("tampered signature", func(t *testing.T) {
+		options, challenge := newRequestOptions()
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		signature, err := base64.RawURLEncoding.DecodeString(assertion.Response.Signature)
+		require.NoError(t, err)
+		signature[len(signature)-1] ^= 0xff
+		assertion.Response.Signature = base64.RawURLEncoding.EncodeToString(signature)
+
+		_, err = w.VerifyAssertion(challenge, assertion, credential, true)
+		require.ErrorIs(t, err, InvalidWebAuthnResponse)
+	})
+
+	t.Run("another user's credential", func(t *testing.T) {
+		options, challenge := newRequestOptions()
+		assertion, err := authenticator.Get(options)
+		require.NoError(t, err)
+
+		other := *credential
+		other.UserId = model.NewId()
+		_, err = w.VerifyAssertion(challenge, assertion, &other, true)
+		require.ErrorIs(t, err, InvalidWebAuthnResponse)
+	})
+
+	t.Run("wrong ceremony type", func(t *testing.T) {
+		options, challenge := newWebAuthnTestOptions(userID)
+		attestation, err := authenticator.Create(options)
+		require.NoError(t, err)
+
+		assertion := &model.WebAuthnAssertionResponse{
+			Id:    credential.CredentialId,
+			RawId: credential.CredentialId,
+			Type:  model.WebAuthnCredentialType,
+			Response: model.WebAuthnAssertionData{
+				ClientDataJSON:    attestation.Response.ClientDataJSON,
+				AuthenticatorData: base64.RawURLEncoding.EncodeToString(make([]byte, 37)),
+				Signature:         base64.RawURLEncoding.EncodeToString([]byte("signature")),
+			},
+		}
+		_, err = w.VerifyAssertion(challenge, assertion, credential, true)
+		require.ErrorIs(t, err, InvalidWebAuthnResponse)
+	})
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	t.Run("nested values", func(t *testing.T) {
+		// {"a": [1, -2, h'0102'], 3: true, "b": null}
+		data := []byte{0xa3, 0x61, 'a', 0x83, 0x01, 0x21, 0x42, 0x01, 0x02, 0x03, 0xf5, 0x61, 'b', 0xf6, 0xff}
+		v, n, err := decodeCBOR(data)
+		require.NoError(t, err)
+		assert.Equal(t, len(data)-1, n)
+		assert.Equal(t, map[any]any{
+			"a":      []any{int64(1), int64(-2), []byte{1, 2}},
+			int64(3): true,
+			"b":      nil,
+		}, v)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, _, err := decodeCBOR([]byte{0x5a, 0xff, 0xff, 0xff, 0xff, 0x01})
+		require.Error(t, err)
+
+		_, _, err = decodeCBOR([]byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+		require.Error(t, err)
+	})
+
+	t.Run("too deep", func(t *testing.T) {
+		data := make([]byte, 20)
+		for i := range data {
+			data[i] = 0x81
+		}
+		_, _, err := decodeCBOR(append(data, 0x00))
+		require.Error(t, err)
+	})
+}
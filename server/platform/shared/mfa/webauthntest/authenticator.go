@@ -0,0 +1,245 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package webauthntest provides a software WebAuthn authenticator for tests.
+package webauthntest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/url"
+	"sort"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+)
+
+// ErrNoCredential is returned when asked to assert a credential the authenticator doesn't hold.
+var ErrNoCredential = errors.New("no matching credential")
+
+// Authenticator creates ES256 credentials and asserts them as a browser would, for the origin it
+// was created for.
+type Authenticator struct {
+	Origin string
+
+	// UserVerified sets whether the authenticator reports having verified the user.
+	UserVerified bool
+
+	credentials map[string]*credential
+}
+
+type credential struct {
+	id        []byte
+	key       *ecdsa.PrivateKey
+	userID    string
+	signCount uint32
+}
+
+func NewAuthenticator(origin string) *Authenticator {
+	return &Authenticator{
+		Origin:       origin,
+		UserVerified: true,
+		credentials:  make(map[string]*credential),
+	}
+}
+
+// Create creates a credential as per the given registration options.
+func (a *Authenticator) Create(options *model.WebAuthnCreationOptions) (*model.WebAuthnAttestationResponse, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 32)
+	if _, err = rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	userID, err := base64.RawURLEncoding.DecodeString(options.User.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	cred := &credential{id: id, key: key, userID: string(userID)}
+	a.credentials[base64.RawURLEncoding.EncodeToString(id)] = cred
+
+	rpID := options.RelyingParty.Id
+	if rpID == "" {
+		rpID = a.rpID()
+	}
+
+	// The attested credential data: an all zero AAGUID, the credential ID and the COSE key.
+	attested := make([]byte, 18)
+	binary.BigEndian.PutUint16(attested[16:], uint16(len(id)))
+	attested = append(attested, id...)
+	attested = append(attested, encodeCBOR(map[int64]any{
+		1:  int64(2),
+		3:  int64(model.WebAuthnAlgES256),
+		-1: int64(1),
+		-2: padTo32(key.PublicKey.X.Bytes()),
+		-3: padTo32(key.PublicKey.Y.Bytes()),
+	})...)
+
+	authData := a.authData(rpID, 0x40, 0)
+	authData = append(authData, attested...)
+
+	attestation := encodeCBOR(map[string]any{
+		"fmt":      model.WebAuthnAttestationNone,
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+
+	return &model.WebAuthnAttestationResponse{
+		Id:    base64.RawURLEncoding.EncodeToString(id),
+		RawId: base64.RawURLEncoding.EncodeToString(id),
+		Type:  model.WebAuthnCredentialType,
+		Response: model.WebAuthnAttestationData{
+			ClientDataJSON:    a.clientData("webauthn.create", options.Challenge),
+			AttestationObject: base64.RawURLEncoding.EncodeToString(attestation),
+			Transports:        []string{"usb"},
+		},
+	}, nil
+}
+
+// Get asserts a credential as per the given authentication options, using the first allowed
+// credential the authenticator holds, or any credential when none are listed.
+func (a *Authenticator) Get(options *model.WebAuthnRequestOptions) (*model.WebAuthnAssertionResponse, error) {
+	var cred *credential
+	for _, allowed := range options.AllowCredentials {
+		if c, ok := a.credentials[allowed.Id]; ok {
+			cred = c
+			break
+		}
+	}
+	if cred == nil && len(options.AllowCredentials) == 0 {
+		for _, c := range a.credentials {
+			cred = c
+			break
+		}
+	}
+	if cred == nil {
+		return nil, ErrNoCredential
+	}
+
+	rpID := options.RPId
+	if rpID == "" {
+		rpID = a.rpID()
+	}
+
+	cred.signCount++
+	authData := a.authData(rpID, 0, cred.signCount)
+	clientDataJSON := a.clientData("webauthn.get", options.Challenge)
+	rawClientData, _ := base64.RawURLEncoding.DecodeString(clientDataJSON)
+	clientDataHash := sha256.Sum256(rawClientData)
+
+	signed := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, cred.key, signed[:])
+	if err != nil {
+		return nil, err
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(cred.id)
+	return &model.WebAuthnAssertionResponse{
+		Id:    id,
+		RawId: id,
+		Type:  model.WebAuthnCredentialType,
+		Response: model.WebAuthnAssertionData{
+			ClientDataJSON:    clientDataJSON,
+			AuthenticatorData: base64.RawURLEncoding.EncodeToString(authData),
+			Signature:         base64.RawURLEncoding.EncodeToString(signature),
+			UserHandle:        model.WebAuthnUserHandle(cred.userID),
+		},
+	}, nil
+}
+
+func (a *Authenticator) rpID() string {
+	u, _ := url.Parse(a.Origin)
+	return u.Hostname()
+}
+
+func (a *Authenticator) authData(rpID string, flags byte, signCount uint32) []byte {
+	flags |= 0x01
+	if a.UserVerified {
+		flags |= 0x04
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	authData := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(authData, signCount)
+}
+
+func (a *Authenticator) clientData(ceremonyType, challenge string) string {
+	b, _ := json.Marshal(map[string]any{
+		"type":        ceremonyType,
+		"challenge":   challenge,
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func padTo32(b []byte) []byte {
+	return append(make([]byte, 32-len(b)), b...)
+}
+
+// encodeCBOR encodes the few types used by attestation objects and COSE keys, with map keys in
+// sorted order.
+func encodeCBOR(v any) []byte {
+	switch v := v.(type) {
+	case int64:
+		if v >= 0 {
+			return cborHead(0, uint64(v))
+		}
+		return cborHead(1, uint64(-1-v))
+	case []byte:
+		return append(cborHead(2, uint64(len(v))), v...)
+	case string:
+		return append(cborHead(3, uint64(len(v))), v...)
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := cborHead(5, uint64(len(v)))
+		for _, k := range keys {
+			out = append(out, encodeCBOR(k)...)
+			out = append(out, encodeCBOR(v[k])...)
+		}
+		return out
+	case map[int64]any:
+		keys := make([]int64, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		out := cborHead(5, uint64(len(v)))
+		for _, k := range keys {
+			out = append(out, encodeCBOR(k)...)
+			out = append(out, encodeCBOR(v[k])...)
+		}
+		return out
+	default:
+		panic("unsupported cbor type")
+	}
+}
+
+func cborHead(major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return []byte{major<<5 | byte(arg)}
+	case arg <= 0xff:
+		return []byte{major<<5 | 24, byte(arg)}
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{major<<5 | 27}, arg)
+	}
+}
@@ -11,6 +11,10 @@ const (
 	AccessTokenGrantType  = "authorization_code"
 	AccessTokenType       = "bearer"
 	RefreshTokenGrantType = "refresh_token"
+
+	// Values of the token_type_hint parameter of introspection and revocation requests.
+	TokenTypeHintAccessToken  = "access_token"
+	TokenTypeHintRefreshToken = "refresh_token"
 )
 
 type AccessData struct {
@@ -24,6 +28,21 @@ type AccessData struct {
 	Audience     string `json:"audience"`
 }
 
+// OAuthIntrospectionResponse describes a token as per RFC 7662. Only Active is set for tokens that
+// are unknown, expired, revoked or issued to another client.
+type OAuthIntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientId  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Audience  string `json:"aud,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+}
+
 type AccessResponse struct {
 	AccessToken      string `json:"access_token"`
 	TokenType        string `json:"token_type"`
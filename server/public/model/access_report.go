@@ -0,0 +1,149 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	AccessReportDefaultInactiveDays = 90
+	AccessReportMaxPageSize         = 100
+)
+
+var AccessReportExportFormats = []string{"csv", "json"}
+
+// AccessReportHeaders are the column names of the access report when
+// it is exported in CSV format. They match the values returned by
+// UserAccessReport.ToReport.
+var AccessReportHeaders = []string{
+	"Id",
+	"Username",
+	"Email",
+	"AuthService",
+	"SystemRoles",
+	"EffectiveRoles",
+	"Teams",
+	"Channels",
+	"AccessTokens",
+	"Bots",
+	"OAuthApps",
+	"LastActivityAt",
+	"DeleteAt",
+	"Inactive",
+}
+
+// AccessReportMembership describes the membership of a user in a team
+// or a channel, with the roles that the user has in it after applying
+// the team and channel schemes.
+type AccessReportMembership struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Type  string `json:"type,omitempty"`
+	Roles string `json:"roles"`
+}
+
+// AccessReportToken describes a personal access token of a user and
+// the last time it was used to authenticate a request.
+type AccessReportToken struct {
+	Id          string `json:"id"`
+	Description string `json:"description"`
+	IsActive    bool   `json:"is_active"`
+	LastUsedAt  int64  `json:"last_used_at"`
+}
+
+// UserAccessReport is a row of the access review report. It contains
+// everything a user has access to, and everything the user owns that
+// can be used to access the server on their behalf.
+type UserAccessReport struct {
+	UserId         string                    `json:"user_id"`
+	Username       string                    `json:"username"`
+	Email          string                    `json:"email"`
+	AuthService    string                    `json:"auth_service"`
+	SystemRoles    string                    `json:"system_roles"`
+	EffectiveRoles []string                  `json:"effective_roles"`
+	Teams          []*AccessReportMembership `json:"teams"`
+	Channels       []*AccessReportMembership `json:"channels"`
+	AccessTokens   []*AccessReportToken      `json:"access_tokens"`
+	Bots           []string                  `json:"bots"`
+	OAuthApps      []string                  `json:"oauth_apps"`
+	LastActivityAt int64                     `json:"last_activity_at"`
+	DeleteAt       int64                     `json:"delete_at"`
+	Inactive       bool                      `json:"inactive"`
+}
+
+func (r *UserAccessReport) ToReport() []string {
+	formatMemberships := func(memberships []*AccessReportMembership) string {
+		values := make([]string, 0, len(memberships))
+		for _, m := range memberships {
+			values = append(values, m.Name+":"+m.Roles)
+		}
+		return strings.Join(values, ";")
+	}
+
+	tokens := make([]string, 0, len(r.AccessTokens))
+	for _, token := range r.AccessTokens {
+		lastUsedAt := "never"
+		if token.LastUsedAt > 0 {
+			lastUsedAt = time.UnixMilli(token.LastUsedAt).UTC().Format(time.RFC3339)
+		}
+		tokens = append(tokens, token.Id+":"+strconv.FormatBool(token.IsActive)+":"+lastUsedAt)
+	}
+
+	lastActivityAt := ""
+	if r.LastActivityAt > 0 {
+		lastActivityAt = time.UnixMilli(r.LastActivityAt).UTC().Format(time.RFC3339)
+	}
+	deleteAt := ""
+	if r.DeleteAt > 0 {
+		deleteAt = time.UnixMilli(r.DeleteAt).UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		r.UserId,
+		r.Username,
+		r.Email,
+		r.AuthService,
+		r.SystemRoles,
+		strings.Join(r.EffectiveRoles, " "),
+		formatMemberships(r.Teams),
+		formatMemberships(r.Channels),
+		strings.Join(tokens, ";"),
+		strings.Join(r.Bots, ";"),
+		strings.Join(r.OAuthApps, ";"),
+		lastActivityAt,
+		deleteAt,
+		strconv.FormatBool(r.Inactive),
+	}
+}
+
+// AccessReportOptions are the options used to generate a batch of the
+// access review report.
+type AccessReportOptions struct {
+	FromUserId string
+	PageSize   int
+	// InactiveSince is the timestamp, in milliseconds, before which a
+	// user without any activity is reported as inactive.
+	InactiveSince int64
+}
+
+func (o *AccessReportOptions) IsValid() *AppError {
+	if o.FromUserId != "" && !IsValidId(o.FromUserId) {
+		return NewAppError("AccessReportOptions.IsValid", "model.access_report_options.is_valid.from_user_id", nil, "", http.StatusBadRequest)
+	}
+
+	if o.PageSize <= 0 || o.PageSize > AccessReportMaxPageSize {
+		return NewAppError("AccessReportOptions.IsValid", "model.access_report_options.is_valid.page_size", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func IsValidAccessReportExportFormat(format string) bool {
+	return slices.Contains(AccessReportExportFormats, format)
+}
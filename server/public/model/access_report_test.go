@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessReportOptionsIsValid(t *testing.T) {
+	t.Run("valid options", func(t *testing.T) {
+		options := &AccessReportOptions{FromUserId: NewId(), PageSize: AccessReportMaxPageSize}
+		require.Nil(t, options.IsValid())
+	})
+
+	t.Run("invalid from user id", func(t *testing.T) {
+		options := &AccessReportOptions{FromUserId: "invalid", PageSize: 10}
+		appErr := options.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.access_report_options.is_valid.from_user_id", appErr.Id)
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		for _, pageSize := range []int{0, AccessReportMaxPageSize + 1} {
+			options := &AccessReportOptions{PageSize: pageSize}
+			appErr := options.IsValid()
+			require.NotNil(t, appErr)
+			assert.Equal(t, "model.access_report_options.is_valid.page_size", appErr.Id)
+		}
+	})
+}
+
+func TestUserAccessReportToReport(t *testing.T) {
+	report := &UserAccessReport{
+		UserId:         "userid",
+		Username:       "username",
+		Email:          "user@example.com",
+		SystemRoles:    "system_user",
+		EffectiveRoles: []string{"channel_user", "system_user", "team_user"},
+		Teams:          []*AccessReportMembership{{Id: "teamid", Name: "team", Roles: "team_user"}},
+		Channels: []*AccessReportMembership{
+			{Id: "channelid1", Name: "team:town-square", Type: "O", Roles: "channel_user"},
+			{Id: "channelid2", Name: "team:private", Type: "P", Roles: "channel_user channel_admin"},
+		},
+		AccessTokens: []*AccessReportToken{
+			{Id: "tokenid1", IsActive: true, LastUsedAt: 1700000000000},
+			{Id: "tokenid2", IsActive: false},
+		},
+		Bots:           []string{"bot1", "bot2"},
+		LastActivityAt: 1700000000000,
+		Inactive:       true,
+	}
+
+	row := report.ToReport()
+	require.Len(t, row, len(AccessReportHeaders))
+	assert.Equal(t, []string{
+		"userid",
+		"username",
+		"user@example.com",
+		"",
+		"system_user",
+		"channel_user system_user team_user",
+		"team:team_user",
+		"team:town-square:channel_user;team:private:channel_user channel_admin",
+		"tokenid1:true:2023-11-14T22:13:20Z;tokenid2:false:never",
+		"bot1;bot2",
+		"",
+		"2023-11-14T22:13:20Z",
+		"",
+		"true",
+	}, row)
+}
@@ -189,10 +189,11 @@ const (
 
 // Jobs
 const (
-	AuditEventCancelJob       = "cancelJob"       // cancel a job
-	AuditEventCreateJob       = "createJob"       // create a job
-	AuditEventJobServer       = "jobServer"       // start job server
-	AuditEventUpdateJobStatus = "updateJobStatus" // update status of a job
+	AuditEventCancelJob         = "cancelJob"         // cancel a job
+	AuditEventCreateJob         = "createJob"         // create a job
+	AuditEventDownloadReportJob = "downloadReportJob" // download the report generated by a job
+	AuditEventJobServer         = "jobServer"         // start job server
+	AuditEventUpdateJobStatus   = "updateJobStatus"   // update status of a job
 )
 
 // LDAP
@@ -216,6 +217,15 @@ const (
 	AuditEventRequestTrialLicense = "requestTrialLicense" // request trial license
 )
 
+// Membership Requests
+const (
+	AuditEventApproveMembershipRequest = "approveMembershipRequest" // approve request of user to join private channel or team
+	AuditEventCancelMembershipRequest  = "cancelMembershipRequest"  // cancel own request to join private channel or team
+	AuditEventCreateMembershipRequest  = "createMembershipRequest"  // request to join private channel or team
+	AuditEventDenyMembershipRequest    = "denyMembershipRequest"    // deny request of user to join private channel or team
+	AuditEventExpireMembershipRequest  = "expireMembershipRequest"  // expire request to join private channel or team not reviewed in time
+)
+
 // OAuth
 const (
 	AuditEventAuthorizeOAuthApp                          = "authorizeOAuthApp"                          // authorize OAuth app
@@ -231,6 +241,7 @@ const (
 	AuditEventMobileLoginWithOAuth                       = "mobileLoginWithOAuth"                       // mobile application login using OAuth authentication provider
 	AuditEventRegenerateOAuthAppSecret                   = "regenerateOAuthAppSecret"                   // regenerate secret key for OAuth app
 	AuditEventRegisterOAuthClient                        = "registerOAuthClient"                        // register OAuth client via dynamic client registration (RFC 7591)
+	AuditEventRevokeOAuthToken                           = "revokeOAuthToken"                           // revoke OAuth access or refresh token (RFC 7009)
 	AuditEventSignupWithOAuth                            = "signupWithOAuth"                            // create account using OAuth authentication provider
 	AuditEventUpdateOAuthApp                             = "updateOAuthApp"                             // update OAuth app
 	AuditEventUpdateOutgoingOAuthConnection              = "updateOutgoingOAuthConnection"              // update outgoing OAuth connection
@@ -294,6 +305,7 @@ const (
 
 // Remote Clusters
 const (
+	AuditEventCompareSharedChannelWithRemote = "compareSharedChannelWithRemote" // compare shared channel posts with remote cluster
 	AuditEventCreateRemoteCluster            = "createRemoteCluster"            // create connection to remote Mattermost cluster
 	AuditEventDeleteRemoteCluster            = "deleteRemoteCluster"            // delete connection to remote Mattermost cluster
 	AuditEventGenerateRemoteClusterInvite    = "generateRemoteClusterInvite"    // generate invitation token for remote cluster connection
@@ -302,6 +314,7 @@ const (
 	AuditEventRemoteClusterAcceptInvite      = "remoteClusterAcceptInvite"      // accept invitation from remote cluster
 	AuditEventRemoteClusterAcceptMessage     = "remoteClusterAcceptMessage"     // accept message from remote cluster
 	AuditEventRemoteUploadProfileImage       = "remoteUploadProfileImage"       // upload profile image from remote cluster
+	AuditEventResyncSharedChannelRemote      = "resyncSharedChannelRemote"      // resend shared channel posts to remote cluster
 	AuditEventUninviteRemoteClusterToChannel = "uninviteRemoteClusterToChannel" // remove remote cluster access from shared channel
 	AuditEventUploadRemoteData               = "uploadRemoteData"               // upload data to remote cluster
 )
@@ -322,6 +335,18 @@ const (
 	AuditEventRemoveSamlPublicCertificate  = "removeSamlPublicCertificate"  // remove SAML public certificate
 )
 
+// SCIM
+const (
+	AuditEventCreateScimGroup  = "createScimGroup"  // provision group through SCIM
+	AuditEventCreateScimUser   = "createScimUser"   // provision user through SCIM
+	AuditEventDeleteScimGroup  = "deleteScimGroup"  // delete group provisioned through SCIM
+	AuditEventDeleteScimUser   = "deleteScimUser"   // deactivate user through SCIM
+	AuditEventPatchScimGroup   = "patchScimGroup"   // update group properties or members through SCIM
+	AuditEventPatchScimUser    = "patchScimUser"    // update user attributes through SCIM
+	AuditEventReplaceScimGroup = "replaceScimGroup" // replace group properties and members through SCIM
+	AuditEventReplaceScimUser  = "replaceScimUser"  // replace user attributes through SCIM
+)
+
 // Scheduled Posts
 const (
 	AuditEventCreateSchedulePost  = "createSchedulePost"  // create post scheduled for future delivery
@@ -415,24 +440,32 @@ const (
 	AuditEventCreateUser                   = "createUser"                   // create user account
 	AuditEventCreateUserAccessToken        = "createUserAccessToken"        // create personal access token for user API access
 	AuditEventDeleteUser                   = "deleteUser"                   // delete user account
+	AuditEventDeleteWebAuthnCredential     = "deleteWebAuthnCredential"     // delete security key or passkey of user
 	AuditEventDemoteUserToGuest            = "demoteUserToGuest"            // demote regular user to guest account with limited permissions
 	AuditEventDisableUserAccessToken       = "disableUserAccessToken"       // disable user personal access token
 	AuditEventEnableUserAccessToken        = "enableUserAccessToken"        // enable user personal access token
+	AuditEventEvictSessionOverLimit        = "evictSessionOverLimit"        // revoke oldest user session over the session policy limits
 	AuditEventExtendSessionExpiry          = "extendSessionExpiry"          // extend user session expiration time
+	AuditEventGenerateMfaRecoveryCodes     = "generateMfaRecoveryCodes"     // generate new multi-factor authentication recovery codes for user
 	AuditEventLocalDeleteUser              = "localDeleteUser"              // delete user locally
 	AuditEventLocalPermanentDeleteAllUsers = "localPermanentDeleteAllUsers" // permanently delete all users locally
 	AuditEventLogin                        = "login"                        // user login to system
 	AuditEventLoginWithDesktopToken        = "loginWithDesktopToken"        // user login to system with desktop token
+	AuditEventLoginWithWebAuthn            = "loginWithWebAuthn"            // user login to system with passkey
 	AuditEventLogout                       = "logout"                       // user logout from system
 	AuditEventMigrateAuthToLdap            = "migrateAuthToLdap"            // migrate user authentication method to LDAP
 	AuditEventMigrateAuthToSaml            = "migrateAuthToSaml"            // migrate user authentication method to SAML
 	AuditEventPatchUser                    = "patchUser"                    // update user properties
 	AuditEventPromoteGuestToUser           = "promoteGuestToUser"           // promote guest account to regular user
+	AuditEventRegisterWebAuthnCredential   = "registerWebAuthnCredential"   // register security key or passkey for user
 	AuditEventResetPassword                = "resetPassword"                // reset user password
 	AuditEventResetPasswordFailedAttempts  = "resetPasswordFailedAttempts"  // reset failed password attempt counter
 	AuditEventRevokeAllSessionsAllUsers    = "revokeAllSessionsAllUsers"    // revoke all active sessions for all users
 	AuditEventRevokeAllSessionsForUser     = "revokeAllSessionsForUser"     // revoke all active sessions for specific user
+	AuditEventRevokeIdleSession            = "revokeIdleSession"            // revoke user session idle for longer than its timeout
+	AuditEventRevokeMfaTrustedDevices      = "revokeMfaTrustedDevices"      // revoke all devices trusted to skip multi-factor authentication for user
 	AuditEventRevokeSession                = "revokeSession"                // revoke specific user session
+	AuditEventRevokeSessionOutsideSubnet   = "revokeSessionOutsideSubnet"   // revoke user session used outside the IP subnet it was created from
 	AuditEventRevokeUserAccessToken        = "revokeUserAccessToken"        // revoke user personal access token
 	AuditEventSendPasswordReset            = "sendPasswordReset"            // send password reset email to user
 	AuditEventSendVerificationEmail        = "sendVerificationEmail"        // send email verification link to user
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+const (
+	ChannelImportUserMappingEmail    = "email"
+	ChannelImportUserMappingUsername = "username"
+
+	ChannelImportUnknownUsersCreate = "create"
+	ChannelImportUnknownUsersRemap  = "remap"
+	ChannelImportUnknownUsersSkip   = "skip"
+)
+
+// ChannelImportOpts are the options used to import the archive of a
+// single channel, created by a channel export, into a team.
+type ChannelImportOpts struct {
+	// Team is the name of the team the channel is imported into.
+	Team string
+	// UserMapping is the user field used to match the users of the
+	// archive with the users of the server, either email or username.
+	UserMapping string
+	// UnknownUsers defines what happens to the users of the archive that
+	// don't match any user of the server. They can be created, remapped
+	// to RemapUsername or skipped along with their content.
+	UnknownUsers  string
+	RemapUsername string
+}
+
+// ChannelImportOptsFromJobData builds the options of a channel import
+// from the data of an import process job.
+func ChannelImportOptsFromJobData(data StringMap) ChannelImportOpts {
+	opts := ChannelImportOpts{
+		Team:          data["team"],
+		UserMapping:   data["user_mapping"],
+		UnknownUsers:  data["unknown_users"],
+		RemapUsername: data["remap_user"],
+	}
+	if opts.UserMapping == "" {
+		opts.UserMapping = ChannelImportUserMappingEmail
+	}
+	if opts.UnknownUsers == "" {
+		opts.UnknownUsers = ChannelImportUnknownUsersCreate
+	}
+	return opts
+}
+
+func (o *ChannelImportOpts) IsValid() *AppError {
+	if !IsValidTeamName(o.Team) {
+		return NewAppError("ChannelImportOpts.IsValid", "model.channel_import_opts.is_valid.team.app_error", nil, "team="+o.Team, http.StatusBadRequest)
+	}
+
+	if o.UserMapping != ChannelImportUserMappingEmail && o.UserMapping != ChannelImportUserMappingUsername {
+		return NewAppError("ChannelImportOpts.IsValid", "model.channel_import_opts.is_valid.user_mapping.app_error", nil, "user_mapping="+o.UserMapping, http.StatusBadRequest)
+	}
+
+	switch o.UnknownUsers {
+	case ChannelImportUnknownUsersCreate, ChannelImportUnknownUsersSkip:
+		if o.RemapUsername != "" {
+			return NewAppError("ChannelImportOpts.IsValid", "model.channel_import_opts.is_valid.remap_user.app_error", nil, "", http.StatusBadRequest)
+		}
+	case ChannelImportUnknownUsersRemap:
+		if !IsValidUsername(o.RemapUsername) {
+			return NewAppError("ChannelImportOpts.IsValid", "model.channel_import_opts.is_valid.remap_user.app_error", nil, "remap_user="+o.RemapUsername, http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("ChannelImportOpts.IsValid", "model.channel_import_opts.is_valid.unknown_users.app_error", nil, "unknown_users="+o.UnknownUsers, http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelImportOptsFromJobData(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		opts := ChannelImportOptsFromJobData(StringMap{"team": "myteam"})
+		assert.Equal(t, ChannelImportOpts{
+			Team:         "myteam",
+			UserMapping:  ChannelImportUserMappingEmail,
+			UnknownUsers: ChannelImportUnknownUsersCreate,
+		}, opts)
+	})
+
+	t.Run("all options", func(t *testing.T) {
+		opts := ChannelImportOptsFromJobData(StringMap{
+			"team":          "myteam",
+			"user_mapping":  ChannelImportUserMappingUsername,
+			"unknown_users": ChannelImportUnknownUsersRemap,
+			"remap_user":    "someone",
+		})
+		assert.Equal(t, ChannelImportOpts{
+			Team:          "myteam",
+			UserMapping:   ChannelImportUserMappingUsername,
+			UnknownUsers:  ChannelImportUnknownUsersRemap,
+			RemapUsername: "someone",
+		}, opts)
+	})
+}
+
+func TestChannelImportOptsIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		opts          ChannelImportOpts
+		expectedError string
+	}{
+		"valid": {
+			opts: ChannelImportOpts{Team: "myteam", UserMapping: ChannelImportUserMappingEmail, UnknownUsers: ChannelImportUnknownUsersSkip},
+		},
+		"valid remap": {
+			opts: ChannelImportOpts{Team: "myteam", UserMapping: ChannelImportUserMappingUsername, UnknownUsers: ChannelImportUnknownUsersRemap, RemapUsername: "someone"},
+		},
+		"invalid team": {
+			opts:          ChannelImportOpts{Team: "", UserMapping: ChannelImportUserMappingEmail, UnknownUsers: ChannelImportUnknownUsersCreate},
+			expectedError: "model.channel_import_opts.is_valid.team.app_error",
+		},
+		"invalid user mapping": {
+			opts:          ChannelImportOpts{Team: "myteam", UserMapping: "id", UnknownUsers: ChannelImportUnknownUsersCreate},
+			expectedError: "model.channel_import_opts.is_valid.user_mapping.app_error",
+		},
+		"invalid unknown users": {
+			opts:          ChannelImportOpts{Team: "myteam", UserMapping: ChannelImportUserMappingEmail, UnknownUsers: "ignore"},
+			expectedError: "model.channel_import_opts.is_valid.unknown_users.app_error",
+		},
+		"remap without user": {
+			opts:          ChannelImportOpts{Team: "myteam", UserMapping: ChannelImportUserMappingEmail, UnknownUsers: ChannelImportUnknownUsersRemap},
+			expectedError: "model.channel_import_opts.is_valid.remap_user.app_error",
+		},
+		"remap user without remap": {
+			opts:          ChannelImportOpts{Team: "myteam", UserMapping: ChannelImportUserMappingEmail, UnknownUsers: ChannelImportUnknownUsersCreate, RemapUsername: "someone"},
+			expectedError: "model.channel_import_opts.is_valid.remap_user.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			appErr := tc.opts.IsValid()
+			if tc.expectedError == "" {
+				require.Nil(t, appErr)
+				return
+			}
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.expectedError, appErr.Id)
+		})
+	}
+}
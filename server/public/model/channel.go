@@ -103,6 +103,9 @@ type Channel struct {
 	PolicyEnforced      bool               `json:"policy_enforced"`
 	PolicyIsActive      bool               `json:"policy_is_active"`
 	DefaultCategoryName string             `json:"default_category_name"`
+
+	// AllowMembershipRequests lets users ask to join the private channel.
+	AllowMembershipRequests bool `json:"allow_membership_requests"`
 }
 
 func (o *Channel) Auditable() map[string]any {
@@ -126,6 +129,8 @@ func (o *Channel) Auditable() map[string]any {
 		"policy_enforced":      o.PolicyEnforced,
 		"autotranslation":      o.AutoTranslation,
 		"policy_is_active":     o.PolicyIsActive, // this field is only for logging purposes
+
+		"allow_membership_requests": o.AllowMembershipRequests,
 	}
 }
 
@@ -153,6 +158,8 @@ type ChannelPatch struct {
 	GroupConstrained *bool              `json:"group_constrained"`
 	BannerInfo       *ChannelBannerInfo `json:"banner_info"`
 	AutoTranslation  *bool              `json:"autotranslation"`
+
+	AllowMembershipRequests *bool `json:"allow_membership_requests"`
 }
 
 func (c *ChannelPatch) Auditable() map[string]any {
@@ -160,6 +167,8 @@ func (c *ChannelPatch) Auditable() map[string]any {
 		"header":            c.Header,
 		"group_constrained": c.GroupConstrained,
 		"purpose":           c.Purpose,
+
+		"allow_membership_requests": c.AllowMembershipRequests,
 	}
 }
 
@@ -404,6 +413,10 @@ func (o *Channel) Patch(patch *ChannelPatch) {
 	if patch.AutoTranslation != nil {
 		o.AutoTranslation = *patch.AutoTranslation
 	}
+
+	if patch.AllowMembershipRequests != nil {
+		o.AllowMembershipRequests = *patch.AllowMembershipRequests
+	}
 }
 
 func (o *Channel) MakeNonNil() {
@@ -693,6 +693,14 @@ func (c *Client4) integrityRoute() clientRoute {
 	return newClientRoute("integrity")
 }
 
+func (c *Client4) membershipRequestsRoute() clientRoute {
+	return newClientRoute("membership_requests")
+}
+
+func (c *Client4) membershipRequestRoute(membershipRequestId string) clientRoute {
+	return c.membershipRequestsRoute().Join(membershipRequestId)
+}
+
 // Returns the HTTP response or any error that occurred during the request.
 func (c *Client4) DoAPIGet(ctx context.Context, url string, etag string) (*http.Response, error) {
 	return c.doAPIRequest(ctx, http.MethodGet, c.APIURL+url, "", etag)
@@ -1032,6 +1040,35 @@ func (c *Client4) LoginWithDesktopToken(ctx context.Context, token, deviceId str
 	return DecodeJSONFromResponse[*User](r)
 }
 
+// BeginWebAuthnLogin returns the options to assert a security key or passkey with, to be passed
+// to navigator.credentials.get. The assertion is then given as the MFA token of a login, or to
+// LoginWithWebAuthn. Without a login ID, any discoverable passkey may be used.
+// Minimum server version: 11.6
+func (c *Client4) BeginWebAuthnLogin(ctx context.Context, loginId string) (*WebAuthnRequestOptions, *Response, error) {
+	m := make(map[string]string)
+	m["login_id"] = loginId
+	r, err := c.doAPIPostJSON(ctx, c.usersRoute().Join("login", "webauthn", "begin"), m)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*WebAuthnRequestOptions](r)
+}
+
+// LoginWithWebAuthn logs in with a passkey asserted by the browser, without a password.
+// Minimum server version: 11.6
+func (c *Client4) LoginWithWebAuthn(ctx context.Context, loginRequest *WebAuthnLoginRequest) (*User, *Response, error) {
+	r, err := c.doAPIPostJSON(ctx, c.usersRoute().Join("login", "webauthn"), loginRequest)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	c.AuthToken = r.Header.Get(HeaderToken)
+	c.AuthType = HeaderBearer
+
+	return DecodeJSONFromResponse[*User](r)
+}
+
 func (c *Client4) LoginType(ctx context.Context, loginId string) (*LoginTypeResponse, *Response, error) {
 	m := make(map[string]string)
 	m["login_id"] = loginId
@@ -1531,6 +1568,74 @@ func (c *Client4) GenerateMfaSecret(ctx context.Context, userId string) (*MfaSec
 	return DecodeJSONFromResponse[*MfaSecret](r)
 }
 
+// GenerateMfaRecoveryCodes replaces the MFA recovery codes of the current user with new ones.
+// Minimum server version: 11.6
+func (c *Client4) GenerateMfaRecoveryCodes(ctx context.Context, userId string) (*MfaRecoveryCodes, *Response, error) {
+	r, err := c.doAPIPost(ctx, c.userRoute(userId).Join("mfa", "recovery_codes"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*MfaRecoveryCodes](r)
+}
+
+// RevokeMfaTrustedDevices makes all devices a user trusted require MFA on their next login.
+// Minimum server version: 11.6
+func (c *Client4) RevokeMfaTrustedDevices(ctx context.Context, userId string) (*Response, error) {
+	r, err := c.doAPIDelete(ctx, c.userRoute(userId).Join("mfa", "trusted_devices"))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GetWebAuthnCredentials returns the security keys and passkeys registered by a user.
+// Minimum server version: 11.6
+func (c *Client4) GetWebAuthnCredentials(ctx context.Context, userId string) ([]*WebAuthnCredential, *Response, error) {
+	r, err := c.doAPIGet(ctx, c.userRoute(userId).Join("webauthn"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*WebAuthnCredential](r)
+}
+
+// BeginWebAuthnRegistration returns the options to create a security key or passkey with, to be
+// passed to navigator.credentials.create. Must be logged in as the user.
+// Minimum server version: 11.6
+func (c *Client4) BeginWebAuthnRegistration(ctx context.Context, userId string) (*WebAuthnCreationOptions, *Response, error) {
+	r, err := c.doAPIPost(ctx, c.userRoute(userId).Join("webauthn", "register", "begin"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*WebAuthnCreationOptions](r)
+}
+
+// FinishWebAuthnRegistration registers the security key or passkey created by the browser. The
+// first one registered activates MFA for the user.
+// Minimum server version: 11.6
+func (c *Client4) FinishWebAuthnRegistration(ctx context.Context, userId string, registration *WebAuthnRegistrationRequest) (*WebAuthnCredential, *Response, error) {
+	r, err := c.doAPIPostJSON(ctx, c.userRoute(userId).Join("webauthn", "register", "finish"), registration)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*WebAuthnCredential](r)
+}
+
+// DeleteWebAuthnCredential removes a security key or passkey of a user.
+// Minimum server version: 11.6
+func (c *Client4) DeleteWebAuthnCredential(ctx context.Context, userId, credentialId string) (*Response, error) {
+	r, err := c.doAPIDelete(ctx, c.userRoute(userId).Join("webauthn", credentialId))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // UpdateUserPassword updates a user's password. Must be logged in as the user or be a system administrator.
 func (c *Client4) UpdateUserPassword(ctx context.Context, userId, currentPassword, newPassword string) (*Response, error) {
 	requestBody := map[string]string{"current_password": currentPassword, "new_password": newPassword}
@@ -1686,6 +1791,18 @@ func (c *Client4) ResetPassword(ctx context.Context, token, newPassword string)
 	return BuildResponse(r), nil
 }
 
+// GetPasswordHasherUsage returns how many active users have their password hashed
+// with each hashing function and set of parameters.
+// Minimum server version: 11.6
+func (c *Client4) GetPasswordHasherUsage(ctx context.Context) ([]*PasswordHasherUsage, *Response, error) {
+	r, err := c.doAPIGet(ctx, c.usersRoute().Join("password", "hashers"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*PasswordHasherUsage](r)
+}
+
 // GetSessions returns a list of sessions based on the provided user id string.
 func (c *Client4) GetSessions(ctx context.Context, userId, etag string) ([]*Session, *Response, error) {
 	r, err := c.doAPIGet(ctx, c.userRoute(userId).Join("sessions"), etag)
@@ -1852,6 +1969,21 @@ func (c *Client4) CreateUserAccessToken(ctx context.Context, userId, description
 	return DecodeJSONFromResponse[*UserAccessToken](r)
 }
 
+// CreateScopedUserAccessToken will generate a user access token restricted to the given
+// OAuth scopes, e.g. a read-only token for a dashboard integration. The permissions required
+// are the same as for CreateUserAccessToken.
+//
+// Minimum server version: 11.6
+func (c *Client4) CreateScopedUserAccessToken(ctx context.Context, userId, description string, scopes []string) (*UserAccessToken, *Response, error) {
+	requestBody := &UserAccessToken{Description: description, Scopes: scopes}
+	r, err := c.doAPIPostJSON(ctx, c.userRoute(userId).Join("tokens"), requestBody)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*UserAccessToken](r)
+}
+
 // GetUserAccessTokens will get a page of access tokens' id, description, is_active
 // and the user_id in the system. The actual token will not be returned. Must have
 // the 'manage_system' permission.
@@ -5405,6 +5537,32 @@ func (c *Client4) GetOAuthAccessToken(ctx context.Context, data url.Values) (*Ac
 	return DecodeJSONFromResponse[*AccessResponse](r)
 }
 
+// IntrospectOAuthToken describes an OAuth token issued to the client authenticating with the
+// given form data.
+// Minimum server version: 11.6
+func (c *Client4) IntrospectOAuthToken(ctx context.Context, data url.Values) (*OAuthIntrospectionResponse, *Response, error) {
+	r, err := c.doAPIRequestReader(ctx, http.MethodPost, c.URL+OAuthIntrospectEndpoint, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()), nil)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	return DecodeJSONFromResponse[*OAuthIntrospectionResponse](r)
+}
+
+// RevokeOAuthToken revokes an OAuth token issued to the client authenticating with the given
+// form data.
+// Minimum server version: 11.6
+func (c *Client4) RevokeOAuthToken(ctx context.Context, data url.Values) (*Response, error) {
+	r, err := c.doAPIRequestReader(ctx, http.MethodPost, c.URL+OAuthRevokeEndpoint, "application/x-www-form-urlencoded", strings.NewReader(data.Encode()), nil)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r), nil
+}
+
 // OutgoingOAuthConnection section
 
 // GetOutgoingOAuthConnections retrieves the outgoing OAuth connections.
@@ -6323,14 +6481,21 @@ func (c *Client4) GetChannelsForScheme(ctx context.Context, schemeId string, pag
 
 // UploadPlugin takes an io.Reader stream pointing to the contents of a .tar.gz plugin.
 func (c *Client4) UploadPlugin(ctx context.Context, file io.Reader) (*Manifest, *Response, error) {
-	return c.uploadPlugin(ctx, file, false)
+	return c.uploadPlugin(ctx, file, nil, false)
 }
 
 func (c *Client4) UploadPluginForced(ctx context.Context, file io.Reader) (*Manifest, *Response, error) {
-	return c.uploadPlugin(ctx, file, true)
+	return c.uploadPlugin(ctx, file, nil, true)
+}
+
+// UploadSignedPlugin uploads a .tar.gz plugin along with its signature, which the server checks
+// against its trust store before installing the plugin. Uploading signed plugins is allowed even
+// when the server requires plugin signatures.
+func (c *Client4) UploadSignedPlugin(ctx context.Context, file, signature io.Reader, force bool) (*Manifest, *Response, error) {
+	return c.uploadPlugin(ctx, file, signature, force)
 }
 
-func (c *Client4) uploadPlugin(ctx context.Context, file io.Reader, force bool) (*Manifest, *Response, error) {
+func (c *Client4) uploadPlugin(ctx context.Context, file, signature io.Reader, force bool) (*Manifest, *Response, error) {
 	body := new(bytes.Buffer)
 	writer := multipart.NewWriter(body)
 
@@ -6350,6 +6515,17 @@ func (c *Client4) uploadPlugin(ctx context.Context, file io.Reader, force bool)
 		return nil, nil, err
 	}
 
+	if signature != nil {
+		part, err = writer.CreateFormFile("signature", "plugin.tar.gz.sig")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, err = io.Copy(part, signature); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if err = writer.Close(); err != nil {
 		return nil, nil, err
 	}
@@ -6362,6 +6538,40 @@ func (c *Client4) uploadPlugin(ctx context.Context, file io.Reader, force bool)
 	return DecodeJSONFromResponse[*Manifest](r)
 }
 
+// VerifyPlugin checks a .tar.gz plugin and its signature against the trust store of the server,
+// reporting the signer, without installing the plugin.
+func (c *Client4) VerifyPlugin(ctx context.Context, file, signature io.Reader) (*PluginSignatureVerification, *Response, error) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("plugin", "plugin.tar.gz")
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err = io.Copy(part, file); err != nil {
+		return nil, nil, err
+	}
+
+	part, err = writer.CreateFormFile("signature", "plugin.tar.gz.sig")
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err = io.Copy(part, signature); err != nil {
+		return nil, nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	r, err := c.doAPIRequestReaderRoute(ctx, http.MethodPost, c.pluginsRoute().Join("verify"), writer.FormDataContentType(), body, nil)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+
+	return DecodeJSONFromResponse[*PluginSignatureVerification](r)
+}
+
 func (c *Client4) InstallPluginFromURL(ctx context.Context, downloadURL string, force bool) (*Manifest, *Response, error) {
 	values := url.Values{}
 	values.Set("plugin_download_url", downloadURL)
@@ -6410,6 +6620,32 @@ func (c *Client4) DetachPlugin(ctx context.Context, pluginID string) (*Response,
 	return BuildResponse(r), nil
 }
 
+// WatchPlugin asks the server to install the plugin found in a local source directory and to
+// reload it whenever it changes.
+//
+// Only available in local mode, and only when developer mode is enabled.
+func (c *Client4) WatchPlugin(ctx context.Context, request *PluginWatchRequest) (*Manifest, *Response, error) {
+	r, err := c.doAPIPostJSON(ctx, c.pluginsRoute().Join("watch"), request)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*Manifest](r)
+}
+
+// UnwatchPlugin stops watching the source directory of a plugin. The plugin stays installed.
+//
+// Only available in local mode.
+func (c *Client4) UnwatchPlugin(ctx context.Context, pluginID string) (*Response, error) {
+	r, err := c.doAPIPost(ctx, c.pluginRoute(pluginID).Join("unwatch"), "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r), nil
+}
+
 // GetPlugins will return a list of plugin manifests for currently active plugins.
 func (c *Client4) GetPlugins(ctx context.Context) (*PluginsResponse, *Response, error) {
 	r, err := c.doAPIGet(ctx, c.pluginsRoute(), "")
@@ -7520,6 +7756,33 @@ func (c *Client4) UninviteRemoteClusterToChannel(ctx context.Context, remoteId,
 	return BuildResponse(r), nil
 }
 
+// CompareSharedChannelWithRemote compares the posts created in a shared channel within [since, until]
+// with the posts held by the remote.
+func (c *Client4) CompareSharedChannelWithRemote(ctx context.Context, remoteId, channelId string, since, until int64) (*SharedChannelSyncComparison, *Response, error) {
+	req := SharedChannelSyncCompareRequest{
+		ChannelId: channelId,
+		Since:     since,
+		Until:     until,
+	}
+	r, err := c.doAPIPostJSON(ctx, c.channelRemoteRoute(remoteId, channelId).Join("compare"), req)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*SharedChannelSyncComparison](r)
+}
+
+// ResyncSharedChannelRemote resends the posts of a shared channel created or edited since the
+// timestamp to the remote.
+func (c *Client4) ResyncSharedChannelRemote(ctx context.Context, remoteId, channelId string, since int64) (*Response, error) {
+	r, err := c.doAPIPostJSON(ctx, c.channelRemoteRoute(remoteId, channelId).Join("resync"), SharedChannelResyncRequest{Since: since})
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 func (c *Client4) GetAncillaryPermissions(ctx context.Context, subsectionPermissions []string) ([]string, *Response, error) {
 	var returnedPermissions []string
 	r, err := c.doAPIPostJSON(ctx, c.permissionsRoute().Join("ancillary"), subsectionPermissions)
@@ -7951,3 +8214,111 @@ func (c *Client4) BurnPost(ctx context.Context, postID string) (*Response, error
 	defer closeBody(r)
 	return BuildResponse(r), nil
 }
+
+// Membership Requests Section
+
+// CreateMembershipRequest asks to join a private channel, or a team if no channel is given.
+func (c *Client4) CreateMembershipRequest(ctx context.Context, create *CreateMembershipRequest) (*MembershipRequest, *Response, error) {
+	r, err := c.doAPIPostJSON(ctx, c.membershipRequestsRoute(), create)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*MembershipRequest](r)
+}
+
+// GetMembershipRequest returns the membership request of the given id.
+func (c *Client4) GetMembershipRequest(ctx context.Context, membershipRequestId string) (*MembershipRequest, *Response, error) {
+	r, err := c.doAPIGet(ctx, c.membershipRequestRoute(membershipRequestId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*MembershipRequest](r)
+}
+
+// ApproveMembershipRequest adds the user of the pending membership request to its channel or team.
+func (c *Client4) ApproveMembershipRequest(ctx context.Context, membershipRequestId string) (*MembershipRequest, *Response, error) {
+	r, err := c.doAPIPost(ctx, c.membershipRequestRoute(membershipRequestId).Join("approve"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*MembershipRequest](r)
+}
+
+// DenyMembershipRequest denies the pending membership request.
+func (c *Client4) DenyMembershipRequest(ctx context.Context, membershipRequestId string) (*MembershipRequest, *Response, error) {
+	r, err := c.doAPIPost(ctx, c.membershipRequestRoute(membershipRequestId).Join("deny"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*MembershipRequest](r)
+}
+
+// CancelMembershipRequest withdraws the pending membership request of the current user.
+func (c *Client4) CancelMembershipRequest(ctx context.Context, membershipRequestId string) (*MembershipRequest, *Response, error) {
+	r, err := c.doAPIPost(ctx, c.membershipRequestRoute(membershipRequestId).Join("cancel"), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*MembershipRequest](r)
+}
+
+// GetMembershipRequestsForUser returns the membership requests of the user, optionally filtered by status.
+func (c *Client4) GetMembershipRequestsForUser(ctx context.Context, userId, status string, page, perPage int) ([]*MembershipRequest, *Response, error) {
+	return c.getMembershipRequests(ctx, c.userRoute(userId).Join("membership_requests"), status, page, perPage)
+}
+
+// GetMembershipRequestsForChannel returns the requests to join the channel, optionally filtered by status.
+func (c *Client4) GetMembershipRequestsForChannel(ctx context.Context, channelId, status string, page, perPage int) ([]*MembershipRequest, *Response, error) {
+	return c.getMembershipRequests(ctx, c.channelRoute(channelId).Join("membership_requests"), status, page, perPage)
+}
+
+// GetMembershipRequestsForTeam returns the requests to join the team, optionally filtered by status.
+func (c *Client4) GetMembershipRequestsForTeam(ctx context.Context, teamId, status string, page, perPage int) ([]*MembershipRequest, *Response, error) {
+	return c.getMembershipRequests(ctx, c.teamRoute(teamId).Join("membership_requests"), status, page, perPage)
+}
+
+func (c *Client4) getMembershipRequests(ctx context.Context, route clientRoute, status string, page, perPage int) ([]*MembershipRequest, *Response, error) {
+	values := url.Values{}
+	if status != "" {
+		values.Set("status", status)
+	}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.doAPIGetWithQuery(ctx, route, values, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*MembershipRequest](r)
+}
+
+// GetRequestableChannelsForTeam returns the private channels of the team the current user can ask to join.
+func (c *Client4) GetRequestableChannelsForTeam(ctx context.Context, teamId string, page, perPage int) ([]*Channel, *Response, error) {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.doAPIGetWithQuery(ctx, c.channelsForTeamRoute(teamId).Join("requestable"), values, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*Channel](r)
+}
+
+// GetRequestableTeamsForUser returns the teams the user can ask to join.
+func (c *Client4) GetRequestableTeamsForUser(ctx context.Context, userId string, page, perPage int) ([]*Team, *Response, error) {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	r, err := c.doAPIGetWithQuery(ctx, c.userRoute(userId).Join("teams", "requestable"), values, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*Team](r)
+}
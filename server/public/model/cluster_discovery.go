@@ -11,6 +11,13 @@ import (
 const (
 	CDSOfflineAfterMillis = 1000 * 60 * 30 // 30 minutes
 	CDSTypeApp            = "mattermost_app"
+
+	// CDSTypeNode is the type of the record that every running server
+	// keeps pinging, so that offline tools know a node is using the
+	// database.
+	CDSTypeNode            = "mattermost_node"
+	CDSNodeClusterName     = "nodes"
+	CDSNodeLiveAfterMillis = 1000 * 60 * 3 // 3 minutes
 )
 
 type ClusterDiscovery struct {
@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"io"
+	"maps"
 	"math"
 	"net"
 	"net/http"
@@ -47,6 +48,17 @@ const (
 	PasswordMaximumLength = 72
 	PasswordMinimumLength = 5
 
+	PasswordHasherPBKDF2   = "pbkdf2"
+	PasswordHasherArgon2id = "argon2id"
+
+	PasswordArgon2idDefaultMemoryKiB   = 19456
+	PasswordArgon2idMinimumMemoryKiB   = 1024
+	PasswordArgon2idMaximumMemoryKiB   = 1048576
+	PasswordArgon2idDefaultIterations  = 2
+	PasswordArgon2idMaximumIterations  = 10
+	PasswordArgon2idDefaultParallelism = 1
+	PasswordArgon2idMaximumParallelism = 16
+
 	ServiceGitlab = "gitlab"
 
 	ServiceGoogle    = "google"
@@ -284,6 +296,9 @@ const (
 	ConnectedWorkspacesSettingsDefaultMaxPostsPerSync     = 50 // a bit more than 4 typical screenfulls of posts
 	ConnectedWorkspacesSettingsDefaultMemberSyncBatchSize = 20 // optimal batch size for syncing channel members
 
+	ConnectedWorkspacesTransportHTTP  = "http"  // messages are posted directly to the remote's SiteURL
+	ConnectedWorkspacesTransportSpool = "spool" // messages are written to a spool directory for store-and-forward delivery
+
 	// These storage classes are the valid values for the x-amz-storage-class header. More documentation here https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObject.html#AmazonS3-PutObject-request-header-StorageClass
 	StorageClassStandard           = "STANDARD"
 	StorageClassReducedRedundancy  = "REDUCED_REDUNDANCY"
@@ -372,6 +387,11 @@ type ServiceSettings struct {
 	AllowedUntrustedInternalConnections *string  `access:"environment_web_server,write_restrictable,cloud_restrictable"`
 	EnableMultifactorAuthentication     *bool    `access:"authentication_mfa"`
 	EnforceMultifactorAuthentication    *bool    `access:"authentication_mfa"`
+	EnableWebAuthn                      *bool    `access:"authentication_mfa"`
+	EnableWebAuthnPasswordless          *bool    `access:"authentication_mfa"`
+	EnforceWebAuthn                     *bool    `access:"authentication_mfa"`
+	EnableMfaRecoveryCodes              *bool    `access:"authentication_mfa"`
+	MfaTrustedDeviceDays                *int     `access:"authentication_mfa"`
 	EnableUserAccessTokens              *bool    `access:"integrations_integration_management"`
 	AllowCorsFrom                       *string  `access:"integrations_cors,write_restrictable,cloud_restrictable"`
 	CorsExposedHeaders                  *string  `access:"integrations_cors,write_restrictable,cloud_restrictable"`
@@ -541,6 +561,26 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.EnforceMultifactorAuthentication = NewPointer(false)
 	}
 
+	if s.EnableWebAuthn == nil {
+		s.EnableWebAuthn = NewPointer(false)
+	}
+
+	if s.EnableWebAuthnPasswordless == nil {
+		s.EnableWebAuthnPasswordless = NewPointer(false)
+	}
+
+	if s.EnforceWebAuthn == nil {
+		s.EnforceWebAuthn = NewPointer(false)
+	}
+
+	if s.EnableMfaRecoveryCodes == nil {
+		s.EnableMfaRecoveryCodes = NewPointer(false)
+	}
+
+	if s.MfaTrustedDeviceDays == nil {
+		s.MfaTrustedDeviceDays = NewPointer(0)
+	}
+
 	if s.EnableUserAccessTokens == nil {
 		s.EnableUserAccessTokens = NewPointer(false)
 	}
@@ -1403,6 +1443,202 @@ func (s *Office365Settings) SSOSettings() *SSOSettings {
 	return &ssoSettings
 }
 
+type OpenIdProviderSettings struct {
+	Providers []*OpenIdProvider `access:"authentication_openid"` // telemetry: none
+}
+
+func (s *OpenIdProviderSettings) SetDefaults() {
+	if s.Providers == nil {
+		s.Providers = []*OpenIdProvider{}
+	}
+
+	for _, provider := range s.Providers {
+		if provider != nil {
+			provider.SetDefaults()
+		}
+	}
+}
+
+func (s *OpenIdProviderSettings) isValid() *AppError {
+	names := make(map[string]bool, len(s.Providers))
+	for _, provider := range s.Providers {
+		if provider == nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_name.app_error", map[string]any{"Name": "", "MaxLength": OpenIdProviderNameMaxLength}, "", http.StatusBadRequest)
+		}
+		if appErr := provider.isValid(); appErr != nil {
+			return appErr
+		}
+
+		if names[*provider.Name] {
+			return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_duplicate.app_error", map[string]any{"Name": *provider.Name}, "", http.StatusBadRequest)
+		}
+		names[*provider.Name] = true
+	}
+
+	return nil
+}
+
+// EnabledProviders returns the providers that are enabled.
+func (s *OpenIdProviderSettings) EnabledProviders() []*OpenIdProvider {
+	var providers []*OpenIdProvider
+	for _, provider := range s.Providers {
+		if provider != nil && provider.Enable != nil && *provider.Enable {
+			providers = append(providers, provider)
+		}
+	}
+
+	return providers
+}
+
+// GetProvider returns the provider of the service, or nil if there is none.
+func (s *OpenIdProviderSettings) GetProvider(service string) *OpenIdProvider {
+	for _, provider := range s.Providers {
+		if provider != nil && provider.Service() == service {
+			return provider
+		}
+	}
+
+	return nil
+}
+
+type ScimSettings struct {
+	Enable *bool `access:"authentication_signup"`
+
+	// AuthService is the authentication service of the users created through
+	// SCIM, whose external ID is their auth data. Users authenticate with a
+	// password if it is empty.
+	AuthService *string `access:"authentication_signup"`
+}
+
+func (s *ScimSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewPointer(false)
+	}
+
+	if s.AuthService == nil {
+		s.AuthService = NewPointer("")
+	}
+}
+
+func (s *ScimSettings) isValid() *AppError {
+	switch *s.AuthService {
+	case "", UserAuthServiceSaml, ServiceGitlab, ServiceGoogle, ServiceOffice365, ServiceOpenid:
+		return nil
+	}
+
+	if IsOpenIdProviderService(*s.AuthService) {
+		return nil
+	}
+
+	return NewAppError("Config.IsValid", "model.config.is_valid.scim_auth_service.app_error", map[string]any{"AuthService": *s.AuthService}, "", http.StatusBadRequest)
+}
+
+// SessionPolicySettings restricts the sessions of users beyond their length,
+// which ServiceSettings configures. Sessions of bots, personal access tokens
+// and OAuth apps aren't restricted.
+type SessionPolicySettings struct {
+	Enable *bool `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+
+	// MaxSessionsPerUser caps the number of sessions of a user, revoking the
+	// oldest ones when a new one is created. Zero means no limit.
+	MaxSessionsPerUser *int `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+
+	// MaxSessionsPerDeviceType caps the number of sessions of a user on each
+	// device type, web, desktop or mobile, likewise.
+	MaxSessionsPerDeviceType *int `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+
+	// AdminIdleTimeoutInMinutes and GuestIdleTimeoutInMinutes replace
+	// ServiceSettings.SessionIdleTimeoutInMinutes for the web and desktop
+	// sessions of system admins and guests, even when sessions are extended
+	// with activity. Zero keeps the service setting.
+	AdminIdleTimeoutInMinutes *int `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	GuestIdleTimeoutInMinutes *int `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+
+	// BindToIPSubnet revokes sessions used from outside the IP subnet they were
+	// created from, whose size is set by the prefix lengths.
+	BindToIPSubnet         *bool `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	IPv4SubnetPrefixLength *int  `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	IPv6SubnetPrefixLength *int  `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+}
+
+func (s *SessionPolicySettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewPointer(false)
+	}
+
+	if s.MaxSessionsPerUser == nil {
+		s.MaxSessionsPerUser = NewPointer(0)
+	}
+
+	if s.MaxSessionsPerDeviceType == nil {
+		s.MaxSessionsPerDeviceType = NewPointer(0)
+	}
+
+	if s.AdminIdleTimeoutInMinutes == nil {
+		s.AdminIdleTimeoutInMinutes = NewPointer(0)
+	}
+
+	if s.GuestIdleTimeoutInMinutes == nil {
+		s.GuestIdleTimeoutInMinutes = NewPointer(0)
+	}
+
+	if s.BindToIPSubnet == nil {
+		s.BindToIPSubnet = NewPointer(false)
+	}
+
+	if s.IPv4SubnetPrefixLength == nil {
+		s.IPv4SubnetPrefixLength = NewPointer(24)
+	}
+
+	if s.IPv6SubnetPrefixLength == nil {
+		s.IPv6SubnetPrefixLength = NewPointer(64)
+	}
+}
+
+func (s *SessionPolicySettings) isValid() *AppError {
+	if *s.MaxSessionsPerUser < 0 || *s.MaxSessionsPerDeviceType < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.session_policy_max_sessions.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.AdminIdleTimeoutInMinutes < 0 || *s.GuestIdleTimeoutInMinutes < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.session_policy_idle_timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.IPv4SubnetPrefixLength < 1 || *s.IPv4SubnetPrefixLength > 32 || *s.IPv6SubnetPrefixLength < 1 || *s.IPv6SubnetPrefixLength > 128 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.session_policy_subnet_prefix.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// MembershipRequestSettings lets users ask to join the private channels and
+// teams whose admins allow it, rather than having to be added to them.
+type MembershipRequestSettings struct {
+	Enable *bool `access:"site_users_and_teams"`
+
+	// RequestExpiryInHours is how long requests wait to be reviewed before
+	// they expire.
+	RequestExpiryInHours *int `access:"site_users_and_teams"`
+}
+
+func (s *MembershipRequestSettings) SetDefaults() {
+	if s.Enable == nil {
+		s.Enable = NewPointer(false)
+	}
+
+	if s.RequestExpiryInHours == nil {
+		s.RequestExpiryInHours = NewPointer(MembershipRequestSettingsDefaultRequestExpiryInHours)
+	}
+}
+
+func (s *MembershipRequestSettings) isValid() *AppError {
+	if *s.RequestExpiryInHours < 1 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.membership_request_expiry.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 type IntuneSettings struct {
 	Enable      *bool   `access:"mobile_intune"`
 	TenantId    *string `access:"mobile_intune"` // telemetry: none
@@ -1765,6 +2001,11 @@ type PasswordSettings struct {
 	Uppercase        *bool `access:"authentication_password"`
 	Symbol           *bool `access:"authentication_password"`
 	EnableForgotLink *bool `access:"authentication_password"`
+
+	Hasher              *string `access:"authentication_password"`
+	Argon2idMemoryKiB   *int    `access:"authentication_password"`
+	Argon2idIterations  *int    `access:"authentication_password"`
+	Argon2idParallelism *int    `access:"authentication_password"`
 }
 
 func (s *PasswordSettings) SetDefaults() {
@@ -1791,6 +2032,42 @@ func (s *PasswordSettings) SetDefaults() {
 	if s.EnableForgotLink == nil {
 		s.EnableForgotLink = NewPointer(true)
 	}
+
+	if s.Hasher == nil {
+		s.Hasher = NewPointer(PasswordHasherPBKDF2)
+	}
+
+	if s.Argon2idMemoryKiB == nil {
+		s.Argon2idMemoryKiB = NewPointer(PasswordArgon2idDefaultMemoryKiB)
+	}
+
+	if s.Argon2idIterations == nil {
+		s.Argon2idIterations = NewPointer(PasswordArgon2idDefaultIterations)
+	}
+
+	if s.Argon2idParallelism == nil {
+		s.Argon2idParallelism = NewPointer(PasswordArgon2idDefaultParallelism)
+	}
+}
+
+func (s *PasswordSettings) isValid() *AppError {
+	if *s.Hasher != PasswordHasherPBKDF2 && *s.Hasher != PasswordHasherArgon2id {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_hasher.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.Argon2idMemoryKiB < PasswordArgon2idMinimumMemoryKiB || *s.Argon2idMemoryKiB > PasswordArgon2idMaximumMemoryKiB {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_argon2id_memory.app_error", map[string]any{"Min": PasswordArgon2idMinimumMemoryKiB, "Max": PasswordArgon2idMaximumMemoryKiB}, "", http.StatusBadRequest)
+	}
+
+	if *s.Argon2idIterations < 1 || *s.Argon2idIterations > PasswordArgon2idMaximumIterations {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_argon2id_iterations.app_error", map[string]any{"Max": PasswordArgon2idMaximumIterations}, "", http.StatusBadRequest)
+	}
+
+	if *s.Argon2idParallelism < 1 || *s.Argon2idParallelism > PasswordArgon2idMaximumParallelism {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_argon2id_parallelism.app_error", map[string]any{"Max": PasswordArgon2idMaximumParallelism}, "", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 type FileSettings struct {
@@ -3433,21 +3710,31 @@ type PluginState struct {
 }
 
 type PluginSettings struct {
-	Enable                      *bool                     `access:"plugins,write_restrictable"`
-	EnableUploads               *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	AllowInsecureDownloadURL    *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	EnableHealthCheck           *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	Directory                   *string                   `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
-	ClientDirectory             *string                   `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
-	Plugins                     map[string]map[string]any `access:"plugins"`                                       // telemetry: none
-	PluginStates                map[string]*PluginState   `access:"plugins"`                                       // telemetry: none
-	EnableMarketplace           *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	EnableRemoteMarketplace     *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	AutomaticPrepackagedPlugins *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	RequirePluginSignature      *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	MarketplaceURL              *string                   `access:"plugins,write_restrictable,cloud_restrictable"`
-	SignaturePublicKeyFiles     []string                  `access:"plugins,write_restrictable,cloud_restrictable"`
-	ChimeraOAuthProxyURL        *string                   `access:"plugins,write_restrictable,cloud_restrictable"`
+	Enable                      *bool                           `access:"plugins,write_restrictable"`
+	EnableUploads               *bool                           `access:"plugins,write_restrictable,cloud_restrictable"`
+	AllowInsecureDownloadURL    *bool                           `access:"plugins,write_restrictable,cloud_restrictable"`
+	EnableHealthCheck           *bool                           `access:"plugins,write_restrictable,cloud_restrictable"`
+	Directory                   *string                         `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	ClientDirectory             *string                         `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	Plugins                     map[string]map[string]any       `access:"plugins"`                                       // telemetry: none
+	PluginStates                map[string]*PluginState         `access:"plugins"`                                       // telemetry: none
+	EnableMarketplace           *bool                           `access:"plugins,write_restrictable,cloud_restrictable"`
+	EnableRemoteMarketplace     *bool                           `access:"plugins,write_restrictable,cloud_restrictable"`
+	AutomaticPrepackagedPlugins *bool                           `access:"plugins,write_restrictable,cloud_restrictable"`
+	RequirePluginSignature      *bool                           `access:"plugins,write_restrictable,cloud_restrictable"`
+	MarketplaceURL              *string                         `access:"plugins,write_restrictable,cloud_restrictable"`
+	SignaturePublicKeyFiles     []string                        `access:"plugins,write_restrictable,cloud_restrictable"`
+	SignatureKeyPolicies        []*PluginSignatureKeyPolicy     `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	SignatureRevokedKeys        []string                        `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	TrustMattermostSignatureKey *bool                           `access:"plugins,write_restrictable,cloud_restrictable"`
+	ChimeraOAuthProxyURL        *string                         `access:"plugins,write_restrictable,cloud_restrictable"`
+	MaxMemoryMB                 *int                            `access:"plugins,write_restrictable,cloud_restrictable"`
+	MaxCPUPercent               *int                            `access:"plugins,write_restrictable,cloud_restrictable"`
+	MaxOpenFiles                *int                            `access:"plugins,write_restrictable,cloud_restrictable"`
+	CgroupPath                  *string                         `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	PluginLimits                map[string]PluginResourceLimits `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	APIQuotaPerMinute           *int                            `access:"plugins,write_restrictable,cloud_restrictable"`
+	APIMethodQuotasPerMinute    map[string]int                  `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
 }
 
 func (s *PluginSettings) SetDefaults(ls LogSettings) {
@@ -3483,6 +3770,10 @@ func (s *PluginSettings) SetDefaults(ls LogSettings) {
 		s.PluginStates = make(map[string]*PluginState)
 	}
 
+	if s.PluginLimits == nil {
+		s.PluginLimits = make(map[string]PluginResourceLimits)
+	}
+
 	if s.PluginStates[PluginIdNPS] == nil {
 		// Enable the NPS plugin by default if diagnostics are enabled
 		s.PluginStates[PluginIdNPS] = &PluginState{Enable: ls.EnableDiagnostics == nil || *ls.EnableDiagnostics}
@@ -3527,9 +3818,122 @@ func (s *PluginSettings) SetDefaults(ls LogSettings) {
 		s.SignaturePublicKeyFiles = []string{}
 	}
 
+	if s.SignatureKeyPolicies == nil {
+		s.SignatureKeyPolicies = []*PluginSignatureKeyPolicy{}
+	}
+
+	for _, policy := range s.SignatureKeyPolicies {
+		if policy != nil {
+			policy.SetDefaults()
+		}
+	}
+
+	if s.SignatureRevokedKeys == nil {
+		s.SignatureRevokedKeys = []string{}
+	}
+
+	if s.TrustMattermostSignatureKey == nil {
+		s.TrustMattermostSignatureKey = NewPointer(true)
+	}
+
 	if s.ChimeraOAuthProxyURL == nil {
 		s.ChimeraOAuthProxyURL = NewPointer("")
 	}
+
+	if s.MaxMemoryMB == nil {
+		s.MaxMemoryMB = NewPointer(0)
+	}
+
+	if s.MaxCPUPercent == nil {
+		s.MaxCPUPercent = NewPointer(0)
+	}
+
+	if s.MaxOpenFiles == nil {
+		s.MaxOpenFiles = NewPointer(0)
+	}
+
+	if s.CgroupPath == nil {
+		s.CgroupPath = NewPointer("")
+	}
+
+	if s.APIQuotaPerMinute == nil {
+		s.APIQuotaPerMinute = NewPointer(0)
+	}
+
+	if s.APIMethodQuotasPerMinute == nil {
+		s.APIMethodQuotasPerMinute = make(map[string]int)
+	}
+}
+
+func (s *PluginSettings) isValid() *AppError {
+	if *s.MaxMemoryMB < 0 || *s.MaxCPUPercent < 0 || *s.MaxOpenFiles < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_resource_limits.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for pluginID, limits := range s.PluginLimits {
+		if pluginID == "" || limits.isValid() != nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.plugin_limits.app_error", map[string]any{"PluginId": pluginID}, "", http.StatusBadRequest)
+		}
+	}
+
+	if *s.CgroupPath != "" && !filepath.IsAbs(*s.CgroupPath) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_cgroup_path.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.APIQuotaPerMinute < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_api_quota.app_error", map[string]any{"Method": "*"}, "", http.StatusBadRequest)
+	}
+
+	for method, quota := range s.APIMethodQuotasPerMinute {
+		if method == "" || quota < 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.plugin_api_quota.app_error", map[string]any{"Method": method}, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, policy := range s.SignatureKeyPolicies {
+		if policy == nil {
+			return NewAppError("Config.IsValid", "model.config.is_valid.plugin_signature_key_policy.app_error", map[string]any{"PublicKeyFile": ""}, "", http.StatusBadRequest)
+		}
+		if appErr := policy.isValid(); appErr != nil {
+			return appErr
+		}
+	}
+
+	for _, key := range s.SignatureRevokedKeys {
+		if !IsValidPluginSignatureKeyReference(key) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.plugin_signature_revoked_key.app_error", map[string]any{"Key": key}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// SignatureKeyPolicy returns the policy of the given public key file, if any.
+func (s *PluginSettings) SignatureKeyPolicy(publicKeyFile string) *PluginSignatureKeyPolicy {
+	for _, policy := range s.SignatureKeyPolicies {
+		if policy != nil && policy.PublicKeyFile != nil && *policy.PublicKeyFile == publicKeyFile {
+			return policy
+		}
+	}
+
+	return nil
+}
+
+// APIQuotas returns the plugin API quotas applied to every plugin.
+func (s *PluginSettings) APIQuotas() PluginAPIQuotas {
+	return PluginAPIQuotas{
+		CallsPerMinute:       *s.APIQuotaPerMinute,
+		MethodCallsPerMinute: maps.Clone(s.APIMethodQuotasPerMinute),
+	}
+}
+
+// ResourceLimits returns the resource limits applied to every plugin's server process.
+func (s *PluginSettings) ResourceLimits() PluginResourceLimits {
+	return PluginResourceLimits{
+		MaxMemoryMB:   *s.MaxMemoryMB,
+		MaxCPUPercent: *s.MaxCPUPercent,
+		MaxOpenFiles:  *s.MaxOpenFiles,
+	}
 }
 
 // Sanitize cleans up the plugin settings by removing any sensitive information.
@@ -3629,7 +4033,9 @@ type ConnectedWorkspacesSettings struct {
 	SyncUsersOnConnectionOpen       *bool
 	GlobalUserSyncBatchSize         *int
 	MaxPostsPerSync                 *int
-	MemberSyncBatchSize             *int // Maximum number of members to process in a single batch during shared channel synchronization
+	MemberSyncBatchSize             *int    // Maximum number of members to process in a single batch during shared channel synchronization
+	Transport                       *string // How messages are exchanged with remote clusters: "http" or "spool"
+	SpoolDirectory                  *string // Directory used by the "spool" transport
 }
 
 func (c *ConnectedWorkspacesSettings) SetDefaults(isUpdate bool, e ExperimentalSettings) {
@@ -3668,6 +4074,27 @@ func (c *ConnectedWorkspacesSettings) SetDefaults(isUpdate bool, e ExperimentalS
 	if c.MemberSyncBatchSize == nil {
 		c.MemberSyncBatchSize = NewPointer(ConnectedWorkspacesSettingsDefaultMemberSyncBatchSize)
 	}
+
+	if c.Transport == nil {
+		c.Transport = NewPointer(ConnectedWorkspacesTransportHTTP)
+	}
+
+	if c.SpoolDirectory == nil {
+		c.SpoolDirectory = NewPointer("")
+	}
+}
+
+func (c *ConnectedWorkspacesSettings) isValid() *AppError {
+	switch *c.Transport {
+	case ConnectedWorkspacesTransportHTTP:
+	case ConnectedWorkspacesTransportSpool:
+		if *c.SpoolDirectory == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.connected_workspaces.spool_directory.app_error", nil, "", http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.connected_workspaces.transport.app_error", map[string]any{"Transport": *c.Transport}, "", http.StatusBadRequest)
+	}
+	return nil
 }
 
 type GlobalRelayMessageExportSettings struct {
@@ -3986,6 +4413,10 @@ type Config struct {
 	GoogleSettings              SSOSettings
 	Office365Settings           Office365Settings
 	OpenIdSettings              SSOSettings
+	OpenIdProviderSettings      OpenIdProviderSettings
+	ScimSettings                ScimSettings
+	SessionPolicySettings       SessionPolicySettings
+	MembershipRequestSettings   MembershipRequestSettings
 	LdapSettings                LdapSettings
 	ComplianceSettings          ComplianceSettings
 	LocalizationSettings        LocalizationSettings
@@ -4058,6 +4489,10 @@ func (o *Config) GetSSOService(service string) *SSOSettings {
 		return &o.OpenIdSettings
 	}
 
+	if provider := o.OpenIdProviderSettings.GetProvider(service); provider != nil {
+		return provider.SSOSettings()
+	}
+
 	return nil
 }
 
@@ -4095,6 +4530,10 @@ func (o *Config) SetDefaults() {
 	o.GitLabSettings.setDefaults("", "", "", "", "")
 	o.GoogleSettings.setDefaults(GoogleSettingsDefaultScope, GoogleSettingsDefaultAuthEndpoint, GoogleSettingsDefaultTokenEndpoint, GoogleSettingsDefaultUserAPIEndpoint, "")
 	o.OpenIdSettings.setDefaults(OpenidSettingsDefaultScope, "", "", "", "#145DBF")
+	o.OpenIdProviderSettings.SetDefaults()
+	o.ScimSettings.SetDefaults()
+	o.SessionPolicySettings.SetDefaults()
+	o.MembershipRequestSettings.SetDefaults()
 	o.ServiceSettings.SetDefaults(isUpdate)
 	o.PasswordSettings.SetDefaults()
 	o.TeamSettings.SetDefaults()
@@ -4152,6 +4591,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.PluginSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	if *o.ServiceSettings.SiteURL == "" && *o.ServiceSettings.AllowCookiesForSubdomains {
 		return NewAppError("Config.IsValid", "model.config.is_valid.allow_cookies_for_subdomains.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -4184,6 +4627,22 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.OpenIdProviderSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
+	if appErr := o.ScimSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
+	if appErr := o.SessionPolicySettings.isValid(); appErr != nil {
+		return appErr
+	}
+
+	if appErr := o.MembershipRequestSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	// Validate IntuneSettings
 	if appErr := o.IntuneSettings.IsValid(); appErr != nil {
 		return appErr
@@ -4209,6 +4668,10 @@ func (o *Config) IsValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.password_length.app_error", map[string]any{"MinLength": PasswordMinimumLength, "MaxLength": PasswordMaximumLength}, "", http.StatusBadRequest)
 	}
 
+	if appErr := o.PasswordSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	if appErr := o.RateLimitSettings.isValid(); appErr != nil {
 		return appErr
 	}
@@ -4257,6 +4720,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.ConnectedWorkspacesSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	if appErr := o.WranglerSettings.IsValid(); appErr != nil {
 		return appErr
 	}
@@ -4724,6 +5191,22 @@ func (s *ServiceSettings) isValid() *AppError {
 		}
 	}
 
+	if *s.EnableWebAuthn && !*s.EnableMultifactorAuthentication {
+		return NewAppError("Config.IsValid", "model.config.is_valid.webauthn_requires_mfa.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if (*s.EnableWebAuthnPasswordless || *s.EnforceWebAuthn) && !*s.EnableWebAuthn {
+		return NewAppError("Config.IsValid", "model.config.is_valid.webauthn_disabled.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.EnforceWebAuthn && !*s.EnforceMultifactorAuthentication {
+		return NewAppError("Config.IsValid", "model.config.is_valid.webauthn_enforce.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.MfaTrustedDeviceDays < 0 || *s.MfaTrustedDeviceDays > MfaTrustedDeviceDaysMax {
+		return NewAppError("Config.IsValid", "model.config.is_valid.mfa_trusted_device_days.app_error", map[string]any{"MaxDays": MfaTrustedDeviceDaysMax}, "", http.StatusBadRequest)
+	}
+
 	// we check if file has a valid parent, the server will try to create the socket
 	// file if it doesn't exist, but we need to be sure if the directory exist or not
 	if *s.EnableLocalMode {
@@ -5050,6 +5533,12 @@ func (o *Config) Sanitize(pluginManifests []*Manifest, opts *SanitizeOptions) {
 		*o.OpenIdSettings.Secret = FakeSetting
 	}
 
+	for _, provider := range o.OpenIdProviderSettings.Providers {
+		if provider != nil && provider.Secret != nil && *provider.Secret != "" {
+			*provider.Secret = FakeSetting
+		}
+	}
+
 	if o.SqlSettings.DataSource != nil {
 		*o.SqlSettings.DataSource = sanitizeDataSourceField(*o.SqlSettings.DataSource, "SqlSettings.DataSource")
 	}
@@ -148,6 +148,53 @@ func TestServiceSettingsIsValid(t *testing.T) {
 			},
 			ExpectError: false,
 		},
+		"WebAuthn without MFA": {
+			ServiceSettings: ServiceSettings{
+				EnableWebAuthn: NewPointer(true),
+			},
+			ExpectError: true,
+		},
+		"WebAuthn with MFA": {
+			ServiceSettings: ServiceSettings{
+				EnableMultifactorAuthentication: NewPointer(true),
+				EnableWebAuthn:                  NewPointer(true),
+				EnableWebAuthnPasswordless:      NewPointer(true),
+			},
+			ExpectError: false,
+		},
+		"passwordless without WebAuthn": {
+			ServiceSettings: ServiceSettings{
+				EnableMultifactorAuthentication: NewPointer(true),
+				EnableWebAuthnPasswordless:      NewPointer(true),
+			},
+			ExpectError: true,
+		},
+		"WebAuthn enforced without MFA enforced": {
+			ServiceSettings: ServiceSettings{
+				EnableMultifactorAuthentication: NewPointer(true),
+				EnableWebAuthn:                  NewPointer(true),
+				EnforceWebAuthn:                 NewPointer(true),
+			},
+			ExpectError: true,
+		},
+		"MFA trusted device days within range": {
+			ServiceSettings: ServiceSettings{
+				MfaTrustedDeviceDays: NewPointer(30),
+			},
+			ExpectError: false,
+		},
+		"MFA trusted device days is negative": {
+			ServiceSettings: ServiceSettings{
+				MfaTrustedDeviceDays: NewPointer(-1),
+			},
+			ExpectError: true,
+		},
+		"MFA trusted device days is too long": {
+			ServiceSettings: ServiceSettings{
+				MfaTrustedDeviceDays: NewPointer(MfaTrustedDeviceDaysMax + 1),
+			},
+			ExpectError: true,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			test.ServiceSettings.SetDefaults(false)
@@ -162,6 +209,71 @@ func TestServiceSettingsIsValid(t *testing.T) {
 	}
 }
 
+func TestPasswordSettingsIsValid(t *testing.T) {
+	for name, test := range map[string]struct {
+		PasswordSettings PasswordSettings
+		ExpectError      bool
+	}{
+		"defaults": {
+			PasswordSettings: PasswordSettings{},
+			ExpectError:      false,
+		},
+		"Argon2id hasher": {
+			PasswordSettings: PasswordSettings{
+				Hasher: NewPointer(PasswordHasherArgon2id),
+			},
+			ExpectError: false,
+		},
+		"unknown hasher": {
+			PasswordSettings: PasswordSettings{
+				Hasher: NewPointer("bcrypt"),
+			},
+			ExpectError: true,
+		},
+		"Argon2id memory too low": {
+			PasswordSettings: PasswordSettings{
+				Argon2idMemoryKiB: NewPointer(PasswordArgon2idMinimumMemoryKiB - 1),
+			},
+			ExpectError: true,
+		},
+		"Argon2id memory too high": {
+			PasswordSettings: PasswordSettings{
+				Argon2idMemoryKiB: NewPointer(PasswordArgon2idMaximumMemoryKiB + 1),
+			},
+			ExpectError: true,
+		},
+		"Argon2id without iterations": {
+			PasswordSettings: PasswordSettings{
+				Argon2idIterations: NewPointer(0),
+			},
+			ExpectError: true,
+		},
+		"Argon2id without parallelism": {
+			PasswordSettings: PasswordSettings{
+				Argon2idParallelism: NewPointer(0),
+			},
+			ExpectError: true,
+		},
+		"Argon2id parallelism too high": {
+			PasswordSettings: PasswordSettings{
+				Argon2idParallelism: NewPointer(PasswordArgon2idMaximumParallelism + 1),
+			},
+			ExpectError: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.PasswordSettings.SetDefaults()
+
+			appErr := test.PasswordSettings.isValid()
+			if test.ExpectError {
+				assert.NotNil(t, appErr)
+			} else {
+				assert.Nil(t, appErr)
+			}
+		})
+	}
+}
+
 func TestConfigEnableDeveloper(t *testing.T) {
 	testCases := []struct {
 		Description     string
@@ -319,6 +431,70 @@ func TestConfigOverwriteSignatureAlgorithm(t *testing.T) {
 	require.Equal(t, *c1.SamlSettings.CanonicalAlgorithm, testAlgorithm)
 }
 
+func TestSessionPolicySettingsIsValid(t *testing.T) {
+	for name, test := range map[string]struct {
+		SessionPolicySettings SessionPolicySettings
+		ExpectError           bool
+	}{
+		"defaults": {
+			SessionPolicySettings: SessionPolicySettings{},
+			ExpectError:           false,
+		},
+		"negative maximum sessions": {
+			SessionPolicySettings: SessionPolicySettings{
+				MaxSessionsPerUser: NewPointer(-1),
+			},
+			ExpectError: true,
+		},
+		"negative maximum sessions per device type": {
+			SessionPolicySettings: SessionPolicySettings{
+				MaxSessionsPerDeviceType: NewPointer(-1),
+			},
+			ExpectError: true,
+		},
+		"negative admin idle timeout": {
+			SessionPolicySettings: SessionPolicySettings{
+				AdminIdleTimeoutInMinutes: NewPointer(-1),
+			},
+			ExpectError: true,
+		},
+		"IPv4 prefix too long": {
+			SessionPolicySettings: SessionPolicySettings{
+				IPv4SubnetPrefixLength: NewPointer(33),
+			},
+			ExpectError: true,
+		},
+		"IPv6 prefix too short": {
+			SessionPolicySettings: SessionPolicySettings{
+				IPv6SubnetPrefixLength: NewPointer(0),
+			},
+			ExpectError: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			test.SessionPolicySettings.SetDefaults()
+
+			appErr := test.SessionPolicySettings.isValid()
+			if test.ExpectError {
+				assert.NotNil(t, appErr)
+			} else {
+				assert.Nil(t, appErr)
+			}
+		})
+	}
+}
+
+func TestMembershipRequestSettingsIsValid(t *testing.T) {
+	s := MembershipRequestSettings{}
+	s.SetDefaults()
+	require.Nil(t, s.isValid())
+
+	s.RequestExpiryInHours = NewPointer(0)
+	appErr := s.isValid()
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.config.is_valid.membership_request_expiry.app_error", appErr.Id)
+}
+
 func TestWranglerSettingsIsValid(t *testing.T) {
 	// // Test valid domains
 	w := &WranglerSettings{
@@ -337,6 +513,80 @@ func TestWranglerSettingsIsValid(t *testing.T) {
 	}
 }
 
+func TestPluginSettingsIsValid(t *testing.T) {
+	for name, test := range map[string]struct {
+		Modify  func(*PluginSettings)
+		ErrorID string
+	}{
+		"defaults": {
+			Modify: func(*PluginSettings) {},
+		},
+		"resource limits": {
+			Modify: func(s *PluginSettings) {
+				*s.MaxMemoryMB = 512
+				*s.MaxCPUPercent = 150
+				*s.MaxOpenFiles = 1024
+				*s.CgroupPath = "/sys/fs/cgroup/mattermost.service/plugins"
+			},
+		},
+		"negative limit": {
+			Modify: func(s *PluginSettings) {
+				*s.MaxOpenFiles = -1
+			},
+			ErrorID: "model.config.is_valid.plugin_resource_limits.app_error",
+		},
+		"relative cgroup path": {
+			Modify: func(s *PluginSettings) {
+				*s.CgroupPath = "mattermost/plugins"
+			},
+			ErrorID: "model.config.is_valid.plugin_cgroup_path.app_error",
+		},
+		"plugin limits": {
+			Modify: func(s *PluginSettings) {
+				s.PluginLimits["com.example.plugin"] = PluginResourceLimits{MaxMemoryMB: 256}
+			},
+		},
+		"negative plugin limit": {
+			Modify: func(s *PluginSettings) {
+				s.PluginLimits["com.example.plugin"] = PluginResourceLimits{MaxCPUPercent: -1}
+			},
+			ErrorID: "model.config.is_valid.plugin_limits.app_error",
+		},
+		"plugin limits without plugin id": {
+			Modify: func(s *PluginSettings) {
+				s.PluginLimits[""] = PluginResourceLimits{MaxMemoryMB: 256}
+			},
+			ErrorID: "model.config.is_valid.plugin_limits.app_error",
+		},
+		"api quotas": {
+			Modify: func(s *PluginSettings) {
+				*s.APIQuotaPerMinute = 6000
+				s.APIMethodQuotasPerMinute["KVSet"] = 600
+			},
+		},
+		"negative api quota": {
+			Modify: func(s *PluginSettings) {
+				s.APIMethodQuotasPerMinute["KVSet"] = -1
+			},
+			ErrorID: "model.config.is_valid.plugin_api_quota.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			settings := &PluginSettings{}
+			settings.SetDefaults(LogSettings{})
+			test.Modify(settings)
+
+			appErr := settings.isValid()
+			if test.ErrorID == "" {
+				assert.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				assert.Equal(t, test.ErrorID, appErr.Id)
+			}
+		})
+	}
+}
+
 func TestConfigIsValidDefaultAlgorithms(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -2374,6 +2624,51 @@ func TestConfigDefaultConnectedWorkspacesSettings(t *testing.T) {
 	})
 }
 
+func TestConnectedWorkspacesSettingsIsValid(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		Transport      string
+		SpoolDirectory string
+		ExpectError    bool
+	}{
+		"http transport": {
+			Transport:   ConnectedWorkspacesTransportHTTP,
+			ExpectError: false,
+		},
+		"spool transport with directory": {
+			Transport:      ConnectedWorkspacesTransportSpool,
+			SpoolDirectory: "/var/spool/mattermost",
+			ExpectError:    false,
+		},
+		"spool transport without directory": {
+			Transport:   ConnectedWorkspacesTransportSpool,
+			ExpectError: true,
+		},
+		"unknown transport": {
+			Transport:   "carrier-pigeon",
+			ExpectError: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			settings := ConnectedWorkspacesSettings{
+				Transport:      NewPointer(test.Transport),
+				SpoolDirectory: NewPointer(test.SpoolDirectory),
+			}
+			settings.SetDefaults(false, ExperimentalSettings{})
+
+			appErr := settings.isValid()
+			if test.ExpectError {
+				assert.NotNil(t, appErr)
+			} else {
+				assert.Nil(t, appErr)
+			}
+		})
+	}
+}
+
 func TestExperimentalAuditSettingsIsValid(t *testing.T) {
 	t.Parallel()
 
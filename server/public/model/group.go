@@ -16,6 +16,10 @@ const (
 	// plugin groups must prefix their source with this
 	GroupSourcePluginPrefix GroupSource = "plugin_"
 
+	// groups synchronized from the groups claim of an OpenID Connect provider
+	// have the service of the provider as their source
+	GroupSourceOpenidPrefix GroupSource = ServiceOpenidProviderPrefix
+
 	GroupNameMaxLength        = 64
 	GroupSourceMaxLength      = 64
 	GroupDisplayNameMaxLength = 128
@@ -214,7 +218,8 @@ func (group *Group) IsValidForCreate() *AppError {
 	isValidSource := false
 	if group.Source == GroupSourceLdap ||
 		group.Source == GroupSourceCustom ||
-		strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix)) {
+		strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix)) ||
+		strings.HasPrefix(string(group.Source), string(GroupSourceOpenidPrefix)) {
 		isValidSource = true
 	}
 
@@ -230,7 +235,9 @@ func (group *Group) IsValidForCreate() *AppError {
 }
 
 func (group *Group) requiresRemoteId() bool {
-	return group.Source == GroupSourceLdap || strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix))
+	return group.Source == GroupSourceLdap ||
+		strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix)) ||
+		strings.HasPrefix(string(group.Source), string(GroupSourceOpenidPrefix))
 }
 
 func GetSyncableGroupSources() []GroupSource {
@@ -238,11 +245,22 @@ func GetSyncableGroupSources() []GroupSource {
 }
 
 func GetSyncableGroupSourcePrefixes() []GroupSource {
-	return []GroupSource{GroupSourcePluginPrefix}
+	return []GroupSource{GroupSourcePluginPrefix, GroupSourceOpenidPrefix}
 }
 
 func (group *Group) IsSyncable() bool {
-	return group.Source == GroupSourceLdap || strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix))
+	return group.Source == GroupSourceLdap ||
+		strings.HasPrefix(string(group.Source), string(GroupSourcePluginPrefix)) ||
+		strings.HasPrefix(string(group.Source), string(GroupSourceOpenidPrefix)) ||
+		group.IsProvisioned()
+}
+
+// IsProvisioned returns whether the group is a custom group provisioned by an
+// identity provider through SCIM. Such groups have the external ID of the
+// identity provider as their remote ID, which custom groups otherwise lack,
+// and their members are managed by the identity provider.
+func (group *Group) IsProvisioned() bool {
+	return group.Source == GroupSourceCustom && group.GetRemoteId() != ""
 }
 
 func (group *Group) IsValidForUpdate() *AppError {
@@ -169,7 +169,7 @@ func (p *PostAction) IsValid() error {
 		if p.Integration.URL == "" {
 			multiErr = multierror.Append(multiErr, fmt.Errorf("action must have an integration URL"))
 		}
-		if !(strings.HasPrefix(p.Integration.URL, "/plugins/") || strings.HasPrefix(p.Integration.URL, "plugins/") || IsValidHTTPURL(p.Integration.URL)) {
+		if !(strings.HasPrefix(p.Integration.URL, "/plugins/") || strings.HasPrefix(p.Integration.URL, "plugins/") || IsValidHTTPURL(p.Integration.URL) || p.Integration.URL == MembershipRequestActionURL) {
 			multiErr = multierror.Append(multiErr, fmt.Errorf("action must have an valid integration URL"))
 		}
 	}
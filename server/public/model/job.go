@@ -48,6 +48,8 @@ const (
 	JobTypeRecap                         = "recap"
 	JobTypeDeleteExpiredPosts            = "delete_expired_posts"
 	JobTypeAutoTranslationRecovery       = "autotranslation_recovery"
+	JobTypeExportAccessReport            = "export_access_report"
+	JobTypeUserSync                      = "user_sync"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -80,6 +82,8 @@ var AllJobTypes = [...]string{
 	JobTypeCleanupDesktopTokens,
 	JobTypeRefreshMaterializedViews,
 	JobTypeMobileSessionMetadata,
+	JobTypeExportAccessReport,
+	JobTypeUserSync,
 }
 
 type Job struct {
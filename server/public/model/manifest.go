@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -209,6 +210,12 @@ type Manifest struct {
 	// Minimum server version: 5.6
 	MinServerVersion string `json:"min_server_version,omitempty" yaml:"min_server_version,omitempty"`
 
+	// Dependencies are the plugins that must be active before your plugin is activated.
+	// Your plugin may call the methods they export through the CallPlugin API.
+	//
+	// Minimum server version: 11.6
+	Dependencies []*ManifestDependency `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+
 	// Server defines the server-side portion of your plugin.
 	Server *ManifestServer `json:"server,omitempty" yaml:"server,omitempty"`
 
@@ -236,6 +243,103 @@ type ManifestServer struct {
 	// If your plugin is compiled for multiple platforms, consider bundling them together
 	// and using the Executables field instead.
 	Executable string `json:"executable" yaml:"executable"`
+
+	// ResourceLimits is the resource budget of your plugin's server process. Administrators may
+	// configure tighter limits, in which case theirs apply.
+	//
+	// Minimum server version: 11.6
+	ResourceLimits *PluginResourceLimits `json:"resource_limits,omitempty" yaml:"resource_limits,omitempty"`
+
+	// ExportedMethods are the names of the methods other plugins may call through the CallPlugin
+	// API. Calls are served by your plugin's OnPluginCall hook.
+	//
+	// Minimum server version: 11.6
+	ExportedMethods []string `json:"exported_methods,omitempty" yaml:"exported_methods,omitempty"`
+}
+
+type ManifestDependency struct {
+	// The id of the plugin depended on.
+	Id string `json:"id" yaml:"id"`
+
+	// The minimum version of the plugin depended on, if any.
+	MinVersion string `json:"min_version,omitempty" yaml:"min_version,omitempty"`
+}
+
+// IsSatisfiedBy reports whether the given plugin manifest satisfies the dependency.
+func (d *ManifestDependency) IsSatisfiedBy(m *Manifest) (bool, error) {
+	if m.Id != d.Id {
+		return false, nil
+	}
+	if d.MinVersion == "" {
+		return true, nil
+	}
+
+	minVersion, err := semver.Parse(d.MinVersion)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse MinVersion")
+	}
+	version, err := semver.Parse(m.Version)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse Version")
+	}
+	return version.GTE(minVersion), nil
+}
+
+// PluginResourceLimits bounds the resources a plugin's server process may use.
+// A zero value means no limit.
+type PluginResourceLimits struct {
+	// MaxMemoryMB is the maximum resident memory of the process, in megabytes.
+	MaxMemoryMB int `json:"max_memory_mb,omitempty" yaml:"max_memory_mb,omitempty"`
+
+	// MaxCPUPercent is the maximum CPU usage of the process, as a percentage of a single core.
+	MaxCPUPercent int `json:"max_cpu_percent,omitempty" yaml:"max_cpu_percent,omitempty"`
+
+	// MaxOpenFiles is the maximum number of file descriptors the process may have open.
+	MaxOpenFiles int `json:"max_open_files,omitempty" yaml:"max_open_files,omitempty"`
+}
+
+// IsZero reports whether no limit is set.
+func (l PluginResourceLimits) IsZero() bool {
+	return l == PluginResourceLimits{}
+}
+
+// Restrict returns the tighter of each limit in l and other.
+func (l PluginResourceLimits) Restrict(other PluginResourceLimits) PluginResourceLimits {
+	tighter := func(a, b int) int {
+		if a == 0 || (b != 0 && b < a) {
+			return b
+		}
+		return a
+	}
+
+	return PluginResourceLimits{
+		MaxMemoryMB:   tighter(l.MaxMemoryMB, other.MaxMemoryMB),
+		MaxCPUPercent: tighter(l.MaxCPUPercent, other.MaxCPUPercent),
+		MaxOpenFiles:  tighter(l.MaxOpenFiles, other.MaxOpenFiles),
+	}
+}
+
+// Override returns l with each limit set in other replacing the one in l.
+func (l PluginResourceLimits) Override(other PluginResourceLimits) PluginResourceLimits {
+	override := func(a, b int) int {
+		if b != 0 {
+			return b
+		}
+		return a
+	}
+
+	return PluginResourceLimits{
+		MaxMemoryMB:   override(l.MaxMemoryMB, other.MaxMemoryMB),
+		MaxCPUPercent: override(l.MaxCPUPercent, other.MaxCPUPercent),
+		MaxOpenFiles:  override(l.MaxOpenFiles, other.MaxOpenFiles),
+	}
+}
+
+func (l PluginResourceLimits) isValid() error {
+	if l.MaxMemoryMB < 0 || l.MaxCPUPercent < 0 || l.MaxOpenFiles < 0 {
+		return errors.New("resource limits can't be negative")
+	}
+	return nil
 }
 
 type ManifestWebapp struct {
@@ -247,6 +351,27 @@ type ManifestWebapp struct {
 	BundleHash []byte `json:"-"`
 }
 
+// DependsOn reports whether the plugin declares a dependency on the given plugin.
+func (m *Manifest) DependsOn(pluginID string) bool {
+	return slices.ContainsFunc(m.Dependencies, func(dependency *ManifestDependency) bool {
+		return dependency.Id == pluginID
+	})
+}
+
+// ExportsMethod reports whether the plugin exports the given method to other plugins.
+func (m *Manifest) ExportsMethod(method string) bool {
+	return m.Server != nil && slices.Contains(m.Server.ExportedMethods, method)
+}
+
+// GetResourceLimits returns the resource limits of the plugin's server process, restricted by
+// the given administrator limits.
+func (m *Manifest) GetResourceLimits(adminLimits PluginResourceLimits) PluginResourceLimits {
+	if m.Server == nil || m.Server.ResourceLimits == nil {
+		return adminLimits
+	}
+	return m.Server.ResourceLimits.Restrict(adminLimits)
+}
+
 func (m *Manifest) HasClient() bool {
 	return m.Webapp != nil
 }
@@ -345,6 +470,27 @@ func (m *Manifest) IsValid() error {
 		}
 	}
 
+	for _, dependency := range m.Dependencies {
+		if dependency == nil || !IsValidPluginId(dependency.Id) || dependency.Id == m.Id {
+			return errors.New("invalid dependency ID")
+		}
+		if dependency.MinVersion != "" {
+			if _, err := semver.Parse(dependency.MinVersion); err != nil {
+				return errors.Wrapf(err, "failed to parse MinVersion of dependency %s", dependency.Id)
+			}
+		}
+	}
+
+	if m.Server != nil && slices.Contains(m.Server.ExportedMethods, "") {
+		return errors.New("exported method names can't be empty")
+	}
+
+	if m.Server != nil && m.Server.ResourceLimits != nil {
+		if err := m.Server.ResourceLimits.isValid(); err != nil {
+			return errors.Wrap(err, "invalid server resource limits")
+		}
+	}
+
 	if m.SettingsSchema != nil {
 		err := m.SettingsSchema.isValid()
 		if err != nil {
@@ -31,6 +31,17 @@ func TestIsValid(t *testing.T) {
 		{"SettingSchema error", &Manifest{Id: "com.company.test", Name: "some name", HomepageURL: "http://someurl.com", SupportURL: "http://someotherurl.com", Version: "5.10.0", MinServerVersion: "5.10.8", SettingsSchema: &PluginSettingsSchema{
 			Settings: []*PluginSetting{{Type: "Invalid"}},
 		}}, true},
+		{"Negative resource limit", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{
+			Executable:     "theexecutable",
+			ResourceLimits: &PluginResourceLimits{MaxMemoryMB: -1},
+		}}, true},
+		{"Self dependency", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "com.company.test"}}}, true},
+		{"Invalid dependency id", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "some id"}}}, true},
+		{"Invalid dependency min version", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: []*ManifestDependency{{Id: "com.company.other", MinVersion: "version"}}}, true},
+		{"Empty exported method", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{
+			Executable:      "theexecutable",
+			ExportedMethods: []string{"GetThing", ""},
+		}}, true},
 		{"Minimal valid manifest", &Manifest{Id: "com.company.test", Name: "some name"}, false},
 		{"Happy case", &Manifest{
 			Id:               "com.company.test",
@@ -907,3 +918,98 @@ func TestManifestMeetMinServerVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestManifestGetResourceLimits(t *testing.T) {
+	adminLimits := PluginResourceLimits{MaxMemoryMB: 512, MaxOpenFiles: 1024}
+
+	t.Run("no server", func(t *testing.T) {
+		manifest := &Manifest{}
+		assert.Equal(t, adminLimits, manifest.GetResourceLimits(adminLimits))
+	})
+
+	t.Run("no limits in manifest", func(t *testing.T) {
+		manifest := &Manifest{Server: &ManifestServer{Executable: "theexecutable"}}
+		assert.Equal(t, adminLimits, manifest.GetResourceLimits(adminLimits))
+	})
+
+	t.Run("tighter limit wins", func(t *testing.T) {
+		manifest := &Manifest{Server: &ManifestServer{
+			Executable: "theexecutable",
+			ResourceLimits: &PluginResourceLimits{
+				MaxMemoryMB:   1024,
+				MaxCPUPercent: 50,
+				MaxOpenFiles:  256,
+			},
+		}}
+		assert.Equal(t, PluginResourceLimits{
+			MaxMemoryMB:   512,
+			MaxCPUPercent: 50,
+			MaxOpenFiles:  256,
+		}, manifest.GetResourceLimits(adminLimits))
+	})
+
+	t.Run("no limits at all", func(t *testing.T) {
+		manifest := &Manifest{Server: &ManifestServer{Executable: "theexecutable"}}
+		assert.True(t, manifest.GetResourceLimits(PluginResourceLimits{}).IsZero())
+	})
+}
+
+func TestManifestDependencies(t *testing.T) {
+	manifest := &Manifest{
+		Id: "com.company.test",
+		Dependencies: []*ManifestDependency{
+			{Id: "com.company.base"},
+			{Id: "com.company.versioned", MinVersion: "1.2.0"},
+		},
+		Server: &ManifestServer{
+			Executable:      "theexecutable",
+			ExportedMethods: []string{"GetThing"},
+		},
+	}
+
+	t.Run("DependsOn", func(t *testing.T) {
+		assert.True(t, manifest.DependsOn("com.company.base"))
+		assert.True(t, manifest.DependsOn("com.company.versioned"))
+		assert.False(t, manifest.DependsOn("com.company.other"))
+	})
+
+	t.Run("ExportsMethod", func(t *testing.T) {
+		assert.True(t, manifest.ExportsMethod("GetThing"))
+		assert.False(t, manifest.ExportsMethod("SetThing"))
+		assert.False(t, (&Manifest{}).ExportsMethod("GetThing"))
+	})
+
+	t.Run("IsSatisfiedBy", func(t *testing.T) {
+		base, versioned := manifest.Dependencies[0], manifest.Dependencies[1]
+
+		satisfied, err := base.IsSatisfiedBy(&Manifest{Id: "com.company.base", Version: "0.1.0"})
+		require.NoError(t, err)
+		assert.True(t, satisfied)
+
+		satisfied, err = base.IsSatisfiedBy(&Manifest{Id: "com.company.other"})
+		require.NoError(t, err)
+		assert.False(t, satisfied)
+
+		satisfied, err = versioned.IsSatisfiedBy(&Manifest{Id: "com.company.versioned", Version: "1.2.0"})
+		require.NoError(t, err)
+		assert.True(t, satisfied)
+
+		satisfied, err = versioned.IsSatisfiedBy(&Manifest{Id: "com.company.versioned", Version: "1.1.9"})
+		require.NoError(t, err)
+		assert.False(t, satisfied)
+
+		_, err = versioned.IsSatisfiedBy(&Manifest{Id: "com.company.versioned", Version: "version"})
+		require.Error(t, err)
+	})
+}
+
+func TestPluginResourceLimitsOverride(t *testing.T) {
+	globalLimits := PluginResourceLimits{MaxMemoryMB: 512, MaxOpenFiles: 1024}
+
+	assert.Equal(t, globalLimits, globalLimits.Override(PluginResourceLimits{}))
+	assert.Equal(t, PluginResourceLimits{
+		MaxMemoryMB:   2048,
+		MaxCPUPercent: 50,
+		MaxOpenFiles:  1024,
+	}, globalLimits.Override(PluginResourceLimits{MaxMemoryMB: 2048, MaxCPUPercent: 50}))
+}
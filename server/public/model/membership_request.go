@@ -0,0 +1,162 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	MembershipRequestStatusPending   = "pending"
+	MembershipRequestStatusApproved  = "approved"
+	MembershipRequestStatusDenied    = "denied"
+	MembershipRequestStatusCancelled = "cancelled"
+	MembershipRequestStatusExpired   = "expired"
+
+	MembershipRequestJustificationMaxRunes = 1024
+
+	MembershipRequestSettingsDefaultRequestExpiryInHours = 7 * 24
+
+	// MembershipRequestActionURL is the integration URL of the buttons of the posts asking
+	// reviewers to approve or deny a request. It is handled by the server itself.
+	MembershipRequestActionURL = "/membership_requests/actions"
+
+	MembershipRequestActionApprove = "approve"
+	MembershipRequestActionDeny    = "deny"
+
+	// PostPropsMembershipRequestId holds the request reviewed through a post.
+	PostPropsMembershipRequestId = "membership_request_id"
+)
+
+// MembershipRequest is the request of a user to join a private channel, or a
+// team they aren't allowed to join by themselves. A request without a channel
+// is for the team. Requests are reviewed by the channel or team admins, and
+// expire if they aren't reviewed in time.
+type MembershipRequest struct {
+	Id            string      `json:"id"`
+	UserId        string      `json:"user_id"`
+	TeamId        string      `json:"team_id"`
+	ChannelId     string      `json:"channel_id"`
+	Justification string      `json:"justification"`
+	Status        string      `json:"status"`
+	ReviewerId    string      `json:"reviewer_id"`
+	PostIds       StringArray `json:"-"`
+	CreateAt      int64       `json:"create_at"`
+	UpdateAt      int64       `json:"update_at"`
+	ExpiresAt     int64       `json:"expires_at"`
+}
+
+func (r *MembershipRequest) Auditable() map[string]any {
+	return map[string]any{
+		"id":          r.Id,
+		"user_id":     r.UserId,
+		"team_id":     r.TeamId,
+		"channel_id":  r.ChannelId,
+		"status":      r.Status,
+		"reviewer_id": r.ReviewerId,
+		"create_at":   r.CreateAt,
+		"update_at":   r.UpdateAt,
+		"expires_at":  r.ExpiresAt,
+	}
+}
+
+func (r *MembershipRequest) PreSave() {
+	if r.Id == "" {
+		r.Id = NewId()
+	}
+
+	if r.Status == "" {
+		r.Status = MembershipRequestStatusPending
+	}
+
+	if r.PostIds == nil {
+		r.PostIds = StringArray{}
+	}
+
+	if r.CreateAt == 0 {
+		r.CreateAt = GetMillis()
+	}
+	r.UpdateAt = r.CreateAt
+}
+
+func (r *MembershipRequest) IsValid() *AppError {
+	if !IsValidId(r.Id) {
+		return NewAppError("MembershipRequest.IsValid", "model.membership_request.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.UserId) {
+		return NewAppError("MembershipRequest.IsValid", "model.membership_request.is_valid.user_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.TeamId) {
+		return NewAppError("MembershipRequest.IsValid", "model.membership_request.is_valid.team_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.ChannelId != "" && !IsValidId(r.ChannelId) {
+		return NewAppError("MembershipRequest.IsValid", "model.membership_request.is_valid.channel_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(r.Justification) > MembershipRequestJustificationMaxRunes {
+		return NewAppError("MembershipRequest.IsValid", "model.membership_request.is_valid.justification.app_error", map[string]any{"MaxLength": MembershipRequestJustificationMaxRunes}, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	switch r.Status {
+	case MembershipRequestStatusPending, MembershipRequestStatusApproved, MembershipRequestStatusDenied,
+		MembershipRequestStatusCancelled, MembershipRequestStatusExpired:
+	default:
+		return NewAppError("MembershipRequest.IsValid", "model.membership_request.is_valid.status.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.ReviewerId != "" && !IsValidId(r.ReviewerId) {
+		return NewAppError("MembershipRequest.IsValid", "model.membership_request.is_valid.reviewer_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.CreateAt == 0 || r.UpdateAt == 0 {
+		return NewAppError("MembershipRequest.IsValid", "model.membership_request.is_valid.create_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.ExpiresAt <= r.CreateAt {
+		return NewAppError("MembershipRequest.IsValid", "model.membership_request.is_valid.expires_at.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsForChannel returns whether the request is to join a channel, rather than a team.
+func (r *MembershipRequest) IsForChannel() bool {
+	return r.ChannelId != ""
+}
+
+func (r *MembershipRequest) IsPending() bool {
+	return r.Status == MembershipRequestStatusPending
+}
+
+// CreateMembershipRequest is the body of the request to ask to join a
+// private channel or a team.
+type CreateMembershipRequest struct {
+	TeamId        string `json:"team_id"`
+	ChannelId     string `json:"channel_id"`
+	Justification string `json:"justification"`
+}
+
+func (r *CreateMembershipRequest) Auditable() map[string]any {
+	return map[string]any{
+		"team_id":    r.TeamId,
+		"channel_id": r.ChannelId,
+	}
+}
+
+type MembershipRequestGetOptions struct {
+	UserId    string
+	TeamId    string
+	ChannelId string
+	// TeamOnly excludes the requests to join the channels of the team.
+	TeamOnly bool
+	Status   string
+	// ExpiresBefore filters for the requests expiring before the given time.
+	ExpiresBefore int64
+	Page          int
+	PerPage       int
+}
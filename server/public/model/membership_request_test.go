@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMembershipRequestPreSave(t *testing.T) {
+	r := MembershipRequest{}
+	r.PreSave()
+
+	assert.True(t, IsValidId(r.Id))
+	assert.Equal(t, MembershipRequestStatusPending, r.Status)
+	assert.NotNil(t, r.PostIds)
+	assert.NotZero(t, r.CreateAt)
+	assert.Equal(t, r.CreateAt, r.UpdateAt)
+
+	createAt := r.CreateAt - 1000
+	r = MembershipRequest{CreateAt: createAt, Status: MembershipRequestStatusApproved}
+	r.PreSave()
+
+	assert.Equal(t, createAt, r.CreateAt)
+	assert.Equal(t, createAt, r.UpdateAt)
+	assert.Equal(t, MembershipRequestStatusApproved, r.Status)
+}
+
+func TestMembershipRequestIsValid(t *testing.T) {
+	newRequest := func() *MembershipRequest {
+		r := &MembershipRequest{
+			UserId:    NewId(),
+			TeamId:    NewId(),
+			ChannelId: NewId(),
+		}
+		r.PreSave()
+		r.ExpiresAt = r.CreateAt + 1000
+		return r
+	}
+
+	require.Nil(t, newRequest().IsValid())
+
+	t.Run("team request", func(t *testing.T) {
+		r := newRequest()
+		r.ChannelId = ""
+		require.Nil(t, r.IsValid())
+		assert.False(t, r.IsForChannel())
+	})
+
+	for name, tc := range map[string]struct {
+		update func(r *MembershipRequest)
+		errID  string
+	}{
+		"invalid id": {
+			update: func(r *MembershipRequest) { r.Id = "" },
+			errID:  "model.membership_request.is_valid.id.app_error",
+		},
+		"invalid user id": {
+			update: func(r *MembershipRequest) { r.UserId = "junk" },
+			errID:  "model.membership_request.is_valid.user_id.app_error",
+		},
+		"missing team id": {
+			update: func(r *MembershipRequest) { r.TeamId = "" },
+			errID:  "model.membership_request.is_valid.team_id.app_error",
+		},
+		"invalid channel id": {
+			update: func(r *MembershipRequest) { r.ChannelId = "junk" },
+			errID:  "model.membership_request.is_valid.channel_id.app_error",
+		},
+		"justification too long": {
+			update: func(r *MembershipRequest) {
+				r.Justification = strings.Repeat("é", MembershipRequestJustificationMaxRunes+1)
+			},
+			errID: "model.membership_request.is_valid.justification.app_error",
+		},
+		"invalid status": {
+			update: func(r *MembershipRequest) { r.Status = "unknown" },
+			errID:  "model.membership_request.is_valid.status.app_error",
+		},
+		"invalid reviewer id": {
+			update: func(r *MembershipRequest) { r.ReviewerId = "junk" },
+			errID:  "model.membership_request.is_valid.reviewer_id.app_error",
+		},
+		"missing create at": {
+			update: func(r *MembershipRequest) { r.CreateAt = 0 },
+			errID:  "model.membership_request.is_valid.create_at.app_error",
+		},
+		"expires before creation": {
+			update: func(r *MembershipRequest) { r.ExpiresAt = r.CreateAt },
+			errID:  "model.membership_request.is_valid.expires_at.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := newRequest()
+			tc.update(r)
+			appErr := r.IsValid()
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.errID, appErr.Id)
+		})
+	}
+
+	t.Run("justification of max length", func(t *testing.T) {
+		r := newRequest()
+		r.Justification = strings.Repeat("é", MembershipRequestJustificationMaxRunes)
+		require.Nil(t, r.IsValid())
+	})
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+const (
+	MfaRecoveryCodeCount  = 10
+	MfaRecoveryCodeLength = 10
+
+	// MfaTrustedDeviceCookie holds the token of a device trusted to skip MFA on login.
+	MfaTrustedDeviceCookie = "MMMFATRUSTED"
+
+	MfaTrustedDeviceDaysMax = 90
+
+	mfaTrustedDeviceTokenPrefix = "trusted_device:"
+)
+
+// MfaRecoveryCode is a one-time code a user can enter in place of an MFA code, e.g. after losing
+// the phone with their authenticator app. Only a hash of the code is stored.
+type MfaRecoveryCode struct {
+	Id       string `json:"id"`
+	UserId   string `json:"user_id"`
+	CodeHash string `json:"-"`
+	CreateAt int64  `json:"create_at"`
+	UsedAt   int64  `json:"used_at"`
+}
+
+func (c *MfaRecoveryCode) PreSave() {
+	if c.Id == "" {
+		c.Id = NewId()
+	}
+
+	if c.CreateAt == 0 {
+		c.CreateAt = GetMillis()
+	}
+}
+
+func (c *MfaRecoveryCode) IsValid() *AppError {
+	if !IsValidId(c.Id) {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(c.UserId) {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.user_id.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CodeHash == "" || len(c.CodeHash) > 128 {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.code_hash.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CreateAt == 0 {
+		return NewAppError("MfaRecoveryCode.IsValid", "model.mfa_recovery_code.is_valid.create_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// MfaRecoveryCodes are newly generated recovery codes, shown to the user only once.
+type MfaRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// NewMfaRecoveryCode returns a random recovery code, formatted for display as two groups of
+// five characters.
+func NewMfaRecoveryCode() string {
+	code := NewRandomString(MfaRecoveryCodeLength)
+	return code[:MfaRecoveryCodeLength/2] + "-" + code[MfaRecoveryCodeLength/2:]
+}
+
+// NormalizeMfaRecoveryCode strips the formatting of a recovery code as entered by a user,
+// reporting false if it can't be a recovery code, such as for a TOTP code.
+func NormalizeMfaRecoveryCode(code string) (string, bool) {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	if len(code) != MfaRecoveryCodeLength {
+		return "", false
+	}
+
+	for _, r := range code {
+		if !strings.ContainsRune("ybndrfg8ejkmcpqxot1uwisza345h769", r) {
+			return "", false
+		}
+	}
+
+	return code, true
+}
+
+// MfaTrustedDevice is a device a user chose to trust after logging in with MFA, so that logging in
+// again from it, with the same device ID, doesn't require an MFA code until it expires. Browsers,
+// which have no device ID, are trusted with an empty one. Only a hash of the token held by the
+// device is stored.
+type MfaTrustedDevice struct {
+	Id        string `json:"id"`
+	UserId    string `json:"user_id"`
+	DeviceId  string `json:"device_id"`
+	TokenHash string `json:"-"`
+	CreateAt  int64  `json:"create_at"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+func (d *MfaTrustedDevice) PreSave() {
+	if d.Id == "" {
+		d.Id = NewId()
+	}
+
+	if d.CreateAt == 0 {
+		d.CreateAt = GetMillis()
+	}
+}
+
+func (d *MfaTrustedDevice) IsValid() *AppError {
+	if !IsValidId(d.Id) {
+		return NewAppError("MfaTrustedDevice.IsValid", "model.mfa_trusted_device.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(d.UserId) {
+		return NewAppError("MfaTrustedDevice.IsValid", "model.mfa_trusted_device.is_valid.user_id.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if len(d.DeviceId) > 512 {
+		return NewAppError("MfaTrustedDevice.IsValid", "model.mfa_trusted_device.is_valid.device_id.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if d.TokenHash == "" || len(d.TokenHash) > 128 {
+		return NewAppError("MfaTrustedDevice.IsValid", "model.mfa_trusted_device.is_valid.token_hash.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	if d.CreateAt == 0 || d.ExpiresAt <= d.CreateAt {
+		return NewAppError("MfaTrustedDevice.IsValid", "model.mfa_trusted_device.is_valid.expires_at.app_error", nil, "id="+d.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// MfaTrustedDeviceMfaToken encodes the token of a trusted device so that it can be sent in place
+// of an MFA code when logging in from that device.
+func MfaTrustedDeviceMfaToken(token, deviceID string) string {
+	return mfaTrustedDeviceTokenPrefix + token + ":" + deviceID
+}
+
+// MfaTrustedDeviceFromMfaToken decodes the token and device ID of a trusted device sent as an MFA
+// token, reporting false when the token is something else.
+func MfaTrustedDeviceFromMfaToken(mfaToken string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(mfaToken, mfaTrustedDeviceTokenPrefix)
+	if !ok {
+		return "", "", false
+	}
+
+	token, deviceID, ok := strings.Cut(rest, ":")
+	if !ok || len(token) != TokenSize {
+		return "", "", false
+	}
+
+	return token, deviceID, true
+}
+
+// HashMfaTrustedDeviceToken returns the hash under which the token of a trusted device is stored.
+func HashMfaTrustedDeviceToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
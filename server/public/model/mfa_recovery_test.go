@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeMfaRecoveryCode(t *testing.T) {
+	code := NewMfaRecoveryCode()
+	require.Len(t, code, MfaRecoveryCodeLength+1)
+	assert.Equal(t, "-", code[5:6])
+
+	normalized, ok := NormalizeMfaRecoveryCode(code)
+	require.True(t, ok)
+	assert.Equal(t, strings.ReplaceAll(code, "-", ""), normalized)
+
+	normalized, ok = NormalizeMfaRecoveryCode(" " + strings.ToUpper(code) + " ")
+	require.True(t, ok)
+	assert.Equal(t, strings.ReplaceAll(code, "-", ""), normalized)
+
+	for _, token := range []string{"", "123456", "ybndr-fg8e", "ybndr-fg8ejl", "ybndr-fg8e!"} {
+		_, ok := NormalizeMfaRecoveryCode(token)
+		assert.False(t, ok, token)
+	}
+}
+
+func TestMfaTrustedDeviceMfaToken(t *testing.T) {
+	token := NewRandomString(TokenSize)
+
+	for _, deviceID := range []string{"", "android_rn-v2:abc:def"} {
+		gotToken, gotDeviceID, ok := MfaTrustedDeviceFromMfaToken(MfaTrustedDeviceMfaToken(token, deviceID))
+		require.True(t, ok)
+		assert.Equal(t, token, gotToken)
+		assert.Equal(t, deviceID, gotDeviceID)
+	}
+
+	for _, mfaToken := range []string{"", "123456", "trusted_device:short:device", "trusted_device:" + token} {
+		_, _, ok := MfaTrustedDeviceFromMfaToken(mfaToken)
+		assert.False(t, ok, mfaToken)
+	}
+
+	assert.Len(t, HashMfaTrustedDeviceToken(token), 64)
+	assert.NotEqual(t, HashMfaTrustedDeviceToken(token), HashMfaTrustedDeviceToken(NewRandomString(TokenSize)))
+}
+
+func TestMfaTrustedDeviceIsValid(t *testing.T) {
+	device := &MfaTrustedDevice{
+		UserId:    NewId(),
+		TokenHash: HashMfaTrustedDeviceToken(NewRandomString(TokenSize)),
+	}
+	device.PreSave()
+	device.ExpiresAt = device.CreateAt + 1
+	require.Nil(t, device.IsValid())
+
+	device.DeviceId = strings.Repeat("a", 513)
+	assert.NotNil(t, device.IsValid())
+	device.DeviceId = ""
+
+	device.ExpiresAt = device.CreateAt
+	assert.NotNil(t, device.IsValid())
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strings"
 	"unicode/utf8"
 )
 
@@ -42,6 +43,10 @@ type OAuthApp struct {
 	IsTrusted       bool        `json:"is_trusted"`
 	MattermostAppID string      `json:"mattermost_app_id"`
 
+	// Scopes restricts the access tokens issued to the app. No scopes means the app may be
+	// granted full access.
+	Scopes StringArray `json:"scopes"`
+
 	IsDynamicallyRegistered bool `json:"is_dynamically_registered,omitempty"`
 }
 
@@ -54,6 +59,7 @@ type OAuthAppRequest struct {
 	Homepage     string      `json:"homepage"`
 	IsTrusted    bool        `json:"is_trusted"`
 	IsPublic     bool        `json:"is_public"`
+	Scopes       StringArray `json:"scopes"`
 }
 
 func (a *OAuthApp) Auditable() map[string]any {
@@ -70,6 +76,7 @@ func (a *OAuthApp) Auditable() map[string]any {
 		"is_trusted":                 a.IsTrusted,
 		"mattermost_app_id":          a.MattermostAppID,
 		"token_endpoint_auth_method": a.GetTokenEndpointAuthMethod(),
+		"scopes":                     a.Scopes,
 		"is_dynamically_registered":  a.IsDynamicallyRegistered,
 	}
 }
@@ -132,6 +139,10 @@ func (a *OAuthApp) IsValid() *AppError {
 		return NewAppError("OAuthApp.IsValid", "model.oauth.is_valid.mattermost_app_id.app_error", nil, "app_id="+a.Id, http.StatusBadRequest)
 	}
 
+	if err := ValidateOAuthScopes("OAuthApp.IsValid", a.Scopes); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -145,6 +156,10 @@ func (a *OAuthApp) PreSave() {
 	// PreSave no longer generates client secrets - callers must explicitly set ClientSecret
 	// if they want to create a confidential client
 
+	if a.Scopes == nil {
+		a.Scopes = StringArray{}
+	}
+
 	a.CreateAt = GetMillis()
 	a.UpdateAt = a.CreateAt
 }
@@ -152,6 +167,10 @@ func (a *OAuthApp) PreSave() {
 // PreUpdate should be run before updating the app in the db.
 func (a *OAuthApp) PreUpdate() {
 	a.UpdateAt = GetMillis()
+
+	if a.Scopes == nil {
+		a.Scopes = StringArray{}
+	}
 }
 
 // Generate a valid strong etag so the browser can cache the results
@@ -168,6 +187,24 @@ func (a *OAuthApp) IsValidRedirectURL(url string) bool {
 	return slices.Contains(a.CallbackUrls, url)
 }
 
+// GrantScopes returns the scopes to grant for the space-delimited scope parameter of an
+// authorization request. Unknown scopes are ignored, and requesting no known scope, or the legacy
+// "user" scope, is granted all the scopes of the app.
+func (a *OAuthApp) GrantScopes(scope string) (StringArray, *AppError) {
+	requested := ParseKnownOAuthScope(scope)
+	if len(requested) == 0 {
+		return a.Scopes, nil
+	}
+
+	for _, s := range requested {
+		if len(a.Scopes) > 0 && !OAuthScopesAllow(a.Scopes, s) {
+			return nil, NewAppError("OAuthApp.GrantScopes", "model.oauth.grant_scopes.not_allowed.app_error", map[string]any{"Scope": s}, "app_id="+a.Id, http.StatusBadRequest)
+		}
+	}
+
+	return requested, nil
+}
+
 // GetTokenEndpointAuthMethod returns the OAuth token endpoint authentication method
 // based on whether the client has a secret
 func (a *OAuthApp) GetTokenEndpointAuthMethod() string {
@@ -190,6 +227,15 @@ func (a *OAuthApp) ValidateForGrantType(grantType, clientSecret, codeVerifier st
 	return a.validateConfidentialClientGrant(grantType, clientSecret)
 }
 
+// Authenticate checks the credentials a client presents to the introspection and revocation
+// endpoints. Public clients have no secret to present.
+func (a *OAuthApp) Authenticate(clientSecret string) *AppError {
+	if a.IsPublicClient() {
+		return a.validatePublicClientGrant("", clientSecret, "")
+	}
+	return a.validateConfidentialClientGrant("", clientSecret)
+}
+
 // validatePublicClientGrant validates that public client requests follow OAuth 2.1 security requirements
 func (a *OAuthApp) validatePublicClientGrant(grantType, clientSecret, codeVerifier string) *AppError {
 	// Public clients must not provide a client secret
@@ -202,6 +248,11 @@ func (a *OAuthApp) validatePublicClientGrant(grantType, clientSecret, codeVerifi
 		return NewAppError("OAuthApp.validatePublicClientGrant", "model.oauth.validate_grant.public_client_refresh_token.app_error", nil, "app_id="+a.Id, http.StatusBadRequest)
 	}
 
+	// Public clients can't authenticate, so they can't act on their own behalf
+	if grantType == GrantTypeClientCredentials {
+		return NewAppError("OAuthApp.validatePublicClientGrant", "model.oauth.validate_grant.public_client_credentials.app_error", nil, "app_id="+a.Id, http.StatusBadRequest)
+	}
+
 	// Public clients must use PKCE for authorization code grant
 	if grantType == AccessTokenGrantType && codeVerifier == "" {
 		return NewAppError("OAuthApp.validatePublicClientGrant", "model.oauth.validate_grant.pkce_required.app_error", nil, "app_id="+a.Id, http.StatusBadRequest)
@@ -247,6 +298,10 @@ func NewOAuthAppFromClientRegistration(req *ClientRegistrationRequest, creatorId
 		app.Homepage = *req.ClientURI
 	}
 
+	if req.Scope != nil {
+		app.Scopes = ParseKnownOAuthScope(*req.Scope)
+	}
+
 	return app
 }
 
@@ -260,6 +315,10 @@ func (a *OAuthApp) ToClientRegistrationResponse(siteURL string) *ClientRegistrat
 		Scope:                   ScopeUser,
 	}
 
+	if len(a.Scopes) > 0 {
+		resp.Scope = strings.Join(a.Scopes, " ")
+	}
+
 	if !a.IsPublicClient() {
 		resp.ClientSecret = &a.ClientSecret
 	}
@@ -13,6 +13,7 @@ type ClientRegistrationRequest struct {
 	TokenEndpointAuthMethod *string  `json:"token_endpoint_auth_method,omitempty"`
 	ClientName              *string  `json:"client_name,omitempty"`
 	ClientURI               *string  `json:"client_uri,omitempty"`
+	Scope                   *string  `json:"scope,omitempty"`
 }
 
 type ClientRegistrationResponse struct {
@@ -74,6 +74,19 @@ func TestNewOAuthAppFromClientRegistration(t *testing.T) {
 
 		require.Empty(t, app.ClientSecret)
 	})
+
+	t.Run("UnknownScopes", func(t *testing.T) {
+		req := &ClientRegistrationRequest{
+			RedirectURIs: []string{"https://example.com/callback"},
+			Scope:        NewPointer("openid profile posts:read offline_access users:read"),
+		}
+
+		app := NewOAuthAppFromClientRegistration(req, NewId())
+		require.Equal(t, StringArray{OAuthScopePostsRead, OAuthScopeUsersRead}, app.Scopes)
+
+		app.PreSave()
+		require.Nil(t, app.IsValid())
+	})
 }
 
 func TestRedirectURIMatchesGlob(t *testing.T) {
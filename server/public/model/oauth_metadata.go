@@ -15,11 +15,17 @@ type AuthorizationServerMetadata struct {
 	GrantTypesSupported               []string `json:"grant_types_supported,omitempty"`
 	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported,omitempty"`
 	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported,omitempty"`
+
+	IntrospectionEndpoint                     string   `json:"introspection_endpoint,omitempty"`
+	IntrospectionEndpointAuthMethodsSupported []string `json:"introspection_endpoint_auth_methods_supported,omitempty"`
+	RevocationEndpoint                        string   `json:"revocation_endpoint,omitempty"`
+	RevocationEndpointAuthMethodsSupported    []string `json:"revocation_endpoint_auth_methods_supported,omitempty"`
 }
 
 const (
 	GrantTypeAuthorizationCode = "authorization_code"
 	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
 
 	ResponseTypeCode = "code"
 
@@ -35,6 +41,8 @@ const (
 	OAuthDeauthorizeEndpoint  = "/oauth/deauthorize"
 	OAuthAppsRegisterEndpoint = "/api/v4/oauth/apps/register"
 	OAuthMetadataEndpoint     = "/.well-known/oauth-authorization-server"
+	OAuthIntrospectEndpoint   = "/oauth/introspect"
+	OAuthRevokeEndpoint       = "/oauth/revoke"
 )
 
 func GetDefaultMetadata(siteURL string) (*AuthorizationServerMetadata, error) {
@@ -46,6 +54,14 @@ func GetDefaultMetadata(siteURL string) (*AuthorizationServerMetadata, error) {
 	if err != nil {
 		return nil, err
 	}
+	introspectionEndpoint, err := url.JoinPath(siteURL, OAuthIntrospectEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	revocationEndpoint, err := url.JoinPath(siteURL, OAuthRevokeEndpoint)
+	if err != nil {
+		return nil, err
+	}
 	return &AuthorizationServerMetadata{
 		Issuer:                siteURL,
 		AuthorizationEndpoint: authorizationEndpoint,
@@ -56,16 +72,26 @@ func GetDefaultMetadata(siteURL string) (*AuthorizationServerMetadata, error) {
 		GrantTypesSupported: []string{
 			GrantTypeAuthorizationCode,
 			GrantTypeRefreshToken,
+			GrantTypeClientCredentials,
 		},
 		TokenEndpointAuthMethodsSupported: []string{
 			ClientAuthMethodNone,             // Public clients (PKCE)
 			ClientAuthMethodClientSecretPost, // Confidential clients
 		},
-		ScopesSupported: []string{
+		ScopesSupported: append([]string{
 			ScopeUser,
-		},
+		}, AllOAuthScopes()...),
 		CodeChallengeMethodsSupported: []string{
 			PKCECodeChallengeMethodS256, // S256 method supported for optional PKCE
 		},
+		IntrospectionEndpoint: introspectionEndpoint,
+		IntrospectionEndpointAuthMethodsSupported: []string{
+			ClientAuthMethodClientSecretPost, // Only confidential clients may introspect tokens
+		},
+		RevocationEndpoint: revocationEndpoint,
+		RevocationEndpointAuthMethodsSupported: []string{
+			ClientAuthMethodNone,
+			ClientAuthMethodClientSecretPost,
+		},
 	}, nil
 }
@@ -0,0 +1,54 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOAuthScope(t *testing.T) {
+	assert.Empty(t, ParseOAuthScope(""))
+	assert.Empty(t, ParseOAuthScope(ScopeUser))
+	assert.Equal(t, StringArray{OAuthScopePostsRead, OAuthScopeUsersRead}, ParseOAuthScope(" posts:read user  users:read posts:read "))
+}
+
+func TestValidateOAuthScopes(t *testing.T) {
+	require.Nil(t, ValidateOAuthScopes("test", nil))
+	require.Nil(t, ValidateOAuthScopes("test", AllOAuthScopes()))
+
+	appErr := ValidateOAuthScopes("test", []string{OAuthScopePostsRead, "posts:admin"})
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.oauth_scope.is_valid.unknown.app_error", appErr.Id)
+
+	appErr = ValidateOAuthScopes("test", []string{strings.Repeat("a", oauthScopesMaxLength+1)})
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.oauth_scope.is_valid.length.app_error", appErr.Id)
+}
+
+func TestOAuthScopesAllow(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Granted     []string
+		Required    string
+		Allowed     bool
+	}{
+		{"No scopes grant full access", nil, OAuthScopeChannelsManage, true},
+		{"Exact scope", []string{OAuthScopeUsersRead}, OAuthScopeUsersRead, true},
+		{"Other scope", []string{OAuthScopeUsersRead}, OAuthScopePostsRead, false},
+		{"Write implies read", []string{OAuthScopePostsWrite}, OAuthScopePostsRead, true},
+		{"Read doesn't imply write", []string{OAuthScopePostsRead}, OAuthScopePostsWrite, false},
+		{"Manage implies read", []string{OAuthScopeChannelsManage}, OAuthScopeChannelsRead, true},
+		{"Empty requirement with scopes", []string{OAuthScopePostsRead}, "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert.Equal(t, tc.Allowed, OAuthScopesAllow(tc.Granted, tc.Required))
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package model
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -122,3 +123,77 @@ func TestOAuthAppIsValid(t *testing.T) {
 		require.Equal(t, ClientAuthMethodClientSecretPost, app.GetTokenEndpointAuthMethod())
 	})
 }
+
+func TestOAuthAppGrantScopes(t *testing.T) {
+	t.Run("unscoped app", func(t *testing.T) {
+		app := &OAuthApp{Id: NewId()}
+
+		scopes, appErr := app.GrantScopes("")
+		require.Nil(t, appErr)
+		require.Empty(t, scopes)
+
+		scopes, appErr = app.GrantScopes("posts:write users:read")
+		require.Nil(t, appErr)
+		require.Equal(t, StringArray{OAuthScopePostsWrite, OAuthScopeUsersRead}, scopes)
+
+		scopes, appErr = app.GrantScopes("openid profile email")
+		require.Nil(t, appErr)
+		require.Empty(t, scopes)
+
+		scopes, appErr = app.GrantScopes("openid posts:read posts:admin")
+		require.Nil(t, appErr)
+		require.Equal(t, StringArray{OAuthScopePostsRead}, scopes)
+	})
+
+	t.Run("scoped app", func(t *testing.T) {
+		app := &OAuthApp{Id: NewId(), Scopes: StringArray{OAuthScopePostsWrite, OAuthScopeUsersRead}}
+
+		scopes, appErr := app.GrantScopes(ScopeUser)
+		require.Nil(t, appErr)
+		require.Equal(t, app.Scopes, scopes)
+
+		scopes, appErr = app.GrantScopes("posts:read")
+		require.Nil(t, appErr)
+		require.Equal(t, StringArray{OAuthScopePostsRead}, scopes)
+
+		scopes, appErr = app.GrantScopes("openid offline_access")
+		require.Nil(t, appErr)
+		require.Equal(t, app.Scopes, scopes)
+
+		_, appErr = app.GrantScopes("posts:read channels:manage")
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.oauth.grant_scopes.not_allowed.app_error", appErr.Id)
+	})
+}
+
+func TestOAuthAppAuthenticate(t *testing.T) {
+	t.Run("confidential client", func(t *testing.T) {
+		app := &OAuthApp{Id: NewId(), ClientSecret: NewId()}
+
+		require.Nil(t, app.Authenticate(app.ClientSecret))
+
+		appErr := app.Authenticate("")
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+
+		require.NotNil(t, app.Authenticate(NewId()))
+	})
+
+	t.Run("public client", func(t *testing.T) {
+		app := &OAuthApp{Id: NewId()}
+
+		require.Nil(t, app.Authenticate(""))
+		require.NotNil(t, app.Authenticate(NewId()))
+	})
+}
+
+func TestOAuthAppValidateForClientCredentials(t *testing.T) {
+	app := &OAuthApp{Id: NewId(), ClientSecret: NewId()}
+	require.Nil(t, app.ValidateForGrantType(GrantTypeClientCredentials, app.ClientSecret, ""))
+	require.NotNil(t, app.ValidateForGrantType(GrantTypeClientCredentials, "", ""))
+
+	publicApp := &OAuthApp{Id: NewId()}
+	appErr := publicApp.ValidateForGrantType(GrantTypeClientCredentials, "", "")
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.oauth.validate_grant.public_client_credentials.app_error", appErr.Id)
+}
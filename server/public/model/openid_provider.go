@@ -0,0 +1,197 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	// ServiceOpenidProviderPrefix prefixes the service of the OpenID Connect
+	// providers configured in OpenIdProviderSettings, which is also the auth
+	// service of their users and the source of their groups.
+	ServiceOpenidProviderPrefix = "openid_"
+
+	// OpenIdProviderNameMaxLength keeps the service of a provider within the
+	// length of the AuthService column of the users.
+	OpenIdProviderNameMaxLength = 25
+
+	OpenIdProviderDefaultButtonColor    = "#145DBF"
+	OpenIdProviderDefaultUsernameClaim  = "preferred_username"
+	OpenIdProviderDefaultEmailClaim     = "email"
+	OpenIdProviderDefaultFirstNameClaim = "given_name"
+	OpenIdProviderDefaultLastNameClaim  = "family_name"
+)
+
+var validOpenIdProviderName = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// OpenIdProvider configures a standards based OpenID Connect identity
+// provider. Several providers can be enabled at once, each with its own
+// login button, and each maps the claims of its users to their profile.
+type OpenIdProvider struct {
+	Name              *string `access:"authentication_openid"`
+	Enable            *bool   `access:"authentication_openid"`
+	ButtonText        *string `access:"authentication_openid"` // telemetry: none
+	ButtonColor       *string `access:"authentication_openid"` // telemetry: none
+	Id                *string `access:"authentication_openid"` // telemetry: none
+	Secret            *string `access:"authentication_openid"` // telemetry: none
+	DiscoveryEndpoint *string `access:"authentication_openid"` // telemetry: none
+	Scope             *string `access:"authentication_openid"` // telemetry: none
+	EnablePKCE        *bool   `access:"authentication_openid"`
+
+	UsernameClaim  *string `access:"authentication_openid"` // telemetry: none
+	EmailClaim     *string `access:"authentication_openid"` // telemetry: none
+	FirstNameClaim *string `access:"authentication_openid"` // telemetry: none
+	LastNameClaim  *string `access:"authentication_openid"` // telemetry: none
+	PositionClaim  *string `access:"authentication_openid"` // telemetry: none
+
+	// GroupsClaim names the claim listing the groups of the user, whose
+	// memberships are synchronized on every login.
+	GroupsClaim *string `access:"authentication_openid"` // telemetry: none
+
+	// AttributeClaims maps the names of custom profile attributes to the
+	// claims holding their values.
+	AttributeClaims map[string]string `access:"authentication_openid"` // telemetry: none
+}
+
+func (p *OpenIdProvider) SetDefaults() {
+	if p.Name == nil {
+		p.Name = NewPointer("")
+	}
+
+	if p.Enable == nil {
+		p.Enable = NewPointer(false)
+	}
+
+	if p.ButtonText == nil {
+		p.ButtonText = NewPointer("")
+	}
+
+	if p.ButtonColor == nil {
+		p.ButtonColor = NewPointer(OpenIdProviderDefaultButtonColor)
+	}
+
+	if p.Id == nil {
+		p.Id = NewPointer("")
+	}
+
+	if p.Secret == nil {
+		p.Secret = NewPointer("")
+	}
+
+	if p.DiscoveryEndpoint == nil {
+		p.DiscoveryEndpoint = NewPointer("")
+	}
+
+	if p.Scope == nil {
+		p.Scope = NewPointer(OpenidSettingsDefaultScope)
+	}
+
+	if p.EnablePKCE == nil {
+		p.EnablePKCE = NewPointer(true)
+	}
+
+	if p.UsernameClaim == nil {
+		p.UsernameClaim = NewPointer(OpenIdProviderDefaultUsernameClaim)
+	}
+
+	if p.EmailClaim == nil {
+		p.EmailClaim = NewPointer(OpenIdProviderDefaultEmailClaim)
+	}
+
+	if p.FirstNameClaim == nil {
+		p.FirstNameClaim = NewPointer(OpenIdProviderDefaultFirstNameClaim)
+	}
+
+	if p.LastNameClaim == nil {
+		p.LastNameClaim = NewPointer(OpenIdProviderDefaultLastNameClaim)
+	}
+
+	if p.PositionClaim == nil {
+		p.PositionClaim = NewPointer("")
+	}
+
+	if p.GroupsClaim == nil {
+		p.GroupsClaim = NewPointer("")
+	}
+
+	if p.AttributeClaims == nil {
+		p.AttributeClaims = make(map[string]string)
+	}
+}
+
+func (p *OpenIdProvider) isValid() *AppError {
+	name := SafeDereference(p.Name)
+	if len(name) > OpenIdProviderNameMaxLength || !validOpenIdProviderName.MatchString(name) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_name.app_error", map[string]any{"Name": name, "MaxLength": OpenIdProviderNameMaxLength}, "", http.StatusBadRequest)
+	}
+
+	if !SafeDereference(p.Enable) {
+		return nil
+	}
+
+	if SafeDereference(p.Id) == "" || SafeDereference(p.Secret) == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_credentials.app_error", map[string]any{"Name": name}, "", http.StatusBadRequest)
+	}
+
+	if !IsValidHTTPURL(SafeDereference(p.DiscoveryEndpoint)) {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_discovery_endpoint.app_error", map[string]any{"Name": name}, "", http.StatusBadRequest)
+	}
+
+	if !strings.Contains(SafeDereference(p.Scope), "openid") {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_scope.app_error", map[string]any{"Name": name}, "", http.StatusBadRequest)
+	}
+
+	if SafeDereference(p.EmailClaim) == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_email_claim.app_error", map[string]any{"Name": name}, "", http.StatusBadRequest)
+	}
+
+	for attribute, claim := range p.AttributeClaims {
+		if attribute == "" || claim == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.openid_provider_attribute_claim.app_error", map[string]any{"Name": name, "Attribute": attribute}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// Service returns the OAuth service of the provider.
+func (p *OpenIdProvider) Service() string {
+	return ServiceOpenidProviderPrefix + SafeDereference(p.Name)
+}
+
+// SSOSettings returns the settings of the provider in the form shared by the
+// other OAuth services. The endpoints are left empty as they are discovered
+// from the DiscoveryEndpoint.
+func (p *OpenIdProvider) SSOSettings() *SSOSettings {
+	ssoSettings := SSOSettings{}
+	ssoSettings.Enable = p.Enable
+	ssoSettings.Secret = p.Secret
+	ssoSettings.Id = p.Id
+	ssoSettings.Scope = p.Scope
+	ssoSettings.DiscoveryEndpoint = p.DiscoveryEndpoint
+	ssoSettings.AuthEndpoint = NewPointer("")
+	ssoSettings.TokenEndpoint = NewPointer("")
+	ssoSettings.UserAPIEndpoint = NewPointer("")
+	ssoSettings.ButtonText = p.ButtonText
+	ssoSettings.ButtonColor = p.ButtonColor
+	ssoSettings.UsePreferredUsername = NewPointer(false)
+	return &ssoSettings
+}
+
+// OpenIdProviderButton describes the login button of a provider in the
+// client configuration.
+type OpenIdProviderButton struct {
+	Service     string `json:"service"`
+	ButtonText  string `json:"button_text"`
+	ButtonColor string `json:"button_color"`
+}
+
+// IsOpenIdProviderService returns whether the service is the one of a provider
+// configured in OpenIdProviderSettings.
+func IsOpenIdProviderService(service string) bool {
+	return strings.HasPrefix(service, ServiceOpenidProviderPrefix) && len(service) > len(ServiceOpenidProviderPrefix)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOpenIdProvider(name string) *OpenIdProvider {
+	provider := &OpenIdProvider{
+		Name:              NewPointer(name),
+		Enable:            NewPointer(true),
+		Id:                NewPointer("client"),
+		Secret:            NewPointer("secret"),
+		DiscoveryEndpoint: NewPointer("https://idp.example.com/.well-known/openid-configuration"),
+	}
+	provider.SetDefaults()
+	return provider
+}
+
+func TestOpenIdProviderSettings(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		settings := OpenIdProviderSettings{Providers: []*OpenIdProvider{newTestOpenIdProvider("staff"), newTestOpenIdProvider("contractors")}}
+		settings.SetDefaults()
+
+		require.Nil(t, settings.isValid())
+		assert.Equal(t, "openid_staff", settings.Providers[0].Service())
+		assert.Same(t, settings.Providers[1], settings.GetProvider("openid_contractors"))
+		assert.Nil(t, settings.GetProvider("openid_other"))
+		assert.Len(t, settings.EnabledProviders(), 2)
+	})
+
+	t.Run("disabled providers only need a name", func(t *testing.T) {
+		provider := &OpenIdProvider{Name: NewPointer("staff")}
+		provider.SetDefaults()
+
+		require.Nil(t, provider.isValid())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, mutate := range map[string]func(p *OpenIdProvider){
+			"empty name":            func(p *OpenIdProvider) { p.Name = NewPointer("") },
+			"uppercase name":        func(p *OpenIdProvider) { p.Name = NewPointer("Staff") },
+			"name too long":         func(p *OpenIdProvider) { p.Name = NewPointer("abcdefghijklmnopqrstuvwxyz") },
+			"missing secret":        func(p *OpenIdProvider) { p.Secret = NewPointer("") },
+			"invalid discovery URL": func(p *OpenIdProvider) { p.DiscoveryEndpoint = NewPointer("idp.example.com") },
+			"scope without openid":  func(p *OpenIdProvider) { p.Scope = NewPointer("profile email") },
+			"missing email claim":   func(p *OpenIdProvider) { p.EmailClaim = NewPointer("") },
+			"empty attribute claim": func(p *OpenIdProvider) { p.AttributeClaims = map[string]string{"Department": ""} },
+		} {
+			t.Run(name, func(t *testing.T) {
+				provider := newTestOpenIdProvider("staff")
+				mutate(provider)
+				assert.NotNil(t, provider.isValid())
+			})
+		}
+	})
+
+	t.Run("duplicate names", func(t *testing.T) {
+		settings := OpenIdProviderSettings{Providers: []*OpenIdProvider{newTestOpenIdProvider("staff"), newTestOpenIdProvider("staff")}}
+
+		appErr := settings.isValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.config.is_valid.openid_provider_duplicate.app_error", appErr.Id)
+	})
+}
+
+func TestOpenIdProviderServices(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{}
+	cfg.OpenIdProviderSettings.Providers = []*OpenIdProvider{newTestOpenIdProvider("staff")}
+	cfg.SetDefaults()
+
+	sso := cfg.GetSSOService("openid_staff")
+	require.NotNil(t, sso)
+	assert.Equal(t, "client", *sso.Id)
+	assert.Nil(t, cfg.GetSSOService("openid_contractors"))
+
+	assert.True(t, IsOpenIdProviderService("openid_staff"))
+	assert.False(t, IsOpenIdProviderService(ServiceOpenid))
+	assert.False(t, IsOpenIdProviderService(ServiceOpenidProviderPrefix))
+
+	assert.True(t, (&User{AuthService: "openid_staff"}).IsOAuthUser())
+	assert.True(t, (&SwitchRequest{CurrentService: UserAuthServiceEmail, NewService: "openid_staff"}).EmailToOAuth())
+	assert.True(t, (&Group{Source: GroupSource("openid_staff")}).IsSyncable())
+
+	cfg.Sanitize(nil, nil)
+	assert.Equal(t, FakeSetting, *cfg.OpenIdProviderSettings.Providers[0].Secret)
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// PasswordHasherUsage is the number of active users whose password is hashed
+// with a given hashing function and set of parameters.
+type PasswordHasherUsage struct {
+	Hasher     string `json:"hasher"`
+	Parameters string `json:"parameters"`
+	Users      int64  `json:"users"`
+
+	// Outdated is true when these passwords are migrated to the configured
+	// hasher the next time their users log in.
+	Outdated bool `json:"outdated"`
+}
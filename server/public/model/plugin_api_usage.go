@@ -0,0 +1,32 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// PluginAPIUsage describes how much a plugin has used the plugin API and the database on this
+// node since the server started. Database usage covers the KV store and direct queries made
+// through the plugin database driver.
+type PluginAPIUsage struct {
+	PluginId           string                  `json:"plugin_id"`
+	Calls              int64                   `json:"calls"`
+	RejectedCalls      int64                   `json:"rejected_calls"`
+	TimeMillis         int64                   `json:"time_millis"`
+	DatabaseCalls      int64                   `json:"database_calls"`
+	DatabaseTimeMillis int64                   `json:"database_time_millis"`
+	Methods            []*PluginAPIMethodUsage `json:"methods"`
+}
+
+// PluginAPIMethodUsage describes how much a plugin has used a single plugin API method.
+type PluginAPIMethodUsage struct {
+	Method        string `json:"method"`
+	Calls         int64  `json:"calls"`
+	RejectedCalls int64  `json:"rejected_calls"`
+	TimeMillis    int64  `json:"time_millis"`
+}
+
+// PluginAPIQuotas limits the number of plugin API calls each plugin may make per minute.
+// A zero value means no limit.
+type PluginAPIQuotas struct {
+	CallsPerMinute       int
+	MethodCallsPerMinute map[string]int
+}
@@ -5,6 +5,15 @@ package model
 
 import (
 	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	// PluginKVSetOperationsMax is the maximum number of operations of a batch.
+	PluginKVSetOperationsMax = 100
+
+	// PluginKVListLimitMax is the maximum number of key-value pairs listed at once.
+	PluginKVListLimitMax = 1000
 )
 
 // PluginKVSetOptions contains information on how to store a value in the plugin KV store.
@@ -45,3 +54,66 @@ func NewPluginKeyValueFromOptions(pluginId, key string, value []byte, opt Plugin
 
 	return kv, nil
 }
+
+// PluginKVSetOperation is a single write of a batch of writes applied atomically.
+type PluginKVSetOperation struct {
+	Key     string             // The key to write
+	Value   []byte             // The value to store, or nil to delete the key
+	Options PluginKVSetOptions // How to store the value
+
+	// CheckOnly only compares the current value with Options.OldValue, without writing. It
+	// requires Options.Atomic, and lets a batch depend on keys it doesn't write.
+	CheckOnly bool
+}
+
+// IsValid returns nil if the operation is valid.
+func (op *PluginKVSetOperation) IsValid() *AppError {
+	if op.Key == "" || utf8.RuneCountInString(op.Key) > KeyValueKeyMaxRunes {
+		return NewAppError("PluginKVSetOperation.IsValid", "model.plugin_key_value.is_valid.key.app_error", map[string]any{"Max": KeyValueKeyMaxRunes, "Min": 0}, "key="+op.Key, http.StatusBadRequest)
+	}
+
+	if op.CheckOnly && !op.Options.Atomic {
+		return NewAppError("PluginKVSetOperation.IsValid", "model.plugin_kvset_operation.is_valid.check_only.app_error", nil, "key="+op.Key, http.StatusBadRequest)
+	}
+
+	return op.Options.IsValid()
+}
+
+// IsValidPluginKVSetOperations returns nil if all operations are valid and no key is written twice.
+func IsValidPluginKVSetOperations(operations []*PluginKVSetOperation) *AppError {
+	if len(operations) == 0 || len(operations) > PluginKVSetOperationsMax {
+		return NewAppError("IsValidPluginKVSetOperations", "model.plugin_kvset_operation.is_valid.count.app_error", map[string]any{"Max": PluginKVSetOperationsMax}, "", http.StatusBadRequest)
+	}
+
+	keys := make(map[string]bool, len(operations))
+	for _, op := range operations {
+		if op == nil {
+			return NewAppError("IsValidPluginKVSetOperations", "model.plugin_kvset_operation.is_valid.count.app_error", map[string]any{"Max": PluginKVSetOperationsMax}, "", http.StatusBadRequest)
+		}
+		if err := op.IsValid(); err != nil {
+			return err
+		}
+		if keys[op.Key] {
+			return NewAppError("IsValidPluginKVSetOperations", "model.plugin_kvset_operation.is_valid.duplicate_key.app_error", nil, "key="+op.Key, http.StatusBadRequest)
+		}
+		keys[op.Key] = true
+	}
+
+	return nil
+}
+
+// PluginKVListOptions selects the key-value pairs to list, in key order.
+type PluginKVListOptions struct {
+	Prefix   string // Only list keys starting with the prefix
+	AfterKey string // Only list keys after the given key, to continue a previous listing
+	Limit    int    // The maximum number of key-value pairs to list
+}
+
+// IsValid returns nil if the chosen options are valid.
+func (opt *PluginKVListOptions) IsValid() *AppError {
+	if opt.Limit <= 0 || opt.Limit > PluginKVListLimitMax {
+		return NewAppError("PluginKVListOptions.IsValid", "model.plugin_kvlist_options.is_valid.limit.app_error", map[string]any{"Max": PluginKVListLimitMax}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
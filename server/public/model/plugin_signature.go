@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/hex"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	pluginSignatureKeyIdLength       = 16
+	pluginSignatureFingerprintLength = 40
+)
+
+// PluginSignatureKeyPolicy restricts what a public key configured in
+// PluginSettings.SignaturePublicKeyFiles is trusted to sign. Keys without a policy are trusted to
+// sign any plugin.
+type PluginSignatureKeyPolicy struct {
+	PublicKeyFile    *string  `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	AllowedPluginIds []string `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	ExpiresAt        *int64   `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+}
+
+func (p *PluginSignatureKeyPolicy) SetDefaults() {
+	if p.PublicKeyFile == nil {
+		p.PublicKeyFile = NewPointer("")
+	}
+
+	if p.AllowedPluginIds == nil {
+		p.AllowedPluginIds = []string{}
+	}
+
+	if p.ExpiresAt == nil {
+		p.ExpiresAt = NewPointer(int64(0))
+	}
+}
+
+func (p *PluginSignatureKeyPolicy) isValid() *AppError {
+	if *p.PublicKeyFile == "" || *p.ExpiresAt < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_signature_key_policy.app_error", map[string]any{"PublicKeyFile": *p.PublicKeyFile}, "", http.StatusBadRequest)
+	}
+
+	for _, id := range p.AllowedPluginIds {
+		if !IsValidPluginId(id) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.plugin_signature_key_policy.app_error", map[string]any{"PublicKeyFile": *p.PublicKeyFile}, "plugin_id="+id, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// IsExpired reports whether the key may no longer be trusted at the given time, in milliseconds
+// since the epoch.
+func (p *PluginSignatureKeyPolicy) IsExpired(now int64) bool {
+	return *p.ExpiresAt != 0 && *p.ExpiresAt <= now
+}
+
+// AllowsPlugin reports whether the key is trusted to sign the given plugin.
+func (p *PluginSignatureKeyPolicy) AllowsPlugin(pluginID string) bool {
+	return len(p.AllowedPluginIds) == 0 || slices.ContainsFunc(p.AllowedPluginIds, func(id string) bool {
+		return strings.EqualFold(id, pluginID)
+	})
+}
+
+// PluginSigner identifies the key that signed a plugin bundle.
+type PluginSigner struct {
+	// PublicKeyFile is the configured public key file containing the key, or empty for the
+	// Mattermost public key built into the server.
+	PublicKeyFile string `json:"public_key_file,omitempty"`
+
+	// KeyId is the 64-bit ID of the signing key, in upper case hexadecimal.
+	KeyId string `json:"key_id"`
+
+	// Fingerprint is the fingerprint of the primary key of the signer, in upper case
+	// hexadecimal.
+	Fingerprint string `json:"fingerprint"`
+
+	// Identities are the user IDs of the signer, e.g. "Mattermost, Inc. <support@mattermost.com>".
+	Identities []string `json:"identities"`
+}
+
+// Matches reports whether the given key ID or fingerprint identifies the signing key or the
+// primary key of the signer.
+func (s *PluginSigner) Matches(key string) bool {
+	key = normalizePluginSignatureKeyReference(key)
+	primaryKeyId := ""
+	if len(s.Fingerprint) == pluginSignatureFingerprintLength {
+		primaryKeyId = s.Fingerprint[pluginSignatureFingerprintLength-pluginSignatureKeyIdLength:]
+	}
+
+	switch len(key) {
+	case pluginSignatureKeyIdLength:
+		return key == s.KeyId || key == primaryKeyId
+	case pluginSignatureFingerprintLength:
+		return key == s.Fingerprint
+	default:
+		return false
+	}
+}
+
+// IsMattermost reports whether the plugin was signed with the Mattermost public key.
+func (s *PluginSigner) IsMattermost() bool {
+	return s.PublicKeyFile == ""
+}
+
+// Auditable returns the signer as recorded in the audit log of a plugin installation.
+func (s *PluginSigner) Auditable() map[string]any {
+	return map[string]any{
+		"public_key_file": s.PublicKeyFile,
+		"key_id":          s.KeyId,
+		"fingerprint":     s.Fingerprint,
+		"identities":      s.Identities,
+	}
+}
+
+// PluginSignatureVerification is the result of checking a plugin bundle and its signature
+// against the trust store of the server.
+type PluginSignatureVerification struct {
+	PluginId      string `json:"plugin_id"`
+	PluginVersion string `json:"plugin_version"`
+
+	// Signer is set whenever the signature matches a known key, even if the key isn't trusted
+	// for the plugin.
+	Signer *PluginSigner `json:"signer,omitempty"`
+
+	// Trusted is whether the server would install the plugin when requiring signatures.
+	Trusted bool `json:"trusted"`
+
+	// Reason explains why the plugin isn't trusted.
+	Reason string `json:"reason,omitempty"`
+}
+
+// IsValidPluginSignatureKeyReference reports whether the given string identifies a key by its
+// 64-bit key ID or its fingerprint, in hexadecimal. Spaces and a 0x prefix are ignored.
+func IsValidPluginSignatureKeyReference(key string) bool {
+	key = normalizePluginSignatureKeyReference(key)
+	if len(key) != pluginSignatureKeyIdLength && len(key) != pluginSignatureFingerprintLength {
+		return false
+	}
+
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
+func normalizePluginSignatureKeyReference(key string) string {
+	key = strings.ReplaceAll(key, " ", "")
+	key = strings.TrimPrefix(strings.TrimPrefix(key, "0x"), "0X")
+	return strings.ToUpper(key)
+}
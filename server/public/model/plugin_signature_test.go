@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginSignatureKeyPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		policy := &PluginSignatureKeyPolicy{PublicKeyFile: NewPointer("internal.plugin.gpg")}
+		policy.SetDefaults()
+
+		require.Nil(t, policy.isValid())
+		assert.False(t, policy.IsExpired(GetMillis()))
+		assert.True(t, policy.AllowsPlugin("com.mattermost.any"))
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		policy := &PluginSignatureKeyPolicy{PublicKeyFile: NewPointer("internal.plugin.gpg"), ExpiresAt: NewPointer(int64(1000))}
+		policy.SetDefaults()
+
+		assert.False(t, policy.IsExpired(999))
+		assert.True(t, policy.IsExpired(1000))
+	})
+
+	t.Run("allowed plugins", func(t *testing.T) {
+		policy := &PluginSignatureKeyPolicy{PublicKeyFile: NewPointer("internal.plugin.gpg"), AllowedPluginIds: []string{"com.example.One"}}
+		policy.SetDefaults()
+
+		require.Nil(t, policy.isValid())
+		assert.True(t, policy.AllowsPlugin("com.example.one"))
+		assert.False(t, policy.AllowsPlugin("com.example.two"))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, policy := range map[string]*PluginSignatureKeyPolicy{
+			"missing public key file": {},
+			"negative expiry":         {PublicKeyFile: NewPointer("internal.plugin.gpg"), ExpiresAt: NewPointer(int64(-1))},
+			"invalid plugin id":       {PublicKeyFile: NewPointer("internal.plugin.gpg"), AllowedPluginIds: []string{"../plugin"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				policy.SetDefaults()
+				assert.NotNil(t, policy.isValid())
+			})
+		}
+	})
+}
+
+func TestPluginSignerMatches(t *testing.T) {
+	t.Parallel()
+
+	signer := &PluginSigner{
+		KeyId:       "1111222233334444",
+		Fingerprint: "C55881B80F69E863B85AD5D1D1B54B47A5CEFEC4",
+	}
+
+	assert.True(t, signer.Matches("1111222233334444"))
+	assert.True(t, signer.Matches("d1b54b47a5cefec4"))
+	assert.True(t, signer.Matches("0xD1B54B47A5CEFEC4"))
+	assert.True(t, signer.Matches("C558 81B8 0F69 E863 B85A  D5D1 D1B5 4B47 A5CE FEC4"))
+	assert.False(t, signer.Matches("5555666677778888"))
+	assert.False(t, signer.Matches("D1B54B47"))
+}
+
+func TestIsValidPluginSignatureKeyReference(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]bool{
+		"":                   false,
+		"D1B54B47":           false,
+		"D1B54B47A5CEFEC4":   true,
+		"0xd1b54b47a5cefec4": true,
+		"C55881B80F69E863B85AD5D1D1B54B47A5CEFEC4":          true,
+		"C558 81B8 0F69 E863 B85A D5D1 D1B5 4B47 A5CE FEC4": true,
+		"G1B54B47A5CEFEC4": false,
+	}
+
+	for key, valid := range testCases {
+		t.Run(key, func(t *testing.T) {
+			assert.Equal(t, valid, IsValidPluginSignatureKeyReference(key))
+		})
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"path/filepath"
+)
+
+// PluginWatchRequest asks the server to watch a local plugin source directory and reload the
+// plugin whenever its manifest, server executable or webapp bundle changes. It is only honored
+// when developer mode is enabled.
+type PluginWatchRequest struct {
+	// Directory is the absolute path of the plugin source directory, containing the manifest.
+	Directory string `json:"directory"`
+
+	// BuildCommand, if set, is run in Directory whenever a source file changes.
+	BuildCommand []string `json:"build_command,omitempty"`
+}
+
+func (pwr *PluginWatchRequest) IsValid() *AppError {
+	if pwr.Directory == "" || !filepath.IsAbs(pwr.Directory) {
+		return NewAppError("PluginWatchRequest.IsValid", "plugin_watch_request.is_valid.directory.app_error", nil, "", http.StatusBadRequest)
+	}
+	if len(pwr.BuildCommand) > 0 && pwr.BuildCommand[0] == "" {
+		return NewAppError("PluginWatchRequest.IsValid", "plugin_watch_request.is_valid.build_command.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
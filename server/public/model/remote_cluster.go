@@ -33,6 +33,22 @@ const (
 	BitflagOptionAutoInvited                      // Remote is automatically invited to all shared channels
 )
 
+// Optional features negotiated between clusters via `RemoteClusterPing`.
+const (
+	RemoteClusterFeatureBookmarks               = "bookmarks"
+	RemoteClusterFeatureChannelInfo             = "channel_info"
+	RemoteClusterFeaturePersistentNotifications = "persistent_notifications"
+	RemoteClusterFeatureSyncCompare             = "sync_compare"
+)
+
+// RemoteClusterFeatures lists the optional features supported by this server.
+var RemoteClusterFeatures = []string{
+	RemoteClusterFeatureBookmarks,
+	RemoteClusterFeatureChannelInfo,
+	RemoteClusterFeaturePersistentNotifications,
+	RemoteClusterFeatureSyncCompare,
+}
+
 var (
 	validRemoteNameChars = regexp.MustCompile(`^[a-zA-Z0-9\.\-\_]+$`)
 
@@ -362,6 +378,10 @@ func (m RemoteClusterMsg) IsValid() *AppError {
 type RemoteClusterPing struct {
 	SentAt int64 `json:"sent_at"`
 	RecvAt int64 `json:"recv_at"`
+
+	// Features lists the optional capabilities supported by the sender. Older
+	// servers don't send this field, in which case no optional features are used.
+	Features []string `json:"features,omitempty"`
 }
 
 // RemoteClusterInvite represents an invitation to establish a simple trust with a remote cluster.
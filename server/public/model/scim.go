@@ -0,0 +1,271 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	ScimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ScimSchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	ScimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	ScimResourceTypeUser  = "User"
+	ScimResourceTypeGroup = "Group"
+
+	ScimPatchOpAdd     = "add"
+	ScimPatchOpRemove  = "remove"
+	ScimPatchOpReplace = "replace"
+
+	// The types of the SCIM errors, returned along with their status.
+	ScimErrorTypeInvalidFilter = "invalidFilter"
+	ScimErrorTypeInvalidPath   = "invalidPath"
+	ScimErrorTypeInvalidSyntax = "invalidSyntax"
+	ScimErrorTypeInvalidValue  = "invalidValue"
+	ScimErrorTypeNoTarget      = "noTarget"
+	ScimErrorTypeUniqueness    = "uniqueness"
+
+	ScimContentType = "application/scim+json"
+
+	// ScimURLPath is the path of the SCIM endpoints, relative to the API.
+	ScimURLPath = "/scim/v2"
+
+	ScimDefaultCount = 100
+	ScimMaxCount     = 1000
+)
+
+type ScimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// ScimMultiValuedAttribute is a value of a multi-valued attribute, such as
+// the emails of a user or the members of a group.
+type ScimMultiValuedAttribute struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// ScimUser is a user resource of the SCIM core schema. Only the attributes
+// with an equivalent on model.User are supported, the others are ignored.
+type ScimUser struct {
+	Schemas     []string                   `json:"schemas"`
+	Id          string                     `json:"id,omitempty"`
+	ExternalId  string                     `json:"externalId,omitempty"`
+	UserName    string                     `json:"userName"`
+	Name        *ScimName                  `json:"name,omitempty"`
+	DisplayName string                     `json:"displayName,omitempty"`
+	NickName    string                     `json:"nickName,omitempty"`
+	Title       string                     `json:"title,omitempty"`
+	Active      *bool                      `json:"active,omitempty"`
+	Password    string                     `json:"password,omitempty"`
+	Emails      []ScimMultiValuedAttribute `json:"emails,omitempty"`
+	Groups      []ScimMultiValuedAttribute `json:"groups,omitempty"`
+	Meta        *ScimMeta                  `json:"meta,omitempty"`
+}
+
+func (u *ScimUser) Auditable() map[string]any {
+	return map[string]any{
+		"id":          u.Id,
+		"external_id": u.ExternalId,
+		"user_name":   u.UserName,
+		"active":      u.Active,
+	}
+}
+
+// PrimaryEmail returns the email marked as primary, or else the first one.
+func (u *ScimUser) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+
+	return ""
+}
+
+// ScimUserFromResourceMap returns the user described by the resource map, as
+// modified by PATCH operations. Some clients send booleans as strings, which
+// are accepted for the active attribute.
+func ScimUserFromResourceMap(resource map[string]any) (*ScimUser, error) {
+	if active, ok := resource["active"].(string); ok {
+		resource["active"] = strings.EqualFold(active, "true")
+	}
+
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var user ScimUser
+	if err := json.Unmarshal(b, &user); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// ScimGroup is a group resource of the SCIM core schema.
+type ScimGroup struct {
+	Schemas     []string                   `json:"schemas"`
+	Id          string                     `json:"id,omitempty"`
+	ExternalId  string                     `json:"externalId,omitempty"`
+	DisplayName string                     `json:"displayName"`
+	Members     []ScimMultiValuedAttribute `json:"members,omitempty"`
+	Meta        *ScimMeta                  `json:"meta,omitempty"`
+}
+
+func (g *ScimGroup) Auditable() map[string]any {
+	return map[string]any{
+		"id":           g.Id,
+		"external_id":  g.ExternalId,
+		"display_name": g.DisplayName,
+		"member_ids":   g.MemberIds(),
+	}
+}
+
+// ScimGroupFromResourceMap returns the group described by the resource map, as
+// modified by PATCH operations.
+func ScimGroupFromResourceMap(resource map[string]any) (*ScimGroup, error) {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var group ScimGroup
+	if err := json.Unmarshal(b, &group); err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// MemberIds returns the IDs of the members of the group.
+func (g *ScimGroup) MemberIds() []string {
+	ids := make([]string, 0, len(g.Members))
+	for _, member := range g.Members {
+		if member.Value != "" {
+			ids = append(ids, member.Value)
+		}
+	}
+
+	return ids
+}
+
+type ScimListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+func NewScimListResponse(resources []any, totalResults, startIndex int) *ScimListResponse {
+	if resources == nil {
+		resources = []any{}
+	}
+
+	return &ScimListResponse{
+		Schemas:      []string{ScimSchemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+type ScimPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+type ScimPatchRequest struct {
+	Schemas    []string              `json:"schemas"`
+	Operations []*ScimPatchOperation `json:"Operations"`
+}
+
+func (r *ScimPatchRequest) Auditable() map[string]any {
+	operations := make([]map[string]any, 0, len(r.Operations))
+	for _, op := range r.Operations {
+		operations = append(operations, map[string]any{
+			"op":   op.Op,
+			"path": op.Path,
+		})
+	}
+
+	return map[string]any{
+		"operations": operations,
+	}
+}
+
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// ScimTime formats a timestamp in milliseconds as a SCIM date time.
+func ScimTime(millis int64) string {
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+// ScimResourceMap returns the resource as a map whose attribute names are in
+// lowercase, as attribute names are case insensitive in filters and in the
+// paths of PATCH operations.
+func ScimResourceMap(resource any) (map[string]any, error) {
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	return lowercaseScimKeys(m).(map[string]any), nil
+}
+
+func lowercaseScimKeys(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, item := range v {
+			m[strings.ToLower(key)] = lowercaseScimKeys(item)
+		}
+		return m
+	case []any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = lowercaseScimKeys(item)
+		}
+		return items
+	}
+
+	return value
+}
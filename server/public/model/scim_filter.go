@@ -0,0 +1,618 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ScimFilter is a parsed SCIM filter, as described in RFC 7644 section
+// 3.4.2.2. Filters are matched against resource maps, as returned by
+// ScimResourceMap.
+type ScimFilter struct {
+	root scimFilterNode
+}
+
+type scimFilterNode interface {
+	matches(resource map[string]any) bool
+}
+
+// scimAttrPath is an attribute path such as userName or emails.value. The
+// names are in lowercase.
+type scimAttrPath struct {
+	attr string
+	sub  string
+}
+
+func (p scimAttrPath) String() string {
+	if p.sub == "" {
+		return p.attr
+	}
+	return p.attr + "." + p.sub
+}
+
+// values returns the values of the attribute in the resource. Complex
+// multi-valued attributes without a sub-attribute, such as emails, stand for
+// their value sub-attribute.
+func (p scimAttrPath) values(resource map[string]any) []any {
+	value, ok := resource[p.attr]
+	if !ok || value == nil {
+		return nil
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		items = []any{value}
+	}
+
+	var values []any
+	for _, item := range items {
+		complexValue, isComplex := item.(map[string]any)
+		switch {
+		case p.sub != "" && isComplex:
+			if v, ok := complexValue[p.sub]; ok && v != nil {
+				values = append(values, v)
+			}
+		case p.sub == "" && isComplex:
+			if v, ok := complexValue["value"]; ok && v != nil {
+				values = append(values, v)
+			}
+		case p.sub == "":
+			values = append(values, item)
+		}
+	}
+
+	return values
+}
+
+type scimFilterAnd struct {
+	left, right scimFilterNode
+}
+
+func (f *scimFilterAnd) matches(resource map[string]any) bool {
+	return f.left.matches(resource) && f.right.matches(resource)
+}
+
+type scimFilterOr struct {
+	left, right scimFilterNode
+}
+
+func (f *scimFilterOr) matches(resource map[string]any) bool {
+	return f.left.matches(resource) || f.right.matches(resource)
+}
+
+type scimFilterNot struct {
+	filter scimFilterNode
+}
+
+func (f *scimFilterNot) matches(resource map[string]any) bool {
+	return !f.filter.matches(resource)
+}
+
+type scimFilterPresent struct {
+	path scimAttrPath
+}
+
+func (f *scimFilterPresent) matches(resource map[string]any) bool {
+	if f.path.sub == "" {
+		switch v := resource[f.path.attr].(type) {
+		case nil:
+			return false
+		case string:
+			return v != ""
+		case []any:
+			return len(v) > 0
+		case map[string]any:
+			return len(v) > 0
+		}
+		return true
+	}
+
+	for _, value := range f.path.values(resource) {
+		if s, ok := value.(string); !ok || s != "" {
+			return true
+		}
+	}
+	return false
+}
+
+type scimFilterCompare struct {
+	path  scimAttrPath
+	op    string
+	value any
+}
+
+func (f *scimFilterCompare) matches(resource map[string]any) bool {
+	values := f.path.values(resource)
+
+	if f.op == "ne" {
+		for _, value := range values {
+			if compareScimValues("eq", value, f.value) {
+				return false
+			}
+		}
+		return f.value != nil || len(values) > 0
+	}
+
+	if len(values) == 0 {
+		return f.op == "eq" && f.value == nil
+	}
+
+	for _, value := range values {
+		if compareScimValues(f.op, value, f.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// scimFilterValuePath matches the resources with a value of a multi-valued
+// complex attribute matching the filter, such as emails[type eq "work"].
+type scimFilterValuePath struct {
+	attr   string
+	filter scimFilterNode
+}
+
+func (f *scimFilterValuePath) matches(resource map[string]any) bool {
+	for _, item := range scimComplexValues(resource[f.attr]) {
+		if f.filter.matches(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func scimComplexValues(value any) []map[string]any {
+	switch v := value.(type) {
+	case map[string]any:
+		return []map[string]any{v}
+	case []any:
+		items := make([]map[string]any, 0, len(v))
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				items = append(items, m)
+			}
+		}
+		return items
+	}
+	return nil
+}
+
+// compareScimValues compares values of the same type. Strings are compared
+// case insensitively, and values of different types never match.
+func compareScimValues(op string, actual, expected any) bool {
+	switch e := expected.(type) {
+	case string:
+		a, ok := actual.(string)
+		if !ok {
+			return false
+		}
+		a, e = strings.ToLower(a), strings.ToLower(e)
+		switch op {
+		case "eq":
+			return a == e
+		case "co":
+			return strings.Contains(a, e)
+		case "sw":
+			return strings.HasPrefix(a, e)
+		case "ew":
+			return strings.HasSuffix(a, e)
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	case float64:
+		a, ok := actual.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return a == e
+		case "gt":
+			return a > e
+		case "ge":
+			return a >= e
+		case "lt":
+			return a < e
+		case "le":
+			return a <= e
+		}
+	case bool:
+		a, ok := actual.(bool)
+		return ok && op == "eq" && a == e
+	}
+
+	return false
+}
+
+// Matches returns whether the resource map matches the filter.
+func (f *ScimFilter) Matches(resource map[string]any) bool {
+	return f.root.matches(resource)
+}
+
+// EqualityValue returns the string the attribute path, such as userName or
+// emails.value, must be equal to for a resource to match the filter, if the
+// filter requires it. It allows to look resources up instead of matching the
+// filter against all of them.
+func (f *ScimFilter) EqualityValue(path string) (string, bool) {
+	return scimEqualityValue(f.root, strings.ToLower(path))
+}
+
+func scimEqualityValue(node scimFilterNode, path string) (string, bool) {
+	switch n := node.(type) {
+	case *scimFilterAnd:
+		if value, ok := scimEqualityValue(n.left, path); ok {
+			return value, true
+		}
+		return scimEqualityValue(n.right, path)
+	case *scimFilterCompare:
+		if value, ok := n.value.(string); ok && n.op == "eq" && n.path.String() == path {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// ScimFilterExpr is a filter as a tree, to translate it into queries: either the comparison of
+// the attribute with the value, or when Op is "and" or "or", the combination of the expressions.
+// Attribute paths are in lowercase, such as username or name.familyname.
+type ScimFilterExpr struct {
+	Op    string
+	Attr  string
+	Value any
+	Exprs []*ScimFilterExpr
+}
+
+// Expr returns the filter as an expression, unless it negates expressions, tests the presence of
+// attributes or filters the values of complex attributes, which aren't translated.
+func (f *ScimFilter) Expr() (*ScimFilterExpr, bool) {
+	return scimFilterExpr(f.root)
+}
+
+func scimFilterExpr(node scimFilterNode) (*ScimFilterExpr, bool) {
+	var op string
+	var left, right scimFilterNode
+	switch n := node.(type) {
+	case *scimFilterCompare:
+		return &ScimFilterExpr{Op: n.op, Attr: n.path.String(), Value: n.value}, true
+	case *scimFilterAnd:
+		op, left, right = "and", n.left, n.right
+	case *scimFilterOr:
+		op, left, right = "or", n.left, n.right
+	default:
+		return nil, false
+	}
+
+	leftExpr, ok := scimFilterExpr(left)
+	if !ok {
+		return nil, false
+	}
+	rightExpr, ok := scimFilterExpr(right)
+	if !ok {
+		return nil, false
+	}
+
+	return &ScimFilterExpr{Op: op, Exprs: []*ScimFilterExpr{leftExpr, rightExpr}}, true
+}
+
+// equalities returns the values the attributes must be equal to for a
+// resource to match the filter, such as {"type": "work"} for type eq "work".
+func (f *ScimFilter) equalities() map[string]any {
+	values := make(map[string]any)
+	var collect func(node scimFilterNode)
+	collect = func(node scimFilterNode) {
+		switch n := node.(type) {
+		case *scimFilterAnd:
+			collect(n.left)
+			collect(n.right)
+		case *scimFilterCompare:
+			if n.op == "eq" && n.path.sub == "" && n.value != nil {
+				values[n.path.attr] = n.value
+			}
+		}
+	}
+	collect(f.root)
+	return values
+}
+
+func ParseScimFilter(filter string) (*ScimFilter, *AppError) {
+	p, err := newScimFilterParser(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != scimTokenEOF {
+		return nil, newScimFilterError(filter)
+	}
+
+	return &ScimFilter{root: root}, nil
+}
+
+func newScimFilterError(filter string) *AppError {
+	return NewAppError("ParseScimFilter", "model.scim.invalid_filter.app_error", map[string]any{"Filter": filter}, "", http.StatusBadRequest)
+}
+
+const (
+	scimTokenEOF = iota
+	scimTokenWord
+	scimTokenString
+	scimTokenLeftParen
+	scimTokenRightParen
+	scimTokenLeftBracket
+	scimTokenRightBracket
+)
+
+type scimToken struct {
+	kind int
+	text string
+}
+
+type scimFilterParser struct {
+	filter string
+	tokens []scimToken
+	pos    int
+}
+
+func newScimFilterParser(filter string) (*scimFilterParser, *AppError) {
+	p := &scimFilterParser{filter: filter}
+
+	runes := []rune(filter)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			p.tokens = append(p.tokens, scimToken{kind: scimTokenLeftParen})
+			i++
+		case r == ')':
+			p.tokens = append(p.tokens, scimToken{kind: scimTokenRightParen})
+			i++
+		case r == '[':
+			p.tokens = append(p.tokens, scimToken{kind: scimTokenLeftBracket})
+			i++
+		case r == ']':
+			p.tokens = append(p.tokens, scimToken{kind: scimTokenRightBracket})
+			i++
+		case r == '"':
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(runes) {
+				return nil, newScimFilterError(filter)
+			}
+			var s string
+			if err := json.Unmarshal([]byte(string(runes[i:j+1])), &s); err != nil {
+				return nil, newScimFilterError(filter)
+			}
+			p.tokens = append(p.tokens, scimToken{kind: scimTokenString, text: s})
+			i = j + 1
+		default:
+			j := i
+			for ; j < len(runes) && !unicode.IsSpace(runes[j]) && !strings.ContainsRune(`()[]"`, runes[j]); j++ {
+			}
+			p.tokens = append(p.tokens, scimToken{kind: scimTokenWord, text: string(runes[i:j])})
+			i = j
+		}
+	}
+
+	return p, nil
+}
+
+func (p *scimFilterParser) peek() scimToken {
+	if p.pos >= len(p.tokens) {
+		return scimToken{kind: scimTokenEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *scimFilterParser) next() scimToken {
+	tok := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *scimFilterParser) isKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == scimTokenWord && strings.EqualFold(tok.text, keyword)
+}
+
+func (p *scimFilterParser) expect(kind int) *AppError {
+	if p.next().kind != kind {
+		return newScimFilterError(p.filter)
+	}
+	return nil
+}
+
+func (p *scimFilterParser) parseOr() (scimFilterNode, *AppError) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &scimFilterOr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *scimFilterParser) parseAnd() (scimFilterNode, *AppError) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &scimFilterAnd{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *scimFilterParser) parseNot() (scimFilterNode, *AppError) {
+	if p.isKeyword("not") {
+		p.next()
+		if err := p.expect(scimTokenLeftParen); err != nil {
+			return nil, err
+		}
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(scimTokenRightParen); err != nil {
+			return nil, err
+		}
+		return &scimFilterNot{filter: filter}, nil
+	}
+
+	if p.peek().kind == scimTokenLeftParen {
+		p.next()
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(scimTokenRightParen); err != nil {
+			return nil, err
+		}
+		return filter, nil
+	}
+
+	return p.parseAttrExp()
+}
+
+func (p *scimFilterParser) parseAttrExp() (scimFilterNode, *AppError) {
+	tok := p.next()
+	if tok.kind != scimTokenWord {
+		return nil, newScimFilterError(p.filter)
+	}
+	path, ok := parseScimAttrPath(tok.text)
+	if !ok {
+		return nil, newScimFilterError(p.filter)
+	}
+
+	if p.peek().kind == scimTokenLeftBracket {
+		if path.sub != "" {
+			return nil, newScimFilterError(p.filter)
+		}
+		p.next()
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(scimTokenRightBracket); err != nil {
+			return nil, err
+		}
+		return &scimFilterValuePath{attr: path.attr, filter: filter}, nil
+	}
+
+	opTok := p.next()
+	if opTok.kind != scimTokenWord {
+		return nil, newScimFilterError(p.filter)
+	}
+	op := strings.ToLower(opTok.text)
+
+	switch op {
+	case "pr":
+		return &scimFilterPresent{path: path}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, newScimFilterError(p.filter)
+	}
+
+	valueTok := p.next()
+	var value any
+	switch valueTok.kind {
+	case scimTokenString:
+		value = valueTok.text
+	case scimTokenWord:
+		switch strings.ToLower(valueTok.text) {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			number, err := strconv.ParseFloat(valueTok.text, 64)
+			if err != nil {
+				return nil, newScimFilterError(p.filter)
+			}
+			value = number
+		}
+	default:
+		return nil, newScimFilterError(p.filter)
+	}
+
+	return &scimFilterCompare{path: path, op: op, value: value}, nil
+}
+
+// parseScimAttrPath parses an attribute path, which may be prefixed by the
+// URN of its schema. The attributes of extension schemas are sub-attributes
+// of their URN, as they are in resources.
+func parseScimAttrPath(text string) (scimAttrPath, bool) {
+	text = strings.ToLower(text)
+
+	var path scimAttrPath
+	if strings.HasPrefix(text, "urn:") {
+		i := strings.LastIndex(text, ":")
+		urn, name := text[:i], text[i+1:]
+		if urn == strings.ToLower(ScimSchemaUser) || urn == strings.ToLower(ScimSchemaGroup) {
+			return parseScimAttrPath(name)
+		}
+		path = scimAttrPath{attr: urn, sub: name}
+	} else {
+		path.attr, path.sub, _ = strings.Cut(text, ".")
+	}
+
+	if !isValidScimAttrName(path.attr) && !strings.HasPrefix(path.attr, "urn:") {
+		return path, false
+	}
+	if path.sub != "" && !isValidScimAttrName(path.sub) {
+		return path, false
+	}
+
+	return path, true
+}
+
+func isValidScimAttrName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '_' && r != '-' && r != '$' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestScimUserMap(t *testing.T) map[string]any {
+	t.Helper()
+
+	resource, err := ScimResourceMap(&ScimUser{
+		Schemas:    []string{ScimSchemaUser},
+		Id:         "userid",
+		ExternalId: "ext-1",
+		UserName:   "bjensen",
+		Name:       &ScimName{GivenName: "Barbara", FamilyName: "Jensen"},
+		Title:      "Tour Guide",
+		Active:     NewPointer(true),
+		Emails: []ScimMultiValuedAttribute{
+			{Value: "bjensen@example.com", Type: "work", Primary: true},
+			{Value: "babs@jensen.org", Type: "home"},
+		},
+		Meta: &ScimMeta{ResourceType: ScimResourceTypeUser, LastModified: "2026-01-23T04:56:22Z"},
+	})
+	require.NoError(t, err)
+	return resource
+}
+
+func TestScimFilter(t *testing.T) {
+	resource := newTestScimUserMap(t)
+
+	for filter, expected := range map[string]bool{
+		`userName eq "bjensen"`:                 true,
+		`USERNAME EQ "BJensen"`:                 true,
+		`userName eq "jsmith"`:                  false,
+		`userName ne "jsmith"`:                  true,
+		`name.familyName co "ens"`:              true,
+		`userName sw "bj"`:                      true,
+		`userName ew "sen"`:                     true,
+		`title pr`:                              true,
+		`nickName pr`:                           false,
+		`active eq true`:                        true,
+		`active eq false`:                       false,
+		`emails eq "babs@jensen.org"`:           true,
+		`emails.value eq "bjensen@example.com"`: true,
+		`emails[type eq "work" and value co "example.com"]`:                    true,
+		`emails[type eq "other"]`:                                              false,
+		`meta.lastModified gt "2026-01-01T00:00:00Z"`:                          true,
+		`meta.lastModified lt "2026-01-01T00:00:00Z"`:                          false,
+		`userName eq "jsmith" or title eq "tour guide"`:                        true,
+		`userName eq "bjensen" and not (title eq "tour guide")`:                false,
+		`(userName eq "jsmith" or userName eq "bjensen") and active eq true`:   true,
+		`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "bjensen"`:     true,
+		`externalId eq "ext-1" and userName eq "bjensen" or userName eq "foo"`: true,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			f, appErr := ParseScimFilter(filter)
+			require.Nil(t, appErr)
+			assert.Equal(t, expected, f.Matches(resource))
+		})
+	}
+
+	t.Run("invalid filters", func(t *testing.T) {
+		for _, filter := range []string{
+			``,
+			`userName`,
+			`userName eq`,
+			`userName is "bjensen"`,
+			`userName eq bjensen`,
+			`userName eq "bjensen`,
+			`(userName eq "bjensen"`,
+			`userName eq "bjensen" and`,
+			`emails[type eq "work"`,
+			`name.givenName[value eq "x"]`,
+			`user/name eq "x"`,
+		} {
+			_, appErr := ParseScimFilter(filter)
+			require.NotNil(t, appErr, filter)
+			assert.Equal(t, "model.scim.invalid_filter.app_error", appErr.Id)
+		}
+	})
+
+	t.Run("equality value", func(t *testing.T) {
+		f, appErr := ParseScimFilter(`active eq true and userName eq "bjensen"`)
+		require.Nil(t, appErr)
+
+		value, ok := f.EqualityValue("userName")
+		assert.True(t, ok)
+		assert.Equal(t, "bjensen", value)
+
+		_, ok = f.EqualityValue("externalId")
+		assert.False(t, ok)
+
+		f, appErr = ParseScimFilter(`userName eq "bjensen" or userName eq "jsmith"`)
+		require.Nil(t, appErr)
+		_, ok = f.EqualityValue("userName")
+		assert.False(t, ok)
+	})
+
+	t.Run("expression", func(t *testing.T) {
+		f, appErr := ParseScimFilter(`active eq true and (userName sw "bj" or name.familyName eq "Jensen")`)
+		require.Nil(t, appErr)
+
+		expr, ok := f.Expr()
+		require.True(t, ok)
+		assert.Equal(t, &ScimFilterExpr{Op: "and", Exprs: []*ScimFilterExpr{
+			{Op: "eq", Attr: "active", Value: true},
+			{Op: "or", Exprs: []*ScimFilterExpr{
+				{Op: "sw", Attr: "username", Value: "bj"},
+				{Op: "eq", Attr: "name.familyname", Value: "Jensen"},
+			}},
+		}}, expr)
+
+		for _, filter := range []string{`not (userName eq "bjensen")`, `title pr`, `emails[type eq "work"]`, `userName eq "bjensen" and title pr`} {
+			f, appErr := ParseScimFilter(filter)
+			require.Nil(t, appErr)
+			_, ok := f.Expr()
+			assert.False(t, ok, filter)
+		}
+	})
+}
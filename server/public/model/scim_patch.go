@@ -0,0 +1,248 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ScimPath is the parsed path of a PATCH operation, such as title,
+// name.givenName or emails[type eq "work"].value.
+type ScimPath struct {
+	Attr   string
+	Filter *ScimFilter
+	Sub    string
+}
+
+func ParseScimPath(path string) (*ScimPath, *AppError) {
+	p, appErr := newScimFilterParser(path)
+	if appErr != nil {
+		return nil, newScimPathError(path)
+	}
+
+	tok := p.next()
+	if tok.kind != scimTokenWord {
+		return nil, newScimPathError(path)
+	}
+	attrPath, ok := parseScimAttrPath(tok.text)
+	if !ok {
+		return nil, newScimPathError(path)
+	}
+	scimPath := &ScimPath{Attr: attrPath.attr, Sub: attrPath.sub}
+
+	if p.peek().kind == scimTokenLeftBracket {
+		if scimPath.Sub != "" {
+			return nil, newScimPathError(path)
+		}
+		p.next()
+		root, appErr := p.parseOr()
+		if appErr != nil {
+			return nil, newScimPathError(path)
+		}
+		if p.next().kind != scimTokenRightBracket {
+			return nil, newScimPathError(path)
+		}
+		scimPath.Filter = &ScimFilter{root: root}
+
+		if tok := p.peek(); tok.kind == scimTokenWord && strings.HasPrefix(tok.text, ".") {
+			p.next()
+			scimPath.Sub = strings.ToLower(strings.TrimPrefix(tok.text, "."))
+			if !isValidScimAttrName(scimPath.Sub) {
+				return nil, newScimPathError(path)
+			}
+		}
+	}
+
+	if p.peek().kind != scimTokenEOF {
+		return nil, newScimPathError(path)
+	}
+
+	return scimPath, nil
+}
+
+func newScimPathError(path string) *AppError {
+	return NewAppError("ParseScimPath", "model.scim.invalid_path.app_error", map[string]any{"Path": path}, "", http.StatusBadRequest)
+}
+
+func newScimPatchValueError(op *ScimPatchOperation) *AppError {
+	return NewAppError("ApplyScimPatchOperation", "model.scim.invalid_value.app_error", map[string]any{"Path": op.Path}, "", http.StatusBadRequest)
+}
+
+// ApplyScimPatchOperation applies the PATCH operation to the resource map, as
+// returned by ScimResourceMap. Operations replacing or adding a sub-attribute
+// of values matching a filter, but matching none, add a value matching it,
+// so that emails[type eq "work"].value sets the work email of the user.
+func ApplyScimPatchOperation(resource map[string]any, op *ScimPatchOperation) *AppError {
+	value := lowercaseScimKeys(op.Value)
+
+	opName := strings.ToLower(op.Op)
+	switch opName {
+	case ScimPatchOpAdd, ScimPatchOpReplace, ScimPatchOpRemove:
+	default:
+		return NewAppError("ApplyScimPatchOperation", "model.scim.invalid_operation.app_error", map[string]any{"Op": op.Op}, "", http.StatusBadRequest)
+	}
+
+	if op.Path == "" {
+		if opName == ScimPatchOpRemove {
+			return NewAppError("ApplyScimPatchOperation", "model.scim.no_target.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		values, ok := value.(map[string]any)
+		if !ok {
+			return newScimPatchValueError(op)
+		}
+		for key, v := range values {
+			// extension attributes are set through their URN, which the
+			// attributes of the core schema may also be qualified with
+			if strings.HasPrefix(key, "urn:") {
+				if key != strings.ToLower(ScimSchemaUser) && key != strings.ToLower(ScimSchemaGroup) {
+					setScimAttribute(resource, opName, key, v)
+					continue
+				}
+				if attrs, ok := v.(map[string]any); ok {
+					for attr, attrValue := range attrs {
+						setScimAttribute(resource, opName, attr, attrValue)
+					}
+				}
+				continue
+			}
+
+			path, ok := parseScimAttrPath(key)
+			if !ok {
+				return newScimPathError(key)
+			}
+			if path.sub == "" {
+				setScimAttribute(resource, opName, path.attr, v)
+				continue
+			}
+			complexValue, ok := resource[path.attr].(map[string]any)
+			if !ok {
+				complexValue = make(map[string]any)
+				resource[path.attr] = complexValue
+			}
+			complexValue[path.sub] = v
+		}
+		return nil
+	}
+
+	path, appErr := ParseScimPath(op.Path)
+	if appErr != nil {
+		return appErr
+	}
+
+	if opName != ScimPatchOpRemove && value == nil {
+		return newScimPatchValueError(op)
+	}
+
+	if path.Filter == nil {
+		if path.Sub == "" {
+			if opName == ScimPatchOpRemove {
+				delete(resource, path.Attr)
+				return nil
+			}
+			setScimAttribute(resource, opName, path.Attr, value)
+			return nil
+		}
+
+		items := scimComplexValues(resource[path.Attr])
+		if len(items) == 0 {
+			if opName != ScimPatchOpRemove {
+				resource[path.Attr] = map[string]any{path.Sub: value}
+			}
+			return nil
+		}
+		for _, item := range items {
+			if opName == ScimPatchOpRemove {
+				delete(item, path.Sub)
+			} else {
+				item[path.Sub] = value
+			}
+		}
+		return nil
+	}
+
+	var items []any
+	switch v := resource[path.Attr].(type) {
+	case []any:
+		items = v
+	case map[string]any:
+		items = []any{v}
+	}
+
+	matched := false
+	kept := make([]any, 0, len(items))
+	for _, item := range items {
+		complexValue, ok := item.(map[string]any)
+		if !ok || !path.Filter.Matches(complexValue) {
+			kept = append(kept, item)
+			continue
+		}
+		matched = true
+
+		switch {
+		case opName == ScimPatchOpRemove && path.Sub == "":
+			continue
+		case opName == ScimPatchOpRemove:
+			delete(complexValue, path.Sub)
+		case path.Sub != "":
+			complexValue[path.Sub] = value
+		default:
+			newValue, ok := value.(map[string]any)
+			if !ok {
+				return newScimPatchValueError(op)
+			}
+			if opName == ScimPatchOpReplace {
+				complexValue = make(map[string]any, len(newValue))
+			}
+			for k, v := range newValue {
+				complexValue[k] = v
+			}
+		}
+		kept = append(kept, complexValue)
+	}
+
+	if !matched && opName != ScimPatchOpRemove {
+		newValue := path.Filter.equalities()
+		if path.Sub != "" {
+			newValue[path.Sub] = value
+		} else if values, ok := value.(map[string]any); ok {
+			for k, v := range values {
+				newValue[k] = v
+			}
+		} else {
+			return newScimPatchValueError(op)
+		}
+		kept = append(kept, newValue)
+	}
+
+	resource[path.Attr] = kept
+	return nil
+}
+
+// setScimAttribute sets the attribute of the resource. Adding values to a
+// multi-valued attribute appends them to its values.
+func setScimAttribute(resource map[string]any, op, attr string, value any) {
+	if op == ScimPatchOpAdd {
+		if existing, ok := resource[attr].([]any); ok {
+			if values, ok := value.([]any); ok {
+				resource[attr] = append(existing, values...)
+			} else {
+				resource[attr] = append(existing, value)
+			}
+			return
+		}
+
+		if existing, ok := resource[attr].(map[string]any); ok {
+			if values, ok := value.(map[string]any); ok {
+				for k, v := range values {
+					existing[k] = v
+				}
+				return
+			}
+		}
+	}
+
+	resource[attr] = value
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScimPath(t *testing.T) {
+	path, appErr := ParseScimPath(`emails[type eq "work"].value`)
+	require.Nil(t, appErr)
+	assert.Equal(t, "emails", path.Attr)
+	assert.Equal(t, "value", path.Sub)
+	require.NotNil(t, path.Filter)
+
+	path, appErr = ParseScimPath(`name.givenName`)
+	require.Nil(t, appErr)
+	assert.Equal(t, "name", path.Attr)
+	assert.Equal(t, "givenname", path.Sub)
+	assert.Nil(t, path.Filter)
+
+	path, appErr = ParseScimPath(`urn:ietf:params:scim:schemas:core:2.0:User:title`)
+	require.Nil(t, appErr)
+	assert.Equal(t, "title", path.Attr)
+
+	for _, invalid := range []string{``, `emails[type eq "work"`, `name.givenName[value eq "x"]`, `title eq "x"`, `emails[type eq "work"].`} {
+		_, appErr = ParseScimPath(invalid)
+		require.NotNil(t, appErr, invalid)
+		assert.Equal(t, "model.scim.invalid_path.app_error", appErr.Id)
+	}
+}
+
+func TestApplyScimPatchOperation(t *testing.T) {
+	apply := func(t *testing.T, ops ...*ScimPatchOperation) *ScimUser {
+		t.Helper()
+		resource := newTestScimUserMap(t)
+		for _, op := range ops {
+			require.Nil(t, ApplyScimPatchOperation(resource, op))
+		}
+		user, err := ScimUserFromResourceMap(resource)
+		require.NoError(t, err)
+		return user
+	}
+
+	t.Run("replace attributes", func(t *testing.T) {
+		user := apply(t,
+			&ScimPatchOperation{Op: "Replace", Path: "title", Value: "Manager"},
+			&ScimPatchOperation{Op: "replace", Path: "name.givenName", Value: "Babs"},
+			&ScimPatchOperation{Op: "Replace", Path: "active", Value: "False"},
+		)
+		assert.Equal(t, "Manager", user.Title)
+		assert.Equal(t, "Babs", user.Name.GivenName)
+		assert.Equal(t, "Jensen", user.Name.FamilyName)
+		require.NotNil(t, user.Active)
+		assert.False(t, *user.Active)
+	})
+
+	t.Run("replace without path", func(t *testing.T) {
+		user := apply(t, &ScimPatchOperation{Op: "replace", Value: map[string]any{
+			"userName":       "babs",
+			"name.givenName": "Babs",
+			"active":         false,
+		}})
+		assert.Equal(t, "babs", user.UserName)
+		assert.Equal(t, "Babs", user.Name.GivenName)
+		assert.False(t, *user.Active)
+	})
+
+	t.Run("filtered value paths", func(t *testing.T) {
+		user := apply(t, &ScimPatchOperation{Op: "replace", Path: `emails[type eq "work"].value`, Value: "babs@example.com"})
+		assert.Equal(t, "babs@example.com", user.PrimaryEmail())
+		assert.Len(t, user.Emails, 2)
+
+		user = apply(t, &ScimPatchOperation{Op: "remove", Path: `emails[type eq "home"]`})
+		require.Len(t, user.Emails, 1)
+		assert.Equal(t, "bjensen@example.com", user.Emails[0].Value)
+
+		user = apply(t,
+			&ScimPatchOperation{Op: "remove", Path: "emails"},
+			&ScimPatchOperation{Op: "add", Path: `emails[type eq "work"].value`, Value: "new@example.com"},
+		)
+		require.Len(t, user.Emails, 1)
+		assert.Equal(t, "new@example.com", user.Emails[0].Value)
+		assert.Equal(t, "work", user.Emails[0].Type)
+	})
+
+	t.Run("add to multi-valued attribute", func(t *testing.T) {
+		user := apply(t, &ScimPatchOperation{Op: "add", Path: "emails", Value: []any{map[string]any{"value": "other@example.com"}}})
+		assert.Len(t, user.Emails, 3)
+	})
+
+	t.Run("remove attribute", func(t *testing.T) {
+		user := apply(t, &ScimPatchOperation{Op: "remove", Path: "title"})
+		assert.Empty(t, user.Title)
+	})
+
+	t.Run("invalid operations", func(t *testing.T) {
+		resource := newTestScimUserMap(t)
+
+		appErr := ApplyScimPatchOperation(resource, &ScimPatchOperation{Op: "move", Path: "title"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.scim.invalid_operation.app_error", appErr.Id)
+
+		appErr = ApplyScimPatchOperation(resource, &ScimPatchOperation{Op: "remove"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.scim.no_target.app_error", appErr.Id)
+
+		appErr = ApplyScimPatchOperation(resource, &ScimPatchOperation{Op: "replace", Value: "title"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.scim.invalid_value.app_error", appErr.Id)
+
+		appErr = ApplyScimPatchOperation(resource, &ScimPatchOperation{Op: "replace", Path: "title"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.scim.invalid_value.app_error", appErr.Id)
+	})
+}
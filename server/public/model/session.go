@@ -29,12 +29,22 @@ const (
 	SessionPropLastRemovedDeviceId        = "last_removed_device_id"
 	SessionPropDeviceNotificationDisabled = "device_notification_disabled"
 	SessionPropMobileVersion              = "mobile_version"
+	SessionPropOAuthScopes                = "oauth_scopes"
+	SessionPropMfaMethod                  = "mfa_method"
+	SessionPropMfaMethodWebAuthn          = "webauthn"
+	SessionPropMfaMethodRecoveryCode      = "recovery_code"
+	SessionPropMfaMethodTrustedDevice     = "trusted_device"
 	SessionTypeUserAccessToken            = "UserAccessToken"
 	SessionTypeCloudKey                   = "CloudKey"
 	SessionTypeRemoteclusterToken         = "RemoteClusterToken"
 	SessionPropIsGuest                    = "is_guest"
+	SessionPropIPAddress                  = "ip_address"
 	SessionActivityTimeout                = 1000 * 60 * 5  // 5 minutes
 	SessionUserAccessTokenExpiryHours     = 100 * 365 * 24 // 100 years
+
+	SessionDeviceTypeWeb     = "web"
+	SessionDeviceTypeDesktop = "desktop"
+	SessionDeviceTypeMobile  = "mobile"
 )
 
 //msgp:tuple StringMap
@@ -254,6 +264,20 @@ func (s *Session) IsSSOLogin() bool {
 	return s.IsOAuthUser() || s.IsSaml()
 }
 
+// DeviceType returns whether the session is on the web, the desktop app or
+// the mobile app.
+func (s *Session) DeviceType() string {
+	if s.IsMobileApp() {
+		return SessionDeviceTypeMobile
+	}
+
+	if strings.HasPrefix(s.Props[SessionPropBrowser], "Desktop App") {
+		return SessionDeviceTypeDesktop
+	}
+
+	return SessionDeviceTypeWeb
+}
+
 func (s *Session) IsGuest() bool {
 	val, ok := s.Props[SessionPropIsGuest]
 	if !ok {
@@ -262,6 +286,25 @@ func (s *Session) IsGuest() bool {
 	return val == "true"
 }
 
+// SetOAuthScopes restricts the session to the given OAuth scopes. No scopes leaves the session
+// unrestricted.
+func (s *Session) SetOAuthScopes(scopes []string) {
+	if len(scopes) > 0 {
+		s.AddProp(SessionPropOAuthScopes, strings.Join(scopes, " "))
+	}
+}
+
+// GetOAuthScopes returns the OAuth scopes the session is restricted to, if any.
+func (s *Session) GetOAuthScopes() []string {
+	return strings.Fields(s.Props[SessionPropOAuthScopes])
+}
+
+// HasOAuthScope reports whether the OAuth scopes of the session, if any, allow an action
+// requiring the given scope.
+func (s *Session) HasOAuthScope(scope string) bool {
+	return OAuthScopesAllow(s.GetOAuthScopes(), scope)
+}
+
 func (s *Session) GetUserRoles() []string {
 	return strings.Fields(s.Roles)
 }
@@ -153,3 +153,36 @@ func TestIsIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionDeviceType(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Session     Session
+		DeviceType  string
+	}{
+		{"Web on empty props", Session{}, SessionDeviceTypeWeb},
+		{"Web from a browser", Session{Props: StringMap{SessionPropBrowser: "Chrome/120.0"}}, SessionDeviceTypeWeb},
+		{"Desktop from the desktop app", Session{Props: StringMap{SessionPropBrowser: "Desktop App/5.8.0"}}, SessionDeviceTypeDesktop},
+		{"Mobile from the mobile app", Session{Props: StringMap{UserAuthServiceIsMobile: "true"}}, SessionDeviceTypeMobile},
+		{"Mobile with a device ID", Session{DeviceId: NewId()}, SessionDeviceTypeMobile},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			require.Equal(t, tc.DeviceType, tc.Session.DeviceType())
+		})
+	}
+}
+
+func TestSessionOAuthScopes(t *testing.T) {
+	session := &Session{}
+	session.SetOAuthScopes(nil)
+	require.NotContains(t, session.Props, SessionPropOAuthScopes)
+	require.Empty(t, session.GetOAuthScopes())
+	require.True(t, session.HasOAuthScope(OAuthScopeChannelsManage))
+
+	session.SetOAuthScopes([]string{OAuthScopePostsWrite, OAuthScopeUsersRead})
+	require.Equal(t, []string{OAuthScopePostsWrite, OAuthScopeUsersRead}, session.GetOAuthScopes())
+	require.True(t, session.HasOAuthScope(OAuthScopePostsRead))
+	require.False(t, session.HasOAuthScope(OAuthScopeChannelsManage))
+}
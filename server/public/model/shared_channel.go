@@ -5,6 +5,7 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"unicode/utf8"
 
@@ -121,6 +122,11 @@ type SharedChannelRemote struct {
 	LastPostCreateAt  int64  `json:"last_post_create_at"`
 	LastPostCreateID  string `json:"last_post_create_id"`
 	LastMembersSyncAt int64  `json:"last_members_sync_at"`
+
+	// LastBookmarksSyncAt and LastChannelInfoSyncAt track the channel bookmarks and
+	// channel header/purpose sent to the remote, independently of the post cursor.
+	LastBookmarksSyncAt   int64 `json:"last_bookmarks_sync_at"`
+	LastChannelInfoSyncAt int64 `json:"last_channel_info_sync_at"`
 }
 
 func (sc *SharedChannelRemote) IsValid() *AppError {
@@ -295,6 +301,21 @@ type SyncMsg struct {
 	MembershipChanges []*MembershipChangeMsg `json:"membership_changes,omitempty"`
 	Acknowledgements  []*PostAcknowledgement `json:"acknowledgements,omitempty"`
 	MentionTransforms map[string]string      `json:"mention_transforms,omitempty"`
+	Bookmarks         []*ChannelBookmark     `json:"bookmarks,omitempty"`
+	ChannelInfo       *SyncChannelInfo       `json:"channel_info,omitempty"`
+
+	// ResolvedPersistentNotifications contains ids of posts whose persistent
+	// notifications were stopped on the sending side.
+	ResolvedPersistentNotifications []string `json:"resolved_persistent_notifications,omitempty"`
+}
+
+// SyncChannelInfo carries the user-editable channel fields that are kept in sync
+// between clusters. UpdateAt is the channel's UpdateAt on the sending side and is
+// used to resolve conflicting edits (last writer wins).
+type SyncChannelInfo struct {
+	Header   string `json:"header"`
+	Purpose  string `json:"purpose"`
+	UpdateAt int64  `json:"update_at"`
 }
 
 func NewSyncMsg(channelID string) *SyncMsg {
@@ -336,6 +357,65 @@ type SyncResponse struct {
 	AcknowledgementErrors        []string `json:"acknowledgement_errors"`
 
 	StatusErrors []string `json:"status_errors"` // user IDs for which the status sync failed
+
+	BookmarkErrors               []string `json:"bookmark_errors,omitempty"`                // bookmark IDs for which the sync failed
+	ChannelInfoError             string   `json:"channel_info_error,omitempty"`             // set when the channel header/purpose sync failed
+	PersistentNotificationErrors []string `json:"persistent_notification_errors,omitempty"` // post IDs for which the resolution sync failed
+}
+
+// SharedChannelSyncCompareRequest asks a remote for the ids of the posts created in a shared
+// channel within a time range. It is sent to remote clusters as the payload of a `RemoteClusterMsg`.
+type SharedChannelSyncCompareRequest struct {
+	ChannelId string `json:"channel_id"`
+	Since     int64  `json:"since"`
+	Until     int64  `json:"until"`
+}
+
+func (r *SharedChannelSyncCompareRequest) IsValid() *AppError {
+	if !IsValidId(r.ChannelId) {
+		return NewAppError("SharedChannelSyncCompareRequest.IsValid", "model.channel.is_valid.id.app_error", nil, "ChannelId="+r.ChannelId, http.StatusBadRequest)
+	}
+
+	if r.Since < 0 || r.Until < r.Since {
+		return NewAppError("SharedChannelSyncCompareRequest.IsValid", "model.shared_channel.sync_compare.invalid_range.app_error", nil, fmt.Sprintf("since=%d, until=%d", r.Since, r.Until), http.StatusBadRequest)
+	}
+	return nil
+}
+
+// SharedChannelSyncComparePosts is the reply to a `SharedChannelSyncCompareRequest`.
+// Truncated is true when the range holds more posts than can be compared at once.
+type SharedChannelSyncComparePosts struct {
+	PostIds   []string `json:"post_ids"`
+	Truncated bool     `json:"truncated"`
+}
+
+// SharedChannelSyncComparison is the result of comparing the posts of a shared channel
+// with a remote cluster. Only posts created within [Since, Until] are compared; deleted
+// posts and system messages, which each cluster creates on its own, are ignored.
+type SharedChannelSyncComparison struct {
+	ChannelId        string   `json:"channel_id" yaml:"channel_id"`
+	RemoteId         string   `json:"remote_id" yaml:"remote_id"`
+	Since            int64    `json:"since" yaml:"since"`
+	Until            int64    `json:"until" yaml:"until"`
+	ComparedAt       int64    `json:"compared_at" yaml:"compared_at"`
+	LocalCount       int      `json:"local_count" yaml:"local_count"`
+	RemoteCount      int      `json:"remote_count" yaml:"remote_count"`
+	MissingOnRemote  []string `json:"missing_on_remote" yaml:"missing_on_remote"`
+	MissingLocally   []string `json:"missing_locally" yaml:"missing_locally"`
+	Truncated        bool     `json:"truncated" yaml:"truncated"`
+	LastPostCreateAt int64    `json:"last_post_create_at" yaml:"last_post_create_at"` // sync cursor for the remote
+	LastPostUpdateAt int64    `json:"last_post_update_at" yaml:"last_post_update_at"` // sync cursor for the remote
+}
+
+// InSync returns true if no posts are missing on either side.
+func (c *SharedChannelSyncComparison) InSync() bool {
+	return len(c.MissingOnRemote) == 0 && len(c.MissingLocally) == 0
+}
+
+// SharedChannelResyncRequest resets the sync cursor of a shared channel remote so that
+// posts created or edited since the timestamp are sent again.
+type SharedChannelResyncRequest struct {
+	Since int64 `json:"since"`
 }
 
 // RegisterPluginOpts is passed by plugins to the `RegisterPluginForSharedChannels` plugin API
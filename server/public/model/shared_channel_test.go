@@ -64,3 +64,27 @@ func TestSharedChannelPreUpdate(t *testing.T) {
 
 	require.GreaterOrEqual(t, o.UpdateAt, now)
 }
+
+func TestSharedChannelSyncCompareRequestIsValid(t *testing.T) {
+	id := NewId()
+	data := []struct {
+		name  string
+		req   *SharedChannelSyncCompareRequest
+		valid bool
+	}{
+		{name: "Zero value", req: &SharedChannelSyncCompareRequest{}, valid: false},
+		{name: "Negative since", req: &SharedChannelSyncCompareRequest{ChannelId: id, Since: -1, Until: 1000}, valid: false},
+		{name: "Until before since", req: &SharedChannelSyncCompareRequest{ChannelId: id, Since: 2000, Until: 1000}, valid: false},
+		{name: "Single instant", req: &SharedChannelSyncCompareRequest{ChannelId: id, Since: 1000, Until: 1000}, valid: true},
+		{name: "Valid range", req: &SharedChannelSyncCompareRequest{ChannelId: id, Since: 0, Until: 1000}, valid: true},
+	}
+
+	for _, item := range data {
+		appErr := item.req.IsValid()
+		if item.valid {
+			assert.Nil(t, appErr, item.name)
+		} else {
+			assert.NotNil(t, appErr, item.name)
+		}
+	}
+}
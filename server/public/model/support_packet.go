@@ -109,6 +109,26 @@ type SupportPacketJobList struct {
 	MigrationJobs              []*Job `yaml:"migration_jobs"`
 }
 
+// SupportPacketSharedChannels contains the sync state of shared channel remotes and the results
+// of the latest sync comparisons run on this node.
+// It is included in the Support Packet.
+type SupportPacketSharedChannels struct {
+	Remotes     []*SupportPacketSharedChannelRemote `yaml:"remotes"`
+	Comparisons []*SharedChannelSyncComparison      `yaml:"comparisons"`
+}
+
+// SupportPacketSharedChannelRemote contains the sync cursor of a shared channel for a remote.
+// SyncLag is the number of milliseconds between the latest post activity in the channel and the cursor.
+type SupportPacketSharedChannelRemote struct {
+	ChannelId         string `yaml:"channel_id"`
+	RemoteId          string `yaml:"remote_id"`
+	IsInviteConfirmed bool   `yaml:"is_invite_confirmed"`
+	LastPostCreateAt  int64  `yaml:"last_post_create_at"`
+	LastPostUpdateAt  int64  `yaml:"last_post_update_at"`
+	LastMembersSyncAt int64  `yaml:"last_members_sync_at"`
+	SyncLag           int64  `yaml:"sync_lag"`
+}
+
 // SupportPacketPermissionInfo contains the list of schemes and the list of roles.
 // It is included in the Support Packet.
 type SupportPacketPermissionInfo struct {
@@ -128,6 +148,8 @@ type SupportPacketConfig struct {
 type SupportPacketPluginList struct {
 	Enabled  []Manifest `json:"enabled"`
 	Disabled []Manifest `json:"disabled"`
+	// APIUsage is sorted by database time, heaviest consumer first.
+	APIUsage []*PluginAPIUsage `json:"api_usage"`
 }
 
 // SupportPacketDatabaseSchema contains the database schema information.
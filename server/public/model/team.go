@@ -42,6 +42,10 @@ type Team struct {
 	GroupConstrained    *bool   `json:"group_constrained"`
 	PolicyID            *string `json:"policy_id"`
 	CloudLimitsArchived bool    `json:"cloud_limits_archived"`
+
+	// AllowMembershipRequests lets users who can't join the team by themselves
+	// ask to join it.
+	AllowMembershipRequests bool `json:"allow_membership_requests"`
 }
 
 func (o *Team) Auditable() map[string]any {
@@ -57,6 +61,8 @@ func (o *Team) Auditable() map[string]any {
 		"group_constrained":     o.GroupConstrained,
 		"policy_id":             o.PolicyID,
 		"cloud_limits_archived": o.CloudLimitsArchived,
+
+		"allow_membership_requests": o.AllowMembershipRequests,
 	}
 }
 
@@ -72,6 +78,8 @@ type TeamPatch struct {
 	AllowOpenInvite     *bool   `json:"allow_open_invite"`
 	GroupConstrained    *bool   `json:"group_constrained"`
 	CloudLimitsArchived *bool   `json:"cloud_limits_archived"`
+
+	AllowMembershipRequests *bool `json:"allow_membership_requests"`
 }
 
 func (o *TeamPatch) Auditable() map[string]any {
@@ -79,6 +87,8 @@ func (o *TeamPatch) Auditable() map[string]any {
 		"allow_open_invite":     o.AllowOpenInvite,
 		"group_constrained":     o.GroupConstrained,
 		"cloud_limits_archived": o.CloudLimitsArchived,
+
+		"allow_membership_requests": o.AllowMembershipRequests,
 	}
 }
 
@@ -280,6 +290,10 @@ func (o *Team) Patch(patch *TeamPatch) {
 	if patch.CloudLimitsArchived != nil {
 		o.CloudLimitsArchived = *patch.CloudLimitsArchived
 	}
+
+	if patch.AllowMembershipRequests != nil {
+		o.AllowMembershipRequests = *patch.AllowMembershipRequests
+	}
 }
 
 func (o *Team) IsGroupConstrained() bool {
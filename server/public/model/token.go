@@ -8,11 +8,12 @@ import (
 )
 
 const (
-	TokenSize                 = 64
-	MaxTokenExipryTime        = 1000 * 60 * 60 * 48 // 48 hour
-	PasswordRecoverExpiryTime = 1000 * 60 * 60 * 24 // 24 hours
-	InvitationExpiryTime      = 1000 * 60 * 60 * 48 // 48 hours
-	MagicLinkExpiryTime       = 1000 * 60 * 5       // 5 minutes
+	TokenSize                   = 64
+	MaxTokenExipryTime          = 1000 * 60 * 60 * 48 // 48 hour
+	PasswordRecoverExpiryTime   = 1000 * 60 * 60 * 24 // 24 hours
+	InvitationExpiryTime        = 1000 * 60 * 60 * 48 // 48 hours
+	MagicLinkExpiryTime         = 1000 * 60 * 5       // 5 minutes
+	WebAuthnChallengeExpiryTime = 1000 * 60 * 5       // 5 minutes
 
 	TokenTypePasswordRecovery         = "password_recovery"
 	TokenTypeVerifyEmail              = "verify_email"
@@ -21,6 +22,8 @@ const (
 	TokenTypeCWSAccess                = "cws_access_token"
 	TokenTypeGuestMagicLinkInvitation = "guest_magic_link_invitation"
 	TokenTypeGuestMagicLink           = "guest_magic_link"
+	TokenTypeWebAuthnRegistration     = "webauthn_registration"
+	TokenTypeWebAuthnLogin            = "webauthn_login"
 
 	TokenTypeOAuth           = "oauth"
 	TokenTypeSaml            = "saml"
@@ -68,6 +71,8 @@ func (t *Token) IsExpired() bool {
 		expiryTime = MagicLinkExpiryTime
 	case TokenTypeGuestMagicLinkInvitation:
 		expiryTime = InvitationExpiryTime
+	case TokenTypeWebAuthnRegistration, TokenTypeWebAuthnLogin:
+		expiryTime = WebAuthnChallengeExpiryTime
 	case TokenTypePasswordRecovery:
 		expiryTime = PasswordRecoverExpiryTime
 	case TokenTypeVerifyEmail:
@@ -13,6 +13,9 @@ type UserAccessToken struct {
 	UserId      string `json:"user_id"`
 	Description string `json:"description"`
 	IsActive    bool   `json:"is_active"`
+
+	// Scopes restricts what the token may access. No scopes means full access.
+	Scopes StringArray `json:"scopes"`
 }
 
 func (t *UserAccessToken) IsValid() *AppError {
@@ -32,10 +35,18 @@ func (t *UserAccessToken) IsValid() *AppError {
 		return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.description.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if err := ValidateOAuthScopes("UserAccessToken.IsValid", t.Scopes); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (t *UserAccessToken) PreSave() {
 	t.Id = NewId()
 	t.IsActive = true
+
+	if t.Scopes == nil {
+		t.Scopes = StringArray{}
+	}
 }
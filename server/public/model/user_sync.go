@@ -0,0 +1,317 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	UserSyncActionCreate     = "create"
+	UserSyncActionUpdate     = "update"
+	UserSyncActionDeactivate = "deactivate"
+	UserSyncActionNone       = "none"
+	UserSyncActionError      = "error"
+
+	userSyncListSeparator = ";"
+	userSyncAdminSuffix   = ":admin"
+)
+
+// UserSyncColumns are the columns accepted in a user sync CSV file. The
+// username column is required, the other ones are optional. A column
+// that is missing, or a cell that is empty, leaves the corresponding
+// field of existing users untouched.
+var UserSyncColumns = []string{
+	"username",
+	"email",
+	"password",
+	"first_name",
+	"last_name",
+	"nickname",
+	"position",
+	"locale",
+	"auth_service",
+	"auth_data",
+	"roles",
+	"active",
+	"teams",
+	"channels",
+}
+
+// UserSyncReportHeaders are the column names of the report of a user
+// sync. They match the values returned by UserSyncReportRow.ToReport.
+var UserSyncReportHeaders = []string{
+	"Line",
+	"Username",
+	"Email",
+	"Action",
+	"Changes",
+	"Error",
+}
+
+// UserSyncMembership is a team or channel membership requested for a
+// user. Channel is empty for team memberships.
+type UserSyncMembership struct {
+	Team    string `json:"team"`
+	Channel string `json:"channel,omitempty"`
+	Admin   bool   `json:"admin"`
+}
+
+// UserSyncRow is a row of a user sync CSV file. Nil fields are left
+// untouched. Teams and Channels, when set, are the exact list of teams
+// and channels the user must be a member of.
+type UserSyncRow struct {
+	Line        int                   `json:"line"`
+	Username    string                `json:"username"`
+	Email       *string               `json:"email,omitempty"`
+	Password    *string               `json:"-"`
+	FirstName   *string               `json:"first_name,omitempty"`
+	LastName    *string               `json:"last_name,omitempty"`
+	Nickname    *string               `json:"nickname,omitempty"`
+	Position    *string               `json:"position,omitempty"`
+	Locale      *string               `json:"locale,omitempty"`
+	AuthService *string               `json:"auth_service,omitempty"`
+	AuthData    *string               `json:"auth_data,omitempty"`
+	Roles       *string               `json:"roles,omitempty"`
+	Active      *bool                 `json:"active,omitempty"`
+	Teams       []*UserSyncMembership `json:"teams,omitempty"`
+	Channels    []*UserSyncMembership `json:"channels,omitempty"`
+}
+
+// UserSyncReportRow is the outcome of the sync of a row.
+type UserSyncReportRow struct {
+	Line     int      `json:"line"`
+	Username string   `json:"username"`
+	Email    string   `json:"email"`
+	Action   string   `json:"action"`
+	Changes  []string `json:"changes"`
+	Error    string   `json:"error,omitempty"`
+}
+
+func (r *UserSyncReportRow) ToReport() []string {
+	return []string{
+		strconv.Itoa(r.Line),
+		r.Username,
+		r.Email,
+		r.Action,
+		strings.Join(r.Changes, "; "),
+		r.Error,
+	}
+}
+
+// UserSyncResult counts the rows of a user sync by outcome.
+type UserSyncResult struct {
+	Created     int `json:"created"`
+	Updated     int `json:"updated"`
+	Deactivated int `json:"deactivated"`
+	Unchanged   int `json:"unchanged"`
+	Failed      int `json:"failed"`
+}
+
+// Add counts a row of the report.
+func (r *UserSyncResult) Add(row *UserSyncReportRow) {
+	switch row.Action {
+	case UserSyncActionCreate:
+		r.Created++
+	case UserSyncActionUpdate:
+		r.Updated++
+	case UserSyncActionDeactivate:
+		r.Deactivated++
+	case UserSyncActionNone:
+		r.Unchanged++
+	default:
+		r.Failed++
+	}
+}
+
+// ToJobData returns the counts as job data.
+func (r *UserSyncResult) ToJobData() StringMap {
+	return StringMap{
+		"created":     strconv.Itoa(r.Created),
+		"updated":     strconv.Itoa(r.Updated),
+		"deactivated": strconv.Itoa(r.Deactivated),
+		"unchanged":   strconv.Itoa(r.Unchanged),
+		"failed":      strconv.Itoa(r.Failed),
+	}
+}
+
+// UserSyncReader reads the rows of a user sync CSV file.
+type UserSyncReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+// NewUserSyncReader reads and validates the header of a user sync CSV
+// file.
+func NewUserSyncReader(r io.Reader) (*UserSyncReader, *AppError) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, NewAppError("NewUserSyncReader", "model.user_sync.header.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(UserSyncColumns, column) {
+			return nil, NewAppError("NewUserSyncReader", "model.user_sync.unknown_column.app_error", map[string]any{"Column": column}, "", http.StatusBadRequest)
+		}
+		if _, ok := columns[column]; ok {
+			return nil, NewAppError("NewUserSyncReader", "model.user_sync.duplicate_column.app_error", map[string]any{"Column": column}, "", http.StatusBadRequest)
+		}
+		columns[column] = i
+	}
+	if _, ok := columns["username"]; !ok {
+		return nil, NewAppError("NewUserSyncReader", "model.user_sync.missing_username.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return &UserSyncReader{
+		reader:  reader,
+		columns: columns,
+	}, nil
+}
+
+// Next returns the next row of the file, or io.EOF once all the rows
+// have been read. Rows that can't be parsed are returned along with the
+// error, so that the rest of the file can still be processed. If the
+// file itself can't be read, a nil row is returned with the error, and
+// no more rows can be read.
+func (r *UserSyncReader) Next() (*UserSyncRow, error) {
+	record, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, err
+		}
+		row := &UserSyncRow{Line: parseErr.StartLine}
+		return row, NewAppError("UserSyncReader.Next", "model.user_sync.parse.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	row := &UserSyncRow{Line: line}
+
+	cell := func(column string) *string {
+		i, ok := r.columns[column]
+		if !ok || i >= len(record) {
+			return nil
+		}
+		value := strings.TrimSpace(record[i])
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+
+	if username := cell("username"); username != nil {
+		row.Username = strings.ToLower(*username)
+	}
+	row.Email = cell("email")
+	if row.Email != nil {
+		row.Email = NewPointer(NormalizeEmail(*row.Email))
+	}
+	row.Password = cell("password")
+	row.FirstName = cell("first_name")
+	row.LastName = cell("last_name")
+	row.Nickname = cell("nickname")
+	row.Position = cell("position")
+	row.Locale = cell("locale")
+	row.AuthService = cell("auth_service")
+	row.AuthData = cell("auth_data")
+	row.Roles = cell("roles")
+
+	if active := cell("active"); active != nil {
+		value, err := strconv.ParseBool(*active)
+		if err != nil {
+			return row, NewAppError("UserSyncReader.Next", "model.user_sync.active.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		row.Active = &value
+	}
+
+	if teams := cell("teams"); teams != nil {
+		row.Teams = []*UserSyncMembership{}
+		for _, item := range splitUserSyncList(*teams) {
+			name, admin := strings.CutSuffix(item, userSyncAdminSuffix)
+			row.Teams = append(row.Teams, &UserSyncMembership{Team: name, Admin: admin})
+		}
+	}
+
+	if channels := cell("channels"); channels != nil {
+		row.Channels = []*UserSyncMembership{}
+		for _, item := range splitUserSyncList(*channels) {
+			item, admin := strings.CutSuffix(item, userSyncAdminSuffix)
+			team, channel, ok := strings.Cut(item, "/")
+			if !ok {
+				return row, NewAppError("UserSyncReader.Next", "model.user_sync.channel.app_error", map[string]any{"Channel": item}, "", http.StatusBadRequest)
+			}
+			row.Channels = append(row.Channels, &UserSyncMembership{Team: team, Channel: channel, Admin: admin})
+		}
+	}
+
+	if appErr := row.IsValid(); appErr != nil {
+		return row, appErr
+	}
+
+	return row, nil
+}
+
+func splitUserSyncList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, userSyncListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (r *UserSyncRow) IsValid() *AppError {
+	if !IsValidUsername(r.Username) {
+		return NewAppError("UserSyncRow.IsValid", "model.user_sync.is_valid.username.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.Email != nil && !IsValidEmail(*r.Email) {
+		return NewAppError("UserSyncRow.IsValid", "model.user_sync.is_valid.email.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.Locale != nil && !IsValidLocale(*r.Locale) {
+		return NewAppError("UserSyncRow.IsValid", "model.user_sync.is_valid.locale.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.Roles != nil && !IsValidUserRoles(*r.Roles) {
+		return NewAppError("UserSyncRow.IsValid", "model.user_sync.is_valid.roles.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.AuthData != nil && r.Password != nil {
+		return NewAppError("UserSyncRow.IsValid", "model.user_sync.is_valid.auth_data_and_password.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, team := range r.Teams {
+		if !IsValidTeamName(team.Team) {
+			return NewAppError("UserSyncRow.IsValid", "model.user_sync.is_valid.team.app_error", map[string]any{"Team": team.Team}, "", http.StatusBadRequest)
+		}
+	}
+
+	for _, channel := range r.Channels {
+		if !IsValidTeamName(channel.Team) || !IsValidChannelIdentifier(channel.Channel) {
+			return NewAppError("UserSyncRow.IsValid", "model.user_sync.is_valid.channel.app_error", map[string]any{"Channel": channel.Team + "/" + channel.Channel}, "", http.StatusBadRequest)
+		}
+		if r.Teams != nil && !slices.ContainsFunc(r.Teams, func(team *UserSyncMembership) bool { return team.Team == channel.Team }) {
+			return NewAppError("UserSyncRow.IsValid", "model.user_sync.is_valid.channel_team.app_error", map[string]any{"Channel": channel.Team + "/" + channel.Channel}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
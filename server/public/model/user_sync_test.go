@@ -0,0 +1,169 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserSyncReader(t *testing.T) {
+	t.Run("valid header", func(t *testing.T) {
+		_, appErr := NewUserSyncReader(strings.NewReader("Username, Email,teams\n"))
+		require.Nil(t, appErr)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		_, appErr := NewUserSyncReader(strings.NewReader(""))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.user_sync.header.app_error", appErr.Id)
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		_, appErr := NewUserSyncReader(strings.NewReader("username,unknown\n"))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.user_sync.unknown_column.app_error", appErr.Id)
+	})
+
+	t.Run("duplicate column", func(t *testing.T) {
+		_, appErr := NewUserSyncReader(strings.NewReader("username,email,email\n"))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.user_sync.duplicate_column.app_error", appErr.Id)
+	})
+
+	t.Run("missing username", func(t *testing.T) {
+		_, appErr := NewUserSyncReader(strings.NewReader("email\n"))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.user_sync.missing_username.app_error", appErr.Id)
+	})
+}
+
+func TestUserSyncReaderNext(t *testing.T) {
+	content := `username,email,active,roles,teams,channels
+User1,User1@Example.com,true,system_user,team1:admin;team2,team1/town-square;team2/channel1:admin
+user2,,,,,
+user3,user3@example.com,maybe,,,
+user4,,,,team1,team2/channel1
+`
+	reader, appErr := NewUserSyncReader(strings.NewReader(content))
+	require.Nil(t, appErr)
+
+	row, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 2, row.Line)
+	assert.Equal(t, "user1", row.Username)
+	assert.Equal(t, NewPointer("user1@example.com"), row.Email)
+	assert.Equal(t, NewPointer(true), row.Active)
+	assert.Equal(t, NewPointer(SystemUserRoleId), row.Roles)
+	assert.Equal(t, []*UserSyncMembership{
+		{Team: "team1", Admin: true},
+		{Team: "team2"},
+	}, row.Teams)
+	assert.Equal(t, []*UserSyncMembership{
+		{Team: "team1", Channel: "town-square"},
+		{Team: "team2", Channel: "channel1", Admin: true},
+	}, row.Channels)
+
+	row, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 3, row.Line)
+	assert.Equal(t, "user2", row.Username)
+	assert.Nil(t, row.Email)
+	assert.Nil(t, row.Active)
+	assert.Nil(t, row.Teams)
+	assert.Nil(t, row.Channels)
+
+	row, err = reader.Next()
+	require.Error(t, err)
+	assert.Equal(t, 4, row.Line)
+	assert.Equal(t, "user3", row.Username)
+
+	row, err = reader.Next()
+	require.Error(t, err)
+	assert.Equal(t, 5, row.Line)
+	var appErr2 *AppError
+	require.ErrorAs(t, err, &appErr2)
+	assert.Equal(t, "model.user_sync.is_valid.channel_team.app_error", appErr2.Id)
+
+	_, err = reader.Next()
+	assert.Equal(t, io.EOF, err)
+
+	t.Run("parse error", func(t *testing.T) {
+		reader, appErr := NewUserSyncReader(strings.NewReader("username\n\"user1\n"))
+		require.Nil(t, appErr)
+
+		row, err := reader.Next()
+		require.Error(t, err)
+		require.NotNil(t, row)
+		assert.Equal(t, 2, row.Line)
+		var appErr2 *AppError
+		require.ErrorAs(t, err, &appErr2)
+		assert.Equal(t, "model.user_sync.parse.app_error", appErr2.Id)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("read failed")
+		reader, appErr := NewUserSyncReader(io.MultiReader(strings.NewReader("username\n"), iotest.ErrReader(readErr)))
+		require.Nil(t, appErr)
+
+		row, err := reader.Next()
+		assert.Nil(t, row)
+		assert.ErrorIs(t, err, readErr)
+	})
+}
+
+func TestUserSyncRowIsValid(t *testing.T) {
+	valid := func() *UserSyncRow {
+		return &UserSyncRow{Username: "user1"}
+	}
+
+	for name, tc := range map[string]struct {
+		update func(row *UserSyncRow)
+		errID  string
+	}{
+		"valid":                  {update: func(row *UserSyncRow) {}},
+		"invalid username":       {update: func(row *UserSyncRow) { row.Username = "" }, errID: "model.user_sync.is_valid.username.app_error"},
+		"invalid email":          {update: func(row *UserSyncRow) { row.Email = NewPointer("invalid") }, errID: "model.user_sync.is_valid.email.app_error"},
+		"invalid locale":         {update: func(row *UserSyncRow) { row.Locale = NewPointer("invalid") }, errID: "model.user_sync.is_valid.locale.app_error"},
+		"invalid roles":          {update: func(row *UserSyncRow) { row.Roles = NewPointer("Invalid-Role") }, errID: "model.user_sync.is_valid.roles.app_error"},
+		"auth data and password": {update: func(row *UserSyncRow) { row.AuthData = NewPointer("id"); row.Password = NewPointer("password") }, errID: "model.user_sync.is_valid.auth_data_and_password.app_error"},
+		"invalid team":           {update: func(row *UserSyncRow) { row.Teams = []*UserSyncMembership{{Team: "-"}} }, errID: "model.user_sync.is_valid.team.app_error"},
+		"invalid channel": {update: func(row *UserSyncRow) {
+			row.Channels = []*UserSyncMembership{{Team: "team1", Channel: "!"}}
+		}, errID: "model.user_sync.is_valid.channel.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			row := valid()
+			tc.update(row)
+			appErr := row.IsValid()
+			if tc.errID == "" {
+				require.Nil(t, appErr)
+				return
+			}
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.errID, appErr.Id)
+		})
+	}
+}
+
+func TestUserSyncResult(t *testing.T) {
+	result := &UserSyncResult{}
+	for _, action := range []string{UserSyncActionCreate, UserSyncActionCreate, UserSyncActionUpdate, UserSyncActionDeactivate, UserSyncActionNone, UserSyncActionError} {
+		result.Add(&UserSyncReportRow{Action: action})
+	}
+
+	assert.Equal(t, StringMap{
+		"created":     "2",
+		"updated":     "1",
+		"deactivated": "1",
+		"unchanged":   "1",
+		"failed":      "1",
+	}, result.ToJobData())
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// COSE algorithm identifiers of the public keys accepted for WebAuthn credentials.
+const (
+	WebAuthnAlgES256 = -7
+	WebAuthnAlgEdDSA = -8
+	WebAuthnAlgRS256 = -257
+)
+
+const (
+	WebAuthnCredentialType = "public-key"
+
+	WebAuthnUserVerificationRequired  = "required"
+	WebAuthnUserVerificationPreferred = "preferred"
+
+	WebAuthnResidentKeyPreferred = "preferred"
+
+	WebAuthnAttestationNone = "none"
+
+	// WebAuthnTimeout is how long browsers are given to complete a ceremony, in milliseconds.
+	WebAuthnTimeout = 1000 * 60 * 5
+
+	WebAuthnMaxCredentialsPerUser = 10
+
+	WebAuthnCredentialNameMaxRunes  = 64
+	WebAuthnCredentialIdMaxLength   = 1400
+	WebAuthnCredentialTransportsMax = 256
+)
+
+// WebAuthnCredential is a security key or passkey registered by a user, used as a second factor
+// or for passwordless login.
+type WebAuthnCredential struct {
+	Id     string `json:"id"`
+	UserId string `json:"user_id"`
+	Name   string `json:"name"`
+
+	// CredentialId is the ID of the credential on the authenticator, base64url encoded.
+	CredentialId string `json:"credential_id"`
+
+	// PublicKey is the COSE encoded public key of the credential.
+	PublicKey []byte `json:"-"`
+
+	// SignCount is the last signature counter reported by the authenticator, used to detect
+	// cloned authenticators. Authenticators not implementing a counter always report zero.
+	SignCount int64 `json:"-"`
+
+	AAGUID     string      `json:"aaguid"`
+	Transports StringArray `json:"transports"`
+	CreateAt   int64       `json:"create_at"`
+	LastUsedAt int64       `json:"last_used_at"`
+}
+
+func (c *WebAuthnCredential) PreSave() {
+	if c.Id == "" {
+		c.Id = NewId()
+	}
+
+	if c.CreateAt == 0 {
+		c.CreateAt = GetMillis()
+	}
+
+	if c.Transports == nil {
+		c.Transports = StringArray{}
+	}
+
+	c.Name = strings.TrimSpace(c.Name)
+}
+
+func (c *WebAuthnCredential) IsValid() *AppError {
+	if !IsValidId(c.Id) {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(c.UserId) {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.user_id.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.Name == "" || utf8.RuneCountInString(c.Name) > WebAuthnCredentialNameMaxRunes {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.name.app_error", map[string]any{"MaxLength": WebAuthnCredentialNameMaxRunes}, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CredentialId == "" || len(c.CredentialId) > WebAuthnCredentialIdMaxLength || len(c.PublicKey) == 0 {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.credential.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if len(strings.Join(c.Transports, ",")) > WebAuthnCredentialTransportsMax {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.transports.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	if c.CreateAt == 0 {
+		return NewAppError("WebAuthnCredential.IsValid", "model.webauthn_credential.is_valid.create_at.app_error", nil, "id="+c.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Descriptor returns the credential as listed in the options of a WebAuthn ceremony.
+func (c *WebAuthnCredential) Descriptor() WebAuthnCredentialDescriptor {
+	return WebAuthnCredentialDescriptor{
+		Type:       WebAuthnCredentialType,
+		Id:         c.CredentialId,
+		Transports: c.Transports,
+	}
+}
+
+// The following types mirror the JSON serialization of the WebAuthn Level 3 API, so that
+// browsers can pass them to and from navigator.credentials as is. Binary values are base64url
+// encoded without padding.
+
+type WebAuthnRelyingParty struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+type WebAuthnUserEntity struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type WebAuthnCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type WebAuthnCredentialDescriptor struct {
+	Type       string   `json:"type"`
+	Id         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+type WebAuthnAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// WebAuthnCreationOptions are the options of a registration ceremony, passed to
+// navigator.credentials.create.
+type WebAuthnCreationOptions struct {
+	Challenge              string                         `json:"challenge"`
+	RelyingParty           WebAuthnRelyingParty           `json:"rp"`
+	User                   WebAuthnUserEntity             `json:"user"`
+	PubKeyCredParams       []WebAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                          `json:"timeout"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                         `json:"attestation"`
+}
+
+// WebAuthnRequestOptions are the options of an authentication ceremony, passed to
+// navigator.credentials.get. No allowed credentials means any discoverable credential of the
+// relying party may be used.
+type WebAuthnRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	Timeout          int64                          `json:"timeout"`
+	RPId             string                         `json:"rpId"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+type WebAuthnAttestationData struct {
+	ClientDataJSON    string   `json:"clientDataJSON"`
+	AttestationObject string   `json:"attestationObject"`
+	Transports        []string `json:"transports,omitempty"`
+}
+
+// WebAuthnAttestationResponse is the credential returned by navigator.credentials.create.
+type WebAuthnAttestationResponse struct {
+	Id       string                  `json:"id"`
+	RawId    string                  `json:"rawId"`
+	Type     string                  `json:"type"`
+	Response WebAuthnAttestationData `json:"response"`
+}
+
+type WebAuthnAssertionData struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle,omitempty"`
+}
+
+// WebAuthnAssertionResponse is the credential returned by navigator.credentials.get.
+type WebAuthnAssertionResponse struct {
+	Id       string                `json:"id"`
+	RawId    string                `json:"rawId"`
+	Type     string                `json:"type"`
+	Response WebAuthnAssertionData `json:"response"`
+}
+
+// ToMfaToken encodes the assertion so that it can be sent in place of a TOTP code wherever an
+// MFA token is accepted, such as when logging in.
+func (a *WebAuthnAssertionResponse) ToMfaToken() string {
+	b, _ := json.Marshal(a)
+	return string(b)
+}
+
+// WebAuthnAssertionFromMfaToken decodes an assertion sent as an MFA token, reporting false when
+// the token is a TOTP code instead.
+func WebAuthnAssertionFromMfaToken(token string) (*WebAuthnAssertionResponse, bool) {
+	token = strings.TrimSpace(token)
+	if !strings.HasPrefix(token, "{") {
+		return nil, false
+	}
+
+	var assertion WebAuthnAssertionResponse
+	if err := json.Unmarshal([]byte(token), &assertion); err != nil || assertion.Response.Signature == "" {
+		return nil, false
+	}
+
+	return &assertion, true
+}
+
+// WebAuthnRegistrationRequest completes the registration of a credential.
+type WebAuthnRegistrationRequest struct {
+	Name       string                       `json:"name"`
+	Credential *WebAuthnAttestationResponse `json:"credential"`
+}
+
+// WebAuthnLoginRequest logs a user in with a passkey, without a password.
+type WebAuthnLoginRequest struct {
+	Credential *WebAuthnAssertionResponse `json:"credential"`
+	DeviceId   string                     `json:"device_id,omitempty"`
+}
+
+// WebAuthnUserHandle returns the user handle identifying the user to authenticators.
+func WebAuthnUserHandle(userID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(userID))
+}
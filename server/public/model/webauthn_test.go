@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebAuthnCredentialIsValid(t *testing.T) {
+	newCredential := func() *WebAuthnCredential {
+		c := &WebAuthnCredential{
+			UserId:       NewId(),
+			Name:         " Security key ",
+			CredentialId: "Y3JlZGVudGlhbA",
+			PublicKey:    []byte{0xa5},
+		}
+		c.PreSave()
+		return c
+	}
+
+	c := newCredential()
+	require.Nil(t, c.IsValid())
+	assert.Equal(t, "Security key", c.Name)
+	assert.NotNil(t, c.Transports)
+
+	for name, tc := range map[string]struct {
+		update func(*WebAuthnCredential)
+		id     string
+	}{
+		"user id":    {func(c *WebAuthnCredential) { c.UserId = "" }, "model.webauthn_credential.is_valid.user_id.app_error"},
+		"empty name": {func(c *WebAuthnCredential) { c.Name = "" }, "model.webauthn_credential.is_valid.name.app_error"},
+		"long name":  {func(c *WebAuthnCredential) { c.Name = strings.Repeat("a", WebAuthnCredentialNameMaxRunes+1) }, "model.webauthn_credential.is_valid.name.app_error"},
+		"public key": {func(c *WebAuthnCredential) { c.PublicKey = nil }, "model.webauthn_credential.is_valid.credential.app_error"},
+		"long id":    {func(c *WebAuthnCredential) { c.CredentialId = strings.Repeat("a", WebAuthnCredentialIdMaxLength+1) }, "model.webauthn_credential.is_valid.credential.app_error"},
+		"transports": {func(c *WebAuthnCredential) {
+			c.Transports = StringArray{strings.Repeat("a", WebAuthnCredentialTransportsMax+1)}
+		}, "model.webauthn_credential.is_valid.transports.app_error"},
+		"create at": {func(c *WebAuthnCredential) { c.CreateAt = 0 }, "model.webauthn_credential.is_valid.create_at.app_error"},
+		"id":        {func(c *WebAuthnCredential) { c.Id = "" }, "model.webauthn_credential.is_valid.id.app_error"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := newCredential()
+			tc.update(c)
+			appErr := c.IsValid()
+			require.NotNil(t, appErr)
+			assert.Equal(t, tc.id, appErr.Id)
+		})
+	}
+}
+
+func TestWebAuthnAssertionFromMfaToken(t *testing.T) {
+	assertion := &WebAuthnAssertionResponse{
+		Id:    "Y3JlZGVudGlhbA",
+		RawId: "Y3JlZGVudGlhbA",
+		Type:  WebAuthnCredentialType,
+		Response: WebAuthnAssertionData{
+			ClientDataJSON:    "e30",
+			AuthenticatorData: "AA",
+			Signature:         "c2lnbmF0dXJl",
+		},
+	}
+
+	decoded, ok := WebAuthnAssertionFromMfaToken(assertion.ToMfaToken())
+	require.True(t, ok)
+	assert.Equal(t, assertion, decoded)
+
+	_, ok = WebAuthnAssertionFromMfaToken("123456")
+	assert.False(t, ok)
+
+	_, ok = WebAuthnAssertionFromMfaToken("{not json")
+	assert.False(t, ok)
+
+	_, ok = WebAuthnAssertionFromMfaToken(`{"id": "Y3JlZGVudGlhbA"}`)
+	assert.False(t, ok)
+}
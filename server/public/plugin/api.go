@@ -943,6 +943,17 @@ type API interface {
 	// Minimum server version: 5.20
 	KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError)
 
+	// KVSetBatch applies several writes, unique per plugin, in a single transaction. Atomic writes
+	// compare the current value with their old value as KVSetWithOptions does, and check-only
+	// operations compare without writing. If any comparison fails, nothing is written.
+	// Returns (false, err) if DB error occurred
+	// Returns (false, nil) if a comparison failed and nothing was written
+	// Returns (true, nil) if all the writes were applied
+	//
+	// @tag KeyValueStore
+	// Minimum server version: 11.6
+	KVSetBatch(operations []*model.PluginKVSetOperation) (bool, *model.AppError)
+
 	// KVSet stores a key-value pair with an expiry time, unique per plugin.
 	//
 	// @tag KeyValueStore
@@ -973,6 +984,14 @@ type API interface {
 	// Minimum server version: 5.6
 	KVList(page, perPage int) ([]string, *model.AppError)
 
+	// KVListWithOptions lists key-value pairs for a plugin in key order, including their values
+	// and expiry times. Iterate over a range of keys by passing the last key listed as the
+	// AfterKey of the next call.
+	//
+	// @tag KeyValueStore
+	// Minimum server version: 11.6
+	KVListWithOptions(options model.PluginKVListOptions) ([]*model.PluginKeyValue, *model.AppError)
+
 	// PublishWebSocketEvent sends an event to WebSocket connections.
 	// event is the type and will be prepended with "custom_<pluginid>_".
 	// payload is the data sent with the event. Interface values must be primitive Go types or mattermost-server/model types.
@@ -1084,6 +1103,17 @@ type API interface {
 	// Minimum server version: 5.18
 	PluginHTTP(request *http.Request) *http.Response
 
+	// CallPlugin calls a method another plugin exports in the ExportedMethods of its manifest,
+	// passing args to its OnPluginCall hook and returning the result. The calling plugin must
+	// declare the other plugin as a dependency in its manifest.
+	//
+	// An error is returned if the other plugin is not active, doesn't export the method, or
+	// fails to serve the call.
+	//
+	// @tag Plugin
+	// Minimum server version: 11.6
+	CallPlugin(pluginID, method string, args []byte) ([]byte, *model.AppError)
+
 	// PublishUserTyping publishes a user is typing WebSocket event.
 	// The parentId parameter may be an empty string, the other parameters are required.
 	//
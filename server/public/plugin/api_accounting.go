@@ -0,0 +1,246 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// APIQuotaExceededErrorID is the id of the error returned by plugin API methods called by a
+// plugin over its quota. Methods without an error return value are counted but never rejected.
+const APIQuotaExceededErrorID = "plugin.api.quota_exceeded.app_error"
+
+const apiQuotaWindow = time.Minute
+
+// apiAccounting counts the plugin API calls and database queries made by each plugin, and
+// enforces the configured API quotas.
+type apiAccounting struct {
+	metrics metricsInterface
+	quotas  atomic.Pointer[model.PluginAPIQuotas]
+
+	mut     sync.RWMutex
+	plugins map[string]*pluginAPIUsage
+}
+
+func newAPIAccounting(metrics metricsInterface) *apiAccounting {
+	accounting := &apiAccounting{
+		metrics: metrics,
+		plugins: make(map[string]*pluginAPIUsage),
+	}
+	accounting.quotas.Store(&model.PluginAPIQuotas{})
+	return accounting
+}
+
+func (a *apiAccounting) setQuotas(quotas model.PluginAPIQuotas) {
+	a.quotas.Store(&quotas)
+}
+
+// forPlugin returns the usage of a plugin, which is kept across restarts of the plugin.
+func (a *apiAccounting) forPlugin(pluginID string) *pluginAPIUsage {
+	a.mut.RLock()
+	usage, ok := a.plugins[pluginID]
+	a.mut.RUnlock()
+	if ok {
+		return usage
+	}
+
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	if usage, ok = a.plugins[pluginID]; !ok {
+		usage = &pluginAPIUsage{
+			accounting:    a,
+			pluginID:      pluginID,
+			methods:       make(map[string]*model.PluginAPIMethodUsage),
+			windowMethods: make(map[string]int),
+		}
+		a.plugins[pluginID] = usage
+	}
+	return usage
+}
+
+// usage returns the usage of all plugins, heaviest database consumer first.
+func (a *apiAccounting) usage() []*model.PluginAPIUsage {
+	a.mut.RLock()
+	plugins := make([]*pluginAPIUsage, 0, len(a.plugins))
+	for _, usage := range a.plugins {
+		plugins = append(plugins, usage)
+	}
+	a.mut.RUnlock()
+
+	result := make([]*model.PluginAPIUsage, 0, len(plugins))
+	for _, usage := range plugins {
+		result = append(result, usage.snapshot())
+	}
+
+	slices.SortFunc(result, func(a, b *model.PluginAPIUsage) int {
+		return cmp.Or(
+			cmp.Compare(b.DatabaseTimeMillis, a.DatabaseTimeMillis),
+			cmp.Compare(b.Calls, a.Calls),
+			strings.Compare(a.PluginId, b.PluginId),
+		)
+	})
+	return result
+}
+
+// pluginAPIUsage is the API and database usage of a single plugin.
+type pluginAPIUsage struct {
+	accounting *apiAccounting
+	pluginID   string
+
+	mut           sync.Mutex
+	methods       map[string]*model.PluginAPIMethodUsage
+	dbCalls       int64
+	dbTime        time.Duration
+	apiTime       time.Duration
+	apiDBTime     time.Duration
+	apiDBCalls    int64
+	windowStart   time.Time
+	windowTotal   int
+	windowMethods map[string]int
+}
+
+// startCall counts a call to an API method. If enforce is set and the call is over quota, the
+// call is counted as rejected and the error to return to the plugin is returned.
+func (u *pluginAPIUsage) startCall(method string, enforce bool) *model.AppError {
+	quotas := u.accounting.quotas.Load()
+
+	u.mut.Lock()
+	now := time.Now()
+	if now.Sub(u.windowStart) >= apiQuotaWindow {
+		u.windowStart = now
+		u.windowTotal = 0
+		clear(u.windowMethods)
+	}
+
+	methodUsage := u.getMethod(method)
+	quota, overQuota := 0, false
+	if enforce {
+		if methodQuota := quotas.MethodCallsPerMinute[method]; methodQuota > 0 && u.windowMethods[method] >= methodQuota {
+			quota, overQuota = methodQuota, true
+		} else if quotas.CallsPerMinute > 0 && u.windowTotal >= quotas.CallsPerMinute {
+			quota, overQuota = quotas.CallsPerMinute, true
+		}
+	}
+
+	if overQuota {
+		methodUsage.RejectedCalls++
+	} else {
+		methodUsage.Calls++
+		u.windowTotal++
+		u.windowMethods[method]++
+	}
+	u.mut.Unlock()
+
+	if !overQuota {
+		return nil
+	}
+
+	if u.accounting.metrics != nil {
+		u.accounting.metrics.IncrementPluginAPIQuotaExceeded(u.pluginID, method)
+	}
+	return model.NewAppError(method, APIQuotaExceededErrorID, map[string]any{"Method": method, "Quota": quota}, "plugin_id="+u.pluginID, http.StatusTooManyRequests)
+}
+
+// endCall records the time spent serving a call to an API method.
+func (u *pluginAPIUsage) endCall(method string, elapsed time.Duration) {
+	u.mut.Lock()
+	defer u.mut.Unlock()
+
+	u.getMethod(method).TimeMillis += elapsed.Milliseconds()
+	u.apiTime += elapsed
+	if isDatabaseAPIMethod(method) {
+		u.apiDBCalls++
+		u.apiDBTime += elapsed
+	}
+}
+
+// recordQuery records a query made by the plugin through the database driver.
+func (u *pluginAPIUsage) recordQuery(elapsed time.Duration) {
+	u.mut.Lock()
+	u.dbCalls++
+	u.dbTime += elapsed
+	u.mut.Unlock()
+
+	if u.accounting.metrics != nil {
+		u.accounting.metrics.ObservePluginDatabaseQueryDuration(u.pluginID, elapsed.Seconds())
+	}
+}
+
+func (u *pluginAPIUsage) getMethod(method string) *model.PluginAPIMethodUsage {
+	methodUsage, ok := u.methods[method]
+	if !ok {
+		methodUsage = &model.PluginAPIMethodUsage{Method: method}
+		u.methods[method] = methodUsage
+	}
+	return methodUsage
+}
+
+func (u *pluginAPIUsage) snapshot() *model.PluginAPIUsage {
+	u.mut.Lock()
+	defer u.mut.Unlock()
+
+	usage := &model.PluginAPIUsage{
+		PluginId:           u.pluginID,
+		TimeMillis:         u.apiTime.Milliseconds(),
+		DatabaseCalls:      u.dbCalls + u.apiDBCalls,
+		DatabaseTimeMillis: (u.dbTime + u.apiDBTime).Milliseconds(),
+		Methods:            make([]*model.PluginAPIMethodUsage, 0, len(u.methods)),
+	}
+	for _, methodUsage := range u.methods {
+		methodUsage := *methodUsage
+		usage.Calls += methodUsage.Calls
+		usage.RejectedCalls += methodUsage.RejectedCalls
+		usage.Methods = append(usage.Methods, &methodUsage)
+	}
+
+	slices.SortFunc(usage.Methods, func(a, b *model.PluginAPIMethodUsage) int {
+		return cmp.Or(cmp.Compare(b.Calls, a.Calls), strings.Compare(a.Method, b.Method))
+	})
+	return usage
+}
+
+// isDatabaseAPIMethod reports whether an API method is served by the plugin key value store,
+// which is backed by the database.
+func isDatabaseAPIMethod(method string) bool {
+	return strings.HasPrefix(method, "KV")
+}
+
+// accountedDriver records the queries made by a plugin through the database driver.
+type accountedDriver struct {
+	AppDriver
+	usage *pluginAPIUsage
+}
+
+func (d *accountedDriver) ConnQuery(connID, q string, args []driver.NamedValue) (string, error) {
+	defer d.recordQuery(time.Now())
+	return d.AppDriver.ConnQuery(connID, q, args)
+}
+
+func (d *accountedDriver) ConnExec(connID, q string, args []driver.NamedValue) (ResultContainer, error) {
+	defer d.recordQuery(time.Now())
+	return d.AppDriver.ConnExec(connID, q, args)
+}
+
+func (d *accountedDriver) StmtQuery(stID string, args []driver.NamedValue) (string, error) {
+	defer d.recordQuery(time.Now())
+	return d.AppDriver.StmtQuery(stID, args)
+}
+
+func (d *accountedDriver) StmtExec(stID string, args []driver.NamedValue) (ResultContainer, error) {
+	defer d.recordQuery(time.Now())
+	return d.AppDriver.StmtExec(stID, args)
+}
+
+func (d *accountedDriver) recordQuery(startTime time.Time) {
+	d.usage.recordQuery(time.Since(startTime))
+}
@@ -0,0 +1,2365 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make pluginapi"
+// DO NOT EDIT
+
+package plugin
+
+import (
+	"io"
+	"net/http"
+	timePkg "time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type apiAccountingLayer struct {
+	apiImpl API
+	usage   *pluginAPIUsage
+}
+
+func (api *apiAccountingLayer) LoadPluginConfiguration(dest any) error {
+	if appErr := api.usage.startCall("LoadPluginConfiguration", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.LoadPluginConfiguration(dest)
+	api.usage.endCall("LoadPluginConfiguration", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) RegisterCommand(command *model.Command) error {
+	if appErr := api.usage.startCall("RegisterCommand", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RegisterCommand(command)
+	api.usage.endCall("RegisterCommand", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) UnregisterCommand(teamID, trigger string) error {
+	if appErr := api.usage.startCall("UnregisterCommand", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UnregisterCommand(teamID, trigger)
+	api.usage.endCall("UnregisterCommand", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) ExecuteSlashCommand(commandArgs *model.CommandArgs) (*model.CommandResponse, error) {
+	if appErr := api.usage.startCall("ExecuteSlashCommand", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.ExecuteSlashCommand(commandArgs)
+	api.usage.endCall("ExecuteSlashCommand", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetConfig() *model.Config {
+	api.usage.startCall("GetConfig", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.GetConfig()
+	api.usage.endCall("GetConfig", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetUnsanitizedConfig() *model.Config {
+	api.usage.startCall("GetUnsanitizedConfig", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.GetUnsanitizedConfig()
+	api.usage.endCall("GetUnsanitizedConfig", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) SaveConfig(config *model.Config) *model.AppError {
+	if appErr := api.usage.startCall("SaveConfig", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SaveConfig(config)
+	api.usage.endCall("SaveConfig", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetPluginConfig() map[string]any {
+	api.usage.startCall("GetPluginConfig", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.GetPluginConfig()
+	api.usage.endCall("GetPluginConfig", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) SavePluginConfig(config map[string]any) *model.AppError {
+	if appErr := api.usage.startCall("SavePluginConfig", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SavePluginConfig(config)
+	api.usage.endCall("SavePluginConfig", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetBundlePath() (string, error) {
+	if appErr := api.usage.startCall("GetBundlePath", true); appErr != nil {
+		return "", appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetBundlePath()
+	api.usage.endCall("GetBundlePath", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetLicense() *model.License {
+	api.usage.startCall("GetLicense", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.GetLicense()
+	api.usage.endCall("GetLicense", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) IsEnterpriseReady() bool {
+	api.usage.startCall("IsEnterpriseReady", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.IsEnterpriseReady()
+	api.usage.endCall("IsEnterpriseReady", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetServerVersion() string {
+	api.usage.startCall("GetServerVersion", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.GetServerVersion()
+	api.usage.endCall("GetServerVersion", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetSystemInstallDate() (int64, *model.AppError) {
+	if appErr := api.usage.startCall("GetSystemInstallDate", true); appErr != nil {
+		return 0, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetSystemInstallDate()
+	api.usage.endCall("GetSystemInstallDate", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetDiagnosticId() string {
+	api.usage.startCall("GetDiagnosticId", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.GetDiagnosticId()
+	api.usage.endCall("GetDiagnosticId", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetTelemetryId() string {
+	api.usage.startCall("GetTelemetryId", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.GetTelemetryId()
+	api.usage.endCall("GetTelemetryId", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) CreateUser(user *model.User) (*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("CreateUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateUser(user)
+	api.usage.endCall("CreateUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeleteUser(userID string) *model.AppError {
+	if appErr := api.usage.startCall("DeleteUser", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeleteUser(userID)
+	api.usage.endCall("DeleteUser", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetUsers(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("GetUsers", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUsers(options)
+	api.usage.endCall("GetUsers", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetUsersByIds(userIDs []string) ([]*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("GetUsersByIds", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUsersByIds(userIDs)
+	api.usage.endCall("GetUsersByIds", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetUser(userID string) (*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("GetUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUser(userID)
+	api.usage.endCall("GetUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetUserByEmail(email string) (*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("GetUserByEmail", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUserByEmail(email)
+	api.usage.endCall("GetUserByEmail", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetUserByUsername(name string) (*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("GetUserByUsername", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUserByUsername(name)
+	api.usage.endCall("GetUserByUsername", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetUsersByUsernames(usernames []string) ([]*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("GetUsersByUsernames", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUsersByUsernames(usernames)
+	api.usage.endCall("GetUsersByUsernames", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetUsersInTeam(teamID string, page int, perPage int) ([]*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("GetUsersInTeam", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUsersInTeam(teamID, page, perPage)
+	api.usage.endCall("GetUsersInTeam", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPreferenceForUser(userID, category, name string) (model.Preference, *model.AppError) {
+	if appErr := api.usage.startCall("GetPreferenceForUser", true); appErr != nil {
+		return *new(model.Preference), appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPreferenceForUser(userID, category, name)
+	api.usage.endCall("GetPreferenceForUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPreferencesForUser(userID string) ([]model.Preference, *model.AppError) {
+	if appErr := api.usage.startCall("GetPreferencesForUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPreferencesForUser(userID)
+	api.usage.endCall("GetPreferencesForUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdatePreferencesForUser(userID string, preferences []model.Preference) *model.AppError {
+	if appErr := api.usage.startCall("UpdatePreferencesForUser", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UpdatePreferencesForUser(userID, preferences)
+	api.usage.endCall("UpdatePreferencesForUser", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) DeletePreferencesForUser(userID string, preferences []model.Preference) *model.AppError {
+	if appErr := api.usage.startCall("DeletePreferencesForUser", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeletePreferencesForUser(userID, preferences)
+	api.usage.endCall("DeletePreferencesForUser", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetSession(sessionID string) (*model.Session, *model.AppError) {
+	if appErr := api.usage.startCall("GetSession", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetSession(sessionID)
+	api.usage.endCall("GetSession", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CreateSession(session *model.Session) (*model.Session, *model.AppError) {
+	if appErr := api.usage.startCall("CreateSession", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateSession(session)
+	api.usage.endCall("CreateSession", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) ExtendSessionExpiry(sessionID string, newExpiry int64) *model.AppError {
+	if appErr := api.usage.startCall("ExtendSessionExpiry", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.ExtendSessionExpiry(sessionID, newExpiry)
+	api.usage.endCall("ExtendSessionExpiry", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) RevokeSession(sessionID string) *model.AppError {
+	if appErr := api.usage.startCall("RevokeSession", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RevokeSession(sessionID)
+	api.usage.endCall("RevokeSession", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) CreateUserAccessToken(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
+	if appErr := api.usage.startCall("CreateUserAccessToken", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateUserAccessToken(token)
+	api.usage.endCall("CreateUserAccessToken", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) RevokeUserAccessToken(tokenID string) *model.AppError {
+	if appErr := api.usage.startCall("RevokeUserAccessToken", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RevokeUserAccessToken(tokenID)
+	api.usage.endCall("RevokeUserAccessToken", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetTeamIcon(teamID string) ([]byte, *model.AppError) {
+	if appErr := api.usage.startCall("GetTeamIcon", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetTeamIcon(teamID)
+	api.usage.endCall("GetTeamIcon", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SetTeamIcon(teamID string, data []byte) *model.AppError {
+	if appErr := api.usage.startCall("SetTeamIcon", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SetTeamIcon(teamID, data)
+	api.usage.endCall("SetTeamIcon", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) RemoveTeamIcon(teamID string) *model.AppError {
+	if appErr := api.usage.startCall("RemoveTeamIcon", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RemoveTeamIcon(teamID)
+	api.usage.endCall("RemoveTeamIcon", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) UpdateUser(user *model.User) (*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateUser(user)
+	api.usage.endCall("UpdateUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetUserStatus(userID string) (*model.Status, *model.AppError) {
+	if appErr := api.usage.startCall("GetUserStatus", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUserStatus(userID)
+	api.usage.endCall("GetUserStatus", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetUserStatusesByIds(userIds []string) ([]*model.Status, *model.AppError) {
+	if appErr := api.usage.startCall("GetUserStatusesByIds", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUserStatusesByIds(userIds)
+	api.usage.endCall("GetUserStatusesByIds", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateUserStatus(userID, status string) (*model.Status, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateUserStatus", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateUserStatus(userID, status)
+	api.usage.endCall("UpdateUserStatus", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SetUserStatusTimedDND(userId string, endtime int64) (*model.Status, *model.AppError) {
+	if appErr := api.usage.startCall("SetUserStatusTimedDND", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SetUserStatusTimedDND(userId, endtime)
+	api.usage.endCall("SetUserStatusTimedDND", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateUserActive(userID string, active bool) *model.AppError {
+	if appErr := api.usage.startCall("UpdateUserActive", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UpdateUserActive(userID, active)
+	api.usage.endCall("UpdateUserActive", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) UpdateUserCustomStatus(userID string, customStatus *model.CustomStatus) *model.AppError {
+	if appErr := api.usage.startCall("UpdateUserCustomStatus", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UpdateUserCustomStatus(userID, customStatus)
+	api.usage.endCall("UpdateUserCustomStatus", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) RemoveUserCustomStatus(userID string) *model.AppError {
+	if appErr := api.usage.startCall("RemoveUserCustomStatus", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RemoveUserCustomStatus(userID)
+	api.usage.endCall("RemoveUserCustomStatus", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetUsersInChannel(channelID, sortBy string, page, perPage int) ([]*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("GetUsersInChannel", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUsersInChannel(channelID, sortBy, page, perPage)
+	api.usage.endCall("GetUsersInChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetLDAPUserAttributes(userID string, attributes []string) (map[string]string, *model.AppError) {
+	if appErr := api.usage.startCall("GetLDAPUserAttributes", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetLDAPUserAttributes(userID, attributes)
+	api.usage.endCall("GetLDAPUserAttributes", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CreateTeam(team *model.Team) (*model.Team, *model.AppError) {
+	if appErr := api.usage.startCall("CreateTeam", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateTeam(team)
+	api.usage.endCall("CreateTeam", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeleteTeam(teamID string) *model.AppError {
+	if appErr := api.usage.startCall("DeleteTeam", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeleteTeam(teamID)
+	api.usage.endCall("DeleteTeam", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetTeams() ([]*model.Team, *model.AppError) {
+	if appErr := api.usage.startCall("GetTeams", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetTeams()
+	api.usage.endCall("GetTeams", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetTeam(teamID string) (*model.Team, *model.AppError) {
+	if appErr := api.usage.startCall("GetTeam", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetTeam(teamID)
+	api.usage.endCall("GetTeam", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetTeamByName(name string) (*model.Team, *model.AppError) {
+	if appErr := api.usage.startCall("GetTeamByName", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetTeamByName(name)
+	api.usage.endCall("GetTeamByName", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetTeamsUnreadForUser(userID string) ([]*model.TeamUnread, *model.AppError) {
+	if appErr := api.usage.startCall("GetTeamsUnreadForUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetTeamsUnreadForUser(userID)
+	api.usage.endCall("GetTeamsUnreadForUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateTeam(team *model.Team) (*model.Team, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateTeam", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateTeam(team)
+	api.usage.endCall("UpdateTeam", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SearchTeams(term string) ([]*model.Team, *model.AppError) {
+	if appErr := api.usage.startCall("SearchTeams", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SearchTeams(term)
+	api.usage.endCall("SearchTeams", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetTeamsForUser(userID string) ([]*model.Team, *model.AppError) {
+	if appErr := api.usage.startCall("GetTeamsForUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetTeamsForUser(userID)
+	api.usage.endCall("GetTeamsForUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CreateTeamMember(teamID, userID string) (*model.TeamMember, *model.AppError) {
+	if appErr := api.usage.startCall("CreateTeamMember", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateTeamMember(teamID, userID)
+	api.usage.endCall("CreateTeamMember", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CreateTeamMembers(teamID string, userIds []string, requestorId string) ([]*model.TeamMember, *model.AppError) {
+	if appErr := api.usage.startCall("CreateTeamMembers", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateTeamMembers(teamID, userIds, requestorId)
+	api.usage.endCall("CreateTeamMembers", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CreateTeamMembersGracefully(teamID string, userIds []string, requestorId string) ([]*model.TeamMemberWithError, *model.AppError) {
+	if appErr := api.usage.startCall("CreateTeamMembersGracefully", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateTeamMembersGracefully(teamID, userIds, requestorId)
+	api.usage.endCall("CreateTeamMembersGracefully", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeleteTeamMember(teamID, userID, requestorId string) *model.AppError {
+	if appErr := api.usage.startCall("DeleteTeamMember", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeleteTeamMember(teamID, userID, requestorId)
+	api.usage.endCall("DeleteTeamMember", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetTeamMembers(teamID string, page, perPage int) ([]*model.TeamMember, *model.AppError) {
+	if appErr := api.usage.startCall("GetTeamMembers", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetTeamMembers(teamID, page, perPage)
+	api.usage.endCall("GetTeamMembers", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetTeamMember(teamID, userID string) (*model.TeamMember, *model.AppError) {
+	if appErr := api.usage.startCall("GetTeamMember", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetTeamMember(teamID, userID)
+	api.usage.endCall("GetTeamMember", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetTeamMembersForUser(userID string, page int, perPage int) ([]*model.TeamMember, *model.AppError) {
+	if appErr := api.usage.startCall("GetTeamMembersForUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetTeamMembersForUser(userID, page, perPage)
+	api.usage.endCall("GetTeamMembersForUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateTeamMemberRoles(teamID, userID, newRoles string) (*model.TeamMember, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateTeamMemberRoles", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateTeamMemberRoles(teamID, userID, newRoles)
+	api.usage.endCall("UpdateTeamMemberRoles", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CreateChannel(channel *model.Channel) (*model.Channel, *model.AppError) {
+	if appErr := api.usage.startCall("CreateChannel", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateChannel(channel)
+	api.usage.endCall("CreateChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeleteChannel(channelId string) *model.AppError {
+	if appErr := api.usage.startCall("DeleteChannel", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeleteChannel(channelId)
+	api.usage.endCall("DeleteChannel", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetPublicChannelsForTeam(teamID string, page, perPage int) ([]*model.Channel, *model.AppError) {
+	if appErr := api.usage.startCall("GetPublicChannelsForTeam", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPublicChannelsForTeam(teamID, page, perPage)
+	api.usage.endCall("GetPublicChannelsForTeam", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetChannel(channelId string) (*model.Channel, *model.AppError) {
+	if appErr := api.usage.startCall("GetChannel", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetChannel(channelId)
+	api.usage.endCall("GetChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetChannelByName(teamID, name string, includeDeleted bool) (*model.Channel, *model.AppError) {
+	if appErr := api.usage.startCall("GetChannelByName", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetChannelByName(teamID, name, includeDeleted)
+	api.usage.endCall("GetChannelByName", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetChannelByNameForTeamName(teamName, channelName string, includeDeleted bool) (*model.Channel, *model.AppError) {
+	if appErr := api.usage.startCall("GetChannelByNameForTeamName", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetChannelByNameForTeamName(teamName, channelName, includeDeleted)
+	api.usage.endCall("GetChannelByNameForTeamName", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetChannelsForTeamForUser(teamID, userID string, includeDeleted bool) ([]*model.Channel, *model.AppError) {
+	if appErr := api.usage.startCall("GetChannelsForTeamForUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetChannelsForTeamForUser(teamID, userID, includeDeleted)
+	api.usage.endCall("GetChannelsForTeamForUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetChannelStats(channelId string) (*model.ChannelStats, *model.AppError) {
+	if appErr := api.usage.startCall("GetChannelStats", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetChannelStats(channelId)
+	api.usage.endCall("GetChannelStats", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetDirectChannel(userId1, userId2 string) (*model.Channel, *model.AppError) {
+	if appErr := api.usage.startCall("GetDirectChannel", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetDirectChannel(userId1, userId2)
+	api.usage.endCall("GetDirectChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetGroupChannel(userIds []string) (*model.Channel, *model.AppError) {
+	if appErr := api.usage.startCall("GetGroupChannel", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGroupChannel(userIds)
+	api.usage.endCall("GetGroupChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateChannel(channel *model.Channel) (*model.Channel, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateChannel", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateChannel(channel)
+	api.usage.endCall("UpdateChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SearchChannels(teamID string, term string) ([]*model.Channel, *model.AppError) {
+	if appErr := api.usage.startCall("SearchChannels", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SearchChannels(teamID, term)
+	api.usage.endCall("SearchChannels", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CreateChannelSidebarCategory(userID, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.AppError) {
+	if appErr := api.usage.startCall("CreateChannelSidebarCategory", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateChannelSidebarCategory(userID, teamID, newCategory)
+	api.usage.endCall("CreateChannelSidebarCategory", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetChannelSidebarCategories(userID, teamID string) (*model.OrderedSidebarCategories, *model.AppError) {
+	if appErr := api.usage.startCall("GetChannelSidebarCategories", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetChannelSidebarCategories(userID, teamID)
+	api.usage.endCall("GetChannelSidebarCategories", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateChannelSidebarCategories(userID, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateChannelSidebarCategories", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateChannelSidebarCategories(userID, teamID, categories)
+	api.usage.endCall("UpdateChannelSidebarCategories", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SearchUsers(search *model.UserSearch) ([]*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("SearchUsers", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SearchUsers(search)
+	api.usage.endCall("SearchUsers", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SearchPostsInTeam(teamID string, paramsList []*model.SearchParams) ([]*model.Post, *model.AppError) {
+	if appErr := api.usage.startCall("SearchPostsInTeam", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SearchPostsInTeam(teamID, paramsList)
+	api.usage.endCall("SearchPostsInTeam", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SearchPostsInTeamForUser(teamID string, userID string, searchParams model.SearchParameter) (*model.PostSearchResults, *model.AppError) {
+	if appErr := api.usage.startCall("SearchPostsInTeamForUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SearchPostsInTeamForUser(teamID, userID, searchParams)
+	api.usage.endCall("SearchPostsInTeamForUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) AddChannelMember(channelId, userID string) (*model.ChannelMember, *model.AppError) {
+	if appErr := api.usage.startCall("AddChannelMember", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.AddChannelMember(channelId, userID)
+	api.usage.endCall("AddChannelMember", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) AddUserToChannel(channelId, userID, asUserId string) (*model.ChannelMember, *model.AppError) {
+	if appErr := api.usage.startCall("AddUserToChannel", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.AddUserToChannel(channelId, userID, asUserId)
+	api.usage.endCall("AddUserToChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetChannelMember(channelId, userID string) (*model.ChannelMember, *model.AppError) {
+	if appErr := api.usage.startCall("GetChannelMember", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetChannelMember(channelId, userID)
+	api.usage.endCall("GetChannelMember", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetChannelMembers(channelId string, page, perPage int) (model.ChannelMembers, *model.AppError) {
+	if appErr := api.usage.startCall("GetChannelMembers", true); appErr != nil {
+		return *new(model.ChannelMembers), appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetChannelMembers(channelId, page, perPage)
+	api.usage.endCall("GetChannelMembers", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetChannelMembersByIds(channelId string, userIds []string) (model.ChannelMembers, *model.AppError) {
+	if appErr := api.usage.startCall("GetChannelMembersByIds", true); appErr != nil {
+		return *new(model.ChannelMembers), appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetChannelMembersByIds(channelId, userIds)
+	api.usage.endCall("GetChannelMembersByIds", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetChannelMembersForUser(teamID, userID string, page, perPage int) ([]*model.ChannelMember, *model.AppError) {
+	if appErr := api.usage.startCall("GetChannelMembersForUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetChannelMembersForUser(teamID, userID, page, perPage)
+	api.usage.endCall("GetChannelMembersForUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateChannelMemberRoles(channelId, userID, newRoles string) (*model.ChannelMember, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateChannelMemberRoles", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateChannelMemberRoles(channelId, userID, newRoles)
+	api.usage.endCall("UpdateChannelMemberRoles", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateChannelMemberNotifications(channelId, userID string, notifications map[string]string) (*model.ChannelMember, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateChannelMemberNotifications", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateChannelMemberNotifications(channelId, userID, notifications)
+	api.usage.endCall("UpdateChannelMemberNotifications", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) PatchChannelMembersNotifications(members []*model.ChannelMemberIdentifier, notifyProps map[string]string) *model.AppError {
+	if appErr := api.usage.startCall("PatchChannelMembersNotifications", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.PatchChannelMembersNotifications(members, notifyProps)
+	api.usage.endCall("PatchChannelMembersNotifications", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetGroup(groupId string) (*model.Group, *model.AppError) {
+	if appErr := api.usage.startCall("GetGroup", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGroup(groupId)
+	api.usage.endCall("GetGroup", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetGroupByName(name string) (*model.Group, *model.AppError) {
+	if appErr := api.usage.startCall("GetGroupByName", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGroupByName(name)
+	api.usage.endCall("GetGroupByName", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetGroupMemberUsers(groupID string, page, perPage int) ([]*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("GetGroupMemberUsers", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGroupMemberUsers(groupID, page, perPage)
+	api.usage.endCall("GetGroupMemberUsers", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetGroupsBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError) {
+	if appErr := api.usage.startCall("GetGroupsBySource", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGroupsBySource(groupSource)
+	api.usage.endCall("GetGroupsBySource", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetGroupsForUser(userID string) ([]*model.Group, *model.AppError) {
+	if appErr := api.usage.startCall("GetGroupsForUser", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGroupsForUser(userID)
+	api.usage.endCall("GetGroupsForUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeleteChannelMember(channelId, userID string) *model.AppError {
+	if appErr := api.usage.startCall("DeleteChannelMember", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeleteChannelMember(channelId, userID)
+	api.usage.endCall("DeleteChannelMember", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) CreatePost(post *model.Post) (*model.Post, *model.AppError) {
+	if appErr := api.usage.startCall("CreatePost", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreatePost(post)
+	api.usage.endCall("CreatePost", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) AddReaction(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	if appErr := api.usage.startCall("AddReaction", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.AddReaction(reaction)
+	api.usage.endCall("AddReaction", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) RemoveReaction(reaction *model.Reaction) *model.AppError {
+	if appErr := api.usage.startCall("RemoveReaction", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RemoveReaction(reaction)
+	api.usage.endCall("RemoveReaction", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetReactions(postId string) ([]*model.Reaction, *model.AppError) {
+	if appErr := api.usage.startCall("GetReactions", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetReactions(postId)
+	api.usage.endCall("GetReactions", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SendEphemeralPost(userID string, post *model.Post) *model.Post {
+	api.usage.startCall("SendEphemeralPost", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SendEphemeralPost(userID, post)
+	api.usage.endCall("SendEphemeralPost", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) UpdateEphemeralPost(userID string, post *model.Post) *model.Post {
+	api.usage.startCall("UpdateEphemeralPost", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UpdateEphemeralPost(userID, post)
+	api.usage.endCall("UpdateEphemeralPost", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) DeleteEphemeralPost(userID, postId string) {
+	api.usage.startCall("DeleteEphemeralPost", false)
+	startTime := timePkg.Now()
+	api.apiImpl.DeleteEphemeralPost(userID, postId)
+	api.usage.endCall("DeleteEphemeralPost", timePkg.Since(startTime))
+}
+
+func (api *apiAccountingLayer) DeletePost(postId string) *model.AppError {
+	if appErr := api.usage.startCall("DeletePost", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeletePost(postId)
+	api.usage.endCall("DeletePost", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetPostThread(postId string) (*model.PostList, *model.AppError) {
+	if appErr := api.usage.startCall("GetPostThread", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPostThread(postId)
+	api.usage.endCall("GetPostThread", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPost(postId string) (*model.Post, *model.AppError) {
+	if appErr := api.usage.startCall("GetPost", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPost(postId)
+	api.usage.endCall("GetPost", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPostsSince(channelId string, time int64) (*model.PostList, *model.AppError) {
+	if appErr := api.usage.startCall("GetPostsSince", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPostsSince(channelId, time)
+	api.usage.endCall("GetPostsSince", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPostsAfter(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError) {
+	if appErr := api.usage.startCall("GetPostsAfter", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPostsAfter(channelId, postId, page, perPage)
+	api.usage.endCall("GetPostsAfter", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPostsBefore(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError) {
+	if appErr := api.usage.startCall("GetPostsBefore", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPostsBefore(channelId, postId, page, perPage)
+	api.usage.endCall("GetPostsBefore", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPostsForChannel(channelId string, page, perPage int) (*model.PostList, *model.AppError) {
+	if appErr := api.usage.startCall("GetPostsForChannel", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPostsForChannel(channelId, page, perPage)
+	api.usage.endCall("GetPostsForChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetTeamStats(teamID string) (*model.TeamStats, *model.AppError) {
+	if appErr := api.usage.startCall("GetTeamStats", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetTeamStats(teamID)
+	api.usage.endCall("GetTeamStats", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdatePost(post *model.Post) (*model.Post, *model.AppError) {
+	if appErr := api.usage.startCall("UpdatePost", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdatePost(post)
+	api.usage.endCall("UpdatePost", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetProfileImage(userID string) ([]byte, *model.AppError) {
+	if appErr := api.usage.startCall("GetProfileImage", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetProfileImage(userID)
+	api.usage.endCall("GetProfileImage", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SetProfileImage(userID string, data []byte) *model.AppError {
+	if appErr := api.usage.startCall("SetProfileImage", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SetProfileImage(userID, data)
+	api.usage.endCall("SetProfileImage", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetEmojiList(sortBy string, page, perPage int) ([]*model.Emoji, *model.AppError) {
+	if appErr := api.usage.startCall("GetEmojiList", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetEmojiList(sortBy, page, perPage)
+	api.usage.endCall("GetEmojiList", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetEmojiByName(name string) (*model.Emoji, *model.AppError) {
+	if appErr := api.usage.startCall("GetEmojiByName", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetEmojiByName(name)
+	api.usage.endCall("GetEmojiByName", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetEmoji(emojiId string) (*model.Emoji, *model.AppError) {
+	if appErr := api.usage.startCall("GetEmoji", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetEmoji(emojiId)
+	api.usage.endCall("GetEmoji", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CopyFileInfos(userID string, fileIds []string) ([]string, *model.AppError) {
+	if appErr := api.usage.startCall("CopyFileInfos", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CopyFileInfos(userID, fileIds)
+	api.usage.endCall("CopyFileInfos", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetFileInfo(fileId string) (*model.FileInfo, *model.AppError) {
+	if appErr := api.usage.startCall("GetFileInfo", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetFileInfo(fileId)
+	api.usage.endCall("GetFileInfo", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SetFileSearchableContent(fileID string, content string) *model.AppError {
+	if appErr := api.usage.startCall("SetFileSearchableContent", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SetFileSearchableContent(fileID, content)
+	api.usage.endCall("SetFileSearchableContent", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetFileInfos(page, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, *model.AppError) {
+	if appErr := api.usage.startCall("GetFileInfos", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetFileInfos(page, perPage, opt)
+	api.usage.endCall("GetFileInfos", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetFile(fileId string) ([]byte, *model.AppError) {
+	if appErr := api.usage.startCall("GetFile", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetFile(fileId)
+	api.usage.endCall("GetFile", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetFileLink(fileId string) (string, *model.AppError) {
+	if appErr := api.usage.startCall("GetFileLink", true); appErr != nil {
+		return "", appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetFileLink(fileId)
+	api.usage.endCall("GetFileLink", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) ReadFile(path string) ([]byte, *model.AppError) {
+	if appErr := api.usage.startCall("ReadFile", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.ReadFile(path)
+	api.usage.endCall("ReadFile", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetEmojiImage(emojiId string) ([]byte, string, *model.AppError) {
+	if appErr := api.usage.startCall("GetEmojiImage", true); appErr != nil {
+		return nil, "", appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB, _returnsC := api.apiImpl.GetEmojiImage(emojiId)
+	api.usage.endCall("GetEmojiImage", timePkg.Since(startTime))
+	return _returnsA, _returnsB, _returnsC
+}
+
+func (api *apiAccountingLayer) UploadFile(data []byte, channelId string, filename string) (*model.FileInfo, *model.AppError) {
+	if appErr := api.usage.startCall("UploadFile", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UploadFile(data, channelId, filename)
+	api.usage.endCall("UploadFile", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) OpenInteractiveDialog(dialog model.OpenDialogRequest) *model.AppError {
+	if appErr := api.usage.startCall("OpenInteractiveDialog", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.OpenInteractiveDialog(dialog)
+	api.usage.endCall("OpenInteractiveDialog", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) SendToastMessage(userID, connectionID, message string, options model.SendToastMessageOptions) *model.AppError {
+	if appErr := api.usage.startCall("SendToastMessage", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SendToastMessage(userID, connectionID, message, options)
+	api.usage.endCall("SendToastMessage", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetPlugins() ([]*model.Manifest, *model.AppError) {
+	if appErr := api.usage.startCall("GetPlugins", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPlugins()
+	api.usage.endCall("GetPlugins", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) EnablePlugin(id string) *model.AppError {
+	if appErr := api.usage.startCall("EnablePlugin", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.EnablePlugin(id)
+	api.usage.endCall("EnablePlugin", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) DisablePlugin(id string) *model.AppError {
+	if appErr := api.usage.startCall("DisablePlugin", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DisablePlugin(id)
+	api.usage.endCall("DisablePlugin", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) RemovePlugin(id string) *model.AppError {
+	if appErr := api.usage.startCall("RemovePlugin", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RemovePlugin(id)
+	api.usage.endCall("RemovePlugin", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetPluginStatus(id string) (*model.PluginStatus, *model.AppError) {
+	if appErr := api.usage.startCall("GetPluginStatus", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPluginStatus(id)
+	api.usage.endCall("GetPluginStatus", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) InstallPlugin(file io.Reader, replace bool) (*model.Manifest, *model.AppError) {
+	if appErr := api.usage.startCall("InstallPlugin", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.InstallPlugin(file, replace)
+	api.usage.endCall("InstallPlugin", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) KVSet(key string, value []byte) *model.AppError {
+	if appErr := api.usage.startCall("KVSet", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.KVSet(key, value)
+	api.usage.endCall("KVSet", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) KVCompareAndSet(key string, oldValue, newValue []byte) (bool, *model.AppError) {
+	if appErr := api.usage.startCall("KVCompareAndSet", true); appErr != nil {
+		return false, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVCompareAndSet(key, oldValue, newValue)
+	api.usage.endCall("KVCompareAndSet", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) KVCompareAndDelete(key string, oldValue []byte) (bool, *model.AppError) {
+	if appErr := api.usage.startCall("KVCompareAndDelete", true); appErr != nil {
+		return false, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVCompareAndDelete(key, oldValue)
+	api.usage.endCall("KVCompareAndDelete", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	if appErr := api.usage.startCall("KVSetWithOptions", true); appErr != nil {
+		return false, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVSetWithOptions(key, value, options)
+	api.usage.endCall("KVSetWithOptions", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) KVSetBatch(operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	if appErr := api.usage.startCall("KVSetBatch", true); appErr != nil {
+		return false, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVSetBatch(operations)
+	api.usage.endCall("KVSetBatch", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError {
+	if appErr := api.usage.startCall("KVSetWithExpiry", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.KVSetWithExpiry(key, value, expireInSeconds)
+	api.usage.endCall("KVSetWithExpiry", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) KVGet(key string) ([]byte, *model.AppError) {
+	if appErr := api.usage.startCall("KVGet", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVGet(key)
+	api.usage.endCall("KVGet", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) KVDelete(key string) *model.AppError {
+	if appErr := api.usage.startCall("KVDelete", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.KVDelete(key)
+	api.usage.endCall("KVDelete", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) KVDeleteAll() *model.AppError {
+	if appErr := api.usage.startCall("KVDeleteAll", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.KVDeleteAll()
+	api.usage.endCall("KVDeleteAll", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) KVList(page, perPage int) ([]string, *model.AppError) {
+	if appErr := api.usage.startCall("KVList", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVList(page, perPage)
+	api.usage.endCall("KVList", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) KVListWithOptions(options model.PluginKVListOptions) ([]*model.PluginKeyValue, *model.AppError) {
+	if appErr := api.usage.startCall("KVListWithOptions", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVListWithOptions(options)
+	api.usage.endCall("KVListWithOptions", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) PublishWebSocketEvent(event string, payload map[string]any, broadcast *model.WebsocketBroadcast) {
+	api.usage.startCall("PublishWebSocketEvent", false)
+	startTime := timePkg.Now()
+	api.apiImpl.PublishWebSocketEvent(event, payload, broadcast)
+	api.usage.endCall("PublishWebSocketEvent", timePkg.Since(startTime))
+}
+
+func (api *apiAccountingLayer) HasPermissionTo(userID string, permission *model.Permission) bool {
+	api.usage.startCall("HasPermissionTo", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.HasPermissionTo(userID, permission)
+	api.usage.endCall("HasPermissionTo", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) HasPermissionToTeam(userID, teamID string, permission *model.Permission) bool {
+	api.usage.startCall("HasPermissionToTeam", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.HasPermissionToTeam(userID, teamID, permission)
+	api.usage.endCall("HasPermissionToTeam", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) HasPermissionToChannel(userID, channelId string, permission *model.Permission) bool {
+	api.usage.startCall("HasPermissionToChannel", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.HasPermissionToChannel(userID, channelId, permission)
+	api.usage.endCall("HasPermissionToChannel", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) RolesGrantPermission(roleNames []string, permissionId string) bool {
+	api.usage.startCall("RolesGrantPermission", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RolesGrantPermission(roleNames, permissionId)
+	api.usage.endCall("RolesGrantPermission", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) LogDebug(msg string, keyValuePairs ...any) {
+	api.usage.startCall("LogDebug", false)
+	startTime := timePkg.Now()
+	api.apiImpl.LogDebug(msg, keyValuePairs...)
+	api.usage.endCall("LogDebug", timePkg.Since(startTime))
+}
+
+func (api *apiAccountingLayer) LogInfo(msg string, keyValuePairs ...any) {
+	api.usage.startCall("LogInfo", false)
+	startTime := timePkg.Now()
+	api.apiImpl.LogInfo(msg, keyValuePairs...)
+	api.usage.endCall("LogInfo", timePkg.Since(startTime))
+}
+
+func (api *apiAccountingLayer) LogError(msg string, keyValuePairs ...any) {
+	api.usage.startCall("LogError", false)
+	startTime := timePkg.Now()
+	api.apiImpl.LogError(msg, keyValuePairs...)
+	api.usage.endCall("LogError", timePkg.Since(startTime))
+}
+
+func (api *apiAccountingLayer) LogWarn(msg string, keyValuePairs ...any) {
+	api.usage.startCall("LogWarn", false)
+	startTime := timePkg.Now()
+	api.apiImpl.LogWarn(msg, keyValuePairs...)
+	api.usage.endCall("LogWarn", timePkg.Since(startTime))
+}
+
+func (api *apiAccountingLayer) SendMail(to, subject, htmlBody string) *model.AppError {
+	if appErr := api.usage.startCall("SendMail", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SendMail(to, subject, htmlBody)
+	api.usage.endCall("SendMail", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) CreateBot(bot *model.Bot) (*model.Bot, *model.AppError) {
+	if appErr := api.usage.startCall("CreateBot", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateBot(bot)
+	api.usage.endCall("CreateBot", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) PatchBot(botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError) {
+	if appErr := api.usage.startCall("PatchBot", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.PatchBot(botUserId, botPatch)
+	api.usage.endCall("PatchBot", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetBot(botUserId string, includeDeleted bool) (*model.Bot, *model.AppError) {
+	if appErr := api.usage.startCall("GetBot", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetBot(botUserId, includeDeleted)
+	api.usage.endCall("GetBot", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetBots(options *model.BotGetOptions) ([]*model.Bot, *model.AppError) {
+	if appErr := api.usage.startCall("GetBots", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetBots(options)
+	api.usage.endCall("GetBots", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateBotActive(botUserId string, active bool) (*model.Bot, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateBotActive", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateBotActive(botUserId, active)
+	api.usage.endCall("UpdateBotActive", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) PermanentDeleteBot(botUserId string) *model.AppError {
+	if appErr := api.usage.startCall("PermanentDeleteBot", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.PermanentDeleteBot(botUserId)
+	api.usage.endCall("PermanentDeleteBot", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) PluginHTTP(request *http.Request) *http.Response {
+	api.usage.startCall("PluginHTTP", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.PluginHTTP(request)
+	api.usage.endCall("PluginHTTP", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) CallPlugin(pluginID, method string, args []byte) ([]byte, *model.AppError) {
+	if appErr := api.usage.startCall("CallPlugin", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CallPlugin(pluginID, method, args)
+	api.usage.endCall("CallPlugin", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) PublishUserTyping(userID, channelId, parentId string) *model.AppError {
+	if appErr := api.usage.startCall("PublishUserTyping", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.PublishUserTyping(userID, channelId, parentId)
+	api.usage.endCall("PublishUserTyping", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) CreateCommand(cmd *model.Command) (*model.Command, error) {
+	if appErr := api.usage.startCall("CreateCommand", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateCommand(cmd)
+	api.usage.endCall("CreateCommand", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) ListCommands(teamID string) ([]*model.Command, error) {
+	if appErr := api.usage.startCall("ListCommands", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.ListCommands(teamID)
+	api.usage.endCall("ListCommands", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) ListCustomCommands(teamID string) ([]*model.Command, error) {
+	if appErr := api.usage.startCall("ListCustomCommands", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.ListCustomCommands(teamID)
+	api.usage.endCall("ListCustomCommands", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) ListPluginCommands(teamID string) ([]*model.Command, error) {
+	if appErr := api.usage.startCall("ListPluginCommands", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.ListPluginCommands(teamID)
+	api.usage.endCall("ListPluginCommands", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) ListBuiltInCommands() ([]*model.Command, error) {
+	if appErr := api.usage.startCall("ListBuiltInCommands", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.ListBuiltInCommands()
+	api.usage.endCall("ListBuiltInCommands", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetCommand(commandID string) (*model.Command, error) {
+	if appErr := api.usage.startCall("GetCommand", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetCommand(commandID)
+	api.usage.endCall("GetCommand", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateCommand(commandID string, updatedCmd *model.Command) (*model.Command, error) {
+	if appErr := api.usage.startCall("UpdateCommand", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateCommand(commandID, updatedCmd)
+	api.usage.endCall("UpdateCommand", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeleteCommand(commandID string) error {
+	if appErr := api.usage.startCall("DeleteCommand", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeleteCommand(commandID)
+	api.usage.endCall("DeleteCommand", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	if appErr := api.usage.startCall("CreateOAuthApp", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateOAuthApp(app)
+	api.usage.endCall("CreateOAuthApp", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetOAuthApp(appID string) (*model.OAuthApp, *model.AppError) {
+	if appErr := api.usage.startCall("GetOAuthApp", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetOAuthApp(appID)
+	api.usage.endCall("GetOAuthApp", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateOAuthApp", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateOAuthApp(app)
+	api.usage.endCall("UpdateOAuthApp", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeleteOAuthApp(appID string) *model.AppError {
+	if appErr := api.usage.startCall("DeleteOAuthApp", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeleteOAuthApp(appID)
+	api.usage.endCall("DeleteOAuthApp", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) PublishPluginClusterEvent(ev model.PluginClusterEvent, opts model.PluginClusterEventSendOptions) error {
+	if appErr := api.usage.startCall("PublishPluginClusterEvent", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.PublishPluginClusterEvent(ev, opts)
+	api.usage.endCall("PublishPluginClusterEvent", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) RequestTrialLicense(requesterID string, users int, termsAccepted bool, receiveEmailsAccepted bool) *model.AppError {
+	if appErr := api.usage.startCall("RequestTrialLicense", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RequestTrialLicense(requesterID, users, termsAccepted, receiveEmailsAccepted)
+	api.usage.endCall("RequestTrialLicense", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetCloudLimits() (*model.ProductLimits, error) {
+	if appErr := api.usage.startCall("GetCloudLimits", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetCloudLimits()
+	api.usage.endCall("GetCloudLimits", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) EnsureBotUser(bot *model.Bot) (string, error) {
+	if appErr := api.usage.startCall("EnsureBotUser", true); appErr != nil {
+		return "", appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.EnsureBotUser(bot)
+	api.usage.endCall("EnsureBotUser", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) RegisterCollectionAndTopic(collectionType, topicType string) error {
+	if appErr := api.usage.startCall("RegisterCollectionAndTopic", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RegisterCollectionAndTopic(collectionType, topicType)
+	api.usage.endCall("RegisterCollectionAndTopic", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) CreateUploadSession(us *model.UploadSession) (*model.UploadSession, error) {
+	if appErr := api.usage.startCall("CreateUploadSession", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateUploadSession(us)
+	api.usage.endCall("CreateUploadSession", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo, error) {
+	if appErr := api.usage.startCall("UploadData", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UploadData(us, rd)
+	api.usage.endCall("UploadData", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetUploadSession(uploadID string) (*model.UploadSession, error) {
+	if appErr := api.usage.startCall("GetUploadSession", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetUploadSession(uploadID)
+	api.usage.endCall("GetUploadSession", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) SendPushNotification(notification *model.PushNotification, userID string) *model.AppError {
+	if appErr := api.usage.startCall("SendPushNotification", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SendPushNotification(notification, userID)
+	api.usage.endCall("SendPushNotification", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) UpdateUserAuth(userID string, userAuth *model.UserAuth) (*model.UserAuth, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateUserAuth", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateUserAuth(userID, userAuth)
+	api.usage.endCall("UpdateUserAuth", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) RegisterPluginForSharedChannels(opts model.RegisterPluginOpts) (remoteID string, err error) {
+	if appErr := api.usage.startCall("RegisterPluginForSharedChannels", true); appErr != nil {
+		return "", appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.RegisterPluginForSharedChannels(opts)
+	api.usage.endCall("RegisterPluginForSharedChannels", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UnregisterPluginForSharedChannels(pluginID string) error {
+	if appErr := api.usage.startCall("UnregisterPluginForSharedChannels", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UnregisterPluginForSharedChannels(pluginID)
+	api.usage.endCall("UnregisterPluginForSharedChannels", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) ShareChannel(sc *model.SharedChannel) (*model.SharedChannel, error) {
+	if appErr := api.usage.startCall("ShareChannel", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.ShareChannel(sc)
+	api.usage.endCall("ShareChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateSharedChannel(sc *model.SharedChannel) (*model.SharedChannel, error) {
+	if appErr := api.usage.startCall("UpdateSharedChannel", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateSharedChannel(sc)
+	api.usage.endCall("UpdateSharedChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UnshareChannel(channelID string) (unshared bool, err error) {
+	if appErr := api.usage.startCall("UnshareChannel", true); appErr != nil {
+		return false, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UnshareChannel(channelID)
+	api.usage.endCall("UnshareChannel", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateSharedChannelCursor(channelID, remoteID string, cusror model.GetPostsSinceForSyncCursor) error {
+	if appErr := api.usage.startCall("UpdateSharedChannelCursor", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UpdateSharedChannelCursor(channelID, remoteID, cusror)
+	api.usage.endCall("UpdateSharedChannelCursor", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) SyncSharedChannel(channelID string) error {
+	if appErr := api.usage.startCall("SyncSharedChannel", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SyncSharedChannel(channelID)
+	api.usage.endCall("SyncSharedChannel", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) InviteRemoteToChannel(channelID string, remoteID string, userID string, shareIfNotShared bool) error {
+	if appErr := api.usage.startCall("InviteRemoteToChannel", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.InviteRemoteToChannel(channelID, remoteID, userID, shareIfNotShared)
+	api.usage.endCall("InviteRemoteToChannel", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) UninviteRemoteFromChannel(channelID string, remoteID string) error {
+	if appErr := api.usage.startCall("UninviteRemoteFromChannel", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UninviteRemoteFromChannel(channelID, remoteID)
+	api.usage.endCall("UninviteRemoteFromChannel", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) UpsertGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	if appErr := api.usage.startCall("UpsertGroupMember", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpsertGroupMember(groupID, userID)
+	api.usage.endCall("UpsertGroupMember", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpsertGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError) {
+	if appErr := api.usage.startCall("UpsertGroupMembers", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpsertGroupMembers(groupID, userIDs)
+	api.usage.endCall("UpsertGroupMembers", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetGroupByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError) {
+	if appErr := api.usage.startCall("GetGroupByRemoteID", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGroupByRemoteID(remoteID, groupSource)
+	api.usage.endCall("GetGroupByRemoteID", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CreateGroup(group *model.Group) (*model.Group, *model.AppError) {
+	if appErr := api.usage.startCall("CreateGroup", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateGroup(group)
+	api.usage.endCall("CreateGroup", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateGroup(group *model.Group) (*model.Group, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateGroup", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateGroup(group)
+	api.usage.endCall("UpdateGroup", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeleteGroup(groupID string) (*model.Group, *model.AppError) {
+	if appErr := api.usage.startCall("DeleteGroup", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.DeleteGroup(groupID)
+	api.usage.endCall("DeleteGroup", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) RestoreGroup(groupID string) (*model.Group, *model.AppError) {
+	if appErr := api.usage.startCall("RestoreGroup", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.RestoreGroup(groupID)
+	api.usage.endCall("RestoreGroup", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeleteGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	if appErr := api.usage.startCall("DeleteGroupMember", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.DeleteGroupMember(groupID, userID)
+	api.usage.endCall("DeleteGroupMember", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	if appErr := api.usage.startCall("GetGroupSyncable", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGroupSyncable(groupID, syncableID, syncableType)
+	api.usage.endCall("GetGroupSyncable", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetGroupSyncables(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError) {
+	if appErr := api.usage.startCall("GetGroupSyncables", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGroupSyncables(groupID, syncableType)
+	api.usage.endCall("GetGroupSyncables", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpsertGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	if appErr := api.usage.startCall("UpsertGroupSyncable", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpsertGroupSyncable(groupSyncable)
+	api.usage.endCall("UpsertGroupSyncable", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateGroupSyncable", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateGroupSyncable(groupSyncable)
+	api.usage.endCall("UpdateGroupSyncable", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	if appErr := api.usage.startCall("DeleteGroupSyncable", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.DeleteGroupSyncable(groupID, syncableID, syncableType)
+	api.usage.endCall("DeleteGroupSyncable", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdateUserRoles(userID, newRoles string) (*model.User, *model.AppError) {
+	if appErr := api.usage.startCall("UpdateUserRoles", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdateUserRoles(userID, newRoles)
+	api.usage.endCall("UpdateUserRoles", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPluginID() string {
+	api.usage.startCall("GetPluginID", false)
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.GetPluginID()
+	api.usage.endCall("GetPluginID", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) GetGroups(page, perPage int, opts model.GroupSearchOpts, viewRestrictions *model.ViewUsersRestrictions) ([]*model.Group, *model.AppError) {
+	if appErr := api.usage.startCall("GetGroups", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetGroups(page, perPage, opts, viewRestrictions)
+	api.usage.endCall("GetGroups", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CreateDefaultSyncableMemberships(params model.CreateDefaultMembershipParams) *model.AppError {
+	if appErr := api.usage.startCall("CreateDefaultSyncableMemberships", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.CreateDefaultSyncableMemberships(params)
+	api.usage.endCall("CreateDefaultSyncableMemberships", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) DeleteGroupConstrainedMemberships() *model.AppError {
+	if appErr := api.usage.startCall("DeleteGroupConstrainedMemberships", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeleteGroupConstrainedMemberships()
+	api.usage.endCall("DeleteGroupConstrainedMemberships", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) CreatePropertyField(field *model.PropertyField) (*model.PropertyField, error) {
+	if appErr := api.usage.startCall("CreatePropertyField", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreatePropertyField(field)
+	api.usage.endCall("CreatePropertyField", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPropertyField(groupID, fieldID string) (*model.PropertyField, error) {
+	if appErr := api.usage.startCall("GetPropertyField", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPropertyField(groupID, fieldID)
+	api.usage.endCall("GetPropertyField", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPropertyFields(groupID string, ids []string) ([]*model.PropertyField, error) {
+	if appErr := api.usage.startCall("GetPropertyFields", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPropertyFields(groupID, ids)
+	api.usage.endCall("GetPropertyFields", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdatePropertyField(groupID string, field *model.PropertyField) (*model.PropertyField, error) {
+	if appErr := api.usage.startCall("UpdatePropertyField", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdatePropertyField(groupID, field)
+	api.usage.endCall("UpdatePropertyField", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeletePropertyField(groupID, fieldID string) error {
+	if appErr := api.usage.startCall("DeletePropertyField", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeletePropertyField(groupID, fieldID)
+	api.usage.endCall("DeletePropertyField", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) SearchPropertyFields(groupID string, opts model.PropertyFieldSearchOpts) ([]*model.PropertyField, error) {
+	if appErr := api.usage.startCall("SearchPropertyFields", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SearchPropertyFields(groupID, opts)
+	api.usage.endCall("SearchPropertyFields", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CountPropertyFields(groupID string, includeDeleted bool) (int64, error) {
+	if appErr := api.usage.startCall("CountPropertyFields", true); appErr != nil {
+		return 0, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CountPropertyFields(groupID, includeDeleted)
+	api.usage.endCall("CountPropertyFields", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CountPropertyFieldsForTarget(groupID, targetType, targetID string, includeDeleted bool) (int64, error) {
+	if appErr := api.usage.startCall("CountPropertyFieldsForTarget", true); appErr != nil {
+		return 0, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CountPropertyFieldsForTarget(groupID, targetType, targetID, includeDeleted)
+	api.usage.endCall("CountPropertyFieldsForTarget", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) CreatePropertyValue(value *model.PropertyValue) (*model.PropertyValue, error) {
+	if appErr := api.usage.startCall("CreatePropertyValue", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreatePropertyValue(value)
+	api.usage.endCall("CreatePropertyValue", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPropertyValue(groupID, valueID string) (*model.PropertyValue, error) {
+	if appErr := api.usage.startCall("GetPropertyValue", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPropertyValue(groupID, valueID)
+	api.usage.endCall("GetPropertyValue", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPropertyValues(groupID string, ids []string) ([]*model.PropertyValue, error) {
+	if appErr := api.usage.startCall("GetPropertyValues", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPropertyValues(groupID, ids)
+	api.usage.endCall("GetPropertyValues", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdatePropertyValue(groupID string, value *model.PropertyValue) (*model.PropertyValue, error) {
+	if appErr := api.usage.startCall("UpdatePropertyValue", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdatePropertyValue(groupID, value)
+	api.usage.endCall("UpdatePropertyValue", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpsertPropertyValue(value *model.PropertyValue) (*model.PropertyValue, error) {
+	if appErr := api.usage.startCall("UpsertPropertyValue", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpsertPropertyValue(value)
+	api.usage.endCall("UpsertPropertyValue", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeletePropertyValue(groupID, valueID string) error {
+	if appErr := api.usage.startCall("DeletePropertyValue", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeletePropertyValue(groupID, valueID)
+	api.usage.endCall("DeletePropertyValue", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) SearchPropertyValues(groupID string, opts model.PropertyValueSearchOpts) ([]*model.PropertyValue, error) {
+	if appErr := api.usage.startCall("SearchPropertyValues", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SearchPropertyValues(groupID, opts)
+	api.usage.endCall("SearchPropertyValues", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) RegisterPropertyGroup(name string) (*model.PropertyGroup, error) {
+	if appErr := api.usage.startCall("RegisterPropertyGroup", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.RegisterPropertyGroup(name)
+	api.usage.endCall("RegisterPropertyGroup", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPropertyGroup(name string) (*model.PropertyGroup, error) {
+	if appErr := api.usage.startCall("GetPropertyGroup", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPropertyGroup(name)
+	api.usage.endCall("GetPropertyGroup", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) GetPropertyFieldByName(groupID, targetID, name string) (*model.PropertyField, error) {
+	if appErr := api.usage.startCall("GetPropertyFieldByName", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetPropertyFieldByName(groupID, targetID, name)
+	api.usage.endCall("GetPropertyFieldByName", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdatePropertyFields(groupID string, fields []*model.PropertyField) ([]*model.PropertyField, error) {
+	if appErr := api.usage.startCall("UpdatePropertyFields", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdatePropertyFields(groupID, fields)
+	api.usage.endCall("UpdatePropertyFields", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpdatePropertyValues(groupID string, values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+	if appErr := api.usage.startCall("UpdatePropertyValues", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpdatePropertyValues(groupID, values)
+	api.usage.endCall("UpdatePropertyValues", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) UpsertPropertyValues(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+	if appErr := api.usage.startCall("UpsertPropertyValues", true); appErr != nil {
+		return nil, appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.UpsertPropertyValues(values)
+	api.usage.endCall("UpsertPropertyValues", timePkg.Since(startTime))
+	return _returnsA, _returnsB
+}
+
+func (api *apiAccountingLayer) DeletePropertyValuesForTarget(groupID, targetType, targetID string) error {
+	if appErr := api.usage.startCall("DeletePropertyValuesForTarget", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeletePropertyValuesForTarget(groupID, targetType, targetID)
+	api.usage.endCall("DeletePropertyValuesForTarget", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) DeletePropertyValuesForField(groupID, fieldID string) error {
+	if appErr := api.usage.startCall("DeletePropertyValuesForField", true); appErr != nil {
+		return appErr
+	}
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.DeletePropertyValuesForField(groupID, fieldID)
+	api.usage.endCall("DeletePropertyValuesForField", timePkg.Since(startTime))
+	return _returnsA
+}
+
+func (api *apiAccountingLayer) LogAuditRec(rec *model.AuditRecord) {
+	api.usage.startCall("LogAuditRec", false)
+	startTime := timePkg.Now()
+	api.apiImpl.LogAuditRec(rec)
+	api.usage.endCall("LogAuditRec", timePkg.Since(startTime))
+}
+
+func (api *apiAccountingLayer) LogAuditRecWithLevel(rec *model.AuditRecord, level mlog.Level) {
+	api.usage.startCall("LogAuditRecWithLevel", false)
+	startTime := timePkg.Now()
+	api.apiImpl.LogAuditRecWithLevel(rec, level)
+	api.usage.endCall("LogAuditRecWithLevel", timePkg.Since(startTime))
+}
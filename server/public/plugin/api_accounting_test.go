@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type accountingTestAPI struct {
+	API
+}
+
+func (api *accountingTestAPI) KVSet(key string, value []byte) *model.AppError {
+	return nil
+}
+
+func (api *accountingTestAPI) GetConfig() *model.Config {
+	return &model.Config{}
+}
+
+type accountingTestDriver struct {
+	AppDriver
+}
+
+func (d *accountingTestDriver) ConnQuery(connID, q string, args []driver.NamedValue) (string, error) {
+	return model.NewId(), nil
+}
+
+func TestAPIAccountingLayer(t *testing.T) {
+	accounting := newAPIAccounting(nil)
+	api := &apiAccountingLayer{apiImpl: &accountingTestAPI{}, usage: accounting.forPlugin("test.plugin")}
+
+	t.Run("calls are counted", func(t *testing.T) {
+		for range 3 {
+			require.Nil(t, api.KVSet("key", []byte("value")))
+		}
+		require.NotNil(t, api.GetConfig())
+
+		usage := accounting.usage()
+		require.Len(t, usage, 1)
+		assert.Equal(t, "test.plugin", usage[0].PluginId)
+		assert.Equal(t, int64(4), usage[0].Calls)
+		assert.Equal(t, int64(3), usage[0].DatabaseCalls)
+		require.Len(t, usage[0].Methods, 2)
+		assert.Equal(t, "KVSet", usage[0].Methods[0].Method)
+		assert.Equal(t, int64(3), usage[0].Methods[0].Calls)
+		assert.Equal(t, "GetConfig", usage[0].Methods[1].Method)
+	})
+
+	t.Run("method quota", func(t *testing.T) {
+		api := &apiAccountingLayer{apiImpl: &accountingTestAPI{}, usage: newAPIAccounting(nil).forPlugin("test.plugin")}
+		api.usage.accounting.setQuotas(model.PluginAPIQuotas{MethodCallsPerMinute: map[string]int{"KVSet": 2}})
+
+		require.Nil(t, api.KVSet("key", []byte("value")))
+		require.Nil(t, api.KVSet("key", []byte("value")))
+
+		appErr := api.KVSet("key", []byte("value"))
+		require.NotNil(t, appErr)
+		assert.Equal(t, APIQuotaExceededErrorID, appErr.Id)
+		assert.Equal(t, http.StatusTooManyRequests, appErr.StatusCode)
+
+		// Methods without an error return value are never rejected.
+		require.NotNil(t, api.GetConfig())
+
+		usage := api.usage.snapshot()
+		assert.Equal(t, int64(3), usage.Calls)
+		assert.Equal(t, int64(1), usage.RejectedCalls)
+	})
+
+	t.Run("total quota", func(t *testing.T) {
+		api := &apiAccountingLayer{apiImpl: &accountingTestAPI{}, usage: newAPIAccounting(nil).forPlugin("test.plugin")}
+		api.usage.accounting.setQuotas(model.PluginAPIQuotas{CallsPerMinute: 2})
+
+		require.NotNil(t, api.GetConfig())
+		require.Nil(t, api.KVSet("key", []byte("value")))
+		require.NotNil(t, api.KVSet("key", []byte("value")))
+	})
+
+	t.Run("quota window", func(t *testing.T) {
+		api := &apiAccountingLayer{apiImpl: &accountingTestAPI{}, usage: newAPIAccounting(nil).forPlugin("test.plugin")}
+		api.usage.accounting.setQuotas(model.PluginAPIQuotas{CallsPerMinute: 1})
+
+		require.Nil(t, api.KVSet("key", []byte("value")))
+		require.NotNil(t, api.KVSet("key", []byte("value")))
+
+		api.usage.windowStart = time.Now().Add(-apiQuotaWindow)
+		require.Nil(t, api.KVSet("key", []byte("value")))
+	})
+}
+
+func TestAPIAccountingUsage(t *testing.T) {
+	accounting := newAPIAccounting(nil)
+
+	light := &apiAccountingLayer{apiImpl: &accountingTestAPI{}, usage: accounting.forPlugin("light.plugin")}
+	for range 10 {
+		light.GetConfig()
+	}
+
+	heavy := accounting.forPlugin("heavy.plugin")
+	db := &accountedDriver{AppDriver: &accountingTestDriver{}, usage: heavy}
+	_, err := db.ConnQuery(model.NewId(), "SELECT 1", nil)
+	require.NoError(t, err)
+	heavy.recordQuery(time.Second)
+
+	assert.Same(t, heavy, accounting.forPlugin("heavy.plugin"))
+
+	usage := accounting.usage()
+	require.Len(t, usage, 2)
+	assert.Equal(t, "heavy.plugin", usage[0].PluginId)
+	assert.Equal(t, int64(2), usage[0].DatabaseCalls)
+	assert.GreaterOrEqual(t, usage[0].DatabaseTimeMillis, int64(1000))
+	assert.Equal(t, "light.plugin", usage[1].PluginId)
+	assert.Equal(t, int64(10), usage[1].Calls)
+	assert.Zero(t, usage[1].DatabaseCalls)
+}
@@ -1007,6 +1007,13 @@ func (api *apiTimerLayer) KVSetWithOptions(key string, value []byte, options mod
 	return _returnsA, _returnsB
 }
 
+func (api *apiTimerLayer) KVSetBatch(operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVSetBatch(operations)
+	api.recordTime(startTime, "KVSetBatch", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
 func (api *apiTimerLayer) KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError {
 	startTime := timePkg.Now()
 	_returnsA := api.apiImpl.KVSetWithExpiry(key, value, expireInSeconds)
@@ -1042,6 +1049,13 @@ func (api *apiTimerLayer) KVList(page, perPage int) ([]string, *model.AppError)
 	return _returnsA, _returnsB
 }
 
+func (api *apiTimerLayer) KVListWithOptions(options model.PluginKVListOptions) ([]*model.PluginKeyValue, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVListWithOptions(options)
+	api.recordTime(startTime, "KVListWithOptions", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
 func (api *apiTimerLayer) PublishWebSocketEvent(event string, payload map[string]any, broadcast *model.WebsocketBroadcast) {
 	startTime := timePkg.Now()
 	api.apiImpl.PublishWebSocketEvent(event, payload, broadcast)
@@ -1156,6 +1170,13 @@ func (api *apiTimerLayer) PluginHTTP(request *http.Request) *http.Response {
 	return _returnsA
 }
 
+func (api *apiTimerLayer) CallPlugin(pluginID, method string, args []byte) ([]byte, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CallPlugin(pluginID, method, args)
+	api.recordTime(startTime, "CallPlugin", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
 func (api *apiTimerLayer) PublishUserTyping(userID, channelId, parentId string) *model.AppError {
 	startTime := timePkg.Now()
 	_returnsA := api.apiImpl.PublishUserTyping(userID, channelId, parentId)
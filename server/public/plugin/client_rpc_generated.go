@@ -325,6 +325,114 @@ func (s *hooksRPCServer) MessageHasBeenDeleted(args *Z_MessageHasBeenDeletedArgs
 	return nil
 }
 
+func init() {
+	hookNameToId["PostWillBePinned"] = PostWillBePinnedID
+}
+
+type Z_PostWillBePinnedArgs struct {
+	A *Context
+	B *model.Post
+}
+
+type Z_PostWillBePinnedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) PostWillBePinned(c *Context, post *model.Post) string {
+	_args := &Z_PostWillBePinnedArgs{c, post}
+	_returns := &Z_PostWillBePinnedReturns{}
+	if g.implemented[PostWillBePinnedID] {
+		if err := g.client.Call("Plugin.PostWillBePinned", _args, _returns); err != nil {
+			g.log.Error("RPC call PostWillBePinned to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) PostWillBePinned(args *Z_PostWillBePinnedArgs, returns *Z_PostWillBePinnedReturns) error {
+	if hook, ok := s.impl.(interface {
+		PostWillBePinned(c *Context, post *model.Post) string
+	}); ok {
+		returns.A = hook.PostWillBePinned(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook PostWillBePinned called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["PostHasBeenPinned"] = PostHasBeenPinnedID
+}
+
+type Z_PostHasBeenPinnedArgs struct {
+	A *Context
+	B *model.Post
+}
+
+type Z_PostHasBeenPinnedReturns struct {
+}
+
+func (g *hooksRPCClient) PostHasBeenPinned(c *Context, post *model.Post) {
+	_args := &Z_PostHasBeenPinnedArgs{c, post}
+	_returns := &Z_PostHasBeenPinnedReturns{}
+	if g.implemented[PostHasBeenPinnedID] {
+		if err := g.client.Call("Plugin.PostHasBeenPinned", _args, _returns); err != nil {
+			g.log.Error("RPC call PostHasBeenPinned to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) PostHasBeenPinned(args *Z_PostHasBeenPinnedArgs, returns *Z_PostHasBeenPinnedReturns) error {
+	if hook, ok := s.impl.(interface {
+		PostHasBeenPinned(c *Context, post *model.Post)
+	}); ok {
+		hook.PostHasBeenPinned(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook PostHasBeenPinned called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["OnPluginCall"] = OnPluginCallID
+}
+
+type Z_OnPluginCallArgs struct {
+	A *Context
+	B string
+	C string
+	D []byte
+}
+
+type Z_OnPluginCallReturns struct {
+	A []byte
+	B error
+}
+
+func (g *hooksRPCClient) OnPluginCall(c *Context, sourcePluginID, method string, args []byte) ([]byte, error) {
+	_args := &Z_OnPluginCallArgs{c, sourcePluginID, method, args}
+	_returns := &Z_OnPluginCallReturns{}
+	if g.implemented[OnPluginCallID] {
+		if err := g.client.Call("Plugin.OnPluginCall", _args, _returns); err != nil {
+			g.log.Error("RPC call OnPluginCall to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) OnPluginCall(args *Z_OnPluginCallArgs, returns *Z_OnPluginCallReturns) error {
+	if hook, ok := s.impl.(interface {
+		OnPluginCall(c *Context, sourcePluginID, method string, args []byte) ([]byte, error)
+	}); ok {
+		returns.A, returns.B = hook.OnPluginCall(args.A, args.B, args.C, args.D)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook OnPluginCall called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["ChannelHasBeenCreated"] = ChannelHasBeenCreatedID
 }
@@ -359,6 +467,148 @@ func (s *hooksRPCServer) ChannelHasBeenCreated(args *Z_ChannelHasBeenCreatedArgs
 	return nil
 }
 
+func init() {
+	hookNameToId["ChannelWillBeUpdated"] = ChannelWillBeUpdatedID
+}
+
+type Z_ChannelWillBeUpdatedArgs struct {
+	A *Context
+	B *model.Channel
+	C *model.Channel
+}
+
+type Z_ChannelWillBeUpdatedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) string {
+	_args := &Z_ChannelWillBeUpdatedArgs{c, newChannel, oldChannel}
+	_returns := &Z_ChannelWillBeUpdatedReturns{}
+	if g.implemented[ChannelWillBeUpdatedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) ChannelWillBeUpdated(args *Z_ChannelWillBeUpdatedArgs, returns *Z_ChannelWillBeUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) string
+	}); ok {
+		returns.A = hook.ChannelWillBeUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelHasBeenUpdated"] = ChannelHasBeenUpdatedID
+}
+
+type Z_ChannelHasBeenUpdatedArgs struct {
+	A *Context
+	B *model.Channel
+	C *model.Channel
+}
+
+type Z_ChannelHasBeenUpdatedReturns struct {
+}
+
+func (g *hooksRPCClient) ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel) {
+	_args := &Z_ChannelHasBeenUpdatedArgs{c, newChannel, oldChannel}
+	_returns := &Z_ChannelHasBeenUpdatedReturns{}
+	if g.implemented[ChannelHasBeenUpdatedID] {
+		if err := g.client.Call("Plugin.ChannelHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ChannelHasBeenUpdated(args *Z_ChannelHasBeenUpdatedArgs, returns *Z_ChannelHasBeenUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel)
+	}); ok {
+		hook.ChannelHasBeenUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelHasBeenUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelWillBeArchived"] = ChannelWillBeArchivedID
+}
+
+type Z_ChannelWillBeArchivedArgs struct {
+	A *Context
+	B *model.Channel
+	C *model.User
+}
+
+type Z_ChannelWillBeArchivedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) ChannelWillBeArchived(c *Context, channel *model.Channel, actor *model.User) string {
+	_args := &Z_ChannelWillBeArchivedArgs{c, channel, actor}
+	_returns := &Z_ChannelWillBeArchivedReturns{}
+	if g.implemented[ChannelWillBeArchivedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeArchived", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) ChannelWillBeArchived(args *Z_ChannelWillBeArchivedArgs, returns *Z_ChannelWillBeArchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeArchived(c *Context, channel *model.Channel, actor *model.User) string
+	}); ok {
+		returns.A = hook.ChannelWillBeArchived(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeArchived called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelHasBeenArchived"] = ChannelHasBeenArchivedID
+}
+
+type Z_ChannelHasBeenArchivedArgs struct {
+	A *Context
+	B *model.Channel
+	C *model.User
+}
+
+type Z_ChannelHasBeenArchivedReturns struct {
+}
+
+func (g *hooksRPCClient) ChannelHasBeenArchived(c *Context, channel *model.Channel, actor *model.User) {
+	_args := &Z_ChannelHasBeenArchivedArgs{c, channel, actor}
+	_returns := &Z_ChannelHasBeenArchivedReturns{}
+	if g.implemented[ChannelHasBeenArchivedID] {
+		if err := g.client.Call("Plugin.ChannelHasBeenArchived", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelHasBeenArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ChannelHasBeenArchived(args *Z_ChannelHasBeenArchivedArgs, returns *Z_ChannelHasBeenArchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelHasBeenArchived(c *Context, channel *model.Channel, actor *model.User)
+	}); ok {
+		hook.ChannelHasBeenArchived(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelHasBeenArchived called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["UserHasJoinedChannel"] = UserHasJoinedChannelID
 }
@@ -499,6 +749,215 @@ func (s *hooksRPCServer) UserHasLeftTeam(args *Z_UserHasLeftTeamArgs, returns *Z
 	return nil
 }
 
+func init() {
+	hookNameToId["TeamWillBeCreated"] = TeamWillBeCreatedID
+}
+
+type Z_TeamWillBeCreatedArgs struct {
+	A *Context
+	B *model.Team
+}
+
+type Z_TeamWillBeCreatedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) TeamWillBeCreated(c *Context, team *model.Team) string {
+	_args := &Z_TeamWillBeCreatedArgs{c, team}
+	_returns := &Z_TeamWillBeCreatedReturns{}
+	if g.implemented[TeamWillBeCreatedID] {
+		if err := g.client.Call("Plugin.TeamWillBeCreated", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamWillBeCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) TeamWillBeCreated(args *Z_TeamWillBeCreatedArgs, returns *Z_TeamWillBeCreatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamWillBeCreated(c *Context, team *model.Team) string
+	}); ok {
+		returns.A = hook.TeamWillBeCreated(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamWillBeCreated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["TeamHasBeenCreated"] = TeamHasBeenCreatedID
+}
+
+type Z_TeamHasBeenCreatedArgs struct {
+	A *Context
+	B *model.Team
+}
+
+type Z_TeamHasBeenCreatedReturns struct {
+}
+
+func (g *hooksRPCClient) TeamHasBeenCreated(c *Context, team *model.Team) {
+	_args := &Z_TeamHasBeenCreatedArgs{c, team}
+	_returns := &Z_TeamHasBeenCreatedReturns{}
+	if g.implemented[TeamHasBeenCreatedID] {
+		if err := g.client.Call("Plugin.TeamHasBeenCreated", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamHasBeenCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) TeamHasBeenCreated(args *Z_TeamHasBeenCreatedArgs, returns *Z_TeamHasBeenCreatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamHasBeenCreated(c *Context, team *model.Team)
+	}); ok {
+		hook.TeamHasBeenCreated(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamHasBeenCreated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["TeamWillBeDeleted"] = TeamWillBeDeletedID
+}
+
+type Z_TeamWillBeDeletedArgs struct {
+	A *Context
+	B *model.Team
+}
+
+type Z_TeamWillBeDeletedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) TeamWillBeDeleted(c *Context, team *model.Team) string {
+	_args := &Z_TeamWillBeDeletedArgs{c, team}
+	_returns := &Z_TeamWillBeDeletedReturns{}
+	if g.implemented[TeamWillBeDeletedID] {
+		if err := g.client.Call("Plugin.TeamWillBeDeleted", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamWillBeDeleted to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) TeamWillBeDeleted(args *Z_TeamWillBeDeletedArgs, returns *Z_TeamWillBeDeletedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamWillBeDeleted(c *Context, team *model.Team) string
+	}); ok {
+		returns.A = hook.TeamWillBeDeleted(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamWillBeDeleted called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["TeamHasBeenDeleted"] = TeamHasBeenDeletedID
+}
+
+type Z_TeamHasBeenDeletedArgs struct {
+	A *Context
+	B *model.Team
+}
+
+type Z_TeamHasBeenDeletedReturns struct {
+}
+
+func (g *hooksRPCClient) TeamHasBeenDeleted(c *Context, team *model.Team) {
+	_args := &Z_TeamHasBeenDeletedArgs{c, team}
+	_returns := &Z_TeamHasBeenDeletedReturns{}
+	if g.implemented[TeamHasBeenDeletedID] {
+		if err := g.client.Call("Plugin.TeamHasBeenDeleted", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamHasBeenDeleted to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) TeamHasBeenDeleted(args *Z_TeamHasBeenDeletedArgs, returns *Z_TeamHasBeenDeletedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamHasBeenDeleted(c *Context, team *model.Team)
+	}); ok {
+		hook.TeamHasBeenDeleted(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamHasBeenDeleted called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserRolesWillChange"] = UserRolesWillChangeID
+}
+
+type Z_UserRolesWillChangeArgs struct {
+	A *Context
+	B *model.User
+	C string
+}
+
+type Z_UserRolesWillChangeReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) UserRolesWillChange(c *Context, user *model.User, newRoles string) string {
+	_args := &Z_UserRolesWillChangeArgs{c, user, newRoles}
+	_returns := &Z_UserRolesWillChangeReturns{}
+	if g.implemented[UserRolesWillChangeID] {
+		if err := g.client.Call("Plugin.UserRolesWillChange", _args, _returns); err != nil {
+			g.log.Error("RPC call UserRolesWillChange to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) UserRolesWillChange(args *Z_UserRolesWillChangeArgs, returns *Z_UserRolesWillChangeReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserRolesWillChange(c *Context, user *model.User, newRoles string) string
+	}); ok {
+		returns.A = hook.UserRolesWillChange(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserRolesWillChange called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserRolesHaveChanged"] = UserRolesHaveChangedID
+}
+
+type Z_UserRolesHaveChangedArgs struct {
+	A *Context
+	B *model.User
+	C string
+}
+
+type Z_UserRolesHaveChangedReturns struct {
+}
+
+func (g *hooksRPCClient) UserRolesHaveChanged(c *Context, user *model.User, oldRoles string) {
+	_args := &Z_UserRolesHaveChangedArgs{c, user, oldRoles}
+	_returns := &Z_UserRolesHaveChangedReturns{}
+	if g.implemented[UserRolesHaveChangedID] {
+		if err := g.client.Call("Plugin.UserRolesHaveChanged", _args, _returns); err != nil {
+			g.log.Error("RPC call UserRolesHaveChanged to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) UserRolesHaveChanged(args *Z_UserRolesHaveChangedArgs, returns *Z_UserRolesHaveChangedReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserRolesHaveChanged(c *Context, user *model.User, oldRoles string)
+	}); ok {
+		hook.UserRolesHaveChanged(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserRolesHaveChanged called but not implemented."))
+	}
+	return nil
+}
+
 func init() {
 	hookNameToId["FileWillBeDownloaded"] = FileWillBeDownloadedID
 }
@@ -5321,6 +5780,35 @@ func (s *apiRPCServer) KVSetWithOptions(args *Z_KVSetWithOptionsArgs, returns *Z
 	return nil
 }
 
+type Z_KVSetBatchArgs struct {
+	A []*model.PluginKVSetOperation
+}
+
+type Z_KVSetBatchReturns struct {
+	A bool
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVSetBatch(operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	_args := &Z_KVSetBatchArgs{operations}
+	_returns := &Z_KVSetBatchReturns{}
+	if err := g.client.Call("Plugin.KVSetBatch", _args, _returns); err != nil {
+		log.Printf("RPC call to KVSetBatch API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVSetBatch(args *Z_KVSetBatchArgs, returns *Z_KVSetBatchReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVSetBatch(operations []*model.PluginKVSetOperation) (bool, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVSetBatch(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API KVSetBatch called but not implemented."))
+	}
+	return nil
+}
+
 type Z_KVSetWithExpiryArgs struct {
 	A string
 	B []byte
@@ -5465,6 +5953,35 @@ func (s *apiRPCServer) KVList(args *Z_KVListArgs, returns *Z_KVListReturns) erro
 	return nil
 }
 
+type Z_KVListWithOptionsArgs struct {
+	A model.PluginKVListOptions
+}
+
+type Z_KVListWithOptionsReturns struct {
+	A []*model.PluginKeyValue
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVListWithOptions(options model.PluginKVListOptions) ([]*model.PluginKeyValue, *model.AppError) {
+	_args := &Z_KVListWithOptionsArgs{options}
+	_returns := &Z_KVListWithOptionsReturns{}
+	if err := g.client.Call("Plugin.KVListWithOptions", _args, _returns); err != nil {
+		log.Printf("RPC call to KVListWithOptions API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVListWithOptions(args *Z_KVListWithOptionsArgs, returns *Z_KVListWithOptionsReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVListWithOptions(options model.PluginKVListOptions) ([]*model.PluginKeyValue, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVListWithOptions(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API KVListWithOptions called but not implemented."))
+	}
+	return nil
+}
+
 type Z_PublishWebSocketEventArgs struct {
 	A string
 	B map[string]any
@@ -5818,6 +6335,37 @@ func (s *apiRPCServer) PermanentDeleteBot(args *Z_PermanentDeleteBotArgs, return
 	return nil
 }
 
+type Z_CallPluginArgs struct {
+	A string
+	B string
+	C []byte
+}
+
+type Z_CallPluginReturns struct {
+	A []byte
+	B *model.AppError
+}
+
+func (g *apiRPCClient) CallPlugin(pluginID, method string, args []byte) ([]byte, *model.AppError) {
+	_args := &Z_CallPluginArgs{pluginID, method, args}
+	_returns := &Z_CallPluginReturns{}
+	if err := g.client.Call("Plugin.CallPlugin", _args, _returns); err != nil {
+		log.Printf("RPC call to CallPlugin API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) CallPlugin(args *Z_CallPluginArgs, returns *Z_CallPluginReturns) error {
+	if hook, ok := s.impl.(interface {
+		CallPlugin(pluginID, method string, args []byte) ([]byte, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.CallPlugin(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("API CallPlugin called but not implemented."))
+	}
+	return nil
+}
+
 type Z_PublishUserTypingArgs struct {
 	A string
 	B string
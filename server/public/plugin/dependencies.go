@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// ActivationWaves groups plugins so that each plugin comes after the plugins it depends on.
+// Plugins of the same wave don't depend on each other and may be activated concurrently once
+// the previous waves are active.
+//
+// Dependencies that are not part of the given plugins don't affect the order. Plugins that are
+// part of a dependency cycle are put in the last wave, where they fail to activate.
+func ActivationWaves(plugins []*model.BundleInfo) [][]*model.BundleInfo {
+	pending := make(map[string]*model.BundleInfo, len(plugins))
+	for _, p := range plugins {
+		pending[p.Manifest.Id] = p
+	}
+
+	var waves [][]*model.BundleInfo
+	for len(pending) > 0 {
+		var wave []*model.BundleInfo
+		for _, p := range plugins {
+			if _, ok := pending[p.Manifest.Id]; !ok {
+				continue
+			}
+
+			ready := true
+			for _, dependency := range p.Manifest.Dependencies {
+				if _, ok := pending[dependency.Id]; ok {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				wave = append(wave, p)
+			}
+		}
+
+		if len(wave) == 0 {
+			// The remaining plugins depend on each other.
+			for _, p := range plugins {
+				if _, ok := pending[p.Manifest.Id]; ok {
+					wave = append(wave, p)
+				}
+			}
+		}
+
+		for _, p := range wave {
+			delete(pending, p.Manifest.Id)
+		}
+		waves = append(waves, wave)
+	}
+
+	return waves
+}
+
+// checkDependencies returns an error if any dependency of the plugin is not active.
+func (env *Environment) checkDependencies(manifest *model.Manifest) error {
+	for _, dependency := range manifest.Dependencies {
+		p, ok := env.registeredPlugins.Load(dependency.Id)
+		if !ok || !env.IsActive(dependency.Id) {
+			return fmt.Errorf("dependency %s is not active", dependency.Id)
+		}
+		dependencyManifest := p.(registeredPlugin).BundleInfo.Manifest
+
+		satisfied, err := dependency.IsSatisfiedBy(dependencyManifest)
+		if err != nil {
+			return fmt.Errorf("invalid dependency %s: %w", dependency.Id, err)
+		}
+		if !satisfied {
+			return fmt.Errorf("dependency %s requires version %s, found %s", dependency.Id, dependency.MinVersion, dependencyManifest.Version)
+		}
+	}
+
+	return nil
+}
+
+// ActiveManifest returns the manifest of the plugin with the given id if it is active.
+func (env *Environment) ActiveManifest(id string) (*model.Manifest, bool) {
+	p, ok := env.registeredPlugins.Load(id)
+	if !ok || !env.IsActive(id) {
+		return nil, false
+	}
+
+	return p.(registeredPlugin).BundleInfo.Manifest, true
+}
+
+// PluginImplements returns true if the active plugin with the given id implements the given hook.
+func (env *Environment) PluginImplements(id string, hookId int) bool {
+	p, ok := env.registeredPlugins.Load(id)
+	if !ok || !env.IsActive(id) {
+		return false
+	}
+
+	rp := p.(registeredPlugin)
+	return rp.supervisor != nil && rp.supervisor.Implements(hookId)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestActivationWaves(t *testing.T) {
+	bundle := func(id string, dependencies ...string) *model.BundleInfo {
+		manifest := &model.Manifest{Id: id}
+		for _, dependency := range dependencies {
+			manifest.Dependencies = append(manifest.Dependencies, &model.ManifestDependency{Id: dependency})
+		}
+		return &model.BundleInfo{Manifest: manifest}
+	}
+
+	ids := func(waves [][]*model.BundleInfo) [][]string {
+		result := [][]string{}
+		for _, wave := range waves {
+			waveIDs := []string{}
+			for _, p := range wave {
+				waveIDs = append(waveIDs, p.Manifest.Id)
+			}
+			result = append(result, waveIDs)
+		}
+		return result
+	}
+
+	t.Run("no plugins", func(t *testing.T) {
+		assert.Empty(t, ActivationWaves(nil))
+	})
+
+	t.Run("no dependencies", func(t *testing.T) {
+		waves := ActivationWaves([]*model.BundleInfo{bundle("a"), bundle("b")})
+		assert.Equal(t, [][]string{{"a", "b"}}, ids(waves))
+	})
+
+	t.Run("dependencies come first", func(t *testing.T) {
+		waves := ActivationWaves([]*model.BundleInfo{
+			bundle("c", "b"),
+			bundle("b", "a"),
+			bundle("a"),
+			bundle("d", "a"),
+		})
+		assert.Equal(t, [][]string{{"a"}, {"b", "d"}, {"c"}}, ids(waves))
+	})
+
+	t.Run("missing dependency", func(t *testing.T) {
+		waves := ActivationWaves([]*model.BundleInfo{bundle("a", "missing"), bundle("b")})
+		assert.Equal(t, [][]string{{"a", "b"}}, ids(waves))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		waves := ActivationWaves([]*model.BundleInfo{
+			bundle("a", "b"),
+			bundle("b", "a"),
+			bundle("c"),
+		})
+		assert.Equal(t, [][]string{{"c"}, {"a", "b"}}, ids(waves))
+	})
+}
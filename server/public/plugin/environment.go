@@ -6,6 +6,7 @@ package plugin
 import (
 	"fmt"
 	"hash/fnv"
+	"maps"
 	"os"
 	"path/filepath"
 	"sync"
@@ -53,6 +54,7 @@ type Environment struct {
 	pluginHealthCheckJob             *PluginHealthCheckJob
 	logger                           *mlog.Logger
 	metrics                          metricsInterface
+	apiAccounting                    *apiAccounting
 	newAPIImpl                       apiImplCreatorFunc
 	dbDriver                         AppDriver
 	pluginDir                        string
@@ -60,6 +62,10 @@ type Environment struct {
 	prepackagedPlugins               []*PrepackagedPlugin
 	transitionallyPrepackagedPlugins []*PrepackagedPlugin
 	prepackagedPluginsLock           sync.RWMutex
+	resourceLimits                   model.PluginResourceLimits
+	pluginResourceLimits             map[string]model.PluginResourceLimits
+	cgroupPath                       string
+	resourceLimitsLock               sync.RWMutex
 }
 
 func NewEnvironment(
@@ -73,6 +79,7 @@ func NewEnvironment(
 	return &Environment{
 		logger:          logger,
 		metrics:         metrics,
+		apiAccounting:   newAPIAccounting(metrics),
 		newAPIImpl:      newAPIImpl,
 		dbDriver:        dbDriver,
 		pluginDir:       pluginDir,
@@ -281,7 +288,15 @@ func checkMinServerVersion(pluginInfo *model.BundleInfo) error {
 }
 
 func (env *Environment) startPluginServer(pluginInfo *model.BundleInfo, opts ...func(*supervisor, *plugin.ClientConfig) error) error {
-	sup, err := newSupervisor(pluginInfo, env.newAPIImpl(pluginInfo.Manifest), env.dbDriver, env.logger, env.metrics, opts...)
+	usage := env.apiAccounting.forPlugin(pluginInfo.Manifest.Id)
+	apiImpl := &apiAccountingLayer{apiImpl: env.newAPIImpl(pluginInfo.Manifest), usage: usage}
+
+	var dbDriver AppDriver
+	if env.dbDriver != nil {
+		dbDriver = &accountedDriver{AppDriver: env.dbDriver, usage: usage}
+	}
+
+	sup, err := newSupervisor(pluginInfo, apiImpl, dbDriver, env.logger, env.metrics, opts...)
 	if err != nil {
 		return errors.Wrapf(err, "unable to start plugin: %v", pluginInfo.Manifest.Id)
 	}
@@ -351,6 +366,11 @@ func (env *Environment) Activate(id string) (manifest *model.Manifest, activated
 		return nil, false, err
 	}
 
+	err = env.checkDependencies(pluginInfo.Manifest)
+	if err != nil {
+		return nil, false, err
+	}
+
 	componentActivated := false
 
 	if pluginInfo.Manifest.HasWebapp() {
@@ -365,7 +385,7 @@ func (env *Environment) Activate(id string) (manifest *model.Manifest, activated
 	}
 
 	if pluginInfo.Manifest.HasServer() {
-		err = env.startPluginServer(pluginInfo, WithExecutableFromManifest(pluginInfo))
+		err = env.startPluginServer(pluginInfo, WithExecutableFromManifest(pluginInfo), env.withResourceLimits(pluginInfo.Manifest))
 		if err != nil {
 			return nil, false, err
 		}
@@ -626,7 +646,8 @@ func (env *Environment) RunMultiPluginHook(hookRunnerFunc func(hooks Hooks, mani
 	}
 }
 
-// PerformHealthCheck uses the active plugin's supervisor to verify if the plugin has crashed.
+// PerformHealthCheck uses the active plugin's supervisor to verify if the plugin has crashed
+// or exceeds its resource budget.
 func (env *Environment) PerformHealthCheck(id string) error {
 	p, ok := env.registeredPlugins.Load(id)
 	if !ok {
@@ -638,7 +659,40 @@ func (env *Environment) PerformHealthCheck(id string) error {
 	if sup == nil {
 		return nil
 	}
-	return sup.PerformHealthCheck()
+	if err := sup.PerformHealthCheck(); err != nil {
+		return err
+	}
+	return sup.CheckResourceUsage(env.metrics)
+}
+
+// SetAPIQuotas sets the number of plugin API calls each plugin may make per minute.
+func (env *Environment) SetAPIQuotas(quotas model.PluginAPIQuotas) {
+	env.apiAccounting.setQuotas(quotas)
+}
+
+// APIUsage returns the plugin API and database usage of each plugin started on this node,
+// heaviest database consumer first.
+func (env *Environment) APIUsage() []*model.PluginAPIUsage {
+	return env.apiAccounting.usage()
+}
+
+// SetResourceLimits sets the resource limits applied to all plugin processes, the limits set for
+// specific plugins, which override them, and the cgroup v2 directory under which plugins get their
+// own cgroup. Plugins may declare tighter limits in their manifest. The limits apply to plugins
+// started afterwards.
+func (env *Environment) SetResourceLimits(limits model.PluginResourceLimits, pluginLimits map[string]model.PluginResourceLimits, cgroupPath string) {
+	env.resourceLimitsLock.Lock()
+	defer env.resourceLimitsLock.Unlock()
+	env.resourceLimits = limits
+	env.pluginResourceLimits = maps.Clone(pluginLimits)
+	env.cgroupPath = cgroupPath
+}
+
+func (env *Environment) withResourceLimits(manifest *model.Manifest) func(*supervisor, *plugin.ClientConfig) error {
+	env.resourceLimitsLock.RLock()
+	defer env.resourceLimitsLock.RUnlock()
+	adminLimits := env.resourceLimits.Override(env.pluginResourceLimits[manifest.Id])
+	return WithResourceLimits(manifest.GetResourceLimits(adminLimits), env.cgroupPath)
 }
 
 // SetPrepackagedPlugins saves prepackaged plugins in the environment.
@@ -65,6 +65,19 @@ const (
 	OnSAMLLoginID                             = 46
 	EmailNotificationWillBeSentID             = 47
 	FileWillBeDownloadedID                    = 48
+	ChannelWillBeUpdatedID                    = 49
+	ChannelHasBeenUpdatedID                   = 50
+	ChannelWillBeArchivedID                   = 51
+	ChannelHasBeenArchivedID                  = 52
+	TeamWillBeCreatedID                       = 53
+	TeamHasBeenCreatedID                      = 54
+	TeamWillBeDeletedID                       = 55
+	TeamHasBeenDeletedID                      = 56
+	UserRolesWillChangeID                     = 57
+	UserRolesHaveChangedID                    = 58
+	PostWillBePinnedID                        = 59
+	PostHasBeenPinnedID                       = 60
+	OnPluginCallID                            = 61
 	TotalHooksID                              = iota
 )
 
@@ -198,11 +211,64 @@ type Hooks interface {
 	// Minimum server version: 9.1
 	MessageHasBeenDeleted(c *Context, post *model.Post)
 
+	// PostWillBePinned is invoked before a post is pinned to or unpinned from its channel.
+	// post.IsPinned holds the requested state.
+	//
+	// To reject the change, return a non-empty string describing why it was rejected.
+	// To allow it, return an empty string.
+	//
+	// Minimum server version: 11.6
+	PostWillBePinned(c *Context, post *model.Post) string
+
+	// PostHasBeenPinned is invoked after a post has been pinned to or unpinned from its channel.
+	// post.IsPinned holds the new state.
+	//
+	// Minimum server version: 11.6
+	PostHasBeenPinned(c *Context, post *model.Post)
+
+	// OnPluginCall is invoked when another plugin calls one of the methods your plugin exports
+	// in the ExportedMethods of its manifest. Only plugins declaring a dependency on your plugin
+	// may call it. The returned bytes are passed back to the caller, and a returned error is
+	// propagated to it, as is if it is a *model.AppError.
+	//
+	// Minimum server version: 11.6
+	OnPluginCall(c *Context, sourcePluginID, method string, args []byte) ([]byte, error)
+
 	// ChannelHasBeenCreated is invoked after the channel has been committed to the database.
 	//
 	// Minimum server version: 5.2
 	ChannelHasBeenCreated(c *Context, channel *model.Channel)
 
+	// ChannelWillBeUpdated is invoked before a channel update is committed to the database.
+	// This includes renames, privacy and scheme changes, and unarchiving.
+	//
+	// To reject the update, return a non-empty string describing why it was rejected.
+	// To allow it, return an empty string.
+	//
+	// Minimum server version: 11.6
+	ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) string
+
+	// ChannelHasBeenUpdated is invoked after a channel update has been committed to the database.
+	// This includes renames, privacy and scheme changes, and unarchiving.
+	//
+	// Minimum server version: 11.6
+	ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel)
+
+	// ChannelWillBeArchived is invoked before a channel is archived.
+	// If actor is not nil, the channel is being archived by the actor.
+	//
+	// To reject the archive, return a non-empty string describing why it was rejected.
+	// To allow it, return an empty string.
+	//
+	// Minimum server version: 11.6
+	ChannelWillBeArchived(c *Context, channel *model.Channel, actor *model.User) string
+
+	// ChannelHasBeenArchived is invoked after a channel has been archived.
+	// If actor is not nil, the channel was archived by the actor.
+	//
+	// Minimum server version: 11.6
+	ChannelHasBeenArchived(c *Context, channel *model.Channel, actor *model.User)
+
 	// UserHasJoinedChannel is invoked after the membership has been committed to the database.
 	// If actor is not nil, the user was invited to the channel by the actor.
 	//
@@ -227,6 +293,47 @@ type Hooks interface {
 	// Minimum server version: 5.2
 	UserHasLeftTeam(c *Context, teamMember *model.TeamMember, actor *model.User)
 
+	// TeamWillBeCreated is invoked before a team is committed to the database.
+	//
+	// To reject the team, return a non-empty string describing why it was rejected.
+	// To allow it, return an empty string.
+	//
+	// Minimum server version: 11.6
+	TeamWillBeCreated(c *Context, team *model.Team) string
+
+	// TeamHasBeenCreated is invoked after a team has been committed to the database.
+	//
+	// Minimum server version: 11.6
+	TeamHasBeenCreated(c *Context, team *model.Team)
+
+	// TeamWillBeDeleted is invoked before a team is archived or permanently deleted.
+	//
+	// To reject the deletion, return a non-empty string describing why it was rejected.
+	// To allow it, return an empty string.
+	//
+	// Minimum server version: 11.6
+	TeamWillBeDeleted(c *Context, team *model.Team) string
+
+	// TeamHasBeenDeleted is invoked after a team has been archived or permanently deleted.
+	//
+	// Minimum server version: 11.6
+	TeamHasBeenDeleted(c *Context, team *model.Team)
+
+	// UserRolesWillChange is invoked before the system roles of a user are updated.
+	// newRoles is the space-separated list of roles the user will have.
+	//
+	// To reject the change, return a non-empty string describing why it was rejected.
+	// To allow it, return an empty string.
+	//
+	// Minimum server version: 11.6
+	UserRolesWillChange(c *Context, user *model.User, newRoles string) string
+
+	// UserRolesHaveChanged is invoked after the system roles of a user have been updated.
+	// oldRoles is the space-separated list of roles the user had before the change.
+	//
+	// Minimum server version: 11.6
+	UserRolesHaveChanged(c *Context, user *model.User, oldRoles string)
+
 	// FileWillBeUploaded is invoked when a file is uploaded, but before it is committed to backing store.
 	// Read from file to retrieve the body of the uploaded file.
 	//
@@ -127,12 +127,58 @@ func (hooks *hooksTimerLayer) MessageHasBeenDeleted(c *Context, post *model.Post
 	hooks.recordTime(startTime, "MessageHasBeenDeleted", true)
 }
 
+func (hooks *hooksTimerLayer) PostWillBePinned(c *Context, post *model.Post) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.PostWillBePinned(c, post)
+	hooks.recordTime(startTime, "PostWillBePinned", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) PostHasBeenPinned(c *Context, post *model.Post) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.PostHasBeenPinned(c, post)
+	hooks.recordTime(startTime, "PostHasBeenPinned", true)
+}
+
+func (hooks *hooksTimerLayer) OnPluginCall(c *Context, sourcePluginID, method string, args []byte) ([]byte, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.OnPluginCall(c, sourcePluginID, method, args)
+	hooks.recordTime(startTime, "OnPluginCall", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
 func (hooks *hooksTimerLayer) ChannelHasBeenCreated(c *Context, channel *model.Channel) {
 	startTime := timePkg.Now()
 	hooks.hooksImpl.ChannelHasBeenCreated(c, channel)
 	hooks.recordTime(startTime, "ChannelHasBeenCreated", true)
 }
 
+func (hooks *hooksTimerLayer) ChannelWillBeUpdated(c *Context, newChannel, oldChannel *model.Channel) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.ChannelWillBeUpdated(c, newChannel, oldChannel)
+	hooks.recordTime(startTime, "ChannelWillBeUpdated", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.ChannelHasBeenUpdated(c, newChannel, oldChannel)
+	hooks.recordTime(startTime, "ChannelHasBeenUpdated", true)
+}
+
+func (hooks *hooksTimerLayer) ChannelWillBeArchived(c *Context, channel *model.Channel, actor *model.User) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.ChannelWillBeArchived(c, channel, actor)
+	hooks.recordTime(startTime, "ChannelWillBeArchived", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) ChannelHasBeenArchived(c *Context, channel *model.Channel, actor *model.User) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.ChannelHasBeenArchived(c, channel, actor)
+	hooks.recordTime(startTime, "ChannelHasBeenArchived", true)
+}
+
 func (hooks *hooksTimerLayer) UserHasJoinedChannel(c *Context, channelMember *model.ChannelMember, actor *model.User) {
 	startTime := timePkg.Now()
 	hooks.hooksImpl.UserHasJoinedChannel(c, channelMember, actor)
@@ -157,6 +203,45 @@ func (hooks *hooksTimerLayer) UserHasLeftTeam(c *Context, teamMember *model.Team
 	hooks.recordTime(startTime, "UserHasLeftTeam", true)
 }
 
+func (hooks *hooksTimerLayer) TeamWillBeCreated(c *Context, team *model.Team) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.TeamWillBeCreated(c, team)
+	hooks.recordTime(startTime, "TeamWillBeCreated", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) TeamHasBeenCreated(c *Context, team *model.Team) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.TeamHasBeenCreated(c, team)
+	hooks.recordTime(startTime, "TeamHasBeenCreated", true)
+}
+
+func (hooks *hooksTimerLayer) TeamWillBeDeleted(c *Context, team *model.Team) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.TeamWillBeDeleted(c, team)
+	hooks.recordTime(startTime, "TeamWillBeDeleted", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) TeamHasBeenDeleted(c *Context, team *model.Team) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.TeamHasBeenDeleted(c, team)
+	hooks.recordTime(startTime, "TeamHasBeenDeleted", true)
+}
+
+func (hooks *hooksTimerLayer) UserRolesWillChange(c *Context, user *model.User, newRoles string) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.UserRolesWillChange(c, user, newRoles)
+	hooks.recordTime(startTime, "UserRolesWillChange", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) UserRolesHaveChanged(c *Context, user *model.User, oldRoles string) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.UserRolesHaveChanged(c, user, oldRoles)
+	hooks.recordTime(startTime, "UserRolesHaveChanged", true)
+}
+
 func (hooks *hooksTimerLayer) FileWillBeUploaded(c *Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string) {
 	startTime := timePkg.Now()
 	_returnsA, _returnsB := hooks.hooksImpl.FileWillBeUploaded(c, info, file, output)
@@ -184,6 +184,58 @@ func FieldListToRecordSuccess(structPrefix string, fieldList *ast.FieldList) str
 	return fmt.Sprintf("%s == nil", result)
 }
 
+// FieldListToErrorReturn returns the values to return from a method returning errValue as its
+// error, and the zero value for the other results. It returns an empty string if the method
+// doesn't return an error.
+func FieldListToErrorReturn(errValue string, fieldList *ast.FieldList, fileset *token.FileSet) string {
+	if fieldList == nil || len(fieldList.List) == 0 {
+		return ""
+	}
+
+	result := []string{}
+	hasError := false
+	for _, field := range fieldList.List {
+		value := zeroValue(field.Type, fileset)
+		if typeName := baseTypeName(field.Type); typeName == "error" || typeName == "AppError" {
+			value = errValue
+			hasError = true
+		}
+
+		for range max(len(field.Names), 1) {
+			result = append(result, value)
+		}
+	}
+
+	if !hasError {
+		return ""
+	}
+	return strings.Join(result, ", ")
+}
+
+func zeroValue(x ast.Expr, fileset *token.FileSet) string {
+	switch t := x.(type) {
+	case *ast.StarExpr, *ast.ArrayType, *ast.MapType, *ast.InterfaceType, *ast.FuncType, *ast.ChanType:
+		return "nil"
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "any", "error":
+			return "nil"
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64", "byte", "rune":
+			return "0"
+		}
+	}
+
+	typeNameBuffer := &bytes.Buffer{}
+	if err := printer.Fprint(typeNameBuffer, fileset, x); err != nil {
+		panic(err)
+	}
+	return "*new(" + typeNameBuffer.String() + ")"
+}
+
 func FieldListToStructList(fieldList *ast.FieldList, fileset *token.FileSet) string {
 	result := []string{}
 	if fieldList == nil || len(fieldList.List) == 0 {
@@ -431,6 +483,46 @@ func (api *apiTimerLayer) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
 {{end}}
 `
 
+var apiAccountingLayerTemplate = `// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make pluginapi"
+// DO NOT EDIT
+
+package plugin
+
+import (
+	"io"
+	"net/http"
+	timePkg "time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type apiAccountingLayer struct {
+	apiImpl API
+	usage   *pluginAPIUsage
+}
+
+{{range .APIMethods}}
+
+func (api *apiAccountingLayer) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
+	{{- if errorReturn "appErr" .Return }}
+	if appErr := api.usage.startCall("{{.Name}}", true); appErr != nil {
+		return {{errorReturn "appErr" .Return}}
+	}
+	{{- else }}
+	api.usage.startCall("{{.Name}}", false)
+	{{- end }}
+	startTime := timePkg.Now()
+	{{ if .Return }} {{destruct "_returns" .Return}} := {{ end }} api.apiImpl.{{.Name}}({{valuesOnly .Params}})
+	api.usage.endCall("{{.Name}}", timePkg.Since(startTime))
+	{{ if .Return }} return {{destruct "_returns" .Return}} {{ end -}}
+}
+
+{{end}}
+`
+
 var hooksTimerLayerTemplate = `// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
 // See LICENSE.txt for license information.
 
@@ -550,6 +642,9 @@ func generatePluginTimerLayer(info *PluginInterfaceInfo) {
 		"shouldRecordSuccess": func(structPrefix string, fields *ast.FieldList) string {
 			return FieldListToRecordSuccess(structPrefix, fields)
 		},
+		"errorReturn": func(errValue string, fields *ast.FieldList) string {
+			return FieldListToErrorReturn(errValue, fields, info.FileSet)
+		},
 	}
 
 	// Prepare template params
@@ -570,8 +665,9 @@ func generatePluginTimerLayer(info *PluginInterfaceInfo) {
 	}
 
 	pluginTemplates := map[string]string{
-		"api_timer_layer_generated.go":   apiTimerLayerTemplate,
-		"hooks_timer_layer_generated.go": hooksTimerLayerTemplate,
+		"api_timer_layer_generated.go":      apiTimerLayerTemplate,
+		"api_accounting_layer_generated.go": apiAccountingLayerTemplate,
+		"hooks_timer_layer_generated.go":    hooksTimerLayerTemplate,
 	}
 
 	for fileName, presetTemplate := range pluginTemplates {
@@ -645,8 +741,8 @@ func main() {
 	log.Println("Generating plugin hooks glue")
 	generateHooksGlue(removeExcluded(forRPC, excludedPluginHooks))
 
-	// Generate plugin timer layers
-	log.Println("Generating plugin timer glue")
+	// Generate plugin timer and accounting layers
+	log.Println("Generating plugin timer and accounting glue")
 	forPlugins, err := getPluginInfo(pluginPackageDir)
 	if err != nil {
 		fmt.Println("Unable to get plugin info: " + err.Error())
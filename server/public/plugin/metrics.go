@@ -8,4 +8,8 @@ type metricsInterface interface {
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
 	ObservePluginMultiHookDuration(elapsed float64)
 	ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64)
+	SetPluginProcessMemory(pluginID string, bytes float64)
+	SetPluginProcessCPU(pluginID string, percent float64)
+	IncrementPluginAPIQuotaExceeded(pluginID, apiName string)
+	ObservePluginDatabaseQueryDuration(pluginID string, elapsed float64)
 }
@@ -116,6 +116,38 @@ func (_m *API) AddUserToChannel(channelId string, userID string, asUserId string
 	return r0, r1
 }
 
+// CallPlugin provides a mock function with given fields: pluginID, method, args
+func (_m *API) CallPlugin(pluginID string, method string, args []byte) ([]byte, *model.AppError) {
+	ret := _m.Called(pluginID, method, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CallPlugin")
+	}
+
+	var r0 []byte
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, string, []byte) ([]byte, *model.AppError)); ok {
+		return rf(pluginID, method, args)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, []byte) []byte); ok {
+		r0 = rf(pluginID, method, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, []byte) *model.AppError); ok {
+		r1 = rf(pluginID, method, args)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // CopyFileInfos provides a mock function with given fields: userID, fileIds
 func (_m *API) CopyFileInfos(userID string, fileIds []string) ([]string, *model.AppError) {
 	ret := _m.Called(userID, fileIds)
@@ -4162,6 +4194,38 @@ func (_m *API) KVList(page int, perPage int) ([]string, *model.AppError) {
 	return r0, r1
 }
 
+// KVListWithOptions provides a mock function with given fields: options
+func (_m *API) KVListWithOptions(options model.PluginKVListOptions) ([]*model.PluginKeyValue, *model.AppError) {
+	ret := _m.Called(options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KVListWithOptions")
+	}
+
+	var r0 []*model.PluginKeyValue
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(model.PluginKVListOptions) ([]*model.PluginKeyValue, *model.AppError)); ok {
+		return rf(options)
+	}
+	if rf, ok := ret.Get(0).(func(model.PluginKVListOptions) []*model.PluginKeyValue); ok {
+		r0 = rf(options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PluginKeyValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.PluginKVListOptions) *model.AppError); ok {
+		r1 = rf(options)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVSet provides a mock function with given fields: key, value
 func (_m *API) KVSet(key string, value []byte) *model.AppError {
 	ret := _m.Called(key, value)
@@ -4182,6 +4246,36 @@ func (_m *API) KVSet(key string, value []byte) *model.AppError {
 	return r0
 }
 
+// KVSetBatch provides a mock function with given fields: operations
+func (_m *API) KVSetBatch(operations []*model.PluginKVSetOperation) (bool, *model.AppError) {
+	ret := _m.Called(operations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KVSetBatch")
+	}
+
+	var r0 bool
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func([]*model.PluginKVSetOperation) (bool, *model.AppError)); ok {
+		return rf(operations)
+	}
+	if rf, ok := ret.Get(0).(func([]*model.PluginKVSetOperation) bool); ok {
+		r0 = rf(operations)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func([]*model.PluginKVSetOperation) *model.AppError); ok {
+		r1 = rf(operations)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVSetWithExpiry provides a mock function with given fields: key, value, expireInSeconds
 func (_m *API) KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError {
 	ret := _m.Called(key, value, expireInSeconds)
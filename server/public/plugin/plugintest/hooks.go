@@ -22,11 +22,57 @@ type Hooks struct {
 	mock.Mock
 }
 
+// ChannelHasBeenArchived provides a mock function with given fields: c, channel, actor
+func (_m *Hooks) ChannelHasBeenArchived(c *plugin.Context, channel *model.Channel, actor *model.User) {
+	_m.Called(c, channel, actor)
+}
+
 // ChannelHasBeenCreated provides a mock function with given fields: c, channel
 func (_m *Hooks) ChannelHasBeenCreated(c *plugin.Context, channel *model.Channel) {
 	_m.Called(c, channel)
 }
 
+// ChannelHasBeenUpdated provides a mock function with given fields: c, newChannel, oldChannel
+func (_m *Hooks) ChannelHasBeenUpdated(c *plugin.Context, newChannel *model.Channel, oldChannel *model.Channel) {
+	_m.Called(c, newChannel, oldChannel)
+}
+
+// ChannelWillBeArchived provides a mock function with given fields: c, channel, actor
+func (_m *Hooks) ChannelWillBeArchived(c *plugin.Context, channel *model.Channel, actor *model.User) string {
+	ret := _m.Called(c, channel, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeArchived")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel, *model.User) string); ok {
+		r0 = rf(c, channel, actor)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ChannelWillBeUpdated provides a mock function with given fields: c, newChannel, oldChannel
+func (_m *Hooks) ChannelWillBeUpdated(c *plugin.Context, newChannel *model.Channel, oldChannel *model.Channel) string {
+	ret := _m.Called(c, newChannel, oldChannel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeUpdated")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel, *model.Channel) string); ok {
+		r0 = rf(c, newChannel, oldChannel)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // ConfigurationWillBeSaved provides a mock function with given fields: newCfg
 func (_m *Hooks) ConfigurationWillBeSaved(newCfg *model.Config) (*model.Config, error) {
 	ret := _m.Called(newCfg)
@@ -429,6 +475,36 @@ func (_m *Hooks) OnInstall(c *plugin.Context, event model.OnInstallEvent) error
 	return r0
 }
 
+// OnPluginCall provides a mock function with given fields: c, sourcePluginID, method, args
+func (_m *Hooks) OnPluginCall(c *plugin.Context, sourcePluginID string, method string, args []byte) ([]byte, error) {
+	ret := _m.Called(c, sourcePluginID, method, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OnPluginCall")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, string, []byte) ([]byte, error)); ok {
+		return rf(c, sourcePluginID, method, args)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, string, []byte) []byte); ok {
+		r0 = rf(c, sourcePluginID, method, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, string, string, []byte) error); ok {
+		r1 = rf(c, sourcePluginID, method, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OnPluginClusterEvent provides a mock function with given fields: c, ev
 func (_m *Hooks) OnPluginClusterEvent(c *plugin.Context, ev model.PluginClusterEvent) {
 	_m.Called(c, ev)
@@ -549,6 +625,29 @@ func (_m *Hooks) OnWebSocketDisconnect(webConnID string, userID string) {
 	_m.Called(webConnID, userID)
 }
 
+// PostHasBeenPinned provides a mock function with given fields: c, post
+func (_m *Hooks) PostHasBeenPinned(c *plugin.Context, post *model.Post) {
+	_m.Called(c, post)
+}
+
+// PostWillBePinned provides a mock function with given fields: c, post
+func (_m *Hooks) PostWillBePinned(c *plugin.Context, post *model.Post) string {
+	ret := _m.Called(c, post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostWillBePinned")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Post) string); ok {
+		r0 = rf(c, post)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // PreferencesHaveChanged provides a mock function with given fields: c, preferences
 func (_m *Hooks) PreferencesHaveChanged(c *plugin.Context, preferences []model.Preference) {
 	_m.Called(c, preferences)
@@ -602,6 +701,52 @@ func (_m *Hooks) ServeMetrics(c *plugin.Context, w http.ResponseWriter, r *http.
 	_m.Called(c, w, r)
 }
 
+// TeamHasBeenCreated provides a mock function with given fields: c, team
+func (_m *Hooks) TeamHasBeenCreated(c *plugin.Context, team *model.Team) {
+	_m.Called(c, team)
+}
+
+// TeamHasBeenDeleted provides a mock function with given fields: c, team
+func (_m *Hooks) TeamHasBeenDeleted(c *plugin.Context, team *model.Team) {
+	_m.Called(c, team)
+}
+
+// TeamWillBeCreated provides a mock function with given fields: c, team
+func (_m *Hooks) TeamWillBeCreated(c *plugin.Context, team *model.Team) string {
+	ret := _m.Called(c, team)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TeamWillBeCreated")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Team) string); ok {
+		r0 = rf(c, team)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// TeamWillBeDeleted provides a mock function with given fields: c, team
+func (_m *Hooks) TeamWillBeDeleted(c *plugin.Context, team *model.Team) string {
+	ret := _m.Called(c, team)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TeamWillBeDeleted")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Team) string); ok {
+		r0 = rf(c, team)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // UserHasBeenCreated provides a mock function with given fields: c, user
 func (_m *Hooks) UserHasBeenCreated(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
@@ -637,6 +782,29 @@ func (_m *Hooks) UserHasLoggedIn(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
 }
 
+// UserRolesHaveChanged provides a mock function with given fields: c, user, oldRoles
+func (_m *Hooks) UserRolesHaveChanged(c *plugin.Context, user *model.User, oldRoles string) {
+	_m.Called(c, user, oldRoles)
+}
+
+// UserRolesWillChange provides a mock function with given fields: c, user, newRoles
+func (_m *Hooks) UserRolesWillChange(c *plugin.Context, user *model.User, newRoles string) string {
+	ret := _m.Called(c, user, newRoles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserRolesWillChange")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.User, string) string); ok {
+		r0 = rf(c, user, newRoles)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // UserWillLogIn provides a mock function with given fields: c, user
 func (_m *Hooks) UserWillLogIn(c *plugin.Context, user *model.User) string {
 	ret := _m.Called(c, user)
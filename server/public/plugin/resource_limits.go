@@ -0,0 +1,109 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"fmt"
+	"time"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var errResourceUsageUnsupported = errors.New("process resource usage is not supported on this platform")
+
+// processUsage is a snapshot of the resources used by a plugin process.
+type processUsage struct {
+	RSSBytes uint64
+	CPUTime  time.Duration
+}
+
+// WithResourceLimits bounds the resources of the plugin process. On Linux, the open files limit
+// is applied as an rlimit and, when cgroupPath points to a cgroup v2 directory the server may
+// manage, memory and CPU are enforced by a child cgroup created for the plugin.
+// Regardless of the platform, the health check restarts plugins that exceed their budget.
+func WithResourceLimits(limits model.PluginResourceLimits, cgroupPath string) func(*supervisor, *plugin.ClientConfig) error {
+	return func(sup *supervisor, _ *plugin.ClientConfig) error {
+		sup.limits = limits
+		sup.cgroupPath = cgroupPath
+		return nil
+	}
+}
+
+// applyResourceLimits applies the supervisor's limits to the freshly started plugin process.
+// Failing to do so is not fatal, as the health check still enforces the budget.
+func (sup *supervisor) applyResourceLimits(logger *mlog.Logger) {
+	if sup.isReattached {
+		return
+	}
+
+	reattachConfig := sup.client.ReattachConfig()
+	if reattachConfig == nil || reattachConfig.Pid == 0 {
+		return
+	}
+	sup.pid = reattachConfig.Pid
+
+	if sup.limits.IsZero() {
+		return
+	}
+
+	cgroupDir, err := applyProcessLimits(sup.pid, sup.pluginID, sup.limits, sup.cgroupPath)
+	if err != nil {
+		logger.Warn("Failed to apply resource limits to plugin process", mlog.Int("pid", sup.pid), mlog.Err(err))
+	}
+	sup.cgroupDir = cgroupDir
+}
+
+// CheckResourceUsage samples the resources used by the plugin process, reports them as metrics
+// and returns an error if the process exceeds its budget. CPU usage is averaged since the
+// previous sample, so the first sample only reports memory.
+func (sup *supervisor) CheckResourceUsage(metrics metricsInterface) error {
+	sup.usageLock.Lock()
+	defer sup.usageLock.Unlock()
+
+	if sup.pid == 0 {
+		return nil
+	}
+
+	usage, err := readProcessUsage(sup.pid)
+	if errors.Is(err, errResourceUsageUnsupported) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read plugin process usage: %w", err)
+	}
+
+	now := time.Now()
+	cpuPercent := -1.0
+	if !sup.lastUsageAt.IsZero() {
+		if elapsed := now.Sub(sup.lastUsageAt); elapsed > 0 {
+			cpuPercent = float64(usage.CPUTime-sup.lastUsage.CPUTime) / float64(elapsed) * 100
+		}
+	}
+	sup.lastUsage = usage
+	sup.lastUsageAt = now
+
+	if metrics != nil {
+		metrics.SetPluginProcessMemory(sup.pluginID, float64(usage.RSSBytes))
+		if cpuPercent >= 0 {
+			metrics.SetPluginProcessCPU(sup.pluginID, cpuPercent)
+		}
+	}
+
+	return checkResourceBudget(usage, cpuPercent, sup.limits)
+}
+
+func checkResourceBudget(usage processUsage, cpuPercent float64, limits model.PluginResourceLimits) error {
+	if limits.MaxMemoryMB > 0 && usage.RSSBytes > uint64(limits.MaxMemoryMB)*1024*1024 {
+		return fmt.Errorf("plugin process uses %d MB of memory, over its limit of %d MB", usage.RSSBytes/1024/1024, limits.MaxMemoryMB)
+	}
+
+	if limits.MaxCPUPercent > 0 && cpuPercent > float64(limits.MaxCPUPercent) {
+		return fmt.Errorf("plugin process uses %.0f%% CPU, over its limit of %d%%", cpuPercent, limits.MaxCPUPercent)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// clockTicksPerSecond is USER_HZ, the unit of the CPU times in /proc/<pid>/stat.
+	// It is 100 on all architectures supported by Linux.
+	clockTicksPerSecond = 100
+
+	// cgroupCPUPeriod is the period, in microseconds, over which the CPU quota is enforced.
+	cgroupCPUPeriod = 100000
+)
+
+// applyProcessLimits limits the open files of the process with an rlimit and, if cgroupPath is
+// set, moves the process to a child cgroup enforcing its memory and CPU limits. It returns the
+// directory of that cgroup, to be removed once the process has exited.
+func applyProcessLimits(pid int, pluginID string, limits model.PluginResourceLimits, cgroupPath string) (string, error) {
+	if limits.MaxOpenFiles > 0 {
+		rlimit := &unix.Rlimit{Cur: uint64(limits.MaxOpenFiles), Max: uint64(limits.MaxOpenFiles)}
+		if err := unix.Prlimit(pid, unix.RLIMIT_NOFILE, rlimit, nil); err != nil {
+			return "", errors.Wrap(err, "failed to set open files limit")
+		}
+	}
+
+	if cgroupPath == "" || (limits.MaxMemoryMB == 0 && limits.MaxCPUPercent == 0) {
+		return "", nil
+	}
+
+	cgroupDir := filepath.Join(cgroupPath, "plugin-"+pluginID)
+	if err := os.Mkdir(cgroupDir, 0755); err != nil && !os.IsExist(err) {
+		return "", errors.Wrap(err, "failed to create plugin cgroup")
+	}
+
+	// The controllers must be enabled in the cgroup.subtree_control of cgroupPath for these
+	// files to exist.
+	if limits.MaxMemoryMB > 0 {
+		memoryMax := strconv.Itoa(limits.MaxMemoryMB * 1024 * 1024)
+		if err := writeCgroupFile(cgroupDir, "memory.max", memoryMax); err != nil {
+			return cgroupDir, err
+		}
+	}
+
+	if limits.MaxCPUPercent > 0 {
+		cpuMax := fmt.Sprintf("%d %d", limits.MaxCPUPercent*cgroupCPUPeriod/100, cgroupCPUPeriod)
+		if err := writeCgroupFile(cgroupDir, "cpu.max", cpuMax); err != nil {
+			return cgroupDir, err
+		}
+	}
+
+	if err := writeCgroupFile(cgroupDir, "cgroup.procs", strconv.Itoa(pid)); err != nil {
+		return cgroupDir, err
+	}
+
+	return cgroupDir, nil
+}
+
+func writeCgroupFile(cgroupDir, name, value string) error {
+	if err := os.WriteFile(filepath.Join(cgroupDir, name), []byte(value), 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", name)
+	}
+	return nil
+}
+
+// removeCgroup removes the cgroup created for a plugin process that has exited.
+func removeCgroup(cgroupDir string) error {
+	return os.Remove(cgroupDir)
+}
+
+// readProcessUsage reads the resident memory and the CPU time of a process from /proc/<pid>/stat.
+func readProcessUsage(pid int) (processUsage, error) {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return processUsage{}, err
+	}
+	return parseProcStat(data)
+}
+
+// parseProcStat parses the contents of /proc/<pid>/stat, see proc(5).
+func parseProcStat(data []byte) (processUsage, error) {
+	// The command name may contain spaces and parentheses, so fields are counted from the last
+	// closing parenthesis, starting with the state (field 3).
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return processUsage{}, errors.New("invalid stat format")
+	}
+	fields := bytes.Fields(data[end+1:])
+
+	const (
+		utimeField = 14 - 3
+		stimeField = 15 - 3
+		rssField   = 24 - 3
+	)
+	if len(fields) <= rssField {
+		return processUsage{}, errors.New("invalid stat format")
+	}
+
+	values := make([]uint64, 0, 3)
+	for _, field := range []int{utimeField, stimeField, rssField} {
+		value, err := strconv.ParseUint(string(fields[field]), 10, 64)
+		if err != nil {
+			return processUsage{}, errors.Wrap(err, "invalid stat format")
+		}
+		values = append(values, value)
+	}
+
+	return processUsage{
+		CPUTime:  time.Duration(values[0]+values[1]) * time.Second / clockTicksPerSecond,
+		RSSBytes: values[2] * uint64(os.Getpagesize()),
+	}, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type testResourceMetrics struct {
+	metricsInterface
+
+	mut        sync.Mutex
+	memory     map[string]float64
+	cpuPercent map[string]float64
+}
+
+func (m *testResourceMetrics) SetPluginProcessMemory(pluginID string, bytes float64) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.memory[pluginID] = bytes
+}
+
+func (m *testResourceMetrics) SetPluginProcessCPU(pluginID string, percent float64) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.cpuPercent[pluginID] = percent
+}
+
+func TestParseProcStat(t *testing.T) {
+	pageSize := uint64(os.Getpagesize())
+
+	t.Run("valid", func(t *testing.T) {
+		stat := "4242 (plugin (v2) exe) S 1 4242 4242 0 -1 4194560 1527 0 0 0 250 150 0 0 20 0 12 0 1000 1234567890 2048 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 3 0 0 0 0 0\n"
+		usage, err := parseProcStat([]byte(stat))
+		require.NoError(t, err)
+		assert.Equal(t, 4*time.Second, usage.CPUTime)
+		assert.Equal(t, 2048*pageSize, usage.RSSBytes)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := parseProcStat([]byte("4242 (exe) S 1 4242"))
+		assert.Error(t, err)
+	})
+
+	t.Run("no command", func(t *testing.T) {
+		_, err := parseProcStat([]byte("4242"))
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckResourceUsage(t *testing.T) {
+	metrics := &testResourceMetrics{
+		memory:     map[string]float64{},
+		cpuPercent: map[string]float64{},
+	}
+
+	sup := &supervisor{pluginID: "test.plugin", pid: os.Getpid()}
+
+	require.NoError(t, sup.CheckResourceUsage(metrics))
+	assert.Greater(t, metrics.memory["test.plugin"], float64(0))
+	assert.NotContains(t, metrics.cpuPercent, "test.plugin", "CPU usage needs two samples")
+
+	require.NoError(t, sup.CheckResourceUsage(metrics))
+	assert.Contains(t, metrics.cpuPercent, "test.plugin")
+
+	t.Run("over memory budget", func(t *testing.T) {
+		sup.limits = model.PluginResourceLimits{MaxMemoryMB: 1}
+		assert.Error(t, sup.CheckResourceUsage(metrics))
+	})
+
+	t.Run("not started", func(t *testing.T) {
+		sup := &supervisor{pluginID: "test.plugin", limits: model.PluginResourceLimits{MaxMemoryMB: 1}}
+		assert.NoError(t, sup.CheckResourceUsage(metrics))
+	})
+}
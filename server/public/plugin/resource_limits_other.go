@@ -0,0 +1,24 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build !linux
+
+package plugin
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// applyProcessLimits is only supported on Linux. Elsewhere, the health check is the only
+// enforcement of the plugin's budget.
+func applyProcessLimits(_ int, _ string, _ model.PluginResourceLimits, _ string) (string, error) {
+	return "", nil
+}
+
+func removeCgroup(_ string) error {
+	return nil
+}
+
+func readProcessUsage(_ int) (processUsage, error) {
+	return processUsage{}, errResourceUsageUnsupported
+}
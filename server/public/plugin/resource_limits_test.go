@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestCheckResourceBudget(t *testing.T) {
+	const mb = 1024 * 1024
+	limits := model.PluginResourceLimits{MaxMemoryMB: 100, MaxCPUPercent: 50}
+
+	for name, test := range map[string]struct {
+		Usage       processUsage
+		CPUPercent  float64
+		Limits      model.PluginResourceLimits
+		ExpectError bool
+	}{
+		"within budget": {
+			Usage:      processUsage{RSSBytes: 99 * mb, CPUTime: time.Second},
+			CPUPercent: 49,
+			Limits:     limits,
+		},
+		"over memory": {
+			Usage:       processUsage{RSSBytes: 101 * mb},
+			CPUPercent:  10,
+			Limits:      limits,
+			ExpectError: true,
+		},
+		"over cpu": {
+			Usage:       processUsage{RSSBytes: 10 * mb},
+			CPUPercent:  51,
+			Limits:      limits,
+			ExpectError: true,
+		},
+		"cpu not sampled yet": {
+			Usage:      processUsage{RSSBytes: 10 * mb},
+			CPUPercent: -1,
+			Limits:     limits,
+		},
+		"no limits": {
+			Usage:      processUsage{RSSBytes: 10000 * mb},
+			CPUPercent: 400,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := checkResourceBudget(test.Usage, test.CPUPercent, test.Limits)
+			if test.ExpectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEnvironmentResourceLimits(t *testing.T) {
+	env := &Environment{}
+	env.SetResourceLimits(
+		model.PluginResourceLimits{MaxMemoryMB: 512, MaxOpenFiles: 1024},
+		map[string]model.PluginResourceLimits{"com.example.heavy": {MaxMemoryMB: 2048}},
+		"/sys/fs/cgroup/mattermost/plugins",
+	)
+
+	limitsFor := func(manifest *model.Manifest) model.PluginResourceLimits {
+		sup := &supervisor{}
+		assert.NoError(t, env.withResourceLimits(manifest)(sup, nil))
+		assert.Equal(t, "/sys/fs/cgroup/mattermost/plugins", sup.cgroupPath)
+		return sup.limits
+	}
+
+	t.Run("global limits", func(t *testing.T) {
+		assert.Equal(t, model.PluginResourceLimits{MaxMemoryMB: 512, MaxOpenFiles: 1024}, limitsFor(&model.Manifest{Id: "com.example.light"}))
+	})
+
+	t.Run("plugin limits override global limits", func(t *testing.T) {
+		assert.Equal(t, model.PluginResourceLimits{MaxMemoryMB: 2048, MaxOpenFiles: 1024}, limitsFor(&model.Manifest{Id: "com.example.heavy"}))
+	})
+
+	t.Run("manifest limits can only tighten", func(t *testing.T) {
+		manifest := &model.Manifest{Id: "com.example.heavy", Server: &model.ManifestServer{
+			Executable:     "theexecutable",
+			ResourceLimits: &model.PluginResourceLimits{MaxMemoryMB: 4096, MaxOpenFiles: 256},
+		}}
+		assert.Equal(t, model.PluginResourceLimits{MaxMemoryMB: 2048, MaxOpenFiles: 256}, limitsFor(manifest))
+	})
+}
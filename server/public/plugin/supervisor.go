@@ -31,6 +31,14 @@ type supervisor struct {
 	implemented  [TotalHooksID]bool
 	hooksClient  *hooksRPCClient
 	isReattached bool
+
+	pid         int
+	limits      model.PluginResourceLimits
+	cgroupPath  string
+	cgroupDir   string
+	usageLock   sync.Mutex
+	lastUsage   processUsage
+	lastUsageAt time.Time
 }
 
 type driverForPlugin struct {
@@ -136,6 +144,8 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 		return nil, err
 	}
 
+	sup.applyResourceLimits(wrappedLogger)
+
 	raw, err := rpcClient.Dispense("hooks")
 	if err != nil {
 		return nil, err
@@ -182,6 +192,12 @@ func (sup *supervisor) Shutdown() {
 		sup.client.Kill()
 	}
 
+	if sup.cgroupDir != "" {
+		if err := removeCgroup(sup.cgroupDir); err != nil {
+			mlog.Warn("Failed to remove plugin cgroup", mlog.String("plugin_id", sup.pluginID), mlog.Err(err))
+		}
+	}
+
 	// Wait for API RPC server and DB RPC server to exit.
 	// And then shutdown conns.
 	if sup.hooksClient != nil {
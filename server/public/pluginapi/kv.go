@@ -276,3 +276,55 @@ func (k *KVService) ListKeys(page, count int, options ...ListKeysOption) ([]stri
 
 	return ret, nil
 }
+
+// SetBatch applies several writes in a single transaction. Writes with SetAtomic compare the
+// current value with the given old value, as Set does. If any comparison fails, nothing is
+// written.
+//
+// Returns (false, err) if DB error occurred
+// Returns (false, nil) if a comparison failed and nothing was written
+// Returns (true, nil) if all the writes were applied
+//
+// Minimum server version: 11.6
+func (k *KVService) SetBatch(writes ...KVWrite) (bool, error) {
+	operations, err := newKVSetOperations(writes)
+	if err != nil {
+		return false, err
+	}
+
+	written, appErr := k.api.KVSetBatch(operations)
+	return written, normalizeAppErr(appErr)
+}
+
+// Iterate returns an iterator over the key-value pairs whose key starts with the given prefix,
+// in key order, fetching pageSize key-value pairs at a time. A pageSize of zero uses a default
+// page size.
+//
+// Minimum server version: 11.6
+func (k *KVService) Iterate(prefix string, pageSize int) *KVIterator {
+	return newKVIterator(prefix, pageSize, func(options model.PluginKVListOptions) ([]*model.PluginKeyValue, error) {
+		kvs, appErr := k.api.KVListWithOptions(options)
+		return kvs, normalizeAppErr(appErr)
+	})
+}
+
+// RunInTransaction runs fn in an optimistic transaction across keys. The writes made by fn are
+// applied at once when it returns, only if none of the keys it read has been modified since.
+// Otherwise, fn is run again, up to a few times before returning ErrKVTransactionConflict.
+//
+// fn must not have side effects other than through the transaction, as it may run several times.
+// If fn returns an error, nothing is written and the error is returned.
+//
+// Minimum server version: 11.6
+func (k *KVService) RunInTransaction(fn func(tx *KVTx) error) error {
+	get := func(key string) ([]byte, error) {
+		data, appErr := k.api.KVGet(key)
+		return data, normalizeAppErr(appErr)
+	}
+	setBatch := func(operations []*model.PluginKVSetOperation) (bool, error) {
+		written, appErr := k.api.KVSetBatch(operations)
+		return written, normalizeAppErr(appErr)
+	}
+
+	return runKVTransaction(fn, get, setBatch)
+}
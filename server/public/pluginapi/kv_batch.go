@@ -0,0 +1,331 @@
+package pluginapi
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// defaultKVIteratePageSize is the number of key-value pairs fetched at once by a KVIterator when
+// no page size is given.
+const defaultKVIteratePageSize = 100
+
+// ErrKVTransactionConflict is returned by RunInTransaction when the keys used by the transaction
+// kept being modified concurrently.
+var ErrKVTransactionConflict = errors.New("transaction conflicted with concurrent writes")
+
+// KVWrite is a single write of a batch applied by SetBatch.
+type KVWrite struct {
+	Key     string
+	Value   any // The value to store, or nil to delete the key
+	Options []KVSetOption
+}
+
+// newKVSetOperation converts a write into the operation sent to the server, encoding the value
+// and the old value as JSON unless given a byte slice.
+func newKVSetOperation(write KVWrite) (*model.PluginKVSetOperation, error) {
+	if strings.HasPrefix(write.Key, internalKeyPrefix) {
+		return nil, errors.Errorf("'%s' prefix is not allowed for keys", internalKeyPrefix)
+	}
+
+	opts := KVSetOptions{}
+	for _, o := range write.Options {
+		if o != nil {
+			o(&opts)
+		}
+	}
+
+	value, err := marshalKVValue(write.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	oldValue, err := marshalKVValue(opts.oldValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PluginKVSetOperation{
+		Key:   write.Key,
+		Value: value,
+		Options: model.PluginKVSetOptions{
+			Atomic:          opts.Atomic,
+			OldValue:        oldValue,
+			ExpireInSeconds: opts.ExpireInSeconds,
+		},
+	}, nil
+}
+
+func newKVSetOperations(writes []KVWrite) ([]*model.PluginKVSetOperation, error) {
+	operations := make([]*model.PluginKVSetOperation, 0, len(writes))
+	for _, write := range writes {
+		op, err := newKVSetOperation(write)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, op)
+	}
+
+	return operations, nil
+}
+
+func marshalKVValue(value any) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	// Assume JSON encoding, unless explicitly given a byte slice.
+	if valueBytes, ok := value.([]byte); ok {
+		return valueBytes, nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal value %v", value)
+	}
+
+	return valueBytes, nil
+}
+
+func unmarshalKVValue(key string, data []byte, o any) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	if bytesOut, ok := o.(*[]byte); ok {
+		*bytesOut = data
+		return nil
+	}
+
+	if err := json.Unmarshal(data, o); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal value for key %s", key)
+	}
+
+	return nil
+}
+
+// KVIterator iterates in key order over the key-value pairs whose key starts with a prefix,
+// fetching them a page at a time. Use it as:
+//
+//	it := client.KV.Iterate("prefix_", 0)
+//	for it.Next() {
+//		var value T
+//		if err := it.Value(&value); err != nil {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// Key-value pairs written during the iteration may or may not be visited.
+type KVIterator struct {
+	list    func(options model.PluginKVListOptions) ([]*model.PluginKeyValue, error)
+	options model.PluginKVListOptions
+	page    []*model.PluginKeyValue
+	current *model.PluginKeyValue
+	done    bool
+	err     error
+}
+
+func newKVIterator(prefix string, pageSize int, list func(options model.PluginKVListOptions) ([]*model.PluginKeyValue, error)) *KVIterator {
+	if pageSize <= 0 {
+		pageSize = defaultKVIteratePageSize
+	}
+	pageSize = min(pageSize, model.PluginKVListLimitMax)
+
+	return &KVIterator{
+		list: list,
+		options: model.PluginKVListOptions{
+			Prefix: prefix,
+			Limit:  pageSize,
+		},
+	}
+}
+
+// Next advances the iterator to the next key-value pair, and returns false once there are no
+// more key-value pairs or an error occurred.
+func (it *KVIterator) Next() bool {
+	it.current = nil
+	if it.err != nil {
+		return false
+	}
+
+	if len(it.page) == 0 {
+		if it.done {
+			return false
+		}
+
+		page, err := it.list(it.options)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if len(page) < it.options.Limit {
+			it.done = true
+		}
+		if len(page) == 0 {
+			return false
+		}
+
+		it.page = page
+		it.options.AfterKey = page[len(page)-1].Key
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Key returns the key of the current key-value pair.
+func (it *KVIterator) Key() string {
+	if it.current == nil {
+		return ""
+	}
+
+	return it.current.Key
+}
+
+// Value gets the value of the current key-value pair into the given interface.
+func (it *KVIterator) Value(o any) error {
+	if it.current == nil {
+		return errors.New("no current key-value pair")
+	}
+
+	return unmarshalKVValue(it.current.Key, it.current.Value, o)
+}
+
+// ExpireAt returns when the current key-value pair expires, in milliseconds since the epoch, or
+// zero if it doesn't expire.
+func (it *KVIterator) ExpireAt() int64 {
+	if it.current == nil {
+		return 0
+	}
+
+	return it.current.ExpireAt
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *KVIterator) Err() error {
+	return it.err
+}
+
+// KVTx is an optimistic transaction across keys, passed to the function given to
+// RunInTransaction.
+//
+// Reads go to the store, and are remembered. Writes are buffered and applied at once when the
+// function returns, only if none of the keys read has been modified in the meantime.
+type KVTx struct {
+	get    func(key string) ([]byte, error)
+	reads  map[string][]byte
+	writes map[string]*model.PluginKVSetOperation
+}
+
+// Get gets the value for the given key into the given interface, seeing the writes made earlier
+// in the transaction. A non-existent key will return no error, with nothing written to the given
+// interface.
+func (tx *KVTx) Get(key string, o any) error {
+	var data []byte
+	if op, ok := tx.writes[key]; ok {
+		data = op.Value
+	} else if value, ok := tx.reads[key]; ok {
+		data = value
+	} else {
+		var err error
+		if data, err = tx.get(key); err != nil {
+			return err
+		}
+		tx.reads[key] = data
+	}
+
+	return unmarshalKVValue(key, data, o)
+}
+
+// Set stores a key-value pair when the transaction commits. SetExpiry is the only option
+// supported, as the transaction itself guarantees atomicity.
+func (tx *KVTx) Set(key string, value any, options ...KVSetOption) error {
+	op, err := newKVSetOperation(KVWrite{Key: key, Value: value, Options: options})
+	if err != nil {
+		return err
+	}
+	if op.Options.Atomic {
+		return errors.New("atomic writes are not supported in transactions")
+	}
+
+	tx.writes[key] = op
+	return nil
+}
+
+// Delete deletes the given key when the transaction commits.
+func (tx *KVTx) Delete(key string) error {
+	return tx.Set(key, nil)
+}
+
+// operations returns the batch committing the transaction: the buffered writes, compared with
+// the values read, and a check of every key read but not written.
+func (tx *KVTx) operations() []*model.PluginKVSetOperation {
+	operations := make([]*model.PluginKVSetOperation, 0, len(tx.reads)+len(tx.writes))
+	for key, op := range tx.writes {
+		if value, ok := tx.reads[key]; ok {
+			op.Options.Atomic = true
+			op.Options.OldValue = value
+		}
+		operations = append(operations, op)
+	}
+
+	for key, value := range tx.reads {
+		if _, ok := tx.writes[key]; ok {
+			continue
+		}
+		operations = append(operations, &model.PluginKVSetOperation{
+			Key:       key,
+			Options:   model.PluginKVSetOptions{Atomic: true, OldValue: value},
+			CheckOnly: true,
+		})
+	}
+
+	slices.SortFunc(operations, func(a, b *model.PluginKVSetOperation) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return operations
+}
+
+// runKVTransaction runs fn and commits its writes, running it again from scratch when a key it
+// read was modified concurrently.
+func runKVTransaction(
+	fn func(tx *KVTx) error,
+	get func(key string) ([]byte, error),
+	setBatch func(operations []*model.PluginKVSetOperation) (bool, error),
+) error {
+	for range numRetries {
+		tx := &KVTx{
+			get:    get,
+			reads:  make(map[string][]byte),
+			writes: make(map[string]*model.PluginKVSetOperation),
+		}
+		if err := fn(tx); err != nil {
+			return err
+		}
+
+		operations := tx.operations()
+		if len(operations) == 0 {
+			return nil
+		}
+
+		committed, err := setBatch(operations)
+		if err != nil {
+			return errors.Wrap(err, "failed to commit transaction")
+		} else if committed {
+			return nil
+		}
+
+		// small delay to allow cooperative scheduling to do its thing
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return ErrKVTransactionConflict
+}
@@ -281,3 +281,101 @@ func paginateSlice[T any](list []T, page int, perPage int) []T {
 	}
 	return list[i:j]
 }
+
+// SetBatch applies several writes at once. If the current value of any atomic write doesn't
+// match its old value, nothing is written and false is returned.
+func (s *MemoryStore) SetBatch(writes ...KVWrite) (bool, error) {
+	operations, err := newKVSetOperations(writes)
+	if err != nil {
+		return false, err
+	}
+
+	return s.setBatch(operations)
+}
+
+func (s *MemoryStore) setBatch(operations []*model.PluginKVSetOperation) (bool, error) {
+	if err := model.IsValidPluginKVSetOperations(operations); err != nil {
+		return false, err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.elems == nil {
+		s.elems = make(map[string]kvElem)
+	}
+
+	for _, op := range operations {
+		if !op.Options.Atomic {
+			continue
+		}
+
+		elem, ok := s.elems[op.Key]
+		exists := ok && !elem.isExpired()
+		if exists != (op.Options.OldValue != nil) || (exists && !bytes.Equal(elem.value, op.Options.OldValue)) {
+			return false, nil
+		}
+	}
+
+	for _, op := range operations {
+		if op.CheckOnly {
+			continue
+		}
+
+		if op.Value == nil {
+			delete(s.elems, op.Key)
+		} else {
+			s.elems[op.Key] = kvElem{
+				value:     op.Value,
+				expiresAt: expireTime(op.Options.ExpireInSeconds),
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// Iterate returns an iterator over the key-value pairs whose key starts with the given prefix,
+// in key order.
+func (s *MemoryStore) Iterate(prefix string, pageSize int) *KVIterator {
+	return newKVIterator(prefix, pageSize, s.list)
+}
+
+func (s *MemoryStore) list(options model.PluginKVListOptions) ([]*model.PluginKeyValue, error) {
+	if err := options.IsValid(); err != nil {
+		return nil, err
+	}
+
+	s.mux.RLock()
+	kvs := make([]*model.PluginKeyValue, 0)
+	for k, e := range s.elems {
+		if e.isExpired() || !strings.HasPrefix(k, options.Prefix) || (options.AfterKey != "" && k <= options.AfterKey) {
+			continue
+		}
+
+		kv := &model.PluginKeyValue{Key: k, Value: e.value}
+		if e.expiresAt != nil {
+			kv.ExpireAt = e.expiresAt.UnixMilli()
+		}
+		kvs = append(kvs, kv)
+	}
+	s.mux.RUnlock()
+
+	slices.SortFunc(kvs, func(a, b *model.PluginKeyValue) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return kvs[:min(len(kvs), options.Limit)], nil
+}
+
+// RunInTransaction runs fn in an optimistic transaction across keys, applying its writes only if
+// none of the keys it read has been modified since.
+func (s *MemoryStore) RunInTransaction(fn func(tx *KVTx) error) error {
+	get := func(key string) ([]byte, error) {
+		var data []byte
+		err := s.Get(key, &data)
+		return data, err
+	}
+
+	return runKVTransaction(fn, get, s.setBatch)
+}
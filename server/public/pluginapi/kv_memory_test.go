@@ -21,9 +21,12 @@ type kvStore interface {
 	Delete(key string) error
 	DeleteAll() error
 	Get(key string, o any) error
+	Iterate(prefix string, pageSize int) *pluginapi.KVIterator
 	ListKeys(page, count int, options ...pluginapi.ListKeysOption) ([]string, error)
+	RunInTransaction(fn func(tx *pluginapi.KVTx) error) error
 	Set(key string, value any, options ...pluginapi.KVSetOption) (bool, error)
 	SetAtomicWithRetries(key string, valueFunc func(oldValue []byte) (newValue any, err error)) error
+	SetBatch(writes ...pluginapi.KVWrite) (bool, error)
 }
 
 var _ kvStore = (*pluginapi.MemoryStore)(nil)
@@ -465,3 +468,210 @@ func isNil(t require.TestingT, store *pluginapi.MemoryStore, key string) {
 	require.NoError(t, err)
 	assert.Nil(t, out)
 }
+
+func TestMemoryStoreSetBatch(t *testing.T) {
+	t.Run("all writes applied", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+		_, err := store.Set("delete", []byte("value"))
+		require.NoError(t, err)
+
+		ok, err := store.SetBatch(
+			pluginapi.KVWrite{Key: "a", Value: []byte("1")},
+			pluginapi.KVWrite{Key: "b", Value: map[string]int{"b": 2}, Options: []pluginapi.KVSetOption{pluginapi.SetAtomic(nil)}},
+			pluginapi.KVWrite{Key: "delete", Value: nil},
+		)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		var a []byte
+		require.NoError(t, store.Get("a", &a))
+		assert.Equal(t, []byte("1"), a)
+
+		var b map[string]int
+		require.NoError(t, store.Get("b", &b))
+		assert.Equal(t, map[string]int{"b": 2}, b)
+
+		var deleted []byte
+		require.NoError(t, store.Get("delete", &deleted))
+		assert.Nil(t, deleted)
+	})
+
+	t.Run("failed comparison writes nothing", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+		_, err := store.Set("b", []byte("current"))
+		require.NoError(t, err)
+
+		ok, err := store.SetBatch(
+			pluginapi.KVWrite{Key: "a", Value: []byte("1")},
+			pluginapi.KVWrite{Key: "b", Value: []byte("2"), Options: []pluginapi.KVSetOption{pluginapi.SetAtomic([]byte("stale"))}},
+		)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		var a, b []byte
+		require.NoError(t, store.Get("a", &a))
+		assert.Nil(t, a)
+		require.NoError(t, store.Get("b", &b))
+		assert.Equal(t, []byte("current"), b)
+	})
+
+	t.Run("insert only if missing", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+		_, err := store.Set("a", []byte("current"))
+		require.NoError(t, err)
+
+		ok, err := store.SetBatch(pluginapi.KVWrite{Key: "a", Value: []byte("new"), Options: []pluginapi.KVSetOption{pluginapi.SetAtomic(nil)}})
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("invalid batches", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+
+		_, err := store.SetBatch()
+		assert.Error(t, err)
+
+		_, err = store.SetBatch(pluginapi.KVWrite{Key: "a"}, pluginapi.KVWrite{Key: "a"})
+		assert.Error(t, err)
+
+		_, err = store.SetBatch(pluginapi.KVWrite{Key: "mmi_a", Value: []byte("1")})
+		assert.Error(t, err)
+	})
+}
+
+func TestMemoryStoreIterate(t *testing.T) {
+	store := pluginapi.MemoryStore{}
+	for i := range 5 {
+		_, err := store.Set(fmt.Sprintf("user_%d", i), i)
+		require.NoError(t, err)
+	}
+	_, err := store.Set("user_expiring", 5, pluginapi.SetExpiry(time.Hour))
+	require.NoError(t, err)
+	_, err = store.Set("other", 6)
+	require.NoError(t, err)
+
+	it := store.Iterate("user_", 2)
+	keys := []string{}
+	for it.Next() {
+		var value int
+		require.NoError(t, it.Value(&value))
+		if it.Key() == "user_expiring" {
+			assert.Equal(t, 5, value)
+			assert.NotZero(t, it.ExpireAt())
+		} else {
+			assert.Equal(t, "user_"+strconv.Itoa(value), it.Key())
+			assert.Zero(t, it.ExpireAt())
+		}
+		keys = append(keys, it.Key())
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"user_0", "user_1", "user_2", "user_3", "user_4", "user_expiring"}, keys)
+
+	it = store.Iterate("missing_", 0)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestMemoryStoreRunInTransaction(t *testing.T) {
+	t.Run("moves a value between keys", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+		_, err := store.Set("from", 10)
+		require.NoError(t, err)
+
+		err = store.RunInTransaction(func(tx *pluginapi.KVTx) error {
+			var from, to int
+			if err := tx.Get("from", &from); err != nil {
+				return err
+			}
+			if err := tx.Get("to", &to); err != nil {
+				return err
+			}
+			if err := tx.Set("from", from-3); err != nil {
+				return err
+			}
+			if err := tx.Set("to", to+3); err != nil {
+				return err
+			}
+
+			// Reads see the writes of the transaction.
+			var written int
+			require.NoError(t, tx.Get("to", &written))
+			assert.Equal(t, 3, written)
+			return nil
+		})
+		require.NoError(t, err)
+
+		var from, to int
+		require.NoError(t, store.Get("from", &from))
+		require.NoError(t, store.Get("to", &to))
+		assert.Equal(t, 7, from)
+		assert.Equal(t, 3, to)
+	})
+
+	t.Run("retries after a conflict", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+		_, err := store.Set("counter", 1)
+		require.NoError(t, err)
+
+		attempts := 0
+		err = store.RunInTransaction(func(tx *pluginapi.KVTx) error {
+			attempts++
+
+			var counter int
+			if err := tx.Get("counter", &counter); err != nil {
+				return err
+			}
+			if attempts == 1 {
+				// A concurrent write to a key read by the transaction.
+				_, err := store.Set("counter", 10)
+				require.NoError(t, err)
+			}
+			return tx.Set("result", counter*2)
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+
+		var result int
+		require.NoError(t, store.Get("result", &result))
+		assert.Equal(t, 20, result)
+	})
+
+	t.Run("gives up after repeated conflicts", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+
+		err := store.RunInTransaction(func(tx *pluginapi.KVTx) error {
+			var counter int
+			if err := tx.Get("counter", &counter); err != nil {
+				return err
+			}
+			_, err := store.Set("counter", counter+1)
+			require.NoError(t, err)
+			return tx.Set("result", counter)
+		})
+		assert.ErrorIs(t, err, pluginapi.ErrKVTransactionConflict)
+	})
+
+	t.Run("error discards the writes", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+		errFailed := errors.New("failed")
+
+		err := store.RunInTransaction(func(tx *pluginapi.KVTx) error {
+			require.NoError(t, tx.Set("key", 1))
+			return errFailed
+		})
+		assert.Equal(t, errFailed, err)
+
+		var value []byte
+		require.NoError(t, store.Get("key", &value))
+		assert.Nil(t, value)
+	})
+
+	t.Run("atomic writes are rejected", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+
+		err := store.RunInTransaction(func(tx *pluginapi.KVTx) error {
+			return tx.Set("key", 1, pluginapi.SetAtomic(nil))
+		})
+		assert.Error(t, err)
+	})
+}
@@ -7,8 +7,10 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -635,3 +637,104 @@ func getKeys(count int) []string {
 	}
 	return ret
 }
+
+func TestSetBatch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("KVSetBatch", []*model.PluginKVSetOperation{
+			{Key: "a", Value: []byte(`{"a":1}`)},
+			{Key: "b", Value: []byte("2"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("1"), ExpireInSeconds: 60}},
+		}).Return(true, nil)
+
+		ok, err := client.KV.SetBatch(
+			pluginapi.KVWrite{Key: "a", Value: map[string]int{"a": 1}},
+			pluginapi.KVWrite{Key: "b", Value: []byte("2"), Options: []pluginapi.KVSetOption{pluginapi.SetAtomic([]byte("1")), pluginapi.SetExpiry(time.Minute)}},
+		)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("KVSetBatch", mock.Anything).Return(false, newAppError())
+
+		ok, err := client.KV.SetBatch(pluginapi.KVWrite{Key: "a", Value: []byte("1")})
+		require.Error(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestIterate(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	api.On("KVListWithOptions", model.PluginKVListOptions{Prefix: "key", Limit: 2}).Return([]*model.PluginKeyValue{
+		{Key: "key1", Value: []byte("1")},
+		{Key: "key2", Value: []byte("2"), ExpireAt: 1234},
+	}, nil)
+	api.On("KVListWithOptions", model.PluginKVListOptions{Prefix: "key", AfterKey: "key2", Limit: 2}).Return([]*model.PluginKeyValue{
+		{Key: "key3", Value: []byte("3")},
+	}, nil)
+
+	it := client.KV.Iterate("key", 2)
+	values := map[string]int{}
+	for it.Next() {
+		var value int
+		require.NoError(t, it.Value(&value))
+		values[it.Key()] = value
+		if it.Key() == "key2" {
+			assert.Equal(t, int64(1234), it.ExpireAt())
+		}
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, map[string]int{"key1": 1, "key2": 2, "key3": 3}, values)
+
+	t.Run("error", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("KVListWithOptions", mock.Anything).Return(nil, newAppError())
+
+		it := client.KV.Iterate("key", 0)
+		assert.False(t, it.Next())
+		assert.Error(t, it.Err())
+	})
+}
+
+func TestRunInTransaction(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	api.On("KVGet", "balance").Return([]byte("10"), nil).Once()
+	api.On("KVGet", "balance").Return([]byte("20"), nil).Once()
+	api.On("KVGet", "limit").Return([]byte("100"), nil).Times(2)
+	api.On("KVSetBatch", []*model.PluginKVSetOperation{
+		{Key: "balance", Value: []byte("15"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("10")}},
+		{Key: "limit", Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("100")}, CheckOnly: true},
+	}).Return(false, nil).Once()
+	api.On("KVSetBatch", []*model.PluginKVSetOperation{
+		{Key: "balance", Value: []byte("25"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("20")}},
+		{Key: "limit", Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("100")}, CheckOnly: true},
+	}).Return(true, nil).Once()
+
+	err := client.KV.RunInTransaction(func(tx *pluginapi.KVTx) error {
+		var balance, limit int
+		if err := tx.Get("balance", &balance); err != nil {
+			return err
+		}
+		if err := tx.Get("limit", &limit); err != nil {
+			return err
+		}
+		return tx.Set("balance", min(balance+5, limit))
+	})
+	require.NoError(t, err)
+}
@@ -168,3 +168,42 @@ func TestGetPluginAssetURL(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestCall(t *testing.T) {
+	type request struct {
+		Name string `json:"name"`
+	}
+	type response struct {
+		Greeting string `json:"greeting"`
+	}
+
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		api.On("CallPlugin", "com.company.other", "Greet", []byte(`{"name":"world"}`)).Return([]byte(`{"greeting":"hello world"}`), nil)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		var resp response
+		err := client.Plugin.Call("com.company.other", "Greet", request{Name: "world"}, &resp)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", resp.Greeting)
+	})
+
+	t.Run("method not exported", func(t *testing.T) {
+		api := &plugintest.API{}
+		api.On("CallPlugin", "com.company.other", "Greet", mock.Anything).Return(nil, model.NewAppError("CallPlugin", "app.plugin.call.method_not_exported.app_error", nil, "", http.StatusNotFound))
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		err := client.Plugin.Call("com.company.other", "Greet", request{}, nil)
+		assert.Equal(t, pluginapi.ErrNotFound, err)
+	})
+
+	t.Run("plugin error", func(t *testing.T) {
+		api := &plugintest.API{}
+		appErr := model.NewAppError("Greet", "some.error", nil, "", http.StatusBadRequest)
+		api.On("CallPlugin", "com.company.other", "Greet", mock.Anything).Return(nil, appErr)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		err := client.Plugin.Call("com.company.other", "Greet", request{}, nil)
+		assert.Equal(t, appErr, err)
+	})
+}
@@ -1,6 +1,7 @@
 package pluginapi
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/url"
@@ -112,3 +113,30 @@ func (p *PluginService) GetPluginStatus(id string) (*model.PluginStatus, error)
 func (p *PluginService) HTTP(request *http.Request) *http.Response {
 	return p.api.PluginHTTP(request)
 }
+
+// Call calls a method exported by another plugin. The request is encoded as JSON and the
+// response, if any, is decoded into resp. The called plugin must be declared as a dependency
+// in the manifest of the calling plugin.
+//
+// Minimum server version: 11.6
+func (p *PluginService) Call(pluginID, method string, req, resp any) error {
+	args, err := json.Marshal(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal request")
+	}
+
+	result, appErr := p.api.CallPlugin(pluginID, method, args)
+	if appErr != nil {
+		return normalizeAppErr(appErr)
+	}
+
+	if resp == nil || len(result) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(result, resp); err != nil {
+		return errors.Wrap(err, "failed to unmarshal response")
+	}
+
+	return nil
+}
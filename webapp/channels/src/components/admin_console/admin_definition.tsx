@@ -3880,6 +3880,58 @@ const AdminDefinition: AdminDefinitionType = {
                                 it.stateIsFalse('ServiceSettings.EnableMultifactorAuthentication'),
                             ),
                         },
+                        {
+                            type: 'bool',
+                            key: 'ServiceSettings.EnableWebAuthn',
+                            label: defineMessage({id: 'admin.service.webAuthnTitle', defaultMessage: 'Enable Security Keys and Passkeys:'}),
+                            help_text: defineMessage({id: 'admin.service.webAuthnDesc', defaultMessage: 'When true, users with AD/LDAP or email login can register security keys and passkeys as a second factor, alongside or instead of an authenticator app. Requires the Site URL to be configured.'}),
+                            isDisabled: it.any(
+                                it.not(it.userHasWritePermissionOnResource(RESOURCE_KEYS.AUTHENTICATION.MFA)),
+                                it.stateIsFalse('ServiceSettings.EnableMultifactorAuthentication'),
+                            ),
+                        },
+                        {
+                            type: 'bool',
+                            key: 'ServiceSettings.EnableWebAuthnPasswordless',
+                            label: defineMessage({id: 'admin.service.webAuthnPasswordlessTitle', defaultMessage: 'Enable Passwordless Login with Passkeys:'}),
+                            help_text: defineMessage({id: 'admin.service.webAuthnPasswordlessDesc', defaultMessage: 'When true, users can log in with a registered passkey that verifies them, such as with a fingerprint or PIN, without entering their password.'}),
+                            isDisabled: it.any(
+                                it.not(it.userHasWritePermissionOnResource(RESOURCE_KEYS.AUTHENTICATION.MFA)),
+                                it.stateIsFalse('ServiceSettings.EnableWebAuthn'),
+                            ),
+                        },
+                        {
+                            type: 'bool',
+                            key: 'ServiceSettings.EnforceWebAuthn',
+                            label: defineMessage({id: 'admin.service.enforceWebAuthnTitle', defaultMessage: 'Enforce Security Keys and Passkeys:'}),
+                            help_text: defineMessage({id: 'admin.service.enforceWebAuthnDesc', defaultMessage: 'When true, sessions must be verified with a security key or passkey. Users are required to register one, and sessions verified with an authenticator app only are not accepted. Personal access tokens are not affected.'}),
+                            isHidden: it.not(it.licensedForFeature('MFA')),
+                            isDisabled: it.any(
+                                it.not(it.userHasWritePermissionOnResource(RESOURCE_KEYS.AUTHENTICATION.MFA)),
+                                it.stateIsFalse('ServiceSettings.EnableWebAuthn'),
+                                it.stateIsFalse('ServiceSettings.EnforceMultifactorAuthentication'),
+                            ),
+                        },
+                        {
+                            type: 'bool',
+                            key: 'ServiceSettings.EnableMfaRecoveryCodes',
+                            label: defineMessage({id: 'admin.service.mfaRecoveryCodesTitle', defaultMessage: 'Enable MFA Recovery Codes:'}),
+                            help_text: defineMessage({id: 'admin.service.mfaRecoveryCodesDesc', defaultMessage: 'When true, users are given one-time recovery codes when activating multi-factor authentication, which they can enter in place of a code if they lose access to their authenticator app or security key.'}),
+                            isDisabled: it.any(
+                                it.not(it.userHasWritePermissionOnResource(RESOURCE_KEYS.AUTHENTICATION.MFA)),
+                                it.stateIsFalse('ServiceSettings.EnableMultifactorAuthentication'),
+                            ),
+                        },
+                        {
+                            type: 'number',
+                            key: 'ServiceSettings.MfaTrustedDeviceDays',
+                            label: defineMessage({id: 'admin.service.mfaTrustedDeviceDaysTitle', defaultMessage: 'Trusted Device Duration (days):'}),
+                            help_text: defineMessage({id: 'admin.service.mfaTrustedDeviceDaysDesc', defaultMessage: 'The number of days users can choose to trust a device after logging in with multi-factor authentication, so that logging in again from it doesn\'t require a code. Set to 0 to not allow trusting devices. Maximum 90 days.'}),
+                            isDisabled: it.any(
+                                it.not(it.userHasWritePermissionOnResource(RESOURCE_KEYS.AUTHENTICATION.MFA)),
+                                it.stateIsFalse('ServiceSettings.EnableMultifactorAuthentication'),
+                            ),
+                        },
                     ],
                 },
             },
@@ -14,6 +14,7 @@ import {passwordErrors} from 'utils/password';
 import BlockableLink from './blockable_link';
 import BooleanSetting from './boolean_setting';
 import CheckboxSetting from './checkbox_setting';
+import DropdownSetting from './dropdown_setting';
 import type {BaseProps, BaseState} from './old_admin_settings';
 import OLDAdminSettings from './old_admin_settings';
 import SettingSet from './setting_set';
@@ -31,9 +32,19 @@ type State = BaseState & {
     passwordUppercase?: boolean;
     passwordSymbol?: boolean;
     passwordEnableForgotLink?: boolean;
+    passwordHasher?: string;
+    passwordArgon2idMemoryKiB?: string;
+    passwordArgon2idIterations?: string;
+    passwordArgon2idParallelism?: string;
     maximumLoginAttempts?: string;
 };
 
+const PASSWORD_HASHER_PBKDF2 = 'pbkdf2';
+const PASSWORD_HASHER_ARGON2ID = 'argon2id';
+const ARGON2ID_DEFAULT_MEMORY_KIB = 19456;
+const ARGON2ID_DEFAULT_ITERATIONS = 2;
+const ARGON2ID_DEFAULT_PARALLELISM = 1;
+
 const messages = defineMessages({
     passwordMinLength: {id: 'user.settings.security.passwordMinLength', defaultMessage: 'Invalid minimum length, cannot show preview.'},
     password: {id: 'admin.security.password', defaultMessage: 'Password'},
@@ -47,6 +58,14 @@ const messages = defineMessages({
     attemptTitle: {id: 'admin.service.attemptTitle', defaultMessage: 'Maximum Login Attempts:'},
     attemptDescription: {id: 'admin.service.attemptDescription', defaultMessage: 'Login attempts allowed before user is locked out and required to reset password via email.'},
     passwordRequirements: {id: 'passwordRequirements', defaultMessage: 'Password Requirements:'},
+    hasherTitle: {id: 'admin.password.hasher.title', defaultMessage: 'Password Hashing Algorithm:'},
+    hasherDescription: {id: 'admin.password.hasher.description', defaultMessage: 'Algorithm used to hash new passwords. Passwords hashed with an older algorithm or weaker parameters are rehashed the next time their users log in. Run "mmctl user password-hashers" to see how many users are on each algorithm.'},
+    argon2idMemoryTitle: {id: 'admin.password.argon2idMemory.title', defaultMessage: 'Argon2id Memory (KiB):'},
+    argon2idMemoryDescription: {id: 'admin.password.argon2idMemory.description', defaultMessage: 'Amount of memory, in KiB, used to hash each password with Argon2id.'},
+    argon2idIterationsTitle: {id: 'admin.password.argon2idIterations.title', defaultMessage: 'Argon2id Iterations:'},
+    argon2idIterationsDescription: {id: 'admin.password.argon2idIterations.description', defaultMessage: 'Number of passes over the memory used to hash each password with Argon2id.'},
+    argon2idParallelismTitle: {id: 'admin.password.argon2idParallelism.title', defaultMessage: 'Argon2id Parallelism:'},
+    argon2idParallelismDescription: {id: 'admin.password.argon2idParallelism.description', defaultMessage: 'Number of threads used to hash each password with Argon2id.'},
 });
 
 export const searchableStrings: Array<string|MessageDescriptor|[MessageDescriptor, {[key: string]: any}]> = [
@@ -62,6 +81,14 @@ export const searchableStrings: Array<string|MessageDescriptor|[MessageDescripto
     messages.preview,
     messages.attemptTitle,
     messages.attemptDescription,
+    messages.hasherTitle,
+    messages.hasherDescription,
+    messages.argon2idMemoryTitle,
+    messages.argon2idMemoryDescription,
+    messages.argon2idIterationsTitle,
+    messages.argon2idIterationsDescription,
+    messages.argon2idParallelismTitle,
+    messages.argon2idParallelismDescription,
 ];
 
 function getPasswordErrorsMessage(lowercase?: boolean, uppercase?: boolean, number?: boolean, symbol?: boolean) {
@@ -97,6 +124,10 @@ export default class PasswordSettings extends OLDAdminSettings<Props, State> {
             passwordUppercase: props.config.PasswordSettings.Uppercase,
             passwordSymbol: props.config.PasswordSettings.Symbol,
             passwordEnableForgotLink: props.config.PasswordSettings.EnableForgotLink,
+            passwordHasher: props.config.PasswordSettings.Hasher,
+            passwordArgon2idMemoryKiB: props.config.PasswordSettings.Argon2idMemoryKiB,
+            passwordArgon2idIterations: props.config.PasswordSettings.Argon2idIterations,
+            passwordArgon2idParallelism: props.config.PasswordSettings.Argon2idParallelism,
             maximumLoginAttempts: props.config.ServiceSettings.MaximumLoginAttempts,
         });
 
@@ -124,6 +155,10 @@ export default class PasswordSettings extends OLDAdminSettings<Props, State> {
             config.PasswordSettings.Number = this.state.passwordNumber;
             config.PasswordSettings.Symbol = this.state.passwordSymbol;
             config.PasswordSettings.EnableForgotLink = this.state.passwordEnableForgotLink;
+            config.PasswordSettings.Hasher = this.state.passwordHasher;
+            config.PasswordSettings.Argon2idMemoryKiB = this.parseIntNonZero(this.state.passwordArgon2idMemoryKiB ?? '', ARGON2ID_DEFAULT_MEMORY_KIB);
+            config.PasswordSettings.Argon2idIterations = this.parseIntNonZero(this.state.passwordArgon2idIterations ?? '', ARGON2ID_DEFAULT_ITERATIONS);
+            config.PasswordSettings.Argon2idParallelism = this.parseIntNonZero(this.state.passwordArgon2idParallelism ?? '', ARGON2ID_DEFAULT_PARALLELISM);
         }
 
         if (config.ServiceSettings) {
@@ -141,6 +176,10 @@ export default class PasswordSettings extends OLDAdminSettings<Props, State> {
             passwordUppercase: config.PasswordSettings?.Uppercase,
             passwordSymbol: config.PasswordSettings?.Symbol,
             passwordEnableForgotLink: config.PasswordSettings?.EnableForgotLink,
+            passwordHasher: config.PasswordSettings?.Hasher,
+            passwordArgon2idMemoryKiB: String(config.PasswordSettings?.Argon2idMemoryKiB),
+            passwordArgon2idIterations: String(config.PasswordSettings?.Argon2idIterations),
+            passwordArgon2idParallelism: String(config.PasswordSettings?.Argon2idParallelism),
             maximumLoginAttempts: String(config.ServiceSettings?.MaximumLoginAttempts),
         };
     }
@@ -305,6 +344,52 @@ export default class PasswordSettings extends OLDAdminSettings<Props, State> {
                     onChange={this.handleChange}
                     disabled={this.props.isDisabled}
                 />
+                <DropdownSetting
+                    id='passwordHasher'
+                    label={<FormattedMessage {...messages.hasherTitle}/>}
+                    helpText={<FormattedMessage {...messages.hasherDescription}/>}
+                    values={[
+                        {value: PASSWORD_HASHER_PBKDF2, text: 'PBKDF2'},
+                        {value: PASSWORD_HASHER_ARGON2ID, text: 'Argon2id'},
+                    ]}
+                    value={this.state.passwordHasher ?? PASSWORD_HASHER_PBKDF2}
+                    onChange={this.handleChange}
+                    setByEnv={this.isSetByEnv('PasswordSettings.Hasher')}
+                    disabled={this.props.isDisabled}
+                />
+                {this.state.passwordHasher === PASSWORD_HASHER_ARGON2ID &&
+                (
+                    <>
+                        <TextSetting
+                            id='passwordArgon2idMemoryKiB'
+                            label={<FormattedMessage {...messages.argon2idMemoryTitle}/>}
+                            helpText={<FormattedMessage {...messages.argon2idMemoryDescription}/>}
+                            value={this.state.passwordArgon2idMemoryKiB ?? ''}
+                            onChange={this.handleChange}
+                            setByEnv={this.isSetByEnv('PasswordSettings.Argon2idMemoryKiB')}
+                            disabled={this.props.isDisabled}
+                        />
+                        <TextSetting
+                            id='passwordArgon2idIterations'
+                            label={<FormattedMessage {...messages.argon2idIterationsTitle}/>}
+                            helpText={<FormattedMessage {...messages.argon2idIterationsDescription}/>}
+                            value={this.state.passwordArgon2idIterations ?? ''}
+                            onChange={this.handleChange}
+                            setByEnv={this.isSetByEnv('PasswordSettings.Argon2idIterations')}
+                            disabled={this.props.isDisabled}
+                        />
+                        <TextSetting
+                            id='passwordArgon2idParallelism'
+                            label={<FormattedMessage {...messages.argon2idParallelismTitle}/>}
+                            helpText={<FormattedMessage {...messages.argon2idParallelismDescription}/>}
+                            value={this.state.passwordArgon2idParallelism ?? ''}
+                            onChange={this.handleChange}
+                            setByEnv={this.isSetByEnv('PasswordSettings.Argon2idParallelism')}
+                            disabled={this.props.isDisabled}
+                        />
+                    </>
+                )
+                }
             </SettingsGroup>
         );
     };
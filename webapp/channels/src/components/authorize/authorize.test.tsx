@@ -4,7 +4,7 @@
 import {shallow} from 'enzyme';
 import React from 'react';
 
-import Authorize from './authorize';
+import Authorize, {getRequestedScopes} from './authorize';
 
 describe('components/user_settings/display/UserSettingsDisplay', () => {
     const oauthApp = {
@@ -106,4 +106,23 @@ describe('components/user_settings/display/UserSettingsDisplay', () => {
 
         expect(wrapper.state().error).toEqual(error.message);
     });
+
+    test('should list the scopes requested by the app', () => {
+        const props = {...requiredProps, location: {search: 'client_id=1234abcd&scope=posts:read%20users:read'}};
+
+        const wrapper = shallow<Authorize>(<Authorize {...props}/>);
+        wrapper.setState({app: oauthApp});
+
+        expect(wrapper.find('li')).toHaveLength(2);
+        expect(wrapper.find({id: 'authorize.modificationAccess'}).exists()).toBe(false);
+    });
+
+    test('getRequestedScopes() should fall back to the scopes of the app', () => {
+        const scopedApp = {...oauthApp, scopes: ['posts:read']};
+
+        expect(getRequestedScopes('posts:write posts:write', scopedApp)).toEqual(['posts:write']);
+        expect(getRequestedScopes('user', scopedApp)).toEqual(['posts:read']);
+        expect(getRequestedScopes(null, scopedApp)).toEqual(['posts:read']);
+        expect(getRequestedScopes(null, oauthApp)).toEqual([]);
+    });
 });
@@ -3,7 +3,7 @@
 
 import React from 'react';
 import type {ReactNode} from 'react';
-import {FormattedMessage} from 'react-intl';
+import {FormattedMessage, defineMessages} from 'react-intl';
 
 import type {OAuthApp} from '@mattermost/types/integrations';
 
@@ -14,6 +14,48 @@ import FormError from 'components/form_error';
 import icon50 from 'images/icon50x50.png';
 import {getHistory} from 'utils/browser_history';
 
+const scopeMessages = defineMessages({
+    'posts:read': {
+        id: 'authorize.scope.postsRead',
+        defaultMessage: 'Read messages and files in the channels you belong to',
+    },
+    'posts:write': {
+        id: 'authorize.scope.postsWrite',
+        defaultMessage: 'Post, edit and delete messages and files on your behalf',
+    },
+    'channels:read': {
+        id: 'authorize.scope.channelsRead',
+        defaultMessage: 'View channels and their members',
+    },
+    'channels:manage': {
+        id: 'authorize.scope.channelsManage',
+        defaultMessage: 'Create, update and delete channels, and manage their members',
+    },
+    'teams:read': {
+        id: 'authorize.scope.teamsRead',
+        defaultMessage: 'View your teams and their members',
+    },
+    'users:read': {
+        id: 'authorize.scope.usersRead',
+        defaultMessage: 'View user profiles and statuses',
+    },
+    scim: {
+        id: 'authorize.scope.scim',
+        defaultMessage: 'Provision users and groups through SCIM',
+    },
+});
+
+// getRequestedScopes returns the scopes the app would be granted, being those requested or, when
+// none are, all the scopes of the app. No scopes means full access to the account.
+export function getRequestedScopes(scope: string | null, app: OAuthApp): string[] {
+    const requested = (scope ?? '').split(' ').filter((s) => s && s !== 'user');
+    if (requested.length > 0) {
+        return [...new Set(requested)];
+    }
+
+    return app.scopes ?? [];
+}
+
 export type Params = {
     responseType: string | null;
     clientId: string | null;
@@ -111,6 +153,47 @@ export default class Authorize extends React.PureComponent<Props, State> {
             icon = icon50;
         }
 
+        const scopes = getRequestedScopes((new URLSearchParams(this.props.location.search)).get('scope'), app);
+        let access;
+        if (scopes.length > 0) {
+            access = (
+                <>
+                    <p>
+                        <FormattedMessage
+                            id='authorize.scopedAccess'
+                            defaultMessage='The app <b>{appName}</b> would like the ability to:'
+                            values={{
+                                appName: app.name,
+                                b: (chunks) => <b>{chunks}</b>,
+                            }}
+                        />
+                    </p>
+                    <ul>
+                        {scopes.map((scope) => (
+                            <li key={scope}>
+                                {scope in scopeMessages ? (
+                                    <FormattedMessage {...scopeMessages[scope as keyof typeof scopeMessages]}/>
+                                ) : scope}
+                            </li>
+                        ))}
+                    </ul>
+                </>
+            );
+        } else {
+            access = (
+                <p>
+                    <FormattedMessage
+                        id='authorize.modificationAccess'
+                        defaultMessage='The app <b>{appName}</b> would like the ability to access and modify your basic information.'
+                        values={{
+                            appName: app.name,
+                            b: (chunks) => <b>{chunks}</b>,
+                        }}
+                    />
+                </p>
+            );
+        }
+
         let error;
         if (this.state.error) {
             error = (
@@ -143,16 +226,7 @@ export default class Authorize extends React.PureComponent<Props, State> {
                             />
                         </div>
                     </div>
-                    <p>
-                        <FormattedMessage
-                            id='authorize.modificationAccess'
-                            defaultMessage='The app <b>{appName}</b> would like the ability to access and modify your basic information.'
-                            values={{
-                                appName: app.name,
-                                b: (chunks) => <b>{chunks}</b>,
-                            }}
-                        />
-                    </p>
+                    {access}
                     <h2 className='prompt__allow'>
                         <FormattedMessage
                             id='authorize.allowAccess'
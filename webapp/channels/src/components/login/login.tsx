@@ -14,7 +14,7 @@ import type {Team} from '@mattermost/types/teams';
 import {loadMe} from 'mattermost-redux/actions/users';
 import {Client4} from 'mattermost-redux/client';
 import {RequestStatus} from 'mattermost-redux/constants';
-import {getConfig, getLicense} from 'mattermost-redux/selectors/entities/general';
+import {getConfig, getLicense, getOpenIdProviderButtons} from 'mattermost-redux/selectors/entities/general';
 import {getIsOnboardingFlowEnabled} from 'mattermost-redux/selectors/entities/preferences';
 import {getTeamByName, getMyTeamMember} from 'mattermost-redux/selectors/entities/teams';
 import {getCurrentUser} from 'mattermost-redux/selectors/entities/users';
@@ -105,6 +105,7 @@ const Login = ({onCustomizeHeader}: LoginProps) => {
         PasswordEnableForgotLink,
     } = useSelector(getConfig);
     const {IsLicensed} = useSelector(getLicense);
+    const openIdProviders = useSelector(getOpenIdProviderButtons);
     const initializing = useSelector((state: GlobalState) => state.requests.users.logout.status === RequestStatus.SUCCESS || !state.storage.initialized);
     const currentUser = useSelector(getCurrentUser);
     const experimentalPrimaryTeam = useSelector((state: GlobalState) => (ExperimentalPrimaryTeam ? getTeamByName(state, ExperimentalPrimaryTeam) : undefined));
@@ -140,15 +141,16 @@ const Login = ({onCustomizeHeader}: LoginProps) => {
     const enableSignUpWithGoogle = EnableSignUpWithGoogle === 'true';
     const enableSignUpWithOffice365 = EnableSignUpWithOffice365 === 'true';
     const enableSignUpWithOpenId = EnableSignUpWithOpenId === 'true';
+    const enableSignUpWithOpenIdProviders = openIdProviders.length > 0;
     const isLicensed = IsLicensed === 'true';
     const ldapEnabled = isLicensed && enableLdap;
     const enableSignUpWithSaml = isLicensed && enableSaml;
     const siteName = SiteName ?? '';
 
     const enableBaseLogin = enableSignInWithEmail || enableSignInWithUsername || ldapEnabled;
-    const enableExternalSignup = enableSignUpWithGitLab || enableSignUpWithOffice365 || enableSignUpWithGoogle || enableSignUpWithOpenId || enableSignUpWithSaml;
+    const enableExternalSignup = enableSignUpWithGitLab || enableSignUpWithOffice365 || enableSignUpWithGoogle || enableSignUpWithOpenId || enableSignUpWithOpenIdProviders || enableSignUpWithSaml;
     const showSignup = enableOpenServer && (enableExternalSignup || enableSignUpWithEmail || enableLdap);
-    const onlyLdapEnabled = enableLdap && !(enableSaml || enableSignInWithEmail || enableSignInWithUsername || enableSignUpWithEmail || enableSignUpWithGitLab || enableSignUpWithGoogle || enableSignUpWithOffice365 || enableSignUpWithOpenId);
+    const onlyLdapEnabled = enableLdap && !(enableSaml || enableSignInWithEmail || enableSignInWithUsername || enableSignUpWithEmail || enableSignUpWithGitLab || enableSignUpWithGoogle || enableSignUpWithOffice365 || enableSignUpWithOpenId || enableSignUpWithOpenIdProviders);
 
     const [desktopLoginLink, setDesktopLoginLink] = useState('');
 
@@ -205,6 +207,20 @@ const Login = ({onCustomizeHeader}: LoginProps) => {
             });
         }
 
+        if (enableSignUpWithOpenIdProviders) {
+            for (const provider of openIdProviders) {
+                const url = `${Client4.getOAuthRoute()}/${provider.service}/login${search}`;
+                externalLoginOptions.push({
+                    id: provider.service,
+                    url,
+                    icon: <LoginOpenIDIcon/>,
+                    label: provider.button_text || formatMessage({id: 'login.openid', defaultMessage: 'Open ID'}),
+                    style: {color: provider.button_color, borderColor: provider.button_color},
+                    onClick: handleExternalAuth(url, provider.service),
+                });
+            }
+        }
+
         if (enableSignUpWithSaml) {
             const url = `${Client4.getUrl()}/login/sso/saml${search}`;
             externalLoginOptions.push({
@@ -14,7 +14,7 @@ import type {UserProfile} from '@mattermost/types/users';
 import {getTeamInviteInfo} from 'mattermost-redux/actions/teams';
 import {createUser, loadMe} from 'mattermost-redux/actions/users';
 import {Client4} from 'mattermost-redux/client';
-import {getConfig, getLicense, getPasswordConfig} from 'mattermost-redux/selectors/entities/general';
+import {getConfig, getLicense, getOpenIdProviderButtons, getPasswordConfig} from 'mattermost-redux/selectors/entities/general';
 import {getIsOnboardingFlowEnabled} from 'mattermost-redux/selectors/entities/preferences';
 import {getCurrentUserId} from 'mattermost-redux/selectors/entities/users';
 import {isEmail} from 'mattermost-redux/utils/helpers';
@@ -102,6 +102,7 @@ const Signup = ({onCustomizeHeader}: SignupProps) => {
         PrivacyPolicyLink,
     } = config;
     const {IsLicensed} = useSelector(getLicense);
+    const openIdProviders = useSelector(getOpenIdProviderButtons);
     const loggedIn = Boolean(useSelector(getCurrentUserId));
     const onboardingFlowEnabled = useSelector(getIsOnboardingFlowEnabled);
     const usedBefore = useSelector((state: GlobalState) => (!inviteId && !loggedIn && token ? getGlobalItem(state, token, null) : undefined));
@@ -119,6 +120,7 @@ const Signup = ({onCustomizeHeader}: SignupProps) => {
     const enableSignUpWithGoogle = enableUserCreation && EnableSignUpWithGoogle === 'true';
     const enableSignUpWithOffice365 = enableUserCreation && EnableSignUpWithOffice365 === 'true';
     const enableSignUpWithOpenId = enableUserCreation && EnableSignUpWithOpenId === 'true';
+    const enableSignUpWithOpenIdProviders = enableUserCreation && openIdProviders.length > 0;
     const enableLDAP = EnableLdap === 'true';
     const enableSAML = EnableSaml === 'true';
     const enableCustomBrand = EnableCustomBrand === 'true';
@@ -141,7 +143,7 @@ const Signup = ({onCustomizeHeader}: SignupProps) => {
     const [acceptedTerms, setAcceptedTerms] = useState(false);
     const [submitClicked, setSubmitClicked] = useState(false);
 
-    const enableExternalSignup = enableSignUpWithGitLab || enableSignUpWithOffice365 || enableSignUpWithGoogle || enableSignUpWithOpenId || enableLDAP || enableSAML;
+    const enableExternalSignup = enableSignUpWithGitLab || enableSignUpWithOffice365 || enableSignUpWithGoogle || enableSignUpWithOpenId || enableSignUpWithOpenIdProviders || enableLDAP || enableSAML;
     const hasError = Boolean(emailError || nameError || passwordError || serverError || alertBanner);
     const canSubmit = Boolean(email && name && password && acceptedTerms) && !hasError && !loading;
     const passwordConfig = useSelector(getPasswordConfig);
@@ -202,6 +204,20 @@ const Signup = ({onCustomizeHeader}: SignupProps) => {
             });
         }
 
+        if (isLicensed && enableSignUpWithOpenIdProviders) {
+            for (const provider of openIdProviders) {
+                const url = `${Client4.getOAuthRoute()}/${provider.service}/signup${search}`;
+                externalLoginOptions.push({
+                    id: provider.service,
+                    url,
+                    icon: <LoginOpenIDIcon/>,
+                    label: provider.button_text || formatMessage({id: 'login.openid', defaultMessage: 'Open ID'}),
+                    style: {color: provider.button_color, borderColor: provider.button_color},
+                    onClick: desktopExternalAuth(url),
+                });
+            }
+        }
+
         if (isLicensed && enableLDAP) {
             const newSearchParam = new URLSearchParams(search);
             newSearchParam.set('extra', Constants.CREATE_LDAP);
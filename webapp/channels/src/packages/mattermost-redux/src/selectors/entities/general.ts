@@ -1,7 +1,7 @@
 // Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
 // See LICENSE.txt for license information.
 
-import type {ClientConfig, FeatureFlags, ClientLicense} from '@mattermost/types/config';
+import type {ClientConfig, FeatureFlags, ClientLicense, OpenIdProviderButton} from '@mattermost/types/config';
 import type {UserPropertyField} from '@mattermost/types/properties';
 import type {GlobalState} from '@mattermost/types/store';
 
@@ -109,6 +109,22 @@ export const getManagedResourcePaths: (state: GlobalState) => string[] = createS
     },
 );
 
+export const getOpenIdProviderButtons: (state: GlobalState) => OpenIdProviderButton[] = createSelector(
+    'getOpenIdProviderButtons',
+    getConfig,
+    (config) => {
+        if (!config.OpenIdProviders) {
+            return [];
+        }
+
+        try {
+            return JSON.parse(config.OpenIdProviders);
+        } catch {
+            return [];
+        }
+    },
+);
+
 export const getServerVersion = (state: GlobalState): string => {
     return state.entities.general.serverVersion;
 };
@@ -72,6 +72,7 @@ export type Channel = {
     policy_is_active?: boolean;
     default_category_name?: string;
     autotranslation?: boolean;
+    allow_membership_requests?: boolean;
 };
 
 export type ServerChannel = Channel & {
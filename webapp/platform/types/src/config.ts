@@ -85,6 +85,7 @@ export type ClientConfig = {
     EnableLdap: string;
     EnableLinkPreviews: string;
     EnableMarketplace: string;
+    EnableMembershipRequests: string;
     EnableMetrics: string;
     EnableMobileFileDownload: string;
     EnableMobileFileUpload: string;
@@ -116,6 +117,11 @@ export type ClientConfig = {
     EnableUserDeactivation: string;
     EnableUserTypingMessages: string;
     EnforceMultifactorAuthentication: string;
+    EnableWebAuthn: string;
+    EnableWebAuthnPasswordless: string;
+    EnforceWebAuthn: string;
+    EnableMfaRecoveryCodes: string;
+    MfaTrustedDeviceDays: string;
     ExperimentalChannelCategorySorting: string;
     ExperimentalEnableAuthenticationTransfer: string;
     ExperimentalEnableAutomaticReplies: string;
@@ -164,6 +170,7 @@ export type ClientConfig = {
     GitLabButtonColor: string;
     OpenIdButtonText: string;
     OpenIdButtonColor: string;
+    OpenIdProviders: string;
     PasswordEnableForgotLink: string;
     PasswordMinimumLength: string;
     PasswordRequireLowercase: string;
@@ -356,6 +363,11 @@ export type ServiceSettings = {
     AllowedUntrustedInternalConnections: string;
     EnableMultifactorAuthentication: boolean;
     EnforceMultifactorAuthentication: boolean;
+    EnableWebAuthn: boolean;
+    EnableWebAuthnPasswordless: boolean;
+    EnforceWebAuthn: boolean;
+    EnableMfaRecoveryCodes: boolean;
+    MfaTrustedDeviceDays: number;
     EnableUserAccessTokens: boolean;
     AllowCorsFrom: string;
     CorsExposedHeaders: string;
@@ -525,6 +537,10 @@ export type PasswordSettings = {
     Uppercase: boolean;
     Symbol: boolean;
     EnableForgotLink: boolean;
+    Hasher: string;
+    Argon2idMemoryKiB: number;
+    Argon2idIterations: number;
+    Argon2idParallelism: number;
 };
 
 export type WranglerSettings = {
@@ -545,6 +561,8 @@ export type ConnectedWorkspacesSettings = {
     GlobalUserSyncBatchSize: number;
     MaxPostsPerSync: number;
     MemberSyncBatchSize: number;
+    Transport: string;
+    SpoolDirectory: string;
 }
 
 export type FileSettings = {
@@ -692,6 +710,56 @@ export type SSOSettings = {
     ButtonColor: string;
 };
 
+export type OpenIdProvider = {
+    Name: string;
+    Enable: boolean;
+    ButtonText: string;
+    ButtonColor: string;
+    Id: string;
+    Secret: string;
+    DiscoveryEndpoint: string;
+    Scope: string;
+    EnablePKCE: boolean;
+    UsernameClaim: string;
+    EmailClaim: string;
+    FirstNameClaim: string;
+    LastNameClaim: string;
+    PositionClaim: string;
+    GroupsClaim: string;
+    AttributeClaims: Record<string, string>;
+};
+
+export type OpenIdProviderSettings = {
+    Providers: OpenIdProvider[];
+};
+
+export type OpenIdProviderButton = {
+    service: string;
+    button_text: string;
+    button_color: string;
+};
+
+export type ScimSettings = {
+    Enable: boolean;
+    AuthService: string;
+};
+
+export type SessionPolicySettings = {
+    Enable: boolean;
+    MaxSessionsPerUser: number;
+    MaxSessionsPerDeviceType: number;
+    AdminIdleTimeoutInMinutes: number;
+    GuestIdleTimeoutInMinutes: number;
+    BindToIPSubnet: boolean;
+    IPv4SubnetPrefixLength: number;
+    IPv6SubnetPrefixLength: number;
+};
+
+export type MembershipRequestSettings = {
+    Enable: boolean;
+    RequestExpiryInHours: number;
+};
+
 export type Office365Settings = {
     Enable: boolean;
     Secret: string;
@@ -1070,6 +1138,10 @@ export type AdminConfig = {
     GoogleSettings: SSOSettings;
     Office365Settings: Office365Settings;
     OpenIdSettings: SSOSettings;
+    OpenIdProviderSettings: OpenIdProviderSettings;
+    ScimSettings: ScimSettings;
+    SessionPolicySettings: SessionPolicySettings;
+    MembershipRequestSettings: MembershipRequestSettings;
     LdapSettings: LdapSettings;
     ComplianceSettings: ComplianceSettings;
     LocalizationSettings: LocalizationSettings;
@@ -113,6 +113,7 @@ export type OAuthApp = {
     'is_trusted': boolean;
     'is_dynamically_registered'?: boolean;
     'is_public'?: boolean;
+    'scopes'?: string[];
 };
 
 export type OutgoingOAuthConnection = {